@@ -2,7 +2,10 @@ package main
 
 import (
 	"github.com/iot-my-world/brain/internal/config"
+	"github.com/iot-my-world/brain/internal/ipAddress"
 	"github.com/iot-my-world/brain/internal/log"
+	"github.com/iot-my-world/brain/internal/migration"
+	"github.com/iot-my-world/brain/internal/migration/migrations"
 	"github.com/iot-my-world/brain/internal/security/encrypt"
 	"github.com/iot-my-world/brain/pkg/security/token"
 	"gopkg.in/mgo.v2"
@@ -19,7 +22,15 @@ import (
 	permissionAdministratorJsonRpcAdaptor "github.com/iot-my-world/brain/pkg/security/permission/administrator/adaptor/jsonRpc"
 	permissionBasicAdministrator "github.com/iot-my-world/brain/pkg/security/permission/administrator/basic"
 
+	roleAdministratorJsonRpcAdaptor "github.com/iot-my-world/brain/pkg/security/role/administrator/adaptor/jsonRpc"
+	roleBasicAdministrator "github.com/iot-my-world/brain/pkg/security/role/administrator/basic"
 	roleMongoRecordHandler "github.com/iot-my-world/brain/pkg/security/role/recordHandler/mongo"
+	roleValidatorJsonRpcAdaptor "github.com/iot-my-world/brain/pkg/security/role/validator/adaptor/jsonRpc"
+	roleBasicValidator "github.com/iot-my-world/brain/pkg/security/role/validator/basic"
+
+	tokenRevocationAdministratorJsonRpcAdaptor "github.com/iot-my-world/brain/pkg/security/token/revocation/administrator/adaptor/jsonRpc"
+	tokenRevocationBasicAdministrator "github.com/iot-my-world/brain/pkg/security/token/revocation/administrator/basic"
+	tokenRevocationMongoRecordHandler "github.com/iot-my-world/brain/pkg/security/token/revocation/recordHandler/mongo"
 
 	humanUserAdministratorJsonRpcAdaptor "github.com/iot-my-world/brain/pkg/user/human/administrator/adaptor/jsonRpc"
 	humanUserBasicAdministrator "github.com/iot-my-world/brain/pkg/user/human/administrator/basic"
@@ -48,6 +59,8 @@ import (
 
 	apiUserAdministratorJsonRpcAdaptor "github.com/iot-my-world/brain/pkg/user/api/administrator/adaptor/jsonRpc"
 	apiUserBasicAdministrator "github.com/iot-my-world/brain/pkg/user/api/administrator/basic"
+	apiUserAuthenticatorJsonRpcAdaptor "github.com/iot-my-world/brain/pkg/user/api/authenticator/adaptor/jsonRpc"
+	apiUserBasicAuthenticator "github.com/iot-my-world/brain/pkg/user/api/authenticator/basic"
 	apiUserBasicPasswordGenerator "github.com/iot-my-world/brain/pkg/user/api/password/generator/basic"
 	apiUserRecordHandlerJsonRpcAdaptor "github.com/iot-my-world/brain/pkg/user/api/recordHandler/adaptor/jsonRpc"
 	apiUserMongoRecordHandler "github.com/iot-my-world/brain/pkg/user/api/recordHandler/mongo"
@@ -56,6 +69,16 @@ import (
 
 	trackingReportJsonRpcAdaptor "github.com/iot-my-world/brain/pkg/report/tracking/adaptor/jsonRpc"
 	trackingBasicReport "github.com/iot-my-world/brain/pkg/report/tracking/basic"
+	tripReportJsonRpcAdaptor "github.com/iot-my-world/brain/pkg/report/trip/adaptor/jsonRpc"
+	tripBasicReport "github.com/iot-my-world/brain/pkg/report/trip/basic"
+	retentionPolicyAdministratorJsonRpcAdaptor "github.com/iot-my-world/brain/pkg/retention/policy/administrator/adaptor/jsonRpc"
+	retentionPolicyBasicAdministrator "github.com/iot-my-world/brain/pkg/retention/policy/administrator/basic"
+	retentionPolicyRecordHandlerJsonRpcAdaptor "github.com/iot-my-world/brain/pkg/retention/policy/recordHandler/adaptor/jsonRpc"
+	retentionPolicyMongoRecordHandler "github.com/iot-my-world/brain/pkg/retention/policy/recordHandler/mongo"
+	retentionPolicyValidatorJsonRpcAdaptor "github.com/iot-my-world/brain/pkg/retention/policy/validator/adaptor/jsonRpc"
+	retentionPolicyBasicValidator "github.com/iot-my-world/brain/pkg/retention/policy/validator/basic"
+	retentionPurger "github.com/iot-my-world/brain/pkg/retention/purger"
+	retentionBasicPurger "github.com/iot-my-world/brain/pkg/retention/purger/basic"
 
 	"flag"
 	"github.com/iot-my-world/brain/pkg/communication/email/mailer"
@@ -63,9 +86,71 @@ import (
 	partyBasicRegistrarJsonRpcAdaptor "github.com/iot-my-world/brain/pkg/party/registrar/adaptor/jsonRpc"
 	partyBasicRegistrar "github.com/iot-my-world/brain/pkg/party/registrar/basic"
 
+	alertEmailGenerator "github.com/iot-my-world/brain/pkg/communication/email/generator/alert"
 	registrationEmailGenerator "github.com/iot-my-world/brain/pkg/communication/email/generator/registration"
 	setPasswordEmailGenerator "github.com/iot-my-world/brain/pkg/communication/email/generator/set/password"
 
+	alertDeliveryRecordHandlerJsonRpcAdaptor "github.com/iot-my-world/brain/pkg/alert/delivery/recordHandler/adaptor/jsonRpc"
+	alertDeliveryMongoRecordHandler "github.com/iot-my-world/brain/pkg/alert/delivery/recordHandler/mongo"
+	alertBasicEngine "github.com/iot-my-world/brain/pkg/alert/engine/basic"
+	notificationChannel "github.com/iot-my-world/brain/pkg/alert/notification/channel"
+	emailNotificationChannel "github.com/iot-my-world/brain/pkg/alert/notification/channel/email"
+	webhookNotificationChannel "github.com/iot-my-world/brain/pkg/alert/notification/channel/webhook"
+	"github.com/iot-my-world/brain/pkg/alert/rule"
+	alertRuleAdministratorJsonRpcAdaptor "github.com/iot-my-world/brain/pkg/alert/rule/administrator/adaptor/jsonRpc"
+	alertRuleBasicAdministrator "github.com/iot-my-world/brain/pkg/alert/rule/administrator/basic"
+	alertRuleRecordHandlerJsonRpcAdaptor "github.com/iot-my-world/brain/pkg/alert/rule/recordHandler/adaptor/jsonRpc"
+	alertRuleMongoRecordHandler "github.com/iot-my-world/brain/pkg/alert/rule/recordHandler/mongo"
+	alertRuleValidatorJsonRpcAdaptor "github.com/iot-my-world/brain/pkg/alert/rule/validator/adaptor/jsonRpc"
+	alertRuleBasicValidator "github.com/iot-my-world/brain/pkg/alert/rule/validator/basic"
+
+	auditEntryMongoRecordHandler "github.com/iot-my-world/brain/pkg/audit/entry/recordHandler/mongo"
+	auditLoggerJsonRpcAdaptor "github.com/iot-my-world/brain/pkg/audit/logger/adaptor/jsonRpc"
+	auditBasicLogger "github.com/iot-my-world/brain/pkg/audit/logger/basic"
+	auditRetriever "github.com/iot-my-world/brain/pkg/audit/retriever"
+	auditRecordHandlerRetriever "github.com/iot-my-world/brain/pkg/audit/retriever/recordHandler"
+
+	alertDeliveryRecordHandler "github.com/iot-my-world/brain/pkg/alert/delivery/recordHandler"
+	alertRuleRecordHandler "github.com/iot-my-world/brain/pkg/alert/rule/recordHandler"
+	sigbugDownlinkRecordHandler "github.com/iot-my-world/brain/pkg/device/sigbug/downlink/recordHandler"
+	sigbugEventRecordHandler "github.com/iot-my-world/brain/pkg/device/sigbug/event/recordHandler"
+	sigbugBatteryReadingRecordHandler "github.com/iot-my-world/brain/pkg/device/sigbug/reading/battery/recordHandler"
+	sigbugGPSReadingRecordHandler "github.com/iot-my-world/brain/pkg/device/sigbug/reading/gps/recordHandler"
+	sigbugHeartbeatReadingRecordHandler "github.com/iot-my-world/brain/pkg/device/sigbug/reading/heartbeat/recordHandler"
+	sigbugTemperatureReadingRecordHandler "github.com/iot-my-world/brain/pkg/device/sigbug/reading/temperature/recordHandler"
+	sigbugRecordHandler "github.com/iot-my-world/brain/pkg/device/sigbug/recordHandler"
+	sigbugTripRecordHandler "github.com/iot-my-world/brain/pkg/device/sigbug/trip/recordHandler"
+	geofenceEventRecordHandler "github.com/iot-my-world/brain/pkg/geofence/event/recordHandler"
+	geofenceRecordHandler "github.com/iot-my-world/brain/pkg/geofence/recordHandler"
+	clientRecordHandler "github.com/iot-my-world/brain/pkg/party/client/recordHandler"
+	companyRecordHandler "github.com/iot-my-world/brain/pkg/party/company/recordHandler"
+	systemRecordHandler "github.com/iot-my-world/brain/pkg/party/system/recordHandler"
+	retentionPolicyRecordHandler "github.com/iot-my-world/brain/pkg/retention/policy/recordHandler"
+	sigfoxBackendDataCallbackMessageDeadLetterRecordHandler "github.com/iot-my-world/brain/pkg/sigfox/backend/callback/data/message/deadLetter/recordHandler"
+	sigfoxBackendRecordHandler "github.com/iot-my-world/brain/pkg/sigfox/backend/recordHandler"
+	apiUserRecordHandler "github.com/iot-my-world/brain/pkg/user/api/recordHandler"
+	humanUserRecordHandler "github.com/iot-my-world/brain/pkg/user/human/recordHandler"
+	webhookDeliveryRecordHandler "github.com/iot-my-world/brain/pkg/webhook/delivery/recordHandler"
+	webhookSubscriptionRecordHandler "github.com/iot-my-world/brain/pkg/webhook/subscription/recordHandler"
+
+	basicClock "github.com/iot-my-world/brain/pkg/clock/basic"
+	lockoutEmailGenerator "github.com/iot-my-world/brain/pkg/communication/email/generator/lockout"
+	"github.com/iot-my-world/brain/pkg/security/lockout"
+	lockoutAdministratorJsonRpcAdaptor "github.com/iot-my-world/brain/pkg/security/lockout/administrator/adaptor/jsonRpc"
+	lockoutBasicAdministrator "github.com/iot-my-world/brain/pkg/security/lockout/administrator/basic"
+	lockoutMongoRecordHandler "github.com/iot-my-world/brain/pkg/security/lockout/recordHandler/mongo"
+	refreshTokenBasicAdministrator "github.com/iot-my-world/brain/pkg/security/token/refresh/administrator/basic"
+	refreshTokenMongoRecordHandler "github.com/iot-my-world/brain/pkg/security/token/refresh/recordHandler/mongo"
+	webhookDeliveryRecordHandlerJsonRpcAdaptor "github.com/iot-my-world/brain/pkg/webhook/delivery/recordHandler/adaptor/jsonRpc"
+	webhookDeliveryMongoRecordHandler "github.com/iot-my-world/brain/pkg/webhook/delivery/recordHandler/mongo"
+	webhookPoolPublisher "github.com/iot-my-world/brain/pkg/webhook/publisher/pool"
+	webhookSubscriptionAdministratorJsonRpcAdaptor "github.com/iot-my-world/brain/pkg/webhook/subscription/administrator/adaptor/jsonRpc"
+	webhookSubscriptionBasicAdministrator "github.com/iot-my-world/brain/pkg/webhook/subscription/administrator/basic"
+	webhookSubscriptionRecordHandlerJsonRpcAdaptor "github.com/iot-my-world/brain/pkg/webhook/subscription/recordHandler/adaptor/jsonRpc"
+	webhookSubscriptionMongoRecordHandler "github.com/iot-my-world/brain/pkg/webhook/subscription/recordHandler/mongo"
+	webhookSubscriptionValidatorJsonRpcAdaptor "github.com/iot-my-world/brain/pkg/webhook/subscription/validator/adaptor/jsonRpc"
+	webhookSubscriptionBasicValidator "github.com/iot-my-world/brain/pkg/webhook/subscription/validator/basic"
+
 	partyAdministratorJsonRpcAdaptor "github.com/iot-my-world/brain/pkg/party/administrator/adaptor/jsonRpc"
 	partyBasicAdministrator "github.com/iot-my-world/brain/pkg/party/administrator/basic"
 
@@ -77,25 +162,84 @@ import (
 
 	sigbugAdministratorJsonRpcAdaptor "github.com/iot-my-world/brain/pkg/device/sigbug/administrator/adaptor/jsonRpc"
 	sigbugBasicAdministrator "github.com/iot-my-world/brain/pkg/device/sigbug/administrator/basic"
+	sigbugDownlinkBasicAdministrator "github.com/iot-my-world/brain/pkg/device/sigbug/downlink/administrator/basic"
+	sigbugDownlinkRecordHandlerJsonRpcAdaptor "github.com/iot-my-world/brain/pkg/device/sigbug/downlink/recordHandler/adaptor/jsonRpc"
+	sigbugDownlinkMongoRecordHandler "github.com/iot-my-world/brain/pkg/device/sigbug/downlink/recordHandler/mongo"
+	sigbugEventRecordHandlerJsonRpcAdaptor "github.com/iot-my-world/brain/pkg/device/sigbug/event/recordHandler/adaptor/jsonRpc"
+	sigbugEventMongoRecordHandler "github.com/iot-my-world/brain/pkg/device/sigbug/event/recordHandler/mongo"
+	sigbugImporterJsonRpcAdaptor "github.com/iot-my-world/brain/pkg/device/sigbug/importer/adaptor/jsonRpc"
+	sigbugBasicImporter "github.com/iot-my-world/brain/pkg/device/sigbug/importer/basic"
+	sigbugMonitor "github.com/iot-my-world/brain/pkg/device/sigbug/monitor"
+	sigbugMonitorJsonRpcAdaptor "github.com/iot-my-world/brain/pkg/device/sigbug/monitor/adaptor/jsonRpc"
+	sigbugBasicMonitor "github.com/iot-my-world/brain/pkg/device/sigbug/monitor/basic"
+	sigbugBatteryReadingAdministratorJsonRpcAdaptor "github.com/iot-my-world/brain/pkg/device/sigbug/reading/battery/administrator/adaptor/jsonRpc"
+	sigbugBatteryReadingBasicAdministrator "github.com/iot-my-world/brain/pkg/device/sigbug/reading/battery/administrator/basic"
+	sigbugBatteryReadingRecordHandlerJsonRpcAdaptor "github.com/iot-my-world/brain/pkg/device/sigbug/reading/battery/recordHandler/adaptor/jsonRpc"
+	sigbugBatteryReadingMongoRecordHandler "github.com/iot-my-world/brain/pkg/device/sigbug/reading/battery/recordHandler/mongo"
+	sigbugBatteryReadingValidatorJsonRpcAdaptor "github.com/iot-my-world/brain/pkg/device/sigbug/reading/battery/validator/adaptor/jsonRpc"
+	sigbugBatteryReadingBasicValidator "github.com/iot-my-world/brain/pkg/device/sigbug/reading/battery/validator/basic"
 	sigbugGPSReadingAdministratorJsonRpcAdaptor "github.com/iot-my-world/brain/pkg/device/sigbug/reading/gps/administrator/adaptor/jsonRpc"
 	sigbugGPSReadingBasicAdministrator "github.com/iot-my-world/brain/pkg/device/sigbug/reading/gps/administrator/basic"
+	sigbugGPSReadingExporterHttpAdaptor "github.com/iot-my-world/brain/pkg/device/sigbug/reading/gps/exporter/adaptor/http"
+	sigbugGPSReadingBasicExporter "github.com/iot-my-world/brain/pkg/device/sigbug/reading/gps/exporter/basic"
 	sigbugGPSReadingRecordHandlerJsonRpcAdaptor "github.com/iot-my-world/brain/pkg/device/sigbug/reading/gps/recordHandler/adaptor/jsonRpc"
 	sigbugGPSReadingMongoRecordHandler "github.com/iot-my-world/brain/pkg/device/sigbug/reading/gps/recordHandler/mongo"
 	sigbugGPSReadingValidatorJsonRpcAdaptor "github.com/iot-my-world/brain/pkg/device/sigbug/reading/gps/validator/adaptor/jsonRpc"
 	sigbugGPSReadingBasicValidator "github.com/iot-my-world/brain/pkg/device/sigbug/reading/gps/validator/basic"
+	sigbugHeartbeatReadingAdministratorJsonRpcAdaptor "github.com/iot-my-world/brain/pkg/device/sigbug/reading/heartbeat/administrator/adaptor/jsonRpc"
+	sigbugHeartbeatReadingBasicAdministrator "github.com/iot-my-world/brain/pkg/device/sigbug/reading/heartbeat/administrator/basic"
+	sigbugHeartbeatReadingRecordHandlerJsonRpcAdaptor "github.com/iot-my-world/brain/pkg/device/sigbug/reading/heartbeat/recordHandler/adaptor/jsonRpc"
+	sigbugHeartbeatReadingMongoRecordHandler "github.com/iot-my-world/brain/pkg/device/sigbug/reading/heartbeat/recordHandler/mongo"
+	sigbugHeartbeatReadingValidatorJsonRpcAdaptor "github.com/iot-my-world/brain/pkg/device/sigbug/reading/heartbeat/validator/adaptor/jsonRpc"
+	sigbugHeartbeatReadingBasicValidator "github.com/iot-my-world/brain/pkg/device/sigbug/reading/heartbeat/validator/basic"
+	sigbugTemperatureReadingAdministratorJsonRpcAdaptor "github.com/iot-my-world/brain/pkg/device/sigbug/reading/temperature/administrator/adaptor/jsonRpc"
+	sigbugTemperatureReadingBasicAdministrator "github.com/iot-my-world/brain/pkg/device/sigbug/reading/temperature/administrator/basic"
+	sigbugTemperatureReadingRecordHandlerJsonRpcAdaptor "github.com/iot-my-world/brain/pkg/device/sigbug/reading/temperature/recordHandler/adaptor/jsonRpc"
+	sigbugTemperatureReadingMongoRecordHandler "github.com/iot-my-world/brain/pkg/device/sigbug/reading/temperature/recordHandler/mongo"
+	sigbugTemperatureReadingValidatorJsonRpcAdaptor "github.com/iot-my-world/brain/pkg/device/sigbug/reading/temperature/validator/adaptor/jsonRpc"
+	sigbugTemperatureReadingBasicValidator "github.com/iot-my-world/brain/pkg/device/sigbug/reading/temperature/validator/basic"
 	sigbugRecordHandlerJsonRpcAdaptor "github.com/iot-my-world/brain/pkg/device/sigbug/recordHandler/adaptor/jsonRpc"
 	sigbugMongoRecordHandler "github.com/iot-my-world/brain/pkg/device/sigbug/recordHandler/mongo"
-	sigbugSigfoxMessageHandler "github.com/iot-my-world/brain/pkg/device/sigbug/sigfox/message/handler"
+	sigbugSigfoxMessage "github.com/iot-my-world/brain/pkg/device/sigbug/sigfox/message"
+	sigbugSigfoxMessageDecoder "github.com/iot-my-world/brain/pkg/device/sigbug/sigfox/message/decoder"
+	sigbugSigfoxMessageSink "github.com/iot-my-world/brain/pkg/device/sigbug/sigfox/message/sink"
+	sigbugTripRecordHandlerJsonRpcAdaptor "github.com/iot-my-world/brain/pkg/device/sigbug/trip/recordHandler/adaptor/jsonRpc"
+	sigbugTripMongoRecordHandler "github.com/iot-my-world/brain/pkg/device/sigbug/trip/recordHandler/mongo"
+	sigbugTripBasicSegmenter "github.com/iot-my-world/brain/pkg/device/sigbug/trip/segmenter/basic"
 	sigbugValidatorJsonRpcAdaptor "github.com/iot-my-world/brain/pkg/device/sigbug/validator/adaptor/jsonRpc"
 	sigbugBasicValidator "github.com/iot-my-world/brain/pkg/device/sigbug/validator/basic"
 
+	geofenceAdministratorJsonRpcAdaptor "github.com/iot-my-world/brain/pkg/geofence/administrator/adaptor/jsonRpc"
+	geofenceBasicAdministrator "github.com/iot-my-world/brain/pkg/geofence/administrator/basic"
+	geofenceBasicEvaluator "github.com/iot-my-world/brain/pkg/geofence/evaluator/basic"
+	geofenceEventRecordHandlerJsonRpcAdaptor "github.com/iot-my-world/brain/pkg/geofence/event/recordHandler/adaptor/jsonRpc"
+	geofenceEventMongoRecordHandler "github.com/iot-my-world/brain/pkg/geofence/event/recordHandler/mongo"
+	geofenceRecordHandlerJsonRpcAdaptor "github.com/iot-my-world/brain/pkg/geofence/recordHandler/adaptor/jsonRpc"
+	geofenceMongoRecordHandler "github.com/iot-my-world/brain/pkg/geofence/recordHandler/mongo"
+	geofenceValidatorJsonRpcAdaptor "github.com/iot-my-world/brain/pkg/geofence/validator/adaptor/jsonRpc"
+	geofenceBasicValidator "github.com/iot-my-world/brain/pkg/geofence/validator/basic"
+
+	"github.com/iot-my-world/brain/pkg/communication/websocket"
+	websocketClientHelper "github.com/iot-my-world/brain/pkg/communication/websocket/clientHelper"
+	"net/http"
+
 	jsonRpcHttpServer "github.com/iot-my-world/brain/pkg/api/jsonRpc/server/http"
 	jsonRpcServiceProvider "github.com/iot-my-world/brain/pkg/api/jsonRpc/service/provider"
 
 	sigfoxBackendAdministratorJsonRpcAdaptor "github.com/iot-my-world/brain/pkg/sigfox/backend/administrator/adaptor/jsonRpc"
 	sigfoxBackendBasicAdministrator "github.com/iot-my-world/brain/pkg/sigfox/backend/administrator/basic"
 	sigfoxBackendAuthoriser "github.com/iot-my-world/brain/pkg/sigfox/backend/authoriser"
+	sigfoxBackendDataCallbackMessageDeadLetterAdministratorJsonRpcAdaptor "github.com/iot-my-world/brain/pkg/sigfox/backend/callback/data/message/deadLetter/administrator/adaptor/jsonRpc"
+	sigfoxBackendDataCallbackMessageDeadLetterBasicAdministrator "github.com/iot-my-world/brain/pkg/sigfox/backend/callback/data/message/deadLetter/administrator/basic"
+	sigfoxBackendDataCallbackMessageDeadLetterRecordHandlerJsonRpcAdaptor "github.com/iot-my-world/brain/pkg/sigfox/backend/callback/data/message/deadLetter/recordHandler/adaptor/jsonRpc"
+	sigfoxBackendDataCallbackMessageDeadLetterMongoRecordHandler "github.com/iot-my-world/brain/pkg/sigfox/backend/callback/data/message/deadLetter/recordHandler/mongo"
+	sigfoxBackendDataMessageDecoder "github.com/iot-my-world/brain/pkg/sigfox/backend/callback/data/message/decoder"
+	sigfoxBackendDataMessageDecoderRegistry "github.com/iot-my-world/brain/pkg/sigfox/backend/callback/data/message/decoder/registry"
+	sigfoxBackendDataMessageBasicDecoderRegistry "github.com/iot-my-world/brain/pkg/sigfox/backend/callback/data/message/decoder/registry/basic"
+	sigfoxBackendDataCallbackMessagePoolDispatcher "github.com/iot-my-world/brain/pkg/sigfox/backend/callback/data/message/dispatcher/pool"
 	sigfoxBackendDataMessageHandler "github.com/iot-my-world/brain/pkg/sigfox/backend/callback/data/message/handler"
+	sigfoxBackendDataMessageDecodingHandler "github.com/iot-my-world/brain/pkg/sigfox/backend/callback/data/message/handler/decoding"
+	sigfoxBackendCallbackServerHttpAdaptor "github.com/iot-my-world/brain/pkg/sigfox/backend/callback/server/adaptor/http"
 	sigfoxBackendCallbackServerJsonRpcAdaptor "github.com/iot-my-world/brain/pkg/sigfox/backend/callback/server/adaptor/jsonRpc"
 	sigfoxBasicBackendCallbackServer "github.com/iot-my-world/brain/pkg/sigfox/backend/callback/server/basic"
 	sigfoxBackendRecordHandlerJsonRpcAdaptor "github.com/iot-my-world/brain/pkg/sigfox/backend/recordHandler/adaptor/jsonRpc"
@@ -109,8 +253,14 @@ import (
 )
 
 var humanUserAPIServerPort = "9010"
+var websocketServerPort = "9012"
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		migrate(os.Args[2:])
+		return
+	}
+
 	pathToConfigFile := flag.String("pathToConfigFile", "configs/config.toml", "brain configuration file")
 	//kafkaBrokers := flag.String("kafkaBrokers", "localhost:9092", "ipAddress:port of each kafka broker node (, separated)")
 	flag.Parse()
@@ -144,9 +294,23 @@ func main() {
 	log.Info("Connected to Mongo!")
 	defer mainMongoSession.Close()
 
+	// Apply any pending database migrations
+	if brainConfig.AutoMigrate {
+		migrationRunner, err := migration.NewRunner(mainMongoSession.DB(databaseName), migrations.All())
+		if err != nil {
+			log.Fatal(err.Error())
+		}
+		if err := migrationRunner.Up(0, false); err != nil {
+			log.Fatal(err.Error())
+		}
+	}
+
 	// Get or Generate RSA Key Pair
 	rsaPrivateKey := encrypt.FetchPrivateKey(brainConfig.KeyFilePath)
 
+	// Get or Generate the key with which totp secrets are encrypted
+	totpSecretKey := encrypt.FetchSecretKey(brainConfig.KeyFilePath)
+
 	// Create Mailer
 	Mailer := gmailMailer.New(mailer.AuthInfo{
 		Identity: "",
@@ -162,6 +326,12 @@ func main() {
 	SetPasswordEmailGenerator := setPasswordEmailGenerator.New(
 		brainConfig.PathToEmailTemplateFolder,
 	)
+	AlertEmailGenerator := alertEmailGenerator.New(
+		brainConfig.PathToEmailTemplateFolder,
+	)
+	LockoutEmailGenerator := lockoutEmailGenerator.New(
+		brainConfig.PathToEmailTemplateFolder,
+	)
 
 	// create and start nerveBroadcast producer
 	//kafkaBrokerNodes := strings.Split(*kafkaBrokers, ",")
@@ -214,6 +384,28 @@ func main() {
 		ClientRecordHandler,
 		&systemClaims,
 	)
+
+	// Lockout
+	LockoutRecordHandler := lockoutMongoRecordHandler.New(
+		mainMongoSession,
+		databaseName,
+		databaseCollection.Lockout,
+	)
+	LockoutAdministrator := lockoutBasicAdministrator.New(
+		LockoutRecordHandler,
+		UserRecordHandler,
+		Mailer,
+		LockoutEmailGenerator,
+		basicClock.New(),
+		lockout.Policy{
+			DelayThreshold:   brainConfig.LoginDelayThreshold,
+			BaseDelay:        time.Duration(brainConfig.LoginBaseDelay) * time.Second,
+			LockoutThreshold: brainConfig.LoginLockoutThreshold,
+			LockoutDuration:  time.Duration(brainConfig.LoginLockoutDuration) * time.Second,
+		},
+		&systemClaims,
+	)
+
 	UserBasicAdministrator := humanUserBasicAdministrator.New(
 		UserRecordHandler,
 		UserValidator,
@@ -223,6 +415,8 @@ func main() {
 		&systemClaims,
 		SetPasswordEmailGenerator,
 		brainConfig.Environment,
+		LockoutAdministrator,
+		totpSecretKey,
 	)
 
 	// Company
@@ -310,6 +504,47 @@ func main() {
 		APIUserRecordHandler,
 	)
 
+	// Role
+	RoleValidator := roleBasicValidator.New(
+		PartyBasicAdministrator,
+		PermissionBasicHandler,
+		RoleRecordHandler,
+	)
+	RoleAdministrator := roleBasicAdministrator.New(
+		RoleValidator,
+		RoleRecordHandler,
+		UserRecordHandler,
+	)
+
+	// Webhook
+	WebhookSubscriptionRecordHandler := webhookSubscriptionMongoRecordHandler.New(
+		mainMongoSession,
+		databaseName,
+		databaseCollection.WebhookSubscription,
+	)
+	WebhookSubscriptionValidator := webhookSubscriptionBasicValidator.New(
+		PartyBasicAdministrator,
+	)
+	WebhookSubscriptionAdministrator := webhookSubscriptionBasicAdministrator.New(
+		WebhookSubscriptionValidator,
+		WebhookSubscriptionRecordHandler,
+		APIUserPasswordGenerator,
+	)
+	WebhookDeliveryRecordHandler := webhookDeliveryMongoRecordHandler.New(
+		mainMongoSession,
+		databaseName,
+		databaseCollection.WebhookDelivery,
+	)
+	WebhookPublisher := webhookPoolPublisher.New(
+		WebhookSubscriptionRecordHandler,
+		WebhookDeliveryRecordHandler,
+		10,
+		5,
+		30*time.Second,
+		10*time.Second,
+		&systemClaims,
+	)
+
 	// Sigbug Device
 	SigbugRecordHandler := sigbugMongoRecordHandler.New(
 		mainMongoSession,
@@ -321,9 +556,24 @@ func main() {
 		PartyBasicAdministrator,
 		&systemClaims,
 	)
+	SigbugDownlinkRecordHandler := sigbugDownlinkMongoRecordHandler.New(
+		mainMongoSession,
+		databaseName,
+		databaseCollection.SigbugDownlink,
+	)
 	SigbugAdministrator := sigbugBasicAdministrator.New(
 		SigbugValidator,
 		SigbugRecordHandler,
+		SigbugDownlinkRecordHandler,
+		WebhookPublisher,
+	)
+	SigbugDownlinkAdministrator := sigbugDownlinkBasicAdministrator.New(
+		SigbugRecordHandler,
+		SigbugDownlinkRecordHandler,
+	)
+	SigbugImporter := sigbugBasicImporter.New(
+		SigbugValidator,
+		SigbugAdministrator,
 	)
 	SigbugGPSReadingRecordHandler := sigbugGPSReadingMongoRecordHandler.New(
 		mainMongoSession,
@@ -339,6 +589,137 @@ func main() {
 		SigbugGPSReadingValidator,
 		SigbugGPSReadingRecordHandler,
 	)
+	SigbugGPSReadingExporter := sigbugGPSReadingBasicExporter.New(
+		SigbugGPSReadingRecordHandler,
+	)
+	SigbugBatteryReadingRecordHandler := sigbugBatteryReadingMongoRecordHandler.New(
+		mainMongoSession,
+		databaseName,
+		databaseCollection.SigbugBatteryReading,
+	)
+	SigbugBatteryReadingValidator := sigbugBatteryReadingBasicValidator.New(
+		SigbugRecordHandler,
+		PartyBasicAdministrator,
+		&systemClaims,
+	)
+	SigbugBatteryReadingAdministrator := sigbugBatteryReadingBasicAdministrator.New(
+		SigbugBatteryReadingValidator,
+		SigbugBatteryReadingRecordHandler,
+	)
+	SigbugTemperatureReadingRecordHandler := sigbugTemperatureReadingMongoRecordHandler.New(
+		mainMongoSession,
+		databaseName,
+		databaseCollection.SigbugTemperatureReading,
+	)
+	SigbugTemperatureReadingValidator := sigbugTemperatureReadingBasicValidator.New(
+		SigbugRecordHandler,
+		PartyBasicAdministrator,
+		&systemClaims,
+	)
+	SigbugTemperatureReadingAdministrator := sigbugTemperatureReadingBasicAdministrator.New(
+		SigbugTemperatureReadingValidator,
+		SigbugTemperatureReadingRecordHandler,
+	)
+	SigbugHeartbeatReadingRecordHandler := sigbugHeartbeatReadingMongoRecordHandler.New(
+		mainMongoSession,
+		databaseName,
+		databaseCollection.SigbugHeartbeatReading,
+	)
+	SigbugHeartbeatReadingValidator := sigbugHeartbeatReadingBasicValidator.New(
+		SigbugRecordHandler,
+		PartyBasicAdministrator,
+		&systemClaims,
+	)
+	SigbugHeartbeatReadingAdministrator := sigbugHeartbeatReadingBasicAdministrator.New(
+		SigbugHeartbeatReadingValidator,
+		SigbugHeartbeatReadingRecordHandler,
+	)
+	SigbugEventRecordHandler := sigbugEventMongoRecordHandler.New(
+		mainMongoSession,
+		databaseName,
+		databaseCollection.SigbugEvent,
+	)
+	SigbugTripRecordHandler := sigbugTripMongoRecordHandler.New(
+		mainMongoSession,
+		databaseName,
+		databaseCollection.SigbugTrip,
+	)
+	SigbugTripSegmenter := sigbugTripBasicSegmenter.New(
+		SigbugTripRecordHandler,
+		SigbugGPSReadingRecordHandler,
+		float32(brainConfig.TripDwellRadius),
+		time.Duration(brainConfig.TripDwellTime)*time.Second,
+		time.Duration(brainConfig.TripMaxGap)*time.Second,
+	)
+
+	// Geofence
+	GeofenceRecordHandler := geofenceMongoRecordHandler.New(
+		mainMongoSession,
+		databaseName,
+		databaseCollection.Geofence,
+	)
+	GeofenceValidator := geofenceBasicValidator.New(
+		PartyBasicAdministrator,
+		SigbugRecordHandler,
+	)
+	GeofenceAdministrator := geofenceBasicAdministrator.New(
+		GeofenceValidator,
+		GeofenceRecordHandler,
+	)
+	GeofenceEventRecordHandler := geofenceEventMongoRecordHandler.New(
+		mainMongoSession,
+		databaseName,
+		databaseCollection.GeofenceEvent,
+	)
+	GeofenceEvaluator := geofenceBasicEvaluator.New(
+		GeofenceRecordHandler,
+		GeofenceEventRecordHandler,
+		SigbugGPSReadingRecordHandler,
+		&systemClaims,
+	)
+
+	// Alert
+	AlertRuleRecordHandler := alertRuleMongoRecordHandler.New(
+		mainMongoSession,
+		databaseName,
+		databaseCollection.AlertRule,
+	)
+	AlertRuleValidator := alertRuleBasicValidator.New(
+		PartyBasicAdministrator,
+		SigbugRecordHandler,
+		GeofenceRecordHandler,
+	)
+	AlertRuleAdministrator := alertRuleBasicAdministrator.New(
+		AlertRuleValidator,
+		AlertRuleRecordHandler,
+		APIUserPasswordGenerator,
+	)
+	AlertDeliveryRecordHandler := alertDeliveryMongoRecordHandler.New(
+		mainMongoSession,
+		databaseName,
+		databaseCollection.AlertDelivery,
+	)
+	AlertEngine := alertBasicEngine.New(
+		AlertRuleRecordHandler,
+		AlertDeliveryRecordHandler,
+		map[rule.ChannelType]notificationChannel.Channel{
+			rule.EmailChannel:   emailNotificationChannel.New(Mailer, AlertEmailGenerator),
+			rule.WebhookChannel: webhookNotificationChannel.New(10 * time.Second),
+		},
+		10,
+		&systemClaims,
+	)
+
+	// Sigbug Monitor
+	SigbugMonitor := sigbugBasicMonitor.New(
+		SigbugRecordHandler,
+		SigbugEventRecordHandler,
+		PartyBasicAdministrator,
+		AlertEngine,
+		WebhookPublisher,
+		time.Duration(brainConfig.DeviceSilenceThreshold)*time.Second,
+		&systemClaims,
+	)
 
 	// Sigfox Backend
 	SigfoxBackendRecordHandler := sigfoxBackendMongoRecordHandler.New(
@@ -346,16 +727,55 @@ func main() {
 		databaseName,
 		databaseCollection.SigfoxBackend,
 	)
+	// Audit
+	AuditEntryRecordHandler := auditEntryMongoRecordHandler.New(
+		mainMongoSession,
+		databaseName,
+		databaseCollection.AuditEntry,
+	)
+	AuditLogger := auditBasicLogger.New(
+		AuditEntryRecordHandler,
+	)
+
+	// Token Revocation
+	TokenRevocationRecordHandler := tokenRevocationMongoRecordHandler.New(
+		mainMongoSession,
+		databaseName,
+		databaseCollection.TokenRevocation,
+	)
+	TokenRevocationAdministrator := tokenRevocationBasicAdministrator.New(
+		TokenRevocationRecordHandler,
+		UserRecordHandler,
+		APIUserRecordHandler,
+		SigfoxBackendRecordHandler,
+		&systemClaims,
+	)
+
+	// Refresh Token
+	RefreshTokenRecordHandler := refreshTokenMongoRecordHandler.New(
+		mainMongoSession,
+		databaseName,
+		databaseCollection.RefreshToken,
+	)
+	RefreshTokenAdministrator := refreshTokenBasicAdministrator.New(
+		RefreshTokenRecordHandler,
+		TokenRevocationAdministrator,
+		time.Duration(brainConfig.RefreshTokenLifetime)*time.Second,
+		time.Duration(brainConfig.RefreshSessionLifetime)*time.Second,
+		&systemClaims,
+	)
+
 	SigfoxBackendValidator := sigfoxBackendBasicValidator.New(
 		PartyBasicAdministrator,
 		SigfoxBackendRecordHandler,
 		&systemClaims,
-		token.NewJWTValidator(&rsaPrivateKey.PublicKey),
+		token.NewJWTValidator(&rsaPrivateKey.PublicKey, TokenRevocationAdministrator),
 	)
 	SigfoxBackendAdministrator := sigfoxBackendBasicAdministrator.New(
 		SigfoxBackendValidator,
 		SigfoxBackendRecordHandler,
 		rsaPrivateKey,
+		TokenRevocationAdministrator,
 	)
 	SigfoxBackendDataCallbackMessageMongoRecordHandler := sigfoxBackendDataCallbackMessageMongoRecordHandler.New(
 		mainMongoSession,
@@ -367,49 +787,193 @@ func main() {
 		SigfoxBackendDataCallbackMessageBasicValidator,
 		SigfoxBackendDataCallbackMessageMongoRecordHandler,
 	)
+	SigfoxBackendDataCallbackMessageDeadLetterRecordHandler := sigfoxBackendDataCallbackMessageDeadLetterMongoRecordHandler.New(
+		mainMongoSession,
+		databaseName,
+		databaseCollection.SigfoxBackendDataCallbackMessageDeadLetter,
+	)
 
 	// Report
 	TrackingReport := trackingBasicReport.New(
 		PartyBasicAdministrator,
+		SigbugRecordHandler,
+		SigbugGPSReadingRecordHandler,
+	)
+	TripReport := tripBasicReport.New(
+		SigbugRecordHandler,
+		SigbugTripRecordHandler,
+	)
+
+	// Retention
+	RetentionPolicyRecordHandler := retentionPolicyMongoRecordHandler.New(
+		mainMongoSession,
+		databaseName,
+		databaseCollection.RetentionPolicy,
+	)
+	RetentionPolicyValidator := retentionPolicyBasicValidator.New(
+		PartyBasicAdministrator,
+		RetentionPolicyRecordHandler,
+		brainConfig.RetentionArchiveDirectory,
+	)
+	RetentionPolicyAdministrator := retentionPolicyBasicAdministrator.New(
+		RetentionPolicyValidator,
+		RetentionPolicyRecordHandler,
+	)
+	RetentionPurger := retentionBasicPurger.New(
+		RetentionPolicyRecordHandler,
+		SigbugRecordHandler,
+		SigfoxBackendDataCallbackMessageMongoRecordHandler,
+		SigbugGPSReadingRecordHandler,
+		brainConfig.RetentionArchiveDirectory,
 	)
 
 	HumanUserJsonRpcServerAuthenticator := humanUserJsonRpcServerAuthenticator.New(
 		UserRecordHandler,
 		rsaPrivateKey,
 		&systemClaims,
+		TokenRevocationAdministrator,
+		PartyBasicAdministrator,
+		LockoutAdministrator,
+		RefreshTokenAdministrator,
+		totpSecretKey,
+	)
+	APIUserAuthenticator := apiUserBasicAuthenticator.New(
+		PartyBasicAdministrator,
+		APIUserRecordHandler,
+		rsaPrivateKey,
+		&systemClaims,
+		TokenRevocationAdministrator,
+	)
+
+	// Websocket Hub
+	WebsocketHub := websocket.NewHub()
+	go WebsocketHub.Run()
+
+	// Sigfox Backend Data Message Decoders
+	SigfoxBackendDataMessageDecoderRegistry := sigfoxBackendDataMessageBasicDecoderRegistry.New()
+	SigbugSigfoxMessageSink := sigbugSigfoxMessageSink.New(
+		SigbugRecordHandler,
+		SigbugAdministrator,
+		SigbugDownlinkAdministrator,
+		SigbugGPSReadingAdministrator,
+		SigbugGPSReadingRecordHandler,
+		SigbugBatteryReadingAdministrator,
+		SigbugTemperatureReadingAdministrator,
+		SigbugHeartbeatReadingAdministrator,
+		SigbugEventRecordHandler,
+		SigbugMonitor,
+		WebsocketHub,
+		GeofenceEvaluator,
+		SigbugTripSegmenter,
+		AlertEngine,
+		WebhookPublisher,
 	)
+	for messageType, decoder := range map[byte]sigfoxBackendDataMessageDecoder.Decoder{
+		sigbugSigfoxMessage.CouldNotGetGPSFixMessageType: sigbugSigfoxMessageDecoder.NewCouldNotGetGPSFix(),
+		sigbugSigfoxMessage.GPSReading:                   sigbugSigfoxMessageDecoder.NewGPSReading(),
+		sigbugSigfoxMessage.BatteryReading:               sigbugSigfoxMessageDecoder.NewBatteryReading(),
+		sigbugSigfoxMessage.TemperatureReading:           sigbugSigfoxMessageDecoder.NewTemperatureReading(),
+		sigbugSigfoxMessage.Heartbeat:                    sigbugSigfoxMessageDecoder.NewHeartbeat(),
+	} {
+		if err := SigfoxBackendDataMessageDecoderRegistry.Register(&sigfoxBackendDataMessageDecoderRegistry.RegisterRequest{
+			DeviceType:  sigbugSigfoxMessage.DeviceType,
+			MessageType: messageType,
+			Decoder:     decoder,
+			Sink:        SigbugSigfoxMessageSink,
+		}); err != nil {
+			log.Fatal("unable to register sigbug sigfox message decoder: " + err.Error())
+		}
+	}
 
 	// Sigfox Backend Callback Server
-	SigfoxBackendCallbackServer := sigfoxBasicBackendCallbackServer.New(
-		SigfoxBackendDataCallbackMessageBasicAdministrator,
+	SigfoxBackendDataCallbackMessageDispatcher := sigfoxBackendDataCallbackMessagePoolDispatcher.New(
 		[]sigfoxBackendDataMessageHandler.Handler{
-			sigbugSigfoxMessageHandler.New(
-				SigbugRecordHandler,
-				SigbugAdministrator,
-				SigbugGPSReadingAdministrator,
+			sigfoxBackendDataMessageDecodingHandler.New(
+				sigbugSigfoxMessage.DeviceType,
+				SigfoxBackendDataMessageDecoderRegistry,
 			),
 		},
+		SigfoxBackendDataCallbackMessageDeadLetterRecordHandler,
+		brainConfig.DispatchWorkers,
+		brainConfig.DispatchMaxAttempts,
+		time.Duration(brainConfig.DispatchRetryBackoff)*time.Second,
+		5*time.Second,
+	)
+	SigfoxBackendDataCallbackMessageDeadLetterAdministrator := sigfoxBackendDataCallbackMessageDeadLetterBasicAdministrator.New(
+		SigfoxBackendDataCallbackMessageDeadLetterRecordHandler,
+		SigfoxBackendDataCallbackMessageDispatcher,
 	)
+	SigfoxBackendCallbackServer := sigfoxBasicBackendCallbackServer.New(
+		SigfoxBackendDataCallbackMessageBasicAdministrator,
+		SigfoxBackendDataCallbackMessageMongoRecordHandler,
+		SigfoxBackendDataCallbackMessageDispatcher,
+		SigbugDownlinkAdministrator,
+		&systemClaims,
+	)
+
+	IPAddressResolver, err := ipAddress.NewResolver(brainConfig.TrustedProxies)
+	if err != nil {
+		log.Fatal(err.Error())
+	}
+
+	// the state of the entities targeted by audited calls
+	// is retrieved from the record handlers of the entities
+	AuditTargetRetrievers := make(map[jsonRpcServiceProvider.Name]auditRetriever.Retriever)
+	for serviceProvider, recordHandler := range map[string]interface{}{
+		humanUserRecordHandler.ServiceProvider:                                  UserRecordHandler,
+		apiUserRecordHandler.ServiceProvider:                                    APIUserRecordHandler,
+		companyRecordHandler.ServiceProvider:                                    CompanyRecordHandler,
+		clientRecordHandler.ServiceProvider:                                     ClientRecordHandler,
+		systemRecordHandler.ServiceProvider:                                     SystemRecordHandler,
+		sigbugRecordHandler.ServiceProvider:                                     SigbugRecordHandler,
+		sigbugDownlinkRecordHandler.ServiceProvider:                             SigbugDownlinkRecordHandler,
+		sigbugGPSReadingRecordHandler.ServiceProvider:                           SigbugGPSReadingRecordHandler,
+		sigbugBatteryReadingRecordHandler.ServiceProvider:                       SigbugBatteryReadingRecordHandler,
+		sigbugTemperatureReadingRecordHandler.ServiceProvider:                   SigbugTemperatureReadingRecordHandler,
+		sigbugHeartbeatReadingRecordHandler.ServiceProvider:                     SigbugHeartbeatReadingRecordHandler,
+		sigbugEventRecordHandler.ServiceProvider:                                SigbugEventRecordHandler,
+		sigbugTripRecordHandler.ServiceProvider:                                 SigbugTripRecordHandler,
+		geofenceRecordHandler.ServiceProvider:                                   GeofenceRecordHandler,
+		geofenceEventRecordHandler.ServiceProvider:                              GeofenceEventRecordHandler,
+		alertRuleRecordHandler.ServiceProvider:                                  AlertRuleRecordHandler,
+		alertDeliveryRecordHandler.ServiceProvider:                              AlertDeliveryRecordHandler,
+		webhookSubscriptionRecordHandler.ServiceProvider:                        WebhookSubscriptionRecordHandler,
+		webhookDeliveryRecordHandler.ServiceProvider:                            WebhookDeliveryRecordHandler,
+		retentionPolicyRecordHandler.ServiceProvider:                            RetentionPolicyRecordHandler,
+		sigfoxBackendRecordHandler.ServiceProvider:                              SigfoxBackendRecordHandler,
+		sigfoxBackendDataCallbackMessageDeadLetterRecordHandler.ServiceProvider: SigfoxBackendDataCallbackMessageDeadLetterRecordHandler,
+	} {
+		AuditTargetRetriever, err := auditRecordHandlerRetriever.New(recordHandler)
+		if err != nil {
+			log.Fatal(serviceProvider + ": " + err.Error())
+		}
+		AuditTargetRetrievers[jsonRpcServiceProvider.Name(serviceProvider)] = AuditTargetRetriever
+	}
 
 	humanUserJsonRpcHttpServer := jsonRpcHttpServer.New(
 		"/api-1",
 		"0.0.0.0",
 		humanUserAPIServerPort,
 		humanUserAuthoriser.New(
-			token.NewJWTValidator(&rsaPrivateKey.PublicKey),
+			token.NewJWTValidator(&rsaPrivateKey.PublicKey, TokenRevocationAdministrator),
 			PermissionBasicHandler,
 		),
+		AuditLogger,
+		AuditTargetRetrievers,
+		&systemClaims,
 	)
 	if err := humanUserJsonRpcHttpServer.RegisterBatchServiceProviders(
 		[]jsonRpcServiceProvider.Provider{
-			humanUserJsonRpcServerAuthenticatorJsonRpcAdaptor.New(HumanUserJsonRpcServerAuthenticator),
+			humanUserJsonRpcServerAuthenticatorJsonRpcAdaptor.New(HumanUserJsonRpcServerAuthenticator, IPAddressResolver),
 			humanUserRecordHandlerJsonRpcAdaptor.New(UserRecordHandler),
 			humanUserValidatorJsonRpcAdaptor.New(UserValidator),
 			humanUserAdministratorJsonRpcAdaptor.New(UserBasicAdministrator),
 			apiUserRecordHandlerJsonRpcAdaptor.New(APIUserRecordHandler),
 			apiUserValidatorJsonRpcAdaptor.New(APIUserValidator),
 			apiUserAdministratorJsonRpcAdaptor.New(APIUserAdministrator),
+			apiUserAuthenticatorJsonRpcAdaptor.New(APIUserAuthenticator),
 			permissionAdministratorJsonRpcAdaptor.New(PermissionBasicHandler),
+			tokenRevocationAdministratorJsonRpcAdaptor.New(TokenRevocationAdministrator),
 			companyRecordHandlerJsonRpcAdaptor.New(CompanyRecordHandler),
 			companyValidatorJsonRpcAdaptor.New(CompanyValidator),
 			companyAdministratorJsonRpcAdaptor.New(CompanyAdministrator),
@@ -422,17 +986,63 @@ func main() {
 			sigbugRecordHandlerJsonRpcAdaptor.New(SigbugRecordHandler),
 			sigbugValidatorJsonRpcAdaptor.New(SigbugValidator),
 			sigbugAdministratorJsonRpcAdaptor.New(SigbugAdministrator),
+			sigbugImporterJsonRpcAdaptor.New(SigbugImporter),
+			sigbugDownlinkRecordHandlerJsonRpcAdaptor.New(SigbugDownlinkRecordHandler),
 			sigbugGPSReadingRecordHandlerJsonRpcAdaptor.New(SigbugGPSReadingRecordHandler),
 			sigbugGPSReadingValidatorJsonRpcAdaptor.New(SigbugGPSReadingValidator),
 			sigbugGPSReadingAdministratorJsonRpcAdaptor.New(SigbugGPSReadingAdministrator),
+			sigbugBatteryReadingRecordHandlerJsonRpcAdaptor.New(SigbugBatteryReadingRecordHandler),
+			sigbugBatteryReadingValidatorJsonRpcAdaptor.New(SigbugBatteryReadingValidator),
+			sigbugBatteryReadingAdministratorJsonRpcAdaptor.New(SigbugBatteryReadingAdministrator),
+			sigbugTemperatureReadingRecordHandlerJsonRpcAdaptor.New(SigbugTemperatureReadingRecordHandler),
+			sigbugTemperatureReadingValidatorJsonRpcAdaptor.New(SigbugTemperatureReadingValidator),
+			sigbugTemperatureReadingAdministratorJsonRpcAdaptor.New(SigbugTemperatureReadingAdministrator),
+			sigbugHeartbeatReadingRecordHandlerJsonRpcAdaptor.New(SigbugHeartbeatReadingRecordHandler),
+			sigbugHeartbeatReadingValidatorJsonRpcAdaptor.New(SigbugHeartbeatReadingValidator),
+			sigbugHeartbeatReadingAdministratorJsonRpcAdaptor.New(SigbugHeartbeatReadingAdministrator),
+			sigbugEventRecordHandlerJsonRpcAdaptor.New(SigbugEventRecordHandler),
+			sigbugTripRecordHandlerJsonRpcAdaptor.New(SigbugTripRecordHandler),
+			sigbugMonitorJsonRpcAdaptor.New(SigbugMonitor),
+			geofenceRecordHandlerJsonRpcAdaptor.New(GeofenceRecordHandler),
+			geofenceValidatorJsonRpcAdaptor.New(GeofenceValidator),
+			geofenceAdministratorJsonRpcAdaptor.New(GeofenceAdministrator),
+			geofenceEventRecordHandlerJsonRpcAdaptor.New(GeofenceEventRecordHandler),
+			alertRuleRecordHandlerJsonRpcAdaptor.New(AlertRuleRecordHandler),
+			alertRuleValidatorJsonRpcAdaptor.New(AlertRuleValidator),
+			alertRuleAdministratorJsonRpcAdaptor.New(AlertRuleAdministrator),
+			alertDeliveryRecordHandlerJsonRpcAdaptor.New(AlertDeliveryRecordHandler),
+			webhookSubscriptionRecordHandlerJsonRpcAdaptor.New(WebhookSubscriptionRecordHandler),
+			webhookSubscriptionValidatorJsonRpcAdaptor.New(WebhookSubscriptionValidator),
+			webhookSubscriptionAdministratorJsonRpcAdaptor.New(WebhookSubscriptionAdministrator),
+			webhookDeliveryRecordHandlerJsonRpcAdaptor.New(WebhookDeliveryRecordHandler),
+			auditLoggerJsonRpcAdaptor.New(AuditLogger),
+			lockoutAdministratorJsonRpcAdaptor.New(LockoutAdministrator),
+			roleValidatorJsonRpcAdaptor.New(RoleValidator),
+			roleAdministratorJsonRpcAdaptor.New(RoleAdministrator),
 			trackingReportJsonRpcAdaptor.New(TrackingReport),
+			tripReportJsonRpcAdaptor.New(TripReport),
+			retentionPolicyRecordHandlerJsonRpcAdaptor.New(RetentionPolicyRecordHandler),
+			retentionPolicyValidatorJsonRpcAdaptor.New(RetentionPolicyValidator),
+			retentionPolicyAdministratorJsonRpcAdaptor.New(RetentionPolicyAdministrator),
 			sigfoxBackendRecordHandlerJsonRpcAdaptor.New(SigfoxBackendRecordHandler),
 			sigfoxBackendValidatorJsonRpcAdaptor.New(SigfoxBackendValidator),
 			sigfoxBackendAdministratorJsonRpcAdaptor.New(SigfoxBackendAdministrator),
+			sigfoxBackendDataCallbackMessageDeadLetterRecordHandlerJsonRpcAdaptor.New(SigfoxBackendDataCallbackMessageDeadLetterRecordHandler),
+			sigfoxBackendDataCallbackMessageDeadLetterAdministratorJsonRpcAdaptor.New(SigfoxBackendDataCallbackMessageDeadLetterAdministrator),
 		},
 	); err != nil {
 		log.Fatal(err)
 	}
+	humanUserJsonRpcHttpServer.RegisterHandler(
+		sigbugGPSReadingExporterHttpAdaptor.Path,
+		sigbugGPSReadingExporterHttpAdaptor.NewAuthorisedHandler(
+			SigbugGPSReadingExporter,
+			humanUserAuthoriser.New(
+				token.NewJWTValidator(&rsaPrivateKey.PublicKey, TokenRevocationAdministrator),
+				PermissionBasicHandler,
+			),
+		),
+	)
 	log.Info("Starting Human User API Server on port: " + humanUserAPIServerPort)
 	go func() {
 		err := humanUserJsonRpcHttpServer.SecureStart()
@@ -440,20 +1050,61 @@ func main() {
 		os.Exit(1)
 	}()
 
+	// set up websocket server
+	websocketServerMux := http.NewServeMux()
+	websocketServerMux.Handle(
+		"/ws",
+		websocketClientHelper.NewAuthorisedHandler(
+			WebsocketHub,
+			humanUserAuthoriser.New(
+				token.NewJWTValidator(&rsaPrivateKey.PublicKey, TokenRevocationAdministrator),
+				PermissionBasicHandler,
+			),
+		),
+	)
+	log.Info("Starting Websocket Server on port: " + websocketServerPort)
+	go func() {
+		err := http.ListenAndServe("0.0.0.0:"+websocketServerPort, websocketServerMux)
+		log.Error("websocket server has stopped: ", err)
+		os.Exit(1)
+	}()
+
 	// set  up sigfox backend server
+	SigfoxBackendAuthoriser := sigfoxBackendAuthoriser.New(
+		token.NewJWTValidator(&rsaPrivateKey.PublicKey, TokenRevocationAdministrator),
+	)
 	sigfoxBackendJsonRpcHttpServer := jsonRpcHttpServer.New(
 		"/api-2",
 		"0.0.0.0",
 		"9011",
-		sigfoxBackendAuthoriser.New(
-			token.NewJWTValidator(&rsaPrivateKey.PublicKey),
-		),
+		SigfoxBackendAuthoriser,
+		// data callbacks are not audited since every
+		// message received is already recorded
+		nil,
+		nil,
+		nil,
 	)
 	if err := sigfoxBackendJsonRpcHttpServer.RegisterBatchServiceProviders([]jsonRpcServiceProvider.Provider{
 		sigfoxBackendCallbackServerJsonRpcAdaptor.New(SigfoxBackendCallbackServer),
 	}); err != nil {
 		log.Fatal(err.Error())
 	}
+	// bidirectional callbacks must be responded to in the raw
+	// shape expected by the sigfox backend, not over json rpc
+	sigfoxBackendJsonRpcHttpServer.RegisterHandler(
+		sigfoxBackendCallbackServerHttpAdaptor.DataPath,
+		sigfoxBackendCallbackServerHttpAdaptor.NewAuthorisedDataHandler(
+			SigfoxBackendCallbackServer,
+			SigfoxBackendAuthoriser,
+		),
+	)
+	sigfoxBackendJsonRpcHttpServer.RegisterHandler(
+		sigfoxBackendCallbackServerHttpAdaptor.DownlinkAckPath,
+		sigfoxBackendCallbackServerHttpAdaptor.NewAuthorisedDownlinkAckHandler(
+			SigfoxBackendCallbackServer,
+			SigfoxBackendAuthoriser,
+		),
+	)
 	log.Info("Starting Sigfox Backend secure API Server on port: " + "9011")
 	go func() {
 		err := sigfoxBackendJsonRpcHttpServer.SecureStart()
@@ -461,6 +1112,40 @@ func main() {
 		os.Exit(1)
 	}()
 
+	// periodically check for sigbugs which have stopped reporting,
+	// unless the check is disabled with a period that is not positive
+	if brainConfig.DeviceSilenceCheckPeriod > 0 {
+		go func() {
+			ticker := time.NewTicker(time.Duration(brainConfig.DeviceSilenceCheckPeriod) * time.Second)
+			for range ticker.C {
+				if _, err := SigbugMonitor.CheckDevices(&sigbugMonitor.CheckDevicesRequest{
+					Claims: &systemClaims,
+				}); err != nil {
+					log.Error("error checking for silent sigbug devices: " + err.Error())
+				}
+			}
+		}()
+	} else {
+		log.Warn("device silence check period is not positive, not checking for silent sigbug devices")
+	}
+
+	// periodically purge records which have outlived their retention policy,
+	// unless purging is disabled with a period that is not positive
+	if brainConfig.RetentionPurgePeriod > 0 {
+		go func() {
+			ticker := time.NewTicker(time.Duration(brainConfig.RetentionPurgePeriod) * time.Second)
+			for range ticker.C {
+				if _, err := RetentionPurger.Purge(&retentionPurger.PurgeRequest{
+					Claims: &systemClaims,
+				}); err != nil {
+					log.Error("error purging records: " + err.Error())
+				}
+			}
+		}()
+	} else {
+		log.Warn("retention purge period is not positive, not purging records")
+	}
+
 	//// set up kafka messaging
 	//MessageConsumerGroup := messageConsumerGroup.New(
 	//	kafkaBrokerNodes,
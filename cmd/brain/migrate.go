@@ -0,0 +1,63 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"github.com/iot-my-world/brain/internal/config"
+	"github.com/iot-my-world/brain/internal/log"
+	"github.com/iot-my-world/brain/internal/migration"
+	"github.com/iot-my-world/brain/internal/migration/migrations"
+	"strings"
+)
+
+// migrate runs the brain migrate subcommand which applies or reverses
+// database migrations, e.g.
+//
+//	brain migrate -pathToConfigFile configs/config.toml -dryRun
+//	brain migrate -down -targetVersion 1
+func migrate(arguments []string) {
+	migrateFlags := flag.NewFlagSet("migrate", flag.ExitOnError)
+	pathToConfigFile := migrateFlags.String("pathToConfigFile", "configs/config.toml", "brain configuration file")
+	targetVersion := migrateFlags.Int("targetVersion", 0, "version to migrate to, 0 being the latest when migrating up")
+	down := migrateFlags.Bool("down", false, "reverse migrations down to the target version")
+	dryRun := migrateFlags.Bool("dryRun", false, "log what would be done without changing the database")
+	if err := migrateFlags.Parse(arguments); err != nil {
+		log.Fatal(err.Error())
+	}
+
+	brainConfig := config.New(*pathToConfigFile)
+	databaseName := "brain"
+
+	databaseSession, err := migration.GetDatabaseSession(
+		strings.Join(brainConfig.MongoNodes, ","),
+		brainConfig.MongoUser,
+		brainConfig.MongoPassword,
+		databaseName,
+	)
+	if err != nil {
+		log.Fatal(err.Error())
+	}
+	defer databaseSession.Close()
+
+	runner, err := migration.NewRunner(databaseSession.DB(databaseName), migrations.All())
+	if err != nil {
+		log.Fatal(err.Error())
+	}
+
+	if *down {
+		err = runner.Down(*targetVersion, *dryRun)
+	} else {
+		err = runner.Up(*targetVersion, *dryRun)
+	}
+	if err != nil {
+		log.Fatal(err.Error())
+	}
+
+	appliedMigrations, err := runner.Applied()
+	if err != nil {
+		log.Fatal(err.Error())
+	}
+	for _, appliedMigration := range appliedMigrations {
+		log.Info(fmt.Sprintf("applied migration version %d: %s", appliedMigration.Version, appliedMigration.Description))
+	}
+}
@@ -19,6 +19,24 @@ type Config struct {
 	PathToEmailTemplateFolder string
 	KeyFilePath               string
 	Environment               environment.Type
+	AutoMigrate               bool
+	DeviceSilenceThreshold    int64
+	DeviceSilenceCheckPeriod  int64
+	LoginDelayThreshold       int
+	LoginBaseDelay            int64
+	LoginLockoutThreshold     int
+	LoginLockoutDuration      int64
+	RefreshTokenLifetime      int64
+	RefreshSessionLifetime    int64
+	TripDwellRadius           float64
+	TripDwellTime             int64
+	TripMaxGap                int64
+	RetentionPurgePeriod      int64
+	RetentionArchiveDirectory string
+	TrustedProxies            []string
+	DispatchWorkers           int
+	DispatchMaxAttempts       int
+	DispatchRetryBackoff      int64
 }
 
 func New(pathToConfigFile string) Config {
@@ -35,6 +53,24 @@ func New(pathToConfigFile string) Config {
 	viper.SetDefault("pathToEmailTemplateFolder", "assets/email/template")
 	viper.SetDefault("keyFilePath", "")
 	viper.SetDefault("environment", environment.Development)
+	viper.SetDefault("autoMigrate", false)
+	viper.SetDefault("deviceSilenceThreshold", 86400)
+	viper.SetDefault("deviceSilenceCheckPeriod", 300)
+	viper.SetDefault("loginDelayThreshold", 3)
+	viper.SetDefault("loginBaseDelay", 1)
+	viper.SetDefault("loginLockoutThreshold", 10)
+	viper.SetDefault("loginLockoutDuration", 900)
+	viper.SetDefault("refreshTokenLifetime", 604800)
+	viper.SetDefault("refreshSessionLifetime", 2592000)
+	viper.SetDefault("tripDwellRadius", 100)
+	viper.SetDefault("tripDwellTime", 300)
+	viper.SetDefault("tripMaxGap", 1800)
+	viper.SetDefault("retentionPurgePeriod", 86400)
+	viper.SetDefault("retentionArchiveDirectory", "")
+	viper.SetDefault("trustedProxies", []string{})
+	viper.SetDefault("dispatchWorkers", 10)
+	viper.SetDefault("dispatchMaxAttempts", 5)
+	viper.SetDefault("dispatchRetryBackoff", 1)
 
 	// check if the config file exists
 	if _, err := os.Stat(pathToConfigFile); err != nil {
@@ -67,5 +103,23 @@ func New(pathToConfigFile string) Config {
 		PathToEmailTemplateFolder: viper.GetString("pathToEmailTemplateFolder"),
 		KeyFilePath:               viper.GetString("keyFilePath"),
 		Environment:               environment.Type(viper.GetString("environment")),
+		AutoMigrate:               viper.GetBool("autoMigrate"),
+		DeviceSilenceThreshold:    viper.GetInt64("deviceSilenceThreshold"),
+		DeviceSilenceCheckPeriod:  viper.GetInt64("deviceSilenceCheckPeriod"),
+		LoginDelayThreshold:       viper.GetInt("loginDelayThreshold"),
+		LoginBaseDelay:            viper.GetInt64("loginBaseDelay"),
+		LoginLockoutThreshold:     viper.GetInt("loginLockoutThreshold"),
+		LoginLockoutDuration:      viper.GetInt64("loginLockoutDuration"),
+		RefreshTokenLifetime:      viper.GetInt64("refreshTokenLifetime"),
+		RefreshSessionLifetime:    viper.GetInt64("refreshSessionLifetime"),
+		TripDwellRadius:           viper.GetFloat64("tripDwellRadius"),
+		TripDwellTime:             viper.GetInt64("tripDwellTime"),
+		TripMaxGap:                viper.GetInt64("tripMaxGap"),
+		RetentionPurgePeriod:      viper.GetInt64("retentionPurgePeriod"),
+		RetentionArchiveDirectory: viper.GetString("retentionArchiveDirectory"),
+		TrustedProxies:            viper.GetStringSlice("trustedProxies"),
+		DispatchWorkers:           viper.GetInt("dispatchWorkers"),
+		DispatchMaxAttempts:       viper.GetInt("dispatchMaxAttempts"),
+		DispatchRetryBackoff:      viper.GetInt64("dispatchRetryBackoff"),
 	}
 }
@@ -0,0 +1,85 @@
+package ipAddress
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+)
+
+// Resolver determines the ip address of the client which made a request.
+// The headers set by a reverse proxy are only taken into account if the
+// request was received from one of the trusted proxies, since otherwise
+// the client could set them to anything.
+type Resolver struct {
+	trustedProxies []*net.IPNet
+}
+
+// NewResolver returns a resolver which trusts the proxies with the given
+// ip addresses or CIDR ranges, e.g. 10.0.0.1 or 10.0.0.0/8
+func NewResolver(trustedProxies []string) (*Resolver, error) {
+	resolver := Resolver{
+		trustedProxies: make([]*net.IPNet, 0),
+	}
+	for _, trustedProxy := range trustedProxies {
+		if !strings.Contains(trustedProxy, "/") {
+			if ip := net.ParseIP(trustedProxy); ip != nil && ip.To4() != nil {
+				trustedProxy += "/32"
+			} else {
+				trustedProxy += "/128"
+			}
+		}
+		_, network, err := net.ParseCIDR(trustedProxy)
+		if err != nil {
+			return nil, fmt.Errorf("invalid trusted proxy %s: %s", trustedProxy, err.Error())
+		}
+		resolver.trustedProxies = append(resolver.trustedProxies, network)
+	}
+	return &resolver, nil
+}
+
+// trusted returns true if the given address is that of a trusted proxy
+func (r *Resolver) trusted(address string) bool {
+	ip := net.ParseIP(address)
+	if ip == nil {
+		return false
+	}
+	for _, network := range r.trustedProxies {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// FromRequest returns the ip address of the client which made the given request
+func (r *Resolver) FromRequest(request *http.Request) string {
+	remoteAddress, _, err := net.SplitHostPort(request.RemoteAddr)
+	if err != nil {
+		remoteAddress = request.RemoteAddr
+	}
+	if !r.trusted(remoteAddress) {
+		return remoteAddress
+	}
+
+	// each proxy appends the address from which it received the request
+	// to X-Forwarded-For, so the client is the last untrusted address
+	if forwardedFor := request.Header.Get("X-Forwarded-For"); forwardedFor != "" {
+		forwardedAddresses := strings.Split(forwardedFor, ",")
+		for i := len(forwardedAddresses) - 1; i >= 0; i-- {
+			forwardedAddress := strings.TrimSpace(forwardedAddresses[i])
+			if forwardedAddress == "" {
+				continue
+			}
+			if i == 0 || !r.trusted(forwardedAddress) {
+				return forwardedAddress
+			}
+		}
+	}
+
+	if realIP := strings.TrimSpace(request.Header.Get("X-Real-IP")); realIP != "" {
+		return realIP
+	}
+
+	return remoteAddress
+}
@@ -0,0 +1,76 @@
+package ipAddress
+
+import (
+	"github.com/stretchr/testify/assert"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestFromRequest(t *testing.T) {
+	resolver, err := NewResolver([]string{"10.0.0.1", "192.168.0.0/16"})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	tests := []struct {
+		name         string
+		remoteAddr   string
+		forwardedFor string
+		realIP       string
+		expected     string
+	}{
+		{
+			name:       "direct",
+			remoteAddr: "203.0.113.1:1234",
+			expected:   "203.0.113.1",
+		},
+		{
+			name:         "headers from untrusted client ignored",
+			remoteAddr:   "203.0.113.1:1234",
+			forwardedFor: "198.51.100.1",
+			realIP:       "198.51.100.2",
+			expected:     "203.0.113.1",
+		},
+		{
+			name:         "forwarded by trusted proxy",
+			remoteAddr:   "10.0.0.1:1234",
+			forwardedFor: "203.0.113.1",
+			expected:     "203.0.113.1",
+		},
+		{
+			name:         "spoofed address before client ignored",
+			remoteAddr:   "10.0.0.1:1234",
+			forwardedFor: "198.51.100.1, 203.0.113.1, 192.168.1.1",
+			expected:     "203.0.113.1",
+		},
+		{
+			name:       "real ip from trusted proxy",
+			remoteAddr: "192.168.1.1:1234",
+			realIP:     "203.0.113.1",
+			expected:   "203.0.113.1",
+		},
+		{
+			name:         "only trusted proxies",
+			remoteAddr:   "10.0.0.1:1234",
+			forwardedFor: "192.168.1.2, 192.168.1.1",
+			expected:     "192.168.1.2",
+		},
+	}
+
+	for _, test := range tests {
+		request := httptest.NewRequest("POST", "/", nil)
+		request.RemoteAddr = test.remoteAddr
+		if test.forwardedFor != "" {
+			request.Header.Set("X-Forwarded-For", test.forwardedFor)
+		}
+		if test.realIP != "" {
+			request.Header.Set("X-Real-IP", test.realIP)
+		}
+		assert.Equal(t, test.expected, resolver.FromRequest(request), test.name)
+	}
+}
+
+func TestNewResolverInvalidProxy(t *testing.T) {
+	_, err := NewResolver([]string{"not an address"})
+	assert.Error(t, err)
+}
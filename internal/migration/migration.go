@@ -0,0 +1,25 @@
+package migration
+
+import (
+	"gopkg.in/mgo.v2"
+)
+
+// Migration is a versioned change to the brain database.
+// Up applies the change and Down reverses it. When dryRun is
+// true neither should modify the database, but should rather
+// log what would have been done.
+type Migration interface {
+	// Version is the version of the database once the migration has been applied
+	Version() int
+	Description() string
+	Up(database *mgo.Database, dryRun bool) error
+	Down(database *mgo.Database, dryRun bool) error
+}
+
+// Record is stored in the migration collection for each migration
+// which has been applied to the database
+type Record struct {
+	Version     int    `json:"version" bson:"version"`
+	Description string `json:"description" bson:"description"`
+	AppliedTime int64  `json:"appliedTime" bson:"appliedTime"`
+}
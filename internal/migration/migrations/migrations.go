@@ -0,0 +1,22 @@
+package migrations
+
+import (
+	"github.com/iot-my-world/brain/internal/migration"
+	zx303Migration "github.com/iot-my-world/brain/internal/migration/v1_v2/zx303"
+	sigfoxDataCallbackMessageMigration "github.com/iot-my-world/brain/internal/migration/v2_v3/sigfoxDataCallbackMessage"
+	sigbugGPSReadingMigration "github.com/iot-my-world/brain/internal/migration/v3_v4/sigbugGPSReading"
+	deduplicatedSigfoxDataCallbackMessageMigration "github.com/iot-my-world/brain/internal/migration/v4_v5/sigfoxDataCallbackMessage"
+	zx303SigbugMigration "github.com/iot-my-world/brain/internal/migration/v5_v6/zx303Sigbug"
+)
+
+// All is every migration of the brain database.
+// New migrations should be added here.
+func All() []migration.Migration {
+	return []migration.Migration{
+		zx303Migration.New(),
+		sigfoxDataCallbackMessageMigration.New(),
+		sigbugGPSReadingMigration.New(),
+		deduplicatedSigfoxDataCallbackMessageMigration.New(),
+		zx303SigbugMigration.New(),
+	}
+}
@@ -0,0 +1,176 @@
+package migration
+
+import (
+	"errors"
+	"fmt"
+	"github.com/iot-my-world/brain/internal/log"
+	databaseCollection "github.com/iot-my-world/brain/pkg/database/collection"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"sort"
+	"time"
+)
+
+// Runner applies and reverses migrations in order of version,
+// keeping a record of those which have been applied in the
+// migration collection
+type Runner struct {
+	database   *mgo.Database
+	records    recordStore
+	migrations []Migration
+}
+
+// recordStore keeps the records of the migrations which have been applied
+type recordStore interface {
+	all() ([]Record, error)
+	insert(record Record) error
+	remove(version int) error
+}
+
+func NewRunner(database *mgo.Database, migrations []Migration) (*Runner, error) {
+	if err := database.C(databaseCollection.Migration).EnsureIndex(mgo.Index{
+		Key:    []string{"version"},
+		Unique: true,
+	}); err != nil {
+		return nil, errors.New("error ensuring migration collection index: " + err.Error())
+	}
+
+	return newRunner(database, &mongoRecordStore{collection: database.C(databaseCollection.Migration)}, migrations)
+}
+
+func newRunner(database *mgo.Database, records recordStore, migrations []Migration) (*Runner, error) {
+	// order the migrations by version and confirm that the versions are unique
+	orderedMigrations := make([]Migration, len(migrations))
+	copy(orderedMigrations, migrations)
+	sort.Slice(orderedMigrations, func(i, j int) bool {
+		return orderedMigrations[i].Version() < orderedMigrations[j].Version()
+	})
+	for i := range orderedMigrations {
+		if orderedMigrations[i].Version() < 1 {
+			return nil, errors.New(fmt.Sprintf("invalid migration version %d", orderedMigrations[i].Version()))
+		}
+		if i > 0 && orderedMigrations[i].Version() == orderedMigrations[i-1].Version() {
+			return nil, errors.New(fmt.Sprintf("duplicate migration version %d", orderedMigrations[i].Version()))
+		}
+	}
+
+	return &Runner{
+		database:   database,
+		records:    records,
+		migrations: orderedMigrations,
+	}, nil
+}
+
+// Applied returns the records of all migrations applied to the database, ordered by version
+func (r *Runner) Applied() ([]Record, error) {
+	appliedRecords, err := r.records.all()
+	if err != nil {
+		return nil, errors.New("error finding applied migrations: " + err.Error())
+	}
+	return appliedRecords, nil
+}
+
+// Up applies, in order, all of the migrations which have not yet been applied
+// up to and including the target version. A target version of 0 applies all.
+func (r *Runner) Up(targetVersion int, dryRun bool) error {
+	applied, err := r.appliedVersions()
+	if err != nil {
+		return err
+	}
+
+	for _, m := range r.migrations {
+		if targetVersion != 0 && m.Version() > targetVersion {
+			break
+		}
+		if applied[m.Version()] {
+			continue
+		}
+
+		log.Info(fmt.Sprintf("migrating up to version %d: %s", m.Version(), m.Description()))
+		if err := m.Up(r.database, dryRun); err != nil {
+			return errors.New(fmt.Sprintf("error migrating up to version %d: %s", m.Version(), err.Error()))
+		}
+		if dryRun {
+			continue
+		}
+
+		if err := r.records.insert(Record{
+			Version:     m.Version(),
+			Description: m.Description(),
+			AppliedTime: time.Now().UTC().Unix(),
+		}); err != nil {
+			return errors.New(fmt.Sprintf("error recording migration version %d: %s", m.Version(), err.Error()))
+		}
+	}
+
+	return nil
+}
+
+// Down reverses, in reverse order, all of the applied migrations
+// with a version greater than the target version
+func (r *Runner) Down(targetVersion int, dryRun bool) error {
+	applied, err := r.appliedVersions()
+	if err != nil {
+		return err
+	}
+
+	for i := len(r.migrations) - 1; i >= 0; i-- {
+		m := r.migrations[i]
+		if m.Version() <= targetVersion {
+			break
+		}
+		if !applied[m.Version()] {
+			continue
+		}
+
+		log.Info(fmt.Sprintf("migrating down from version %d: %s", m.Version(), m.Description()))
+		if err := m.Down(r.database, dryRun); err != nil {
+			return errors.New(fmt.Sprintf("error migrating down from version %d: %s", m.Version(), err.Error()))
+		}
+		if dryRun {
+			continue
+		}
+
+		if err := r.records.remove(m.Version()); err != nil {
+			return errors.New(fmt.Sprintf("error removing record of migration version %d: %s", m.Version(), err.Error()))
+		}
+	}
+
+	return nil
+}
+
+func (r *Runner) appliedVersions() (map[int]bool, error) {
+	appliedRecords, err := r.Applied()
+	if err != nil {
+		return nil, err
+	}
+	applied := make(map[int]bool)
+	for _, record := range appliedRecords {
+		applied[record.Version] = true
+	}
+	return applied, nil
+}
+
+// mongoRecordStore keeps the records of applied migrations in the migration collection
+type mongoRecordStore struct {
+	collection *mgo.Collection
+}
+
+func (s *mongoRecordStore) all() ([]Record, error) {
+	records := make([]Record, 0)
+	if err := s.collection.Find(nil).Sort("version").All(&records); err != nil {
+		return nil, err
+	}
+	if records == nil {
+		records = make([]Record, 0)
+	}
+	return records, nil
+}
+
+func (s *mongoRecordStore) insert(record Record) error {
+	return s.collection.Insert(record)
+}
+
+func (s *mongoRecordStore) remove(version int) error {
+	return s.collection.Remove(bson.M{"version": version})
+}
@@ -0,0 +1,254 @@
+package migration
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/mgo.v2"
+	"sort"
+	"strconv"
+	"testing"
+)
+
+// fakeMigration records, in the shared log, each step taken
+type fakeMigration struct {
+	version int
+	fail    bool
+	steps   *[]string
+}
+
+func (m *fakeMigration) Version() int {
+	return m.version
+}
+
+func (m *fakeMigration) Description() string {
+	return "fake"
+}
+
+func (m *fakeMigration) Up(database *mgo.Database, dryRun bool) error {
+	return m.step("up", dryRun)
+}
+
+func (m *fakeMigration) Down(database *mgo.Database, dryRun bool) error {
+	return m.step("down", dryRun)
+}
+
+func (m *fakeMigration) step(direction string, dryRun bool) error {
+	if dryRun {
+		direction = "dry run " + direction
+	}
+	*m.steps = append(*m.steps, direction+" "+strconv.Itoa(m.version))
+	if m.fail {
+		return errors.New("migration failed")
+	}
+	return nil
+}
+
+// fakeRecordStore keeps the records of applied migrations by version
+type fakeRecordStore struct {
+	records map[int]Record
+}
+
+func newFakeRecordStore(appliedVersions ...int) *fakeRecordStore {
+	s := &fakeRecordStore{records: make(map[int]Record)}
+	for _, version := range appliedVersions {
+		s.records[version] = Record{Version: version}
+	}
+	return s
+}
+
+func (s *fakeRecordStore) all() ([]Record, error) {
+	records := make([]Record, 0)
+	for _, record := range s.records {
+		records = append(records, record)
+	}
+	sort.Slice(records, func(i, j int) bool {
+		return records[i].Version < records[j].Version
+	})
+	return records, nil
+}
+
+func (s *fakeRecordStore) insert(record Record) error {
+	if _, found := s.records[record.Version]; found {
+		return errors.New("duplicate version")
+	}
+	s.records[record.Version] = record
+	return nil
+}
+
+func (s *fakeRecordStore) remove(version int) error {
+	delete(s.records, version)
+	return nil
+}
+
+func (s *fakeRecordStore) versions() []int {
+	records, _ := s.all()
+	versions := make([]int, 0)
+	for _, record := range records {
+		versions = append(versions, record.Version)
+	}
+	return versions
+}
+
+// newTestMigrations returns fake migrations of the given versions, failing those given
+func newTestMigrations(steps *[]string, versions []int, failingVersions ...int) []Migration {
+	migrations := make([]Migration, 0)
+	for _, version := range versions {
+		m := &fakeMigration{version: version, steps: steps}
+		for _, failingVersion := range failingVersions {
+			m.fail = m.fail || version == failingVersion
+		}
+		migrations = append(migrations, m)
+	}
+	return migrations
+}
+
+func TestNewRunnerInvalidVersions(t *testing.T) {
+	tests := []struct {
+		name     string
+		versions []int
+	}{
+		{
+			name:     "duplicate version",
+			versions: []int{2, 3, 2},
+		},
+		{
+			name:     "version less than 1",
+			versions: []int{0, 1},
+		},
+	}
+
+	for _, test := range tests {
+		steps := make([]string, 0)
+		_, err := newRunner(nil, newFakeRecordStore(), newTestMigrations(&steps, test.versions))
+		assert.Error(t, err, test.name)
+	}
+}
+
+func TestUp(t *testing.T) {
+	tests := []struct {
+		name             string
+		applied          []int
+		failing          []int
+		targetVersion    int
+		dryRun           bool
+		expectError      bool
+		expectedSteps    []string
+		expectedVersions []int
+	}{
+		{
+			name:             "all in order of version",
+			expectedSteps:    []string{"up 2", "up 3", "up 4", "up 5"},
+			expectedVersions: []int{2, 3, 4, 5},
+		},
+		{
+			name:             "only those not applied",
+			applied:          []int{2, 4},
+			expectedSteps:    []string{"up 3", "up 5"},
+			expectedVersions: []int{2, 3, 4, 5},
+		},
+		{
+			name:             "up to the target version",
+			targetVersion:    3,
+			expectedSteps:    []string{"up 2", "up 3"},
+			expectedVersions: []int{2, 3},
+		},
+		{
+			name:             "dry run",
+			applied:          []int{2},
+			dryRun:           true,
+			expectedSteps:    []string{"dry run up 3", "dry run up 4", "dry run up 5"},
+			expectedVersions: []int{2},
+		},
+		{
+			name:             "stops at a failure",
+			failing:          []int{4},
+			expectError:      true,
+			expectedSteps:    []string{"up 2", "up 3", "up 4"},
+			expectedVersions: []int{2, 3},
+		},
+	}
+
+	for _, test := range tests {
+		steps := make([]string, 0)
+		records := newFakeRecordStore(test.applied...)
+		runner, err := newRunner(nil, records, newTestMigrations(&steps, []int{4, 2, 5, 3}, test.failing...))
+		if !assert.NoError(t, err, test.name) {
+			continue
+		}
+
+		err = runner.Up(test.targetVersion, test.dryRun)
+		if test.expectError {
+			assert.Error(t, err, test.name)
+		} else {
+			assert.NoError(t, err, test.name)
+		}
+		assert.Equal(t, test.expectedSteps, steps, test.name)
+		assert.Equal(t, test.expectedVersions, records.versions(), test.name)
+	}
+}
+
+func TestDown(t *testing.T) {
+	tests := []struct {
+		name             string
+		applied          []int
+		failing          []int
+		targetVersion    int
+		dryRun           bool
+		expectError      bool
+		expectedSteps    []string
+		expectedVersions []int
+	}{
+		{
+			name:             "all in reverse order of version",
+			applied:          []int{2, 3, 4, 5},
+			expectedSteps:    []string{"down 5", "down 4", "down 3", "down 2"},
+			expectedVersions: []int{},
+		},
+		{
+			name:             "only those applied",
+			applied:          []int{2, 4},
+			expectedSteps:    []string{"down 4", "down 2"},
+			expectedVersions: []int{},
+		},
+		{
+			name:             "down to the target version",
+			applied:          []int{2, 3, 4, 5},
+			targetVersion:    3,
+			expectedSteps:    []string{"down 5", "down 4"},
+			expectedVersions: []int{2, 3},
+		},
+		{
+			name:             "dry run",
+			applied:          []int{2, 3},
+			dryRun:           true,
+			expectedSteps:    []string{"dry run down 3", "dry run down 2"},
+			expectedVersions: []int{2, 3},
+		},
+		{
+			name:             "stops at a failure",
+			applied:          []int{2, 3, 4, 5},
+			failing:          []int{4},
+			expectError:      true,
+			expectedSteps:    []string{"down 5", "down 4"},
+			expectedVersions: []int{2, 3, 4},
+		},
+	}
+
+	for _, test := range tests {
+		steps := make([]string, 0)
+		records := newFakeRecordStore(test.applied...)
+		runner, err := newRunner(nil, records, newTestMigrations(&steps, []int{4, 2, 5, 3}, test.failing...))
+		if !assert.NoError(t, err, test.name) {
+			continue
+		}
+
+		err = runner.Down(test.targetVersion, test.dryRun)
+		if test.expectError {
+			assert.Error(t, err, test.name)
+		} else {
+			assert.NoError(t, err, test.name)
+		}
+		assert.Equal(t, test.expectedSteps, steps, test.name)
+		assert.Equal(t, test.expectedVersions, records.versions(), test.name)
+	}
+}
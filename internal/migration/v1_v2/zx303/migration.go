@@ -2,55 +2,81 @@ package zx303
 
 import (
 	"errors"
-	"github.com/iot-my-world/brain/internal/migration/v1_v2/zx303/v1"
-	"github.com/iot-my-world/brain/internal/migration/v1_v2/zx303/v2"
+	"fmt"
+	"github.com/iot-my-world/brain/internal/log"
+	"github.com/iot-my-world/brain/internal/migration"
 	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
 )
 
 const zx303DeviceCollection = "zx303Device"
 
-func Migrate(database *mgo.Database) error {
+// zx303 devices gained login and heartbeat fields in v2
+var v2Fields = bson.M{
+	"loggedIn":               false,
+	"logInTimestamp":         int64(0),
+	"logOutTimestamp":        int64(0),
+	"lastHeartbeatTimestamp": int64(0),
+}
+
+type zx303Migration struct {
+}
+
+func New() migration.Migration {
+	return &zx303Migration{}
+}
+
+func (m *zx303Migration) Version() int {
+	return 2
+}
+
+func (m *zx303Migration) Description() string {
+	return "add login and heartbeat fields to zx303 devices"
+}
 
-	// get zx303 device collection
+func (m *zx303Migration) Up(database *mgo.Database, dryRun bool) error {
 	collection := database.C(zx303DeviceCollection)
 
-	// retrieve old devices
-	oldZX303s := make([]v1.ZX303, 0)
-	if err := collection.Find(nil).All(&oldZX303s); err != nil {
-		return errors.New("error finding all zx303s " + err.Error())
+	// only devices which have not yet been migrated
+	selector := bson.M{"loggedIn": bson.M{"$exists": false}}
+
+	if dryRun {
+		noDevices, err := collection.Find(selector).Count()
+		if err != nil {
+			return errors.New("error counting zx303s to migrate: " + err.Error())
+		}
+		log.Info(fmt.Sprintf("dry run: would add login and heartbeat fields to %d zx303 devices", noDevices))
+		return nil
 	}
-	if oldZX303s == nil {
-		oldZX303s = make([]v1.ZX303, 0)
+
+	if _, err := collection.UpdateAll(selector, bson.M{"$set": v2Fields}); err != nil {
+		return errors.New("error adding login and heartbeat fields to zx303s: " + err.Error())
 	}
 
-	// clear the collection
-	if _, err := collection.RemoveAll(nil); err != nil {
-		return errors.New("failed to remove all old zx303 devices: " + err.Error())
+	return nil
+}
+
+func (m *zx303Migration) Down(database *mgo.Database, dryRun bool) error {
+	collection := database.C(zx303DeviceCollection)
+
+	// only devices which have been migrated
+	selector := bson.M{"loggedIn": bson.M{"$exists": true}}
+
+	if dryRun {
+		noDevices, err := collection.Find(selector).Count()
+		if err != nil {
+			return errors.New("error counting zx303s to migrate: " + err.Error())
+		}
+		log.Info(fmt.Sprintf("dry run: would remove login and heartbeat fields from %d zx303 devices", noDevices))
+		return nil
 	}
 
-	// migrate devices
-	newZX303s := make([]interface{}, 0)
-	for _, oldZX303 := range oldZX303s {
-		newZX303s = append(newZX303s,
-			v2.ZX303{
-				Id:                     oldZX303.Id,
-				IMEI:                   oldZX303.IMEI,
-				SimCountryCode:         oldZX303.SimCountryCode,
-				SimNumber:              oldZX303.SimNumber,
-				OwnerPartyType:         oldZX303.OwnerPartyType,
-				OwnerId:                oldZX303.OwnerId,
-				AssignedPartyType:      oldZX303.AssignedPartyType,
-				AssignedId:             oldZX303.AssignedId,
-				LoggedIn:               false,
-				LogInTimestamp:         0,
-				LogOutTimestamp:        0,
-				LastHeartbeatTimestamp: 0,
-			})
+	unsetFields := bson.M{}
+	for field := range v2Fields {
+		unsetFields[field] = ""
 	}
-	insertBulkOperation := collection.Bulk()
-	insertBulkOperation.Insert(newZX303s...)
-	if _, err := insertBulkOperation.Run(); err != nil {
-		return errors.New("error inserting bulk new zx303 devices: " + err.Error())
+	if _, err := collection.UpdateAll(selector, bson.M{"$unset": unsetFields}); err != nil {
+		return errors.New("error removing login and heartbeat fields from zx303s: " + err.Error())
 	}
 
 	return nil
@@ -0,0 +1,135 @@
+package sigfoxDataCallbackMessage
+
+import (
+	"errors"
+	"fmt"
+	"github.com/iot-my-world/brain/internal/log"
+	"github.com/iot-my-world/brain/internal/migration"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+)
+
+const sigfoxBackendDataCallbackMessageCollection = "sigfoxBackendDataCallbackMessage"
+
+// the index which guards against repeated messages in v3
+var sequenceIndexKey = []string{"deviceId", "seqNumber", "time"}
+
+// sigfox data callback messages gained callback metadata in v3
+var v3Fields = []string{
+	"seqNumber",
+	"time",
+	"station",
+	"rssi",
+	"snr",
+	"duplicate",
+	"computedLocation",
+}
+
+type sigfoxDataCallbackMessageMigration struct {
+}
+
+func New() migration.Migration {
+	return &sigfoxDataCallbackMessageMigration{}
+}
+
+func (m *sigfoxDataCallbackMessageMigration) Version() int {
+	return 3
+}
+
+func (m *sigfoxDataCallbackMessageMigration) Description() string {
+	return "add sequence number and device time to sigfox data callback messages"
+}
+
+// Up gives messages received before v3 a sequence number of 0 and takes their
+// device time to be the time at which they were received. This keeps them
+// unique by device, sequence number and time so that the index can be built.
+func (m *sigfoxDataCallbackMessageMigration) Up(database *mgo.Database, dryRun bool) error {
+	collection := database.C(sigfoxBackendDataCallbackMessageCollection)
+
+	// only messages which have not yet been migrated
+	selector := bson.M{"time": bson.M{"$exists": false}}
+
+	if dryRun {
+		noMessages, err := collection.Find(selector).Count()
+		if err != nil {
+			return errors.New("error counting sigfox data callback messages to migrate: " + err.Error())
+		}
+		log.Info(fmt.Sprintf("dry run: would add sequence number and device time to %d sigfox data callback messages", noMessages))
+		return nil
+	}
+
+	var message struct {
+		Id        string `bson:"id"`
+		Timestamp int64  `bson:"timeStamp"`
+	}
+	iter := collection.Find(selector).Iter()
+	for iter.Next(&message) {
+		if err := collection.Update(
+			bson.M{"id": message.Id},
+			bson.M{"$set": bson.M{
+				"seqNumber": 0,
+				"time":      message.Timestamp,
+			}},
+		); err != nil {
+			return errors.New("error adding sequence number and device time to sigfox data callback message: " + err.Error())
+		}
+	}
+	if err := iter.Close(); err != nil {
+		return errors.New("error iterating over sigfox data callback messages: " + err.Error())
+	}
+
+	return nil
+}
+
+func (m *sigfoxDataCallbackMessageMigration) Down(database *mgo.Database, dryRun bool) error {
+	collection := database.C(sigfoxBackendDataCallbackMessageCollection)
+
+	// only messages which have been migrated
+	selector := bson.M{"time": bson.M{"$exists": true}}
+
+	if dryRun {
+		noMessages, err := collection.Find(selector).Count()
+		if err != nil {
+			return errors.New("error counting sigfox data callback messages to migrate: " + err.Error())
+		}
+		log.Info(fmt.Sprintf("dry run: would remove callback metadata from %d sigfox data callback messages", noMessages))
+		return nil
+	}
+
+	// the index cannot be kept once the fields which it is built on are removed
+	indexes, err := collection.Indexes()
+	if err != nil {
+		return errors.New("error listing sigfox data callback message indexes: " + err.Error())
+	}
+	for _, index := range indexes {
+		if !isSequenceIndex(index) {
+			continue
+		}
+		if err := collection.DropIndex(sequenceIndexKey...); err != nil {
+			return errors.New("error dropping sigfox data callback message sequence index: " + err.Error())
+		}
+		break
+	}
+
+	unsetFields := bson.M{}
+	for _, field := range v3Fields {
+		unsetFields[field] = ""
+	}
+	if _, err := collection.UpdateAll(selector, bson.M{"$unset": unsetFields}); err != nil {
+		return errors.New("error removing callback metadata from sigfox data callback messages: " + err.Error())
+	}
+
+	return nil
+}
+
+func isSequenceIndex(index mgo.Index) bool {
+	if len(index.Key) != len(sequenceIndexKey) {
+		return false
+	}
+	for keyIdx := range index.Key {
+		if index.Key[keyIdx] != sequenceIndexKey[keyIdx] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,117 @@
+package sigbugGPSReading
+
+import (
+	"errors"
+	"fmt"
+	"github.com/iot-my-world/brain/internal/log"
+	"github.com/iot-my-world/brain/internal/migration"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+)
+
+const sigbugGPSReadingCollection = "sigbugGPSReading"
+
+// the name of the 2dsphere index on the location of readings in v4
+const locationIndexName = "location_2dsphere"
+
+type sigbugGPSReadingMigration struct {
+}
+
+func New() migration.Migration {
+	return &sigbugGPSReadingMigration{}
+}
+
+func (m *sigbugGPSReadingMigration) Version() int {
+	return 4
+}
+
+func (m *sigbugGPSReadingMigration) Description() string {
+	return "add GeoJSON location to sigbug gps readings"
+}
+
+// Up gives readings recorded before v4 a GeoJSON location. Readings whose
+// latitude and longitude are not a valid position, or are both 0, are left
+// without one, as they are when recorded in v4.
+func (m *sigbugGPSReadingMigration) Up(database *mgo.Database, dryRun bool) error {
+	collection := database.C(sigbugGPSReadingCollection)
+
+	// only readings which have not yet been migrated
+	selector := bson.M{
+		"location":  bson.M{"$exists": false},
+		"latitude":  bson.M{"$gte": -90, "$lte": 90},
+		"longitude": bson.M{"$gte": -180, "$lte": 180},
+		// readings without a fix are given at 0, 0
+		"$nor": []bson.M{{"latitude": 0, "longitude": 0}},
+	}
+
+	if dryRun {
+		noReadings, err := collection.Find(selector).Count()
+		if err != nil {
+			return errors.New("error counting sigbug gps readings to migrate: " + err.Error())
+		}
+		log.Info(fmt.Sprintf("dry run: would add location to %d sigbug gps readings", noReadings))
+		return nil
+	}
+
+	var reading struct {
+		Id        string  `bson:"id"`
+		Latitude  float64 `bson:"latitude"`
+		Longitude float64 `bson:"longitude"`
+	}
+	iter := collection.Find(selector).Iter()
+	for iter.Next(&reading) {
+		if err := collection.Update(
+			bson.M{"id": reading.Id},
+			bson.M{"$set": bson.M{
+				"location": bson.M{
+					"type":        "Point",
+					"coordinates": []float64{reading.Longitude, reading.Latitude},
+				},
+			}},
+		); err != nil {
+			return errors.New("error adding location to sigbug gps reading: " + err.Error())
+		}
+	}
+	if err := iter.Close(); err != nil {
+		return errors.New("error iterating over sigbug gps readings: " + err.Error())
+	}
+
+	return nil
+}
+
+func (m *sigbugGPSReadingMigration) Down(database *mgo.Database, dryRun bool) error {
+	collection := database.C(sigbugGPSReadingCollection)
+
+	// only readings which have been migrated
+	selector := bson.M{"location": bson.M{"$exists": true}}
+
+	if dryRun {
+		noReadings, err := collection.Find(selector).Count()
+		if err != nil {
+			return errors.New("error counting sigbug gps readings to migrate: " + err.Error())
+		}
+		log.Info(fmt.Sprintf("dry run: would remove location from %d sigbug gps readings", noReadings))
+		return nil
+	}
+
+	// the index cannot be kept once the field which it is built on is removed
+	indexes, err := collection.Indexes()
+	if err != nil {
+		return errors.New("error listing sigbug gps reading indexes: " + err.Error())
+	}
+	for _, index := range indexes {
+		if index.Name != locationIndexName {
+			continue
+		}
+		if err := collection.DropIndexName(locationIndexName); err != nil {
+			return errors.New("error dropping sigbug gps reading location index: " + err.Error())
+		}
+		break
+	}
+
+	if _, err := collection.UpdateAll(selector, bson.M{"$unset": bson.M{"location": ""}}); err != nil {
+		return errors.New("error removing location from sigbug gps readings: " + err.Error())
+	}
+
+	return nil
+}
@@ -0,0 +1,156 @@
+package sigfoxDataCallbackMessage
+
+import (
+	"errors"
+	"fmt"
+	"github.com/iot-my-world/brain/internal/log"
+	"github.com/iot-my-world/brain/internal/migration"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+)
+
+const sigfoxBackendDataCallbackMessageCollection = "sigfoxBackendDataCallbackMessage"
+
+// the index which guarded against repeated messages in v3 and v4. it
+// took messages without sigfox metadata to be repeats of each other.
+var sequenceIndexKey = []string{"deviceId", "seqNumber", "time"}
+
+// the index which guards against repeated messages in v5
+var deduplicationIndex = mgo.Index{
+	Key:    []string{"deduplicationKey"},
+	Unique: true,
+	Sparse: true,
+}
+
+type sigfoxDataCallbackMessageMigration struct {
+}
+
+func New() migration.Migration {
+	return &sigfoxDataCallbackMessageMigration{}
+}
+
+func (m *sigfoxDataCallbackMessageMigration) Version() int {
+	return 5
+}
+
+func (m *sigfoxDataCallbackMessageMigration) Description() string {
+	return "identify repeated sigfox data callback messages by deduplication key"
+}
+
+// Up gives messages with a device time a deduplication key made up of their
+// device, sequence number and device time, as is given to messages received
+// in v5, and replaces the sequence index with an index on the key.
+func (m *sigfoxDataCallbackMessageMigration) Up(database *mgo.Database, dryRun bool) error {
+	collection := database.C(sigfoxBackendDataCallbackMessageCollection)
+
+	// only messages which can be deduplicated and have not yet been migrated
+	selector := bson.M{
+		"deduplicationKey": bson.M{"$exists": false},
+		"time":             bson.M{"$nin": []interface{}{nil, 0}},
+	}
+
+	if dryRun {
+		noMessages, err := collection.Find(selector).Count()
+		if err != nil {
+			return errors.New("error counting sigfox data callback messages to migrate: " + err.Error())
+		}
+		log.Info(fmt.Sprintf("dry run: would add deduplication key to %d sigfox data callback messages", noMessages))
+		return nil
+	}
+
+	if err := dropIndex(collection, sequenceIndexKey); err != nil {
+		return err
+	}
+
+	var message struct {
+		Id        string `bson:"id"`
+		DeviceId  string `bson:"deviceId"`
+		SeqNumber int    `bson:"seqNumber"`
+		Time      int64  `bson:"time"`
+	}
+	iter := collection.Find(selector).Iter()
+	for iter.Next(&message) {
+		if err := collection.Update(
+			bson.M{"id": message.Id},
+			bson.M{"$set": bson.M{
+				"deduplicationKey": fmt.Sprintf("%s:%d:%d", message.DeviceId, message.SeqNumber, message.Time),
+			}},
+		); err != nil {
+			return errors.New("error adding deduplication key to sigfox data callback message: " + err.Error())
+		}
+	}
+	if err := iter.Close(); err != nil {
+		return errors.New("error iterating over sigfox data callback messages: " + err.Error())
+	}
+
+	if err := collection.EnsureIndex(deduplicationIndex); err != nil {
+		return errors.New("error creating sigfox data callback message deduplication index: " + err.Error())
+	}
+
+	return nil
+}
+
+// Down removes deduplication keys and restores the sequence index. This fails if
+// messages without sigfox metadata were received from a device more than once.
+func (m *sigfoxDataCallbackMessageMigration) Down(database *mgo.Database, dryRun bool) error {
+	collection := database.C(sigfoxBackendDataCallbackMessageCollection)
+
+	// only messages which have been migrated
+	selector := bson.M{"deduplicationKey": bson.M{"$exists": true}}
+
+	if dryRun {
+		noMessages, err := collection.Find(selector).Count()
+		if err != nil {
+			return errors.New("error counting sigfox data callback messages to migrate: " + err.Error())
+		}
+		log.Info(fmt.Sprintf("dry run: would remove deduplication key from %d sigfox data callback messages", noMessages))
+		return nil
+	}
+
+	if err := dropIndex(collection, deduplicationIndex.Key); err != nil {
+		return err
+	}
+
+	if _, err := collection.UpdateAll(selector, bson.M{"$unset": bson.M{"deduplicationKey": ""}}); err != nil {
+		return errors.New("error removing deduplication key from sigfox data callback messages: " + err.Error())
+	}
+
+	if err := collection.EnsureIndex(mgo.Index{
+		Key:    sequenceIndexKey,
+		Unique: true,
+	}); err != nil {
+		return errors.New("error creating sigfox data callback message sequence index: " + err.Error())
+	}
+
+	return nil
+}
+
+// dropIndex drops the index with the given key if it exists
+func dropIndex(collection *mgo.Collection, key []string) error {
+	indexes, err := collection.Indexes()
+	if err != nil {
+		return errors.New("error listing sigfox data callback message indexes: " + err.Error())
+	}
+	for _, index := range indexes {
+		if !sameKey(index.Key, key) {
+			continue
+		}
+		if err := collection.DropIndex(key...); err != nil {
+			return errors.New("error dropping sigfox data callback message index: " + err.Error())
+		}
+		break
+	}
+	return nil
+}
+
+func sameKey(key1, key2 []string) bool {
+	if len(key1) != len(key2) {
+		return false
+	}
+	for keyIdx := range key1 {
+		if key1[keyIdx] != key2[keyIdx] {
+			return false
+		}
+	}
+	return true
+}
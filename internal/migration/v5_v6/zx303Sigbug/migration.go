@@ -0,0 +1,232 @@
+package zx303Sigbug
+
+import (
+	"errors"
+	"fmt"
+	"github.com/iot-my-world/brain/internal/log"
+	"github.com/iot-my-world/brain/internal/migration"
+	"github.com/iot-my-world/brain/pkg/party"
+	"github.com/iot-my-world/brain/pkg/search/identifier/id"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"math"
+	"regexp"
+)
+
+const zx303DeviceCollection = "zx303Device"
+const zx303GPSReadingCollection = "zx303GPSReading"
+const sigbugCollection = "sigbug"
+const sigbugGPSReadingCollection = "sigbugGPSReading"
+
+// zx303 readings did not come from a sigfox message, but sigbug readings
+// must have a unique message id. Migrated readings are given one made
+// from the id of the zx303 reading with this prefix.
+const messageIdPrefix = "zx303GPSReading:"
+
+// zx303 is a zx303 device as it is stored in v5
+type zx303 struct {
+	Id                string        `bson:"id"`
+	IMEI              string        `bson:"imei"`
+	OwnerPartyType    party.Type    `bson:"ownerPartyType"`
+	OwnerId           id.Identifier `bson:"ownerId"`
+	AssignedPartyType party.Type    `bson:"assignedPartyType"`
+	AssignedId        id.Identifier `bson:"assignedId"`
+}
+
+// zx303GPSReading is a zx303 gps reading as it is stored in v5
+type zx303GPSReading struct {
+	Id                string        `bson:"id"`
+	DeviceId          id.Identifier `bson:"deviceId"`
+	OwnerPartyType    party.Type    `bson:"ownerPartyType"`
+	OwnerId           id.Identifier `bson:"ownerId"`
+	AssignedPartyType party.Type    `bson:"assignedPartyType"`
+	AssignedId        id.Identifier `bson:"assignedId"`
+	TimeStamp         int64         `bson:"timeStamp"`
+	Latitude          float32       `bson:"latitude"`
+	Longitude         float32       `bson:"longitude"`
+}
+
+type zx303SigbugMigration struct {
+}
+
+func New() migration.Migration {
+	return &zx303SigbugMigration{}
+}
+
+func (m *zx303SigbugMigration) Version() int {
+	return 6
+}
+
+func (m *zx303SigbugMigration) Description() string {
+	return "migrate zx303 devices and their gps readings to sigbugs"
+}
+
+// Up gives each zx303 device a sigbug with the same id, identified on the
+// sigfox network by the IMEI of the zx303, and gives each of their gps readings
+// a sigbug gps reading. The zx303 devices and readings are left as they are.
+// Devices whose id or IMEI is already that of another sigbug are skipped,
+// together with their readings, as are readings which have already been migrated.
+func (m *zx303SigbugMigration) Up(database *mgo.Database, dryRun bool) error {
+	zx303s := database.C(zx303DeviceCollection)
+	zx303GPSReadings := database.C(zx303GPSReadingCollection)
+	sigbugs := database.C(sigbugCollection)
+	sigbugGPSReadings := database.C(sigbugGPSReadingCollection)
+
+	if dryRun {
+		noDevices, err := zx303s.Count()
+		if err != nil {
+			return errors.New("error counting zx303s to migrate: " + err.Error())
+		}
+		noReadings, err := zx303GPSReadings.Count()
+		if err != nil {
+			return errors.New("error counting zx303 gps readings to migrate: " + err.Error())
+		}
+		log.Info(fmt.Sprintf("dry run: would migrate up to %d zx303 devices and %d zx303 gps readings to sigbugs", noDevices, noReadings))
+		return nil
+	}
+
+	// the readings of skipped devices are not migrated as they would
+	// otherwise be given to a sigbug which is not the device
+	skippedDeviceIds := make(map[string]bool)
+
+	var device zx303
+	deviceIter := zx303s.Find(nil).Iter()
+	for deviceIter.Next(&device) {
+		noMigrated, err := sigbugs.Find(bson.M{"id": device.Id, "deviceId": device.IMEI}).Count()
+		if err != nil {
+			return errors.New("error finding sigbug of zx303: " + err.Error())
+		}
+		if noMigrated > 0 {
+			continue
+		}
+		noExisting, err := sigbugs.Find(bson.M{"$or": []bson.M{
+			{"id": device.Id},
+			{"deviceId": device.IMEI},
+		}}).Count()
+		if err != nil {
+			return errors.New("error finding sigbug of zx303: " + err.Error())
+		}
+		if noExisting > 0 {
+			log.Info(fmt.Sprintf("skipping zx303 %s, a sigbug with its id or imei already exists", device.Id))
+			skippedDeviceIds[device.Id] = true
+			continue
+		}
+		if err := sigbugs.Insert(bson.M{
+			"id":                device.Id,
+			"deviceId":          device.IMEI,
+			"ownerPartyType":    device.OwnerPartyType,
+			"ownerId":           device.OwnerId,
+			"assignedPartyType": device.AssignedPartyType,
+			"assignedId":        device.AssignedId,
+			// the device has not yet sent a sigfox message
+			"lastMessage": bson.M{
+				"id":        "",
+				"timeStamp": int64(0),
+				"deviceId":  device.IMEI,
+				"data":      make([]byte, 0),
+			},
+			"silenceThreshold": int64(0),
+			"offline":          false,
+		}); err != nil {
+			return errors.New("error inserting sigbug of zx303: " + err.Error())
+		}
+	}
+	if err := deviceIter.Close(); err != nil {
+		return errors.New("error iterating over zx303s: " + err.Error())
+	}
+
+	var reading zx303GPSReading
+	readingIter := zx303GPSReadings.Find(nil).Iter()
+	for readingIter.Next(&reading) {
+		if skippedDeviceIds[reading.DeviceId.Id] {
+			continue
+		}
+		sigbugGPSReading := bson.M{
+			"id":                reading.Id,
+			"deviceId":          reading.DeviceId,
+			"ownerPartyType":    reading.OwnerPartyType,
+			"ownerId":           reading.OwnerId,
+			"assignedPartyType": reading.AssignedPartyType,
+			"assignedId":        reading.AssignedId,
+			"messageId":         id.Identifier{Id: messageIdPrefix + reading.Id},
+			"timeStamp":         reading.TimeStamp,
+			"latitude":          reading.Latitude,
+			"longitude":         reading.Longitude,
+		}
+		if readingLocation := location(reading.Latitude, reading.Longitude); readingLocation != nil {
+			sigbugGPSReading["location"] = readingLocation
+		}
+
+		// the reading is only inserted if it has not already been migrated
+		if _, err := sigbugGPSReadings.Upsert(
+			bson.M{"messageId.id": messageIdPrefix + reading.Id},
+			bson.M{"$setOnInsert": sigbugGPSReading},
+		); err != nil {
+			return errors.New("error inserting sigbug gps reading of zx303 gps reading: " + err.Error())
+		}
+	}
+	if err := readingIter.Close(); err != nil {
+		return errors.New("error iterating over zx303 gps readings: " + err.Error())
+	}
+
+	return nil
+}
+
+// Down removes the sigbug gps readings migrated from zx303 gps readings
+// and the sigbugs which have both the id and the IMEI of a zx303 device
+func (m *zx303SigbugMigration) Down(database *mgo.Database, dryRun bool) error {
+	zx303s := database.C(zx303DeviceCollection)
+	sigbugs := database.C(sigbugCollection)
+	sigbugGPSReadings := database.C(sigbugGPSReadingCollection)
+
+	// only readings which have been migrated
+	readingSelector := bson.M{"messageId.id": bson.RegEx{Pattern: "^" + regexp.QuoteMeta(messageIdPrefix)}}
+
+	if dryRun {
+		noReadings, err := sigbugGPSReadings.Find(readingSelector).Count()
+		if err != nil {
+			return errors.New("error counting sigbug gps readings to migrate: " + err.Error())
+		}
+		noDevices, err := zx303s.Count()
+		if err != nil {
+			return errors.New("error counting zx303s to migrate: " + err.Error())
+		}
+		log.Info(fmt.Sprintf("dry run: would remove %d sigbug gps readings and the sigbugs of up to %d zx303 devices", noReadings, noDevices))
+		return nil
+	}
+
+	if _, err := sigbugGPSReadings.RemoveAll(readingSelector); err != nil {
+		return errors.New("error removing sigbug gps readings of zx303 gps readings: " + err.Error())
+	}
+
+	var device zx303
+	deviceIter := zx303s.Find(nil).Iter()
+	for deviceIter.Next(&device) {
+		if _, err := sigbugs.RemoveAll(bson.M{
+			"id":       device.Id,
+			"deviceId": device.IMEI,
+		}); err != nil {
+			return errors.New("error removing sigbug of zx303: " + err.Error())
+		}
+	}
+	if err := deviceIter.Close(); err != nil {
+		return errors.New("error iterating over zx303s: " + err.Error())
+	}
+
+	return nil
+}
+
+// location returns the GeoJSON location of a reading at the given latitude
+// and longitude, as it is given to sigbug gps readings. It is nil if they are
+// not a valid position or are both 0.
+func location(latitude, longitude float32) bson.M {
+	if math.IsNaN(float64(latitude)) || latitude < -90 || latitude > 90 ||
+		math.IsNaN(float64(longitude)) || longitude < -180 || longitude > 180 ||
+		(latitude == 0 && longitude == 0) {
+		return nil
+	}
+	return bson.M{
+		"type":        "Point",
+		"coordinates": []float64{float64(longitude), float64(latitude)},
+	}
+}
@@ -0,0 +1,67 @@
+package publicAddress
+
+import (
+	"github.com/stretchr/testify/assert"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestIsPublic(t *testing.T) {
+	tests := []struct {
+		address  string
+		expected bool
+	}{
+		{address: "203.0.113.1", expected: true},
+		{address: "8.8.8.8", expected: true},
+		{address: "2001:4860:4860::8888", expected: true},
+		{address: "127.0.0.1", expected: false},
+		{address: "10.1.2.3", expected: false},
+		{address: "172.16.0.1", expected: false},
+		{address: "192.168.1.1", expected: false},
+		{address: "169.254.169.254", expected: false},
+		{address: "0.0.0.0", expected: false},
+		{address: "::1", expected: false},
+		{address: "::ffff:127.0.0.1", expected: false},
+		{address: "fd00::1", expected: false},
+		{address: "fe80::1", expected: false},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.expected, IsPublic(net.ParseIP(test.address)), test.address)
+	}
+}
+
+func TestValidateURL(t *testing.T) {
+	tests := []struct {
+		url   string
+		valid bool
+	}{
+		{url: "https://203.0.113.1/hook", valid: true},
+		{url: "http://127.0.0.1:8080/hook", valid: false},
+		{url: "http://localhost/hook", valid: false},
+		{url: "http://169.254.169.254/latest/meta-data", valid: false},
+		{url: "http://[::1]/hook", valid: false},
+		{url: "ftp://203.0.113.1/hook", valid: false},
+		{url: "not a url", valid: false},
+	}
+
+	for _, test := range tests {
+		err := ValidateURL(test.url)
+		if test.valid {
+			assert.NoError(t, err, test.url)
+		} else {
+			assert.Error(t, err, test.url)
+		}
+	}
+}
+
+func TestNewHTTPClientRefusesNonPublicAddress(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	_, err := NewHTTPClient(time.Second).Get(server.URL)
+	assert.Error(t, err)
+}
@@ -0,0 +1,39 @@
+package encrypt
+
+import (
+	"crypto/rand"
+	"github.com/go-errors/errors"
+	"github.com/iot-my-world/brain/internal/log"
+	"io/ioutil"
+	"os"
+)
+
+// secretKeyLength is the length of an AES-256 key
+const secretKeyLength = 32
+
+// FetchSecretKey returns the symmetric key with which secrets are encrypted
+// at rest, generating and saving a new key if there is none in the given dir
+func FetchSecretKey(dir string) []byte {
+	secretKeyFilePath := dir + "secretKey"
+
+	secretKey, err := ioutil.ReadFile(secretKeyFilePath)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			log.Fatal(err)
+		}
+		log.Info("Secret Key not found at: '" + secretKeyFilePath + "' Generating a new Key.")
+		secretKey = make([]byte, secretKeyLength)
+		if _, err := rand.Read(secretKey); err != nil {
+			log.Fatal("Failed to generate secret key!", err)
+		}
+		if err := ioutil.WriteFile(secretKeyFilePath, secretKey, 0600); err != nil {
+			log.Fatal("Failed to save secret key to file!", err)
+		}
+	}
+
+	if len(secretKey) != secretKeyLength {
+		log.Fatal(errors.New("Secret key at: '" + secretKeyFilePath + "' is not 32 bytes long!"))
+	}
+
+	return secretKey
+}
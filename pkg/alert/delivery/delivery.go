@@ -0,0 +1,36 @@
+package delivery
+
+import (
+	"github.com/iot-my-world/brain/pkg/alert/rule"
+	"github.com/iot-my-world/brain/pkg/party"
+	"github.com/iot-my-world/brain/pkg/search/identifier/id"
+)
+
+type Status string
+
+const Pending Status = "Pending"
+const Delivered Status = "Delivered"
+const Failed Status = "Failed"
+
+// Delivery is a record of an attempt to deliver the notification of a
+// fired alert rule to one of the recipients of the rule
+type Delivery struct {
+	Id string `json:"id" bson:"id"`
+
+	// Owner Details, taken from the rule
+	OwnerPartyType party.Type    `json:"ownerPartyType" bson:"ownerPartyType"`
+	OwnerId        id.Identifier `json:"ownerId" bson:"ownerId"`
+
+	// Delivery Details
+	RuleId      id.Identifier    `json:"ruleId" bson:"ruleId"`
+	TriggerType rule.TriggerType `json:"triggerType" bson:"triggerType"`
+	DeviceId    id.Identifier    `json:"deviceId" bson:"deviceId"`
+	Recipient   rule.Recipient   `json:"recipient" bson:"recipient"`
+	Status      Status           `json:"status" bson:"status"`
+	Error       string           `json:"error" bson:"error"`
+	TimeStamp   int64            `json:"timeStamp" bson:"timeStamp"`
+}
+
+func (d *Delivery) SetId(id string) {
+	d.Id = id
+}
@@ -0,0 +1,101 @@
+package jsonRpc
+
+import (
+	"github.com/iot-my-world/brain/internal/log"
+	"github.com/iot-my-world/brain/pkg/alert/delivery"
+	alertDeliveryRecordHandler "github.com/iot-my-world/brain/pkg/alert/delivery/recordHandler"
+	jsonRpcServiceProvider "github.com/iot-my-world/brain/pkg/api/jsonRpc/service/provider"
+	"github.com/iot-my-world/brain/pkg/search/criterion"
+	wrappedCriterion "github.com/iot-my-world/brain/pkg/search/criterion/wrapped"
+	wrappedIdentifier "github.com/iot-my-world/brain/pkg/search/identifier/wrapped"
+	"github.com/iot-my-world/brain/pkg/search/query"
+	wrappedClaims "github.com/iot-my-world/brain/pkg/security/claims/wrapped"
+	"net/http"
+)
+
+type adaptor struct {
+	RecordHandler alertDeliveryRecordHandler.RecordHandler
+}
+
+func New(recordHandler alertDeliveryRecordHandler.RecordHandler) *adaptor {
+	return &adaptor{
+		RecordHandler: recordHandler,
+	}
+}
+
+func (a *adaptor) Name() jsonRpcServiceProvider.Name {
+	return jsonRpcServiceProvider.Name(alertDeliveryRecordHandler.ServiceProvider)
+}
+
+func (a *adaptor) MethodRequiresAuthorization(string) bool {
+	return true
+}
+
+type RetrieveRequest struct {
+	WrappedIdentifier wrappedIdentifier.Wrapped `json:"identifier"`
+}
+
+type RetrieveResponse struct {
+	Delivery delivery.Delivery `json:"delivery"`
+}
+
+func (a *adaptor) Retrieve(r *http.Request, request *RetrieveRequest, response *RetrieveResponse) error {
+	claims, err := wrappedClaims.UnwrapClaimsFromContext(r)
+	if err != nil {
+		log.Warn(err.Error())
+		return err
+	}
+
+	retrieveDeliveryResponse, err := a.RecordHandler.Retrieve(
+		&alertDeliveryRecordHandler.RetrieveRequest{
+			Claims:     claims,
+			Identifier: request.WrappedIdentifier.Identifier,
+		})
+	if err != nil {
+		return err
+	}
+
+	response.Delivery = retrieveDeliveryResponse.Delivery
+
+	return nil
+}
+
+type CollectRequest struct {
+	Criteria []wrappedCriterion.Wrapped `json:"criteria"`
+	Query    query.Query                `json:"query"`
+}
+
+type CollectResponse struct {
+	Records []delivery.Delivery `json:"records"`
+	Total   int                 `json:"total"`
+}
+
+func (a *adaptor) Collect(r *http.Request, request *CollectRequest, response *CollectResponse) error {
+	claims, err := wrappedClaims.UnwrapClaimsFromContext(r)
+	if err != nil {
+		log.Warn(err.Error())
+		return err
+	}
+
+	criteria := make([]criterion.Criterion, 0)
+	for criterionIdx := range request.Criteria {
+		if c, err := request.Criteria[criterionIdx].UnWrap(); err == nil {
+			criteria = append(criteria, c)
+		} else {
+			return err
+		}
+	}
+
+	collectDeliveryResponse, err := a.RecordHandler.Collect(&alertDeliveryRecordHandler.CollectRequest{
+		Claims:   claims,
+		Criteria: criteria,
+		Query:    request.Query,
+	})
+	if err != nil {
+		return err
+	}
+
+	response.Records = collectDeliveryResponse.Records
+	response.Total = collectDeliveryResponse.Total
+	return nil
+}
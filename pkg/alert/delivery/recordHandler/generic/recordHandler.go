@@ -0,0 +1,133 @@
+package alertDeliveryRecordHandler
+
+import (
+	brainException "github.com/iot-my-world/brain/internal/exception"
+	"github.com/iot-my-world/brain/pkg/alert/delivery"
+	alertDeliveryRecordHandler "github.com/iot-my-world/brain/pkg/alert/delivery/recordHandler"
+	alertDeliveryRecordHandlerException "github.com/iot-my-world/brain/pkg/alert/delivery/recordHandler/exception"
+	brainRecordHandler "github.com/iot-my-world/brain/pkg/recordHandler"
+	brainRecordHandlerException "github.com/iot-my-world/brain/pkg/recordHandler/exception"
+)
+
+type RecordHandler struct {
+	alertDeliveryRecordHandler brainRecordHandler.RecordHandler
+}
+
+func New(
+	brainDeliveryRecordHandler brainRecordHandler.RecordHandler,
+) alertDeliveryRecordHandler.RecordHandler {
+
+	return &RecordHandler{
+		alertDeliveryRecordHandler: brainDeliveryRecordHandler,
+	}
+}
+
+type CreateRequest struct {
+	Delivery delivery.Delivery
+}
+
+type CreateResponse struct {
+	Delivery delivery.Delivery
+}
+
+func (r *RecordHandler) ValidateCreateRequest(request *alertDeliveryRecordHandler.CreateRequest) error {
+	reasonsInvalid := make([]string, 0)
+
+	if len(reasonsInvalid) > 0 {
+		return brainException.RequestInvalid{Reasons: reasonsInvalid}
+	}
+
+	return nil
+}
+
+func (r *RecordHandler) Create(request *alertDeliveryRecordHandler.CreateRequest) (*alertDeliveryRecordHandler.CreateResponse, error) {
+	if err := r.ValidateCreateRequest(request); err != nil {
+		return nil, err
+	}
+
+	createResponse := brainRecordHandler.CreateResponse{}
+	if err := r.alertDeliveryRecordHandler.Create(&brainRecordHandler.CreateRequest{
+		Entity: &request.Delivery,
+	}, &createResponse); err != nil {
+		return nil, alertDeliveryRecordHandlerException.Create{Reasons: []string{err.Error()}}
+	}
+	createdDelivery, ok := createResponse.Entity.(*delivery.Delivery)
+	if !ok {
+		return nil, alertDeliveryRecordHandlerException.Create{Reasons: []string{"could not cast created entity to alert delivery"}}
+	}
+
+	return &alertDeliveryRecordHandler.CreateResponse{
+		Delivery: *createdDelivery,
+	}, nil
+}
+
+func (r *RecordHandler) Retrieve(request *alertDeliveryRecordHandler.RetrieveRequest) (*alertDeliveryRecordHandler.RetrieveResponse, error) {
+	retrievedDelivery := delivery.Delivery{}
+	retrieveResponse := brainRecordHandler.RetrieveResponse{
+		Entity: &retrievedDelivery,
+	}
+	if err := r.alertDeliveryRecordHandler.Retrieve(&brainRecordHandler.RetrieveRequest{
+		Claims:     request.Claims,
+		Identifier: request.Identifier,
+	}, &retrieveResponse); err != nil {
+		switch err.(type) {
+		case brainRecordHandlerException.NotFound:
+			return nil, alertDeliveryRecordHandlerException.NotFound{}
+		default:
+			return nil, err
+		}
+	}
+
+	return &alertDeliveryRecordHandler.RetrieveResponse{
+		Delivery: retrievedDelivery,
+	}, nil
+}
+
+func (r *RecordHandler) Update(request *alertDeliveryRecordHandler.UpdateRequest) (*alertDeliveryRecordHandler.UpdateResponse, error) {
+	updateResponse := brainRecordHandler.UpdateResponse{}
+	if err := r.alertDeliveryRecordHandler.Update(&brainRecordHandler.UpdateRequest{
+		Claims:     request.Claims,
+		Identifier: request.Identifier,
+		Entity:     &request.Delivery,
+	}, &updateResponse); err != nil {
+		return nil, alertDeliveryRecordHandlerException.Update{Reasons: []string{err.Error()}}
+	}
+
+	return &alertDeliveryRecordHandler.UpdateResponse{}, nil
+}
+
+func (r *RecordHandler) Delete(request *alertDeliveryRecordHandler.DeleteRequest) (*alertDeliveryRecordHandler.DeleteResponse, error) {
+	deleteResponse := brainRecordHandler.DeleteResponse{}
+	if err := r.alertDeliveryRecordHandler.Delete(&brainRecordHandler.DeleteRequest{
+		Claims:     request.Claims,
+		Identifier: request.Identifier,
+	}, &deleteResponse); err != nil {
+		return nil, alertDeliveryRecordHandlerException.Delete{Reasons: []string{err.Error()}}
+	}
+
+	return &alertDeliveryRecordHandler.DeleteResponse{}, nil
+}
+
+func (r *RecordHandler) Collect(request *alertDeliveryRecordHandler.CollectRequest) (*alertDeliveryRecordHandler.CollectResponse, error) {
+	var collectedDelivery []delivery.Delivery
+	collectResponse := brainRecordHandler.CollectResponse{
+		Records: &collectedDelivery,
+	}
+	err := r.alertDeliveryRecordHandler.Collect(&brainRecordHandler.CollectRequest{
+		Claims:   request.Claims,
+		Criteria: request.Criteria,
+		Query:    request.Query,
+	}, &collectResponse)
+	if err != nil {
+		return nil, alertDeliveryRecordHandlerException.Collect{Reasons: []string{err.Error()}}
+	}
+
+	if collectedDelivery == nil {
+		collectedDelivery = make([]delivery.Delivery, 0)
+	}
+
+	return &alertDeliveryRecordHandler.CollectResponse{
+		Records: collectedDelivery,
+		Total:   collectResponse.Total,
+	}, nil
+}
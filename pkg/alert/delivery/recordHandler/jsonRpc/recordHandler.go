@@ -0,0 +1,127 @@
+package jsonRpc
+
+import (
+	brainException "github.com/iot-my-world/brain/internal/exception"
+	"github.com/iot-my-world/brain/internal/log"
+	alertDeliveryRecordHandler "github.com/iot-my-world/brain/pkg/alert/delivery/recordHandler"
+	alertDeliveryRecordHandlerJsonRpcAdaptor "github.com/iot-my-world/brain/pkg/alert/delivery/recordHandler/adaptor/jsonRpc"
+	jsonRpcClient "github.com/iot-my-world/brain/pkg/api/jsonRpc/client"
+	wrappedCriterion "github.com/iot-my-world/brain/pkg/search/criterion/wrapped"
+	wrappedIdentifier "github.com/iot-my-world/brain/pkg/search/identifier/wrapped"
+)
+
+type recordHandler struct {
+	jsonRpcClient jsonRpcClient.Client
+}
+
+func New(
+	jsonRpcClient jsonRpcClient.Client,
+) alertDeliveryRecordHandler.RecordHandler {
+	return &recordHandler{
+		jsonRpcClient: jsonRpcClient,
+	}
+}
+
+func (r *recordHandler) Create(request *alertDeliveryRecordHandler.CreateRequest) (*alertDeliveryRecordHandler.CreateResponse, error) {
+	return nil, brainException.NotImplemented{}
+}
+
+func (r *recordHandler) ValidateRetrieveRequest(request *alertDeliveryRecordHandler.RetrieveRequest) error {
+	reasonsInvalid := make([]string, 0)
+	if request.Identifier == nil {
+		reasonsInvalid = append(reasonsInvalid, "identifier is nil")
+	}
+
+	if len(reasonsInvalid) > 0 {
+		return brainException.RequestInvalid{Reasons: reasonsInvalid}
+	}
+	return nil
+}
+
+func (r *recordHandler) Retrieve(request *alertDeliveryRecordHandler.RetrieveRequest) (*alertDeliveryRecordHandler.RetrieveResponse, error) {
+	if err := r.ValidateRetrieveRequest(request); err != nil {
+		log.Error(err.Error())
+		return nil, err
+	}
+
+	// wrap identifier
+	id, err := wrappedIdentifier.Wrap(request.Identifier)
+	if err != nil {
+		log.Error(err.Error())
+		return nil, err
+	}
+
+	alertDeliveryRetrieveResponse := alertDeliveryRecordHandlerJsonRpcAdaptor.RetrieveResponse{}
+	if err := r.jsonRpcClient.JsonRpcRequest(
+		alertDeliveryRecordHandler.RetrieveService,
+		alertDeliveryRecordHandlerJsonRpcAdaptor.RetrieveRequest{
+			WrappedIdentifier: *id,
+		},
+		&alertDeliveryRetrieveResponse,
+	); err != nil {
+		log.Error(err.Error())
+		return nil, err
+	}
+
+	return &alertDeliveryRecordHandler.RetrieveResponse{
+		Delivery: alertDeliveryRetrieveResponse.Delivery,
+	}, nil
+}
+func (r *recordHandler) Update(request *alertDeliveryRecordHandler.UpdateRequest) (*alertDeliveryRecordHandler.UpdateResponse, error) {
+	return nil, brainException.NotImplemented{}
+}
+func (r *recordHandler) Delete(request *alertDeliveryRecordHandler.DeleteRequest) (*alertDeliveryRecordHandler.DeleteResponse, error) {
+	return nil, brainException.NotImplemented{}
+}
+
+func (r *recordHandler) ValidateCollectRequest(request *alertDeliveryRecordHandler.CollectRequest) error {
+	reasonsInvalid := make([]string, 0)
+
+	if request.Criteria == nil {
+		reasonsInvalid = append(reasonsInvalid, "criteria is nil")
+	} else {
+		for _, crit := range request.Criteria {
+			if crit == nil {
+				reasonsInvalid = append(reasonsInvalid, "a criterion is nil")
+			}
+		}
+	}
+
+	if len(reasonsInvalid) > 0 {
+		return brainException.RequestInvalid{Reasons: reasonsInvalid}
+	}
+	return nil
+}
+
+func (r *recordHandler) Collect(request *alertDeliveryRecordHandler.CollectRequest) (*alertDeliveryRecordHandler.CollectResponse, error) {
+	if err := r.ValidateCollectRequest(request); err != nil {
+		return nil, err
+	}
+
+	// wrap criteria
+	criteria := make([]wrappedCriterion.Wrapped, 0)
+	for _, crit := range request.Criteria {
+		wrapped, err := wrappedCriterion.Wrap(crit)
+		if err != nil {
+			log.Error(err.Error())
+			return nil, err
+		}
+		criteria = append(criteria, *wrapped)
+	}
+
+	collectResponse := alertDeliveryRecordHandlerJsonRpcAdaptor.CollectResponse{}
+	if err := r.jsonRpcClient.JsonRpcRequest(
+		alertDeliveryRecordHandler.CollectService,
+		alertDeliveryRecordHandlerJsonRpcAdaptor.CollectRequest{
+			Criteria: criteria,
+			Query:    request.Query,
+		},
+		&collectResponse); err != nil {
+		return nil, err
+	}
+
+	return &alertDeliveryRecordHandler.CollectResponse{
+		Records: collectResponse.Records,
+		Total:   collectResponse.Total,
+	}, nil
+}
@@ -0,0 +1,37 @@
+package mongo
+
+import (
+	"github.com/iot-my-world/brain/pkg/alert/delivery"
+	alertDeliveryRecordHandler "github.com/iot-my-world/brain/pkg/alert/delivery/recordHandler"
+	alertDeliveryGenericRecordHandler "github.com/iot-my-world/brain/pkg/alert/delivery/recordHandler/generic"
+	brainMongoRecordHandler "github.com/iot-my-world/brain/pkg/recordHandler/mongo"
+	"github.com/iot-my-world/brain/pkg/security/claims"
+	"gopkg.in/mgo.v2"
+)
+
+func New(
+	mongoSession *mgo.Session,
+	databaseName string,
+	collectionName string,
+) alertDeliveryRecordHandler.RecordHandler {
+	mongoRecordHandler := brainMongoRecordHandler.New(
+		mongoSession,
+		databaseName,
+		collectionName,
+		[]mgo.Index{
+			{
+				Key:    []string{"id"},
+				Unique: true,
+			},
+			{
+				Key: []string{"ruleId.id", "deviceId.id", "timeStamp"},
+			},
+		},
+		delivery.IsValidIdentifier,
+		claims.ContextualiseFilter,
+	)
+
+	return alertDeliveryGenericRecordHandler.New(
+		mongoRecordHandler,
+	)
+}
@@ -0,0 +1,308 @@
+package basic
+
+import (
+	"errors"
+	brainException "github.com/iot-my-world/brain/internal/exception"
+	"github.com/iot-my-world/brain/internal/log"
+	"github.com/iot-my-world/brain/pkg/alert/delivery"
+	alertDeliveryRecordHandler "github.com/iot-my-world/brain/pkg/alert/delivery/recordHandler"
+	alertEngine "github.com/iot-my-world/brain/pkg/alert/engine"
+	alertEngineException "github.com/iot-my-world/brain/pkg/alert/engine/exception"
+	"github.com/iot-my-world/brain/pkg/alert/notification"
+	notificationChannel "github.com/iot-my-world/brain/pkg/alert/notification/channel"
+	"github.com/iot-my-world/brain/pkg/alert/rule"
+	alertRuleRecordHandler "github.com/iot-my-world/brain/pkg/alert/rule/recordHandler"
+	"github.com/iot-my-world/brain/pkg/search/criterion"
+	exactTextCriterion "github.com/iot-my-world/brain/pkg/search/criterion/exact/text"
+	dateRangeCriterion "github.com/iot-my-world/brain/pkg/search/criterion/range/date"
+	"github.com/iot-my-world/brain/pkg/search/identifier/id"
+	"github.com/iot-my-world/brain/pkg/search/query"
+	humanUserLoginClaims "github.com/iot-my-world/brain/pkg/security/claims/login/user/human"
+	"time"
+)
+
+// sendQueueSize is the number of notifications which can be waiting for a
+// worker. Deliveries for which there is no room are failed.
+const sendQueueSize = 1000
+
+// send is the sending of a notification to a single recipient
+type send struct {
+	delivery     delivery.Delivery
+	notification notification.Notification
+}
+
+// engine records the deliveries of fired rules and gives them to a pool
+// of workers which send the notifications, so that firing a rule does not
+// wait on mail servers or webhook urls
+type engine struct {
+	alertRuleRecordHandler     alertRuleRecordHandler.RecordHandler
+	alertDeliveryRecordHandler alertDeliveryRecordHandler.RecordHandler
+	channels                   map[rule.ChannelType]notificationChannel.Channel
+	sends                      chan *send
+	systemClaims               *humanUserLoginClaims.Login
+}
+
+func New(
+	alertRuleRecordHandler alertRuleRecordHandler.RecordHandler,
+	alertDeliveryRecordHandler alertDeliveryRecordHandler.RecordHandler,
+	channels map[rule.ChannelType]notificationChannel.Channel,
+	workers int,
+	systemClaims *humanUserLoginClaims.Login,
+) alertEngine.Engine {
+	e := &engine{
+		alertRuleRecordHandler:     alertRuleRecordHandler,
+		alertDeliveryRecordHandler: alertDeliveryRecordHandler,
+		channels:                   channels,
+		sends:                      make(chan *send, sendQueueSize),
+		systemClaims:               systemClaims,
+	}
+
+	// start workers
+	for i := 0; i < workers; i++ {
+		go e.work()
+	}
+
+	return e
+}
+
+func (e *engine) ValidateFireRequest(request *alertEngine.FireRequest) error {
+	reasonsInvalid := make([]string, 0)
+
+	if request.Claims == nil {
+		reasonsInvalid = append(reasonsInvalid, "claims are nil")
+	}
+
+	if request.TriggerType == "" {
+		reasonsInvalid = append(reasonsInvalid, "trigger type is blank")
+	}
+
+	if request.Device.Id == "" {
+		reasonsInvalid = append(reasonsInvalid, "device id is blank")
+	}
+
+	if len(reasonsInvalid) > 0 {
+		return brainException.RequestInvalid{Reasons: reasonsInvalid}
+	}
+	return nil
+}
+
+func (e *engine) Fire(request *alertEngine.FireRequest) (*alertEngine.FireResponse, error) {
+	if err := e.ValidateFireRequest(request); err != nil {
+		log.Error(err.Error())
+		return nil, err
+	}
+
+	deliveries := make([]delivery.Delivery, 0)
+
+	// rules may be set up by both the owner of the device and the party it is assigned to
+	partyIds := make([]id.Identifier, 0)
+	if request.Device.OwnerId.Id != "" {
+		partyIds = append(partyIds, request.Device.OwnerId)
+	}
+	if request.Device.AssignedId.Id != "" && request.Device.AssignedId.Id != request.Device.OwnerId.Id {
+		partyIds = append(partyIds, request.Device.AssignedId)
+	}
+
+	for _, partyId := range partyIds {
+		// collect the rules of the party with this trigger.
+		// system claims are used since the device need not be
+		// in the context of the party for which the rule is fired
+		ruleCollectResponse, err := e.alertRuleRecordHandler.Collect(&alertRuleRecordHandler.CollectRequest{
+			Claims: e.systemClaims,
+			Criteria: []criterion.Criterion{
+				exactTextCriterion.Criterion{
+					Field: "ownerId.id",
+					Text:  partyId.Id,
+				},
+				exactTextCriterion.Criterion{
+					Field: "trigger.type",
+					Text:  string(request.TriggerType),
+				},
+			},
+		})
+		if err != nil {
+			err = alertEngineException.RuleCollection{Reasons: []string{err.Error()}}
+			log.Error(err.Error())
+			return nil, err
+		}
+
+		for ruleIdx := range ruleCollectResponse.Records {
+			ruleToFire := &ruleCollectResponse.Records[ruleIdx]
+			if !ruleToFire.Enabled ||
+				!ruleToFire.AppliesToDevice(id.Identifier{Id: request.Device.Id}) ||
+				!triggered(ruleToFire, request) {
+				continue
+			}
+
+			// an error firing one rule does not prevent the others from
+			// firing, the error having been logged where it occurred
+			coolingDown, err := e.coolingDown(ruleToFire, request)
+			if err != nil || coolingDown {
+				continue
+			}
+
+			ruleDeliveries, err := e.deliver(ruleToFire, request)
+			if err != nil {
+				continue
+			}
+			deliveries = append(deliveries, ruleDeliveries...)
+		}
+	}
+
+	return &alertEngine.FireResponse{
+		Deliveries: deliveries,
+	}, nil
+}
+
+// triggered returns true if the conditions of the trigger of the
+// given rule are met by the given fire request
+func triggered(ruleToCheck *rule.Rule, request *alertEngine.FireRequest) bool {
+	switch ruleToCheck.Trigger.Type {
+	case rule.GeofenceExit:
+		if len(ruleToCheck.Trigger.GeofenceIds) == 0 {
+			return true
+		}
+		for _, geofenceId := range ruleToCheck.Trigger.GeofenceIds {
+			if geofenceId.Id == request.GeofenceId.Id {
+				return true
+			}
+		}
+		return false
+
+	case rule.SpeedOverThreshold:
+		return request.Speed > ruleToCheck.Trigger.SpeedThreshold
+
+	default:
+		return true
+	}
+}
+
+// coolingDown returns true if the given rule has fired for the device
+// of the given request within the cooldown period of the rule
+func (e *engine) coolingDown(ruleToCheck *rule.Rule, request *alertEngine.FireRequest) (bool, error) {
+	if ruleToCheck.Cooldown <= 0 {
+		return false, nil
+	}
+
+	deliveryCollectResponse, err := e.alertDeliveryRecordHandler.Collect(&alertDeliveryRecordHandler.CollectRequest{
+		Claims: e.systemClaims,
+		Criteria: []criterion.Criterion{
+			exactTextCriterion.Criterion{
+				Field: "ruleId.id",
+				Text:  ruleToCheck.Id,
+			},
+			exactTextCriterion.Criterion{
+				Field: "deviceId.id",
+				Text:  request.Device.Id,
+			},
+			dateRangeCriterion.Criterion{
+				Field: "timeStamp",
+				StartDate: dateRangeCriterion.RangeValue{
+					Date:      time.Now().UTC().Unix() - ruleToCheck.Cooldown,
+					Inclusive: true,
+				},
+				EndDate: dateRangeCriterion.RangeValue{
+					Ignore: true,
+				},
+			},
+		},
+		Query: query.Query{Limit: 1},
+	})
+	if err != nil {
+		err = alertEngineException.CooldownCheck{Reasons: []string{err.Error()}}
+		log.Error(err.Error())
+		return false, err
+	}
+
+	return len(deliveryCollectResponse.Records) > 0, nil
+}
+
+// deliver records a pending delivery of the notification of the given rule
+// to each of its recipients and queues the notifications to be sent. The
+// delivery is recorded before sending so that the cooldown of the rule
+// applies while the notification is being sent.
+func (e *engine) deliver(ruleToFire *rule.Rule, request *alertEngine.FireRequest) ([]delivery.Delivery, error) {
+	deliveries := make([]delivery.Delivery, 0)
+
+	ruleNotification := notification.Notification{
+		RuleId:         id.Identifier{Id: ruleToFire.Id},
+		RuleName:       ruleToFire.Name,
+		TriggerType:    ruleToFire.Trigger.Type,
+		DeviceId:       id.Identifier{Id: request.Device.Id},
+		SigfoxDeviceId: request.Device.DeviceId,
+		TimeStamp:      request.TimeStamp,
+		Latitude:       request.Latitude,
+		Longitude:      request.Longitude,
+		Speed:          request.Speed,
+		GeofenceId:     request.GeofenceId,
+	}
+
+	for _, recipient := range ruleToFire.Recipients {
+		deliveryToCreate := delivery.Delivery{
+			OwnerPartyType: ruleToFire.OwnerPartyType,
+			OwnerId:        ruleToFire.OwnerId,
+			RuleId:         id.Identifier{Id: ruleToFire.Id},
+			TriggerType:    ruleToFire.Trigger.Type,
+			DeviceId:       id.Identifier{Id: request.Device.Id},
+			Recipient:      recipient,
+			Status:         delivery.Pending,
+			TimeStamp:      time.Now().UTC().Unix(),
+		}
+		if _, found := e.channels[recipient.Channel]; !found {
+			deliveryToCreate.Status = delivery.Failed
+			deliveryToCreate.Error = "no channel for " + string(recipient.Channel)
+		}
+
+		createResponse, err := e.alertDeliveryRecordHandler.Create(&alertDeliveryRecordHandler.CreateRequest{
+			Delivery: deliveryToCreate,
+		})
+		if err != nil {
+			err = alertEngineException.DeliveryCreation{Reasons: []string{err.Error()}}
+			log.Error(err.Error())
+			return nil, err
+		}
+		deliveries = append(deliveries, createResponse.Delivery)
+
+		if createResponse.Delivery.Status != delivery.Pending {
+			continue
+		}
+		select {
+		case e.sends <- &send{
+			delivery:     createResponse.Delivery,
+			notification: ruleNotification,
+		}:
+		default:
+			e.complete(createResponse.Delivery, errors.New("send queue full"))
+		}
+	}
+
+	return deliveries, nil
+}
+
+// work sends notifications until the send channel is closed
+func (e *engine) work() {
+	for s := range e.sends {
+		_, err := e.channels[s.delivery.Recipient.Channel].Send(&notificationChannel.SendRequest{
+			Recipient:    s.delivery.Recipient,
+			Notification: s.notification,
+		})
+		e.complete(s.delivery, err)
+	}
+}
+
+// complete records the outcome of sending the notification of the given delivery
+func (e *engine) complete(completedDelivery delivery.Delivery, sendErr error) {
+	completedDelivery.Status = delivery.Delivered
+	if sendErr != nil {
+		completedDelivery.Status = delivery.Failed
+		completedDelivery.Error = sendErr.Error()
+	}
+
+	if _, err := e.alertDeliveryRecordHandler.Update(&alertDeliveryRecordHandler.UpdateRequest{
+		Claims:     e.systemClaims,
+		Identifier: id.Identifier{Id: completedDelivery.Id},
+		Delivery:   completedDelivery,
+	}); err != nil {
+		log.Error("error updating alert delivery: " + err.Error())
+	}
+}
@@ -0,0 +1,327 @@
+package basic
+
+import (
+	"errors"
+	"github.com/iot-my-world/brain/pkg/alert/delivery"
+	alertDeliveryRecordHandler "github.com/iot-my-world/brain/pkg/alert/delivery/recordHandler"
+	alertEngine "github.com/iot-my-world/brain/pkg/alert/engine"
+	notificationChannel "github.com/iot-my-world/brain/pkg/alert/notification/channel"
+	"github.com/iot-my-world/brain/pkg/alert/rule"
+	alertRuleRecordHandler "github.com/iot-my-world/brain/pkg/alert/rule/recordHandler"
+	"github.com/iot-my-world/brain/pkg/device/sigbug"
+	"github.com/iot-my-world/brain/pkg/party"
+	exactTextCriterion "github.com/iot-my-world/brain/pkg/search/criterion/exact/text"
+	dateRangeCriterion "github.com/iot-my-world/brain/pkg/search/criterion/range/date"
+	"github.com/iot-my-world/brain/pkg/search/identifier/id"
+	humanUserLoginClaims "github.com/iot-my-world/brain/pkg/security/claims/login/user/human"
+	"github.com/stretchr/testify/assert"
+	"strconv"
+	"testing"
+	"time"
+)
+
+type fakeRuleRecordHandler struct {
+	alertRuleRecordHandler.RecordHandler
+	rules []rule.Rule
+}
+
+func (r *fakeRuleRecordHandler) Collect(request *alertRuleRecordHandler.CollectRequest) (*alertRuleRecordHandler.CollectResponse, error) {
+	records := make([]rule.Rule, 0)
+nextRule:
+	for _, existingRule := range r.rules {
+		for _, c := range request.Criteria {
+			textCriterion := c.(exactTextCriterion.Criterion)
+			switch textCriterion.Field {
+			case "ownerId.id":
+				if existingRule.OwnerId.Id != textCriterion.Text {
+					continue nextRule
+				}
+			case "trigger.type":
+				if string(existingRule.Trigger.Type) != textCriterion.Text {
+					continue nextRule
+				}
+			default:
+				panic("unsupported field " + textCriterion.Field)
+			}
+		}
+		records = append(records, existingRule)
+	}
+	return &alertRuleRecordHandler.CollectResponse{Records: records, Total: len(records)}, nil
+}
+
+// fakeDeliveryRecordHandler stores deliveries in memory. Collecting
+// the deliveries of the rule with id broken fails.
+type fakeDeliveryRecordHandler struct {
+	alertDeliveryRecordHandler.RecordHandler
+	deliveries []delivery.Delivery
+}
+
+func (r *fakeDeliveryRecordHandler) Create(request *alertDeliveryRecordHandler.CreateRequest) (*alertDeliveryRecordHandler.CreateResponse, error) {
+	request.Delivery.Id = strconv.Itoa(len(r.deliveries) + 1)
+	r.deliveries = append(r.deliveries, request.Delivery)
+	return &alertDeliveryRecordHandler.CreateResponse{Delivery: request.Delivery}, nil
+}
+
+func (r *fakeDeliveryRecordHandler) Update(request *alertDeliveryRecordHandler.UpdateRequest) (*alertDeliveryRecordHandler.UpdateResponse, error) {
+	for i := range r.deliveries {
+		if r.deliveries[i].Id == request.Identifier.(id.Identifier).Id {
+			r.deliveries[i] = request.Delivery
+		}
+	}
+	return &alertDeliveryRecordHandler.UpdateResponse{}, nil
+}
+
+func (r *fakeDeliveryRecordHandler) Collect(request *alertDeliveryRecordHandler.CollectRequest) (*alertDeliveryRecordHandler.CollectResponse, error) {
+	records := make([]delivery.Delivery, 0)
+nextDelivery:
+	for _, existingDelivery := range r.deliveries {
+		for _, c := range request.Criteria {
+			switch typedCriterion := c.(type) {
+			case exactTextCriterion.Criterion:
+				switch typedCriterion.Field {
+				case "ruleId.id":
+					if typedCriterion.Text == "broken" {
+						return nil, errors.New("collection failed")
+					}
+					if existingDelivery.RuleId.Id != typedCriterion.Text {
+						continue nextDelivery
+					}
+				case "deviceId.id":
+					if existingDelivery.DeviceId.Id != typedCriterion.Text {
+						continue nextDelivery
+					}
+				default:
+					panic("unsupported field " + typedCriterion.Field)
+				}
+			case dateRangeCriterion.Criterion:
+				if existingDelivery.TimeStamp < typedCriterion.StartDate.Date {
+					continue nextDelivery
+				}
+			default:
+				panic("unsupported criterion")
+			}
+		}
+		records = append(records, existingDelivery)
+	}
+	return &alertDeliveryRecordHandler.CollectResponse{Records: records, Total: len(records)}, nil
+}
+
+func (r *fakeDeliveryRecordHandler) withRecipient(address string) delivery.Delivery {
+	for _, d := range r.deliveries {
+		if d.Recipient.Address == address {
+			return d
+		}
+	}
+	return delivery.Delivery{}
+}
+
+// fakeChannel fails to send to the address fail
+type fakeChannel struct {
+	notificationChannel.Channel
+	sentTo []string
+}
+
+func (c *fakeChannel) Send(request *notificationChannel.SendRequest) (*notificationChannel.SendResponse, error) {
+	c.sentTo = append(c.sentTo, request.Recipient.Address)
+	if request.Recipient.Address == "fail" {
+		return nil, errors.New("send failed")
+	}
+	return &notificationChannel.SendResponse{}, nil
+}
+
+var testClaims = &humanUserLoginClaims.Login{
+	UserId:    id.Identifier{Id: "root"},
+	PartyType: party.System,
+	PartyId:   id.Identifier{Id: "system"},
+}
+
+func TestTriggered(t *testing.T) {
+	tests := []struct {
+		name     string
+		trigger  rule.Trigger
+		request  alertEngine.FireRequest
+		expected bool
+	}{
+		{
+			name:     "any geofence",
+			trigger:  rule.Trigger{Type: rule.GeofenceExit},
+			request:  alertEngine.FireRequest{GeofenceId: id.Identifier{Id: "g1"}},
+			expected: true,
+		},
+		{
+			name:     "listed geofence",
+			trigger:  rule.Trigger{Type: rule.GeofenceExit, GeofenceIds: []id.Identifier{{Id: "g1"}, {Id: "g2"}}},
+			request:  alertEngine.FireRequest{GeofenceId: id.Identifier{Id: "g2"}},
+			expected: true,
+		},
+		{
+			name:     "unlisted geofence",
+			trigger:  rule.Trigger{Type: rule.GeofenceExit, GeofenceIds: []id.Identifier{{Id: "g1"}}},
+			request:  alertEngine.FireRequest{GeofenceId: id.Identifier{Id: "g2"}},
+			expected: false,
+		},
+		{
+			name:     "over speed threshold",
+			trigger:  rule.Trigger{Type: rule.SpeedOverThreshold, SpeedThreshold: 100},
+			request:  alertEngine.FireRequest{Speed: 100.5},
+			expected: true,
+		},
+		{
+			name:     "at speed threshold",
+			trigger:  rule.Trigger{Type: rule.SpeedOverThreshold, SpeedThreshold: 100},
+			request:  alertEngine.FireRequest{Speed: 100},
+			expected: false,
+		},
+		{
+			name:     "device offline",
+			trigger:  rule.Trigger{Type: rule.DeviceOffline},
+			expected: true,
+		},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.expected, triggered(&rule.Rule{Trigger: test.trigger}, &test.request), test.name)
+	}
+}
+
+func TestCoolingDown(t *testing.T) {
+	now := time.Now().UTC().Unix()
+	e := &engine{
+		alertDeliveryRecordHandler: &fakeDeliveryRecordHandler{deliveries: []delivery.Delivery{
+			{RuleId: id.Identifier{Id: "recent"}, DeviceId: id.Identifier{Id: "device"}, TimeStamp: now - 30},
+			{RuleId: id.Identifier{Id: "old"}, DeviceId: id.Identifier{Id: "device"}, TimeStamp: now - 120},
+			{RuleId: id.Identifier{Id: "otherDevice"}, DeviceId: id.Identifier{Id: "other"}, TimeStamp: now - 30},
+		}},
+		systemClaims: testClaims,
+	}
+
+	tests := []struct {
+		name     string
+		rule     rule.Rule
+		expected bool
+	}{
+		{name: "fired within cooldown", rule: rule.Rule{Id: "recent", Cooldown: 60}, expected: true},
+		{name: "fired before cooldown", rule: rule.Rule{Id: "old", Cooldown: 60}, expected: false},
+		{name: "fired for other device", rule: rule.Rule{Id: "otherDevice", Cooldown: 60}, expected: false},
+		{name: "no cooldown", rule: rule.Rule{Id: "recent"}, expected: false},
+	}
+
+	for _, test := range tests {
+		coolingDown, err := e.coolingDown(&test.rule, &alertEngine.FireRequest{
+			Device: sigbug.Sigbug{Id: "device"},
+		})
+		if assert.NoError(t, err, test.name) {
+			assert.Equal(t, test.expected, coolingDown, test.name)
+		}
+	}
+}
+
+func TestFire(t *testing.T) {
+	speedRule := func(ruleId, address string) rule.Rule {
+		return rule.Rule{
+			Id:      ruleId,
+			OwnerId: id.Identifier{Id: "company"},
+			Enabled: true,
+			Trigger: rule.Trigger{Type: rule.SpeedOverThreshold, SpeedThreshold: 50},
+			Recipients: []rule.Recipient{
+				{Channel: rule.WebhookChannel, Address: address},
+			},
+			Cooldown: 60,
+		}
+	}
+	disabledRule := speedRule("disabled", "disabled")
+	disabledRule.Enabled = false
+	noChannelRule := speedRule("noChannel", "noChannel")
+	noChannelRule.Recipients[0].Channel = rule.EmailChannel
+
+	deliveryRecordHandler := &fakeDeliveryRecordHandler{deliveries: []delivery.Delivery{
+		{
+			RuleId:    id.Identifier{Id: "coolingDown"},
+			DeviceId:  id.Identifier{Id: "device"},
+			Recipient: rule.Recipient{Address: "previous"},
+			Status:    delivery.Delivered,
+			TimeStamp: time.Now().UTC().Unix(),
+		},
+	}}
+	channel := &fakeChannel{}
+	e := &engine{
+		alertRuleRecordHandler: &fakeRuleRecordHandler{rules: []rule.Rule{
+			speedRule("broken", "broken"),
+			speedRule("ok", "ok"),
+			speedRule("fail", "fail"),
+			speedRule("coolingDown", "coolingDown"),
+			disabledRule,
+			noChannelRule,
+		}},
+		alertDeliveryRecordHandler: deliveryRecordHandler,
+		channels: map[rule.ChannelType]notificationChannel.Channel{
+			rule.WebhookChannel: channel,
+		},
+		sends:        make(chan *send, 10),
+		systemClaims: testClaims,
+	}
+
+	fireResponse, err := e.Fire(&alertEngine.FireRequest{
+		Claims:      testClaims,
+		TriggerType: rule.SpeedOverThreshold,
+		Device:      sigbug.Sigbug{Id: "device", OwnerId: id.Identifier{Id: "company"}},
+		Speed:       60,
+	})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	// the broken rule does not prevent the others from firing
+	if !assert.Len(t, fireResponse.Deliveries, 3) {
+		return
+	}
+	assert.Equal(t, delivery.Pending, deliveryRecordHandler.withRecipient("ok").Status)
+	assert.Equal(t, delivery.Pending, deliveryRecordHandler.withRecipient("fail").Status)
+	assert.Equal(t, delivery.Failed, deliveryRecordHandler.withRecipient("noChannel").Status)
+
+	// nothing has been sent yet
+	assert.Empty(t, channel.sentTo)
+	assert.Len(t, e.sends, 2)
+
+	// send the queued notifications
+	close(e.sends)
+	e.work()
+
+	assert.Equal(t, []string{"ok", "fail"}, channel.sentTo)
+	assert.Equal(t, delivery.Delivered, deliveryRecordHandler.withRecipient("ok").Status)
+	failedDelivery := deliveryRecordHandler.withRecipient("fail")
+	assert.Equal(t, delivery.Failed, failedDelivery.Status)
+	assert.Equal(t, "send failed", failedDelivery.Error)
+}
+
+func TestFireFailsDeliveriesWhenQueueFull(t *testing.T) {
+	deliveryRecordHandler := &fakeDeliveryRecordHandler{}
+	e := &engine{
+		alertRuleRecordHandler: &fakeRuleRecordHandler{rules: []rule.Rule{{
+			Id:         "offline",
+			OwnerId:    id.Identifier{Id: "company"},
+			Enabled:    true,
+			Trigger:    rule.Trigger{Type: rule.DeviceOffline},
+			Recipients: []rule.Recipient{{Channel: rule.WebhookChannel, Address: "ok"}},
+		}}},
+		alertDeliveryRecordHandler: deliveryRecordHandler,
+		channels: map[rule.ChannelType]notificationChannel.Channel{
+			rule.WebhookChannel: &fakeChannel{},
+		},
+		sends:        make(chan *send),
+		systemClaims: testClaims,
+	}
+
+	_, err := e.Fire(&alertEngine.FireRequest{
+		Claims:      testClaims,
+		TriggerType: rule.DeviceOffline,
+		Device:      sigbug.Sigbug{Id: "device", OwnerId: id.Identifier{Id: "company"}},
+	})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	failedDelivery := deliveryRecordHandler.withRecipient("ok")
+	assert.Equal(t, delivery.Failed, failedDelivery.Status)
+	assert.Equal(t, "send queue full", failedDelivery.Error)
+}
@@ -0,0 +1,36 @@
+package engine
+
+import (
+	"github.com/iot-my-world/brain/pkg/alert/delivery"
+	"github.com/iot-my-world/brain/pkg/alert/rule"
+	"github.com/iot-my-world/brain/pkg/device/sigbug"
+	"github.com/iot-my-world/brain/pkg/search/identifier/id"
+	"github.com/iot-my-world/brain/pkg/security/claims"
+)
+
+// Engine fires the alert rules which apply to something that
+// has happened on a device and delivers their notifications
+type Engine interface {
+	Fire(request *FireRequest) (*FireResponse, error)
+}
+
+type FireRequest struct {
+	Claims      claims.Claims
+	TriggerType rule.TriggerType
+	Device      sigbug.Sigbug
+	TimeStamp   int64
+
+	// Position of the device, if known
+	Latitude  float32
+	Longitude float32
+
+	// Speed of the device in km/h for SpeedOverThreshold triggers
+	Speed float32
+
+	// Geofence which was exited for GeofenceExit triggers
+	GeofenceId id.Identifier
+}
+
+type FireResponse struct {
+	Deliveries []delivery.Delivery
+}
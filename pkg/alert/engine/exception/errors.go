@@ -0,0 +1,27 @@
+package exception
+
+import "strings"
+
+type RuleCollection struct {
+	Reasons []string
+}
+
+func (e RuleCollection) Error() string {
+	return "error collecting alert rules: " + strings.Join(e.Reasons, "; ")
+}
+
+type CooldownCheck struct {
+	Reasons []string
+}
+
+func (e CooldownCheck) Error() string {
+	return "error checking alert rule cooldown: " + strings.Join(e.Reasons, "; ")
+}
+
+type DeliveryCreation struct {
+	Reasons []string
+}
+
+func (e DeliveryCreation) Error() string {
+	return "error creating alert delivery: " + strings.Join(e.Reasons, "; ")
+}
@@ -0,0 +1,20 @@
+package channel
+
+import (
+	"github.com/iot-my-world/brain/pkg/alert/notification"
+	"github.com/iot-my-world/brain/pkg/alert/rule"
+)
+
+// Channel delivers notifications to the recipients of alert rules.
+// There is an implementation of Channel for each rule.ChannelType.
+type Channel interface {
+	Send(request *SendRequest) (*SendResponse, error)
+}
+
+type SendRequest struct {
+	Recipient    rule.Recipient
+	Notification notification.Notification
+}
+
+type SendResponse struct {
+}
@@ -0,0 +1,69 @@
+package email
+
+import (
+	brainException "github.com/iot-my-world/brain/internal/exception"
+	"github.com/iot-my-world/brain/internal/log"
+	notificationChannel "github.com/iot-my-world/brain/pkg/alert/notification/channel"
+	notificationChannelException "github.com/iot-my-world/brain/pkg/alert/notification/channel/exception"
+	emailGenerator "github.com/iot-my-world/brain/pkg/communication/email/generator"
+	alertEmailGenerator "github.com/iot-my-world/brain/pkg/communication/email/generator/alert"
+	"github.com/iot-my-world/brain/pkg/communication/email/mailer"
+)
+
+// channel delivers notifications by email
+type channel struct {
+	mailer              mailer.Mailer
+	alertEmailGenerator emailGenerator.Generator
+}
+
+func New(
+	mailer mailer.Mailer,
+	alertEmailGenerator emailGenerator.Generator,
+) notificationChannel.Channel {
+	return &channel{
+		mailer:              mailer,
+		alertEmailGenerator: alertEmailGenerator,
+	}
+}
+
+func (c *channel) ValidateSendRequest(request *notificationChannel.SendRequest) error {
+	reasonsInvalid := make([]string, 0)
+
+	if request.Recipient.Address == "" {
+		reasonsInvalid = append(reasonsInvalid, "recipient address is blank")
+	}
+
+	if len(reasonsInvalid) > 0 {
+		return brainException.RequestInvalid{Reasons: reasonsInvalid}
+	}
+	return nil
+}
+
+func (c *channel) Send(request *notificationChannel.SendRequest) (*notificationChannel.SendResponse, error) {
+	if err := c.ValidateSendRequest(request); err != nil {
+		log.Error(err.Error())
+		return nil, err
+	}
+
+	generateResponse, err := c.alertEmailGenerator.Generate(&emailGenerator.GenerateRequest{
+		Data: alertEmailGenerator.Data{
+			Recipient:    request.Recipient,
+			Notification: request.Notification,
+		},
+	})
+	if err != nil {
+		err = notificationChannelException.EmailGeneration{Reasons: []string{err.Error()}}
+		log.Error(err.Error())
+		return nil, err
+	}
+
+	if _, err := c.mailer.Send(&mailer.SendRequest{
+		Email: generateResponse.Email,
+	}); err != nil {
+		err = notificationChannelException.EmailSending{Reasons: []string{err.Error()}}
+		log.Error(err.Error())
+		return nil, err
+	}
+
+	return &notificationChannel.SendResponse{}, nil
+}
@@ -0,0 +1,35 @@
+package exception
+
+import "strings"
+
+type EmailGeneration struct {
+	Reasons []string
+}
+
+func (e EmailGeneration) Error() string {
+	return "error generating alert email: " + strings.Join(e.Reasons, "; ")
+}
+
+type EmailSending struct {
+	Reasons []string
+}
+
+func (e EmailSending) Error() string {
+	return "error sending alert email: " + strings.Join(e.Reasons, "; ")
+}
+
+type WebhookRequest struct {
+	Reasons []string
+}
+
+func (e WebhookRequest) Error() string {
+	return "error making alert webhook request: " + strings.Join(e.Reasons, "; ")
+}
+
+type WebhookResponse struct {
+	Reasons []string
+}
+
+func (e WebhookResponse) Error() string {
+	return "unsuccessful alert webhook response: " + strings.Join(e.Reasons, "; ")
+}
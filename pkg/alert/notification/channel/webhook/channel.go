@@ -0,0 +1,89 @@
+package webhook
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	brainException "github.com/iot-my-world/brain/internal/exception"
+	"github.com/iot-my-world/brain/internal/log"
+	"github.com/iot-my-world/brain/internal/publicAddress"
+	notificationChannel "github.com/iot-my-world/brain/pkg/alert/notification/channel"
+	notificationChannelException "github.com/iot-my-world/brain/pkg/alert/notification/channel/exception"
+	webhookPublisher "github.com/iot-my-world/brain/pkg/webhook/publisher"
+	"net/http"
+	"time"
+)
+
+// channel delivers notifications by posting them as json to the url given
+// as the address of the recipient, signed with the secret of the recipient
+// as webhook deliveries are. Urls which lead to non public addresses
+// are refused, both before sending and when connecting.
+type channel struct {
+	httpClient *http.Client
+}
+
+func New(
+	timeout time.Duration,
+) notificationChannel.Channel {
+	return &channel{
+		httpClient: publicAddress.NewHTTPClient(timeout),
+	}
+}
+
+func (c *channel) ValidateSendRequest(request *notificationChannel.SendRequest) error {
+	reasonsInvalid := make([]string, 0)
+
+	if request.Recipient.Address == "" {
+		reasonsInvalid = append(reasonsInvalid, "recipient address is blank")
+	} else if err := publicAddress.ValidateURL(request.Recipient.Address); err != nil {
+		reasonsInvalid = append(reasonsInvalid, "recipient address invalid: "+err.Error())
+	}
+
+	if request.Recipient.Secret == "" {
+		reasonsInvalid = append(reasonsInvalid, "recipient secret is blank")
+	}
+
+	if len(reasonsInvalid) > 0 {
+		return brainException.RequestInvalid{Reasons: reasonsInvalid}
+	}
+	return nil
+}
+
+func (c *channel) Send(request *notificationChannel.SendRequest) (*notificationChannel.SendResponse, error) {
+	if err := c.ValidateSendRequest(request); err != nil {
+		log.Error(err.Error())
+		return nil, err
+	}
+
+	body, err := json.Marshal(request.Notification)
+	if err != nil {
+		err = notificationChannelException.WebhookRequest{Reasons: []string{"marshalling notification", err.Error()}}
+		log.Error(err.Error())
+		return nil, err
+	}
+
+	httpRequest, err := http.NewRequest(http.MethodPost, request.Recipient.Address, bytes.NewReader(body))
+	if err != nil {
+		err = notificationChannelException.WebhookRequest{Reasons: []string{"creating request", err.Error()}}
+		log.Error(err.Error())
+		return nil, err
+	}
+	httpRequest.Header.Set("Content-Type", "application/json")
+	httpRequest.Header.Set(webhookPublisher.SignatureHeader, webhookPublisher.Sign(request.Recipient.Secret, body))
+
+	response, err := c.httpClient.Do(httpRequest)
+	if err != nil {
+		err = notificationChannelException.WebhookRequest{Reasons: []string{err.Error()}}
+		log.Error(err.Error())
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		err = notificationChannelException.WebhookResponse{Reasons: []string{fmt.Sprintf("status %d", response.StatusCode)}}
+		log.Error(err.Error())
+		return nil, err
+	}
+
+	return &notificationChannel.SendResponse{}, nil
+}
@@ -0,0 +1,48 @@
+package notification
+
+import (
+	"fmt"
+	"github.com/iot-my-world/brain/pkg/alert/rule"
+	"github.com/iot-my-world/brain/pkg/search/identifier/id"
+	"time"
+)
+
+// Notification describes the firing of an alert rule and is what
+// is delivered to each of the recipients of the rule
+type Notification struct {
+	RuleId      id.Identifier    `json:"ruleId"`
+	RuleName    string           `json:"ruleName"`
+	TriggerType rule.TriggerType `json:"triggerType"`
+
+	// Device Details
+	DeviceId       id.Identifier `json:"deviceId"`
+	SigfoxDeviceId string        `json:"sigfoxDeviceId"`
+
+	// Trigger Details
+	TimeStamp  int64         `json:"timeStamp"`
+	Latitude   float32       `json:"latitude"`
+	Longitude  float32       `json:"longitude"`
+	Speed      float32       `json:"speed"`
+	GeofenceId id.Identifier `json:"geofenceId"`
+}
+
+// Time returns the time at which the rule was triggered formatted for display
+func (n Notification) Time() string {
+	return time.Unix(n.TimeStamp, 0).UTC().Format(time.RFC1123)
+}
+
+// Description returns a human readable description of what triggered the rule
+func (n Notification) Description() string {
+	switch n.TriggerType {
+	case rule.GeofenceExit:
+		return fmt.Sprintf("device %s left a geofence at %f, %f", n.SigfoxDeviceId, n.Latitude, n.Longitude)
+	case rule.DeviceOffline:
+		return fmt.Sprintf("device %s has gone offline", n.SigfoxDeviceId)
+	case rule.NoGPSFix:
+		return fmt.Sprintf("device %s could not get a gps fix", n.SigfoxDeviceId)
+	case rule.SpeedOverThreshold:
+		return fmt.Sprintf("device %s was travelling at %.1f km/h at %f, %f", n.SigfoxDeviceId, n.Speed, n.Latitude, n.Longitude)
+	default:
+		return fmt.Sprintf("device %s triggered %s", n.SigfoxDeviceId, n.TriggerType)
+	}
+}
@@ -0,0 +1,84 @@
+package jsonRpc
+
+import (
+	"github.com/iot-my-world/brain/internal/log"
+	"github.com/iot-my-world/brain/pkg/alert/rule"
+	"github.com/iot-my-world/brain/pkg/alert/rule/administrator"
+	jsonRpcServiceProvider "github.com/iot-my-world/brain/pkg/api/jsonRpc/service/provider"
+	wrappedClaims "github.com/iot-my-world/brain/pkg/security/claims/wrapped"
+	"net/http"
+)
+
+type adaptor struct {
+	administrator administrator.Administrator
+}
+
+func New(administrator administrator.Administrator) *adaptor {
+	return &adaptor{
+		administrator: administrator,
+	}
+}
+
+func (a *adaptor) Name() jsonRpcServiceProvider.Name {
+	return jsonRpcServiceProvider.Name(administrator.ServiceProvider)
+}
+
+func (a *adaptor) MethodRequiresAuthorization(string) bool {
+	return true
+}
+
+type CreateRequest struct {
+	Rule rule.Rule `json:"rule"`
+}
+
+type CreateResponse struct {
+	Rule rule.Rule `json:"rule"`
+}
+
+func (a *adaptor) Create(r *http.Request, request *CreateRequest, response *CreateResponse) error {
+	claims, err := wrappedClaims.UnwrapClaimsFromContext(r)
+	if err != nil {
+		log.Warn(err.Error())
+		return err
+	}
+
+	createResponse, err := a.administrator.Create(&administrator.CreateRequest{
+		Claims: claims,
+		Rule:   request.Rule,
+	})
+	if err != nil {
+		return err
+	}
+
+	response.Rule = createResponse.Rule
+
+	return nil
+}
+
+type UpdateAllowedFieldsRequest struct {
+	Rule rule.Rule `json:"rule"`
+}
+
+type UpdateAllowedFieldsResponse struct {
+	Rule rule.Rule `json:"rule"`
+}
+
+func (a *adaptor) UpdateAllowedFields(r *http.Request, request *UpdateAllowedFieldsRequest, response *UpdateAllowedFieldsResponse) error {
+	claims, err := wrappedClaims.UnwrapClaimsFromContext(r)
+	if err != nil {
+		log.Warn(err.Error())
+		return err
+	}
+
+	updateAllowedFieldsResponse, err := a.administrator.UpdateAllowedFields(&administrator.UpdateAllowedFieldsRequest{
+		Claims: claims,
+		Rule:   request.Rule,
+	})
+	if err != nil {
+		return err
+	}
+
+	response.Rule = updateAllowedFieldsResponse.Rule
+
+	return nil
+}
@@ -0,0 +1,53 @@
+package administrator
+
+import (
+	"github.com/iot-my-world/brain/pkg/alert/rule"
+	"github.com/iot-my-world/brain/pkg/security/claims"
+	"github.com/iot-my-world/brain/pkg/security/permission/api"
+)
+
+type Administrator interface {
+	Create(request *CreateRequest) (*CreateResponse, error)
+	UpdateAllowedFields(request *UpdateAllowedFieldsRequest) (*UpdateAllowedFieldsResponse, error)
+}
+
+const ServiceProvider = "AlertRule-Administrator"
+const UpdateAllowedFieldsService = ServiceProvider + ".UpdateAllowedFields"
+const CreateService = ServiceProvider + ".Create"
+
+var SystemUserPermissions = []api.Permission{
+	CreateService,
+	UpdateAllowedFieldsService,
+}
+
+var CompanyAdminUserPermissions = []api.Permission{
+	CreateService,
+	UpdateAllowedFieldsService,
+}
+
+var CompanyUserPermissions = make([]api.Permission, 0)
+
+var ClientAdminUserPermissions = []api.Permission{
+	CreateService,
+	UpdateAllowedFieldsService,
+}
+
+var ClientUserPermissions = make([]api.Permission, 0)
+
+type CreateRequest struct {
+	Claims claims.Claims
+	Rule   rule.Rule
+}
+
+type CreateResponse struct {
+	Rule rule.Rule
+}
+
+type UpdateAllowedFieldsRequest struct {
+	Claims claims.Claims
+	Rule   rule.Rule
+}
+
+type UpdateAllowedFieldsResponse struct {
+	Rule rule.Rule
+}
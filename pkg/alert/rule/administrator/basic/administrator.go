@@ -0,0 +1,189 @@
+package basic
+
+import (
+	"fmt"
+	brainException "github.com/iot-my-world/brain/internal/exception"
+	"github.com/iot-my-world/brain/internal/log"
+	"github.com/iot-my-world/brain/pkg/alert/rule"
+	"github.com/iot-my-world/brain/pkg/alert/rule/action"
+	alertRuleAdministrator "github.com/iot-my-world/brain/pkg/alert/rule/administrator"
+	"github.com/iot-my-world/brain/pkg/alert/rule/administrator/exception"
+	"github.com/iot-my-world/brain/pkg/alert/rule/recordHandler"
+	"github.com/iot-my-world/brain/pkg/alert/rule/validator"
+	"github.com/iot-my-world/brain/pkg/search/identifier/id"
+	apiUserPasswordGenerator "github.com/iot-my-world/brain/pkg/user/api/password/generator"
+)
+
+type administrator struct {
+	alertRuleValidator     validator.Validator
+	alertRuleRecordHandler recordHandler.RecordHandler
+	secretGenerator        apiUserPasswordGenerator.Generator
+}
+
+func New(
+	alertRuleValidator validator.Validator,
+	alertRuleRecordHandler recordHandler.RecordHandler,
+	secretGenerator apiUserPasswordGenerator.Generator,
+) alertRuleAdministrator.Administrator {
+	return &administrator{
+		alertRuleValidator:     alertRuleValidator,
+		alertRuleRecordHandler: alertRuleRecordHandler,
+		secretGenerator:        secretGenerator,
+	}
+}
+
+func (a *administrator) ValidateCreateRequest(request *alertRuleAdministrator.CreateRequest) error {
+	reasonsInvalid := make([]string, 0)
+
+	if request.Claims == nil {
+		reasonsInvalid = append(reasonsInvalid, "claims are nil")
+	} else {
+		alertRuleValidateResponse, err := a.alertRuleValidator.Validate(&validator.ValidateRequest{
+			Claims: request.Claims,
+			Rule:   request.Rule,
+			Action: action.Create,
+		})
+		if err != nil {
+			reasonsInvalid = append(reasonsInvalid, "error validating alert rule: "+err.Error())
+		} else {
+			if len(alertRuleValidateResponse.ReasonsInvalid) > 0 {
+				for _, reason := range alertRuleValidateResponse.ReasonsInvalid {
+					reasonsInvalid = append(reasonsInvalid, fmt.Sprintf("alert rule invalid: %s - %s - %s", reason.Field, reason.Type, reason.Help))
+				}
+			}
+		}
+	}
+
+	if len(reasonsInvalid) > 0 {
+		return brainException.RequestInvalid{Reasons: reasonsInvalid}
+	}
+
+	return nil
+}
+
+func (a *administrator) Create(request *alertRuleAdministrator.CreateRequest) (*alertRuleAdministrator.CreateResponse, error) {
+	if err := a.ValidateCreateRequest(request); err != nil {
+		return nil, err
+	}
+
+	// generate the secrets of webhook recipients which were not given one
+	if err := a.setRecipientSecrets(request.Rule.Recipients, nil); err != nil {
+		return nil, err
+	}
+
+	createResponse, err := a.alertRuleRecordHandler.Create(&recordHandler.CreateRequest{
+		Rule: request.Rule,
+	})
+	if err != nil {
+		err = exception.RuleCreation{Reasons: []string{err.Error()}}
+		log.Error(err.Error())
+		return nil, err
+	}
+
+	return &alertRuleAdministrator.CreateResponse{
+		Rule: createResponse.Rule,
+	}, nil
+}
+
+func (a *administrator) ValidateUpdateAllowedFieldsRequest(request *alertRuleAdministrator.UpdateAllowedFieldsRequest) error {
+	reasonsInvalid := make([]string, 0)
+
+	if request.Claims == nil {
+		reasonsInvalid = append(reasonsInvalid, "claims are nil")
+	} else {
+		// alert rule must be valid
+		validationResponse, err := a.alertRuleValidator.Validate(&validator.ValidateRequest{
+			Claims: request.Claims,
+			Rule:   request.Rule,
+			Action: action.UpdateAllowedFields,
+		})
+		if err != nil {
+			reasonsInvalid = append(reasonsInvalid, "error validating alert rule: "+err.Error())
+		} else {
+			if len(validationResponse.ReasonsInvalid) > 0 {
+				for _, reason := range validationResponse.ReasonsInvalid {
+					reasonsInvalid = append(reasonsInvalid, fmt.Sprintf("alert rule invalid: %s - %s - %s", reason.Field, reason.Type, reason.Help))
+				}
+			}
+		}
+	}
+
+	if len(reasonsInvalid) > 0 {
+		return brainException.RequestInvalid{Reasons: reasonsInvalid}
+	}
+	return nil
+}
+
+func (a *administrator) UpdateAllowedFields(request *alertRuleAdministrator.UpdateAllowedFieldsRequest) (*alertRuleAdministrator.UpdateAllowedFieldsResponse, error) {
+	if err := a.ValidateUpdateAllowedFieldsRequest(request); err != nil {
+		return nil, err
+	}
+
+	// retrieve the alert rule
+	alertRuleRetrieveResponse, err := a.alertRuleRecordHandler.Retrieve(&recordHandler.RetrieveRequest{
+		Claims:     request.Claims,
+		Identifier: id.Identifier{Id: request.Rule.Id},
+	})
+	if err != nil {
+		return nil, exception.RuleRetrieval{Reasons: []string{err.Error()}}
+	}
+
+	// update the allowed fields on the alert rule
+	alertRuleRetrieveResponse.Rule.Name = request.Rule.Name
+	alertRuleRetrieveResponse.Rule.Enabled = request.Rule.Enabled
+	alertRuleRetrieveResponse.Rule.Trigger = request.Rule.Trigger
+	// webhook recipients which are not given a secret keep the secret they
+	// had, if they were already a recipient, otherwise one is generated
+	if err := a.setRecipientSecrets(request.Rule.Recipients, alertRuleRetrieveResponse.Rule.Recipients); err != nil {
+		return nil, err
+	}
+	alertRuleRetrieveResponse.Rule.Recipients = request.Rule.Recipients
+	alertRuleRetrieveResponse.Rule.Cooldown = request.Rule.Cooldown
+	alertRuleRetrieveResponse.Rule.DeviceIds = request.Rule.DeviceIds
+
+	// update the alert rule
+	_, err = a.alertRuleRecordHandler.Update(&recordHandler.UpdateRequest{
+		Claims:     request.Claims,
+		Identifier: id.Identifier{Id: request.Rule.Id},
+		Rule:       alertRuleRetrieveResponse.Rule,
+	})
+	if err != nil {
+		return nil, exception.RuleUpdate{Reasons: []string{err.Error()}}
+	}
+
+	return &alertRuleAdministrator.UpdateAllowedFieldsResponse{
+		Rule: alertRuleRetrieveResponse.Rule,
+	}, nil
+}
+
+// setRecipientSecrets sets the secret of each of the given webhook recipients
+// without one to that of the existing recipient with the same address or,
+// if there is no such recipient, to a newly generated secret
+func (a *administrator) setRecipientSecrets(recipients, existingRecipients []rule.Recipient) error {
+	for i := range recipients {
+		if recipients[i].Channel != rule.WebhookChannel || recipients[i].Secret != "" {
+			continue
+		}
+
+		for _, existingRecipient := range existingRecipients {
+			if existingRecipient.Channel == rule.WebhookChannel && existingRecipient.Address == recipients[i].Address {
+				recipients[i].Secret = existingRecipient.Secret
+				break
+			}
+		}
+		if recipients[i].Secret != "" {
+			continue
+		}
+
+		secretGenerateResponse, err := a.secretGenerator.Generate(&apiUserPasswordGenerator.GenerateRequest{
+			CryptoBytesLength: 32,
+		})
+		if err != nil {
+			err = exception.SecretGeneration{Reasons: []string{err.Error()}}
+			log.Error(err.Error())
+			return err
+		}
+		recipients[i].Secret = secretGenerateResponse.Password
+	}
+	return nil
+}
@@ -0,0 +1,37 @@
+package exception
+
+import (
+	"strings"
+)
+
+type RuleCreation struct {
+	Reasons []string
+}
+
+func (e RuleCreation) Error() string {
+	return "error creating alert rule: " + strings.Join(e.Reasons, "; ")
+}
+
+type RuleRetrieval struct {
+	Reasons []string
+}
+
+func (e RuleRetrieval) Error() string {
+	return "error retrieving alert rule: " + strings.Join(e.Reasons, "; ")
+}
+
+type RuleUpdate struct {
+	Reasons []string
+}
+
+func (e RuleUpdate) Error() string {
+	return "error updating alert rule: " + strings.Join(e.Reasons, "; ")
+}
+
+type SecretGeneration struct {
+	Reasons []string
+}
+
+func (e SecretGeneration) Error() string {
+	return "error generating webhook recipient secret: " + strings.Join(e.Reasons, "; ")
+}
@@ -0,0 +1,81 @@
+package jsonRpc
+
+import (
+	brainException "github.com/iot-my-world/brain/internal/exception"
+	"github.com/iot-my-world/brain/internal/log"
+	alertRuleAdministrator "github.com/iot-my-world/brain/pkg/alert/rule/administrator"
+	alertRuleAdministratorJsonRpcAdaptor "github.com/iot-my-world/brain/pkg/alert/rule/administrator/adaptor/jsonRpc"
+	jsonRpcClient "github.com/iot-my-world/brain/pkg/api/jsonRpc/client"
+)
+
+type administrator struct {
+	jsonRpcClient jsonRpcClient.Client
+}
+
+func New(
+	jsonRpcClient jsonRpcClient.Client,
+) alertRuleAdministrator.Administrator {
+	return &administrator{
+		jsonRpcClient: jsonRpcClient,
+	}
+}
+
+func (a *administrator) ValidateCreateRequest(request *alertRuleAdministrator.CreateRequest) error {
+	reasonsInvalid := make([]string, 0)
+
+	if len(reasonsInvalid) > 0 {
+		return brainException.RequestInvalid{Reasons: reasonsInvalid}
+	}
+	return nil
+}
+
+func (a *administrator) Create(request *alertRuleAdministrator.CreateRequest) (*alertRuleAdministrator.CreateResponse, error) {
+	if err := a.ValidateCreateRequest(request); err != nil {
+		return nil, err
+	}
+
+	alertRuleCreateResponse := alertRuleAdministratorJsonRpcAdaptor.CreateResponse{}
+	if err := a.jsonRpcClient.JsonRpcRequest(
+		alertRuleAdministrator.CreateService,
+		alertRuleAdministratorJsonRpcAdaptor.CreateRequest{
+			Rule: request.Rule,
+		},
+		&alertRuleCreateResponse,
+	); err != nil {
+		log.Error(err.Error())
+		return nil, err
+	}
+
+	return &alertRuleAdministrator.CreateResponse{Rule: alertRuleCreateResponse.Rule}, nil
+}
+
+func (a *administrator) ValidateUpdateAllowedFieldsRequest(request *alertRuleAdministrator.UpdateAllowedFieldsRequest) error {
+	reasonsInvalid := make([]string, 0)
+
+	if len(reasonsInvalid) > 0 {
+		return brainException.RequestInvalid{Reasons: reasonsInvalid}
+	}
+	return nil
+}
+
+func (a *administrator) UpdateAllowedFields(request *alertRuleAdministrator.UpdateAllowedFieldsRequest) (*alertRuleAdministrator.UpdateAllowedFieldsResponse, error) {
+	if err := a.ValidateUpdateAllowedFieldsRequest(request); err != nil {
+		return nil, err
+	}
+
+	alertRuleUpdateAllowedFieldsResponse := alertRuleAdministratorJsonRpcAdaptor.UpdateAllowedFieldsResponse{}
+	if err := a.jsonRpcClient.JsonRpcRequest(
+		alertRuleAdministrator.UpdateAllowedFieldsService,
+		alertRuleAdministratorJsonRpcAdaptor.UpdateAllowedFieldsRequest{
+			Rule: request.Rule,
+		},
+		&alertRuleUpdateAllowedFieldsResponse,
+	); err != nil {
+		log.Error(err.Error())
+		return nil, err
+	}
+
+	return &alertRuleAdministrator.UpdateAllowedFieldsResponse{
+		Rule: alertRuleUpdateAllowedFieldsResponse.Rule,
+	}, nil
+}
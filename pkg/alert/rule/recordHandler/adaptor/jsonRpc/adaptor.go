@@ -0,0 +1,101 @@
+package jsonRpc
+
+import (
+	"github.com/iot-my-world/brain/internal/log"
+	"github.com/iot-my-world/brain/pkg/alert/rule"
+	alertRuleRecordHandler "github.com/iot-my-world/brain/pkg/alert/rule/recordHandler"
+	jsonRpcServiceProvider "github.com/iot-my-world/brain/pkg/api/jsonRpc/service/provider"
+	"github.com/iot-my-world/brain/pkg/search/criterion"
+	wrappedCriterion "github.com/iot-my-world/brain/pkg/search/criterion/wrapped"
+	wrappedIdentifier "github.com/iot-my-world/brain/pkg/search/identifier/wrapped"
+	"github.com/iot-my-world/brain/pkg/search/query"
+	wrappedClaims "github.com/iot-my-world/brain/pkg/security/claims/wrapped"
+	"net/http"
+)
+
+type adaptor struct {
+	RecordHandler alertRuleRecordHandler.RecordHandler
+}
+
+func New(recordHandler alertRuleRecordHandler.RecordHandler) *adaptor {
+	return &adaptor{
+		RecordHandler: recordHandler,
+	}
+}
+
+func (a *adaptor) Name() jsonRpcServiceProvider.Name {
+	return jsonRpcServiceProvider.Name(alertRuleRecordHandler.ServiceProvider)
+}
+
+func (a *adaptor) MethodRequiresAuthorization(string) bool {
+	return true
+}
+
+type RetrieveRequest struct {
+	WrappedIdentifier wrappedIdentifier.Wrapped `json:"identifier"`
+}
+
+type RetrieveResponse struct {
+	Rule rule.Rule `json:"rule"`
+}
+
+func (a *adaptor) Retrieve(r *http.Request, request *RetrieveRequest, response *RetrieveResponse) error {
+	claims, err := wrappedClaims.UnwrapClaimsFromContext(r)
+	if err != nil {
+		log.Warn(err.Error())
+		return err
+	}
+
+	retrieveRuleResponse, err := a.RecordHandler.Retrieve(
+		&alertRuleRecordHandler.RetrieveRequest{
+			Claims:     claims,
+			Identifier: request.WrappedIdentifier.Identifier,
+		})
+	if err != nil {
+		return err
+	}
+
+	response.Rule = retrieveRuleResponse.Rule
+
+	return nil
+}
+
+type CollectRequest struct {
+	Criteria []wrappedCriterion.Wrapped `json:"criteria"`
+	Query    query.Query                `json:"query"`
+}
+
+type CollectResponse struct {
+	Records []rule.Rule `json:"records"`
+	Total   int         `json:"total"`
+}
+
+func (a *adaptor) Collect(r *http.Request, request *CollectRequest, response *CollectResponse) error {
+	claims, err := wrappedClaims.UnwrapClaimsFromContext(r)
+	if err != nil {
+		log.Warn(err.Error())
+		return err
+	}
+
+	criteria := make([]criterion.Criterion, 0)
+	for criterionIdx := range request.Criteria {
+		if c, err := request.Criteria[criterionIdx].UnWrap(); err == nil {
+			criteria = append(criteria, c)
+		} else {
+			return err
+		}
+	}
+
+	collectRuleResponse, err := a.RecordHandler.Collect(&alertRuleRecordHandler.CollectRequest{
+		Claims:   claims,
+		Criteria: criteria,
+		Query:    request.Query,
+	})
+	if err != nil {
+		return err
+	}
+
+	response.Records = collectRuleResponse.Records
+	response.Total = collectRuleResponse.Total
+	return nil
+}
@@ -0,0 +1,133 @@
+package alertRuleRecordHandler
+
+import (
+	brainException "github.com/iot-my-world/brain/internal/exception"
+	"github.com/iot-my-world/brain/pkg/alert/rule"
+	alertRuleRecordHandler "github.com/iot-my-world/brain/pkg/alert/rule/recordHandler"
+	alertRuleRecordHandlerException "github.com/iot-my-world/brain/pkg/alert/rule/recordHandler/exception"
+	brainRecordHandler "github.com/iot-my-world/brain/pkg/recordHandler"
+	brainRecordHandlerException "github.com/iot-my-world/brain/pkg/recordHandler/exception"
+)
+
+type RecordHandler struct {
+	alertRuleRecordHandler brainRecordHandler.RecordHandler
+}
+
+func New(
+	brainRuleRecordHandler brainRecordHandler.RecordHandler,
+) alertRuleRecordHandler.RecordHandler {
+
+	return &RecordHandler{
+		alertRuleRecordHandler: brainRuleRecordHandler,
+	}
+}
+
+type CreateRequest struct {
+	Rule rule.Rule
+}
+
+type CreateResponse struct {
+	Rule rule.Rule
+}
+
+func (r *RecordHandler) ValidateCreateRequest(request *alertRuleRecordHandler.CreateRequest) error {
+	reasonsInvalid := make([]string, 0)
+
+	if len(reasonsInvalid) > 0 {
+		return brainException.RequestInvalid{Reasons: reasonsInvalid}
+	}
+
+	return nil
+}
+
+func (r *RecordHandler) Create(request *alertRuleRecordHandler.CreateRequest) (*alertRuleRecordHandler.CreateResponse, error) {
+	if err := r.ValidateCreateRequest(request); err != nil {
+		return nil, err
+	}
+
+	createResponse := brainRecordHandler.CreateResponse{}
+	if err := r.alertRuleRecordHandler.Create(&brainRecordHandler.CreateRequest{
+		Entity: &request.Rule,
+	}, &createResponse); err != nil {
+		return nil, alertRuleRecordHandlerException.Create{Reasons: []string{err.Error()}}
+	}
+	createdRule, ok := createResponse.Entity.(*rule.Rule)
+	if !ok {
+		return nil, alertRuleRecordHandlerException.Create{Reasons: []string{"could not cast created entity to alert rule"}}
+	}
+
+	return &alertRuleRecordHandler.CreateResponse{
+		Rule: *createdRule,
+	}, nil
+}
+
+func (r *RecordHandler) Retrieve(request *alertRuleRecordHandler.RetrieveRequest) (*alertRuleRecordHandler.RetrieveResponse, error) {
+	retrievedRule := rule.Rule{}
+	retrieveResponse := brainRecordHandler.RetrieveResponse{
+		Entity: &retrievedRule,
+	}
+	if err := r.alertRuleRecordHandler.Retrieve(&brainRecordHandler.RetrieveRequest{
+		Claims:     request.Claims,
+		Identifier: request.Identifier,
+	}, &retrieveResponse); err != nil {
+		switch err.(type) {
+		case brainRecordHandlerException.NotFound:
+			return nil, alertRuleRecordHandlerException.NotFound{}
+		default:
+			return nil, err
+		}
+	}
+
+	return &alertRuleRecordHandler.RetrieveResponse{
+		Rule: retrievedRule,
+	}, nil
+}
+
+func (r *RecordHandler) Update(request *alertRuleRecordHandler.UpdateRequest) (*alertRuleRecordHandler.UpdateResponse, error) {
+	updateResponse := brainRecordHandler.UpdateResponse{}
+	if err := r.alertRuleRecordHandler.Update(&brainRecordHandler.UpdateRequest{
+		Claims:     request.Claims,
+		Identifier: request.Identifier,
+		Entity:     &request.Rule,
+	}, &updateResponse); err != nil {
+		return nil, alertRuleRecordHandlerException.Update{Reasons: []string{err.Error()}}
+	}
+
+	return &alertRuleRecordHandler.UpdateResponse{}, nil
+}
+
+func (r *RecordHandler) Delete(request *alertRuleRecordHandler.DeleteRequest) (*alertRuleRecordHandler.DeleteResponse, error) {
+	deleteResponse := brainRecordHandler.DeleteResponse{}
+	if err := r.alertRuleRecordHandler.Delete(&brainRecordHandler.DeleteRequest{
+		Claims:     request.Claims,
+		Identifier: request.Identifier,
+	}, &deleteResponse); err != nil {
+		return nil, alertRuleRecordHandlerException.Delete{Reasons: []string{err.Error()}}
+	}
+
+	return &alertRuleRecordHandler.DeleteResponse{}, nil
+}
+
+func (r *RecordHandler) Collect(request *alertRuleRecordHandler.CollectRequest) (*alertRuleRecordHandler.CollectResponse, error) {
+	var collectedRule []rule.Rule
+	collectResponse := brainRecordHandler.CollectResponse{
+		Records: &collectedRule,
+	}
+	err := r.alertRuleRecordHandler.Collect(&brainRecordHandler.CollectRequest{
+		Claims:   request.Claims,
+		Criteria: request.Criteria,
+		Query:    request.Query,
+	}, &collectResponse)
+	if err != nil {
+		return nil, alertRuleRecordHandlerException.Collect{Reasons: []string{err.Error()}}
+	}
+
+	if collectedRule == nil {
+		collectedRule = make([]rule.Rule, 0)
+	}
+
+	return &alertRuleRecordHandler.CollectResponse{
+		Records: collectedRule,
+		Total:   collectResponse.Total,
+	}, nil
+}
@@ -0,0 +1,127 @@
+package jsonRpc
+
+import (
+	brainException "github.com/iot-my-world/brain/internal/exception"
+	"github.com/iot-my-world/brain/internal/log"
+	alertRuleRecordHandler "github.com/iot-my-world/brain/pkg/alert/rule/recordHandler"
+	alertRuleRecordHandlerJsonRpcAdaptor "github.com/iot-my-world/brain/pkg/alert/rule/recordHandler/adaptor/jsonRpc"
+	jsonRpcClient "github.com/iot-my-world/brain/pkg/api/jsonRpc/client"
+	wrappedCriterion "github.com/iot-my-world/brain/pkg/search/criterion/wrapped"
+	wrappedIdentifier "github.com/iot-my-world/brain/pkg/search/identifier/wrapped"
+)
+
+type recordHandler struct {
+	jsonRpcClient jsonRpcClient.Client
+}
+
+func New(
+	jsonRpcClient jsonRpcClient.Client,
+) alertRuleRecordHandler.RecordHandler {
+	return &recordHandler{
+		jsonRpcClient: jsonRpcClient,
+	}
+}
+
+func (r *recordHandler) Create(request *alertRuleRecordHandler.CreateRequest) (*alertRuleRecordHandler.CreateResponse, error) {
+	return nil, brainException.NotImplemented{}
+}
+
+func (r *recordHandler) ValidateRetrieveRequest(request *alertRuleRecordHandler.RetrieveRequest) error {
+	reasonsInvalid := make([]string, 0)
+	if request.Identifier == nil {
+		reasonsInvalid = append(reasonsInvalid, "identifier is nil")
+	}
+
+	if len(reasonsInvalid) > 0 {
+		return brainException.RequestInvalid{Reasons: reasonsInvalid}
+	}
+	return nil
+}
+
+func (r *recordHandler) Retrieve(request *alertRuleRecordHandler.RetrieveRequest) (*alertRuleRecordHandler.RetrieveResponse, error) {
+	if err := r.ValidateRetrieveRequest(request); err != nil {
+		log.Error(err.Error())
+		return nil, err
+	}
+
+	// wrap identifier
+	id, err := wrappedIdentifier.Wrap(request.Identifier)
+	if err != nil {
+		log.Error(err.Error())
+		return nil, err
+	}
+
+	alertRuleRetrieveResponse := alertRuleRecordHandlerJsonRpcAdaptor.RetrieveResponse{}
+	if err := r.jsonRpcClient.JsonRpcRequest(
+		alertRuleRecordHandler.RetrieveService,
+		alertRuleRecordHandlerJsonRpcAdaptor.RetrieveRequest{
+			WrappedIdentifier: *id,
+		},
+		&alertRuleRetrieveResponse,
+	); err != nil {
+		log.Error(err.Error())
+		return nil, err
+	}
+
+	return &alertRuleRecordHandler.RetrieveResponse{
+		Rule: alertRuleRetrieveResponse.Rule,
+	}, nil
+}
+func (r *recordHandler) Update(request *alertRuleRecordHandler.UpdateRequest) (*alertRuleRecordHandler.UpdateResponse, error) {
+	return nil, brainException.NotImplemented{}
+}
+func (r *recordHandler) Delete(request *alertRuleRecordHandler.DeleteRequest) (*alertRuleRecordHandler.DeleteResponse, error) {
+	return nil, brainException.NotImplemented{}
+}
+
+func (r *recordHandler) ValidateCollectRequest(request *alertRuleRecordHandler.CollectRequest) error {
+	reasonsInvalid := make([]string, 0)
+
+	if request.Criteria == nil {
+		reasonsInvalid = append(reasonsInvalid, "criteria is nil")
+	} else {
+		for _, crit := range request.Criteria {
+			if crit == nil {
+				reasonsInvalid = append(reasonsInvalid, "a criterion is nil")
+			}
+		}
+	}
+
+	if len(reasonsInvalid) > 0 {
+		return brainException.RequestInvalid{Reasons: reasonsInvalid}
+	}
+	return nil
+}
+
+func (r *recordHandler) Collect(request *alertRuleRecordHandler.CollectRequest) (*alertRuleRecordHandler.CollectResponse, error) {
+	if err := r.ValidateCollectRequest(request); err != nil {
+		return nil, err
+	}
+
+	// wrap criteria
+	criteria := make([]wrappedCriterion.Wrapped, 0)
+	for _, crit := range request.Criteria {
+		wrapped, err := wrappedCriterion.Wrap(crit)
+		if err != nil {
+			log.Error(err.Error())
+			return nil, err
+		}
+		criteria = append(criteria, *wrapped)
+	}
+
+	collectResponse := alertRuleRecordHandlerJsonRpcAdaptor.CollectResponse{}
+	if err := r.jsonRpcClient.JsonRpcRequest(
+		alertRuleRecordHandler.CollectService,
+		alertRuleRecordHandlerJsonRpcAdaptor.CollectRequest{
+			Criteria: criteria,
+			Query:    request.Query,
+		},
+		&collectResponse); err != nil {
+		return nil, err
+	}
+
+	return &alertRuleRecordHandler.CollectResponse{
+		Records: collectResponse.Records,
+		Total:   collectResponse.Total,
+	}, nil
+}
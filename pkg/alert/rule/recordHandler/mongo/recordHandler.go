@@ -0,0 +1,37 @@
+package mongo
+
+import (
+	"github.com/iot-my-world/brain/pkg/alert/rule"
+	alertRuleRecordHandler "github.com/iot-my-world/brain/pkg/alert/rule/recordHandler"
+	alertRuleGenericRecordHandler "github.com/iot-my-world/brain/pkg/alert/rule/recordHandler/generic"
+	brainMongoRecordHandler "github.com/iot-my-world/brain/pkg/recordHandler/mongo"
+	"github.com/iot-my-world/brain/pkg/security/claims"
+	"gopkg.in/mgo.v2"
+)
+
+func New(
+	mongoSession *mgo.Session,
+	databaseName string,
+	collectionName string,
+) alertRuleRecordHandler.RecordHandler {
+	mongoRecordHandler := brainMongoRecordHandler.New(
+		mongoSession,
+		databaseName,
+		collectionName,
+		[]mgo.Index{
+			{
+				Key:    []string{"id"},
+				Unique: true,
+			},
+			{
+				Key: []string{"ownerId.id", "trigger.type"},
+			},
+		},
+		rule.IsValidIdentifier,
+		claims.ContextualiseFilter,
+	)
+
+	return alertRuleGenericRecordHandler.New(
+		mongoRecordHandler,
+	)
+}
@@ -0,0 +1,80 @@
+package rule
+
+import (
+	"github.com/iot-my-world/brain/pkg/party"
+	"github.com/iot-my-world/brain/pkg/search/identifier/id"
+)
+
+type TriggerType string
+
+const GeofenceExit TriggerType = "GeofenceExit"
+const DeviceOffline TriggerType = "DeviceOffline"
+const NoGPSFix TriggerType = "NoGPSFix"
+const SpeedOverThreshold TriggerType = "SpeedOverThreshold"
+
+type Trigger struct {
+	Type TriggerType `json:"type" bson:"type"`
+
+	// GeofenceIds restricts a GeofenceExit trigger to exits from
+	// the given geofences. If empty an exit from any geofence fires.
+	GeofenceIds []id.Identifier `json:"geofenceIds" bson:"geofenceIds"`
+
+	// SpeedThreshold in km/h above which a SpeedOverThreshold trigger fires
+	SpeedThreshold float32 `json:"speedThreshold" bson:"speedThreshold"`
+}
+
+type ChannelType string
+
+const EmailChannel ChannelType = "Email"
+const WebhookChannel ChannelType = "Webhook"
+
+type Recipient struct {
+	Channel ChannelType `json:"channel" bson:"channel"`
+	Name    string      `json:"name" bson:"name"`
+
+	// Address is an email address for the Email channel
+	// and a URL for the Webhook channel
+	Address string `json:"address" bson:"address"`
+
+	// Secret is the key used to sign the body of each request made
+	// to the URL of a Webhook channel recipient. It is generated if not given.
+	Secret string `json:"secret" bson:"secret"`
+}
+
+type Rule struct {
+	Id string `json:"id" bson:"id"`
+
+	OwnerPartyType party.Type    `json:"ownerPartyType" bson:"ownerPartyType"`
+	OwnerId        id.Identifier `json:"ownerId" bson:"ownerId"`
+	Name           string        `json:"name" bson:"name"`
+	Enabled        bool          `json:"enabled" bson:"enabled"`
+
+	Trigger    Trigger     `json:"trigger" bson:"trigger"`
+	Recipients []Recipient `json:"recipients" bson:"recipients"`
+
+	// Devices which this rule applies to. If empty the rule applies to
+	// all of the devices owned by or assigned to the owner of the rule.
+	DeviceIds []id.Identifier `json:"deviceIds" bson:"deviceIds"`
+
+	// Cooldown is the number of seconds after the rule fires for a device
+	// during which it will not fire again for that device
+	Cooldown int64 `json:"cooldown" bson:"cooldown"`
+}
+
+func (r *Rule) SetId(id string) {
+	r.Id = id
+}
+
+// AppliesToDevice returns true if the rule applies to the device with
+// the given id
+func (r *Rule) AppliesToDevice(deviceId id.Identifier) bool {
+	if len(r.DeviceIds) == 0 {
+		return true
+	}
+	for _, ruleDeviceId := range r.DeviceIds {
+		if ruleDeviceId.Id == deviceId.Id {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,60 @@
+package jsonRpc
+
+import (
+	"github.com/iot-my-world/brain/internal/log"
+	"github.com/iot-my-world/brain/pkg/action"
+	"github.com/iot-my-world/brain/pkg/alert/rule"
+	"github.com/iot-my-world/brain/pkg/alert/rule/validator"
+	jsonRpcServiceProvider "github.com/iot-my-world/brain/pkg/api/jsonRpc/service/provider"
+	wrappedClaims "github.com/iot-my-world/brain/pkg/security/claims/wrapped"
+	"github.com/iot-my-world/brain/pkg/validate/reasonInvalid"
+	"net/http"
+)
+
+type adaptor struct {
+	alertRuleValidator validator.Validator
+}
+
+func New(alertRuleValidator validator.Validator) *adaptor {
+	return &adaptor{
+		alertRuleValidator: alertRuleValidator,
+	}
+}
+
+func (a *adaptor) Name() jsonRpcServiceProvider.Name {
+	return jsonRpcServiceProvider.Name(validator.ServiceProvider)
+}
+
+func (a *adaptor) MethodRequiresAuthorization(string) bool {
+	return true
+}
+
+type ValidateRequest struct {
+	Rule   rule.Rule     `json:"rule"`
+	Action action.Action `json:"action"`
+}
+
+type ValidateResponse struct {
+	ReasonsInvalid []reasonInvalid.ReasonInvalid `json:"reasonsInvalid"`
+}
+
+func (a *adaptor) Validate(r *http.Request, request *ValidateRequest, response *ValidateResponse) error {
+	claims, err := wrappedClaims.UnwrapClaimsFromContext(r)
+	if err != nil {
+		log.Warn(err.Error())
+		return err
+	}
+
+	validateRuleResponse, err := a.alertRuleValidator.Validate(&validator.ValidateRequest{
+		Claims: claims,
+		Rule:   request.Rule,
+		Action: request.Action,
+	})
+	if err != nil {
+		return err
+	}
+
+	response.ReasonsInvalid = validateRuleResponse.ReasonsInvalid
+
+	return nil
+}
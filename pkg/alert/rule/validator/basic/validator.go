@@ -0,0 +1,292 @@
+package validator
+
+import (
+	brainException "github.com/iot-my-world/brain/internal/exception"
+	"github.com/iot-my-world/brain/internal/log"
+	"github.com/iot-my-world/brain/internal/publicAddress"
+	"github.com/iot-my-world/brain/pkg/action"
+	"github.com/iot-my-world/brain/pkg/alert/rule"
+	alertRuleAction "github.com/iot-my-world/brain/pkg/alert/rule/action"
+	alertRuleValidator "github.com/iot-my-world/brain/pkg/alert/rule/validator"
+	alertRuleValidatorException "github.com/iot-my-world/brain/pkg/alert/rule/validator/exception"
+	sigbugRecordHandler "github.com/iot-my-world/brain/pkg/device/sigbug/recordHandler"
+	sigbugRecordHandlerException "github.com/iot-my-world/brain/pkg/device/sigbug/recordHandler/exception"
+	geofenceRecordHandler "github.com/iot-my-world/brain/pkg/geofence/recordHandler"
+	geofenceRecordHandlerException "github.com/iot-my-world/brain/pkg/geofence/recordHandler/exception"
+	"github.com/iot-my-world/brain/pkg/party"
+	partyAdministrator "github.com/iot-my-world/brain/pkg/party/administrator"
+	partyAdministratorException "github.com/iot-my-world/brain/pkg/party/administrator/exception"
+	"github.com/iot-my-world/brain/pkg/validate/reasonInvalid"
+	"net/mail"
+	"net/url"
+)
+
+type validator struct {
+	partyAdministrator    partyAdministrator.Administrator
+	sigbugRecordHandler   sigbugRecordHandler.RecordHandler
+	geofenceRecordHandler geofenceRecordHandler.RecordHandler
+	actionIgnoredReasons  map[action.Action]reasonInvalid.IgnoredReasonsInvalid
+}
+
+func New(
+	partyAdministrator partyAdministrator.Administrator,
+	sigbugRecordHandler sigbugRecordHandler.RecordHandler,
+	geofenceRecordHandler geofenceRecordHandler.RecordHandler,
+) alertRuleValidator.Validator {
+
+	actionIgnoredReasons := map[action.Action]reasonInvalid.IgnoredReasonsInvalid{
+		alertRuleAction.Create: {
+			ReasonsInvalid: map[string][]reasonInvalid.Type{
+				"id": {
+					reasonInvalid.Blank,
+				},
+			},
+		},
+		alertRuleAction.UpdateAllowedFields: {
+			ReasonsInvalid: map[string][]reasonInvalid.Type{},
+		},
+	}
+
+	return &validator{
+		partyAdministrator:    partyAdministrator,
+		sigbugRecordHandler:   sigbugRecordHandler,
+		geofenceRecordHandler: geofenceRecordHandler,
+		actionIgnoredReasons:  actionIgnoredReasons,
+	}
+}
+
+func (v *validator) ValidateValidateRequest(request *alertRuleValidator.ValidateRequest) error {
+	reasonsInvalid := make([]string, 0)
+
+	if request.Claims == nil {
+		reasonsInvalid = append(reasonsInvalid, "claims are nil")
+	}
+
+	if len(reasonsInvalid) > 0 {
+		return brainException.RequestInvalid{Reasons: reasonsInvalid}
+	}
+	return nil
+}
+
+func (v *validator) Validate(request *alertRuleValidator.ValidateRequest) (*alertRuleValidator.ValidateResponse, error) {
+	if err := v.ValidateValidateRequest(request); err != nil {
+		return nil, err
+	}
+
+	allReasonsInvalid := make([]reasonInvalid.ReasonInvalid, 0)
+	alertRuleToValidate := &request.Rule
+
+	if (*alertRuleToValidate).Id == "" {
+		allReasonsInvalid = append(allReasonsInvalid, reasonInvalid.ReasonInvalid{
+			Field: "id",
+			Type:  reasonInvalid.Blank,
+			Help:  "cannot be blank",
+			Data:  (*alertRuleToValidate).Id,
+		})
+	}
+
+	if (*alertRuleToValidate).OwnerPartyType == "" {
+		allReasonsInvalid = append(allReasonsInvalid, reasonInvalid.ReasonInvalid{
+			Field: "ownerPartyType",
+			Type:  reasonInvalid.Blank,
+			Help:  "cannot be blank",
+			Data:  (*alertRuleToValidate).OwnerPartyType,
+		})
+	}
+
+	if (*alertRuleToValidate).OwnerId.Id == "" {
+		allReasonsInvalid = append(allReasonsInvalid, reasonInvalid.ReasonInvalid{
+			Field: "ownerId",
+			Type:  reasonInvalid.Blank,
+			Help:  "cannot be blank",
+			Data:  (*alertRuleToValidate).OwnerId,
+		})
+	}
+
+	// if neither owner party type nor owner id are blank
+	if (*alertRuleToValidate).OwnerPartyType != "" && (*alertRuleToValidate).OwnerId.Id != "" {
+		// owner party type must be valid. i.e. must be of a valid type and the party must exist
+		switch (*alertRuleToValidate).OwnerPartyType {
+		case party.System, party.Client, party.Company:
+			_, err := v.partyAdministrator.RetrieveParty(&partyAdministrator.RetrievePartyRequest{
+				Claims:     request.Claims,
+				PartyType:  (*alertRuleToValidate).OwnerPartyType,
+				Identifier: (*alertRuleToValidate).OwnerId,
+			})
+			if err != nil {
+				switch err.(type) {
+				case partyAdministratorException.NotFound:
+					allReasonsInvalid = append(allReasonsInvalid, reasonInvalid.ReasonInvalid{
+						Field: "ownerId",
+						Type:  reasonInvalid.MustExist,
+						Help:  "owner party must exist",
+						Data:  (*alertRuleToValidate).OwnerId,
+					})
+				default:
+					err = alertRuleValidatorException.Validate{Reasons: []string{"retrieving owner party", err.Error()}}
+					log.Error(err.Error())
+					return nil, err
+				}
+			}
+
+		default:
+			allReasonsInvalid = append(allReasonsInvalid, reasonInvalid.ReasonInvalid{
+				Field: "ownerPartyType",
+				Type:  reasonInvalid.Invalid,
+				Help:  "must be a valid type",
+				Data:  (*alertRuleToValidate).OwnerPartyType,
+			})
+		}
+	}
+
+	if (*alertRuleToValidate).Name == "" {
+		allReasonsInvalid = append(allReasonsInvalid, reasonInvalid.ReasonInvalid{
+			Field: "name",
+			Type:  reasonInvalid.Blank,
+			Help:  "cannot be blank",
+			Data:  (*alertRuleToValidate).Name,
+		})
+	}
+
+	// the trigger must be valid for its type
+	switch (*alertRuleToValidate).Trigger.Type {
+	case rule.GeofenceExit:
+		for _, geofenceId := range (*alertRuleToValidate).Trigger.GeofenceIds {
+			if _, err := v.geofenceRecordHandler.Retrieve(&geofenceRecordHandler.RetrieveRequest{
+				Claims:     request.Claims,
+				Identifier: geofenceId,
+			}); err != nil {
+				switch err.(type) {
+				case geofenceRecordHandlerException.NotFound:
+					allReasonsInvalid = append(allReasonsInvalid, reasonInvalid.ReasonInvalid{
+						Field: "trigger.geofenceIds",
+						Type:  reasonInvalid.MustExist,
+						Help:  "geofence must exist",
+						Data:  geofenceId,
+					})
+				default:
+					err = alertRuleValidatorException.Validate{Reasons: []string{"retrieving geofence", err.Error()}}
+					log.Error(err.Error())
+					return nil, err
+				}
+			}
+		}
+
+	case rule.SpeedOverThreshold:
+		if (*alertRuleToValidate).Trigger.SpeedThreshold <= 0 {
+			allReasonsInvalid = append(allReasonsInvalid, reasonInvalid.ReasonInvalid{
+				Field: "trigger.speedThreshold",
+				Type:  reasonInvalid.Invalid,
+				Help:  "must be greater than 0",
+				Data:  (*alertRuleToValidate).Trigger.SpeedThreshold,
+			})
+		}
+
+	case rule.DeviceOffline, rule.NoGPSFix:
+
+	case "":
+		allReasonsInvalid = append(allReasonsInvalid, reasonInvalid.ReasonInvalid{
+			Field: "trigger.type",
+			Type:  reasonInvalid.Blank,
+			Help:  "cannot be blank",
+			Data:  (*alertRuleToValidate).Trigger.Type,
+		})
+
+	default:
+		allReasonsInvalid = append(allReasonsInvalid, reasonInvalid.ReasonInvalid{
+			Field: "trigger.type",
+			Type:  reasonInvalid.Invalid,
+			Help:  "must be a valid type",
+			Data:  (*alertRuleToValidate).Trigger.Type,
+		})
+	}
+
+	// each recipient must have a valid address for its channel
+	for _, recipient := range (*alertRuleToValidate).Recipients {
+		switch recipient.Channel {
+		case rule.EmailChannel:
+			if _, err := mail.ParseAddress(recipient.Address); err != nil {
+				allReasonsInvalid = append(allReasonsInvalid, reasonInvalid.ReasonInvalid{
+					Field: "recipients",
+					Type:  reasonInvalid.Invalid,
+					Help:  "must be a valid email address",
+					Data:  recipient,
+				})
+			}
+
+		case rule.WebhookChannel:
+			if webhookURL, err := url.ParseRequestURI(recipient.Address); err != nil ||
+				(webhookURL.Scheme != "http" && webhookURL.Scheme != "https") {
+				allReasonsInvalid = append(allReasonsInvalid, reasonInvalid.ReasonInvalid{
+					Field: "recipients",
+					Type:  reasonInvalid.Invalid,
+					Help:  "must be a valid http or https url",
+					Data:  recipient,
+				})
+			} else if err := publicAddress.ValidateURL(recipient.Address); err != nil {
+				// urls may not lead to the services of the platform itself
+				allReasonsInvalid = append(allReasonsInvalid, reasonInvalid.ReasonInvalid{
+					Field: "recipients",
+					Type:  reasonInvalid.Invalid,
+					Help:  "must resolve to a public address",
+					Data:  recipient,
+				})
+			}
+
+		default:
+			allReasonsInvalid = append(allReasonsInvalid, reasonInvalid.ReasonInvalid{
+				Field: "recipients",
+				Type:  reasonInvalid.Invalid,
+				Help:  "must have a valid channel",
+				Data:  recipient,
+			})
+		}
+	}
+
+	if (*alertRuleToValidate).Cooldown < 0 {
+		allReasonsInvalid = append(allReasonsInvalid, reasonInvalid.ReasonInvalid{
+			Field: "cooldown",
+			Type:  reasonInvalid.Invalid,
+			Help:  "cannot be negative",
+			Data:  (*alertRuleToValidate).Cooldown,
+		})
+	}
+
+	// each of the devices on the alert rule must exist
+	for _, deviceId := range (*alertRuleToValidate).DeviceIds {
+		if _, err := v.sigbugRecordHandler.Retrieve(&sigbugRecordHandler.RetrieveRequest{
+			Claims:     request.Claims,
+			Identifier: deviceId,
+		}); err != nil {
+			switch err.(type) {
+			case sigbugRecordHandlerException.NotFound:
+				allReasonsInvalid = append(allReasonsInvalid, reasonInvalid.ReasonInvalid{
+					Field: "deviceIds",
+					Type:  reasonInvalid.MustExist,
+					Help:  "device must exist",
+					Data:  deviceId,
+				})
+			default:
+				err = alertRuleValidatorException.Validate{Reasons: []string{"retrieving device", err.Error()}}
+				log.Error(err.Error())
+				return nil, err
+			}
+		}
+	}
+
+	// Make list of reasons invalid to return
+	returnedReasonsInvalid := make([]reasonInvalid.ReasonInvalid, 0)
+
+	// Add all reasons that cannot be ignored for the given action
+	if v.actionIgnoredReasons[request.Action].ReasonsInvalid != nil {
+		for _, reason := range allReasonsInvalid {
+			if !v.actionIgnoredReasons[request.Action].CanIgnore(reason) {
+				returnedReasonsInvalid = append(returnedReasonsInvalid, reason)
+			}
+		}
+	}
+
+	return &alertRuleValidator.ValidateResponse{
+		ReasonsInvalid: returnedReasonsInvalid,
+	}, nil
+}
@@ -0,0 +1,11 @@
+package exception
+
+import "strings"
+
+type Validate struct {
+	Reasons []string
+}
+
+func (e Validate) Error() string {
+	return "error validating alert rule: " + strings.Join(e.Reasons, "; ")
+}
@@ -24,6 +24,7 @@ import (
 type client struct {
 	url                        string
 	jwt                        string
+	refreshToken               string
 	claims                     claims.Claims
 	loggedIn                   bool
 	loginRequest               jsonRpcServerAuthenticator.LoginRequest
@@ -140,28 +141,11 @@ func (c *client) Login(loginRequest jsonRpcServerAuthenticator.LoginRequest) err
 	// save the login request for maintain/refresh login
 	c.loginRequest = loginRequest
 
-	// save the token
-	c.jwt = loginResponse.Jwt
-
-	object, err := jose.ParseSigned(c.jwt)
-	if err != nil {
-		return errors.New("error parsing jwt " + err.Error())
-	}
-
-	// Access Underlying payload without verification
-	fv := reflect.ValueOf(object).Elem().FieldByName("payload")
-
-	wrapped := wrappedClaims.Wrapped{}
-	if err := json.Unmarshal(fv.Bytes(), &wrapped); err != nil {
-		return errors.New("error unmarshalling claims " + err.Error())
-	}
-
-	unwrappedClaims, err := wrapped.Unwrap()
-	if err != nil {
-		return errors.New("error unwrapping claims " + err.Error())
+	// save the tokens
+	if err := c.SetJWT(loginResponse.Jwt); err != nil {
+		return err
 	}
-
-	c.claims = unwrappedClaims
+	c.refreshToken = loginResponse.RefreshToken
 	c.loggedIn = true
 
 	return nil
@@ -169,6 +153,7 @@ func (c *client) Login(loginRequest jsonRpcServerAuthenticator.LoginRequest) err
 
 func (c *client) Logout() {
 	c.jwt = ""
+	c.refreshToken = ""
 	c.claims = nil
 	c.loggedIn = false
 }
@@ -208,7 +193,24 @@ func (c *client) GetJWT() string {
 	return c.jwt
 }
 
+// RefreshLogin exchanges the refresh token for a new jwt and refresh token.
+// If there is no refresh token, or it cannot be exchanged, the client logs in again.
 func (c *client) RefreshLogin() error {
+	if c.refreshToken != "" {
+		refreshResponse, err := c.jsonRpcServerAuthenticator.Refresh(&jsonRpcServerAuthenticator.RefreshRequest{
+			RefreshToken: c.refreshToken,
+		})
+		if err == nil {
+			if err := c.SetJWT(refreshResponse.Jwt); err != nil {
+				return err
+			}
+			c.refreshToken = refreshResponse.RefreshToken
+			return nil
+		}
+		log.Warn("unable to refresh json rpc client login, logging in again: " + err.Error())
+		c.refreshToken = ""
+	}
+
 	if err := c.Login(c.loginRequest); err != nil {
 		return err
 	}
@@ -224,7 +226,7 @@ func (c *client) MaintainLogin() error {
 			if err := c.RefreshLogin(); err != nil {
 				return err
 			}
-			refreshTokenTimer.Reset(c.claims.TimeToExpiry() - 1*time.Second)
+			refreshTokenTimer.Reset(c.claims.TimeToExpiry() - 10*time.Second)
 		}
 	}
 }
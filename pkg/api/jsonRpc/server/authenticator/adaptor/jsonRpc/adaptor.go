@@ -1,18 +1,26 @@
 package jsonRpc
 
 import (
+	"github.com/iot-my-world/brain/internal/ipAddress"
+	"github.com/iot-my-world/brain/internal/log"
 	jsonRpcServerAuthenticator "github.com/iot-my-world/brain/pkg/api/jsonRpc/server/authenticator"
 	jsonRpcServiceProvider "github.com/iot-my-world/brain/pkg/api/jsonRpc/service/provider"
+	wrappedClaims "github.com/iot-my-world/brain/pkg/security/claims/wrapped"
 	"net/http"
 )
 
 type adaptor struct {
 	authorizationAdministrator jsonRpcServerAuthenticator.Authenticator
+	ipAddressResolver          *ipAddress.Resolver
 }
 
-func New(authorizationAdministrator jsonRpcServerAuthenticator.Authenticator) *adaptor {
+func New(
+	authorizationAdministrator jsonRpcServerAuthenticator.Authenticator,
+	ipAddressResolver *ipAddress.Resolver,
+) *adaptor {
 	return &adaptor{
 		authorizationAdministrator: authorizationAdministrator,
+		ipAddressResolver:          ipAddressResolver,
 	}
 }
 
@@ -22,7 +30,8 @@ func (a *adaptor) Name() jsonRpcServiceProvider.Name {
 
 func (a *adaptor) MethodRequiresAuthorization(method string) bool {
 	switch method {
-	case jsonRpcServerAuthenticator.LoginService:
+	case jsonRpcServerAuthenticator.LoginService,
+		jsonRpcServerAuthenticator.RefreshService:
 		return false
 	}
 	return true
@@ -35,6 +44,24 @@ type LogoutResponse struct {
 }
 
 func (a *adaptor) Logout(r *http.Request, request *LogoutRequest, response *LogoutResponse) error {
+	wrapped, err := wrappedClaims.WrappedClaimsFromContext(r)
+	if err != nil {
+		log.Warn(err.Error())
+		return err
+	}
+	claims, err := wrapped.Unwrap()
+	if err != nil {
+		log.Warn(err.Error())
+		return err
+	}
+
+	if _, err := a.authorizationAdministrator.Logout(&jsonRpcServerAuthenticator.LogoutRequest{
+		Claims:  claims,
+		TokenId: wrapped.TokenId,
+	}); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -44,7 +71,9 @@ type LoginRequest struct {
 }
 
 type LoginResponse struct {
-	Jwt string `json:"jwt"`
+	Jwt          string `json:"jwt"`
+	RefreshToken string `json:"refreshToken"`
+	TOTPRequired bool   `json:"totpRequired"`
 }
 
 func (a *adaptor) Login(r *http.Request, request *LoginRequest, response *LoginResponse) error {
@@ -52,12 +81,75 @@ func (a *adaptor) Login(r *http.Request, request *LoginRequest, response *LoginR
 	loginResponse, err := a.authorizationAdministrator.Login(&jsonRpcServerAuthenticator.LoginRequest{
 		UsernameOrEmailAddress: request.UsernameOrEmailAddress,
 		Password:               request.Password,
+		IPAddress:              a.ipAddressResolver.FromRequest(r),
 	})
 	if err != nil {
 		return err
 	}
 
 	response.Jwt = loginResponse.Jwt
+	response.RefreshToken = loginResponse.RefreshToken
+	response.TOTPRequired = loginResponse.TOTPRequired
+
+	return nil
+}
+
+type VerifyTOTPRequest struct {
+	Code string `json:"code"`
+}
+
+type VerifyTOTPResponse struct {
+	Jwt          string `json:"jwt"`
+	RefreshToken string `json:"refreshToken"`
+}
+
+func (a *adaptor) VerifyTOTP(r *http.Request, request *VerifyTOTPRequest, response *VerifyTOTPResponse) error {
+	wrapped, err := wrappedClaims.WrappedClaimsFromContext(r)
+	if err != nil {
+		log.Warn(err.Error())
+		return err
+	}
+	claims, err := wrapped.Unwrap()
+	if err != nil {
+		log.Warn(err.Error())
+		return err
+	}
+
+	verifyTOTPResponse, err := a.authorizationAdministrator.VerifyTOTP(&jsonRpcServerAuthenticator.VerifyTOTPRequest{
+		Claims:    claims,
+		TokenId:   wrapped.TokenId,
+		Code:      request.Code,
+		IPAddress: a.ipAddressResolver.FromRequest(r),
+	})
+	if err != nil {
+		return err
+	}
+
+	response.Jwt = verifyTOTPResponse.Jwt
+	response.RefreshToken = verifyTOTPResponse.RefreshToken
+
+	return nil
+}
+
+type RefreshRequest struct {
+	RefreshToken string `json:"refreshToken"`
+}
+
+type RefreshResponse struct {
+	Jwt          string `json:"jwt"`
+	RefreshToken string `json:"refreshToken"`
+}
+
+func (a *adaptor) Refresh(r *http.Request, request *RefreshRequest, response *RefreshResponse) error {
+	refreshResponse, err := a.authorizationAdministrator.Refresh(&jsonRpcServerAuthenticator.RefreshRequest{
+		RefreshToken: request.RefreshToken,
+	})
+	if err != nil {
+		return err
+	}
+
+	response.Jwt = refreshResponse.Jwt
+	response.RefreshToken = refreshResponse.RefreshToken
 
 	return nil
 }
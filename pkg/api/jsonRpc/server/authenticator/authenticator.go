@@ -1,15 +1,46 @@
 package authenticator
 
+import (
+	"github.com/iot-my-world/brain/pkg/security/claims"
+	"github.com/iot-my-world/brain/pkg/security/permission/api"
+)
+
 type Authenticator interface {
 	Login(request *LoginRequest) (*LoginResponse, error)
 	Logout(request *LogoutRequest) (*LogoutResponse, error)
+	VerifyTOTP(request *VerifyTOTPRequest) (*VerifyTOTPResponse, error)
+	Refresh(request *RefreshRequest) (*RefreshResponse, error)
 }
 
 const ServiceProvider = "Server-Authenticator"
 const LoginService = ServiceProvider + ".Login"
 const LogoutService = ServiceProvider + ".Logout"
+const VerifyTOTPService = ServiceProvider + ".VerifyTOTP"
+const RefreshService = ServiceProvider + ".Refresh"
+
+var SystemUserPermissions = []api.Permission{
+	LogoutService,
+}
+
+var CompanyAdminUserPermissions = []api.Permission{
+	LogoutService,
+}
+
+var CompanyUserPermissions = []api.Permission{
+	LogoutService,
+}
+
+var ClientAdminUserPermissions = []api.Permission{
+	LogoutService,
+}
+
+var ClientUserPermissions = []api.Permission{
+	LogoutService,
+}
 
 type LogoutRequest struct {
+	Claims  claims.Claims
+	TokenId string
 }
 
 type LogoutResponse struct {
@@ -18,8 +49,37 @@ type LogoutResponse struct {
 type LoginRequest struct {
 	UsernameOrEmailAddress string
 	Password               string
+	IPAddress              string
 }
 
 type LoginResponse struct {
 	Jwt string
+
+	// RefreshToken can be exchanged once for a new Jwt and RefreshToken
+	RefreshToken string
+
+	// TOTPRequired is set if the user has two-factor authentication
+	// enabled, in which case Jwt only allows the code to be verified
+	TOTPRequired bool
+}
+
+type VerifyTOTPRequest struct {
+	Claims    claims.Claims
+	TokenId   string
+	Code      string
+	IPAddress string
+}
+
+type VerifyTOTPResponse struct {
+	Jwt          string
+	RefreshToken string
+}
+
+type RefreshRequest struct {
+	RefreshToken string
+}
+
+type RefreshResponse struct {
+	Jwt          string
+	RefreshToken string
 }
@@ -33,7 +33,11 @@ func (a *authenticator) Login(request *jsonRpcServerAuthenticator.LoginRequest)
 		return nil, err
 	}
 
-	return &jsonRpcServerAuthenticator.LoginResponse{Jwt: loginResponse.Jwt}, nil
+	return &jsonRpcServerAuthenticator.LoginResponse{
+		Jwt:          loginResponse.Jwt,
+		RefreshToken: loginResponse.RefreshToken,
+		TOTPRequired: loginResponse.TOTPRequired,
+	}, nil
 }
 
 func (a *authenticator) Logout(request *jsonRpcServerAuthenticator.LogoutRequest) (*jsonRpcServerAuthenticator.LogoutResponse, error) {
@@ -49,3 +53,41 @@ func (a *authenticator) Logout(request *jsonRpcServerAuthenticator.LogoutRequest
 
 	return &jsonRpcServerAuthenticator.LogoutResponse{}, nil
 }
+
+func (a *authenticator) VerifyTOTP(request *jsonRpcServerAuthenticator.VerifyTOTPRequest) (*jsonRpcServerAuthenticator.VerifyTOTPResponse, error) {
+	verifyTOTPResponse := jsonRpcServerAuthenticatorJsonRpcAdaptor.VerifyTOTPResponse{}
+	if err := a.jsonRpcClient.JsonRpcRequest(
+		jsonRpcServerAuthenticator.VerifyTOTPService,
+		jsonRpcServerAuthenticatorJsonRpcAdaptor.VerifyTOTPRequest{
+			Code: request.Code,
+		},
+		&verifyTOTPResponse,
+	); err != nil {
+		log.Error(err.Error())
+		return nil, err
+	}
+
+	return &jsonRpcServerAuthenticator.VerifyTOTPResponse{
+		Jwt:          verifyTOTPResponse.Jwt,
+		RefreshToken: verifyTOTPResponse.RefreshToken,
+	}, nil
+}
+
+func (a *authenticator) Refresh(request *jsonRpcServerAuthenticator.RefreshRequest) (*jsonRpcServerAuthenticator.RefreshResponse, error) {
+	refreshResponse := jsonRpcServerAuthenticatorJsonRpcAdaptor.RefreshResponse{}
+	if err := a.jsonRpcClient.JsonRpcRequest(
+		jsonRpcServerAuthenticator.RefreshService,
+		jsonRpcServerAuthenticatorJsonRpcAdaptor.RefreshRequest{
+			RefreshToken: request.RefreshToken,
+		},
+		&refreshResponse,
+	); err != nil {
+		log.Error(err.Error())
+		return nil, err
+	}
+
+	return &jsonRpcServerAuthenticator.RefreshResponse{
+		Jwt:          refreshResponse.Jwt,
+		RefreshToken: refreshResponse.RefreshToken,
+	}, nil
+}
@@ -0,0 +1,251 @@
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/iot-my-world/brain/internal/log"
+	jsonRpcServiceProvider "github.com/iot-my-world/brain/pkg/api/jsonRpc/service/provider"
+	auditLogger "github.com/iot-my-world/brain/pkg/audit/logger"
+	auditRetriever "github.com/iot-my-world/brain/pkg/audit/retriever"
+	"github.com/iot-my-world/brain/pkg/search/identifier"
+	"github.com/iot-my-world/brain/pkg/search/identifier/id"
+	wrappedIdentifier "github.com/iot-my-world/brain/pkg/search/identifier/wrapped"
+	"github.com/iot-my-world/brain/pkg/security/claims"
+	"io/ioutil"
+	netHttp "net/http"
+	"sort"
+	"strings"
+)
+
+// responseRecorder passes a response through to the underlying
+// writer while keeping a copy of the body for the audit log
+type responseRecorder struct {
+	netHttp.ResponseWriter
+	body bytes.Buffer
+}
+
+func (r *responseRecorder) Write(data []byte) (int, error) {
+	r.body.Write(data)
+	return r.ResponseWriter.Write(data)
+}
+
+// serveAndAudit serves the given request and records the call in the audit
+// log. The state of the entity targeted by the call is retrieved before and
+// after the call from the record handler of the service provider, if there is
+// one. Failures to retrieve the state are recorded with the call.
+func (s *server) serveAndAudit(
+	next netHttp.Handler,
+	w netHttp.ResponseWriter,
+	r *netHttp.Request,
+	jsonRpcServiceMethod string,
+	callClaims claims.Claims,
+) {
+	stateErrors := make([]string, 0)
+	target, _ := auditTarget(requestParams(r))
+	targetRetriever := s.targetRetriever(jsonRpcServiceMethod)
+	var before json.RawMessage
+	if target != nil && targetRetriever != nil {
+		retrievedState, err := s.retrieveTarget(targetRetriever, target)
+		if err != nil {
+			stateErrors = append(stateErrors, "before: "+err.Error())
+		}
+		before = retrievedState
+	}
+
+	recorder := &responseRecorder{ResponseWriter: w}
+	next.ServeHTTP(recorder, r)
+
+	var response struct {
+		Result json.RawMessage `json:"result"`
+		Error  interface{}     `json:"error"`
+	}
+	if err := json.Unmarshal(recorder.body.Bytes(), &response); err != nil {
+		log.Warn("unable to parse json rpc response for audit log: " + err.Error())
+	}
+	callError := ""
+	if response.Error != nil {
+		if errorString, ok := response.Error.(string); ok {
+			callError = errorString
+		} else if marshalledError, err := json.Marshal(response.Error); err == nil {
+			callError = string(marshalledError)
+		}
+	}
+
+	// if the target could not be determined from the request,
+	// e.g. on creation, try and determine it from the response
+	resultTarget, resultState := auditTarget(response.Result)
+	if target == nil {
+		target = resultTarget
+	}
+	after := resultState
+	if target != nil && targetRetriever != nil {
+		// the state returned by the call is only recorded if
+		// the target cannot be retrieved, e.g. after deletion
+		if retrievedState, err := s.retrieveTarget(targetRetriever, target); err != nil {
+			stateErrors = append(stateErrors, "after: "+err.Error())
+		} else {
+			after = retrievedState
+		}
+	}
+
+	if _, err := s.auditLogger.Record(&auditLogger.RecordRequest{
+		Claims:      callClaims,
+		Method:      jsonRpcServiceMethod,
+		Target:      target,
+		Before:      redact(before),
+		After:       redact(after),
+		StateErrors: stateErrors,
+		Error:       callError,
+	}); err != nil {
+		log.Error("error recording audit log entry: " + err.Error())
+	}
+}
+
+// targetRetriever returns the retriever of the entities of the record
+// handler associated with the provider of the given service method,
+// e.g. Company-RecordHandler for Company-Administrator.Create, or nil
+// if there is no retriever for that record handler
+func (s *server) targetRetriever(jsonRpcServiceMethod string) auditRetriever.Retriever {
+	provider := strings.Split(jsonRpcServiceMethod, ".")[0]
+	if !strings.HasSuffix(provider, "-RecordHandler") {
+		dashIdx := strings.LastIndex(provider, "-")
+		if dashIdx < 0 {
+			return nil
+		}
+		provider = provider[:dashIdx] + "-RecordHandler"
+	}
+	return s.auditTargetRetrievers[jsonRpcServiceProvider.Name(provider)]
+}
+
+// retrieveTarget retrieves the json marshalled state of the target entity
+// with the system claims, so that it is recorded whether or not the caller
+// is permitted to retrieve it
+func (s *server) retrieveTarget(targetRetriever auditRetriever.Retriever, target identifier.Identifier) (json.RawMessage, error) {
+	retrieveResponse, err := targetRetriever.Retrieve(&auditRetriever.RetrieveRequest{
+		Claims:     s.systemClaims,
+		Identifier: target,
+	})
+	if err != nil {
+		return nil, err
+	}
+	state, err := json.Marshal(retrieveResponse.Entity)
+	if err != nil {
+		return nil, err
+	}
+	return state, nil
+}
+
+// redactedValue replaces the values of secret fields in the audit log
+const redactedValue = "[redacted]"
+
+// secretFieldSuffixes are the lower case suffixes of the names of
+// fields, e.g. password or urlToken, which are never recorded
+var secretFieldSuffixes = []string{
+	"password",
+	"secret",
+	"token",
+	"tokenhash",
+	"jwt",
+	"recoverycodes",
+}
+
+// redact returns the given json with the values of all secret
+// fields, at any depth, replaced so that they are not recorded
+func redact(state json.RawMessage) json.RawMessage {
+	if state == nil {
+		return nil
+	}
+	var value interface{}
+	if err := json.Unmarshal(state, &value); err != nil {
+		return nil
+	}
+	redactedState, err := json.Marshal(redactValue(value))
+	if err != nil {
+		return nil
+	}
+	return redactedState
+}
+
+func redactValue(value interface{}) interface{} {
+	switch typedValue := value.(type) {
+	case map[string]interface{}:
+		for fieldName, fieldValue := range typedValue {
+			if secretField(fieldName) {
+				typedValue[fieldName] = redactedValue
+			} else {
+				typedValue[fieldName] = redactValue(fieldValue)
+			}
+		}
+	case []interface{}:
+		for i := range typedValue {
+			typedValue[i] = redactValue(typedValue[i])
+		}
+	}
+	return value
+}
+
+func secretField(fieldName string) bool {
+	lowerFieldName := strings.ToLower(fieldName)
+	for _, suffix := range secretFieldSuffixes {
+		if strings.HasSuffix(lowerFieldName, suffix) {
+			return true
+		}
+	}
+	return false
+}
+
+// requestParams returns the first parameter of the json rpc request,
+// leaving the body of the request intact to be read again. The size
+// of the body is limited by applyAuthorization before it is read.
+func requestParams(r *netHttp.Request) json.RawMessage {
+	if r.Body == nil {
+		return nil
+	}
+	bodyBytes, _ := ioutil.ReadAll(r.Body)
+	r.Body = ioutil.NopCloser(bytes.NewBuffer(bodyBytes))
+
+	var request struct {
+		Params []json.RawMessage `json:"params"`
+	}
+	if err := json.Unmarshal(bodyBytes, &request); err != nil || len(request.Params) == 0 {
+		return nil
+	}
+	return request.Params[0]
+}
+
+// auditTarget looks through the fields of the given json object for the
+// entity targeted by a call. A wrapped identifier field, e.g. companyIdentifier,
+// is preferred. Otherwise the first entity with an id is the target, in which
+// case its state is also returned.
+func auditTarget(object json.RawMessage) (identifier.Identifier, json.RawMessage) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(object, &fields); err != nil {
+		return nil, nil
+	}
+	fieldNames := make([]string, 0)
+	for fieldName := range fields {
+		fieldNames = append(fieldNames, fieldName)
+	}
+	sort.Strings(fieldNames)
+
+	for _, fieldName := range fieldNames {
+		if !strings.HasSuffix(strings.ToLower(fieldName), "identifier") {
+			continue
+		}
+		var wrapped wrappedIdentifier.Wrapped
+		if err := json.Unmarshal(fields[fieldName], &wrapped); err == nil && wrapped.Identifier != nil {
+			return wrapped.Identifier, nil
+		}
+	}
+
+	for _, fieldName := range fieldNames {
+		var entity struct {
+			Id string `json:"id"`
+		}
+		if err := json.Unmarshal(fields[fieldName], &entity); err == nil && entity.Id != "" {
+			return id.Identifier{Id: entity.Id}, fields[fieldName]
+		}
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,214 @@
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	jsonRpcServiceProvider "github.com/iot-my-world/brain/pkg/api/jsonRpc/service/provider"
+	auditLogger "github.com/iot-my-world/brain/pkg/audit/logger"
+	auditRetriever "github.com/iot-my-world/brain/pkg/audit/retriever"
+	"github.com/iot-my-world/brain/pkg/party"
+	"github.com/iot-my-world/brain/pkg/search/identifier/id"
+	humanUserLoginClaims "github.com/iot-my-world/brain/pkg/security/claims/login/user/human"
+	wrappedClaims "github.com/iot-my-world/brain/pkg/security/claims/wrapped"
+	"github.com/stretchr/testify/assert"
+	netHttp "net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+type widget struct {
+	Id       string `json:"id"`
+	Name     string `json:"name"`
+	Password string `json:"password"`
+}
+
+// widgetStore is shared by the widget services registered with the server
+type widgetStore struct {
+	widgets map[string]widget
+}
+
+// fakeRetriever retrieves widgets from the store, failing if told to
+type fakeRetriever struct {
+	store    *widgetStore
+	fail     bool
+	requests []auditRetriever.RetrieveRequest
+}
+
+func (r *fakeRetriever) Retrieve(request *auditRetriever.RetrieveRequest) (*auditRetriever.RetrieveResponse, error) {
+	r.requests = append(r.requests, *request)
+	if r.fail {
+		return nil, errors.New("retrieval failed")
+	}
+	retrievedWidget, found := r.store.widgets[request.Identifier.(id.Identifier).Id]
+	if !found {
+		return nil, errors.New("not found")
+	}
+	return &auditRetriever.RetrieveResponse{Entity: retrievedWidget}, nil
+}
+
+type widgetAdministrator struct {
+	store *widgetStore
+}
+
+func (w *widgetAdministrator) Name() jsonRpcServiceProvider.Name {
+	return "Widget-Administrator"
+}
+
+func (w *widgetAdministrator) MethodRequiresAuthorization(string) bool {
+	return true
+}
+
+type UpdateWidgetRequest struct {
+	Widget widget `json:"widget"`
+}
+
+type UpdateWidgetResponse struct {
+	Widget widget `json:"widget"`
+}
+
+func (w *widgetAdministrator) Update(r *netHttp.Request, request *UpdateWidgetRequest, response *UpdateWidgetResponse) error {
+	w.store.widgets[request.Widget.Id] = request.Widget
+	response.Widget = request.Widget
+	return nil
+}
+
+// fakeAuthoriser authorises all service methods
+type fakeAuthoriser struct{}
+
+func (a *fakeAuthoriser) AuthoriseServiceMethod(jwt string, jsonRpcMethod string) (wrappedClaims.Wrapped, error) {
+	return wrappedClaims.Wrap(humanUserLoginClaims.Login{
+		UserId:         id.Identifier{Id: "user"},
+		ExpirationTime: time.Now().Add(time.Hour).Unix(),
+	})
+}
+
+type fakeAuditLogger struct {
+	auditLogger.Logger
+	records []auditLogger.RecordRequest
+}
+
+func (l *fakeAuditLogger) Record(request *auditLogger.RecordRequest) (*auditLogger.RecordResponse, error) {
+	l.records = append(l.records, *request)
+	return &auditLogger.RecordResponse{}, nil
+}
+
+var testSystemClaims = humanUserLoginClaims.Login{
+	UserId:    id.Identifier{Id: "system user"},
+	PartyType: party.System,
+}
+
+func newTestServer(t *testing.T, failRetrieval bool) (*server, *widgetStore, *fakeRetriever, *fakeAuditLogger) {
+	store := &widgetStore{widgets: map[string]widget{
+		"1": {Id: "1", Name: "before", Password: "old hash"},
+	}}
+	retriever := &fakeRetriever{store: store, fail: failRetrieval}
+	logger := &fakeAuditLogger{}
+	s := New(
+		"/api",
+		"",
+		"",
+		&fakeAuthoriser{},
+		logger,
+		map[jsonRpcServiceProvider.Name]auditRetriever.Retriever{
+			"Widget-RecordHandler": retriever,
+		},
+		testSystemClaims,
+	).(*server)
+	assert.NoError(t, s.RegisterBatchServiceProviders([]jsonRpcServiceProvider.Provider{
+		&widgetAdministrator{store: store},
+	}))
+	return s, store, retriever, logger
+}
+
+func updateWidget(s *server) *httptest.ResponseRecorder {
+	body, _ := json.Marshal(map[string]interface{}{
+		"id":     "1",
+		"method": "Widget-Administrator.Update",
+		"params": []interface{}{map[string]interface{}{
+			"widget": widget{Id: "1", Name: "after", Password: "new hash"},
+		}},
+	})
+	request := httptest.NewRequest("POST", "/api", bytes.NewReader(body))
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("Authorization", "jwt")
+	recorder := httptest.NewRecorder()
+	s.applyAuthorization(s.rpcServer).ServeHTTP(recorder, request)
+	return recorder
+}
+
+func TestAuditBeforeAndAfter(t *testing.T) {
+	s, store, retriever, logger := newTestServer(t, false)
+
+	recorder := updateWidget(s)
+	assert.Equal(t, netHttp.StatusOK, recorder.Code)
+	assert.Equal(t, "after", store.widgets["1"].Name)
+
+	if !assert.Len(t, logger.records, 1) {
+		return
+	}
+	record := logger.records[0]
+	assert.Equal(t, "Widget-Administrator.Update", record.Method)
+	assert.Equal(t, id.Identifier{Id: "1"}, record.Target)
+	assert.JSONEq(t, `{"id":"1","name":"before","password":"[redacted]"}`, string(record.Before))
+	assert.JSONEq(t, `{"id":"1","name":"after","password":"[redacted]"}`, string(record.After))
+	assert.Empty(t, record.StateErrors)
+
+	// the target is retrieved with the system claims, whatever the
+	// caller is permitted to retrieve
+	if assert.Len(t, retriever.requests, 2) {
+		for _, request := range retriever.requests {
+			assert.Equal(t, testSystemClaims, request.Claims)
+		}
+	}
+}
+
+func TestAuditTargetRetrievalFailure(t *testing.T) {
+	s, _, _, logger := newTestServer(t, true)
+
+	recorder := updateWidget(s)
+	assert.Equal(t, netHttp.StatusOK, recorder.Code)
+
+	if !assert.Len(t, logger.records, 1) {
+		return
+	}
+	// the state before the call could not be retrieved, which is recorded,
+	// and the state returned by the call is recorded in place of that after
+	assert.Nil(t, logger.records[0].Before)
+	assert.JSONEq(t, `{"id":"1","name":"after","password":"[redacted]"}`, string(logger.records[0].After))
+	assert.Equal(t, []string{"before: retrieval failed", "after: retrieval failed"}, logger.records[0].StateErrors)
+}
+
+func TestRequestBodyTooLarge(t *testing.T) {
+	s, _, _, logger := newTestServer(t, false)
+
+	request := httptest.NewRequest("POST", "/api", bytes.NewReader(make([]byte, maxRequestBodySize+1)))
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("Authorization", "jwt")
+	recorder := httptest.NewRecorder()
+	s.applyAuthorization(s.rpcServer).ServeHTTP(recorder, request)
+
+	assert.Equal(t, netHttp.StatusRequestEntityTooLarge, recorder.Code)
+	assert.Empty(t, logger.records)
+}
+
+func TestRedact(t *testing.T) {
+	redacted := redact(json.RawMessage(`{
+		"name": "user",
+		"password": "hash",
+		"totp": {"enabled": true, "secret": "secret"},
+		"backends": [{"id": "1", "token": "token"}],
+		"urlToken": "token",
+		"tokenId": "1"
+	}`))
+	assert.JSONEq(t, `{
+		"name": "user",
+		"password": "[redacted]",
+		"totp": {"enabled": true, "secret": "[redacted]"},
+		"backends": [{"id": "1", "token": "[redacted]"}],
+		"urlToken": "[redacted]",
+		"tokenId": "1"
+	}`, string(redacted))
+	assert.Nil(t, redact(nil))
+}
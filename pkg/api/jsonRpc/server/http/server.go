@@ -14,12 +14,23 @@ import (
 	server2 "github.com/iot-my-world/brain/pkg/api/jsonRpc/server"
 	jsonRpcServerAuthoriser "github.com/iot-my-world/brain/pkg/api/jsonRpc/server/authoriser"
 	jsonRpcServiceProvider "github.com/iot-my-world/brain/pkg/api/jsonRpc/service/provider"
+	auditLogger "github.com/iot-my-world/brain/pkg/audit/logger"
+	auditRetriever "github.com/iot-my-world/brain/pkg/audit/retriever"
+	"github.com/iot-my-world/brain/pkg/security/claims"
 	"io/ioutil"
 	netHttp "net/http"
 	"runtime/debug"
 	"strings"
 )
 
+// maxRequestBodySize is the largest request body, in bytes, which is
+// read in full to authorise, and audit, a call
+const maxRequestBodySize = 32 << 20
+
+// errRequestBodyTooLarge is returned on reading a request body
+// which is larger than the maximum
+var errRequestBodyTooLarge = errors.New("request body too large")
+
 type server struct {
 	path             string
 	host             string
@@ -28,6 +39,12 @@ type server struct {
 	authoriser       jsonRpcServerAuthoriser.Authoriser
 	serverMux        *mux.Router
 	serviceProviders map[jsonRpcServiceProvider.Name]jsonRpcServiceProvider.Provider
+	auditLogger      auditLogger.Logger
+	// auditTargetRetrievers retrieve the entities targeted by audited calls
+	// by the name of their record handler, e.g. Company-RecordHandler. They
+	// are retrieved with the system claims, whatever the caller may retrieve.
+	auditTargetRetrievers map[jsonRpcServiceProvider.Name]auditRetriever.Retriever
+	systemClaims          claims.Claims
 }
 
 func New(
@@ -35,17 +52,23 @@ func New(
 	host string,
 	port string,
 	authoriser jsonRpcServerAuthoriser.Authoriser,
+	auditLogger auditLogger.Logger,
+	auditTargetRetrievers map[jsonRpcServiceProvider.Name]auditRetriever.Retriever,
+	systemClaims claims.Claims,
 ) server2.Server {
 	rpcServer := rpc.NewServer()
 	rpcServer.RegisterCodec(cors.CodecWithCors([]string{"*"}, gorillaJson.NewCodec()), "application/json")
 	return &server{
-		path:             path,
-		host:             host,
-		port:             port,
-		serverMux:        mux.NewRouter(),
-		rpcServer:        rpcServer,
-		authoriser:       authoriser,
-		serviceProviders: make(map[jsonRpcServiceProvider.Name]jsonRpcServiceProvider.Provider),
+		path:                  path,
+		host:                  host,
+		port:                  port,
+		serverMux:             mux.NewRouter(),
+		rpcServer:             rpcServer,
+		authoriser:            authoriser,
+		serviceProviders:      make(map[jsonRpcServiceProvider.Name]jsonRpcServiceProvider.Provider),
+		auditLogger:           auditLogger,
+		auditTargetRetrievers: auditTargetRetrievers,
+		systemClaims:          systemClaims,
 	}
 }
 
@@ -96,10 +119,24 @@ func (s *server) RegisterBatchServiceProviders(serviceProviders []jsonRpcService
 	return nil
 }
 
+func (s *server) RegisterHandler(path string, handler netHttp.Handler) {
+	s.serverMux.Handle(path, handler)
+}
+
 func (s *server) applyAuthorization(next netHttp.Handler) netHttp.Handler {
 	return netHttp.HandlerFunc(func(w netHttp.ResponseWriter, r *netHttp.Request) {
+		// the body is read in full to determine the service method, and
+		// again for the audit log, and so its size is limited
+		if r.Body != nil {
+			r.Body = netHttp.MaxBytesReader(w, r.Body, maxRequestBodySize)
+		}
+
 		// Retrieve json rpc service method from request body
 		serviceProvider, jsonRpcServiceMethod, err := s.getServiceProvider(r)
+		if err == errRequestBodyTooLarge {
+			netHttp.Error(w, err.Error(), netHttp.StatusRequestEntityTooLarge)
+			return
+		}
 		if err != nil {
 			// if it can't be retrieved, error 404
 			netHttp.Error(w, err.Error(), netHttp.StatusNotFound)
@@ -124,6 +161,18 @@ func (s *server) applyAuthorization(next netHttp.Handler) netHttp.Handler {
 		jwt := r.Header["Authorization"][0]
 		if wrappedClaims, err := s.authoriser.AuthoriseServiceMethod(jwt, jsonRpcServiceMethod); err == nil {
 			ctx := context.WithValue(r.Context(), "wrappedClaims", wrappedClaims)
+
+			// calls to methods which could change the state of
+			// the system are recorded in the audit log, if there is one
+			if s.auditLogger != nil && !auditLogger.ReadOnly(jsonRpcServiceMethod) {
+				if unwrappedClaims, err := wrappedClaims.Unwrap(); err == nil {
+					s.serveAndAudit(next, w, r.WithContext(ctx), jsonRpcServiceMethod, unwrappedClaims)
+					return
+				} else {
+					log.Warn("unable to unwrap claims for audit log: " + err.Error())
+				}
+			}
+
 			next.ServeHTTP(w, r.WithContext(ctx))
 			return
 		} else {
@@ -142,8 +191,10 @@ func (s *server) getServiceProvider(r *netHttp.Request) (jsonRpcServiceProvider.
 	}
 
 	// Extract body of http Request
-	var bodyBytes []byte
-	bodyBytes, _ = ioutil.ReadAll(r.Body)
+	bodyBytes, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		return nil, "", errRequestBodyTooLarge
+	}
 
 	// Reset body of request
 	r.Body = ioutil.NopCloser(bytes.NewBuffer(bodyBytes))
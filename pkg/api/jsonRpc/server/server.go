@@ -2,6 +2,7 @@ package server
 
 import (
 	jsonRpcServiceProvider "github.com/iot-my-world/brain/pkg/api/jsonRpc/service/provider"
+	"net/http"
 )
 
 type Server interface {
@@ -9,4 +10,7 @@ type Server interface {
 	SecureStart() error
 	RegisterServiceProvider(jsonRpcServiceProvider.Provider) error
 	RegisterBatchServiceProviders([]jsonRpcServiceProvider.Provider) error
+	// RegisterHandler registers a handler to be served alongside the
+	// json rpc api, for responses which cannot be given over json rpc
+	RegisterHandler(path string, handler http.Handler)
 }
@@ -0,0 +1,45 @@
+package entry
+
+import (
+	"github.com/iot-my-world/brain/pkg/party"
+	"github.com/iot-my-world/brain/pkg/search/identifier/id"
+	wrappedIdentifier "github.com/iot-my-world/brain/pkg/search/identifier/wrapped"
+	"github.com/iot-my-world/brain/pkg/security/claims"
+)
+
+// Entry is a record of a call made to a service
+// method which could have changed the state of the system
+type Entry struct {
+	Id string `json:"id" bson:"id"`
+
+	// Owner Details, the party on whose behalf the call was made
+	OwnerPartyType party.Type    `json:"ownerPartyType" bson:"ownerPartyType"`
+	OwnerId        id.Identifier `json:"ownerId" bson:"ownerId"`
+
+	// Caller Details
+	UserId     id.Identifier `json:"userId" bson:"userId"`
+	ClaimsType claims.Type   `json:"claimsType" bson:"claimsType"`
+
+	// Call Details. The target is identified by the identifier
+	// given in the call, which may not be an id, e.g. a username,
+	// and by its id if that is known.
+	Method           string                     `json:"method" bson:"method"`
+	TargetIdentifier *wrappedIdentifier.Wrapped `json:"targetIdentifier" bson:"targetIdentifier"`
+	TargetId         id.Identifier              `json:"targetId" bson:"targetId"`
+	Diff             []Change                   `json:"diff" bson:"diff"`
+	StateErrors      []string                   `json:"stateErrors" bson:"stateErrors"`
+	Error            string                     `json:"error" bson:"error"`
+	TimeStamp        int64                      `json:"timeStamp" bson:"timeStamp"`
+}
+
+// Change is the before and after value of a field of
+// the entity targeted by the call
+type Change struct {
+	Field  string      `json:"field" bson:"field"`
+	Before interface{} `json:"before" bson:"before"`
+	After  interface{} `json:"after" bson:"after"`
+}
+
+func (e *Entry) SetId(id string) {
+	e.Id = id
+}
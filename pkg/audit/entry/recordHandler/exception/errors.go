@@ -0,0 +1,55 @@
+package exception
+
+import "strings"
+
+type RecordHandlerNil struct{}
+
+func (e RecordHandlerNil) Error() string {
+	return "given brain audit entry recordHandler is nil"
+}
+
+type NotFound struct{}
+
+func (e NotFound) Error() string {
+	return "audit entry not found"
+}
+
+type Create struct {
+	Reasons []string
+}
+
+func (e Create) Error() string {
+	return "audit entry creation error: " + strings.Join(e.Reasons, "; ")
+}
+
+type Retrieve struct {
+	Reasons []string
+}
+
+func (e Retrieve) Error() string {
+	return "audit entry retrieval error: " + strings.Join(e.Reasons, "; ")
+}
+
+type Update struct {
+	Reasons []string
+}
+
+func (e Update) Error() string {
+	return "audit entry update error: " + strings.Join(e.Reasons, "; ")
+}
+
+type Delete struct {
+	Reasons []string
+}
+
+func (e Delete) Error() string {
+	return "audit entry delete error: " + strings.Join(e.Reasons, "; ")
+}
+
+type Collect struct {
+	Reasons []string
+}
+
+func (e Collect) Error() string {
+	return "audit entry collect error: " + strings.Join(e.Reasons, "; ")
+}
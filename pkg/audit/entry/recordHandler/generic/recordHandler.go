@@ -0,0 +1,133 @@
+package auditEntryRecordHandler
+
+import (
+	brainException "github.com/iot-my-world/brain/internal/exception"
+	"github.com/iot-my-world/brain/pkg/audit/entry"
+	auditEntryRecordHandler "github.com/iot-my-world/brain/pkg/audit/entry/recordHandler"
+	auditEntryRecordHandlerException "github.com/iot-my-world/brain/pkg/audit/entry/recordHandler/exception"
+	brainRecordHandler "github.com/iot-my-world/brain/pkg/recordHandler"
+	brainRecordHandlerException "github.com/iot-my-world/brain/pkg/recordHandler/exception"
+)
+
+type RecordHandler struct {
+	auditEntryRecordHandler brainRecordHandler.RecordHandler
+}
+
+func New(
+	brainEntryRecordHandler brainRecordHandler.RecordHandler,
+) auditEntryRecordHandler.RecordHandler {
+
+	return &RecordHandler{
+		auditEntryRecordHandler: brainEntryRecordHandler,
+	}
+}
+
+type CreateRequest struct {
+	Entry entry.Entry
+}
+
+type CreateResponse struct {
+	Entry entry.Entry
+}
+
+func (r *RecordHandler) ValidateCreateRequest(request *auditEntryRecordHandler.CreateRequest) error {
+	reasonsInvalid := make([]string, 0)
+
+	if len(reasonsInvalid) > 0 {
+		return brainException.RequestInvalid{Reasons: reasonsInvalid}
+	}
+
+	return nil
+}
+
+func (r *RecordHandler) Create(request *auditEntryRecordHandler.CreateRequest) (*auditEntryRecordHandler.CreateResponse, error) {
+	if err := r.ValidateCreateRequest(request); err != nil {
+		return nil, err
+	}
+
+	createResponse := brainRecordHandler.CreateResponse{}
+	if err := r.auditEntryRecordHandler.Create(&brainRecordHandler.CreateRequest{
+		Entity: &request.Entry,
+	}, &createResponse); err != nil {
+		return nil, auditEntryRecordHandlerException.Create{Reasons: []string{err.Error()}}
+	}
+	createdEntry, ok := createResponse.Entity.(*entry.Entry)
+	if !ok {
+		return nil, auditEntryRecordHandlerException.Create{Reasons: []string{"could not cast created entity to audit entry"}}
+	}
+
+	return &auditEntryRecordHandler.CreateResponse{
+		Entry: *createdEntry,
+	}, nil
+}
+
+func (r *RecordHandler) Retrieve(request *auditEntryRecordHandler.RetrieveRequest) (*auditEntryRecordHandler.RetrieveResponse, error) {
+	retrievedEntry := entry.Entry{}
+	retrieveResponse := brainRecordHandler.RetrieveResponse{
+		Entity: &retrievedEntry,
+	}
+	if err := r.auditEntryRecordHandler.Retrieve(&brainRecordHandler.RetrieveRequest{
+		Claims:     request.Claims,
+		Identifier: request.Identifier,
+	}, &retrieveResponse); err != nil {
+		switch err.(type) {
+		case brainRecordHandlerException.NotFound:
+			return nil, auditEntryRecordHandlerException.NotFound{}
+		default:
+			return nil, err
+		}
+	}
+
+	return &auditEntryRecordHandler.RetrieveResponse{
+		Entry: retrievedEntry,
+	}, nil
+}
+
+func (r *RecordHandler) Update(request *auditEntryRecordHandler.UpdateRequest) (*auditEntryRecordHandler.UpdateResponse, error) {
+	updateResponse := brainRecordHandler.UpdateResponse{}
+	if err := r.auditEntryRecordHandler.Update(&brainRecordHandler.UpdateRequest{
+		Claims:     request.Claims,
+		Identifier: request.Identifier,
+		Entity:     &request.Entry,
+	}, &updateResponse); err != nil {
+		return nil, auditEntryRecordHandlerException.Update{Reasons: []string{err.Error()}}
+	}
+
+	return &auditEntryRecordHandler.UpdateResponse{}, nil
+}
+
+func (r *RecordHandler) Delete(request *auditEntryRecordHandler.DeleteRequest) (*auditEntryRecordHandler.DeleteResponse, error) {
+	deleteResponse := brainRecordHandler.DeleteResponse{}
+	if err := r.auditEntryRecordHandler.Delete(&brainRecordHandler.DeleteRequest{
+		Claims:     request.Claims,
+		Identifier: request.Identifier,
+	}, &deleteResponse); err != nil {
+		return nil, auditEntryRecordHandlerException.Delete{Reasons: []string{err.Error()}}
+	}
+
+	return &auditEntryRecordHandler.DeleteResponse{}, nil
+}
+
+func (r *RecordHandler) Collect(request *auditEntryRecordHandler.CollectRequest) (*auditEntryRecordHandler.CollectResponse, error) {
+	var collectedEntry []entry.Entry
+	collectResponse := brainRecordHandler.CollectResponse{
+		Records: &collectedEntry,
+	}
+	err := r.auditEntryRecordHandler.Collect(&brainRecordHandler.CollectRequest{
+		Claims:   request.Claims,
+		Criteria: request.Criteria,
+		Query:    request.Query,
+	}, &collectResponse)
+	if err != nil {
+		return nil, auditEntryRecordHandlerException.Collect{Reasons: []string{err.Error()}}
+	}
+
+	if collectedEntry == nil {
+		collectedEntry = make([]entry.Entry, 0)
+	}
+
+	return &auditEntryRecordHandler.CollectResponse{
+		Records: collectedEntry,
+		Total:   collectResponse.Total,
+	}, nil
+}
@@ -0,0 +1,37 @@
+package mongo
+
+import (
+	"github.com/iot-my-world/brain/pkg/audit/entry"
+	auditEntryRecordHandler "github.com/iot-my-world/brain/pkg/audit/entry/recordHandler"
+	auditEntryGenericRecordHandler "github.com/iot-my-world/brain/pkg/audit/entry/recordHandler/generic"
+	brainMongoRecordHandler "github.com/iot-my-world/brain/pkg/recordHandler/mongo"
+	"github.com/iot-my-world/brain/pkg/security/claims"
+	"gopkg.in/mgo.v2"
+)
+
+func New(
+	mongoSession *mgo.Session,
+	databaseName string,
+	collectionName string,
+) auditEntryRecordHandler.RecordHandler {
+	mongoRecordHandler := brainMongoRecordHandler.New(
+		mongoSession,
+		databaseName,
+		collectionName,
+		[]mgo.Index{
+			{
+				Key:    []string{"id"},
+				Unique: true,
+			},
+			{
+				Key: []string{"ownerId.id", "timeStamp"},
+			},
+		},
+		entry.IsValidIdentifier,
+		claims.ContextualiseFilter,
+	)
+
+	return auditEntryGenericRecordHandler.New(
+		mongoRecordHandler,
+	)
+}
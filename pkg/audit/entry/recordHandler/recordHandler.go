@@ -0,0 +1,79 @@
+package recordHandler
+
+import (
+	"github.com/iot-my-world/brain/pkg/audit/entry"
+	"github.com/iot-my-world/brain/pkg/search/criterion"
+	"github.com/iot-my-world/brain/pkg/search/identifier"
+	"github.com/iot-my-world/brain/pkg/search/query"
+	"github.com/iot-my-world/brain/pkg/security/claims"
+	"github.com/iot-my-world/brain/pkg/security/permission/api"
+)
+
+type RecordHandler interface {
+	Create(*CreateRequest) (*CreateResponse, error)
+	Retrieve(*RetrieveRequest) (*RetrieveResponse, error)
+	Update(*UpdateRequest) (*UpdateResponse, error)
+	Delete(*DeleteRequest) (*DeleteResponse, error)
+	Collect(*CollectRequest) (*CollectResponse, error)
+}
+
+const ServiceProvider = "AuditEntry-RecordHandler"
+const CreateService = ServiceProvider + ".Create"
+const RetrieveService = ServiceProvider + ".Retrieve"
+const UpdateService = ServiceProvider + ".Update"
+const DeleteService = ServiceProvider + ".Delete"
+const CollectService = ServiceProvider + ".Collect"
+
+var SystemUserPermissions = make([]api.Permission, 0)
+
+var CompanyAdminUserPermissions = make([]api.Permission, 0)
+
+var CompanyUserPermissions = make([]api.Permission, 0)
+
+var ClientAdminUserPermissions = make([]api.Permission, 0)
+
+var ClientUserPermissions = make([]api.Permission, 0)
+
+type CreateRequest struct {
+	Entry entry.Entry
+}
+
+type CreateResponse struct {
+	Entry entry.Entry
+}
+
+type RetrieveRequest struct {
+	Claims     claims.Claims
+	Identifier identifier.Identifier
+}
+
+type RetrieveResponse struct {
+	Entry entry.Entry
+}
+
+type UpdateRequest struct {
+	Claims     claims.Claims
+	Identifier identifier.Identifier
+	Entry      entry.Entry
+}
+
+type UpdateResponse struct{}
+
+type DeleteRequest struct {
+	Claims     claims.Claims
+	Identifier identifier.Identifier
+}
+
+type DeleteResponse struct {
+}
+
+type CollectRequest struct {
+	Claims   claims.Claims
+	Criteria []criterion.Criterion
+	Query    query.Query
+}
+
+type CollectResponse struct {
+	Records []entry.Entry
+	Total   int
+}
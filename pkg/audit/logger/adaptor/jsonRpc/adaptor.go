@@ -0,0 +1,71 @@
+package jsonRpc
+
+import (
+	"github.com/iot-my-world/brain/internal/log"
+	jsonRpcServiceProvider "github.com/iot-my-world/brain/pkg/api/jsonRpc/service/provider"
+	"github.com/iot-my-world/brain/pkg/audit/entry"
+	auditLogger "github.com/iot-my-world/brain/pkg/audit/logger"
+	"github.com/iot-my-world/brain/pkg/search/criterion"
+	wrappedCriterion "github.com/iot-my-world/brain/pkg/search/criterion/wrapped"
+	"github.com/iot-my-world/brain/pkg/search/query"
+	wrappedClaims "github.com/iot-my-world/brain/pkg/security/claims/wrapped"
+	"net/http"
+)
+
+type adaptor struct {
+	logger auditLogger.Logger
+}
+
+func New(logger auditLogger.Logger) *adaptor {
+	return &adaptor{
+		logger: logger,
+	}
+}
+
+func (a *adaptor) Name() jsonRpcServiceProvider.Name {
+	return jsonRpcServiceProvider.Name(auditLogger.ServiceProvider)
+}
+
+func (a *adaptor) MethodRequiresAuthorization(string) bool {
+	return true
+}
+
+type CollectRequest struct {
+	Criteria []wrappedCriterion.Wrapped `json:"criteria"`
+	Query    query.Query                `json:"query"`
+}
+
+type CollectResponse struct {
+	Records []entry.Entry `json:"records"`
+	Total   int           `json:"total"`
+}
+
+func (a *adaptor) Collect(r *http.Request, request *CollectRequest, response *CollectResponse) error {
+	claims, err := wrappedClaims.UnwrapClaimsFromContext(r)
+	if err != nil {
+		log.Warn(err.Error())
+		return err
+	}
+
+	criteria := make([]criterion.Criterion, 0)
+	for criterionIdx := range request.Criteria {
+		if c, err := request.Criteria[criterionIdx].UnWrap(); err == nil {
+			criteria = append(criteria, c)
+		} else {
+			return err
+		}
+	}
+
+	collectResponse, err := a.logger.Collect(&auditLogger.CollectRequest{
+		Claims:   claims,
+		Criteria: criteria,
+		Query:    request.Query,
+	})
+	if err != nil {
+		return err
+	}
+
+	response.Records = collectResponse.Records
+	response.Total = collectResponse.Total
+	return nil
+}
@@ -0,0 +1,247 @@
+package basic
+
+import (
+	"encoding/json"
+	brainException "github.com/iot-my-world/brain/internal/exception"
+	"github.com/iot-my-world/brain/internal/log"
+	"github.com/iot-my-world/brain/pkg/audit/entry"
+	auditEntryRecordHandler "github.com/iot-my-world/brain/pkg/audit/entry/recordHandler"
+	auditLogger "github.com/iot-my-world/brain/pkg/audit/logger"
+	auditLoggerException "github.com/iot-my-world/brain/pkg/audit/logger/exception"
+	"github.com/iot-my-world/brain/pkg/search/identifier/id"
+	wrappedIdentifier "github.com/iot-my-world/brain/pkg/search/identifier/wrapped"
+	"github.com/iot-my-world/brain/pkg/security/claims"
+	apiUserLoginClaims "github.com/iot-my-world/brain/pkg/security/claims/login/user/api"
+	humanUserLoginClaims "github.com/iot-my-world/brain/pkg/security/claims/login/user/human"
+	registerClientAdminUserClaims "github.com/iot-my-world/brain/pkg/security/claims/registerClientAdminUser"
+	registerClientUserClaims "github.com/iot-my-world/brain/pkg/security/claims/registerClientUser"
+	registerCompanyAdminUserClaims "github.com/iot-my-world/brain/pkg/security/claims/registerCompanyAdminUser"
+	registerCompanyUserClaims "github.com/iot-my-world/brain/pkg/security/claims/registerCompanyUser"
+	resetPasswordClaims "github.com/iot-my-world/brain/pkg/security/claims/resetPassword"
+	sigfoxBackendClaims "github.com/iot-my-world/brain/pkg/security/claims/sigfoxBackend"
+	verifyTOTPClaims "github.com/iot-my-world/brain/pkg/security/claims/verifyTOTP"
+	"reflect"
+	"sort"
+	"time"
+)
+
+// redactedFields are fields of which the values are
+// never written to the log, only that they changed
+var redactedFields = map[string]bool{
+	"password": true,
+	"secret":   true,
+}
+
+const redacted = "[REDACTED]"
+
+type logger struct {
+	auditEntryRecordHandler auditEntryRecordHandler.RecordHandler
+}
+
+func New(
+	auditEntryRecordHandler auditEntryRecordHandler.RecordHandler,
+) auditLogger.Logger {
+	return &logger{
+		auditEntryRecordHandler: auditEntryRecordHandler,
+	}
+}
+
+func (l *logger) ValidateRecordRequest(request *auditLogger.RecordRequest) error {
+	reasonsInvalid := make([]string, 0)
+
+	if request.Claims == nil {
+		reasonsInvalid = append(reasonsInvalid, "claims are nil")
+	}
+
+	if request.Method == "" {
+		reasonsInvalid = append(reasonsInvalid, "method is blank")
+	}
+
+	if len(reasonsInvalid) > 0 {
+		return brainException.RequestInvalid{Reasons: reasonsInvalid}
+	}
+	return nil
+}
+
+func (l *logger) Record(request *auditLogger.RecordRequest) (*auditLogger.RecordResponse, error) {
+	if err := l.ValidateRecordRequest(request); err != nil {
+		log.Error(err.Error())
+		return nil, err
+	}
+
+	before, err := unmarshalState(request.Before)
+	if err != nil {
+		log.Error(err.Error())
+		return nil, err
+	}
+	after, err := unmarshalState(request.After)
+	if err != nil {
+		log.Error(err.Error())
+		return nil, err
+	}
+
+	// keep the given identifier of the target, whatever its type
+	var targetIdentifier *wrappedIdentifier.Wrapped
+	if request.Target != nil {
+		targetIdentifier, err = wrappedIdentifier.Wrap(request.Target)
+		if err != nil {
+			err = auditLoggerException.TargetWrapping{Reasons: []string{err.Error()}}
+			log.Error(err.Error())
+			return nil, err
+		}
+	}
+
+	// determine the id of the target, preferring the given identifier
+	// and otherwise falling back to the id in the state of the target
+	var targetId id.Identifier
+	if idIdentifier, ok := request.Target.(id.Identifier); ok {
+		targetId = idIdentifier
+	} else if afterId, ok := after["id"].(string); ok && afterId != "" {
+		targetId = id.Identifier{Id: afterId}
+	} else if beforeId, ok := before["id"].(string); ok && beforeId != "" {
+		targetId = id.Identifier{Id: beforeId}
+	}
+
+	createResponse, err := l.auditEntryRecordHandler.Create(&auditEntryRecordHandler.CreateRequest{
+		Entry: entry.Entry{
+			OwnerPartyType:   request.Claims.PartyDetails().PartyType,
+			OwnerId:          request.Claims.PartyDetails().PartyId,
+			UserId:           userId(request.Claims),
+			ClaimsType:       request.Claims.Type(),
+			Method:           request.Method,
+			TargetIdentifier: targetIdentifier,
+			TargetId:         targetId,
+			Diff:             diff(before, after),
+			StateErrors:      request.StateErrors,
+			Error:            request.Error,
+			TimeStamp:        time.Now().UTC().Unix(),
+		},
+	})
+	if err != nil {
+		err = auditLoggerException.EntryCreation{Reasons: []string{err.Error()}}
+		log.Error(err.Error())
+		return nil, err
+	}
+
+	return &auditLogger.RecordResponse{
+		Entry: createResponse.Entry,
+	}, nil
+}
+
+func (l *logger) ValidateCollectRequest(request *auditLogger.CollectRequest) error {
+	reasonsInvalid := make([]string, 0)
+
+	if request.Claims == nil {
+		reasonsInvalid = append(reasonsInvalid, "claims are nil")
+	}
+
+	if len(reasonsInvalid) > 0 {
+		return brainException.RequestInvalid{Reasons: reasonsInvalid}
+	}
+	return nil
+}
+
+// Collect returns the audit entries in the context of the given claims.
+// System users see all entries and other parties only their own.
+func (l *logger) Collect(request *auditLogger.CollectRequest) (*auditLogger.CollectResponse, error) {
+	if err := l.ValidateCollectRequest(request); err != nil {
+		log.Error(err.Error())
+		return nil, err
+	}
+
+	collectResponse, err := l.auditEntryRecordHandler.Collect(&auditEntryRecordHandler.CollectRequest{
+		Claims:   request.Claims,
+		Criteria: request.Criteria,
+		Query:    request.Query,
+	})
+	if err != nil {
+		err = auditLoggerException.EntryCollection{Reasons: []string{err.Error()}}
+		log.Error(err.Error())
+		return nil, err
+	}
+
+	return &auditLogger.CollectResponse{
+		Records: collectResponse.Records,
+		Total:   collectResponse.Total,
+	}, nil
+}
+
+// unmarshalState unmarshals the given json marshalled state of an entity.
+// No state, or a state which is not an object, results in an empty map.
+func unmarshalState(state json.RawMessage) (map[string]interface{}, error) {
+	result := make(map[string]interface{})
+	if len(state) == 0 {
+		return result, nil
+	}
+
+	var unmarshalledState interface{}
+	if err := json.Unmarshal(state, &unmarshalledState); err != nil {
+		return nil, auditLoggerException.StateUnmarshalling{Reasons: []string{err.Error()}}
+	}
+	if object, ok := unmarshalledState.(map[string]interface{}); ok {
+		result = object
+	}
+
+	return result, nil
+}
+
+// diff returns the changes to the top level fields
+// between the given before and after states
+func diff(before, after map[string]interface{}) []entry.Change {
+	fields := make([]string, 0)
+	for field := range before {
+		fields = append(fields, field)
+	}
+	for field := range after {
+		if _, found := before[field]; !found {
+			fields = append(fields, field)
+		}
+	}
+	sort.Strings(fields)
+
+	changes := make([]entry.Change, 0)
+	for _, field := range fields {
+		if reflect.DeepEqual(before[field], after[field]) {
+			continue
+		}
+		change := entry.Change{
+			Field:  field,
+			Before: before[field],
+			After:  after[field],
+		}
+		if redactedFields[field] {
+			change.Before = redacted
+			change.After = redacted
+		}
+		changes = append(changes, change)
+	}
+
+	return changes
+}
+
+// userId returns the id of the user, or other entity,
+// to which a token with the given claims was issued
+func userId(claimsToCheck claims.Claims) id.Identifier {
+	switch typedClaims := claimsToCheck.(type) {
+	case humanUserLoginClaims.Login:
+		return typedClaims.UserId
+	case apiUserLoginClaims.Login:
+		return typedClaims.UserId
+	case resetPasswordClaims.ResetPassword:
+		return typedClaims.UserId
+	case registerCompanyAdminUserClaims.RegisterCompanyAdminUser:
+		return id.Identifier{Id: typedClaims.User.Id}
+	case registerCompanyUserClaims.RegisterCompanyUser:
+		return id.Identifier{Id: typedClaims.User.Id}
+	case registerClientAdminUserClaims.RegisterClientAdminUser:
+		return id.Identifier{Id: typedClaims.User.Id}
+	case registerClientUserClaims.RegisterClientUser:
+		return id.Identifier{Id: typedClaims.User.Id}
+	case sigfoxBackendClaims.SigfoxBackend:
+		return typedClaims.BackendId
+	case verifyTOTPClaims.VerifyTOTP:
+		return typedClaims.UserId
+	default:
+		return id.Identifier{}
+	}
+}
@@ -0,0 +1,35 @@
+package exception
+
+import "strings"
+
+type EntryCreation struct {
+	Reasons []string
+}
+
+func (e EntryCreation) Error() string {
+	return "error creating audit entry: " + strings.Join(e.Reasons, "; ")
+}
+
+type EntryCollection struct {
+	Reasons []string
+}
+
+func (e EntryCollection) Error() string {
+	return "error collecting audit entries: " + strings.Join(e.Reasons, "; ")
+}
+
+type StateUnmarshalling struct {
+	Reasons []string
+}
+
+func (e StateUnmarshalling) Error() string {
+	return "error unmarshalling audit target state: " + strings.Join(e.Reasons, "; ")
+}
+
+type TargetWrapping struct {
+	Reasons []string
+}
+
+func (e TargetWrapping) Error() string {
+	return "error wrapping audit target identifier: " + strings.Join(e.Reasons, "; ")
+}
@@ -0,0 +1,78 @@
+package jsonRpc
+
+import (
+	brainException "github.com/iot-my-world/brain/internal/exception"
+	"github.com/iot-my-world/brain/internal/log"
+	jsonRpcClient "github.com/iot-my-world/brain/pkg/api/jsonRpc/client"
+	auditLogger "github.com/iot-my-world/brain/pkg/audit/logger"
+	auditLoggerJsonRpcAdaptor "github.com/iot-my-world/brain/pkg/audit/logger/adaptor/jsonRpc"
+	wrappedCriterion "github.com/iot-my-world/brain/pkg/search/criterion/wrapped"
+)
+
+type logger struct {
+	jsonRpcClient jsonRpcClient.Client
+}
+
+func New(
+	jsonRpcClient jsonRpcClient.Client,
+) auditLogger.Logger {
+	return &logger{
+		jsonRpcClient: jsonRpcClient,
+	}
+}
+
+func (l *logger) Record(request *auditLogger.RecordRequest) (*auditLogger.RecordResponse, error) {
+	return nil, brainException.NotImplemented{}
+}
+
+func (l *logger) ValidateCollectRequest(request *auditLogger.CollectRequest) error {
+	reasonsInvalid := make([]string, 0)
+
+	if request.Criteria == nil {
+		reasonsInvalid = append(reasonsInvalid, "criteria is nil")
+	} else {
+		for _, crit := range request.Criteria {
+			if crit == nil {
+				reasonsInvalid = append(reasonsInvalid, "a criterion is nil")
+			}
+		}
+	}
+
+	if len(reasonsInvalid) > 0 {
+		return brainException.RequestInvalid{Reasons: reasonsInvalid}
+	}
+	return nil
+}
+
+func (l *logger) Collect(request *auditLogger.CollectRequest) (*auditLogger.CollectResponse, error) {
+	if err := l.ValidateCollectRequest(request); err != nil {
+		return nil, err
+	}
+
+	// wrap criteria
+	criteria := make([]wrappedCriterion.Wrapped, 0)
+	for _, crit := range request.Criteria {
+		wrapped, err := wrappedCriterion.Wrap(crit)
+		if err != nil {
+			log.Error(err.Error())
+			return nil, err
+		}
+		criteria = append(criteria, *wrapped)
+	}
+
+	collectResponse := auditLoggerJsonRpcAdaptor.CollectResponse{}
+	if err := l.jsonRpcClient.JsonRpcRequest(
+		auditLogger.CollectService,
+		auditLoggerJsonRpcAdaptor.CollectRequest{
+			Criteria: criteria,
+			Query:    request.Query,
+		},
+		&collectResponse); err != nil {
+		return nil, err
+	}
+
+	return &auditLogger.CollectResponse{
+		Records: collectResponse.Records,
+		Total:   collectResponse.Total,
+	}, nil
+}
@@ -0,0 +1,99 @@
+package logger
+
+import (
+	"encoding/json"
+	"github.com/iot-my-world/brain/pkg/audit/entry"
+	"github.com/iot-my-world/brain/pkg/search/criterion"
+	"github.com/iot-my-world/brain/pkg/search/identifier"
+	"github.com/iot-my-world/brain/pkg/search/query"
+	"github.com/iot-my-world/brain/pkg/security/claims"
+	"github.com/iot-my-world/brain/pkg/security/permission/api"
+	"strings"
+)
+
+// Logger keeps a log of the calls made to service
+// methods which could change the state of the system
+type Logger interface {
+	Record(request *RecordRequest) (*RecordResponse, error)
+	Collect(request *CollectRequest) (*CollectResponse, error)
+}
+
+const ServiceProvider = "Audit-Log"
+const CollectService = ServiceProvider + ".Collect"
+
+var SystemUserPermissions = []api.Permission{
+	CollectService,
+}
+
+var CompanyAdminUserPermissions = []api.Permission{
+	CollectService,
+}
+
+var CompanyUserPermissions = make([]api.Permission, 0)
+
+var ClientAdminUserPermissions = []api.Permission{
+	CollectService,
+}
+
+var ClientUserPermissions = make([]api.Permission, 0)
+
+// readOnlyMethodPrefixes are the prefixes of the names
+// of service methods which do not change the state of the system
+var readOnlyMethodPrefixes = []string{
+	"Retrieve",
+	"Collect",
+	"Validate",
+	"Get",
+	"Check",
+	"Are",
+	"Live",
+	"Historical",
+}
+
+// ReadOnly returns true if the given json rpc service method,
+// e.g. Company-RecordHandler.Retrieve, does not change the
+// state of the system and so does not need to be audited
+func ReadOnly(serviceMethod string) bool {
+	method := serviceMethod[strings.LastIndex(serviceMethod, ".")+1:]
+	for _, prefix := range readOnlyMethodPrefixes {
+		if strings.HasPrefix(method, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+type RecordRequest struct {
+	Claims claims.Claims
+	Method string
+
+	// Target identifies the entity targeted by the call, if known
+	Target identifier.Identifier
+
+	// Before and After are the json marshalled
+	// state of the target before and after the call
+	Before json.RawMessage
+	After  json.RawMessage
+
+	// StateErrors are the reasons for which the state
+	// of the target could not be retrieved, if any
+	StateErrors []string
+
+	// Error returned by the call, if any
+	Error string
+}
+
+type RecordResponse struct {
+	Entry entry.Entry
+}
+
+type CollectRequest struct {
+	Claims   claims.Claims
+	Criteria []criterion.Criterion
+	Query    query.Query
+}
+
+type CollectResponse struct {
+	Records []entry.Entry
+	Total   int
+}
@@ -0,0 +1,19 @@
+package exception
+
+import "strings"
+
+type InvalidRecordHandler struct {
+	Reasons []string
+}
+
+func (e InvalidRecordHandler) Error() string {
+	return "invalid record handler for audit target retrieval: " + strings.Join(e.Reasons, "; ")
+}
+
+type Retrieval struct {
+	Reasons []string
+}
+
+func (e Retrieval) Error() string {
+	return "error retrieving audit target: " + strings.Join(e.Reasons, "; ")
+}
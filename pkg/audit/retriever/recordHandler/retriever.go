@@ -0,0 +1,99 @@
+package recordHandler
+
+import (
+	brainException "github.com/iot-my-world/brain/internal/exception"
+	"github.com/iot-my-world/brain/internal/log"
+	auditRetriever "github.com/iot-my-world/brain/pkg/audit/retriever"
+	auditRetrieverException "github.com/iot-my-world/brain/pkg/audit/retriever/exception"
+	"github.com/iot-my-world/brain/pkg/search/identifier"
+	"github.com/iot-my-world/brain/pkg/security/claims"
+	"reflect"
+)
+
+var claimsType = reflect.TypeOf((*claims.Claims)(nil)).Elem()
+var identifierType = reflect.TypeOf((*identifier.Identifier)(nil)).Elem()
+var errorType = reflect.TypeOf((*error)(nil)).Elem()
+
+// retriever retrieves entities with the Retrieve method of an entity
+// record handler, e.g. Company-RecordHandler. Every record handler has
+// a method of the same shape, but with request and response types of
+// its own, and so the method is called by reflection.
+type retriever struct {
+	retrieveMethod reflect.Value
+	requestType    reflect.Type
+}
+
+// New returns a retriever of the entities of the given record handler.
+// The record handler must have a Retrieve method which takes a request
+// with Claims and Identifier fields and returns a response of which the
+// first field is the entity, as every entity record handler does.
+func New(
+	recordHandler interface{},
+) (auditRetriever.Retriever, error) {
+	retrieveMethod := reflect.ValueOf(recordHandler).MethodByName("Retrieve")
+	if !retrieveMethod.IsValid() {
+		return nil, auditRetrieverException.InvalidRecordHandler{Reasons: []string{"no Retrieve method"}}
+	}
+
+	methodType := retrieveMethod.Type()
+	if methodType.NumIn() != 1 || methodType.NumOut() != 2 ||
+		methodType.In(0).Kind() != reflect.Ptr || methodType.In(0).Elem().Kind() != reflect.Struct ||
+		methodType.Out(0).Kind() != reflect.Ptr || methodType.Out(0).Elem().Kind() != reflect.Struct ||
+		methodType.Out(0).Elem().NumField() == 0 ||
+		methodType.Out(1) != errorType {
+		return nil, auditRetrieverException.InvalidRecordHandler{Reasons: []string{"unexpected Retrieve method signature", methodType.String()}}
+	}
+
+	requestType := methodType.In(0).Elem()
+	if field, found := requestType.FieldByName("Claims"); !found || field.Type != claimsType {
+		return nil, auditRetrieverException.InvalidRecordHandler{Reasons: []string{"no Claims field in request"}}
+	}
+	if field, found := requestType.FieldByName("Identifier"); !found || field.Type != identifierType {
+		return nil, auditRetrieverException.InvalidRecordHandler{Reasons: []string{"no Identifier field in request"}}
+	}
+
+	return &retriever{
+		retrieveMethod: retrieveMethod,
+		requestType:    requestType,
+	}, nil
+}
+
+func (r *retriever) ValidateRetrieveRequest(request *auditRetriever.RetrieveRequest) error {
+	reasonsInvalid := make([]string, 0)
+
+	if request.Claims == nil {
+		reasonsInvalid = append(reasonsInvalid, "claims are nil")
+	}
+
+	if request.Identifier == nil {
+		reasonsInvalid = append(reasonsInvalid, "identifier is nil")
+	}
+
+	if len(reasonsInvalid) > 0 {
+		return brainException.RequestInvalid{Reasons: reasonsInvalid}
+	}
+	return nil
+}
+
+func (r *retriever) Retrieve(request *auditRetriever.RetrieveRequest) (*auditRetriever.RetrieveResponse, error) {
+	if err := r.ValidateRetrieveRequest(request); err != nil {
+		log.Error(err.Error())
+		return nil, err
+	}
+
+	retrieveRequest := reflect.New(r.requestType)
+	retrieveRequest.Elem().FieldByName("Claims").Set(reflect.ValueOf(&request.Claims).Elem())
+	retrieveRequest.Elem().FieldByName("Identifier").Set(reflect.ValueOf(&request.Identifier).Elem())
+
+	results := r.retrieveMethod.Call([]reflect.Value{retrieveRequest})
+	if err, _ := results[1].Interface().(error); err != nil {
+		return nil, auditRetrieverException.Retrieval{Reasons: []string{err.Error()}}
+	}
+	if results[0].IsNil() {
+		return nil, auditRetrieverException.Retrieval{Reasons: []string{"no response"}}
+	}
+
+	return &auditRetriever.RetrieveResponse{
+		Entity: results[0].Elem().Field(0).Interface(),
+	}, nil
+}
@@ -0,0 +1,83 @@
+package recordHandler
+
+import (
+	"errors"
+	auditRetriever "github.com/iot-my-world/brain/pkg/audit/retriever"
+	auditRetrieverException "github.com/iot-my-world/brain/pkg/audit/retriever/exception"
+	"github.com/iot-my-world/brain/pkg/search/identifier"
+	"github.com/iot-my-world/brain/pkg/search/identifier/id"
+	"github.com/iot-my-world/brain/pkg/security/claims"
+	humanUserLoginClaims "github.com/iot-my-world/brain/pkg/security/claims/login/user/human"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+type widget struct {
+	Id string
+}
+
+type RetrieveRequest struct {
+	Claims     claims.Claims
+	Identifier identifier.Identifier
+}
+
+type RetrieveResponse struct {
+	Widget widget
+}
+
+// widgetRecordHandler has a Retrieve method of the shape of
+// those of the entity record handlers
+type widgetRecordHandler struct {
+	requests []RetrieveRequest
+}
+
+func (r *widgetRecordHandler) Retrieve(request *RetrieveRequest) (*RetrieveResponse, error) {
+	r.requests = append(r.requests, *request)
+	if request.Identifier.(id.Identifier).Id == "missing" {
+		return nil, errors.New("not found")
+	}
+	return &RetrieveResponse{Widget: widget{Id: request.Identifier.(id.Identifier).Id}}, nil
+}
+
+func TestRetrieve(t *testing.T) {
+	recordHandler := &widgetRecordHandler{}
+	widgetRetriever, err := New(recordHandler)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	retrieveClaims := humanUserLoginClaims.Login{UserId: id.Identifier{Id: "user"}}
+	retrieveResponse, err := widgetRetriever.Retrieve(&auditRetriever.RetrieveRequest{
+		Claims:     retrieveClaims,
+		Identifier: id.Identifier{Id: "1"},
+	})
+	if assert.NoError(t, err) {
+		assert.Equal(t, widget{Id: "1"}, retrieveResponse.Entity)
+	}
+	if assert.Len(t, recordHandler.requests, 1) {
+		assert.Equal(t, retrieveClaims, recordHandler.requests[0].Claims)
+		assert.Equal(t, id.Identifier{Id: "1"}, recordHandler.requests[0].Identifier)
+	}
+
+	_, err = widgetRetriever.Retrieve(&auditRetriever.RetrieveRequest{
+		Claims:     retrieveClaims,
+		Identifier: id.Identifier{Id: "missing"},
+	})
+	assert.IsType(t, auditRetrieverException.Retrieval{}, err)
+}
+
+type noRetrieveRecordHandler struct{}
+
+type invalidRetrieveRecordHandler struct{}
+
+func (r *invalidRetrieveRecordHandler) Retrieve(request *RetrieveResponse) (*RetrieveResponse, error) {
+	return nil, nil
+}
+
+func TestNewInvalidRecordHandler(t *testing.T) {
+	_, err := New(&noRetrieveRecordHandler{})
+	assert.IsType(t, auditRetrieverException.InvalidRecordHandler{}, err)
+
+	_, err = New(&invalidRetrieveRecordHandler{})
+	assert.IsType(t, auditRetrieverException.InvalidRecordHandler{}, err)
+}
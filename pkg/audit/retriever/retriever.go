@@ -0,0 +1,21 @@
+package retriever
+
+import (
+	"github.com/iot-my-world/brain/pkg/search/identifier"
+	"github.com/iot-my-world/brain/pkg/security/claims"
+)
+
+// Retriever retrieves the entity targeted by an audited call
+// so that its state before and after the call can be recorded
+type Retriever interface {
+	Retrieve(request *RetrieveRequest) (*RetrieveResponse, error)
+}
+
+type RetrieveRequest struct {
+	Claims     claims.Claims
+	Identifier identifier.Identifier
+}
+
+type RetrieveResponse struct {
+	Entity interface{}
+}
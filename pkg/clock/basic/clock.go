@@ -0,0 +1,17 @@
+package basic
+
+import (
+	"github.com/iot-my-world/brain/pkg/clock"
+	"time"
+)
+
+type basicClock struct {
+}
+
+func New() clock.Clock {
+	return &basicClock{}
+}
+
+func (c *basicClock) Now() time.Time {
+	return time.Now()
+}
@@ -0,0 +1,9 @@
+package clock
+
+import "time"
+
+// Clock is the source of the current time, allowing
+// time dependent logic to be tested with a fake clock
+type Clock interface {
+	Now() time.Time
+}
@@ -0,0 +1,33 @@
+package alert
+
+import (
+	"github.com/iot-my-world/brain/pkg/alert/notification"
+	"github.com/iot-my-world/brain/pkg/alert/rule"
+	"github.com/iot-my-world/brain/pkg/communication/email"
+	"net/mail"
+)
+
+type Data struct {
+	Recipient    rule.Recipient
+	Notification notification.Notification
+}
+
+func (d Data) Details() email.Details {
+	// the mailer requires a name for each recipient
+	recipientName := d.Recipient.Name
+	if recipientName == "" {
+		recipientName = d.Recipient.Address
+	}
+
+	return email.Details{
+		Subject: "Alert: " + d.Notification.RuleName,
+		To: []mail.Address{{
+			Name:    recipientName,
+			Address: d.Recipient.Address,
+		}},
+		From: mail.Address{
+			Name:    "IOT My World Team",
+			Address: "iotmywordteam@gmail.com",
+		},
+	}
+}
@@ -0,0 +1,26 @@
+package lockout
+
+import (
+	"github.com/iot-my-world/brain/pkg/communication/email"
+	humanUser "github.com/iot-my-world/brain/pkg/user/human"
+	"net/mail"
+)
+
+type Data struct {
+	User        humanUser.User
+	LockedUntil string
+}
+
+func (d Data) Details() email.Details {
+	return email.Details{
+		Subject: "Your IOT My World Account Has Been Locked",
+		To: []mail.Address{{
+			Name:    d.User.Name,
+			Address: d.User.EmailAddress,
+		}},
+		From: mail.Address{
+			Name:    "IOT My World Team",
+			Address: "iotmywordteam@gmail.com",
+		},
+	}
+}
@@ -88,7 +88,6 @@ func (c *Connection) StartRX() error {
 		//fmt.Printf("Recieved message: %s\n", msgByteData)
 		c.MessageRx <- msgByteData
 	}
-	return nil
 }
 
 func (c *Connection) StartTX() error {
@@ -115,5 +114,4 @@ func (c *Connection) StartTX() error {
 			}
 		}
 	}
-	return nil
 }
@@ -2,21 +2,32 @@ package websocket
 
 import (
 	"encoding/json"
+	"errors"
 	"github.com/gorilla/websocket"
 	"github.com/iot-my-world/brain/internal/log"
+	jsonRpcServerAuthoriser "github.com/iot-my-world/brain/pkg/api/jsonRpc/server/authoriser"
 	"github.com/iot-my-world/brain/pkg/communication/websocket/message"
+	"github.com/iot-my-world/brain/pkg/security/claims"
+	"sync"
 	"time"
 )
 
 func NewClientHelper(
 	Conn *websocket.Conn,
 	Hub *Hub,
+	Claims claims.Claims,
+	JWT string,
+	Authoriser jsonRpcServerAuthoriser.Authoriser,
 ) *clientHelper {
 
 	return &clientHelper{
-		Conn:      Conn,
-		MsgToSend: make(chan Message),
-		Hub:       Hub,
+		Conn:       Conn,
+		MsgToSend:  make(chan Message, 100),
+		Hub:        Hub,
+		claims:     Claims,
+		jwt:        JWT,
+		authoriser: Authoriser,
+		closed:     make(chan bool),
 	}
 }
 
@@ -27,27 +38,57 @@ type clientHelper struct {
 	MsgToSend chan Message
 	// hub
 	Hub *Hub
+	// claims of the client that opened the connection
+	claims claims.Claims
+	// jwt with which the connection was opened and the authoriser with
+	// which it is checked again periodically, so that the connection is
+	// closed once the jwt expires or is revoked
+	jwt        string
+	authoriser jsonRpcServerAuthoriser.Authoriser
+	// closed is closed to close the connection
+	closed    chan bool
+	closeOnce sync.Once
+	// parties and devices for which the client wants publications
+	subscription      Subscription
+	subscriptionMutex sync.RWMutex
 }
 
-func (c *clientHelper) Send(message Message) error {
-	sendTimeOutTicker := time.NewTicker(2 * time.Second)
-	defer func() {
-		sendTimeOutTicker.Stop()
-	}()
+func (c *clientHelper) Claims() claims.Claims {
+	return c.claims
+}
+
+func (c *clientHelper) Subscription() Subscription {
+	c.subscriptionMutex.RLock()
+	defer c.subscriptionMutex.RUnlock()
+	return c.subscription
+}
 
+func (c *clientHelper) setSubscription(subscription Subscription) {
+	c.subscriptionMutex.Lock()
+	defer c.subscriptionMutex.Unlock()
+	c.subscription = subscription
+}
+
+func (c *clientHelper) Send(message Message) error {
 	select {
 	case c.MsgToSend <- message:
-	case <-sendTimeOutTicker.C:
-		log.Error("Time out on waiting to get message into Client Helper's MsgToSend Channel. Msg: ", message)
+		return nil
+	default:
+		return errors.New("client helper's MsgToSend channel is full")
 	}
+}
 
-	return nil
+func (c *clientHelper) Close() {
+	c.closeOnce.Do(func() {
+		close(c.closed)
+	})
 }
 
 func (c *clientHelper) HandleRX() {
 	defer func() {
-		//unregister clients here
 		log.Info("wsClientReader Connection Closed")
+		c.Hub.Unregister <- c
+		c.Close()
 		c.Conn.Close()
 	}()
 	c.Conn.SetReadLimit(MaxMessageSize)
@@ -95,18 +136,52 @@ func (c *clientHelper) HandleRX() {
 			log.Error("Error unmarshalling received message: " + err.Error())
 			continue
 		}
-		Broadcast <- receivedMsg
+		switch receivedMsg.Type {
+		case message.SubscribeRequest:
+			subscription := Subscription{}
+			if err := json.Unmarshal([]byte(receivedMsg.SerialData), &subscription); err != nil {
+				log.Error("Error unmarshalling subscription: " + err.Error())
+				continue
+			}
+			c.setSubscription(subscription)
+
+		case message.UnsubscribeRequest:
+			c.setSubscription(Subscription{})
+
+		default:
+			log.Warn("Unexpected message type received from client: ", receivedMsg.Type)
+		}
 	}
 }
 
 func (c *clientHelper) HandleTX() {
 	ticker := time.NewTicker(PingPeriod)
+	authorisationTicker := time.NewTicker(AuthorisationCheckPeriod)
+	expiryTimer := time.NewTimer(c.claims.TimeToExpiry())
 	defer func() {
 		c.Conn.Close()
 		ticker.Stop()
+		authorisationTicker.Stop()
+		expiryTimer.Stop()
 	}()
 	for {
 		select {
+		case <-c.closed:
+			log.Debug("The client helper was closed")
+			c.Conn.SetWriteDeadline(time.Now().Add(WriteWait))
+			c.Conn.WriteMessage(websocket.CloseMessage, []byte{})
+			return
+		case <-expiryTimer.C:
+			log.Info("Closing websocket connection of which the jwt has expired")
+			c.writeUnauthorisedClose("jwt expired")
+			return
+		case <-authorisationTicker.C:
+			// the jwt may have been revoked since the connection was opened
+			if _, err := c.authoriser.AuthoriseServiceMethod(c.jwt, SubscribeService); err != nil {
+				log.Info("Closing websocket connection which is no longer authorised: ", err)
+				c.writeUnauthorisedClose("unauthorised")
+				return
+			}
 		case message, ok := <-c.MsgToSend:
 			c.Conn.SetWriteDeadline(time.Now().Add(WriteWait))
 			if !ok {
@@ -135,3 +210,13 @@ func (c *clientHelper) HandleTX() {
 		}
 	}
 }
+
+// writeUnauthorisedClose tells the peer that the connection is being
+// closed since it is no longer authorised
+func (c *clientHelper) writeUnauthorisedClose(reason string) {
+	c.Conn.SetWriteDeadline(time.Now().Add(WriteWait))
+	c.Conn.WriteMessage(
+		websocket.CloseMessage,
+		websocket.FormatCloseMessage(websocket.ClosePolicyViolation, reason),
+	)
+}
@@ -3,17 +3,81 @@ package clientHelper
 import (
 	"github.com/gorilla/websocket"
 	"github.com/iot-my-world/brain/internal/log"
+	jsonRpcServerAuthoriser "github.com/iot-my-world/brain/pkg/api/jsonRpc/server/authoriser"
 	websocket2 "github.com/iot-my-world/brain/pkg/communication/websocket"
+	"github.com/iot-my-world/brain/pkg/security/claims"
 	"net/http"
 )
 
+// JWTSubprotocol is the websocket subprotocol which, when requested, is
+// followed in the Sec-WebSocket-Protocol header by the jwt of the client
+const JWTSubprotocol = "jwt"
+
+// NewAuthorisedHandler returns a handler which authorises websocket requests
+// for the hub's subscribe service before serving them. Since browsers cannot
+// set headers on websocket requests other than the subprotocols, the jwt may
+// be given either in the Authorization header or as the subprotocol following
+// the jwt subprotocol, i.e. new WebSocket(url, ["jwt", token]). It is not
+// taken from the url, where it would end up in logs.
+func NewAuthorisedHandler(hub *websocket2.Hub, authoriser jsonRpcServerAuthoriser.Authoriser) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		jwt := subprotocolJWT(r)
+		if r.Header["Authorization"] != nil {
+			jwt = r.Header["Authorization"][0]
+		}
+		if jwt == "" {
+			log.Info("Unauthorised Websocket access! - No jwt!")
+			http.Error(w, "Unauthorised", http.StatusForbidden)
+			return
+		}
+
+		// authorize access to the hub
+		wrappedClaims, err := authoriser.AuthoriseServiceMethod(jwt, websocket2.SubscribeService)
+		if err != nil {
+			log.Warn("Unauthorised Websocket Access Attempt", err.Error())
+			http.Error(w, "Unauthorised", http.StatusForbidden)
+			return
+		}
+		unwrappedClaims, err := wrappedClaims.Unwrap()
+		if err != nil {
+			log.Warn("Unauthorised Websocket Access Attempt", err.Error())
+			http.Error(w, "Unauthorised", http.StatusForbidden)
+			return
+		}
+
+		ServeWs(w, r, hub, unwrappedClaims, jwt, authoriser)
+	})
+}
+
+// subprotocolJWT returns the jwt given as the subprotocol following the
+// jwt subprotocol in the request, if any
+func subprotocolJWT(r *http.Request) string {
+	subprotocols := websocket.Subprotocols(r)
+	for i := 0; i < len(subprotocols)-1; i++ {
+		if subprotocols[i] == JWTSubprotocol {
+			return subprotocols[i+1]
+		}
+	}
+	return ""
+}
+
 // serveWs handles websocket requests from the peer.
-func ServeWs(w http.ResponseWriter, r *http.Request, hub *websocket2.Hub) {
+func ServeWs(
+	w http.ResponseWriter,
+	r *http.Request,
+	hub *websocket2.Hub,
+	claims claims.Claims,
+	jwt string,
+	authoriser jsonRpcServerAuthoriser.Authoriser,
+) {
 	log.Info("New Websocket Client Connected")
 
 	//Allow any origin to connect
 	var upgrader = websocket.Upgrader{
 		CheckOrigin: func(r *http.Request) bool { return true },
+		// the jwt subprotocol is selected in the response since browsers
+		// require one of the requested subprotocols to be
+		Subprotocols: []string{JWTSubprotocol},
 	}
 
 	//Upgrade the connection
@@ -25,7 +89,7 @@ func ServeWs(w http.ResponseWriter, r *http.Request, hub *websocket2.Hub) {
 	}
 
 	//Construct a new client helper
-	newClientHelper := websocket2.NewClientHelper(conn, hub)
+	newClientHelper := websocket2.NewClientHelper(conn, hub, claims, jwt, authoriser)
 
 	log.Info("Registering Client With Hub")
 	// Register ClientHelper with hub
@@ -11,4 +11,6 @@ const (
 	PingPeriod = (PongWait * 9) / 10
 	// Maximum message size allowed from peer.
 	MaxMessageSize = 4096
+	// Check that the peer's jwt is still valid with this period.
+	AuthorisationCheckPeriod = time.Minute
 )
@@ -94,12 +94,11 @@ func (cp *ContextProvider) StartRX() error {
 		if err := json.Unmarshal(msgByteData, &rxedMsg); err != nil {
 			log.Error("error unmarshalling rxed WS Message: " + err.Error())
 		}
-		switch Type {
+		switch rxedMsg.Type {
 		default:
 			fmt.Println("Default Case!", rxedMsg)
 		}
 	}
-	return nil
 }
 
 func (cp *ContextProvider) StartTX() error {
@@ -126,5 +125,4 @@ func (cp *ContextProvider) StartTX() error {
 			}
 		}
 	}
-	return nil
 }
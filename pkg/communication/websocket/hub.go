@@ -2,30 +2,31 @@ package websocket
 
 import (
 	"encoding/json"
-	"fmt"
+	"errors"
 	"github.com/iot-my-world/brain/internal/log"
 	"github.com/iot-my-world/brain/pkg/communication/websocket/message"
+	"github.com/iot-my-world/brain/pkg/security/claims"
+	"time"
 )
 
 type Hub struct {
 	/*
-	   A central hub will receive all incoming messages and broadcast them
-	   to all registered "Subscriber"s
+	   A central hub will receive all publications and broadcast them
+	   to all registered "Subscriber"s that are allowed to see them
 	   (i.e. the Subscriber structures in the clients map)
 	*/
-	Clients    map[Subscriber]bool
-	Broadcast  chan Message
-	Register   chan Subscriber
-	Unregister chan Subscriber
-	content    Message
+	Clients      map[Subscriber]bool
+	Publications chan Publication
+	Register     chan Subscriber
+	Unregister   chan Subscriber
 }
 
 func NewHub() *Hub {
 	return &Hub{
-		Broadcast:  make(chan Message),
-		Register:   make(chan Subscriber),
-		Unregister: make(chan Subscriber),
-		Clients:    make(map[Subscriber]bool),
+		Publications: make(chan Publication, 1000),
+		Register:     make(chan Subscriber),
+		Unregister:   make(chan Subscriber),
+		Clients:      make(map[Subscriber]bool),
 	}
 }
 
@@ -51,42 +52,68 @@ func (h *Hub) Run() {
 			})
 			if err != nil {
 				log.Warn("Unable to marshal welcome message data for client")
+				break
 			}
-			messageData := string(messageByteData[:])
 
-			welcomeMessage := Message{
+			if err := c.Send(Message{
 				Type:       message.WelcomeMessage,
-				SerialData: messageData,
+				SerialData: string(messageByteData[:]),
+			}); err != nil {
+				log.Error("Error Sending welcome msg to client: " + err.Error())
 			}
-			if err == nil {
-				Send(welcomeMessage)
-			} else {
-				log.Warn("Unable to marshal message for client")
-			}
-			break
 
 		case c := <-h.Unregister:
-			_, ok := h.Clients[c]
-			if ok {
+			if _, ok := h.Clients[c]; ok {
 				delete(h.Clients, c)
 			}
-			break
 
-		case m := <-h.Broadcast:
-			h.content = m
-			h.broadcastMessage()
-			break
+		case p := <-h.Publications:
+			h.broadcastPublication(p)
 		}
 	}
 }
 
-func (h *Hub) broadcastMessage() {
-	fmt.Println("hub is broadcasting message:", h.content)
+// Publish queues the given publication to be broadcast by the hub
+func (h *Hub) Publish(publication Publication) error {
+	sendTimeOutTicker := time.NewTicker(2 * time.Second)
+	defer func() {
+		sendTimeOutTicker.Stop()
+	}()
+
+	select {
+	case h.Publications <- publication:
+	case <-sendTimeOutTicker.C:
+		return errors.New("time out on waiting to get publication into hub's publication channel")
+	}
+
+	return nil
+}
+
+// broadcastPublication sends the publication to each subscriber which is
+// allowed to see and wants it. Subscribers are not waited on, so that a slow
+// one cannot hold up the others: one which has fallen too far behind to take
+// the publication is dropped from the hub and closed.
+func (h *Hub) broadcastPublication(publication Publication) {
 	for c := range h.Clients {
-		if err := Send(h.content); err != nil {
-			log.Error("Error Sending msg to client: " + err.Error())
-			// TODO: Unregister Client here?
+		subscriberClaims := c.Claims()
+		if subscriberClaims == nil || subscriberClaims.Expired() {
+			continue
 		}
-	}
 
+		// confirm that the subscriber is allowed to see this publication
+		if !claims.EntityInContext(publication.OwnerId, publication.AssignedId, subscriberClaims) {
+			continue
+		}
+
+		// confirm that the subscriber wants this publication
+		if !c.Subscription().Wants(publication) {
+			continue
+		}
+
+		if err := c.Send(publication.Message); err != nil {
+			log.Warn("Dropping websocket client: " + err.Error())
+			delete(h.Clients, c)
+			c.Close()
+		}
+	}
 }
@@ -0,0 +1,88 @@
+package websocket
+
+import (
+	"errors"
+	"github.com/iot-my-world/brain/pkg/communication/websocket/message"
+	"github.com/iot-my-world/brain/pkg/party"
+	"github.com/iot-my-world/brain/pkg/search/identifier/id"
+	"github.com/iot-my-world/brain/pkg/security/claims"
+	humanUserLoginClaims "github.com/iot-my-world/brain/pkg/security/claims/login/user/human"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+// fakeSubscriber takes up to the given number of messages
+type fakeSubscriber struct {
+	claims       claims.Claims
+	subscription Subscription
+	bufferSize   int
+	sent         []Message
+	closed       bool
+}
+
+func (s *fakeSubscriber) Send(message Message) error {
+	if len(s.sent) >= s.bufferSize {
+		return errors.New("buffer full")
+	}
+	s.sent = append(s.sent, message)
+	return nil
+}
+
+func (s *fakeSubscriber) Close() {
+	s.closed = true
+}
+
+func (s *fakeSubscriber) Claims() claims.Claims {
+	return s.claims
+}
+
+func (s *fakeSubscriber) Subscription() Subscription {
+	return s.subscription
+}
+
+func newFakeSubscriber(partyId string, bufferSize int) *fakeSubscriber {
+	return &fakeSubscriber{
+		claims: humanUserLoginClaims.Login{
+			PartyType:      party.Company,
+			PartyId:        id.Identifier{Id: partyId},
+			ExpirationTime: time.Now().Add(time.Hour).Unix(),
+		},
+		subscription: Subscription{
+			PartyIds: []id.Identifier{{Id: partyId}},
+		},
+		bufferSize: bufferSize,
+	}
+}
+
+func TestBroadcastPublication(t *testing.T) {
+	hub := NewHub()
+	slowSubscriber := newFakeSubscriber("company", 1)
+	subscriber := newFakeSubscriber("company", 10)
+	otherSubscriber := newFakeSubscriber("other company", 10)
+	for _, s := range []*fakeSubscriber{slowSubscriber, subscriber, otherSubscriber} {
+		hub.Clients[s] = true
+	}
+
+	publication := Publication{
+		Message:  Message{Type: message.SigbugGPSReading},
+		DeviceId: id.Identifier{Id: "device"},
+		OwnerId:  id.Identifier{Id: "company"},
+	}
+	hub.broadcastPublication(publication)
+	hub.broadcastPublication(publication)
+
+	// the subscriber which could not keep up is dropped without
+	// holding up the others
+	assert.Len(t, slowSubscriber.sent, 1)
+	assert.True(t, slowSubscriber.closed)
+	assert.NotContains(t, hub.Clients, slowSubscriber)
+
+	assert.Len(t, subscriber.sent, 2)
+	assert.False(t, subscriber.closed)
+	assert.Contains(t, hub.Clients, subscriber)
+
+	// publications are only sent to subscribers allowed to see them
+	assert.Empty(t, otherSubscriber.sent)
+	assert.Contains(t, hub.Clients, otherSubscriber)
+}
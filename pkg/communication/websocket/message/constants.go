@@ -9,4 +9,9 @@ const (
 	GetServiceContextResponse
 	ClockEvent
 	WelcomeMessage
+	SubscribeRequest
+	UnsubscribeRequest
+	SigbugGPSReading
+	SigbugLastMessageUpdate
+	GeofenceEvent
 )
@@ -0,0 +1,19 @@
+package websocket
+
+import (
+	"github.com/iot-my-world/brain/pkg/search/identifier/id"
+)
+
+// Publication is a message to be broadcast by the hub together with the
+// details of the device that it concerns. The owner and assigned ids are used
+// by the hub to determine which subscribers are allowed to see the message.
+type Publication struct {
+	Message    Message
+	DeviceId   id.Identifier
+	OwnerId    id.Identifier
+	AssignedId id.Identifier
+}
+
+type Publisher interface {
+	Publish(publication Publication) error
+}
@@ -0,0 +1,28 @@
+package websocket
+
+import (
+	"github.com/iot-my-world/brain/pkg/security/permission/api"
+)
+
+const ServiceProvider = "Websocket-Hub"
+const SubscribeService = ServiceProvider + ".Subscribe"
+
+var SystemUserPermissions = []api.Permission{
+	SubscribeService,
+}
+
+var CompanyAdminUserPermissions = []api.Permission{
+	SubscribeService,
+}
+
+var CompanyUserPermissions = []api.Permission{
+	SubscribeService,
+}
+
+var ClientAdminUserPermissions = []api.Permission{
+	SubscribeService,
+}
+
+var ClientUserPermissions = []api.Permission{
+	SubscribeService,
+}
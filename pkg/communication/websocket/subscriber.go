@@ -1,5 +1,15 @@
 package websocket
 
+import (
+	"github.com/iot-my-world/brain/pkg/security/claims"
+)
+
 type Subscriber interface {
+	// Send queues the message to be sent without blocking, failing
+	// if the subscriber is not keeping up with its messages
 	Send(message Message) error
+	// Close closes the connection of the subscriber
+	Close()
+	Claims() claims.Claims
+	Subscription() Subscription
 }
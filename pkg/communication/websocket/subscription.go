@@ -0,0 +1,29 @@
+package websocket
+
+import (
+	"github.com/iot-my-world/brain/pkg/search/identifier/id"
+)
+
+// Subscription is sent by a websocket client in the serial data of a
+// SubscribeRequest message to indicate the parties and devices for
+// which it would like to receive publications
+type Subscription struct {
+	PartyIds  []id.Identifier `json:"partyIds"`
+	DeviceIds []id.Identifier `json:"deviceIds"`
+}
+
+// Wants returns true if the given publication is for one of the subscribed
+// devices or for a device owned by or assigned to one of the subscribed parties
+func (s Subscription) Wants(publication Publication) bool {
+	for _, deviceId := range s.DeviceIds {
+		if deviceId.Id == publication.DeviceId.Id {
+			return true
+		}
+	}
+	for _, partyId := range s.PartyIds {
+		if partyId.Id == publication.OwnerId.Id || partyId.Id == publication.AssignedId.Id {
+			return true
+		}
+	}
+	return false
+}
@@ -9,5 +9,24 @@ const Company = "company"
 const Client = "client"
 const Sigbug = "sigbug"
 const SigbugGPSReading = "sigbugGPSReading"
+const SigbugBatteryReading = "sigbugBatteryReading"
+const SigbugTemperatureReading = "sigbugTemperatureReading"
+const SigbugHeartbeatReading = "sigbugHeartbeatReading"
+const SigbugEvent = "sigbugEvent"
+const SigbugTrip = "sigbugTrip"
 const SigfoxBackend = "sigfoxBackend"
 const SigfoxBackendDataCallbackMessage = "sigfoxBackendDataCallbackMessage"
+const Geofence = "geofence"
+const GeofenceEvent = "geofenceEvent"
+const AlertRule = "alertRule"
+const AlertDelivery = "alertDelivery"
+const WebhookSubscription = "webhookSubscription"
+const WebhookDelivery = "webhookDelivery"
+const AuditEntry = "auditEntry"
+const TokenRevocation = "tokenRevocation"
+const Lockout = "lockout"
+const RefreshToken = "refreshToken"
+const Migration = "migration"
+const RetentionPolicy = "retentionPolicy"
+const SigbugDownlink = "sigbugDownlink"
+const SigfoxBackendDataCallbackMessageDeadLetter = "sigfoxBackendDataCallbackMessageDeadLetter"
@@ -1,10 +1,13 @@
 package jsonRpc
 
 import (
+	"encoding/hex"
 	"github.com/iot-my-world/brain/internal/log"
 	jsonRpcServiceProvider "github.com/iot-my-world/brain/pkg/api/jsonRpc/service/provider"
 	"github.com/iot-my-world/brain/pkg/device/sigbug"
 	"github.com/iot-my-world/brain/pkg/device/sigbug/administrator"
+	"github.com/iot-my-world/brain/pkg/device/sigbug/downlink"
+	wrappedIdentifier "github.com/iot-my-world/brain/pkg/search/identifier/wrapped"
 	wrappedClaims "github.com/iot-my-world/brain/pkg/security/claims/wrapped"
 	"net/http"
 )
@@ -82,3 +85,39 @@ func (a *adaptor) UpdateAllowedFields(r *http.Request, request *UpdateAllowedFie
 
 	return nil
 }
+
+type QueueDownlinkRequest struct {
+	WrappedIdentifier wrappedIdentifier.Wrapped `json:"identifier"`
+	Data              string                    `json:"data"`
+}
+
+type QueueDownlinkResponse struct {
+	Downlink downlink.Downlink `json:"downlink"`
+}
+
+func (a *adaptor) QueueDownlink(r *http.Request, request *QueueDownlinkRequest, response *QueueDownlinkResponse) error {
+	claims, err := wrappedClaims.UnwrapClaimsFromContext(r)
+	if err != nil {
+		log.Warn(err.Error())
+		return err
+	}
+
+	downlinkData, err := hex.DecodeString(request.Data)
+	if err != nil {
+		log.Error(err.Error())
+		return err
+	}
+
+	queueDownlinkResponse, err := a.administrator.QueueDownlink(&administrator.QueueDownlinkRequest{
+		Claims:     claims,
+		Identifier: request.WrappedIdentifier.Identifier,
+		Data:       downlinkData,
+	})
+	if err != nil {
+		return err
+	}
+
+	response.Downlink = queueDownlinkResponse.Downlink
+
+	return nil
+}
@@ -2,6 +2,7 @@ package administrator
 
 import (
 	"github.com/iot-my-world/brain/pkg/device/sigbug"
+	"github.com/iot-my-world/brain/pkg/device/sigbug/downlink"
 	"github.com/iot-my-world/brain/pkg/search/identifier"
 	"github.com/iot-my-world/brain/pkg/security/claims"
 	"github.com/iot-my-world/brain/pkg/security/permission/api"
@@ -12,20 +13,24 @@ type Administrator interface {
 	Create(request *CreateRequest) (*CreateResponse, error)
 	UpdateAllowedFields(request *UpdateAllowedFieldsRequest) (*UpdateAllowedFieldsResponse, error)
 	LastMessageUpdate(request *LastMessageUpdateRequest) (*LastMessageUpdateResponse, error)
+	QueueDownlink(request *QueueDownlinkRequest) (*QueueDownlinkResponse, error)
 }
 
 const ServiceProvider = "SigbugDevice-Administrator"
 const UpdateAllowedFieldsService = ServiceProvider + ".UpdateAllowedFields"
 const CreateService = ServiceProvider + ".Create"
+const QueueDownlinkService = ServiceProvider + ".QueueDownlink"
 
 var SystemUserPermissions = []api.Permission{
 	CreateService,
 	UpdateAllowedFieldsService,
+	QueueDownlinkService,
 }
 
 var CompanyAdminUserPermissions = []api.Permission{
 	CreateService,
 	UpdateAllowedFieldsService,
+	QueueDownlinkService,
 }
 
 var CompanyUserPermissions = make([]api.Permission, 0)
@@ -33,6 +38,7 @@ var CompanyUserPermissions = make([]api.Permission, 0)
 var ClientAdminUserPermissions = []api.Permission{
 	CreateService,
 	UpdateAllowedFieldsService,
+	QueueDownlinkService,
 }
 
 var ClientUserPermissions = make([]api.Permission, 0)
@@ -64,3 +70,13 @@ type LastMessageUpdateRequest struct {
 type LastMessageUpdateResponse struct {
 	Sigbug sigbug.Sigbug
 }
+
+type QueueDownlinkRequest struct {
+	Claims     claims.Claims
+	Identifier identifier.Identifier
+	Data       []byte
+}
+
+type QueueDownlinkResponse struct {
+	Downlink downlink.Downlink
+}
@@ -4,26 +4,39 @@ import (
 	"fmt"
 	brainException "github.com/iot-my-world/brain/internal/exception"
 	"github.com/iot-my-world/brain/internal/log"
+	"github.com/iot-my-world/brain/pkg/device/sigbug"
 	"github.com/iot-my-world/brain/pkg/device/sigbug/action"
 	sigbugAdministrator "github.com/iot-my-world/brain/pkg/device/sigbug/administrator"
 	"github.com/iot-my-world/brain/pkg/device/sigbug/administrator/exception"
+	"github.com/iot-my-world/brain/pkg/device/sigbug/downlink"
+	sigbugDownlinkRecordHandler "github.com/iot-my-world/brain/pkg/device/sigbug/downlink/recordHandler"
 	"github.com/iot-my-world/brain/pkg/device/sigbug/recordHandler"
 	"github.com/iot-my-world/brain/pkg/device/sigbug/validator"
 	"github.com/iot-my-world/brain/pkg/search/identifier/id"
+	"github.com/iot-my-world/brain/pkg/security/claims"
+	webhookEvent "github.com/iot-my-world/brain/pkg/webhook/event"
+	webhookPublisher "github.com/iot-my-world/brain/pkg/webhook/publisher"
+	"time"
 )
 
 type administrator struct {
-	sigbugDeviceValidator validator.Validator
-	sigbugRecordHandler   recordHandler.RecordHandler
+	sigbugDeviceValidator       validator.Validator
+	sigbugRecordHandler         recordHandler.RecordHandler
+	sigbugDownlinkRecordHandler sigbugDownlinkRecordHandler.RecordHandler
+	webhookPublisher            webhookPublisher.Publisher
 }
 
 func New(
 	sigbugDeviceValidator validator.Validator,
 	sigbugRecordHandler recordHandler.RecordHandler,
+	sigbugDownlinkRecordHandler sigbugDownlinkRecordHandler.RecordHandler,
+	webhookPublisher webhookPublisher.Publisher,
 ) sigbugAdministrator.Administrator {
 	return &administrator{
-		sigbugDeviceValidator: sigbugDeviceValidator,
-		sigbugRecordHandler:   sigbugRecordHandler,
+		sigbugDeviceValidator:       sigbugDeviceValidator,
+		sigbugRecordHandler:         sigbugRecordHandler,
+		sigbugDownlinkRecordHandler: sigbugDownlinkRecordHandler,
+		webhookPublisher:            webhookPublisher,
 	}
 }
 
@@ -68,6 +81,11 @@ func (a *administrator) Create(request *sigbugAdministrator.CreateRequest) (*sig
 		return nil, exception.DeviceCreation{Reasons: []string{err.Error()}}
 	}
 
+	// publish the assignment of the device if it was created with an assigned party
+	if createResponse.Sigbug.AssignedId.Id != "" {
+		a.publishAssignment(request.Claims, createResponse.Sigbug)
+	}
+
 	return &sigbugAdministrator.CreateResponse{
 		Sigbug: createResponse.Sigbug,
 	}, nil
@@ -82,6 +100,7 @@ func (a *administrator) ValidateUpdateAllowedFieldsRequest(request *sigbugAdmini
 		// device must be valid
 		validationResponse, err := a.sigbugDeviceValidator.Validate(&validator.ValidateRequest{
 			Claims: request.Claims,
+			Sigbug: request.Sigbug,
 			Action: action.UpdateAllowedFields,
 		})
 		if err != nil {
@@ -116,6 +135,11 @@ func (a *administrator) UpdateAllowedFields(request *sigbugAdministrator.UpdateA
 	}
 
 	// update the allowed fields on the device
+	assignmentChanged := deviceRetrieveResponse.Sigbug.AssignedPartyType != request.Sigbug.AssignedPartyType ||
+		deviceRetrieveResponse.Sigbug.AssignedId.Id != request.Sigbug.AssignedId.Id
+	deviceRetrieveResponse.Sigbug.SilenceThreshold = request.Sigbug.SilenceThreshold
+	deviceRetrieveResponse.Sigbug.AssignedPartyType = request.Sigbug.AssignedPartyType
+	deviceRetrieveResponse.Sigbug.AssignedId = request.Sigbug.AssignedId
 
 	// update the device
 	_, err = a.sigbugRecordHandler.Update(&recordHandler.UpdateRequest{
@@ -127,11 +151,30 @@ func (a *administrator) UpdateAllowedFields(request *sigbugAdministrator.UpdateA
 		return nil, exception.DeviceUpdate{Reasons: []string{err.Error()}}
 	}
 
+	// publish the assignment of the device if it has been assigned to another party
+	if assignmentChanged && deviceRetrieveResponse.Sigbug.AssignedId.Id != "" {
+		a.publishAssignment(request.Claims, deviceRetrieveResponse.Sigbug)
+	}
+
 	return &sigbugAdministrator.UpdateAllowedFieldsResponse{
 		Sigbug: deviceRetrieveResponse.Sigbug,
 	}, nil
 }
 
+// publishAssignment publishes the assignment of the given device to webhooks.
+// Failure to publish is logged but does not fail the change to the device.
+func (a *administrator) publishAssignment(claims claims.Claims, device sigbug.Sigbug) {
+	if _, err := a.webhookPublisher.Publish(&webhookPublisher.PublishRequest{
+		Claims:    claims,
+		EventType: webhookEvent.DeviceAssigned,
+		Device:    device,
+		TimeStamp: time.Now().UTC().Unix(),
+		Data:      device,
+	}); err != nil {
+		log.Error("error publishing device assignment to webhooks: " + err.Error())
+	}
+}
+
 func (a *administrator) ValidateLastMessageUpdateRequest(request *sigbugAdministrator.LastMessageUpdateRequest) error {
 	reasonsInvalid := make([]string, 0)
 
@@ -191,3 +234,63 @@ func (a *administrator) LastMessageUpdate(request *sigbugAdministrator.LastMessa
 		Sigbug: sigbugDeviceRetrieveResponse.Sigbug,
 	}, nil
 }
+
+func (a *administrator) ValidateQueueDownlinkRequest(request *sigbugAdministrator.QueueDownlinkRequest) error {
+	reasonsInvalid := make([]string, 0)
+
+	if request.Claims == nil {
+		reasonsInvalid = append(reasonsInvalid, "claims are nil")
+	}
+
+	if request.Identifier == nil {
+		reasonsInvalid = append(reasonsInvalid, "identifier is nil")
+	}
+
+	if len(request.Data) != downlink.PayloadLength {
+		reasonsInvalid = append(reasonsInvalid, fmt.Sprintf("downlink data must be %d bytes long", downlink.PayloadLength))
+	}
+
+	if len(reasonsInvalid) > 0 {
+		return brainException.RequestInvalid{Reasons: reasonsInvalid}
+	}
+	return nil
+}
+
+func (a *administrator) QueueDownlink(request *sigbugAdministrator.QueueDownlinkRequest) (*sigbugAdministrator.QueueDownlinkResponse, error) {
+	if err := a.ValidateQueueDownlinkRequest(request); err != nil {
+		return nil, err
+	}
+
+	// retrieve the sigbug device
+	sigbugDeviceRetrieveResponse, err := a.sigbugRecordHandler.Retrieve(&recordHandler.RetrieveRequest{
+		Claims:     request.Claims,
+		Identifier: request.Identifier,
+	})
+	if err != nil {
+		err = exception.DownlinkQueue{Reasons: []string{"device retrieval", err.Error()}}
+		log.Error(err.Error())
+		return nil, err
+	}
+
+	// add the downlink to the device's queue
+	downlinkCreateResponse, err := a.sigbugDownlinkRecordHandler.Create(&sigbugDownlinkRecordHandler.CreateRequest{
+		Downlink: downlink.Downlink{
+			DeviceId:          id.Identifier{Id: sigbugDeviceRetrieveResponse.Sigbug.Id},
+			OwnerPartyType:    sigbugDeviceRetrieveResponse.Sigbug.OwnerPartyType,
+			OwnerId:           sigbugDeviceRetrieveResponse.Sigbug.OwnerId,
+			AssignedPartyType: sigbugDeviceRetrieveResponse.Sigbug.AssignedPartyType,
+			AssignedId:        sigbugDeviceRetrieveResponse.Sigbug.AssignedId,
+			Data:              request.Data,
+			QueuedTime:        time.Now().UTC().Unix(),
+		},
+	})
+	if err != nil {
+		err = exception.DownlinkQueue{Reasons: []string{"downlink creation", err.Error()}}
+		log.Error(err.Error())
+		return nil, err
+	}
+
+	return &sigbugAdministrator.QueueDownlinkResponse{
+		Downlink: downlinkCreateResponse.Downlink,
+	}, nil
+}
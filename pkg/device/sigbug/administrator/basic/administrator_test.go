@@ -0,0 +1,114 @@
+package basic
+
+import (
+	"github.com/iot-my-world/brain/pkg/device/sigbug"
+	sigbugAdministrator "github.com/iot-my-world/brain/pkg/device/sigbug/administrator"
+	"github.com/iot-my-world/brain/pkg/device/sigbug/recordHandler"
+	"github.com/iot-my-world/brain/pkg/device/sigbug/validator"
+	"github.com/iot-my-world/brain/pkg/party"
+	"github.com/iot-my-world/brain/pkg/search/identifier/id"
+	humanUserLoginClaims "github.com/iot-my-world/brain/pkg/security/claims/login/user/human"
+	"github.com/iot-my-world/brain/pkg/validate/reasonInvalid"
+	webhookEvent "github.com/iot-my-world/brain/pkg/webhook/event"
+	webhookPublisher "github.com/iot-my-world/brain/pkg/webhook/publisher"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+type fakeValidator struct{}
+
+func (v *fakeValidator) Validate(*validator.ValidateRequest) (*validator.ValidateResponse, error) {
+	return &validator.ValidateResponse{ReasonsInvalid: make([]reasonInvalid.ReasonInvalid, 0)}, nil
+}
+
+// fakeRecordHandler keeps a single device
+type fakeRecordHandler struct {
+	recordHandler.RecordHandler
+	device sigbug.Sigbug
+}
+
+func (r *fakeRecordHandler) Retrieve(*recordHandler.RetrieveRequest) (*recordHandler.RetrieveResponse, error) {
+	return &recordHandler.RetrieveResponse{Sigbug: r.device}, nil
+}
+
+func (r *fakeRecordHandler) Update(request *recordHandler.UpdateRequest) (*recordHandler.UpdateResponse, error) {
+	r.device = request.Sigbug
+	return &recordHandler.UpdateResponse{}, nil
+}
+
+// fakePublisher records the events published
+type fakePublisher struct {
+	published []webhookPublisher.PublishRequest
+}
+
+func (p *fakePublisher) Publish(request *webhookPublisher.PublishRequest) (*webhookPublisher.PublishResponse, error) {
+	p.published = append(p.published, *request)
+	return &webhookPublisher.PublishResponse{}, nil
+}
+
+func TestUpdateAllowedFieldsPublishesAssignment(t *testing.T) {
+	device := sigbug.Sigbug{
+		Id:             "device",
+		OwnerPartyType: party.Company,
+		OwnerId:        id.Identifier{Id: "company"},
+	}
+	assigned := device
+	assigned.AssignedPartyType = party.Client
+	assigned.AssignedId = id.Identifier{Id: "client"}
+	reassigned := device
+	reassigned.AssignedPartyType = party.Client
+	reassigned.AssignedId = id.Identifier{Id: "other client"}
+
+	tests := []struct {
+		name            string
+		device          sigbug.Sigbug
+		update          sigbug.Sigbug
+		expectPublished bool
+	}{
+		{
+			name:            "assigned",
+			device:          device,
+			update:          assigned,
+			expectPublished: true,
+		},
+		{
+			name:            "reassigned",
+			device:          assigned,
+			update:          reassigned,
+			expectPublished: true,
+		},
+		{
+			name:   "assignment unchanged",
+			device: assigned,
+			update: assigned,
+		},
+		{
+			name:   "unassigned",
+			device: assigned,
+			update: device,
+		},
+	}
+
+	for _, test := range tests {
+		sigbugRecordHandler := &fakeRecordHandler{device: test.device}
+		publisher := &fakePublisher{}
+		updateResponse, err := New(&fakeValidator{}, sigbugRecordHandler, nil, publisher).UpdateAllowedFields(&sigbugAdministrator.UpdateAllowedFieldsRequest{
+			Claims: humanUserLoginClaims.Login{},
+			Sigbug: test.update,
+		})
+		if !assert.NoError(t, err, test.name) {
+			continue
+		}
+
+		assert.Equal(t, test.update.AssignedId, sigbugRecordHandler.device.AssignedId, test.name)
+		assert.Equal(t, test.update.AssignedId, updateResponse.Sigbug.AssignedId, test.name)
+		if test.expectPublished {
+			if assert.Len(t, publisher.published, 1, test.name) {
+				assert.Equal(t, webhookEvent.DeviceAssigned, publisher.published[0].EventType, test.name)
+				assert.Equal(t, test.update.AssignedId, publisher.published[0].Device.AssignedId, test.name)
+			}
+		} else {
+			assert.Empty(t, publisher.published, test.name)
+		}
+	}
+}
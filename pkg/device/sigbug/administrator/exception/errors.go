@@ -51,3 +51,11 @@ type LastMessageUpdate struct {
 func (e LastMessageUpdate) Error() string {
 	return "last message update error: " + strings.Join(e.Reasons, "; ")
 }
+
+type DownlinkQueue struct {
+	Reasons []string
+}
+
+func (e DownlinkQueue) Error() string {
+	return "error queueing downlink: " + strings.Join(e.Reasons, "; ")
+}
@@ -1,11 +1,13 @@
 package jsonRpc
 
 import (
+	"encoding/hex"
 	brainException "github.com/iot-my-world/brain/internal/exception"
 	"github.com/iot-my-world/brain/internal/log"
 	jsonRpcClient "github.com/iot-my-world/brain/pkg/api/jsonRpc/client"
 	sigbugAdministrator "github.com/iot-my-world/brain/pkg/device/sigbug/administrator"
 	sigbugAdministratorJsonRpcAdaptor "github.com/iot-my-world/brain/pkg/device/sigbug/administrator/adaptor/jsonRpc"
+	wrappedIdentifier "github.com/iot-my-world/brain/pkg/search/identifier/wrapped"
 )
 
 type administrator struct {
@@ -83,3 +85,46 @@ func (a *administrator) UpdateAllowedFields(request *sigbugAdministrator.UpdateA
 func (a *administrator) LastMessageUpdate(request *sigbugAdministrator.LastMessageUpdateRequest) (*sigbugAdministrator.LastMessageUpdateResponse, error) {
 	return nil, brainException.NotImplemented{}
 }
+
+func (a *administrator) ValidateQueueDownlinkRequest(request *sigbugAdministrator.QueueDownlinkRequest) error {
+	reasonsInvalid := make([]string, 0)
+
+	if request.Identifier == nil {
+		reasonsInvalid = append(reasonsInvalid, "identifier is nil")
+	}
+
+	if len(reasonsInvalid) > 0 {
+		return brainException.RequestInvalid{Reasons: reasonsInvalid}
+	}
+	return nil
+}
+
+func (a *administrator) QueueDownlink(request *sigbugAdministrator.QueueDownlinkRequest) (*sigbugAdministrator.QueueDownlinkResponse, error) {
+	if err := a.ValidateQueueDownlinkRequest(request); err != nil {
+		return nil, err
+	}
+
+	// wrap identifier
+	id, err := wrappedIdentifier.Wrap(request.Identifier)
+	if err != nil {
+		log.Error(err.Error())
+		return nil, err
+	}
+
+	queueDownlinkResponse := sigbugAdministratorJsonRpcAdaptor.QueueDownlinkResponse{}
+	if err := a.jsonRpcClient.JsonRpcRequest(
+		sigbugAdministrator.QueueDownlinkService,
+		sigbugAdministratorJsonRpcAdaptor.QueueDownlinkRequest{
+			WrappedIdentifier: *id,
+			Data:              hex.EncodeToString(request.Data),
+		},
+		&queueDownlinkResponse,
+	); err != nil {
+		log.Error(err.Error())
+		return nil, err
+	}
+
+	return &sigbugAdministrator.QueueDownlinkResponse{
+		Downlink: queueDownlinkResponse.Downlink,
+	}, nil
+}
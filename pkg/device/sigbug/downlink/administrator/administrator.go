@@ -0,0 +1,42 @@
+package administrator
+
+import (
+	"github.com/iot-my-world/brain/pkg/device/sigbug/downlink"
+	"github.com/iot-my-world/brain/pkg/search/identifier"
+	"github.com/iot-my-world/brain/pkg/security/claims"
+)
+
+// Administrator sends the downlinks queued for sigbug devices.
+// Downlinks can only be sent in reply to sigfox backend callbacks
+// and so this service is not exposed over the api.
+type Administrator interface {
+	SendNext(request *SendNextRequest) (*SendNextResponse, error)
+	Acknowledge(request *AcknowledgeRequest) (*AcknowledgeResponse, error)
+}
+
+type SendNextRequest struct {
+	Claims     claims.Claims
+	Identifier identifier.Identifier
+	MessageId  string
+}
+
+type SendNextResponse struct {
+	// Sent is false if there was no downlink queued for the device
+	Sent     bool
+	Downlink downlink.Downlink
+}
+
+type AcknowledgeRequest struct {
+	Claims     claims.Claims
+	Identifier identifier.Identifier
+
+	// Delivered is false if sigfox could not deliver the
+	// downlink, in which case it is sent again
+	Delivered bool
+}
+
+type AcknowledgeResponse struct {
+	// Acknowledged is false if there was no sent downlink to acknowledge
+	Acknowledged bool
+	Downlink     downlink.Downlink
+}
@@ -0,0 +1,234 @@
+package basic
+
+import (
+	brainException "github.com/iot-my-world/brain/internal/exception"
+	"github.com/iot-my-world/brain/internal/log"
+	sigbugDownlinkAdministrator "github.com/iot-my-world/brain/pkg/device/sigbug/downlink/administrator"
+	sigbugDownlinkAdministratorException "github.com/iot-my-world/brain/pkg/device/sigbug/downlink/administrator/exception"
+	sigbugDownlinkRecordHandler "github.com/iot-my-world/brain/pkg/device/sigbug/downlink/recordHandler"
+	sigbugDownlinkRecordHandlerException "github.com/iot-my-world/brain/pkg/device/sigbug/downlink/recordHandler/exception"
+	sigbugRecordHandler "github.com/iot-my-world/brain/pkg/device/sigbug/recordHandler"
+	brainRecordHandler "github.com/iot-my-world/brain/pkg/recordHandler"
+	"github.com/iot-my-world/brain/pkg/search/criterion"
+	exactBooleanCriterion "github.com/iot-my-world/brain/pkg/search/criterion/exact/boolean"
+	exactTextCriterion "github.com/iot-my-world/brain/pkg/search/criterion/exact/text"
+	numberRangeCriterion "github.com/iot-my-world/brain/pkg/search/criterion/range/number"
+	"github.com/iot-my-world/brain/pkg/search/identifier/id"
+	"github.com/iot-my-world/brain/pkg/search/query"
+	"time"
+)
+
+// acknowledgementTimeout is how long after being sent a downlink which has
+// not been acknowledged by sigfox is taken to be lost and is sent again
+const acknowledgementTimeout = 10 * time.Minute
+
+type administrator struct {
+	sigbugRecordHandler         sigbugRecordHandler.RecordHandler
+	sigbugDownlinkRecordHandler sigbugDownlinkRecordHandler.RecordHandler
+}
+
+func New(
+	sigbugRecordHandler sigbugRecordHandler.RecordHandler,
+	sigbugDownlinkRecordHandler sigbugDownlinkRecordHandler.RecordHandler,
+) sigbugDownlinkAdministrator.Administrator {
+	return &administrator{
+		sigbugRecordHandler:         sigbugRecordHandler,
+		sigbugDownlinkRecordHandler: sigbugDownlinkRecordHandler,
+	}
+}
+
+func (a *administrator) ValidateSendNextRequest(request *sigbugDownlinkAdministrator.SendNextRequest) error {
+	reasonsInvalid := make([]string, 0)
+
+	if request.Claims == nil {
+		reasonsInvalid = append(reasonsInvalid, "claims are nil")
+	}
+
+	if request.Identifier == nil {
+		reasonsInvalid = append(reasonsInvalid, "identifier is nil")
+	}
+
+	if len(reasonsInvalid) > 0 {
+		return brainException.RequestInvalid{Reasons: reasonsInvalid}
+	}
+	return nil
+}
+
+// SendNext takes the oldest unsent downlink off of the queue of the device
+// and marks it as sent. The downlink is claimed atomically so that it is only
+// sent once if messages from the device are handled concurrently.
+func (a *administrator) SendNext(request *sigbugDownlinkAdministrator.SendNextRequest) (*sigbugDownlinkAdministrator.SendNextResponse, error) {
+	if err := a.ValidateSendNextRequest(request); err != nil {
+		return nil, err
+	}
+
+	// retrieve the sigbug device
+	sigbugRetrieveResponse, err := a.sigbugRecordHandler.Retrieve(&sigbugRecordHandler.RetrieveRequest{
+		Claims:     request.Claims,
+		Identifier: request.Identifier,
+	})
+	if err != nil {
+		err = sigbugDownlinkAdministratorException.SendNext{Reasons: []string{"device retrieval", err.Error()}}
+		log.Error(err.Error())
+		return nil, err
+	}
+	deviceId := sigbugRetrieveResponse.Sigbug.Id
+	now := time.Now().UTC()
+
+	// return downlinks which were sent but never acknowledged to the queue
+	if _, err := a.sigbugDownlinkRecordHandler.UpdateAll(&sigbugDownlinkRecordHandler.UpdateAllRequest{
+		Claims: request.Claims,
+		Criteria: append(
+			undeliveredCriteria(deviceId, true),
+			numberRangeCriterion.Criterion{
+				Field: "sentTime",
+				Start: numberRangeCriterion.RangeValue{Ignore: true},
+				End: numberRangeCriterion.RangeValue{
+					Number:    float64(now.Add(-acknowledgementTimeout).Unix()),
+					Inclusive: true,
+				},
+			},
+		),
+		Update: unsentUpdate(),
+	}); err != nil {
+		err = sigbugDownlinkAdministratorException.SendNext{Reasons: []string{"returning unacknowledged downlinks", err.Error()}}
+		log.Error(err.Error())
+		return nil, err
+	}
+
+	// claim the oldest unsent downlink
+	updateFieldsResponse, err := a.sigbugDownlinkRecordHandler.UpdateFields(&sigbugDownlinkRecordHandler.UpdateFieldsRequest{
+		Claims:   request.Claims,
+		Criteria: undeliveredCriteria(deviceId, false),
+		Query: query.Query{
+			Order:  []query.SortOrder{query.SortOrderAscending, query.SortOrderAscending},
+			SortBy: []string{"queuedTime", "id"},
+		},
+		Update: brainRecordHandler.FieldsUpdate{
+			Set: map[string]interface{}{
+				"sent":      true,
+				"sentTime":  now.Unix(),
+				"messageId": id.Identifier{Id: request.MessageId},
+			},
+		},
+	})
+	if err != nil {
+		switch err.(type) {
+		case sigbugDownlinkRecordHandlerException.NotFound:
+			return &sigbugDownlinkAdministrator.SendNextResponse{
+				Sent: false,
+			}, nil
+		default:
+			err = sigbugDownlinkAdministratorException.SendNext{Reasons: []string{"claiming downlink", err.Error()}}
+			log.Error(err.Error())
+			return nil, err
+		}
+	}
+
+	return &sigbugDownlinkAdministrator.SendNextResponse{
+		Sent:     true,
+		Downlink: updateFieldsResponse.Downlink,
+	}, nil
+}
+
+func (a *administrator) ValidateAcknowledgeRequest(request *sigbugDownlinkAdministrator.AcknowledgeRequest) error {
+	reasonsInvalid := make([]string, 0)
+
+	if request.Claims == nil {
+		reasonsInvalid = append(reasonsInvalid, "claims are nil")
+	}
+
+	if request.Identifier == nil {
+		reasonsInvalid = append(reasonsInvalid, "identifier is nil")
+	}
+
+	if len(reasonsInvalid) > 0 {
+		return brainException.RequestInvalid{Reasons: reasonsInvalid}
+	}
+	return nil
+}
+
+// Acknowledge marks the downlink most recently sent to the device as
+// delivered, or returns it to the queue if sigfox could not deliver it
+func (a *administrator) Acknowledge(request *sigbugDownlinkAdministrator.AcknowledgeRequest) (*sigbugDownlinkAdministrator.AcknowledgeResponse, error) {
+	if err := a.ValidateAcknowledgeRequest(request); err != nil {
+		return nil, err
+	}
+
+	// retrieve the sigbug device
+	sigbugRetrieveResponse, err := a.sigbugRecordHandler.Retrieve(&sigbugRecordHandler.RetrieveRequest{
+		Claims:     request.Claims,
+		Identifier: request.Identifier,
+	})
+	if err != nil {
+		err = sigbugDownlinkAdministratorException.Acknowledge{Reasons: []string{"device retrieval", err.Error()}}
+		log.Error(err.Error())
+		return nil, err
+	}
+
+	update := unsentUpdate()
+	if request.Delivered {
+		update = brainRecordHandler.FieldsUpdate{
+			Set: map[string]interface{}{
+				"delivered":     true,
+				"deliveredTime": time.Now().UTC().Unix(),
+			},
+		}
+	}
+	updateFieldsResponse, err := a.sigbugDownlinkRecordHandler.UpdateFields(&sigbugDownlinkRecordHandler.UpdateFieldsRequest{
+		Claims:   request.Claims,
+		Criteria: undeliveredCriteria(sigbugRetrieveResponse.Sigbug.Id, true),
+		Query: query.Query{
+			Order:  []query.SortOrder{query.SortOrderDescending},
+			SortBy: []string{"sentTime"},
+		},
+		Update: update,
+	})
+	if err != nil {
+		switch err.(type) {
+		case sigbugDownlinkRecordHandlerException.NotFound:
+			return &sigbugDownlinkAdministrator.AcknowledgeResponse{
+				Acknowledged: false,
+			}, nil
+		default:
+			err = sigbugDownlinkAdministratorException.Acknowledge{Reasons: []string{"downlink update", err.Error()}}
+			log.Error(err.Error())
+			return nil, err
+		}
+	}
+
+	return &sigbugDownlinkAdministrator.AcknowledgeResponse{
+		Acknowledged: true,
+		Downlink:     updateFieldsResponse.Downlink,
+	}, nil
+}
+
+// undeliveredCriteria returns criteria for the undelivered
+// downlinks of the device which have or have not been sent
+func undeliveredCriteria(deviceId string, sent bool) []criterion.Criterion {
+	return []criterion.Criterion{
+		exactTextCriterion.Criterion{
+			Field: "deviceId.id",
+			Text:  deviceId,
+		},
+		exactBooleanCriterion.Criterion{
+			Field:   "delivered",
+			Boolean: false,
+		},
+		exactBooleanCriterion.Criterion{
+			Field:   "sent",
+			Boolean: sent,
+		},
+	}
+}
+
+// unsentUpdate returns a sent downlink to the queue
+func unsentUpdate() brainRecordHandler.FieldsUpdate {
+	return brainRecordHandler.FieldsUpdate{
+		Set: map[string]interface{}{
+			"sent":      false,
+			"sentTime":  0,
+			"messageId": id.Identifier{},
+		},
+	}
+}
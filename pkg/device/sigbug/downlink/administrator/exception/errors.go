@@ -0,0 +1,19 @@
+package exception
+
+import "strings"
+
+type SendNext struct {
+	Reasons []string
+}
+
+func (e SendNext) Error() string {
+	return "error sending next downlink: " + strings.Join(e.Reasons, "; ")
+}
+
+type Acknowledge struct {
+	Reasons []string
+}
+
+func (e Acknowledge) Error() string {
+	return "error acknowledging downlink: " + strings.Join(e.Reasons, "; ")
+}
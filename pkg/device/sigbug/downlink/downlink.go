@@ -0,0 +1,42 @@
+package downlink
+
+import (
+	"github.com/iot-my-world/brain/pkg/party"
+	"github.com/iot-my-world/brain/pkg/search/identifier/id"
+)
+
+// PayloadLength is the length in bytes of a sigfox downlink payload
+const PayloadLength = 8
+
+// Downlink is data queued to be sent to a sigbug device
+// in reply to the next data message from the device which
+// requests an acknowledgement
+type Downlink struct {
+	Id       string        `json:"id" bson:"id"`
+	DeviceId id.Identifier `json:"deviceId" bson:"deviceId"`
+
+	// Owner Details, taken from the device
+	OwnerPartyType    party.Type    `json:"ownerPartyType" bson:"ownerPartyType"`
+	OwnerId           id.Identifier `json:"ownerId" bson:"ownerId"`
+	AssignedPartyType party.Type    `json:"assignedPartyType" bson:"assignedPartyType"`
+	AssignedId        id.Identifier `json:"assignedId" bson:"assignedId"`
+
+	Data       []byte `json:"data" bson:"data"`
+	QueuedTime int64  `json:"queuedTime" bson:"queuedTime"`
+
+	// Sending Details, set when the downlink is sent in reply to a
+	// message from the device. A downlink which is sent but not
+	// acknowledged is sent again.
+	Sent      bool          `json:"sent" bson:"sent"`
+	SentTime  int64         `json:"sentTime" bson:"sentTime"`
+	MessageId id.Identifier `json:"messageId" bson:"messageId"`
+
+	// Delivery Details, set once sigfox acknowledges that
+	// the device has received the downlink
+	Delivered     bool  `json:"delivered" bson:"delivered"`
+	DeliveredTime int64 `json:"deliveredTime" bson:"deliveredTime"`
+}
+
+func (d *Downlink) SetId(id string) {
+	d.Id = id
+}
@@ -0,0 +1,101 @@
+package jsonRpc
+
+import (
+	"github.com/iot-my-world/brain/internal/log"
+	jsonRpcServiceProvider "github.com/iot-my-world/brain/pkg/api/jsonRpc/service/provider"
+	"github.com/iot-my-world/brain/pkg/device/sigbug/downlink"
+	sigbugDownlinkRecordHandler "github.com/iot-my-world/brain/pkg/device/sigbug/downlink/recordHandler"
+	"github.com/iot-my-world/brain/pkg/search/criterion"
+	wrappedCriterion "github.com/iot-my-world/brain/pkg/search/criterion/wrapped"
+	wrappedIdentifier "github.com/iot-my-world/brain/pkg/search/identifier/wrapped"
+	"github.com/iot-my-world/brain/pkg/search/query"
+	wrappedClaims "github.com/iot-my-world/brain/pkg/security/claims/wrapped"
+	"net/http"
+)
+
+type adaptor struct {
+	RecordHandler sigbugDownlinkRecordHandler.RecordHandler
+}
+
+func New(recordHandler sigbugDownlinkRecordHandler.RecordHandler) *adaptor {
+	return &adaptor{
+		RecordHandler: recordHandler,
+	}
+}
+
+func (a *adaptor) Name() jsonRpcServiceProvider.Name {
+	return jsonRpcServiceProvider.Name(sigbugDownlinkRecordHandler.ServiceProvider)
+}
+
+func (a *adaptor) MethodRequiresAuthorization(string) bool {
+	return true
+}
+
+type RetrieveRequest struct {
+	WrappedIdentifier wrappedIdentifier.Wrapped `json:"identifier"`
+}
+
+type RetrieveResponse struct {
+	Downlink downlink.Downlink `json:"downlink"`
+}
+
+func (a *adaptor) Retrieve(r *http.Request, request *RetrieveRequest, response *RetrieveResponse) error {
+	claims, err := wrappedClaims.UnwrapClaimsFromContext(r)
+	if err != nil {
+		log.Warn(err.Error())
+		return err
+	}
+
+	retrieveDownlinkResponse, err := a.RecordHandler.Retrieve(
+		&sigbugDownlinkRecordHandler.RetrieveRequest{
+			Claims:     claims,
+			Identifier: request.WrappedIdentifier.Identifier,
+		})
+	if err != nil {
+		return err
+	}
+
+	response.Downlink = retrieveDownlinkResponse.Downlink
+
+	return nil
+}
+
+type CollectRequest struct {
+	Criteria []wrappedCriterion.Wrapped `json:"criteria"`
+	Query    query.Query                `json:"query"`
+}
+
+type CollectResponse struct {
+	Records []downlink.Downlink `json:"records"`
+	Total   int                 `json:"total"`
+}
+
+func (a *adaptor) Collect(r *http.Request, request *CollectRequest, response *CollectResponse) error {
+	claims, err := wrappedClaims.UnwrapClaimsFromContext(r)
+	if err != nil {
+		log.Warn(err.Error())
+		return err
+	}
+
+	criteria := make([]criterion.Criterion, 0)
+	for criterionIdx := range request.Criteria {
+		if c, err := request.Criteria[criterionIdx].UnWrap(); err == nil {
+			criteria = append(criteria, c)
+		} else {
+			return err
+		}
+	}
+
+	collectDownlinkResponse, err := a.RecordHandler.Collect(&sigbugDownlinkRecordHandler.CollectRequest{
+		Claims:   claims,
+		Criteria: criteria,
+		Query:    request.Query,
+	})
+	if err != nil {
+		return err
+	}
+
+	response.Records = collectDownlinkResponse.Records
+	response.Total = collectDownlinkResponse.Total
+	return nil
+}
@@ -0,0 +1,55 @@
+package exception
+
+import "strings"
+
+type RecordHandlerNil struct{}
+
+func (e RecordHandlerNil) Error() string {
+	return "given brain sigbug downlink recordHandler is nil"
+}
+
+type NotFound struct{}
+
+func (e NotFound) Error() string {
+	return "sigbug downlink not found"
+}
+
+type Create struct {
+	Reasons []string
+}
+
+func (e Create) Error() string {
+	return "sigbug downlink creation error: " + strings.Join(e.Reasons, "; ")
+}
+
+type Retrieve struct {
+	Reasons []string
+}
+
+func (e Retrieve) Error() string {
+	return "sigbug downlink retrieval error: " + strings.Join(e.Reasons, "; ")
+}
+
+type Update struct {
+	Reasons []string
+}
+
+func (e Update) Error() string {
+	return "sigbug downlink update error: " + strings.Join(e.Reasons, "; ")
+}
+
+type Delete struct {
+	Reasons []string
+}
+
+func (e Delete) Error() string {
+	return "sigbug downlink delete error: " + strings.Join(e.Reasons, "; ")
+}
+
+type Collect struct {
+	Reasons []string
+}
+
+func (e Collect) Error() string {
+	return "sigbug downlink collect error: " + strings.Join(e.Reasons, "; ")
+}
@@ -0,0 +1,172 @@
+package sigbugDownlinkRecordHandler
+
+import (
+	brainException "github.com/iot-my-world/brain/internal/exception"
+	"github.com/iot-my-world/brain/pkg/device/sigbug/downlink"
+	sigbugDownlinkRecordHandler "github.com/iot-my-world/brain/pkg/device/sigbug/downlink/recordHandler"
+	sigbugDownlinkRecordHandlerException "github.com/iot-my-world/brain/pkg/device/sigbug/downlink/recordHandler/exception"
+	brainRecordHandler "github.com/iot-my-world/brain/pkg/recordHandler"
+	brainRecordHandlerException "github.com/iot-my-world/brain/pkg/recordHandler/exception"
+)
+
+type RecordHandler struct {
+	sigbugDownlinkRecordHandler brainRecordHandler.RecordHandler
+}
+
+func New(
+	brainDownlinkRecordHandler brainRecordHandler.RecordHandler,
+) sigbugDownlinkRecordHandler.RecordHandler {
+
+	return &RecordHandler{
+		sigbugDownlinkRecordHandler: brainDownlinkRecordHandler,
+	}
+}
+
+type CreateRequest struct {
+	Downlink downlink.Downlink
+}
+
+type CreateResponse struct {
+	Downlink downlink.Downlink
+}
+
+func (r *RecordHandler) ValidateCreateRequest(request *sigbugDownlinkRecordHandler.CreateRequest) error {
+	reasonsInvalid := make([]string, 0)
+
+	if len(reasonsInvalid) > 0 {
+		return brainException.RequestInvalid{Reasons: reasonsInvalid}
+	}
+
+	return nil
+}
+
+func (r *RecordHandler) Create(request *sigbugDownlinkRecordHandler.CreateRequest) (*sigbugDownlinkRecordHandler.CreateResponse, error) {
+	if err := r.ValidateCreateRequest(request); err != nil {
+		return nil, err
+	}
+
+	createResponse := brainRecordHandler.CreateResponse{}
+	if err := r.sigbugDownlinkRecordHandler.Create(&brainRecordHandler.CreateRequest{
+		Entity: &request.Downlink,
+	}, &createResponse); err != nil {
+		return nil, sigbugDownlinkRecordHandlerException.Create{Reasons: []string{err.Error()}}
+	}
+	createdDownlink, ok := createResponse.Entity.(*downlink.Downlink)
+	if !ok {
+		return nil, sigbugDownlinkRecordHandlerException.Create{Reasons: []string{"could not cast created entity to sigbug downlink"}}
+	}
+
+	return &sigbugDownlinkRecordHandler.CreateResponse{
+		Downlink: *createdDownlink,
+	}, nil
+}
+
+func (r *RecordHandler) Retrieve(request *sigbugDownlinkRecordHandler.RetrieveRequest) (*sigbugDownlinkRecordHandler.RetrieveResponse, error) {
+	retrievedDownlink := downlink.Downlink{}
+	retrieveResponse := brainRecordHandler.RetrieveResponse{
+		Entity: &retrievedDownlink,
+	}
+	if err := r.sigbugDownlinkRecordHandler.Retrieve(&brainRecordHandler.RetrieveRequest{
+		Claims:     request.Claims,
+		Identifier: request.Identifier,
+	}, &retrieveResponse); err != nil {
+		switch err.(type) {
+		case brainRecordHandlerException.NotFound:
+			return nil, sigbugDownlinkRecordHandlerException.NotFound{}
+		default:
+			return nil, err
+		}
+	}
+
+	return &sigbugDownlinkRecordHandler.RetrieveResponse{
+		Downlink: retrievedDownlink,
+	}, nil
+}
+
+func (r *RecordHandler) Update(request *sigbugDownlinkRecordHandler.UpdateRequest) (*sigbugDownlinkRecordHandler.UpdateResponse, error) {
+	updateResponse := brainRecordHandler.UpdateResponse{}
+	if err := r.sigbugDownlinkRecordHandler.Update(&brainRecordHandler.UpdateRequest{
+		Claims:     request.Claims,
+		Identifier: request.Identifier,
+		Entity:     &request.Downlink,
+	}, &updateResponse); err != nil {
+		return nil, sigbugDownlinkRecordHandlerException.Update{Reasons: []string{err.Error()}}
+	}
+
+	return &sigbugDownlinkRecordHandler.UpdateResponse{}, nil
+}
+
+func (r *RecordHandler) Delete(request *sigbugDownlinkRecordHandler.DeleteRequest) (*sigbugDownlinkRecordHandler.DeleteResponse, error) {
+	deleteResponse := brainRecordHandler.DeleteResponse{}
+	if err := r.sigbugDownlinkRecordHandler.Delete(&brainRecordHandler.DeleteRequest{
+		Claims:     request.Claims,
+		Identifier: request.Identifier,
+	}, &deleteResponse); err != nil {
+		return nil, sigbugDownlinkRecordHandlerException.Delete{Reasons: []string{err.Error()}}
+	}
+
+	return &sigbugDownlinkRecordHandler.DeleteResponse{}, nil
+}
+
+func (r *RecordHandler) Collect(request *sigbugDownlinkRecordHandler.CollectRequest) (*sigbugDownlinkRecordHandler.CollectResponse, error) {
+	var collectedDownlink []downlink.Downlink
+	collectResponse := brainRecordHandler.CollectResponse{
+		Records: &collectedDownlink,
+	}
+	err := r.sigbugDownlinkRecordHandler.Collect(&brainRecordHandler.CollectRequest{
+		Claims:   request.Claims,
+		Criteria: request.Criteria,
+		Query:    request.Query,
+	}, &collectResponse)
+	if err != nil {
+		return nil, sigbugDownlinkRecordHandlerException.Collect{Reasons: []string{err.Error()}}
+	}
+
+	if collectedDownlink == nil {
+		collectedDownlink = make([]downlink.Downlink, 0)
+	}
+
+	return &sigbugDownlinkRecordHandler.CollectResponse{
+		Records: collectedDownlink,
+		Total:   collectResponse.Total,
+	}, nil
+}
+
+func (r *RecordHandler) UpdateFields(request *sigbugDownlinkRecordHandler.UpdateFieldsRequest) (*sigbugDownlinkRecordHandler.UpdateFieldsResponse, error) {
+	updatedDownlink := downlink.Downlink{}
+	updateFieldsResponse := brainRecordHandler.UpdateFieldsResponse{
+		Entity: &updatedDownlink,
+	}
+	if err := r.sigbugDownlinkRecordHandler.UpdateFields(&brainRecordHandler.UpdateFieldsRequest{
+		Claims:   request.Claims,
+		Criteria: request.Criteria,
+		Query:    request.Query,
+		Update:   request.Update,
+	}, &updateFieldsResponse); err != nil {
+		switch err.(type) {
+		case brainRecordHandlerException.NotFound:
+			return nil, sigbugDownlinkRecordHandlerException.NotFound{}
+		default:
+			return nil, sigbugDownlinkRecordHandlerException.Update{Reasons: []string{err.Error()}}
+		}
+	}
+
+	return &sigbugDownlinkRecordHandler.UpdateFieldsResponse{
+		Downlink: updatedDownlink,
+	}, nil
+}
+
+func (r *RecordHandler) UpdateAll(request *sigbugDownlinkRecordHandler.UpdateAllRequest) (*sigbugDownlinkRecordHandler.UpdateAllResponse, error) {
+	updateAllResponse := brainRecordHandler.UpdateAllResponse{}
+	if err := r.sigbugDownlinkRecordHandler.UpdateAll(&brainRecordHandler.UpdateAllRequest{
+		Claims:   request.Claims,
+		Criteria: request.Criteria,
+		Update:   request.Update,
+	}, &updateAllResponse); err != nil {
+		return nil, sigbugDownlinkRecordHandlerException.Update{Reasons: []string{err.Error()}}
+	}
+
+	return &sigbugDownlinkRecordHandler.UpdateAllResponse{
+		NoUpdated: updateAllResponse.NoUpdated,
+	}, nil
+}
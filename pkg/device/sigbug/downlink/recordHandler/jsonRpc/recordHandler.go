@@ -0,0 +1,135 @@
+package jsonRpc
+
+import (
+	brainException "github.com/iot-my-world/brain/internal/exception"
+	"github.com/iot-my-world/brain/internal/log"
+	jsonRpcClient "github.com/iot-my-world/brain/pkg/api/jsonRpc/client"
+	sigbugDownlinkRecordHandler "github.com/iot-my-world/brain/pkg/device/sigbug/downlink/recordHandler"
+	sigbugDownlinkRecordHandlerJsonRpcAdaptor "github.com/iot-my-world/brain/pkg/device/sigbug/downlink/recordHandler/adaptor/jsonRpc"
+	wrappedCriterion "github.com/iot-my-world/brain/pkg/search/criterion/wrapped"
+	wrappedIdentifier "github.com/iot-my-world/brain/pkg/search/identifier/wrapped"
+)
+
+type recordHandler struct {
+	jsonRpcClient jsonRpcClient.Client
+}
+
+func New(
+	jsonRpcClient jsonRpcClient.Client,
+) sigbugDownlinkRecordHandler.RecordHandler {
+	return &recordHandler{
+		jsonRpcClient: jsonRpcClient,
+	}
+}
+
+func (r *recordHandler) Create(request *sigbugDownlinkRecordHandler.CreateRequest) (*sigbugDownlinkRecordHandler.CreateResponse, error) {
+	return nil, brainException.NotImplemented{}
+}
+
+func (r *recordHandler) ValidateRetrieveRequest(request *sigbugDownlinkRecordHandler.RetrieveRequest) error {
+	reasonsInvalid := make([]string, 0)
+	if request.Identifier == nil {
+		reasonsInvalid = append(reasonsInvalid, "identifier is nil")
+	}
+
+	if len(reasonsInvalid) > 0 {
+		return brainException.RequestInvalid{Reasons: reasonsInvalid}
+	}
+	return nil
+}
+
+func (r *recordHandler) Retrieve(request *sigbugDownlinkRecordHandler.RetrieveRequest) (*sigbugDownlinkRecordHandler.RetrieveResponse, error) {
+	if err := r.ValidateRetrieveRequest(request); err != nil {
+		log.Error(err.Error())
+		return nil, err
+	}
+
+	// wrap identifier
+	id, err := wrappedIdentifier.Wrap(request.Identifier)
+	if err != nil {
+		log.Error(err.Error())
+		return nil, err
+	}
+
+	sigbugDownlinkRetrieveResponse := sigbugDownlinkRecordHandlerJsonRpcAdaptor.RetrieveResponse{}
+	if err := r.jsonRpcClient.JsonRpcRequest(
+		sigbugDownlinkRecordHandler.RetrieveService,
+		sigbugDownlinkRecordHandlerJsonRpcAdaptor.RetrieveRequest{
+			WrappedIdentifier: *id,
+		},
+		&sigbugDownlinkRetrieveResponse,
+	); err != nil {
+		log.Error(err.Error())
+		return nil, err
+	}
+
+	return &sigbugDownlinkRecordHandler.RetrieveResponse{
+		Downlink: sigbugDownlinkRetrieveResponse.Downlink,
+	}, nil
+}
+func (r *recordHandler) Update(request *sigbugDownlinkRecordHandler.UpdateRequest) (*sigbugDownlinkRecordHandler.UpdateResponse, error) {
+	return nil, brainException.NotImplemented{}
+}
+func (r *recordHandler) Delete(request *sigbugDownlinkRecordHandler.DeleteRequest) (*sigbugDownlinkRecordHandler.DeleteResponse, error) {
+	return nil, brainException.NotImplemented{}
+}
+
+func (r *recordHandler) ValidateCollectRequest(request *sigbugDownlinkRecordHandler.CollectRequest) error {
+	reasonsInvalid := make([]string, 0)
+
+	if request.Criteria == nil {
+		reasonsInvalid = append(reasonsInvalid, "criteria is nil")
+	} else {
+		for _, crit := range request.Criteria {
+			if crit == nil {
+				reasonsInvalid = append(reasonsInvalid, "a criterion is nil")
+			}
+		}
+	}
+
+	if len(reasonsInvalid) > 0 {
+		return brainException.RequestInvalid{Reasons: reasonsInvalid}
+	}
+	return nil
+}
+
+func (r *recordHandler) Collect(request *sigbugDownlinkRecordHandler.CollectRequest) (*sigbugDownlinkRecordHandler.CollectResponse, error) {
+	if err := r.ValidateCollectRequest(request); err != nil {
+		return nil, err
+	}
+
+	// wrap criteria
+	criteria := make([]wrappedCriterion.Wrapped, 0)
+	for _, crit := range request.Criteria {
+		wrapped, err := wrappedCriterion.Wrap(crit)
+		if err != nil {
+			log.Error(err.Error())
+			return nil, err
+		}
+		criteria = append(criteria, *wrapped)
+	}
+
+	collectResponse := sigbugDownlinkRecordHandlerJsonRpcAdaptor.CollectResponse{}
+	if err := r.jsonRpcClient.JsonRpcRequest(
+		sigbugDownlinkRecordHandler.CollectService,
+		sigbugDownlinkRecordHandlerJsonRpcAdaptor.CollectRequest{
+			Criteria: criteria,
+			Query:    request.Query,
+		},
+		&collectResponse); err != nil {
+		return nil, err
+	}
+
+	return &sigbugDownlinkRecordHandler.CollectResponse{
+		Records: collectResponse.Records,
+		Total:   collectResponse.Total,
+	}, nil
+}
+
+func (r *recordHandler) UpdateFields(request *sigbugDownlinkRecordHandler.UpdateFieldsRequest) (*sigbugDownlinkRecordHandler.UpdateFieldsResponse, error) {
+	return nil, brainException.NotImplemented{}
+}
+
+func (r *recordHandler) UpdateAll(request *sigbugDownlinkRecordHandler.UpdateAllRequest) (*sigbugDownlinkRecordHandler.UpdateAllResponse, error) {
+	return nil, brainException.NotImplemented{}
+}
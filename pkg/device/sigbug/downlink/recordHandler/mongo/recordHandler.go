@@ -0,0 +1,37 @@
+package mongo
+
+import (
+	"github.com/iot-my-world/brain/pkg/device/sigbug/downlink"
+	sigbugDownlinkRecordHandler "github.com/iot-my-world/brain/pkg/device/sigbug/downlink/recordHandler"
+	sigbugDownlinkGenericRecordHandler "github.com/iot-my-world/brain/pkg/device/sigbug/downlink/recordHandler/generic"
+	brainMongoRecordHandler "github.com/iot-my-world/brain/pkg/recordHandler/mongo"
+	"github.com/iot-my-world/brain/pkg/security/claims"
+	"gopkg.in/mgo.v2"
+)
+
+func New(
+	mongoSession *mgo.Session,
+	databaseName string,
+	collectionName string,
+) sigbugDownlinkRecordHandler.RecordHandler {
+	mongoRecordHandler := brainMongoRecordHandler.New(
+		mongoSession,
+		databaseName,
+		collectionName,
+		[]mgo.Index{
+			{
+				Key:    []string{"id"},
+				Unique: true,
+			},
+			{
+				Key: []string{"deviceId.id", "delivered", "sent", "queuedTime"},
+			},
+		},
+		downlink.IsValidIdentifier,
+		claims.ContextualiseFilter,
+	)
+
+	return sigbugDownlinkGenericRecordHandler.New(
+		mongoRecordHandler,
+	)
+}
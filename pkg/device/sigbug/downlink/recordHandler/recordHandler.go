@@ -0,0 +1,122 @@
+package recordHandler
+
+import (
+	"github.com/iot-my-world/brain/pkg/device/sigbug/downlink"
+	brainRecordHandler "github.com/iot-my-world/brain/pkg/recordHandler"
+	"github.com/iot-my-world/brain/pkg/search/criterion"
+	"github.com/iot-my-world/brain/pkg/search/identifier"
+	"github.com/iot-my-world/brain/pkg/search/query"
+	"github.com/iot-my-world/brain/pkg/security/claims"
+	"github.com/iot-my-world/brain/pkg/security/permission/api"
+)
+
+type RecordHandler interface {
+	Create(*CreateRequest) (*CreateResponse, error)
+	Retrieve(*RetrieveRequest) (*RetrieveResponse, error)
+	Update(*UpdateRequest) (*UpdateResponse, error)
+	Delete(*DeleteRequest) (*DeleteResponse, error)
+	Collect(*CollectRequest) (*CollectResponse, error)
+	UpdateFields(*UpdateFieldsRequest) (*UpdateFieldsResponse, error)
+	UpdateAll(*UpdateAllRequest) (*UpdateAllResponse, error)
+}
+
+const ServiceProvider = "SigbugDownlink-RecordHandler"
+const CreateService = ServiceProvider + ".Create"
+const RetrieveService = ServiceProvider + ".Retrieve"
+const UpdateService = ServiceProvider + ".Update"
+const DeleteService = ServiceProvider + ".Delete"
+const CollectService = ServiceProvider + ".Collect"
+
+var SystemUserPermissions = []api.Permission{
+	RetrieveService,
+	CollectService,
+}
+
+var CompanyAdminUserPermissions = []api.Permission{
+	RetrieveService,
+	CollectService,
+}
+
+var CompanyUserPermissions = []api.Permission{
+	RetrieveService,
+	CollectService,
+}
+
+var ClientAdminUserPermissions = []api.Permission{
+	RetrieveService,
+	CollectService,
+}
+
+var ClientUserPermissions = []api.Permission{
+	RetrieveService,
+	CollectService,
+}
+
+type CreateRequest struct {
+	Downlink downlink.Downlink
+}
+
+type CreateResponse struct {
+	Downlink downlink.Downlink
+}
+
+type RetrieveRequest struct {
+	Claims     claims.Claims
+	Identifier identifier.Identifier
+}
+
+type RetrieveResponse struct {
+	Downlink downlink.Downlink
+}
+
+type UpdateRequest struct {
+	Claims     claims.Claims
+	Identifier identifier.Identifier
+	Downlink   downlink.Downlink
+}
+
+type UpdateResponse struct{}
+
+type DeleteRequest struct {
+	Claims     claims.Claims
+	Identifier identifier.Identifier
+}
+
+type DeleteResponse struct {
+}
+
+type CollectRequest struct {
+	Claims   claims.Claims
+	Criteria []criterion.Criterion
+	Query    query.Query
+}
+
+type CollectResponse struct {
+	Records []downlink.Downlink
+	Total   int
+}
+
+// UpdateFieldsRequest atomically updates the fields of the first
+// downlink matching the criteria, in the sort order of the query
+type UpdateFieldsRequest struct {
+	Claims   claims.Claims
+	Criteria []criterion.Criterion
+	Query    query.Query
+	Update   brainRecordHandler.FieldsUpdate
+}
+
+type UpdateFieldsResponse struct {
+	Downlink downlink.Downlink
+}
+
+// UpdateAllRequest updates the fields of all downlinks
+// matching the criteria
+type UpdateAllRequest struct {
+	Claims   claims.Claims
+	Criteria []criterion.Criterion
+	Update   brainRecordHandler.FieldsUpdate
+}
+
+type UpdateAllResponse struct {
+	NoUpdated int
+}
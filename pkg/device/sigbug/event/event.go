@@ -0,0 +1,41 @@
+package event
+
+import (
+	"github.com/iot-my-world/brain/pkg/party"
+	"github.com/iot-my-world/brain/pkg/search/identifier/id"
+)
+
+type Type string
+
+// CouldNotGetGPSFix is raised when a sigbug reports that it
+// was unable to get a gps fix
+const CouldNotGetGPSFix Type = "CouldNotGetGPSFix"
+
+// WentOffline is raised when a sigbug has been silent for longer than its silence threshold
+const WentOffline Type = "WentOffline"
+
+// CameOnline is raised when a sigbug which was offline sends a message
+const CameOnline Type = "CameOnline"
+
+// Event is something of note reported by a sigbug device
+type Event struct {
+	Id   string `json:"id" bson:"id"`
+	Type Type   `json:"type" bson:"type"`
+
+	// Device Details
+	DeviceId id.Identifier `json:"deviceId" bson:"deviceId"`
+
+	// Owner Details, taken from the device
+	OwnerPartyType    party.Type    `json:"ownerPartyType" bson:"ownerPartyType"`
+	OwnerId           id.Identifier `json:"ownerId" bson:"ownerId"`
+	AssignedPartyType party.Type    `json:"assignedPartyType" bson:"assignedPartyType"`
+	AssignedId        id.Identifier `json:"assignedId" bson:"assignedId"`
+
+	// Event Details
+	MessageId id.Identifier `json:"messageId" bson:"messageId"`
+	TimeStamp int64         `json:"timeStamp" bson:"timeStamp"`
+}
+
+func (e *Event) SetId(id string) {
+	e.Id = id
+}
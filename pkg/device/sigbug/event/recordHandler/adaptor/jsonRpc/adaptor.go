@@ -0,0 +1,101 @@
+package jsonRpc
+
+import (
+	"github.com/iot-my-world/brain/internal/log"
+	jsonRpcServiceProvider "github.com/iot-my-world/brain/pkg/api/jsonRpc/service/provider"
+	"github.com/iot-my-world/brain/pkg/device/sigbug/event"
+	sigbugEventRecordHandler "github.com/iot-my-world/brain/pkg/device/sigbug/event/recordHandler"
+	"github.com/iot-my-world/brain/pkg/search/criterion"
+	wrappedCriterion "github.com/iot-my-world/brain/pkg/search/criterion/wrapped"
+	wrappedIdentifier "github.com/iot-my-world/brain/pkg/search/identifier/wrapped"
+	"github.com/iot-my-world/brain/pkg/search/query"
+	wrappedClaims "github.com/iot-my-world/brain/pkg/security/claims/wrapped"
+	"net/http"
+)
+
+type adaptor struct {
+	RecordHandler sigbugEventRecordHandler.RecordHandler
+}
+
+func New(recordHandler sigbugEventRecordHandler.RecordHandler) *adaptor {
+	return &adaptor{
+		RecordHandler: recordHandler,
+	}
+}
+
+func (a *adaptor) Name() jsonRpcServiceProvider.Name {
+	return jsonRpcServiceProvider.Name(sigbugEventRecordHandler.ServiceProvider)
+}
+
+func (a *adaptor) MethodRequiresAuthorization(string) bool {
+	return true
+}
+
+type RetrieveRequest struct {
+	WrappedIdentifier wrappedIdentifier.Wrapped `json:"identifier"`
+}
+
+type RetrieveResponse struct {
+	Event event.Event `json:"event"`
+}
+
+func (a *adaptor) Retrieve(r *http.Request, request *RetrieveRequest, response *RetrieveResponse) error {
+	claims, err := wrappedClaims.UnwrapClaimsFromContext(r)
+	if err != nil {
+		log.Warn(err.Error())
+		return err
+	}
+
+	retrieveEventResponse, err := a.RecordHandler.Retrieve(
+		&sigbugEventRecordHandler.RetrieveRequest{
+			Claims:     claims,
+			Identifier: request.WrappedIdentifier.Identifier,
+		})
+	if err != nil {
+		return err
+	}
+
+	response.Event = retrieveEventResponse.Event
+
+	return nil
+}
+
+type CollectRequest struct {
+	Criteria []wrappedCriterion.Wrapped `json:"criteria"`
+	Query    query.Query                `json:"query"`
+}
+
+type CollectResponse struct {
+	Records []event.Event `json:"records"`
+	Total   int           `json:"total"`
+}
+
+func (a *adaptor) Collect(r *http.Request, request *CollectRequest, response *CollectResponse) error {
+	claims, err := wrappedClaims.UnwrapClaimsFromContext(r)
+	if err != nil {
+		log.Warn(err.Error())
+		return err
+	}
+
+	criteria := make([]criterion.Criterion, 0)
+	for criterionIdx := range request.Criteria {
+		if c, err := request.Criteria[criterionIdx].UnWrap(); err == nil {
+			criteria = append(criteria, c)
+		} else {
+			return err
+		}
+	}
+
+	collectEventResponse, err := a.RecordHandler.Collect(&sigbugEventRecordHandler.CollectRequest{
+		Claims:   claims,
+		Criteria: criteria,
+		Query:    request.Query,
+	})
+	if err != nil {
+		return err
+	}
+
+	response.Records = collectEventResponse.Records
+	response.Total = collectEventResponse.Total
+	return nil
+}
@@ -0,0 +1,137 @@
+package sigbugEventRecordHandler
+
+import (
+	brainException "github.com/iot-my-world/brain/internal/exception"
+	"github.com/iot-my-world/brain/pkg/device/sigbug/event"
+	sigbugEventRecordHandler "github.com/iot-my-world/brain/pkg/device/sigbug/event/recordHandler"
+	sigbugEventRecordHandlerException "github.com/iot-my-world/brain/pkg/device/sigbug/event/recordHandler/exception"
+	brainRecordHandler "github.com/iot-my-world/brain/pkg/recordHandler"
+	brainRecordHandlerException "github.com/iot-my-world/brain/pkg/recordHandler/exception"
+)
+
+type RecordHandler struct {
+	sigbugEventRecordHandler brainRecordHandler.RecordHandler
+}
+
+func New(
+	brainEventRecordHandler brainRecordHandler.RecordHandler,
+) sigbugEventRecordHandler.RecordHandler {
+
+	return &RecordHandler{
+		sigbugEventRecordHandler: brainEventRecordHandler,
+	}
+}
+
+type CreateRequest struct {
+	Event event.Event
+}
+
+type CreateResponse struct {
+	Event event.Event
+}
+
+func (r *RecordHandler) ValidateCreateRequest(request *sigbugEventRecordHandler.CreateRequest) error {
+	reasonsInvalid := make([]string, 0)
+
+	if request.Claims == nil {
+		reasonsInvalid = append(reasonsInvalid, "claims are nil")
+	}
+
+	if len(reasonsInvalid) > 0 {
+		return brainException.RequestInvalid{Reasons: reasonsInvalid}
+	}
+
+	return nil
+}
+
+func (r *RecordHandler) Create(request *sigbugEventRecordHandler.CreateRequest) (*sigbugEventRecordHandler.CreateResponse, error) {
+	if err := r.ValidateCreateRequest(request); err != nil {
+		return nil, err
+	}
+
+	createResponse := brainRecordHandler.CreateResponse{}
+	if err := r.sigbugEventRecordHandler.Create(&brainRecordHandler.CreateRequest{
+		Entity: &request.Event,
+	}, &createResponse); err != nil {
+		return nil, sigbugEventRecordHandlerException.Create{Reasons: []string{err.Error()}}
+	}
+	createdEvent, ok := createResponse.Entity.(*event.Event)
+	if !ok {
+		return nil, sigbugEventRecordHandlerException.Create{Reasons: []string{"could not cast created entity to sigbug event"}}
+	}
+
+	return &sigbugEventRecordHandler.CreateResponse{
+		Event: *createdEvent,
+	}, nil
+}
+
+func (r *RecordHandler) Retrieve(request *sigbugEventRecordHandler.RetrieveRequest) (*sigbugEventRecordHandler.RetrieveResponse, error) {
+	retrievedEvent := event.Event{}
+	retrieveResponse := brainRecordHandler.RetrieveResponse{
+		Entity: &retrievedEvent,
+	}
+	if err := r.sigbugEventRecordHandler.Retrieve(&brainRecordHandler.RetrieveRequest{
+		Claims:     request.Claims,
+		Identifier: request.Identifier,
+	}, &retrieveResponse); err != nil {
+		switch err.(type) {
+		case brainRecordHandlerException.NotFound:
+			return nil, sigbugEventRecordHandlerException.NotFound{}
+		default:
+			return nil, err
+		}
+	}
+
+	return &sigbugEventRecordHandler.RetrieveResponse{
+		Event: retrievedEvent,
+	}, nil
+}
+
+func (r *RecordHandler) Update(request *sigbugEventRecordHandler.UpdateRequest) (*sigbugEventRecordHandler.UpdateResponse, error) {
+	updateResponse := brainRecordHandler.UpdateResponse{}
+	if err := r.sigbugEventRecordHandler.Update(&brainRecordHandler.UpdateRequest{
+		Claims:     request.Claims,
+		Identifier: request.Identifier,
+		Entity:     &request.Event,
+	}, &updateResponse); err != nil {
+		return nil, sigbugEventRecordHandlerException.Update{Reasons: []string{err.Error()}}
+	}
+
+	return &sigbugEventRecordHandler.UpdateResponse{}, nil
+}
+
+func (r *RecordHandler) Delete(request *sigbugEventRecordHandler.DeleteRequest) (*sigbugEventRecordHandler.DeleteResponse, error) {
+	deleteResponse := brainRecordHandler.DeleteResponse{}
+	if err := r.sigbugEventRecordHandler.Delete(&brainRecordHandler.DeleteRequest{
+		Claims:     request.Claims,
+		Identifier: request.Identifier,
+	}, &deleteResponse); err != nil {
+		return nil, sigbugEventRecordHandlerException.Delete{Reasons: []string{err.Error()}}
+	}
+
+	return &sigbugEventRecordHandler.DeleteResponse{}, nil
+}
+
+func (r *RecordHandler) Collect(request *sigbugEventRecordHandler.CollectRequest) (*sigbugEventRecordHandler.CollectResponse, error) {
+	var collectedEvent []event.Event
+	collectResponse := brainRecordHandler.CollectResponse{
+		Records: &collectedEvent,
+	}
+	err := r.sigbugEventRecordHandler.Collect(&brainRecordHandler.CollectRequest{
+		Claims:   request.Claims,
+		Criteria: request.Criteria,
+		Query:    request.Query,
+	}, &collectResponse)
+	if err != nil {
+		return nil, sigbugEventRecordHandlerException.Collect{Reasons: []string{err.Error()}}
+	}
+
+	if collectedEvent == nil {
+		collectedEvent = make([]event.Event, 0)
+	}
+
+	return &sigbugEventRecordHandler.CollectResponse{
+		Records: collectedEvent,
+		Total:   collectResponse.Total,
+	}, nil
+}
@@ -0,0 +1,127 @@
+package jsonRpc
+
+import (
+	brainException "github.com/iot-my-world/brain/internal/exception"
+	"github.com/iot-my-world/brain/internal/log"
+	jsonRpcClient "github.com/iot-my-world/brain/pkg/api/jsonRpc/client"
+	sigbugEventRecordHandler "github.com/iot-my-world/brain/pkg/device/sigbug/event/recordHandler"
+	sigbugEventRecordHandlerJsonRpcAdaptor "github.com/iot-my-world/brain/pkg/device/sigbug/event/recordHandler/adaptor/jsonRpc"
+	wrappedCriterion "github.com/iot-my-world/brain/pkg/search/criterion/wrapped"
+	wrappedIdentifier "github.com/iot-my-world/brain/pkg/search/identifier/wrapped"
+)
+
+type recordHandler struct {
+	jsonRpcClient jsonRpcClient.Client
+}
+
+func New(
+	jsonRpcClient jsonRpcClient.Client,
+) sigbugEventRecordHandler.RecordHandler {
+	return &recordHandler{
+		jsonRpcClient: jsonRpcClient,
+	}
+}
+
+func (r *recordHandler) Create(request *sigbugEventRecordHandler.CreateRequest) (*sigbugEventRecordHandler.CreateResponse, error) {
+	return nil, brainException.NotImplemented{}
+}
+
+func (r *recordHandler) ValidateRetrieveRequest(request *sigbugEventRecordHandler.RetrieveRequest) error {
+	reasonsInvalid := make([]string, 0)
+	if request.Identifier == nil {
+		reasonsInvalid = append(reasonsInvalid, "identifier is nil")
+	}
+
+	if len(reasonsInvalid) > 0 {
+		return brainException.RequestInvalid{Reasons: reasonsInvalid}
+	}
+	return nil
+}
+
+func (r *recordHandler) Retrieve(request *sigbugEventRecordHandler.RetrieveRequest) (*sigbugEventRecordHandler.RetrieveResponse, error) {
+	if err := r.ValidateRetrieveRequest(request); err != nil {
+		log.Error(err.Error())
+		return nil, err
+	}
+
+	// wrap identifier
+	id, err := wrappedIdentifier.Wrap(request.Identifier)
+	if err != nil {
+		log.Error(err.Error())
+		return nil, err
+	}
+
+	sigbugEventRetrieveResponse := sigbugEventRecordHandlerJsonRpcAdaptor.RetrieveResponse{}
+	if err := r.jsonRpcClient.JsonRpcRequest(
+		sigbugEventRecordHandler.RetrieveService,
+		sigbugEventRecordHandlerJsonRpcAdaptor.RetrieveRequest{
+			WrappedIdentifier: *id,
+		},
+		&sigbugEventRetrieveResponse,
+	); err != nil {
+		log.Error(err.Error())
+		return nil, err
+	}
+
+	return &sigbugEventRecordHandler.RetrieveResponse{
+		Event: sigbugEventRetrieveResponse.Event,
+	}, nil
+}
+func (r *recordHandler) Update(request *sigbugEventRecordHandler.UpdateRequest) (*sigbugEventRecordHandler.UpdateResponse, error) {
+	return nil, brainException.NotImplemented{}
+}
+func (r *recordHandler) Delete(request *sigbugEventRecordHandler.DeleteRequest) (*sigbugEventRecordHandler.DeleteResponse, error) {
+	return nil, brainException.NotImplemented{}
+}
+
+func (r *recordHandler) ValidateCollectRequest(request *sigbugEventRecordHandler.CollectRequest) error {
+	reasonsInvalid := make([]string, 0)
+
+	if request.Criteria == nil {
+		reasonsInvalid = append(reasonsInvalid, "criteria is nil")
+	} else {
+		for _, crit := range request.Criteria {
+			if crit == nil {
+				reasonsInvalid = append(reasonsInvalid, "a criterion is nil")
+			}
+		}
+	}
+
+	if len(reasonsInvalid) > 0 {
+		return brainException.RequestInvalid{Reasons: reasonsInvalid}
+	}
+	return nil
+}
+
+func (r *recordHandler) Collect(request *sigbugEventRecordHandler.CollectRequest) (*sigbugEventRecordHandler.CollectResponse, error) {
+	if err := r.ValidateCollectRequest(request); err != nil {
+		return nil, err
+	}
+
+	// wrap criteria
+	criteria := make([]wrappedCriterion.Wrapped, 0)
+	for _, crit := range request.Criteria {
+		wrapped, err := wrappedCriterion.Wrap(crit)
+		if err != nil {
+			log.Error(err.Error())
+			return nil, err
+		}
+		criteria = append(criteria, *wrapped)
+	}
+
+	collectResponse := sigbugEventRecordHandlerJsonRpcAdaptor.CollectResponse{}
+	if err := r.jsonRpcClient.JsonRpcRequest(
+		sigbugEventRecordHandler.CollectService,
+		sigbugEventRecordHandlerJsonRpcAdaptor.CollectRequest{
+			Criteria: criteria,
+			Query:    request.Query,
+		},
+		&collectResponse); err != nil {
+		return nil, err
+	}
+
+	return &sigbugEventRecordHandler.CollectResponse{
+		Records: collectResponse.Records,
+		Total:   collectResponse.Total,
+	}, nil
+}
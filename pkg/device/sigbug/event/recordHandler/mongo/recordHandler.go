@@ -0,0 +1,37 @@
+package mongo
+
+import (
+	"github.com/iot-my-world/brain/pkg/device/sigbug/event"
+	sigbugEventRecordHandler "github.com/iot-my-world/brain/pkg/device/sigbug/event/recordHandler"
+	sigbugEventGenericRecordHandler "github.com/iot-my-world/brain/pkg/device/sigbug/event/recordHandler/generic"
+	brainMongoRecordHandler "github.com/iot-my-world/brain/pkg/recordHandler/mongo"
+	"github.com/iot-my-world/brain/pkg/security/claims"
+	"gopkg.in/mgo.v2"
+)
+
+func New(
+	mongoSession *mgo.Session,
+	databaseName string,
+	collectionName string,
+) sigbugEventRecordHandler.RecordHandler {
+	mongoRecordHandler := brainMongoRecordHandler.New(
+		mongoSession,
+		databaseName,
+		collectionName,
+		[]mgo.Index{
+			{
+				Key:    []string{"id"},
+				Unique: true,
+			},
+			{
+				Key: []string{"deviceId.id"},
+			},
+		},
+		event.IsValidIdentifier,
+		claims.ContextualiseFilter,
+	)
+
+	return sigbugEventGenericRecordHandler.New(
+		mongoRecordHandler,
+	)
+}
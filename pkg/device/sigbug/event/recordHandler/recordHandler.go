@@ -0,0 +1,95 @@
+package recordHandler
+
+import (
+	"github.com/iot-my-world/brain/pkg/device/sigbug/event"
+	"github.com/iot-my-world/brain/pkg/search/criterion"
+	"github.com/iot-my-world/brain/pkg/search/identifier"
+	"github.com/iot-my-world/brain/pkg/search/query"
+	"github.com/iot-my-world/brain/pkg/security/claims"
+	"github.com/iot-my-world/brain/pkg/security/permission/api"
+)
+
+type RecordHandler interface {
+	Create(*CreateRequest) (*CreateResponse, error)
+	Retrieve(*RetrieveRequest) (*RetrieveResponse, error)
+	Update(*UpdateRequest) (*UpdateResponse, error)
+	Delete(*DeleteRequest) (*DeleteResponse, error)
+	Collect(*CollectRequest) (*CollectResponse, error)
+}
+
+const ServiceProvider = "SigbugEvent-RecordHandler"
+const CreateService = ServiceProvider + ".Create"
+const RetrieveService = ServiceProvider + ".Retrieve"
+const UpdateService = ServiceProvider + ".Update"
+const DeleteService = ServiceProvider + ".Delete"
+const CollectService = ServiceProvider + ".Collect"
+
+var SystemUserPermissions = []api.Permission{
+	RetrieveService,
+	CollectService,
+}
+
+var CompanyAdminUserPermissions = []api.Permission{
+	RetrieveService,
+	CollectService,
+}
+
+var CompanyUserPermissions = []api.Permission{
+	RetrieveService,
+	CollectService,
+}
+
+var ClientAdminUserPermissions = []api.Permission{
+	RetrieveService,
+	CollectService,
+}
+
+var ClientUserPermissions = []api.Permission{
+	RetrieveService,
+	CollectService,
+}
+
+type CreateRequest struct {
+	Claims claims.Claims
+	Event  event.Event
+}
+
+type CreateResponse struct {
+	Event event.Event
+}
+
+type RetrieveRequest struct {
+	Claims     claims.Claims
+	Identifier identifier.Identifier
+}
+
+type RetrieveResponse struct {
+	Event event.Event
+}
+
+type UpdateRequest struct {
+	Claims     claims.Claims
+	Identifier identifier.Identifier
+	Event      event.Event
+}
+
+type UpdateResponse struct{}
+
+type DeleteRequest struct {
+	Claims     claims.Claims
+	Identifier identifier.Identifier
+}
+
+type DeleteResponse struct {
+}
+
+type CollectRequest struct {
+	Claims   claims.Claims
+	Criteria []criterion.Criterion
+	Query    query.Query
+}
+
+type CollectResponse struct {
+	Records []event.Event
+	Total   int
+}
@@ -0,0 +1,62 @@
+package jsonRpc
+
+import (
+	"github.com/iot-my-world/brain/internal/log"
+	jsonRpcServiceProvider "github.com/iot-my-world/brain/pkg/api/jsonRpc/service/provider"
+	sigbugImporter "github.com/iot-my-world/brain/pkg/device/sigbug/importer"
+	wrappedClaims "github.com/iot-my-world/brain/pkg/security/claims/wrapped"
+	"net/http"
+)
+
+type adaptor struct {
+	sigbugImporter sigbugImporter.Importer
+}
+
+func New(sigbugImporter sigbugImporter.Importer) *adaptor {
+	return &adaptor{
+		sigbugImporter: sigbugImporter,
+	}
+}
+
+func (a *adaptor) Name() jsonRpcServiceProvider.Name {
+	return jsonRpcServiceProvider.Name(sigbugImporter.ServiceProvider)
+}
+
+func (a *adaptor) MethodRequiresAuthorization(string) bool {
+	return true
+}
+
+type ImportRequest struct {
+	// File is the base64 encoded content of the file
+	File       []byte                    `json:"file"`
+	FileFormat sigbugImporter.FileFormat `json:"fileFormat"`
+	DryRun     bool                      `json:"dryRun"`
+}
+
+type ImportResponse struct {
+	Rows      []sigbugImporter.Row `json:"rows"`
+	NoCreated int                  `json:"noCreated"`
+}
+
+func (a *adaptor) Import(r *http.Request, request *ImportRequest, response *ImportResponse) error {
+	claims, err := wrappedClaims.UnwrapClaimsFromContext(r)
+	if err != nil {
+		log.Warn(err.Error())
+		return err
+	}
+
+	importResponse, err := a.sigbugImporter.Import(&sigbugImporter.ImportRequest{
+		Claims:     claims,
+		File:       request.File,
+		FileFormat: request.FileFormat,
+		DryRun:     request.DryRun,
+	})
+	if err != nil {
+		return err
+	}
+
+	response.Rows = importResponse.Rows
+	response.NoCreated = importResponse.NoCreated
+
+	return nil
+}
@@ -0,0 +1,260 @@
+package basic
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"github.com/360EntSecGroup-Skylar/excelize"
+	brainException "github.com/iot-my-world/brain/internal/exception"
+	"github.com/iot-my-world/brain/internal/log"
+	"github.com/iot-my-world/brain/pkg/device/sigbug"
+	sigbugAction "github.com/iot-my-world/brain/pkg/device/sigbug/action"
+	sigbugAdministrator "github.com/iot-my-world/brain/pkg/device/sigbug/administrator"
+	sigbugImporter "github.com/iot-my-world/brain/pkg/device/sigbug/importer"
+	sigbugImporterException "github.com/iot-my-world/brain/pkg/device/sigbug/importer/exception"
+	sigbugValidator "github.com/iot-my-world/brain/pkg/device/sigbug/validator"
+	"github.com/iot-my-world/brain/pkg/party"
+	"github.com/iot-my-world/brain/pkg/search/identifier/id"
+	sigfoxBackendDataCallbackMessage "github.com/iot-my-world/brain/pkg/sigfox/backend/callback/data/message"
+	"github.com/iot-my-world/brain/pkg/validate/reasonInvalid"
+	"github.com/iot-my-world/brain/pkg/workbook"
+	"io"
+	"strings"
+)
+
+type importer struct {
+	sigbugValidator     sigbugValidator.Validator
+	sigbugAdministrator sigbugAdministrator.Administrator
+}
+
+func New(
+	sigbugValidator sigbugValidator.Validator,
+	sigbugAdministrator sigbugAdministrator.Administrator,
+) sigbugImporter.Importer {
+	return &importer{
+		sigbugValidator:     sigbugValidator,
+		sigbugAdministrator: sigbugAdministrator,
+	}
+}
+
+// fileRow is a row read from an import file
+type fileRow struct {
+	number int
+	values map[string]string
+}
+
+func (i *importer) ValidateImportRequest(request *sigbugImporter.ImportRequest) error {
+	reasonsInvalid := make([]string, 0)
+
+	if request.Claims == nil {
+		reasonsInvalid = append(reasonsInvalid, "claims are nil")
+	}
+
+	if len(request.File) == 0 {
+		reasonsInvalid = append(reasonsInvalid, "file is empty")
+	}
+
+	switch request.FileFormat {
+	case sigbugImporter.XLSX, sigbugImporter.CSV:
+	default:
+		reasonsInvalid = append(reasonsInvalid, fmt.Sprintf("invalid file format: '%s'", request.FileFormat))
+	}
+
+	if len(reasonsInvalid) > 0 {
+		return brainException.RequestInvalid{Reasons: reasonsInvalid}
+	}
+	return nil
+}
+
+func (i *importer) Import(request *sigbugImporter.ImportRequest) (*sigbugImporter.ImportResponse, error) {
+	if err := i.ValidateImportRequest(request); err != nil {
+		log.Error(err.Error())
+		return nil, err
+	}
+
+	fileRows, err := readFile(request.File, request.FileFormat)
+	if err != nil {
+		log.Error(err.Error())
+		return nil, err
+	}
+
+	// build and validate the device in every row
+	rows := make([]sigbugImporter.Row, 0)
+	deviceIdRows := make(map[string]int)
+	for _, rowToImport := range fileRows {
+		row := sigbugImporter.Row{
+			Number: rowToImport.number,
+			Sigbug: sigbug.Sigbug{
+				DeviceId:          rowToImport.values[sigbugImporter.DeviceIdHeader],
+				OwnerPartyType:    party.Type(rowToImport.values[sigbugImporter.OwnerPartyTypeHeader]),
+				OwnerId:           id.Identifier{Id: rowToImport.values[sigbugImporter.OwnerIdHeader]},
+				AssignedPartyType: party.Type(rowToImport.values[sigbugImporter.AssignedPartyTypeHeader]),
+				AssignedId:        id.Identifier{Id: rowToImport.values[sigbugImporter.AssignedIdHeader]},
+				LastMessage: sigfoxBackendDataCallbackMessage.Message{
+					Data: make([]byte, 0),
+				},
+			},
+		}
+
+		// devices without an owner are owned by the party of the importer
+		if row.Sigbug.OwnerPartyType == "" && row.Sigbug.OwnerId.Id == "" {
+			row.Sigbug.OwnerPartyType = request.Claims.PartyDetails().PartyType
+			row.Sigbug.OwnerId = request.Claims.PartyDetails().PartyId
+		}
+
+		sigbugValidateResponse, err := i.sigbugValidator.Validate(&sigbugValidator.ValidateRequest{
+			Claims: request.Claims,
+			Sigbug: row.Sigbug,
+			Action: sigbugAction.Create,
+		})
+		if err != nil {
+			err = sigbugImporterException.RowValidation{Reasons: []string{fmt.Sprintf("row %d", row.Number), err.Error()}}
+			log.Error(err.Error())
+			return nil, err
+		}
+		row.ReasonsInvalid = sigbugValidateResponse.ReasonsInvalid
+
+		// the validator only finds devices which already exist,
+		// so device ids repeated in the file are checked here
+		if row.Sigbug.DeviceId != "" {
+			if firstRowNumber, found := deviceIdRows[row.Sigbug.DeviceId]; found {
+				row.ReasonsInvalid = append(row.ReasonsInvalid, reasonInvalid.ReasonInvalid{
+					Field: "deviceId",
+					Type:  reasonInvalid.Duplicate,
+					Help:  fmt.Sprintf("already in row %d", firstRowNumber),
+					Data:  row.Sigbug.DeviceId,
+				})
+			} else {
+				deviceIdRows[row.Sigbug.DeviceId] = row.Number
+			}
+		}
+
+		rows = append(rows, row)
+	}
+
+	if request.DryRun {
+		return &sigbugImporter.ImportResponse{
+			Rows: rows,
+		}, nil
+	}
+
+	// create the device in every valid row, carrying on past failures so
+	// that the outcome of every row is known and can be given back
+	noCreated := 0
+	for rowIdx := range rows {
+		if len(rows[rowIdx].ReasonsInvalid) > 0 {
+			continue
+		}
+		createResponse, err := i.sigbugAdministrator.Create(&sigbugAdministrator.CreateRequest{
+			Claims: request.Claims,
+			Sigbug: rows[rowIdx].Sigbug,
+		})
+		if err != nil {
+			log.Error(fmt.Sprintf("error creating device in row %d: %s", rows[rowIdx].Number, err.Error()))
+			rows[rowIdx].ReasonsInvalid = append(rows[rowIdx].ReasonsInvalid, reasonInvalid.ReasonInvalid{
+				Field: "deviceId",
+				Type:  reasonInvalid.Unknown,
+				Help:  "error creating device: " + err.Error(),
+				Data:  rows[rowIdx].Sigbug.DeviceId,
+			})
+			continue
+		}
+		rows[rowIdx].Sigbug = createResponse.Sigbug
+		rows[rowIdx].Created = true
+		noCreated++
+	}
+
+	return &sigbugImporter.ImportResponse{
+		Rows:      rows,
+		NoCreated: noCreated,
+	}, nil
+}
+
+// readFile reads the non blank rows of the given import file
+func readFile(file []byte, fileFormat sigbugImporter.FileFormat) ([]fileRow, error) {
+	var headerColumns map[string]int
+	var dataRows [][]string
+	rowNumbers := make([]int, 0)
+
+	switch fileFormat {
+	case sigbugImporter.XLSX:
+		fileWorkbook, err := workbook.NewFromReader(bytes.NewReader(file), nil)
+		if err != nil {
+			return nil, sigbugImporterException.FileRead{Reasons: []string{err.Error()}}
+		}
+		sheetName := fileWorkbook.FirstSheetName()
+		headerColumns = make(map[string]int)
+		for header, columnName := range fileWorkbook.SheetHeaderMaps[sheetName] {
+			columnNumber, err := excelize.ColumnNameToNumber(columnName)
+			if err != nil {
+				return nil, sigbugImporterException.FileRead{Reasons: []string{err.Error()}}
+			}
+			headerColumns[strings.TrimSpace(header)] = columnNumber - 1
+		}
+		dataRows, err = fileWorkbook.DataRows(sheetName)
+		if err != nil {
+			return nil, sigbugImporterException.FileRead{Reasons: []string{err.Error()}}
+		}
+		// the header is on the first row of the sheet
+		for rowIdx := range dataRows {
+			rowNumbers = append(rowNumbers, rowIdx+2)
+		}
+
+	case sigbugImporter.CSV:
+		csvReader := csv.NewReader(bytes.NewReader(file))
+		csvReader.FieldsPerRecord = -1
+		// blank lines are skipped by the reader and so the line of
+		// each record is taken from the reader to number the rows
+		for {
+			record, err := csvReader.Read()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				return nil, sigbugImporterException.FileRead{Reasons: []string{err.Error()}}
+			}
+			line, _ := csvReader.FieldPos(0)
+			if headerColumns == nil {
+				headerColumns = make(map[string]int)
+				for columnIdx, header := range record {
+					headerColumns[strings.TrimSpace(header)] = columnIdx
+				}
+				continue
+			}
+			dataRows = append(dataRows, record)
+			rowNumbers = append(rowNumbers, line)
+		}
+		if headerColumns == nil {
+			return nil, sigbugImporterException.FileRead{Reasons: []string{"no header row"}}
+		}
+
+	default:
+		return nil, sigbugImporterException.FileRead{Reasons: []string{fmt.Sprintf("invalid file format: '%s'", fileFormat)}}
+	}
+
+	if _, found := headerColumns[sigbugImporter.DeviceIdHeader]; !found {
+		return nil, sigbugImporterException.FileRead{Reasons: []string{"no " + sigbugImporter.DeviceIdHeader + " column"}}
+	}
+
+	fileRows := make([]fileRow, 0)
+	for rowIdx, dataRow := range dataRows {
+		rowToRead := fileRow{
+			number: rowNumbers[rowIdx],
+			values: make(map[string]string),
+		}
+		blank := true
+		for header, columnIdx := range headerColumns {
+			if columnIdx < len(dataRow) {
+				rowToRead.values[header] = strings.TrimSpace(dataRow[columnIdx])
+				if rowToRead.values[header] != "" {
+					blank = false
+				}
+			}
+		}
+		if !blank {
+			fileRows = append(fileRows, rowToRead)
+		}
+	}
+
+	return fileRows, nil
+}
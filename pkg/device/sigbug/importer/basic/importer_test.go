@@ -0,0 +1,203 @@
+package basic
+
+import (
+	"errors"
+	"github.com/360EntSecGroup-Skylar/excelize"
+	"github.com/iot-my-world/brain/pkg/device/sigbug"
+	sigbugAdministrator "github.com/iot-my-world/brain/pkg/device/sigbug/administrator"
+	sigbugImporter "github.com/iot-my-world/brain/pkg/device/sigbug/importer"
+	sigbugImporterException "github.com/iot-my-world/brain/pkg/device/sigbug/importer/exception"
+	sigbugValidator "github.com/iot-my-world/brain/pkg/device/sigbug/validator"
+	"github.com/iot-my-world/brain/pkg/party"
+	"github.com/iot-my-world/brain/pkg/search/identifier/id"
+	humanUserLoginClaims "github.com/iot-my-world/brain/pkg/security/claims/login/user/human"
+	"github.com/iot-my-world/brain/pkg/validate/reasonInvalid"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+// fakeValidator finds the device with the id invalid to be invalid
+type fakeValidator struct {
+	sigbugValidator.Validator
+}
+
+func (v *fakeValidator) Validate(request *sigbugValidator.ValidateRequest) (*sigbugValidator.ValidateResponse, error) {
+	reasonsInvalid := make([]reasonInvalid.ReasonInvalid, 0)
+	if request.Sigbug.DeviceId == "invalid" {
+		reasonsInvalid = append(reasonsInvalid, reasonInvalid.ReasonInvalid{
+			Field: "deviceId",
+			Type:  reasonInvalid.Invalid,
+			Data:  request.Sigbug.DeviceId,
+		})
+	}
+	return &sigbugValidator.ValidateResponse{ReasonsInvalid: reasonsInvalid}, nil
+}
+
+// fakeAdministrator fails to create the device with the id broken
+type fakeAdministrator struct {
+	sigbugAdministrator.Administrator
+	created []sigbug.Sigbug
+}
+
+func (a *fakeAdministrator) Create(request *sigbugAdministrator.CreateRequest) (*sigbugAdministrator.CreateResponse, error) {
+	if request.Sigbug.DeviceId == "broken" {
+		return nil, errors.New("creation failed")
+	}
+	request.Sigbug.Id = "id-" + request.Sigbug.DeviceId
+	a.created = append(a.created, request.Sigbug)
+	return &sigbugAdministrator.CreateResponse{Sigbug: request.Sigbug}, nil
+}
+
+var testClaims = humanUserLoginClaims.Login{
+	UserId:    id.Identifier{Id: "user"},
+	PartyType: party.Company,
+	PartyId:   id.Identifier{Id: "company"},
+}
+
+// xlsxFile returns an xlsx file with the given rows on its first sheet
+func xlsxFile(t *testing.T, rows [][]string) []byte {
+	file := excelize.NewFile()
+	for rowIdx, row := range rows {
+		for columnIdx, value := range row {
+			file.SetCellValue("Sheet1", string(rune('A'+columnIdx))+string(rune('1'+rowIdx)), value)
+		}
+	}
+	buffer, err := file.WriteToBuffer()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return buffer.Bytes()
+}
+
+func TestReadFileHeaderMapping(t *testing.T) {
+	tests := []struct {
+		name       string
+		file       []byte
+		fileFormat sigbugImporter.FileFormat
+	}{
+		{
+			name:       "csv",
+			file:       []byte(" ownerId , deviceId,unknown\ncompany,1,x\n\n,,\n,2\n"),
+			fileFormat: sigbugImporter.CSV,
+		},
+		{
+			name: "xlsx",
+			file: xlsxFile(t, [][]string{
+				{" ownerId ", "deviceId", "unknown"},
+				{"company", "1", "x"},
+				{},
+				{"", ""},
+				{"", "2"},
+			}),
+			fileFormat: sigbugImporter.XLSX,
+		},
+	}
+
+	for _, test := range tests {
+		fileRows, err := readFile(test.file, test.fileFormat)
+		if !assert.NoError(t, err, test.name) {
+			continue
+		}
+
+		// blank rows are skipped and rows are numbered by line
+		if !assert.Len(t, fileRows, 2, test.name) {
+			continue
+		}
+		assert.Equal(t, 2, fileRows[0].number, test.name)
+		assert.Equal(t, "1", fileRows[0].values[sigbugImporter.DeviceIdHeader], test.name)
+		assert.Equal(t, "company", fileRows[0].values[sigbugImporter.OwnerIdHeader], test.name)
+		assert.Equal(t, 5, fileRows[1].number, test.name)
+		assert.Equal(t, "2", fileRows[1].values[sigbugImporter.DeviceIdHeader], test.name)
+	}
+}
+
+func TestReadFileWithoutDeviceIdColumn(t *testing.T) {
+	_, err := readFile([]byte("ownerId\ncompany\n"), sigbugImporter.CSV)
+	assert.IsType(t, sigbugImporterException.FileRead{}, err)
+}
+
+func TestImport(t *testing.T) {
+	tests := []struct {
+		name              string
+		file              string
+		dryRun            bool
+		expectedCreated   []bool
+		expectedNoCreated int
+		expectedInvalid   []string
+	}{
+		{
+			name:              "all rows valid",
+			file:              "deviceId\n1\n2\n",
+			expectedCreated:   []bool{true, true},
+			expectedNoCreated: 2,
+			expectedInvalid:   []string{"", ""},
+		},
+		{
+			name:            "dry run",
+			file:            "deviceId\n1\n2\n",
+			dryRun:          true,
+			expectedCreated: []bool{false, false},
+			expectedInvalid: []string{"", ""},
+		},
+		{
+			name:              "invalid row is not created",
+			file:              "deviceId\n1\ninvalid\n3\n",
+			expectedCreated:   []bool{true, false, true},
+			expectedNoCreated: 2,
+			expectedInvalid:   []string{"", "deviceId", ""},
+		},
+		{
+			name:              "repeated device id is not created",
+			file:              "deviceId\n1\n1\n",
+			expectedCreated:   []bool{true, false},
+			expectedNoCreated: 1,
+			expectedInvalid:   []string{"", "deviceId"},
+		},
+		{
+			name:              "creation carries on past failure",
+			file:              "deviceId\n1\nbroken\n3\n",
+			expectedCreated:   []bool{true, false, true},
+			expectedNoCreated: 2,
+			expectedInvalid:   []string{"", "deviceId", ""},
+		},
+		{
+			name:            "dry run with invalid row",
+			file:            "deviceId\n1\ninvalid\n",
+			dryRun:          true,
+			expectedCreated: []bool{false, false},
+			expectedInvalid: []string{"", "deviceId"},
+		},
+	}
+
+	for _, test := range tests {
+		administrator := &fakeAdministrator{}
+		importResponse, err := New(&fakeValidator{}, administrator).Import(&sigbugImporter.ImportRequest{
+			Claims:     testClaims,
+			File:       []byte(test.file),
+			FileFormat: sigbugImporter.CSV,
+			DryRun:     test.dryRun,
+		})
+		if !assert.NoError(t, err, test.name) {
+			continue
+		}
+		if !assert.Len(t, importResponse.Rows, len(test.expectedCreated), test.name) {
+			continue
+		}
+
+		assert.Equal(t, test.expectedNoCreated, importResponse.NoCreated, test.name)
+		assert.Len(t, administrator.created, test.expectedNoCreated, test.name)
+		for rowIdx, row := range importResponse.Rows {
+			assert.Equal(t, rowIdx+2, row.Number, test.name)
+			assert.Equal(t, test.expectedCreated[rowIdx], row.Created, test.name)
+			if test.expectedInvalid[rowIdx] == "" {
+				assert.Empty(t, row.ReasonsInvalid, test.name)
+			} else if assert.Len(t, row.ReasonsInvalid, 1, test.name) {
+				assert.Equal(t, test.expectedInvalid[rowIdx], row.ReasonsInvalid[0].Field, test.name)
+			}
+
+			// devices without an owner are owned by the party of the importer
+			assert.Equal(t, party.Company, row.Sigbug.OwnerPartyType, test.name)
+			assert.Equal(t, id.Identifier{Id: "company"}, row.Sigbug.OwnerId, test.name)
+		}
+	}
+}
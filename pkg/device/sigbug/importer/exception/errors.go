@@ -0,0 +1,21 @@
+package exception
+
+import (
+	"strings"
+)
+
+type FileRead struct {
+	Reasons []string
+}
+
+func (e FileRead) Error() string {
+	return "error reading import file: " + strings.Join(e.Reasons, "; ")
+}
+
+type RowValidation struct {
+	Reasons []string
+}
+
+func (e RowValidation) Error() string {
+	return "error validating import row: " + strings.Join(e.Reasons, "; ")
+}
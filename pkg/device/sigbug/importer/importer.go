@@ -0,0 +1,70 @@
+package importer
+
+import (
+	"github.com/iot-my-world/brain/pkg/device/sigbug"
+	"github.com/iot-my-world/brain/pkg/security/claims"
+	"github.com/iot-my-world/brain/pkg/security/permission/api"
+	"github.com/iot-my-world/brain/pkg/validate/reasonInvalid"
+)
+
+type Importer interface {
+	Import(request *ImportRequest) (*ImportResponse, error)
+}
+
+const ServiceProvider = "SigbugDevice-Importer"
+const ImportService = ServiceProvider + ".Import"
+
+var SystemUserPermissions = []api.Permission{
+	ImportService,
+}
+
+var CompanyAdminUserPermissions = []api.Permission{
+	ImportService,
+}
+
+var CompanyUserPermissions = make([]api.Permission, 0)
+
+var ClientAdminUserPermissions = []api.Permission{
+	ImportService,
+}
+
+var ClientUserPermissions = make([]api.Permission, 0)
+
+type FileFormat string
+
+const XLSX FileFormat = "xlsx"
+const CSV FileFormat = "csv"
+
+// Headers of the columns in an import file. Only the
+// device id column is required, if the owner columns are
+// omitted devices are owned by the party of the importer.
+const DeviceIdHeader = "deviceId"
+const OwnerPartyTypeHeader = "ownerPartyType"
+const OwnerIdHeader = "ownerId"
+const AssignedPartyTypeHeader = "assignedPartyType"
+const AssignedIdHeader = "assignedId"
+
+type ImportRequest struct {
+	Claims     claims.Claims
+	File       []byte
+	FileFormat FileFormat
+	// DryRun validates the rows of the file without creating any devices
+	DryRun bool
+}
+
+// ImportResponse gives the outcome of each row of the file. The device in
+// every valid row is created, whether or not other rows are invalid or fail
+// to be created. Rows which were created are marked as such, and the reasons
+// for which the others were not are given on each of them.
+type ImportResponse struct {
+	Rows      []Row
+	NoCreated int
+}
+
+type Row struct {
+	// Number is the line number of the row in the file, starting at 1
+	Number         int
+	Sigbug         sigbug.Sigbug
+	ReasonsInvalid []reasonInvalid.ReasonInvalid
+	Created        bool
+}
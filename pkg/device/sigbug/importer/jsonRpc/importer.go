@@ -0,0 +1,59 @@
+package jsonRpc
+
+import (
+	brainException "github.com/iot-my-world/brain/internal/exception"
+	"github.com/iot-my-world/brain/internal/log"
+	jsonRpcClient "github.com/iot-my-world/brain/pkg/api/jsonRpc/client"
+	sigbugImporter "github.com/iot-my-world/brain/pkg/device/sigbug/importer"
+	sigbugImporterJsonRpcAdaptor "github.com/iot-my-world/brain/pkg/device/sigbug/importer/adaptor/jsonRpc"
+)
+
+type importer struct {
+	jsonRpcClient jsonRpcClient.Client
+}
+
+func New(
+	jsonRpcClient jsonRpcClient.Client,
+) sigbugImporter.Importer {
+	return &importer{
+		jsonRpcClient: jsonRpcClient,
+	}
+}
+
+func (i *importer) ValidateImportRequest(request *sigbugImporter.ImportRequest) error {
+	reasonsInvalid := make([]string, 0)
+
+	if len(request.File) == 0 {
+		reasonsInvalid = append(reasonsInvalid, "file is empty")
+	}
+
+	if len(reasonsInvalid) > 0 {
+		return brainException.RequestInvalid{Reasons: reasonsInvalid}
+	}
+	return nil
+}
+
+func (i *importer) Import(request *sigbugImporter.ImportRequest) (*sigbugImporter.ImportResponse, error) {
+	if err := i.ValidateImportRequest(request); err != nil {
+		return nil, err
+	}
+
+	importResponse := sigbugImporterJsonRpcAdaptor.ImportResponse{}
+	if err := i.jsonRpcClient.JsonRpcRequest(
+		sigbugImporter.ImportService,
+		sigbugImporterJsonRpcAdaptor.ImportRequest{
+			File:       request.File,
+			FileFormat: request.FileFormat,
+			DryRun:     request.DryRun,
+		},
+		&importResponse,
+	); err != nil {
+		log.Error(err.Error())
+		return nil, err
+	}
+
+	return &sigbugImporter.ImportResponse{
+		Rows:      importResponse.Rows,
+		NoCreated: importResponse.NoCreated,
+	}, nil
+}
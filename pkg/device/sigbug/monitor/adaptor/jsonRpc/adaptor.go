@@ -0,0 +1,56 @@
+package jsonRpc
+
+import (
+	"github.com/iot-my-world/brain/internal/log"
+	jsonRpcServiceProvider "github.com/iot-my-world/brain/pkg/api/jsonRpc/service/provider"
+	"github.com/iot-my-world/brain/pkg/device/sigbug"
+	sigbugMonitor "github.com/iot-my-world/brain/pkg/device/sigbug/monitor"
+	wrappedClaims "github.com/iot-my-world/brain/pkg/security/claims/wrapped"
+	"net/http"
+)
+
+type adaptor struct {
+	monitor sigbugMonitor.Monitor
+}
+
+func New(monitor sigbugMonitor.Monitor) *adaptor {
+	return &adaptor{
+		monitor: monitor,
+	}
+}
+
+func (a *adaptor) Name() jsonRpcServiceProvider.Name {
+	return jsonRpcServiceProvider.Name(sigbugMonitor.ServiceProvider)
+}
+
+func (a *adaptor) MethodRequiresAuthorization(string) bool {
+	return true
+}
+
+type CollectSilentDevicesRequest struct {
+}
+
+type CollectSilentDevicesResponse struct {
+	Records []sigbug.Sigbug `json:"records"`
+	Total   int             `json:"total"`
+}
+
+func (a *adaptor) CollectSilentDevices(r *http.Request, request *CollectSilentDevicesRequest, response *CollectSilentDevicesResponse) error {
+	claims, err := wrappedClaims.UnwrapClaimsFromContext(r)
+	if err != nil {
+		log.Warn(err.Error())
+		return err
+	}
+
+	collectSilentDevicesResponse, err := a.monitor.CollectSilentDevices(&sigbugMonitor.CollectSilentDevicesRequest{
+		Claims: claims,
+	})
+	if err != nil {
+		return err
+	}
+
+	response.Records = collectSilentDevicesResponse.Records
+	response.Total = collectSilentDevicesResponse.Total
+
+	return nil
+}
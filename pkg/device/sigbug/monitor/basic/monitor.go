@@ -0,0 +1,367 @@
+package basic
+
+import (
+	brainException "github.com/iot-my-world/brain/internal/exception"
+	"github.com/iot-my-world/brain/internal/log"
+	alertEngine "github.com/iot-my-world/brain/pkg/alert/engine"
+	"github.com/iot-my-world/brain/pkg/alert/rule"
+	"github.com/iot-my-world/brain/pkg/device/sigbug"
+	sigbugEvent "github.com/iot-my-world/brain/pkg/device/sigbug/event"
+	sigbugEventRecordHandler "github.com/iot-my-world/brain/pkg/device/sigbug/event/recordHandler"
+	sigbugMonitor "github.com/iot-my-world/brain/pkg/device/sigbug/monitor"
+	sigbugMonitorException "github.com/iot-my-world/brain/pkg/device/sigbug/monitor/exception"
+	sigbugRecordHandler "github.com/iot-my-world/brain/pkg/device/sigbug/recordHandler"
+	"github.com/iot-my-world/brain/pkg/party"
+	partyAdministrator "github.com/iot-my-world/brain/pkg/party/administrator"
+	"github.com/iot-my-world/brain/pkg/party/client"
+	"github.com/iot-my-world/brain/pkg/party/company"
+	brainRecordHandler "github.com/iot-my-world/brain/pkg/recordHandler"
+	"github.com/iot-my-world/brain/pkg/search/criterion"
+	exactTextCriterion "github.com/iot-my-world/brain/pkg/search/criterion/exact/text"
+	"github.com/iot-my-world/brain/pkg/search/identifier/id"
+	"github.com/iot-my-world/brain/pkg/search/query"
+	"github.com/iot-my-world/brain/pkg/security/claims"
+	humanUserLoginClaims "github.com/iot-my-world/brain/pkg/security/claims/login/user/human"
+	webhookEvent "github.com/iot-my-world/brain/pkg/webhook/event"
+	webhookPublisher "github.com/iot-my-world/brain/pkg/webhook/publisher"
+	"time"
+)
+
+// pageSize is the number of devices collected at a time so
+// that all of the devices are not loaded into memory at once
+const pageSize = 500
+
+type monitor struct {
+	sigbugRecordHandler      sigbugRecordHandler.RecordHandler
+	sigbugEventRecordHandler sigbugEventRecordHandler.RecordHandler
+	partyAdministrator       partyAdministrator.Administrator
+	alertEngine              alertEngine.Engine
+	webhookPublisher         webhookPublisher.Publisher
+	defaultSilenceThreshold  time.Duration
+	systemClaims             *humanUserLoginClaims.Login
+}
+
+func New(
+	sigbugRecordHandler sigbugRecordHandler.RecordHandler,
+	sigbugEventRecordHandler sigbugEventRecordHandler.RecordHandler,
+	partyAdministrator partyAdministrator.Administrator,
+	alertEngine alertEngine.Engine,
+	webhookPublisher webhookPublisher.Publisher,
+	defaultSilenceThreshold time.Duration,
+	systemClaims *humanUserLoginClaims.Login,
+) sigbugMonitor.Monitor {
+	return &monitor{
+		sigbugRecordHandler:      sigbugRecordHandler,
+		sigbugEventRecordHandler: sigbugEventRecordHandler,
+		partyAdministrator:       partyAdministrator,
+		alertEngine:              alertEngine,
+		webhookPublisher:         webhookPublisher,
+		defaultSilenceThreshold:  defaultSilenceThreshold,
+		systemClaims:             systemClaims,
+	}
+}
+
+func (m *monitor) ValidateCheckDevicesRequest(request *sigbugMonitor.CheckDevicesRequest) error {
+	reasonsInvalid := make([]string, 0)
+
+	if request.Claims == nil {
+		reasonsInvalid = append(reasonsInvalid, "claims are nil")
+	}
+
+	if len(reasonsInvalid) > 0 {
+		return brainException.RequestInvalid{Reasons: reasonsInvalid}
+	}
+	return nil
+}
+
+// CheckDevices marks the devices in the context of the given claims which have
+// been silent for longer than their threshold as offline, and those which
+// have since sent a message as online, recording an event for every change
+func (m *monitor) CheckDevices(request *sigbugMonitor.CheckDevicesRequest) (*sigbugMonitor.CheckDevicesResponse, error) {
+	if err := m.ValidateCheckDevicesRequest(request); err != nil {
+		log.Error(err.Error())
+		return nil, err
+	}
+
+	response := sigbugMonitor.CheckDevicesResponse{
+		WentOffline: make([]sigbug.Sigbug, 0),
+		CameOnline:  make([]sigbug.Sigbug, 0),
+	}
+	now := time.Now().UTC().Unix()
+	partyThresholds := make(map[string]int64)
+	if err := m.forEachDevice(request.Claims, func(device sigbug.Sigbug) error {
+		changed, err := m.checkDevice(&device, now, partyThresholds)
+		if err != nil {
+			// failure to check one device does not fail the check of the others
+			log.Error(err.Error())
+			return nil
+		}
+		if !changed {
+			return nil
+		}
+		if device.Offline {
+			response.WentOffline = append(response.WentOffline, device)
+		} else {
+			response.CameOnline = append(response.CameOnline, device)
+		}
+		return nil
+	}); err != nil {
+		log.Error(err.Error())
+		return nil, err
+	}
+
+	return &response, nil
+}
+
+func (m *monitor) ValidateCheckDeviceRequest(request *sigbugMonitor.CheckDeviceRequest) error {
+	reasonsInvalid := make([]string, 0)
+
+	if request.Claims == nil {
+		reasonsInvalid = append(reasonsInvalid, "claims are nil")
+	}
+
+	if request.Sigbug.Id == "" {
+		reasonsInvalid = append(reasonsInvalid, "sigbug id is blank")
+	}
+
+	if len(reasonsInvalid) > 0 {
+		return brainException.RequestInvalid{Reasons: reasonsInvalid}
+	}
+	return nil
+}
+
+// CheckDevice marks the given device as offline if it has been silent for
+// longer than its threshold, or as online if it has since sent a message,
+// recording an event if its status has changed. It is used to bring a
+// device back online as soon as a message is received from it.
+func (m *monitor) CheckDevice(request *sigbugMonitor.CheckDeviceRequest) (*sigbugMonitor.CheckDeviceResponse, error) {
+	if err := m.ValidateCheckDeviceRequest(request); err != nil {
+		log.Error(err.Error())
+		return nil, err
+	}
+
+	device := request.Sigbug
+	if _, err := m.checkDevice(&device, time.Now().UTC().Unix(), make(map[string]int64)); err != nil {
+		log.Error(err.Error())
+		return nil, err
+	}
+
+	return &sigbugMonitor.CheckDeviceResponse{
+		Sigbug: device,
+	}, nil
+}
+
+func (m *monitor) ValidateCollectSilentDevicesRequest(request *sigbugMonitor.CollectSilentDevicesRequest) error {
+	reasonsInvalid := make([]string, 0)
+
+	if request.Claims == nil {
+		reasonsInvalid = append(reasonsInvalid, "claims are nil")
+	}
+
+	if len(reasonsInvalid) > 0 {
+		return brainException.RequestInvalid{Reasons: reasonsInvalid}
+	}
+	return nil
+}
+
+// CollectSilentDevices returns the devices in the context of the given claims
+// which have been silent for longer than their threshold
+func (m *monitor) CollectSilentDevices(request *sigbugMonitor.CollectSilentDevicesRequest) (*sigbugMonitor.CollectSilentDevicesResponse, error) {
+	if err := m.ValidateCollectSilentDevicesRequest(request); err != nil {
+		log.Error(err.Error())
+		return nil, err
+	}
+
+	// the record handler contextualises the collection with the claims of the caller
+	silentDevices := make([]sigbug.Sigbug, 0)
+	now := time.Now().UTC().Unix()
+	partyThresholds := make(map[string]int64)
+	if err := m.forEachDevice(request.Claims, func(device sigbug.Sigbug) error {
+		silent, err := m.silent(device, now, partyThresholds)
+		if err != nil {
+			return err
+		}
+		if silent {
+			silentDevices = append(silentDevices, device)
+		}
+		return nil
+	}); err != nil {
+		log.Error(err.Error())
+		return nil, err
+	}
+
+	return &sigbugMonitor.CollectSilentDevicesResponse{
+		Records: silentDevices,
+		Total:   len(silentDevices),
+	}, nil
+}
+
+// forEachDevice calls the given function with each of the devices in the
+// context of the given claims, collected a page at a time in order of id,
+// stopping at the first error returned
+func (m *monitor) forEachDevice(claims claims.Claims, f func(device sigbug.Sigbug) error) error {
+	for offset := 0; ; offset += pageSize {
+		collectResponse, err := m.sigbugRecordHandler.Collect(&sigbugRecordHandler.CollectRequest{
+			Claims:   claims,
+			Criteria: make([]criterion.Criterion, 0),
+			Query: query.Query{
+				Limit:  pageSize,
+				Offset: offset,
+				Order:  []query.SortOrder{query.SortOrderAscending},
+				SortBy: []string{"id"},
+			},
+		})
+		if err != nil {
+			return sigbugMonitorException.DeviceCollection{Reasons: []string{err.Error()}}
+		}
+
+		for _, device := range collectResponse.Records {
+			if err := f(device); err != nil {
+				return err
+			}
+		}
+
+		if len(collectResponse.Records) < pageSize {
+			return nil
+		}
+	}
+}
+
+// checkDevice sets the status of the given device, returning true if it
+// has changed. A change is stored and recorded as an event, and a device
+// which went offline fires the device offline alert rules and webhooks.
+func (m *monitor) checkDevice(device *sigbug.Sigbug, now int64, partyThresholds map[string]int64) (bool, error) {
+	silent, err := m.silent(*device, now, partyThresholds)
+	if err != nil {
+		return false, err
+	}
+	if silent == device.Offline {
+		return false, nil
+	}
+
+	// the status of the device has changed
+	device.Offline = silent
+	eventType := sigbugEvent.CameOnline
+	if device.Offline {
+		eventType = sigbugEvent.WentOffline
+	}
+	if err := m.updateStatus(*device, eventType, now); err != nil {
+		return false, err
+	}
+	if !device.Offline {
+		return true, nil
+	}
+
+	// failure to fire alert rules or publish to webhooks does not fail the check
+	if _, err := m.alertEngine.Fire(&alertEngine.FireRequest{
+		Claims:      m.systemClaims,
+		TriggerType: rule.DeviceOffline,
+		Device:      *device,
+		TimeStamp:   now,
+	}); err != nil {
+		log.Error("error firing device offline alert rules: " + err.Error())
+	}
+	if _, err := m.webhookPublisher.Publish(&webhookPublisher.PublishRequest{
+		Claims:    m.systemClaims,
+		EventType: webhookEvent.DeviceOffline,
+		Device:    *device,
+		TimeStamp: now,
+		Data:      *device,
+	}); err != nil {
+		log.Error("error publishing device offline to webhooks: " + err.Error())
+	}
+
+	return true, nil
+}
+
+// silent returns true if the given device has not sent a message within its
+// silence threshold. Devices which have never sent a message are not silent.
+// The thresholds of owner parties are cached in the given map.
+func (m *monitor) silent(device sigbug.Sigbug, now int64, partyThresholds map[string]int64) (bool, error) {
+	if device.LastMessage.Timestamp == 0 {
+		return false, nil
+	}
+
+	threshold := device.SilenceThreshold
+	if threshold == 0 {
+		partyThreshold, found := partyThresholds[device.OwnerId.Id]
+		if !found {
+			var err error
+			partyThreshold, err = m.partySilenceThreshold(device.OwnerPartyType, device.OwnerId)
+			if err != nil {
+				return false, err
+			}
+			partyThresholds[device.OwnerId.Id] = partyThreshold
+		}
+		threshold = partyThreshold
+	}
+
+	return now-device.LastMessage.Timestamp > threshold, nil
+}
+
+// partySilenceThreshold returns the silence threshold in seconds of
+// devices owned by the given party
+func (m *monitor) partySilenceThreshold(partyType party.Type, partyId id.Identifier) (int64, error) {
+	retrieveResponse, err := m.partyAdministrator.RetrieveParty(&partyAdministrator.RetrievePartyRequest{
+		Claims:     m.systemClaims,
+		PartyType:  partyType,
+		Identifier: partyId,
+	})
+	if err != nil {
+		return 0, sigbugMonitorException.SilenceThreshold{Reasons: []string{"retrieving owner party", err.Error()}}
+	}
+
+	var threshold int64
+	switch typedParty := retrieveResponse.Party.(type) {
+	case company.Company:
+		threshold = typedParty.DeviceSilenceThreshold
+	case client.Client:
+		threshold = typedParty.DeviceSilenceThreshold
+	}
+	if threshold == 0 {
+		threshold = int64(m.defaultSilenceThreshold.Seconds())
+	}
+
+	return threshold, nil
+}
+
+// updateStatus stores the status of the given device and records an
+// event of the given type for the change
+func (m *monitor) updateStatus(device sigbug.Sigbug, eventType sigbugEvent.Type, now int64) error {
+	// only the status is set so that the rest of the device, which
+	// may have changed since it was collected, is not overwritten
+	if _, err := m.sigbugRecordHandler.UpdateFields(&sigbugRecordHandler.UpdateFieldsRequest{
+		Claims: m.systemClaims,
+		Criteria: []criterion.Criterion{
+			exactTextCriterion.Criterion{
+				Field: "id",
+				Text:  device.Id,
+			},
+		},
+		Update: brainRecordHandler.FieldsUpdate{
+			Set: map[string]interface{}{
+				"offline": device.Offline,
+			},
+		},
+	}); err != nil {
+		return sigbugMonitorException.StatusUpdate{Reasons: []string{"updating device", err.Error()}}
+	}
+
+	if _, err := m.sigbugEventRecordHandler.Create(&sigbugEventRecordHandler.CreateRequest{
+		Claims: m.systemClaims,
+		Event: sigbugEvent.Event{
+			Type:              eventType,
+			DeviceId:          id.Identifier{Id: device.Id},
+			OwnerPartyType:    device.OwnerPartyType,
+			OwnerId:           device.OwnerId,
+			AssignedPartyType: device.AssignedPartyType,
+			AssignedId:        device.AssignedId,
+			MessageId:         id.Identifier{Id: device.LastMessage.Id},
+			TimeStamp:         now,
+		},
+	}); err != nil {
+		return sigbugMonitorException.StatusUpdate{Reasons: []string{"creating event", err.Error()}}
+	}
+
+	return nil
+}
@@ -0,0 +1,215 @@
+package basic
+
+import (
+	alertEngine "github.com/iot-my-world/brain/pkg/alert/engine"
+	"github.com/iot-my-world/brain/pkg/device/sigbug"
+	sigbugEvent "github.com/iot-my-world/brain/pkg/device/sigbug/event"
+	sigbugEventRecordHandler "github.com/iot-my-world/brain/pkg/device/sigbug/event/recordHandler"
+	sigbugMonitor "github.com/iot-my-world/brain/pkg/device/sigbug/monitor"
+	sigbugRecordHandler "github.com/iot-my-world/brain/pkg/device/sigbug/recordHandler"
+	"github.com/iot-my-world/brain/pkg/party"
+	partyAdministrator "github.com/iot-my-world/brain/pkg/party/administrator"
+	"github.com/iot-my-world/brain/pkg/party/company"
+	"github.com/iot-my-world/brain/pkg/search/identifier/id"
+	humanUserLoginClaims "github.com/iot-my-world/brain/pkg/security/claims/login/user/human"
+	sigfoxBackendDataCallbackMessage "github.com/iot-my-world/brain/pkg/sigfox/backend/callback/data/message"
+	webhookPublisher "github.com/iot-my-world/brain/pkg/webhook/publisher"
+	"github.com/stretchr/testify/assert"
+	"strconv"
+	"testing"
+	"time"
+)
+
+// fakeRecordHandler pages through the given devices, recording
+// the collect requests and status updates made
+type fakeRecordHandler struct {
+	sigbugRecordHandler.RecordHandler
+	devices  []sigbug.Sigbug
+	requests []sigbugRecordHandler.CollectRequest
+	updates  []sigbugRecordHandler.UpdateFieldsRequest
+}
+
+func (r *fakeRecordHandler) Collect(request *sigbugRecordHandler.CollectRequest) (*sigbugRecordHandler.CollectResponse, error) {
+	r.requests = append(r.requests, *request)
+	records := make([]sigbug.Sigbug, 0)
+	for deviceIdx := request.Query.Offset; deviceIdx < len(r.devices) && len(records) < request.Query.Limit; deviceIdx++ {
+		records = append(records, r.devices[deviceIdx])
+	}
+	return &sigbugRecordHandler.CollectResponse{Records: records, Total: len(r.devices)}, nil
+}
+
+func (r *fakeRecordHandler) UpdateFields(request *sigbugRecordHandler.UpdateFieldsRequest) (*sigbugRecordHandler.UpdateFieldsResponse, error) {
+	r.updates = append(r.updates, *request)
+	return &sigbugRecordHandler.UpdateFieldsResponse{}, nil
+}
+
+// fakeEventRecordHandler records the events created
+type fakeEventRecordHandler struct {
+	sigbugEventRecordHandler.RecordHandler
+	events []sigbugEvent.Event
+}
+
+func (r *fakeEventRecordHandler) Create(request *sigbugEventRecordHandler.CreateRequest) (*sigbugEventRecordHandler.CreateResponse, error) {
+	r.events = append(r.events, request.Event)
+	return &sigbugEventRecordHandler.CreateResponse{Event: request.Event}, nil
+}
+
+// fakePartyAdministrator retrieves a company with the given silence threshold
+type fakePartyAdministrator struct {
+	partyAdministrator.Administrator
+	deviceSilenceThreshold int64
+}
+
+func (a *fakePartyAdministrator) RetrieveParty(request *partyAdministrator.RetrievePartyRequest) (*partyAdministrator.RetrievePartyResponse, error) {
+	return &partyAdministrator.RetrievePartyResponse{
+		Party: company.Company{
+			Id:                     request.Identifier.(id.Identifier).Id,
+			DeviceSilenceThreshold: a.deviceSilenceThreshold,
+		},
+	}, nil
+}
+
+type fakeAlertEngine struct {
+	fired []alertEngine.FireRequest
+}
+
+func (e *fakeAlertEngine) Fire(request *alertEngine.FireRequest) (*alertEngine.FireResponse, error) {
+	e.fired = append(e.fired, *request)
+	return &alertEngine.FireResponse{}, nil
+}
+
+type fakeWebhookPublisher struct {
+	published []webhookPublisher.PublishRequest
+}
+
+func (p *fakeWebhookPublisher) Publish(request *webhookPublisher.PublishRequest) (*webhookPublisher.PublishResponse, error) {
+	p.published = append(p.published, *request)
+	return &webhookPublisher.PublishResponse{}, nil
+}
+
+// newTestDevice returns a device which last sent a message the given number of seconds ago
+func newTestDevice(deviceIdx int, silentFor int64, offline bool) sigbug.Sigbug {
+	return sigbug.Sigbug{
+		Id:             strconv.Itoa(deviceIdx),
+		OwnerPartyType: party.Company,
+		OwnerId:        id.Identifier{Id: "company"},
+		LastMessage: sigfoxBackendDataCallbackMessage.Message{
+			Id:        "message",
+			Timestamp: time.Now().UTC().Unix() - silentFor,
+		},
+		Offline: offline,
+	}
+}
+
+func TestCheckDevices(t *testing.T) {
+	// all but the last two of the devices, which are over the
+	// threshold of the owner party, have recently sent a message
+	devices := make([]sigbug.Sigbug, 0)
+	for deviceIdx := 0; deviceIdx < pageSize+1; deviceIdx++ {
+		devices = append(devices, newTestDevice(deviceIdx, 10, false))
+	}
+	devices[0].Offline = true
+	devices = append(devices, newTestDevice(len(devices), 7200, false))
+	devices = append(devices, newTestDevice(len(devices), 7200, true))
+
+	recordHandler := &fakeRecordHandler{devices: devices}
+	eventRecordHandler := &fakeEventRecordHandler{}
+	engine := &fakeAlertEngine{}
+	publisher := &fakeWebhookPublisher{}
+	checkDevicesResponse, err := New(
+		recordHandler,
+		eventRecordHandler,
+		&fakePartyAdministrator{deviceSilenceThreshold: 3600},
+		engine,
+		publisher,
+		time.Hour*24,
+		&humanUserLoginClaims.Login{},
+	).CheckDevices(&sigbugMonitor.CheckDevicesRequest{
+		Claims: humanUserLoginClaims.Login{},
+	})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	// the devices are collected a page at a time
+	if assert.Len(t, recordHandler.requests, 2) {
+		assert.Equal(t, 0, recordHandler.requests[0].Query.Offset)
+		assert.Equal(t, pageSize, recordHandler.requests[1].Query.Offset)
+		for _, request := range recordHandler.requests {
+			assert.Equal(t, pageSize, request.Query.Limit)
+			assert.Equal(t, []string{"id"}, request.Query.SortBy)
+		}
+	}
+
+	if assert.Len(t, checkDevicesResponse.CameOnline, 1) {
+		assert.Equal(t, devices[0].Id, checkDevicesResponse.CameOnline[0].Id)
+		assert.False(t, checkDevicesResponse.CameOnline[0].Offline)
+	}
+	if assert.Len(t, checkDevicesResponse.WentOffline, 1) {
+		assert.Equal(t, devices[pageSize+1].Id, checkDevicesResponse.WentOffline[0].Id)
+		assert.True(t, checkDevicesResponse.WentOffline[0].Offline)
+	}
+
+	// an event is recorded for each change, and only
+	// devices which went offline fire alerts and webhooks
+	assert.Len(t, recordHandler.updates, 2)
+	if assert.Len(t, eventRecordHandler.events, 2) {
+		assert.Equal(t, sigbugEvent.CameOnline, eventRecordHandler.events[0].Type)
+		assert.Equal(t, sigbugEvent.WentOffline, eventRecordHandler.events[1].Type)
+	}
+	assert.Len(t, engine.fired, 1)
+	assert.Len(t, publisher.published, 1)
+}
+
+func TestCheckDevice(t *testing.T) {
+	tests := []struct {
+		name          string
+		device        sigbug.Sigbug
+		expectOffline bool
+		expectedEvent sigbugEvent.Type
+	}{
+		{
+			name:          "came online",
+			device:        newTestDevice(0, 0, true),
+			expectOffline: false,
+			expectedEvent: sigbugEvent.CameOnline,
+		},
+		{
+			name:          "went offline",
+			device:        newTestDevice(0, 7200, false),
+			expectOffline: true,
+			expectedEvent: sigbugEvent.WentOffline,
+		},
+		{
+			name:          "still online",
+			device:        newTestDevice(0, 0, false),
+			expectOffline: false,
+		},
+	}
+
+	for _, test := range tests {
+		eventRecordHandler := &fakeEventRecordHandler{}
+		checkDeviceResponse, err := New(
+			&fakeRecordHandler{},
+			eventRecordHandler,
+			&fakePartyAdministrator{deviceSilenceThreshold: 3600},
+			&fakeAlertEngine{},
+			&fakeWebhookPublisher{},
+			time.Hour*24,
+			&humanUserLoginClaims.Login{},
+		).CheckDevice(&sigbugMonitor.CheckDeviceRequest{
+			Claims: humanUserLoginClaims.Login{},
+			Sigbug: test.device,
+		})
+		if !assert.NoError(t, err, test.name) {
+			continue
+		}
+
+		assert.Equal(t, test.expectOffline, checkDeviceResponse.Sigbug.Offline, test.name)
+		if test.expectedEvent == "" {
+			assert.Empty(t, eventRecordHandler.events, test.name)
+		} else if assert.Len(t, eventRecordHandler.events, 1, test.name) {
+			assert.Equal(t, test.expectedEvent, eventRecordHandler.events[0].Type, test.name)
+		}
+	}
+}
@@ -0,0 +1,27 @@
+package exception
+
+import "strings"
+
+type DeviceCollection struct {
+	Reasons []string
+}
+
+func (e DeviceCollection) Error() string {
+	return "error collecting sigbug devices: " + strings.Join(e.Reasons, "; ")
+}
+
+type SilenceThreshold struct {
+	Reasons []string
+}
+
+func (e SilenceThreshold) Error() string {
+	return "error determining silence threshold: " + strings.Join(e.Reasons, "; ")
+}
+
+type StatusUpdate struct {
+	Reasons []string
+}
+
+func (e StatusUpdate) Error() string {
+	return "error updating sigbug device status: " + strings.Join(e.Reasons, "; ")
+}
@@ -0,0 +1,46 @@
+package jsonRpc
+
+import (
+	brainException "github.com/iot-my-world/brain/internal/exception"
+	"github.com/iot-my-world/brain/internal/log"
+	jsonRpcClient "github.com/iot-my-world/brain/pkg/api/jsonRpc/client"
+	sigbugMonitor "github.com/iot-my-world/brain/pkg/device/sigbug/monitor"
+	sigbugMonitorJsonRpcAdaptor "github.com/iot-my-world/brain/pkg/device/sigbug/monitor/adaptor/jsonRpc"
+)
+
+type monitor struct {
+	jsonRpcClient jsonRpcClient.Client
+}
+
+func New(
+	jsonRpcClient jsonRpcClient.Client,
+) sigbugMonitor.Monitor {
+	return &monitor{
+		jsonRpcClient: jsonRpcClient,
+	}
+}
+
+func (m *monitor) CheckDevices(request *sigbugMonitor.CheckDevicesRequest) (*sigbugMonitor.CheckDevicesResponse, error) {
+	return nil, brainException.NotImplemented{}
+}
+
+func (m *monitor) CheckDevice(request *sigbugMonitor.CheckDeviceRequest) (*sigbugMonitor.CheckDeviceResponse, error) {
+	return nil, brainException.NotImplemented{}
+}
+
+func (m *monitor) CollectSilentDevices(request *sigbugMonitor.CollectSilentDevicesRequest) (*sigbugMonitor.CollectSilentDevicesResponse, error) {
+	collectSilentDevicesResponse := sigbugMonitorJsonRpcAdaptor.CollectSilentDevicesResponse{}
+	if err := m.jsonRpcClient.JsonRpcRequest(
+		sigbugMonitor.CollectSilentDevicesService,
+		sigbugMonitorJsonRpcAdaptor.CollectSilentDevicesRequest{},
+		&collectSilentDevicesResponse,
+	); err != nil {
+		log.Error(err.Error())
+		return nil, err
+	}
+
+	return &sigbugMonitor.CollectSilentDevicesResponse{
+		Records: collectSilentDevicesResponse.Records,
+		Total:   collectSilentDevicesResponse.Total,
+	}, nil
+}
@@ -0,0 +1,65 @@
+package monitor
+
+import (
+	"github.com/iot-my-world/brain/pkg/device/sigbug"
+	"github.com/iot-my-world/brain/pkg/security/claims"
+	"github.com/iot-my-world/brain/pkg/security/permission/api"
+)
+
+// Monitor keeps track of which sigbugs have stopped reporting
+type Monitor interface {
+	CheckDevices(request *CheckDevicesRequest) (*CheckDevicesResponse, error)
+	CheckDevice(request *CheckDeviceRequest) (*CheckDeviceResponse, error)
+	CollectSilentDevices(request *CollectSilentDevicesRequest) (*CollectSilentDevicesResponse, error)
+}
+
+const ServiceProvider = "SigbugDevice-Monitor"
+const CollectSilentDevicesService = ServiceProvider + ".CollectSilentDevices"
+
+var SystemUserPermissions = []api.Permission{
+	CollectSilentDevicesService,
+}
+
+var CompanyAdminUserPermissions = []api.Permission{
+	CollectSilentDevicesService,
+}
+
+var CompanyUserPermissions = []api.Permission{
+	CollectSilentDevicesService,
+}
+
+var ClientAdminUserPermissions = []api.Permission{
+	CollectSilentDevicesService,
+}
+
+var ClientUserPermissions = []api.Permission{
+	CollectSilentDevicesService,
+}
+
+type CheckDevicesRequest struct {
+	Claims claims.Claims
+}
+
+type CheckDevicesResponse struct {
+	WentOffline []sigbug.Sigbug
+	CameOnline  []sigbug.Sigbug
+}
+
+type CheckDeviceRequest struct {
+	Claims claims.Claims
+	Sigbug sigbug.Sigbug
+}
+
+type CheckDeviceResponse struct {
+	// Sigbug is the given device with its status as checked
+	Sigbug sigbug.Sigbug
+}
+
+type CollectSilentDevicesRequest struct {
+	Claims claims.Claims
+}
+
+type CollectSilentDevicesResponse struct {
+	Records []sigbug.Sigbug
+	Total   int
+}
@@ -0,0 +1,56 @@
+package jsonRpc
+
+import (
+	"github.com/iot-my-world/brain/internal/log"
+	jsonRpcServiceProvider "github.com/iot-my-world/brain/pkg/api/jsonRpc/service/provider"
+	sigbugBatteryReading "github.com/iot-my-world/brain/pkg/device/sigbug/reading/battery"
+	sigbugBatteryReadingAdministrator "github.com/iot-my-world/brain/pkg/device/sigbug/reading/battery/administrator"
+	wrappedClaims "github.com/iot-my-world/brain/pkg/security/claims/wrapped"
+	"net/http"
+)
+
+type adaptor struct {
+	sigbugBatteryReadingAdministrator sigbugBatteryReadingAdministrator.Administrator
+}
+
+func New(administrator sigbugBatteryReadingAdministrator.Administrator) *adaptor {
+	return &adaptor{
+		sigbugBatteryReadingAdministrator: administrator,
+	}
+}
+
+func (a *adaptor) Name() jsonRpcServiceProvider.Name {
+	return sigbugBatteryReadingAdministrator.ServiceProvider
+}
+
+func (a *adaptor) MethodRequiresAuthorization(string) bool {
+	return true
+}
+
+type CreateRequest struct {
+	Reading sigbugBatteryReading.Reading `json:"reading"`
+}
+
+type CreateResponse struct {
+	Reading sigbugBatteryReading.Reading `json:"reading"`
+}
+
+func (a *adaptor) Create(r *http.Request, request *CreateRequest, response *CreateResponse) error {
+	claims, err := wrappedClaims.UnwrapClaimsFromContext(r)
+	if err != nil {
+		log.Warn(err.Error())
+		return err
+	}
+
+	createResponse, err := a.sigbugBatteryReadingAdministrator.Create(&sigbugBatteryReadingAdministrator.CreateRequest{
+		Claims:  claims,
+		Reading: request.Reading,
+	})
+	if err != nil {
+		return err
+	}
+
+	response.Reading = createResponse.Reading
+
+	return nil
+}
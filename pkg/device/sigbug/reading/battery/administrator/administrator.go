@@ -0,0 +1,44 @@
+package administrator
+
+import (
+	sigbugBatteryReading "github.com/iot-my-world/brain/pkg/device/sigbug/reading/battery"
+	"github.com/iot-my-world/brain/pkg/security/claims"
+	"github.com/iot-my-world/brain/pkg/security/permission/api"
+)
+
+type Administrator interface {
+	Create(request *CreateRequest) (*CreateResponse, error)
+}
+
+const ServiceProvider = "SigbugBatteryReading-Administrator"
+const CreateService = ServiceProvider + ".Create"
+
+var SystemUserPermissions = []api.Permission{
+	CreateService,
+}
+
+var CompanyAdminUserPermissions = make([]api.Permission, 0)
+
+var CompanyUserPermissions = make([]api.Permission, 0)
+
+var ClientAdminUserPermissions = make([]api.Permission, 0)
+
+var ClientUserPermissions = make([]api.Permission, 0)
+
+type CreateRequest struct {
+	Claims  claims.Claims
+	Reading sigbugBatteryReading.Reading
+}
+
+type CreateResponse struct {
+	Reading sigbugBatteryReading.Reading
+}
+
+type UpdateAllowedFieldsRequest struct {
+	Claims  claims.Claims
+	Reading sigbugBatteryReading.Reading
+}
+
+type UpdateAllowedFieldsResponse struct {
+	Reading sigbugBatteryReading.Reading
+}
@@ -0,0 +1,79 @@
+package basic
+
+import (
+	"fmt"
+	brainException "github.com/iot-my-world/brain/internal/exception"
+	sigbugBatteryReadingAction "github.com/iot-my-world/brain/pkg/device/sigbug/reading/battery/action"
+	sigbugBatteryReadingAdministrator "github.com/iot-my-world/brain/pkg/device/sigbug/reading/battery/administrator"
+	sigbugBatteryReadingAdministratorException "github.com/iot-my-world/brain/pkg/device/sigbug/reading/battery/administrator/exception"
+	sigbugBatteryReadingRecordHandler "github.com/iot-my-world/brain/pkg/device/sigbug/reading/battery/recordHandler"
+	sigbugBatteryReadingRecordHandlerException "github.com/iot-my-world/brain/pkg/device/sigbug/reading/battery/recordHandler/exception"
+	sigbugBatteryReadingValidator "github.com/iot-my-world/brain/pkg/device/sigbug/reading/battery/validator"
+)
+
+type administrator struct {
+	sigfoxBackendDataCallbackReadingValidator sigbugBatteryReadingValidator.Validator
+	sigbugBatteryReadingRecordHandler         sigbugBatteryReadingRecordHandler.RecordHandler
+}
+
+func New(
+	sigfoxBackendDataCallbackReadingValidator sigbugBatteryReadingValidator.Validator,
+	sigbugBatteryReadingRecordHandler sigbugBatteryReadingRecordHandler.RecordHandler,
+) sigbugBatteryReadingAdministrator.Administrator {
+	return &administrator{
+		sigfoxBackendDataCallbackReadingValidator: sigfoxBackendDataCallbackReadingValidator,
+		sigbugBatteryReadingRecordHandler:         sigbugBatteryReadingRecordHandler,
+	}
+}
+
+func (a *administrator) ValidateCreateRequest(request *sigbugBatteryReadingAdministrator.CreateRequest) error {
+	reasonsInvalid := make([]string, 0)
+
+	if request.Claims == nil {
+		reasonsInvalid = append(reasonsInvalid, "claims are nil")
+	} else {
+		sigfoxBackendDataCallbackReadingValidateResponse, err := a.sigfoxBackendDataCallbackReadingValidator.Validate(&sigbugBatteryReadingValidator.ValidateRequest{
+			Claims:  request.Claims,
+			Reading: request.Reading,
+			Action:  sigbugBatteryReadingAction.Create,
+		})
+		if err != nil {
+			reasonsInvalid = append(reasonsInvalid, "error validating reading reading: "+err.Error())
+		} else {
+			if len(sigfoxBackendDataCallbackReadingValidateResponse.ReasonsInvalid) > 0 {
+				for _, reason := range sigfoxBackendDataCallbackReadingValidateResponse.ReasonsInvalid {
+					reasonsInvalid = append(reasonsInvalid, fmt.Sprintf("reading invalid: %s - %s - %s", reason.Field, reason.Type, reason.Help))
+				}
+			}
+		}
+	}
+
+	if len(reasonsInvalid) > 0 {
+		return brainException.RequestInvalid{Reasons: reasonsInvalid}
+	}
+
+	return nil
+}
+
+func (a *administrator) Create(request *sigbugBatteryReadingAdministrator.CreateRequest) (*sigbugBatteryReadingAdministrator.CreateResponse, error) {
+	if err := a.ValidateCreateRequest(request); err != nil {
+		return nil, err
+	}
+
+	createResponse, err := a.sigbugBatteryReadingRecordHandler.Create(&sigbugBatteryReadingRecordHandler.CreateRequest{
+		Reading: request.Reading,
+	})
+	if err != nil {
+		switch err.(type) {
+		case sigbugBatteryReadingRecordHandlerException.Duplicate:
+			// duplicate readings are reported as such so that they can be ignored
+			return nil, err
+		default:
+			return nil, sigbugBatteryReadingAdministratorException.DeviceCreation{Reasons: []string{err.Error()}}
+		}
+	}
+
+	return &sigbugBatteryReadingAdministrator.CreateResponse{
+		Reading: createResponse.Reading,
+	}, nil
+}
@@ -0,0 +1,50 @@
+package jsonRpc
+
+import (
+	brainException "github.com/iot-my-world/brain/internal/exception"
+	"github.com/iot-my-world/brain/internal/log"
+	jsonRpcClient "github.com/iot-my-world/brain/pkg/api/jsonRpc/client"
+	sigbugBatteryReadingAdministrator "github.com/iot-my-world/brain/pkg/device/sigbug/reading/battery/administrator"
+	sigbugBatteryReadingAdministratorJsonRpcAdaptor "github.com/iot-my-world/brain/pkg/device/sigbug/reading/battery/administrator/adaptor/jsonRpc"
+)
+
+type administrator struct {
+	jsonRpcClient jsonRpcClient.Client
+}
+
+func New(
+	jsonRpcClient jsonRpcClient.Client,
+) sigbugBatteryReadingAdministrator.Administrator {
+	return &administrator{
+		jsonRpcClient: jsonRpcClient,
+	}
+}
+
+func (a *administrator) ValidateCreateRequest(request *sigbugBatteryReadingAdministrator.CreateRequest) error {
+	reasonsInvalid := make([]string, 0)
+
+	if len(reasonsInvalid) > 0 {
+		return brainException.RequestInvalid{Reasons: reasonsInvalid}
+	}
+	return nil
+}
+
+func (a *administrator) Create(request *sigbugBatteryReadingAdministrator.CreateRequest) (*sigbugBatteryReadingAdministrator.CreateResponse, error) {
+	if err := a.ValidateCreateRequest(request); err != nil {
+		return nil, err
+	}
+
+	messageCreateResponse := sigbugBatteryReadingAdministratorJsonRpcAdaptor.CreateResponse{}
+	if err := a.jsonRpcClient.JsonRpcRequest(
+		sigbugBatteryReadingAdministrator.CreateService,
+		sigbugBatteryReadingAdministratorJsonRpcAdaptor.CreateRequest{
+			Reading: request.Reading,
+		},
+		&messageCreateResponse,
+	); err != nil {
+		log.Error(err.Error())
+		return nil, err
+	}
+
+	return &sigbugBatteryReadingAdministrator.CreateResponse{Reading: messageCreateResponse.Reading}, nil
+}
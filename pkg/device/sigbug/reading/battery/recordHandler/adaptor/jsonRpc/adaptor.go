@@ -0,0 +1,101 @@
+package jsonRpc
+
+import (
+	"github.com/iot-my-world/brain/internal/log"
+	jsonRpcServiceProvider "github.com/iot-my-world/brain/pkg/api/jsonRpc/service/provider"
+	sigbugBatteryReading "github.com/iot-my-world/brain/pkg/device/sigbug/reading/battery"
+	sigbugBatteryReadingRecordHandler "github.com/iot-my-world/brain/pkg/device/sigbug/reading/battery/recordHandler"
+	"github.com/iot-my-world/brain/pkg/search/criterion"
+	wrappedCriterion "github.com/iot-my-world/brain/pkg/search/criterion/wrapped"
+	wrappedIdentifier "github.com/iot-my-world/brain/pkg/search/identifier/wrapped"
+	"github.com/iot-my-world/brain/pkg/search/query"
+	wrappedClaims "github.com/iot-my-world/brain/pkg/security/claims/wrapped"
+	"net/http"
+)
+
+type adaptor struct {
+	RecordHandler sigbugBatteryReadingRecordHandler.RecordHandler
+}
+
+func New(recordHandler sigbugBatteryReadingRecordHandler.RecordHandler) *adaptor {
+	return &adaptor{
+		RecordHandler: recordHandler,
+	}
+}
+
+func (a *adaptor) Name() jsonRpcServiceProvider.Name {
+	return sigbugBatteryReadingRecordHandler.ServiceProvider
+}
+
+func (a *adaptor) MethodRequiresAuthorization(string) bool {
+	return true
+}
+
+type RetrieveRequest struct {
+	WrappedIdentifier wrappedIdentifier.Wrapped `json:"identifier"`
+}
+
+type RetrieveResponse struct {
+	Reading sigbugBatteryReading.Reading `json:"reading"`
+}
+
+func (a *adaptor) Retrieve(r *http.Request, request *RetrieveRequest, response *RetrieveResponse) error {
+	claims, err := wrappedClaims.UnwrapClaimsFromContext(r)
+	if err != nil {
+		log.Warn(err.Error())
+		return err
+	}
+
+	retrieveReadingResponse, err := a.RecordHandler.Retrieve(
+		&sigbugBatteryReadingRecordHandler.RetrieveRequest{
+			Claims:     claims,
+			Identifier: request.WrappedIdentifier.Identifier,
+		})
+	if err != nil {
+		return err
+	}
+
+	response.Reading = retrieveReadingResponse.Reading
+
+	return nil
+}
+
+type CollectRequest struct {
+	Criteria []wrappedCriterion.Wrapped `json:"criteria"`
+	Query    query.Query                `json:"query"`
+}
+
+type CollectResponse struct {
+	Records []sigbugBatteryReading.Reading `json:"records"`
+	Total   int                            `json:"total"`
+}
+
+func (a *adaptor) Collect(r *http.Request, request *CollectRequest, response *CollectResponse) error {
+	claims, err := wrappedClaims.UnwrapClaimsFromContext(r)
+	if err != nil {
+		log.Warn(err.Error())
+		return err
+	}
+
+	criteria := make([]criterion.Criterion, 0)
+	for criterionIdx := range request.Criteria {
+		if c, err := request.Criteria[criterionIdx].UnWrap(); err == nil {
+			criteria = append(criteria, c)
+		} else {
+			return err
+		}
+	}
+
+	collectReadingResponse, err := a.RecordHandler.Collect(&sigbugBatteryReadingRecordHandler.CollectRequest{
+		Claims:   claims,
+		Criteria: criteria,
+		Query:    request.Query,
+	})
+	if err != nil {
+		return err
+	}
+
+	response.Records = collectReadingResponse.Records
+	response.Total = collectReadingResponse.Total
+	return nil
+}
@@ -0,0 +1,138 @@
+package sigbugBatteryReadingRecordHandler
+
+import (
+	brainException "github.com/iot-my-world/brain/internal/exception"
+	sigbugBatteryReading "github.com/iot-my-world/brain/pkg/device/sigbug/reading/battery"
+	sigbugBatteryReadingRecordHandler "github.com/iot-my-world/brain/pkg/device/sigbug/reading/battery/recordHandler"
+	sigbugBatteryReadingRecordHandlerException "github.com/iot-my-world/brain/pkg/device/sigbug/reading/battery/recordHandler/exception"
+	brainRecordHandler "github.com/iot-my-world/brain/pkg/recordHandler"
+	brainRecordHandlerException "github.com/iot-my-world/brain/pkg/recordHandler/exception"
+)
+
+type RecordHandler struct {
+	sigbugBatteryReadingRecordHandler brainRecordHandler.RecordHandler
+}
+
+func New(
+	brainReadingRecordHandler brainRecordHandler.RecordHandler,
+) sigbugBatteryReadingRecordHandler.RecordHandler {
+
+	return &RecordHandler{
+		sigbugBatteryReadingRecordHandler: brainReadingRecordHandler,
+	}
+}
+
+type CreateRequest struct {
+	Reading sigbugBatteryReading.Reading
+}
+
+type CreateResponse struct {
+	Reading sigbugBatteryReading.Reading
+}
+
+func (r *RecordHandler) ValidateCreateRequest(request *sigbugBatteryReadingRecordHandler.CreateRequest) error {
+	reasonsInvalid := make([]string, 0)
+
+	if len(reasonsInvalid) > 0 {
+		return brainException.RequestInvalid{Reasons: reasonsInvalid}
+	}
+
+	return nil
+}
+
+func (r *RecordHandler) Create(request *sigbugBatteryReadingRecordHandler.CreateRequest) (*sigbugBatteryReadingRecordHandler.CreateResponse, error) {
+	if err := r.ValidateCreateRequest(request); err != nil {
+		return nil, err
+	}
+
+	createResponse := brainRecordHandler.CreateResponse{}
+	if err := r.sigbugBatteryReadingRecordHandler.Create(&brainRecordHandler.CreateRequest{
+		Entity: &request.Reading,
+	}, &createResponse); err != nil {
+		switch err.(type) {
+		case brainRecordHandlerException.Duplicate:
+			return nil, sigbugBatteryReadingRecordHandlerException.Duplicate{Reasons: []string{err.Error()}}
+		default:
+			return nil, sigbugBatteryReadingRecordHandlerException.Create{Reasons: []string{err.Error()}}
+		}
+	}
+	createdReading, ok := createResponse.Entity.(*sigbugBatteryReading.Reading)
+	if !ok {
+		return nil, sigbugBatteryReadingRecordHandlerException.Create{Reasons: []string{"could not cast created entity to message"}}
+	}
+
+	return &sigbugBatteryReadingRecordHandler.CreateResponse{
+		Reading: *createdReading,
+	}, nil
+}
+
+func (r *RecordHandler) Retrieve(request *sigbugBatteryReadingRecordHandler.RetrieveRequest) (*sigbugBatteryReadingRecordHandler.RetrieveResponse, error) {
+	retrievedReading := sigbugBatteryReading.Reading{}
+	retrieveResponse := brainRecordHandler.RetrieveResponse{
+		Entity: &retrievedReading,
+	}
+	if err := r.sigbugBatteryReadingRecordHandler.Retrieve(&brainRecordHandler.RetrieveRequest{
+		Claims:     request.Claims,
+		Identifier: request.Identifier,
+	}, &retrieveResponse); err != nil {
+		switch err.(type) {
+		case brainRecordHandlerException.NotFound:
+			return nil, sigbugBatteryReadingRecordHandlerException.NotFound{}
+		default:
+			return nil, err
+		}
+	}
+
+	return &sigbugBatteryReadingRecordHandler.RetrieveResponse{
+		Reading: retrievedReading,
+	}, nil
+}
+
+func (r *RecordHandler) Update(request *sigbugBatteryReadingRecordHandler.UpdateRequest) (*sigbugBatteryReadingRecordHandler.UpdateResponse, error) {
+	updateResponse := brainRecordHandler.UpdateResponse{}
+	if err := r.sigbugBatteryReadingRecordHandler.Update(&brainRecordHandler.UpdateRequest{
+		Claims:     request.Claims,
+		Identifier: request.Identifier,
+		Entity:     &request.Reading,
+	}, &updateResponse); err != nil {
+		return nil, sigbugBatteryReadingRecordHandlerException.Update{Reasons: []string{err.Error()}}
+	}
+
+	return &sigbugBatteryReadingRecordHandler.UpdateResponse{}, nil
+}
+
+func (r *RecordHandler) Delete(request *sigbugBatteryReadingRecordHandler.DeleteRequest) (*sigbugBatteryReadingRecordHandler.DeleteResponse, error) {
+	deleteResponse := brainRecordHandler.DeleteResponse{}
+	if err := r.sigbugBatteryReadingRecordHandler.Delete(&brainRecordHandler.DeleteRequest{
+		Claims:     request.Claims,
+		Identifier: request.Identifier,
+	}, &deleteResponse); err != nil {
+		return nil, sigbugBatteryReadingRecordHandlerException.Delete{Reasons: []string{err.Error()}}
+	}
+
+	return &sigbugBatteryReadingRecordHandler.DeleteResponse{}, nil
+}
+
+func (r *RecordHandler) Collect(request *sigbugBatteryReadingRecordHandler.CollectRequest) (*sigbugBatteryReadingRecordHandler.CollectResponse, error) {
+	var collectedReading []sigbugBatteryReading.Reading
+	collectResponse := brainRecordHandler.CollectResponse{
+		Records: &collectedReading,
+	}
+	err := r.sigbugBatteryReadingRecordHandler.Collect(&brainRecordHandler.CollectRequest{
+		Claims:   request.Claims,
+		Criteria: request.Criteria,
+		Query:    request.Query,
+	}, &collectResponse)
+	if err != nil {
+		return nil, sigbugBatteryReadingRecordHandlerException.Collect{Reasons: []string{err.Error()}}
+	}
+
+	if collectedReading == nil {
+		collectedReading = make([]sigbugBatteryReading.Reading, 0)
+	}
+
+	return &sigbugBatteryReadingRecordHandler.CollectResponse{
+		Records: collectedReading,
+		Total:   collectResponse.Total,
+	}, nil
+}
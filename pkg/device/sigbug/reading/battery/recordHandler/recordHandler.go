@@ -0,0 +1,91 @@
+package recordHandler
+
+import (
+	sigbugBatteryReading "github.com/iot-my-world/brain/pkg/device/sigbug/reading/battery"
+	"github.com/iot-my-world/brain/pkg/search/criterion"
+	"github.com/iot-my-world/brain/pkg/search/identifier"
+	"github.com/iot-my-world/brain/pkg/search/query"
+	"github.com/iot-my-world/brain/pkg/security/claims"
+	"github.com/iot-my-world/brain/pkg/security/permission/api"
+)
+
+type RecordHandler interface {
+	Create(*CreateRequest) (*CreateResponse, error)
+	Retrieve(*RetrieveRequest) (*RetrieveResponse, error)
+	Update(*UpdateRequest) (*UpdateResponse, error)
+	Delete(*DeleteRequest) (*DeleteResponse, error)
+	Collect(*CollectRequest) (*CollectResponse, error)
+}
+
+const ServiceProvider = "SigbugBatteryReading-RecordHandler"
+const CreateService = ServiceProvider + ".Create"
+const RetrieveService = ServiceProvider + ".Retrieve"
+const UpdateService = ServiceProvider + ".Update"
+const DeleteService = ServiceProvider + ".Delete"
+const CollectService = ServiceProvider + ".Collect"
+
+var SystemUserPermissions = make([]api.Permission, 0)
+
+var CompanyAdminUserPermissions = []api.Permission{
+	CollectService,
+	RetrieveService,
+}
+
+var CompanyUserPermissions = []api.Permission{
+	CollectService,
+	RetrieveService,
+}
+
+var ClientAdminUserPermissions = []api.Permission{
+	CollectService,
+	RetrieveService,
+}
+
+var ClientUserPermissions = []api.Permission{
+	CollectService,
+	RetrieveService,
+}
+
+type CreateRequest struct {
+	Reading sigbugBatteryReading.Reading
+}
+
+type CreateResponse struct {
+	Reading sigbugBatteryReading.Reading
+}
+
+type RetrieveRequest struct {
+	Claims     claims.Claims
+	Identifier identifier.Identifier
+}
+
+type RetrieveResponse struct {
+	Reading sigbugBatteryReading.Reading
+}
+
+type UpdateRequest struct {
+	Claims     claims.Claims
+	Identifier identifier.Identifier
+	Reading    sigbugBatteryReading.Reading
+}
+
+type UpdateResponse struct{}
+
+type DeleteRequest struct {
+	Claims     claims.Claims
+	Identifier identifier.Identifier
+}
+
+type DeleteResponse struct {
+}
+
+type CollectRequest struct {
+	Claims   claims.Claims
+	Criteria []criterion.Criterion
+	Query    query.Query
+}
+
+type CollectResponse struct {
+	Records []sigbugBatteryReading.Reading
+	Total   int
+}
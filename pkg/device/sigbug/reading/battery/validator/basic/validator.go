@@ -0,0 +1,169 @@
+package validator
+
+import (
+	brainException "github.com/iot-my-world/brain/internal/exception"
+	"github.com/iot-my-world/brain/internal/log"
+	"github.com/iot-my-world/brain/pkg/action"
+	sigbugBatteryReadingAction "github.com/iot-my-world/brain/pkg/device/sigbug/reading/battery/action"
+	sigbugBatteryReadingValidator "github.com/iot-my-world/brain/pkg/device/sigbug/reading/battery/validator"
+	sigbugBatteryReadingValidatorException "github.com/iot-my-world/brain/pkg/device/sigbug/reading/battery/validator/exception"
+	sigbugRecordHandler "github.com/iot-my-world/brain/pkg/device/sigbug/recordHandler"
+	sigbugRecordHandlerException "github.com/iot-my-world/brain/pkg/device/sigbug/recordHandler/exception"
+	partyAdministrator "github.com/iot-my-world/brain/pkg/party/administrator"
+	humanUserLoginClaims "github.com/iot-my-world/brain/pkg/security/claims/login/user/human"
+	"github.com/iot-my-world/brain/pkg/validate/reasonInvalid"
+)
+
+type validator struct {
+	actionIgnoredReasons map[action.Action]reasonInvalid.IgnoredReasonsInvalid
+	systemClaims         *humanUserLoginClaims.Login
+	sigbugRecordHandler  sigbugRecordHandler.RecordHandler
+}
+
+func New(
+	sigbugRecordHandler sigbugRecordHandler.RecordHandler,
+	partyAdministrator partyAdministrator.Administrator,
+	systemClaims *humanUserLoginClaims.Login,
+) sigbugBatteryReadingValidator.Validator {
+
+	actionIgnoredReasons := map[action.Action]reasonInvalid.IgnoredReasonsInvalid{
+		sigbugBatteryReadingAction.Create: {
+			ReasonsInvalid: map[string][]reasonInvalid.Type{
+				"id": {
+					reasonInvalid.Blank,
+				},
+			},
+		},
+	}
+
+	return &validator{
+		sigbugRecordHandler:  sigbugRecordHandler,
+		actionIgnoredReasons: actionIgnoredReasons,
+		systemClaims:         systemClaims,
+	}
+}
+
+func (v *validator) ValidateValidateRequest(request *sigbugBatteryReadingValidator.ValidateRequest) error {
+	reasonsInvalid := make([]string, 0)
+
+	if request.Claims == nil {
+		reasonsInvalid = append(reasonsInvalid, "claims are nil")
+	}
+
+	if len(reasonsInvalid) > 0 {
+		return brainException.RequestInvalid{Reasons: reasonsInvalid}
+	}
+	return nil
+}
+
+func (v *validator) Validate(request *sigbugBatteryReadingValidator.ValidateRequest) (*sigbugBatteryReadingValidator.ValidateResponse, error) {
+	if err := v.ValidateValidateRequest(request); err != nil {
+		return nil, err
+	}
+
+	allReasonsInvalid := make([]reasonInvalid.ReasonInvalid, 0)
+	batteryReadingToValidate := &request.Reading
+
+	if (*batteryReadingToValidate).Id == "" {
+		allReasonsInvalid = append(allReasonsInvalid, reasonInvalid.ReasonInvalid{
+			Field: "id",
+			Type:  reasonInvalid.Blank,
+			Help:  "cannot be blank",
+			Data:  (*batteryReadingToValidate).Id,
+		})
+	}
+
+	if (*batteryReadingToValidate).MessageId.Id == "" {
+		allReasonsInvalid = append(allReasonsInvalid, reasonInvalid.ReasonInvalid{
+			Field: "messageId",
+			Type:  reasonInvalid.Blank,
+			Help:  "cannot be blank",
+			Data:  (*batteryReadingToValidate).MessageId,
+		})
+	}
+
+	if (*batteryReadingToValidate).DeviceId.Id == "" {
+		allReasonsInvalid = append(allReasonsInvalid, reasonInvalid.ReasonInvalid{
+			Field: "deviceId",
+			Type:  reasonInvalid.Blank,
+			Help:  "cannot be blank",
+			Data:  (*batteryReadingToValidate).DeviceId,
+		})
+	} else {
+		// device must exist
+		sigbugRetrieveResponse, err := v.sigbugRecordHandler.Retrieve(&sigbugRecordHandler.RetrieveRequest{
+			Claims:     request.Claims,
+			Identifier: request.Reading.DeviceId,
+		})
+		if err != nil {
+			switch err.(type) {
+			case sigbugRecordHandlerException.NotFound:
+				allReasonsInvalid = append(allReasonsInvalid, reasonInvalid.ReasonInvalid{
+					Field: "deviceId",
+					Type:  reasonInvalid.MustExist,
+					Help:  "associated device must exist",
+					Data:  (*batteryReadingToValidate).DeviceId,
+				})
+			default:
+				err = sigbugBatteryReadingValidatorException.Validate{
+					Reasons: []string{
+						"retrieving sigbug",
+						err.Error(),
+					},
+				}
+				log.Error(err)
+				return nil, err
+			}
+		} else {
+			// device and message must have same owner and assigned party details
+			if sigbugRetrieveResponse.Sigbug.OwnerId != (*batteryReadingToValidate).OwnerId {
+				allReasonsInvalid = append(allReasonsInvalid, reasonInvalid.ReasonInvalid{
+					Field: "ownerId",
+					Type:  reasonInvalid.Invalid,
+					Help:  "owner id must be same as associated sigbug",
+					Data:  (*batteryReadingToValidate).OwnerId,
+				})
+			}
+			if sigbugRetrieveResponse.Sigbug.OwnerPartyType != (*batteryReadingToValidate).OwnerPartyType {
+				allReasonsInvalid = append(allReasonsInvalid, reasonInvalid.ReasonInvalid{
+					Field: "ownerPartyType",
+					Type:  reasonInvalid.Invalid,
+					Help:  "owner party type must be same as associated sigbug",
+					Data:  (*batteryReadingToValidate).OwnerPartyType,
+				})
+			}
+			if sigbugRetrieveResponse.Sigbug.AssignedId != (*batteryReadingToValidate).AssignedId {
+				allReasonsInvalid = append(allReasonsInvalid, reasonInvalid.ReasonInvalid{
+					Field: "assignedId",
+					Type:  reasonInvalid.Invalid,
+					Help:  "assigned id must be same as associated sigbug",
+					Data:  (*batteryReadingToValidate).AssignedId,
+				})
+			}
+			if sigbugRetrieveResponse.Sigbug.AssignedPartyType != (*batteryReadingToValidate).AssignedPartyType {
+				allReasonsInvalid = append(allReasonsInvalid, reasonInvalid.ReasonInvalid{
+					Field: "assignedPartyType",
+					Type:  reasonInvalid.Invalid,
+					Help:  "assigned party type must be same as associated sigbug",
+					Data:  (*batteryReadingToValidate).AssignedPartyType,
+				})
+			}
+		}
+	}
+
+	// Make list of reasons invalid to return
+	returnedReasonsInvalid := make([]reasonInvalid.ReasonInvalid, 0)
+
+	// Add all reasons that cannot be ignored for the given action
+	if v.actionIgnoredReasons[request.Action].ReasonsInvalid != nil {
+		for _, reason := range allReasonsInvalid {
+			if !v.actionIgnoredReasons[request.Action].CanIgnore(reason) {
+				returnedReasonsInvalid = append(returnedReasonsInvalid, reason)
+			}
+		}
+	}
+
+	return &sigbugBatteryReadingValidator.ValidateResponse{
+		ReasonsInvalid: returnedReasonsInvalid,
+	}, nil
+}
@@ -0,0 +1,11 @@
+package exception
+
+import "strings"
+
+type Validate struct {
+	Reasons []string
+}
+
+func (e Validate) Error() string {
+	return "error validating sigbug battery reading: " + strings.Join(e.Reasons, "; ")
+}
@@ -7,6 +7,7 @@ import (
 	sigbugGPSReadingAdministrator "github.com/iot-my-world/brain/pkg/device/sigbug/reading/gps/administrator"
 	sigbugGPSReadingAdministratorException "github.com/iot-my-world/brain/pkg/device/sigbug/reading/gps/administrator/exception"
 	sigbugGPSReadingRecordHandler "github.com/iot-my-world/brain/pkg/device/sigbug/reading/gps/recordHandler"
+	sigbugGPSReadingRecordHandlerException "github.com/iot-my-world/brain/pkg/device/sigbug/reading/gps/recordHandler/exception"
 	sigbugGPSReadingValidator "github.com/iot-my-world/brain/pkg/device/sigbug/reading/gps/validator"
 )
 
@@ -63,7 +64,13 @@ func (a *administrator) Create(request *sigbugGPSReadingAdministrator.CreateRequ
 		Reading: request.Reading,
 	})
 	if err != nil {
-		return nil, sigbugGPSReadingAdministratorException.DeviceCreation{Reasons: []string{err.Error()}}
+		switch err.(type) {
+		case sigbugGPSReadingRecordHandlerException.Duplicate:
+			// duplicate readings are reported as such so that they can be ignored
+			return nil, err
+		default:
+			return nil, sigbugGPSReadingAdministratorException.DeviceCreation{Reasons: []string{err.Error()}}
+		}
 	}
 
 	return &sigbugGPSReadingAdministrator.CreateResponse{
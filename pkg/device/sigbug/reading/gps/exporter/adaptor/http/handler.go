@@ -0,0 +1,120 @@
+package http
+
+import (
+	"encoding/json"
+	"fmt"
+	brainException "github.com/iot-my-world/brain/internal/exception"
+	"github.com/iot-my-world/brain/internal/log"
+	jsonRpcServerAuthoriser "github.com/iot-my-world/brain/pkg/api/jsonRpc/server/authoriser"
+	sigbugGPSReadingExporter "github.com/iot-my-world/brain/pkg/device/sigbug/reading/gps/exporter"
+	"github.com/iot-my-world/brain/pkg/search/criterion"
+	wrappedCriterion "github.com/iot-my-world/brain/pkg/search/criterion/wrapped"
+	"github.com/iot-my-world/brain/pkg/search/query"
+	netHttp "net/http"
+)
+
+// Path is the path on which exports are served
+const Path = "/export/sigbug/gps"
+
+type ExportRequest struct {
+	Criteria []wrappedCriterion.Wrapped      `json:"criteria"`
+	Query    query.Query                     `json:"query"`
+	Format   sigbugGPSReadingExporter.Format `json:"format"`
+}
+
+// NewAuthorisedHandler returns a handler which authorises export requests
+// before streaming the export as a file download. Exports are not served
+// over json rpc since the whole response would have to be held in memory.
+func NewAuthorisedHandler(exporter sigbugGPSReadingExporter.Exporter, authoriser jsonRpcServerAuthoriser.Authoriser) netHttp.Handler {
+	return netHttp.HandlerFunc(func(w netHttp.ResponseWriter, r *netHttp.Request) {
+		w.Header().Set("Access-Control-Allow-Origin", "*")
+		// the file name of the download is read from this header
+		w.Header().Set("Access-Control-Expose-Headers", "Content-Disposition")
+
+		// browsers make a preflight request before posting with an authorization header
+		if r.Method == netHttp.MethodOptions {
+			w.Header().Set("Access-Control-Allow-Methods", "POST, OPTIONS")
+			w.Header().Set(
+				"Access-Control-Allow-Headers",
+				"Origin, X-Requested-With, Content-Type, Accept, Access-Control-Allow-Origin, Authorization",
+			)
+			w.WriteHeader(netHttp.StatusOK)
+			return
+		}
+
+		if r.Method != netHttp.MethodPost {
+			netHttp.Error(w, "Method Not Allowed", netHttp.StatusMethodNotAllowed)
+			return
+		}
+
+		if r.Header["Authorization"] == nil {
+			log.Info("Unauthorised Export access! - No Authorisation header!")
+			netHttp.Error(w, "Unauthorised", netHttp.StatusForbidden)
+			return
+		}
+
+		// authorize access to the export
+		wrappedClaims, err := authoriser.AuthoriseServiceMethod(r.Header["Authorization"][0], sigbugGPSReadingExporter.ExportService)
+		if err != nil {
+			log.Warn("Unauthorised Export Access Attempt", err.Error())
+			netHttp.Error(w, "Unauthorised", netHttp.StatusForbidden)
+			return
+		}
+		claims, err := wrappedClaims.Unwrap()
+		if err != nil {
+			log.Warn("Unauthorised Export Access Attempt", err.Error())
+			netHttp.Error(w, "Unauthorised", netHttp.StatusForbidden)
+			return
+		}
+
+		var request ExportRequest
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			netHttp.Error(w, err.Error(), netHttp.StatusBadRequest)
+			return
+		}
+		criteria := make([]criterion.Criterion, 0)
+		for criterionIdx := range request.Criteria {
+			c, err := request.Criteria[criterionIdx].UnWrap()
+			if err != nil {
+				netHttp.Error(w, err.Error(), netHttp.StatusBadRequest)
+				return
+			}
+			criteria = append(criteria, c)
+		}
+
+		// the headers are only sent once the export starts writing
+		// to the response, so errors before then are still returned
+		w.Header().Set("Content-Type", request.Format.ContentType())
+		w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=\"gps-readings.%s\"", request.Format))
+		exportWriter := &responseWriter{ResponseWriter: w}
+		if _, err := exporter.Export(&sigbugGPSReadingExporter.ExportRequest{
+			Claims:   claims,
+			Criteria: criteria,
+			Query:    request.Query,
+			Format:   request.Format,
+			Writer:   exportWriter,
+		}); err != nil {
+			if !exportWriter.written {
+				w.Header().Del("Content-Disposition")
+				switch err.(type) {
+				case brainException.RequestInvalid:
+					netHttp.Error(w, err.Error(), netHttp.StatusBadRequest)
+				default:
+					netHttp.Error(w, err.Error(), netHttp.StatusInternalServerError)
+				}
+			}
+			return
+		}
+	})
+}
+
+// responseWriter records whether anything has been written to the response
+type responseWriter struct {
+	netHttp.ResponseWriter
+	written bool
+}
+
+func (w *responseWriter) Write(p []byte) (int, error) {
+	w.written = true
+	return w.ResponseWriter.Write(p)
+}
@@ -0,0 +1,168 @@
+package basic
+
+import (
+	"fmt"
+	brainException "github.com/iot-my-world/brain/internal/exception"
+	"github.com/iot-my-world/brain/internal/log"
+	sigbugGPSReading "github.com/iot-my-world/brain/pkg/device/sigbug/reading/gps"
+	sigbugGPSReadingExporter "github.com/iot-my-world/brain/pkg/device/sigbug/reading/gps/exporter"
+	sigbugGPSReadingExporterException "github.com/iot-my-world/brain/pkg/device/sigbug/reading/gps/exporter/exception"
+	sigbugGPSReadingRecordHandler "github.com/iot-my-world/brain/pkg/device/sigbug/reading/gps/recordHandler"
+	"github.com/iot-my-world/brain/pkg/search/query"
+)
+
+// pageSize is the number of readings collected at a time so
+// that large exports are not loaded into memory all at once
+const pageSize = 1000
+
+type exporter struct {
+	sigbugGPSReadingRecordHandler sigbugGPSReadingRecordHandler.RecordHandler
+}
+
+func New(
+	sigbugGPSReadingRecordHandler sigbugGPSReadingRecordHandler.RecordHandler,
+) sigbugGPSReadingExporter.Exporter {
+	return &exporter{
+		sigbugGPSReadingRecordHandler: sigbugGPSReadingRecordHandler,
+	}
+}
+
+func (e *exporter) ValidateExportRequest(request *sigbugGPSReadingExporter.ExportRequest) error {
+	reasonsInvalid := make([]string, 0)
+
+	if request.Claims == nil {
+		reasonsInvalid = append(reasonsInvalid, "claims are nil")
+	}
+
+	for _, crit := range request.Criteria {
+		if crit == nil {
+			reasonsInvalid = append(reasonsInvalid, "a criterion is nil")
+		}
+	}
+
+	switch request.Format {
+	case sigbugGPSReadingExporter.CSV,
+		sigbugGPSReadingExporter.XLSX,
+		sigbugGPSReadingExporter.GPX,
+		sigbugGPSReadingExporter.KML:
+	default:
+		reasonsInvalid = append(reasonsInvalid, fmt.Sprintf("invalid format: '%s'", request.Format))
+	}
+
+	if request.Writer == nil {
+		reasonsInvalid = append(reasonsInvalid, "writer is nil")
+	}
+
+	if len(reasonsInvalid) > 0 {
+		return brainException.RequestInvalid{Reasons: reasonsInvalid}
+	}
+	return nil
+}
+
+func (e *exporter) Export(request *sigbugGPSReadingExporter.ExportRequest) (*sigbugGPSReadingExporter.ExportResponse, error) {
+	if err := e.ValidateExportRequest(request); err != nil {
+		log.Error(err.Error())
+		return nil, err
+	}
+
+	exportQuery := request.Query
+	var readingWriter formatWriter
+	switch request.Format {
+	case sigbugGPSReadingExporter.CSV:
+		readingWriter = newCSVWriter(request.Writer)
+	case sigbugGPSReadingExporter.XLSX:
+		readingWriter = newXLSXWriter(request.Writer)
+	case sigbugGPSReadingExporter.GPX:
+		readingWriter = newGPXWriter(request.Writer)
+	case sigbugGPSReadingExporter.KML:
+		readingWriter = newKMLWriter(request.Writer)
+	}
+	switch request.Format {
+	case sigbugGPSReadingExporter.GPX, sigbugGPSReadingExporter.KML:
+		// readings are grouped into a track per device
+		exportQuery.SortBy = []string{"deviceId.id", "timeStamp"}
+		exportQuery.Order = []query.SortOrder{query.SortOrderAscending, query.SortOrderAscending}
+	default:
+		if len(exportQuery.SortBy) == 0 {
+			exportQuery.SortBy = []string{"timeStamp"}
+			exportQuery.Order = []query.SortOrder{query.SortOrderAscending}
+		}
+	}
+	// readings are sorted by id last so that their order is total and
+	// none are skipped or repeated between pages with equal sort values
+	sortedById := false
+	for _, field := range exportQuery.SortBy {
+		if field == "id" {
+			sortedById = true
+		}
+	}
+	if !sortedById {
+		exportQuery.SortBy = append(append(make([]string, 0), exportQuery.SortBy...), "id")
+		exportQuery.Order = append(append(make([]query.SortOrder, 0), exportQuery.Order...), query.SortOrderAscending)
+	}
+
+	if err := readingWriter.begin(); err != nil {
+		err = sigbugGPSReadingExporterException.Write{Reasons: []string{err.Error()}}
+		log.Error(err.Error())
+		return nil, err
+	}
+
+	total := 0
+	for {
+		// collect the next page of readings, without going past the query limit
+		pageQuery := query.Query{
+			Limit:  pageSize,
+			Offset: exportQuery.Offset + total,
+			Order:  exportQuery.Order,
+			SortBy: exportQuery.SortBy,
+		}
+		if exportQuery.Limit > 0 && exportQuery.Limit-total < pageSize {
+			pageQuery.Limit = exportQuery.Limit - total
+		}
+		if pageQuery.Limit <= 0 {
+			break
+		}
+
+		collectResponse, err := e.sigbugGPSReadingRecordHandler.Collect(&sigbugGPSReadingRecordHandler.CollectRequest{
+			Claims:    request.Claims,
+			Criteria:  request.Criteria,
+			Query:     pageQuery,
+			SkipCount: true,
+		})
+		if err != nil {
+			err = sigbugGPSReadingExporterException.ReadingCollection{Reasons: []string{err.Error()}}
+			log.Error(err.Error())
+			return nil, err
+		}
+
+		for readingIdx := range collectResponse.Records {
+			if err := readingWriter.write(collectResponse.Records[readingIdx]); err != nil {
+				err = sigbugGPSReadingExporterException.Write{Reasons: []string{err.Error()}}
+				log.Error(err.Error())
+				return nil, err
+			}
+		}
+		total += len(collectResponse.Records)
+
+		if len(collectResponse.Records) < pageQuery.Limit {
+			break
+		}
+	}
+
+	if err := readingWriter.end(); err != nil {
+		err = sigbugGPSReadingExporterException.Write{Reasons: []string{err.Error()}}
+		log.Error(err.Error())
+		return nil, err
+	}
+
+	return &sigbugGPSReadingExporter.ExportResponse{
+		Total: total,
+	}, nil
+}
+
+// formatWriter writes readings to an export in a particular format
+type formatWriter interface {
+	begin() error
+	write(reading sigbugGPSReading.Reading) error
+	end() error
+}
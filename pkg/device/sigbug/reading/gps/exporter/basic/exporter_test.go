@@ -0,0 +1,120 @@
+package basic
+
+import (
+	"bytes"
+	sigbugGPSReading "github.com/iot-my-world/brain/pkg/device/sigbug/reading/gps"
+	sigbugGPSReadingExporter "github.com/iot-my-world/brain/pkg/device/sigbug/reading/gps/exporter"
+	sigbugGPSReadingRecordHandler "github.com/iot-my-world/brain/pkg/device/sigbug/reading/gps/recordHandler"
+	"github.com/iot-my-world/brain/pkg/search/criterion"
+	"github.com/iot-my-world/brain/pkg/search/identifier/id"
+	"github.com/iot-my-world/brain/pkg/search/query"
+	humanUserLoginClaims "github.com/iot-my-world/brain/pkg/security/claims/login/user/human"
+	"github.com/stretchr/testify/assert"
+	"strconv"
+	"testing"
+)
+
+// fakeRecordHandler pages through a number of readings, recording
+// the collect requests made
+type fakeRecordHandler struct {
+	sigbugGPSReadingRecordHandler.RecordHandler
+	noReadings int
+	requests   []sigbugGPSReadingRecordHandler.CollectRequest
+}
+
+func (r *fakeRecordHandler) Collect(request *sigbugGPSReadingRecordHandler.CollectRequest) (*sigbugGPSReadingRecordHandler.CollectResponse, error) {
+	r.requests = append(r.requests, *request)
+	records := make([]sigbugGPSReading.Reading, 0)
+	for readingIdx := request.Query.Offset; readingIdx < r.noReadings && len(records) < request.Query.Limit; readingIdx++ {
+		records = append(records, sigbugGPSReading.Reading{
+			Id:       strconv.Itoa(readingIdx),
+			DeviceId: id.Identifier{Id: "device"},
+		})
+	}
+	return &sigbugGPSReadingRecordHandler.CollectResponse{Records: records}, nil
+}
+
+func TestExportPages(t *testing.T) {
+	tests := []struct {
+		name           string
+		noReadings     int
+		query          query.Query
+		format         sigbugGPSReadingExporter.Format
+		expectedTotal  int
+		expectedPages  int
+		expectedSortBy []string
+	}{
+		{
+			name:           "default sort",
+			noReadings:     pageSize + 1,
+			format:         sigbugGPSReadingExporter.CSV,
+			expectedTotal:  pageSize + 1,
+			expectedPages:  2,
+			expectedSortBy: []string{"timeStamp", "id"},
+		},
+		{
+			name:       "given sort",
+			noReadings: 10,
+			query: query.Query{
+				SortBy: []string{"latitude"},
+				Order:  []query.SortOrder{query.SortOrderDescending},
+			},
+			format:         sigbugGPSReadingExporter.CSV,
+			expectedTotal:  10,
+			expectedPages:  1,
+			expectedSortBy: []string{"latitude", "id"},
+		},
+		{
+			name:       "given sort by id",
+			noReadings: 10,
+			query: query.Query{
+				SortBy: []string{"id"},
+				Order:  []query.SortOrder{query.SortOrderDescending},
+			},
+			format:         sigbugGPSReadingExporter.CSV,
+			expectedTotal:  10,
+			expectedPages:  1,
+			expectedSortBy: []string{"id"},
+		},
+		{
+			name:           "limited",
+			noReadings:     2 * pageSize,
+			query:          query.Query{Limit: pageSize + 5},
+			format:         sigbugGPSReadingExporter.CSV,
+			expectedTotal:  pageSize + 5,
+			expectedPages:  2,
+			expectedSortBy: []string{"timeStamp", "id"},
+		},
+		{
+			name:           "track sort",
+			noReadings:     10,
+			format:         sigbugGPSReadingExporter.GPX,
+			expectedTotal:  10,
+			expectedPages:  1,
+			expectedSortBy: []string{"deviceId.id", "timeStamp", "id"},
+		},
+	}
+
+	for _, test := range tests {
+		recordHandler := &fakeRecordHandler{noReadings: test.noReadings}
+		var export bytes.Buffer
+		exportResponse, err := New(recordHandler).Export(&sigbugGPSReadingExporter.ExportRequest{
+			Claims:   humanUserLoginClaims.Login{},
+			Criteria: make([]criterion.Criterion, 0),
+			Query:    test.query,
+			Format:   test.format,
+			Writer:   &export,
+		})
+		if !assert.NoError(t, err, test.name) {
+			continue
+		}
+
+		assert.Equal(t, test.expectedTotal, exportResponse.Total, test.name)
+		assert.Len(t, recordHandler.requests, test.expectedPages, test.name)
+		for _, request := range recordHandler.requests {
+			assert.True(t, request.SkipCount, test.name)
+			assert.Equal(t, test.expectedSortBy, request.Query.SortBy, test.name)
+			assert.Len(t, request.Query.Order, len(test.expectedSortBy), test.name)
+		}
+	}
+}
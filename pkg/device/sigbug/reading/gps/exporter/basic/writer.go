@@ -0,0 +1,218 @@
+package basic
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/xml"
+	"fmt"
+	"github.com/360EntSecGroup-Skylar/excelize"
+	sigbugGPSReading "github.com/iot-my-world/brain/pkg/device/sigbug/reading/gps"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// columnHeaders are the headers of the columns in csv and xlsx exports
+var columnHeaders = []string{
+	"deviceId",
+	"time",
+	"timeStamp",
+	"latitude",
+	"longitude",
+	"ownerPartyType",
+	"ownerId",
+	"assignedPartyType",
+	"assignedId",
+}
+
+// columnValues returns the values of the columns of the given reading
+func columnValues(reading sigbugGPSReading.Reading) []string {
+	return []string{
+		reading.DeviceId.Id,
+		readingTime(reading),
+		strconv.FormatInt(reading.TimeStamp, 10),
+		formatCoordinate(reading.Latitude),
+		formatCoordinate(reading.Longitude),
+		string(reading.OwnerPartyType),
+		reading.OwnerId.Id,
+		string(reading.AssignedPartyType),
+		reading.AssignedId.Id,
+	}
+}
+
+// readingTime returns the time of the given reading in RFC 3339 format
+func readingTime(reading sigbugGPSReading.Reading) string {
+	return time.Unix(reading.TimeStamp, 0).UTC().Format(time.RFC3339)
+}
+
+type csvWriter struct {
+	writer *csv.Writer
+}
+
+func newCSVWriter(writer io.Writer) formatWriter {
+	return &csvWriter{
+		writer: csv.NewWriter(writer),
+	}
+}
+
+func (w *csvWriter) begin() error {
+	return w.writer.Write(columnHeaders)
+}
+
+func (w *csvWriter) write(reading sigbugGPSReading.Reading) error {
+	return w.writer.Write(columnValues(reading))
+}
+
+func (w *csvWriter) end() error {
+	w.writer.Flush()
+	return w.writer.Error()
+}
+
+// xlsxWriter streams rows to a worksheet, which is buffered on
+// disk by excelize, and then writes the workbook once complete
+type xlsxWriter struct {
+	writer       io.Writer
+	file         *excelize.File
+	streamWriter *excelize.StreamWriter
+	rowNumber    int
+}
+
+func newXLSXWriter(writer io.Writer) formatWriter {
+	return &xlsxWriter{
+		writer: writer,
+	}
+}
+
+func (w *xlsxWriter) setRow(values []string) error {
+	w.rowNumber++
+	cell, err := excelize.CoordinatesToCellName(1, w.rowNumber)
+	if err != nil {
+		return err
+	}
+	row := make([]interface{}, len(values))
+	for valueIdx := range values {
+		row[valueIdx] = values[valueIdx]
+	}
+	return w.streamWriter.SetRow(cell, row)
+}
+
+func (w *xlsxWriter) begin() error {
+	w.file = excelize.NewFile()
+	streamWriter, err := w.file.NewStreamWriter("Sheet1")
+	if err != nil {
+		return err
+	}
+	w.streamWriter = streamWriter
+	return w.setRow(columnHeaders)
+}
+
+func (w *xlsxWriter) write(reading sigbugGPSReading.Reading) error {
+	return w.setRow(columnValues(reading))
+}
+
+func (w *xlsxWriter) end() error {
+	if err := w.streamWriter.Flush(); err != nil {
+		return err
+	}
+	return w.file.Write(w.writer)
+}
+
+// xmlTrackWriter writes the readings of each device as
+// a track in an xml document, e.g. a gpx or kml file
+type xmlTrackWriter struct {
+	writer   *bufio.Writer
+	deviceId string
+
+	header     string
+	footer     string
+	trackStart func(deviceId string) string
+	trackEnd   string
+	point      func(reading sigbugGPSReading.Reading) string
+}
+
+func (w *xmlTrackWriter) begin() error {
+	_, err := w.writer.WriteString(xml.Header + w.header)
+	return err
+}
+
+func (w *xmlTrackWriter) write(reading sigbugGPSReading.Reading) error {
+	// readings are sorted by device, so a new track
+	// is started whenever the device changes
+	if w.deviceId != reading.DeviceId.Id {
+		if w.deviceId != "" {
+			if _, err := w.writer.WriteString(w.trackEnd); err != nil {
+				return err
+			}
+		}
+		w.deviceId = reading.DeviceId.Id
+		if _, err := w.writer.WriteString(w.trackStart(w.deviceId)); err != nil {
+			return err
+		}
+	}
+	_, err := w.writer.WriteString(w.point(reading))
+	return err
+}
+
+func (w *xmlTrackWriter) end() error {
+	if w.deviceId != "" {
+		if _, err := w.writer.WriteString(w.trackEnd); err != nil {
+			return err
+		}
+	}
+	if _, err := w.writer.WriteString(w.footer); err != nil {
+		return err
+	}
+	return w.writer.Flush()
+}
+
+// escape returns the given text escaped for use in xml
+func escape(text string) string {
+	var escaped strings.Builder
+	_ = xml.EscapeText(&escaped, []byte(text))
+	return escaped.String()
+}
+
+func formatCoordinate(coordinate float32) string {
+	return strconv.FormatFloat(float64(coordinate), 'f', -1, 32)
+}
+
+func newGPXWriter(writer io.Writer) formatWriter {
+	return &xmlTrackWriter{
+		writer: bufio.NewWriter(writer),
+		header: `<gpx version="1.1" creator="iot-my-world brain" xmlns="http://www.topografix.com/GPX/1/1">` + "\n",
+		footer: "</gpx>\n",
+		trackStart: func(deviceId string) string {
+			return fmt.Sprintf("<trk><name>%s</name><trkseg>\n", escape(deviceId))
+		},
+		trackEnd: "</trkseg></trk>\n",
+		point: func(reading sigbugGPSReading.Reading) string {
+			return fmt.Sprintf(
+				"<trkpt lat=\"%s\" lon=\"%s\"><time>%s</time></trkpt>\n",
+				formatCoordinate(reading.Latitude),
+				formatCoordinate(reading.Longitude),
+				readingTime(reading),
+			)
+		},
+	}
+}
+
+func newKMLWriter(writer io.Writer) formatWriter {
+	return &xmlTrackWriter{
+		writer: bufio.NewWriter(writer),
+		header: `<kml xmlns="http://www.opengis.net/kml/2.2"><Document>` + "\n",
+		footer: "</Document></kml>\n",
+		trackStart: func(deviceId string) string {
+			return fmt.Sprintf("<Placemark><name>%s</name><LineString><coordinates>\n", escape(deviceId))
+		},
+		trackEnd: "</coordinates></LineString></Placemark>\n",
+		point: func(reading sigbugGPSReading.Reading) string {
+			// kml coordinates are given as longitude then latitude
+			return fmt.Sprintf(
+				"%s,%s\n",
+				formatCoordinate(reading.Longitude),
+				formatCoordinate(reading.Latitude),
+			)
+		},
+	}
+}
@@ -0,0 +1,166 @@
+package basic
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/xml"
+	"github.com/360EntSecGroup-Skylar/excelize"
+	sigbugGPSReading "github.com/iot-my-world/brain/pkg/device/sigbug/reading/gps"
+	"github.com/iot-my-world/brain/pkg/party"
+	"github.com/iot-my-world/brain/pkg/search/identifier/id"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+// testReadings are sorted by device, as they are for track exports
+var testReadings = []sigbugGPSReading.Reading{
+	{
+		DeviceId:       id.Identifier{Id: "device<1>"},
+		OwnerPartyType: party.Company,
+		OwnerId:        id.Identifier{Id: "company"},
+		TimeStamp:      1546300800,
+		Latitude:       -26.2041,
+		Longitude:      28.0473,
+	},
+	{
+		DeviceId:          id.Identifier{Id: "device<1>"},
+		OwnerPartyType:    party.Company,
+		OwnerId:           id.Identifier{Id: "company"},
+		AssignedPartyType: party.Client,
+		AssignedId:        id.Identifier{Id: "client"},
+		TimeStamp:         1546300860,
+		Latitude:          -26.2042,
+		Longitude:         28.0474,
+	},
+	{
+		DeviceId:       id.Identifier{Id: "device2"},
+		OwnerPartyType: party.Company,
+		OwnerId:        id.Identifier{Id: "company"},
+		TimeStamp:      1546300800,
+		Latitude:       -33.9249,
+		Longitude:      18.4241,
+	},
+}
+
+var expectedRows = [][]string{
+	columnHeaders,
+	{"device<1>", "2019-01-01T00:00:00Z", "1546300800", "-26.2041", "28.0473", "Company", "company", "", ""},
+	{"device<1>", "2019-01-01T00:01:00Z", "1546300860", "-26.2042", "28.0474", "Company", "company", "Client", "client"},
+	{"device2", "2019-01-01T00:00:00Z", "1546300800", "-33.9249", "18.4241", "Company", "company", "", ""},
+}
+
+// writeReadings writes the test readings with the given writer
+func writeReadings(t *testing.T, readingWriter formatWriter) {
+	if err := readingWriter.begin(); err != nil {
+		t.Fatal(err)
+	}
+	for _, reading := range testReadings {
+		if err := readingWriter.write(reading); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := readingWriter.end(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestCSVWriter(t *testing.T) {
+	var export bytes.Buffer
+	writeReadings(t, newCSVWriter(&export))
+
+	rows, err := csv.NewReader(&export).ReadAll()
+	if assert.NoError(t, err) {
+		assert.Equal(t, expectedRows, rows)
+	}
+}
+
+func TestXLSXWriter(t *testing.T) {
+	var export bytes.Buffer
+	writeReadings(t, newXLSXWriter(&export))
+
+	file, err := excelize.OpenReader(&export)
+	if !assert.NoError(t, err) {
+		return
+	}
+	rows, err := file.GetRows("Sheet1")
+	if !assert.NoError(t, err) || !assert.Len(t, rows, len(expectedRows)) {
+		return
+	}
+	for rowIdx := range expectedRows {
+		// trailing empty cells are not read back
+		expectedRow := expectedRows[rowIdx]
+		for len(expectedRow) > 0 && expectedRow[len(expectedRow)-1] == "" {
+			expectedRow = expectedRow[:len(expectedRow)-1]
+		}
+		assert.Equal(t, expectedRow, rows[rowIdx])
+	}
+}
+
+func TestGPXWriter(t *testing.T) {
+	var export bytes.Buffer
+	writeReadings(t, newGPXWriter(&export))
+
+	var gpx struct {
+		Tracks []struct {
+			Name   string `xml:"name"`
+			Points []struct {
+				Latitude  string `xml:"lat,attr"`
+				Longitude string `xml:"lon,attr"`
+				Time      string `xml:"time"`
+			} `xml:"trkseg>trkpt"`
+		} `xml:"trk"`
+	}
+	if !assert.NoError(t, xml.Unmarshal(export.Bytes(), &gpx)) {
+		return
+	}
+
+	// a track per device
+	if !assert.Len(t, gpx.Tracks, 2) {
+		return
+	}
+	assert.Equal(t, "device<1>", gpx.Tracks[0].Name)
+	if assert.Len(t, gpx.Tracks[0].Points, 2) {
+		assert.Equal(t, "-26.2041", gpx.Tracks[0].Points[0].Latitude)
+		assert.Equal(t, "28.0473", gpx.Tracks[0].Points[0].Longitude)
+		assert.Equal(t, "2019-01-01T00:00:00Z", gpx.Tracks[0].Points[0].Time)
+		assert.Equal(t, "2019-01-01T00:01:00Z", gpx.Tracks[0].Points[1].Time)
+	}
+	assert.Equal(t, "device2", gpx.Tracks[1].Name)
+	assert.Len(t, gpx.Tracks[1].Points, 1)
+}
+
+func TestKMLWriter(t *testing.T) {
+	var export bytes.Buffer
+	writeReadings(t, newKMLWriter(&export))
+
+	var kml struct {
+		Placemarks []struct {
+			Name        string `xml:"name"`
+			Coordinates string `xml:"LineString>coordinates"`
+		} `xml:"Document>Placemark"`
+	}
+	if !assert.NoError(t, xml.Unmarshal(export.Bytes(), &kml)) {
+		return
+	}
+
+	// a line per device, with coordinates given as longitude then latitude
+	if !assert.Len(t, kml.Placemarks, 2) {
+		return
+	}
+	assert.Equal(t, "device<1>", kml.Placemarks[0].Name)
+	assert.Equal(t, "\n28.0473,-26.2041\n28.0474,-26.2042\n", kml.Placemarks[0].Coordinates)
+	assert.Equal(t, "device2", kml.Placemarks[1].Name)
+	assert.Equal(t, "\n18.4241,-33.9249\n", kml.Placemarks[1].Coordinates)
+}
+
+func TestXMLTrackWriterWithoutReadings(t *testing.T) {
+	var export bytes.Buffer
+	gpxWriter := newGPXWriter(&export)
+	if assert.NoError(t, gpxWriter.begin()) && assert.NoError(t, gpxWriter.end()) {
+		assert.Equal(t, xml.Header+
+			`<gpx version="1.1" creator="iot-my-world brain" xmlns="http://www.topografix.com/GPX/1/1">`+"\n"+
+			"</gpx>\n",
+			export.String(),
+		)
+	}
+}
@@ -0,0 +1,21 @@
+package exception
+
+import (
+	"strings"
+)
+
+type ReadingCollection struct {
+	Reasons []string
+}
+
+func (e ReadingCollection) Error() string {
+	return "error collecting gps readings for export: " + strings.Join(e.Reasons, "; ")
+}
+
+type Write struct {
+	Reasons []string
+}
+
+func (e Write) Error() string {
+	return "error writing gps reading export: " + strings.Join(e.Reasons, "; ")
+}
@@ -0,0 +1,74 @@
+package exporter
+
+import (
+	"github.com/iot-my-world/brain/pkg/search/criterion"
+	"github.com/iot-my-world/brain/pkg/search/query"
+	"github.com/iot-my-world/brain/pkg/security/claims"
+	"github.com/iot-my-world/brain/pkg/security/permission/api"
+	"io"
+)
+
+type Exporter interface {
+	Export(request *ExportRequest) (*ExportResponse, error)
+}
+
+const ServiceProvider = "SigbugGPSReading-Exporter"
+const ExportService = ServiceProvider + ".Export"
+
+var SystemUserPermissions = make([]api.Permission, 0)
+
+var CompanyAdminUserPermissions = []api.Permission{
+	ExportService,
+}
+
+var CompanyUserPermissions = []api.Permission{
+	ExportService,
+}
+
+var ClientAdminUserPermissions = []api.Permission{
+	ExportService,
+}
+
+var ClientUserPermissions = []api.Permission{
+	ExportService,
+}
+
+type Format string
+
+const CSV Format = "csv"
+const XLSX Format = "xlsx"
+const GPX Format = "gpx"
+const KML Format = "kml"
+
+// ContentType returns the media type of files in the format
+func (f Format) ContentType() string {
+	switch f {
+	case CSV:
+		return "text/csv"
+	case XLSX:
+		return "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	case GPX:
+		return "application/gpx+xml"
+	case KML:
+		return "application/vnd.google-earth.kml+xml"
+	default:
+		return "application/octet-stream"
+	}
+}
+
+type ExportRequest struct {
+	Claims   claims.Claims
+	Criteria []criterion.Criterion
+	// Query limits and offsets the readings exported.
+	// GPX and KML exports are always sorted by device and
+	// time so that the readings of each device form a track.
+	Query  query.Query
+	Format Format
+	// Writer is the writer to which the export is streamed
+	Writer io.Writer
+}
+
+type ExportResponse struct {
+	// Total is the number of readings exported
+	Total int
+}
@@ -24,12 +24,40 @@ type Reading struct {
 	TimeStamp int64         `json:"timeStamp" bson:"timeStamp"`
 	Latitude  float32       `json:"latitude" bson:"latitude"`
 	Longitude float32       `json:"longitude" bson:"longitude"`
+
+	// Location is the position of the reading as a GeoJSON point so that
+	// readings may be queried by position. It is nil if the latitude and
+	// longitude of the reading are not a valid position or are both 0.
+	Location *Location `json:"location" bson:"location,omitempty"`
+}
+
+// Location is a GeoJSON point
+type Location struct {
+	Type string `json:"type" bson:"type"`
+	// Coordinates are the longitude and latitude of the point, in that order
+	Coordinates []float64 `json:"coordinates" bson:"coordinates"`
 }
 
 func (r *Reading) SetId(id string) {
 	r.Id = id
 }
 
+// SetLocation sets the location of the reading from its latitude and longitude.
+// A latitude and longitude of 0 is given by devices without a fix and so
+// is taken to be no location.
+func (r *Reading) SetLocation() {
+	if math.IsNaN(float64(r.Latitude)) || r.Latitude < -90 || r.Latitude > 90 ||
+		math.IsNaN(float64(r.Longitude)) || r.Longitude < -180 || r.Longitude > 180 ||
+		(r.Latitude == 0 && r.Longitude == 0) {
+		r.Location = nil
+		return
+	}
+	r.Location = &Location{
+		Type:        "Point",
+		Coordinates: []float64{float64(r.Longitude), float64(r.Latitude)},
+	}
+}
+
 func IsValidIdentifier(id identifier.Identifier) bool {
 	if id == nil {
 		return false
@@ -59,3 +87,13 @@ func DifferenceBetween(r1, r2 *Reading) float32 {
 	var d = earthRadiusInKm * c
 	return float32(d * 1000)
 }
+
+// SpeedBetween returns the average speed in km/h at which a device
+// moved from the position of r1 to the position of r2
+func SpeedBetween(r1, r2 *Reading) float32 {
+	seconds := r2.TimeStamp - r1.TimeStamp
+	if seconds <= 0 {
+		return 0
+	}
+	return DifferenceBetween(r1, r2) / float32(seconds) * 3.6
+}
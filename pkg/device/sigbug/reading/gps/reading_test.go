@@ -0,0 +1,60 @@
+package gps
+
+import (
+	"github.com/stretchr/testify/assert"
+	"math"
+	"testing"
+)
+
+func TestSetLocation(t *testing.T) {
+	tests := []struct {
+		name             string
+		latitude         float32
+		longitude        float32
+		expectedLocation *Location
+	}{
+		{
+			name:      "valid position",
+			latitude:  -26.5,
+			longitude: 28.25,
+			expectedLocation: &Location{
+				Type:        "Point",
+				Coordinates: []float64{28.25, -26.5},
+			},
+		},
+		{
+			name:      "on the equator",
+			latitude:  0,
+			longitude: 28.25,
+			expectedLocation: &Location{
+				Type:        "Point",
+				Coordinates: []float64{28.25, 0},
+			},
+		},
+		{
+			name:      "no fix",
+			latitude:  0,
+			longitude: 0,
+		},
+		{
+			name:      "latitude out of range",
+			latitude:  91,
+			longitude: 28.25,
+		},
+		{
+			name:      "longitude not a number",
+			latitude:  -26.5,
+			longitude: float32(math.NaN()),
+		},
+	}
+
+	for _, test := range tests {
+		reading := Reading{
+			Latitude:  test.latitude,
+			Longitude: test.longitude,
+			Location:  &Location{Type: "Point", Coordinates: []float64{1, 1}},
+		}
+		reading.SetLocation()
+		assert.Equal(t, test.expectedLocation, reading.Location, test.name)
+	}
+}
@@ -22,6 +22,16 @@ func (e Create) Error() string {
 	return "reading creation error: " + strings.Join(e.Reasons, "; ")
 }
 
+// Duplicate is returned when a reading has already
+// been recorded for the message from which it was read
+type Duplicate struct {
+	Reasons []string
+}
+
+func (e Duplicate) Error() string {
+	return "reading already recorded: " + strings.Join(e.Reasons, "; ")
+}
+
 type Retrieve struct {
 	Reasons []string
 }
@@ -45,11 +45,20 @@ func (r *RecordHandler) Create(request *sigbugGPSReadingRecordHandler.CreateRequ
 		return nil, err
 	}
 
+	// the location is kept consistent with the latitude and longitude
+	// so that readings can be queried by position
+	request.Reading.SetLocation()
+
 	createResponse := brainRecordHandler.CreateResponse{}
 	if err := r.sigbugGPSReadingRecordHandler.Create(&brainRecordHandler.CreateRequest{
 		Entity: &request.Reading,
 	}, &createResponse); err != nil {
-		return nil, sigbugGPSReadingRecordHandlerException.Create{Reasons: []string{err.Error()}}
+		switch err.(type) {
+		case brainRecordHandlerException.Duplicate:
+			return nil, sigbugGPSReadingRecordHandlerException.Duplicate{Reasons: []string{err.Error()}}
+		default:
+			return nil, sigbugGPSReadingRecordHandlerException.Create{Reasons: []string{err.Error()}}
+		}
 	}
 	createdReading, ok := createResponse.Entity.(*sigbugGPSReading.Reading)
 	if !ok {
@@ -84,6 +93,8 @@ func (r *RecordHandler) Retrieve(request *sigbugGPSReadingRecordHandler.Retrieve
 }
 
 func (r *RecordHandler) Update(request *sigbugGPSReadingRecordHandler.UpdateRequest) (*sigbugGPSReadingRecordHandler.UpdateResponse, error) {
+	request.Reading.SetLocation()
+
 	updateResponse := brainRecordHandler.UpdateResponse{}
 	if err := r.sigbugGPSReadingRecordHandler.Update(&brainRecordHandler.UpdateRequest{
 		Claims:     request.Claims,
@@ -114,9 +125,10 @@ func (r *RecordHandler) Collect(request *sigbugGPSReadingRecordHandler.CollectRe
 		Records: &collectedReading,
 	}
 	err := r.sigbugGPSReadingRecordHandler.Collect(&brainRecordHandler.CollectRequest{
-		Claims:   request.Claims,
-		Criteria: request.Criteria,
-		Query:    request.Query,
+		Claims:    request.Claims,
+		Criteria:  request.Criteria,
+		Query:     request.Query,
+		SkipCount: request.SkipCount,
 	}, &collectResponse)
 	if err != nil {
 		return nil, sigbugGPSReadingRecordHandlerException.Collect{Reasons: []string{err.Error()}}
@@ -131,3 +143,17 @@ func (r *RecordHandler) Collect(request *sigbugGPSReadingRecordHandler.CollectRe
 		Total:   collectResponse.Total,
 	}, nil
 }
+
+func (r *RecordHandler) DeleteAll(request *sigbugGPSReadingRecordHandler.DeleteAllRequest) (*sigbugGPSReadingRecordHandler.DeleteAllResponse, error) {
+	deleteAllResponse := brainRecordHandler.DeleteAllResponse{}
+	if err := r.sigbugGPSReadingRecordHandler.DeleteAll(&brainRecordHandler.DeleteAllRequest{
+		Claims:   request.Claims,
+		Criteria: request.Criteria,
+	}, &deleteAllResponse); err != nil {
+		return nil, sigbugGPSReadingRecordHandlerException.Delete{Reasons: []string{err.Error()}}
+	}
+
+	return &sigbugGPSReadingRecordHandler.DeleteAllResponse{
+		NoDeleted: deleteAllResponse.NoDeleted,
+	}, nil
+}
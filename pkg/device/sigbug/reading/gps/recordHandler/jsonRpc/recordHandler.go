@@ -86,3 +86,7 @@ func (r *recordHandler) Collect(request *sigbugGPSReadingRecordHandler.CollectRe
 		Total:   collectResponse.Total,
 	}, nil
 }
+
+func (r *recordHandler) DeleteAll(request *sigbugGPSReadingRecordHandler.DeleteAllRequest) (*sigbugGPSReadingRecordHandler.DeleteAllResponse, error) {
+	return nil, brainException.NotImplemented{}
+}
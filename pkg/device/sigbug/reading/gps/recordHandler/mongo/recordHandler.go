@@ -23,6 +23,15 @@ func New(
 				Key:    []string{"id"},
 				Unique: true,
 			},
+			{
+				// a message is read once, and so the readings from messages
+				// which are handled again are recognised as duplicates
+				Key:    []string{"messageId.id"},
+				Unique: true,
+			},
+			{
+				Key: []string{"$2dsphere:location"},
+			},
 		},
 		sigbugGPSReading.IsValidIdentifier,
 		claims.ContextualiseFilter,
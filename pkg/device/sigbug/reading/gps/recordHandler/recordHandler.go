@@ -15,6 +15,7 @@ type RecordHandler interface {
 	Update(*UpdateRequest) (*UpdateResponse, error)
 	Delete(*DeleteRequest) (*DeleteResponse, error)
 	Collect(*CollectRequest) (*CollectResponse, error)
+	DeleteAll(*DeleteAllRequest) (*DeleteAllResponse, error)
 }
 
 const ServiceProvider = "SigbugGPSReading-RecordHandler"
@@ -83,9 +84,21 @@ type CollectRequest struct {
 	Claims   claims.Claims
 	Criteria []criterion.Criterion
 	Query    query.Query
+	// SkipCount leaves the total uncounted when it is not needed
+	SkipCount bool
 }
 
 type CollectResponse struct {
 	Records []sigbugGPSReading.Reading
 	Total   int
 }
+
+// DeleteAllRequest deletes all readings matching the criteria
+type DeleteAllRequest struct {
+	Claims   claims.Claims
+	Criteria []criterion.Criterion
+}
+
+type DeleteAllResponse struct {
+	NoDeleted int
+}
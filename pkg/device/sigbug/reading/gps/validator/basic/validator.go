@@ -73,6 +73,15 @@ func (v *validator) Validate(request *sigbugGPSReadingValidator.ValidateRequest)
 		})
 	}
 
+	if (*gpsReadingToValidate).MessageId.Id == "" {
+		allReasonsInvalid = append(allReasonsInvalid, reasonInvalid.ReasonInvalid{
+			Field: "messageId",
+			Type:  reasonInvalid.Blank,
+			Help:  "cannot be blank",
+			Data:  (*gpsReadingToValidate).MessageId,
+		})
+	}
+
 	if (*gpsReadingToValidate).DeviceId.Id == "" {
 		allReasonsInvalid = append(allReasonsInvalid, reasonInvalid.ReasonInvalid{
 			Field: "deviceId",
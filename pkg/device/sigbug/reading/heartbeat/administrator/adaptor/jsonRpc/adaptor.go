@@ -0,0 +1,56 @@
+package jsonRpc
+
+import (
+	"github.com/iot-my-world/brain/internal/log"
+	jsonRpcServiceProvider "github.com/iot-my-world/brain/pkg/api/jsonRpc/service/provider"
+	sigbugHeartbeatReading "github.com/iot-my-world/brain/pkg/device/sigbug/reading/heartbeat"
+	sigbugHeartbeatReadingAdministrator "github.com/iot-my-world/brain/pkg/device/sigbug/reading/heartbeat/administrator"
+	wrappedClaims "github.com/iot-my-world/brain/pkg/security/claims/wrapped"
+	"net/http"
+)
+
+type adaptor struct {
+	sigbugHeartbeatReadingAdministrator sigbugHeartbeatReadingAdministrator.Administrator
+}
+
+func New(administrator sigbugHeartbeatReadingAdministrator.Administrator) *adaptor {
+	return &adaptor{
+		sigbugHeartbeatReadingAdministrator: administrator,
+	}
+}
+
+func (a *adaptor) Name() jsonRpcServiceProvider.Name {
+	return sigbugHeartbeatReadingAdministrator.ServiceProvider
+}
+
+func (a *adaptor) MethodRequiresAuthorization(string) bool {
+	return true
+}
+
+type CreateRequest struct {
+	Reading sigbugHeartbeatReading.Reading `json:"reading"`
+}
+
+type CreateResponse struct {
+	Reading sigbugHeartbeatReading.Reading `json:"reading"`
+}
+
+func (a *adaptor) Create(r *http.Request, request *CreateRequest, response *CreateResponse) error {
+	claims, err := wrappedClaims.UnwrapClaimsFromContext(r)
+	if err != nil {
+		log.Warn(err.Error())
+		return err
+	}
+
+	createResponse, err := a.sigbugHeartbeatReadingAdministrator.Create(&sigbugHeartbeatReadingAdministrator.CreateRequest{
+		Claims:  claims,
+		Reading: request.Reading,
+	})
+	if err != nil {
+		return err
+	}
+
+	response.Reading = createResponse.Reading
+
+	return nil
+}
@@ -0,0 +1,79 @@
+package basic
+
+import (
+	"fmt"
+	brainException "github.com/iot-my-world/brain/internal/exception"
+	sigbugHeartbeatReadingAction "github.com/iot-my-world/brain/pkg/device/sigbug/reading/heartbeat/action"
+	sigbugHeartbeatReadingAdministrator "github.com/iot-my-world/brain/pkg/device/sigbug/reading/heartbeat/administrator"
+	sigbugHeartbeatReadingAdministratorException "github.com/iot-my-world/brain/pkg/device/sigbug/reading/heartbeat/administrator/exception"
+	sigbugHeartbeatReadingRecordHandler "github.com/iot-my-world/brain/pkg/device/sigbug/reading/heartbeat/recordHandler"
+	sigbugHeartbeatReadingRecordHandlerException "github.com/iot-my-world/brain/pkg/device/sigbug/reading/heartbeat/recordHandler/exception"
+	sigbugHeartbeatReadingValidator "github.com/iot-my-world/brain/pkg/device/sigbug/reading/heartbeat/validator"
+)
+
+type administrator struct {
+	sigfoxBackendDataCallbackReadingValidator sigbugHeartbeatReadingValidator.Validator
+	sigbugHeartbeatReadingRecordHandler       sigbugHeartbeatReadingRecordHandler.RecordHandler
+}
+
+func New(
+	sigfoxBackendDataCallbackReadingValidator sigbugHeartbeatReadingValidator.Validator,
+	sigbugHeartbeatReadingRecordHandler sigbugHeartbeatReadingRecordHandler.RecordHandler,
+) sigbugHeartbeatReadingAdministrator.Administrator {
+	return &administrator{
+		sigfoxBackendDataCallbackReadingValidator: sigfoxBackendDataCallbackReadingValidator,
+		sigbugHeartbeatReadingRecordHandler:       sigbugHeartbeatReadingRecordHandler,
+	}
+}
+
+func (a *administrator) ValidateCreateRequest(request *sigbugHeartbeatReadingAdministrator.CreateRequest) error {
+	reasonsInvalid := make([]string, 0)
+
+	if request.Claims == nil {
+		reasonsInvalid = append(reasonsInvalid, "claims are nil")
+	} else {
+		sigfoxBackendDataCallbackReadingValidateResponse, err := a.sigfoxBackendDataCallbackReadingValidator.Validate(&sigbugHeartbeatReadingValidator.ValidateRequest{
+			Claims:  request.Claims,
+			Reading: request.Reading,
+			Action:  sigbugHeartbeatReadingAction.Create,
+		})
+		if err != nil {
+			reasonsInvalid = append(reasonsInvalid, "error validating reading reading: "+err.Error())
+		} else {
+			if len(sigfoxBackendDataCallbackReadingValidateResponse.ReasonsInvalid) > 0 {
+				for _, reason := range sigfoxBackendDataCallbackReadingValidateResponse.ReasonsInvalid {
+					reasonsInvalid = append(reasonsInvalid, fmt.Sprintf("reading invalid: %s - %s - %s", reason.Field, reason.Type, reason.Help))
+				}
+			}
+		}
+	}
+
+	if len(reasonsInvalid) > 0 {
+		return brainException.RequestInvalid{Reasons: reasonsInvalid}
+	}
+
+	return nil
+}
+
+func (a *administrator) Create(request *sigbugHeartbeatReadingAdministrator.CreateRequest) (*sigbugHeartbeatReadingAdministrator.CreateResponse, error) {
+	if err := a.ValidateCreateRequest(request); err != nil {
+		return nil, err
+	}
+
+	createResponse, err := a.sigbugHeartbeatReadingRecordHandler.Create(&sigbugHeartbeatReadingRecordHandler.CreateRequest{
+		Reading: request.Reading,
+	})
+	if err != nil {
+		switch err.(type) {
+		case sigbugHeartbeatReadingRecordHandlerException.Duplicate:
+			// duplicate readings are reported as such so that they can be ignored
+			return nil, err
+		default:
+			return nil, sigbugHeartbeatReadingAdministratorException.DeviceCreation{Reasons: []string{err.Error()}}
+		}
+	}
+
+	return &sigbugHeartbeatReadingAdministrator.CreateResponse{
+		Reading: createResponse.Reading,
+	}, nil
+}
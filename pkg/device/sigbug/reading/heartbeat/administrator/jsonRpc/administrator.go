@@ -0,0 +1,50 @@
+package jsonRpc
+
+import (
+	brainException "github.com/iot-my-world/brain/internal/exception"
+	"github.com/iot-my-world/brain/internal/log"
+	jsonRpcClient "github.com/iot-my-world/brain/pkg/api/jsonRpc/client"
+	sigbugHeartbeatReadingAdministrator "github.com/iot-my-world/brain/pkg/device/sigbug/reading/heartbeat/administrator"
+	sigbugHeartbeatReadingAdministratorJsonRpcAdaptor "github.com/iot-my-world/brain/pkg/device/sigbug/reading/heartbeat/administrator/adaptor/jsonRpc"
+)
+
+type administrator struct {
+	jsonRpcClient jsonRpcClient.Client
+}
+
+func New(
+	jsonRpcClient jsonRpcClient.Client,
+) sigbugHeartbeatReadingAdministrator.Administrator {
+	return &administrator{
+		jsonRpcClient: jsonRpcClient,
+	}
+}
+
+func (a *administrator) ValidateCreateRequest(request *sigbugHeartbeatReadingAdministrator.CreateRequest) error {
+	reasonsInvalid := make([]string, 0)
+
+	if len(reasonsInvalid) > 0 {
+		return brainException.RequestInvalid{Reasons: reasonsInvalid}
+	}
+	return nil
+}
+
+func (a *administrator) Create(request *sigbugHeartbeatReadingAdministrator.CreateRequest) (*sigbugHeartbeatReadingAdministrator.CreateResponse, error) {
+	if err := a.ValidateCreateRequest(request); err != nil {
+		return nil, err
+	}
+
+	messageCreateResponse := sigbugHeartbeatReadingAdministratorJsonRpcAdaptor.CreateResponse{}
+	if err := a.jsonRpcClient.JsonRpcRequest(
+		sigbugHeartbeatReadingAdministrator.CreateService,
+		sigbugHeartbeatReadingAdministratorJsonRpcAdaptor.CreateRequest{
+			Reading: request.Reading,
+		},
+		&messageCreateResponse,
+	); err != nil {
+		log.Error(err.Error())
+		return nil, err
+	}
+
+	return &sigbugHeartbeatReadingAdministrator.CreateResponse{Reading: messageCreateResponse.Reading}, nil
+}
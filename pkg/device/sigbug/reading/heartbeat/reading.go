@@ -0,0 +1,42 @@
+package heartbeat
+
+import (
+	"github.com/iot-my-world/brain/pkg/party"
+	"github.com/iot-my-world/brain/pkg/search/identifier"
+	"github.com/iot-my-world/brain/pkg/search/identifier/id"
+)
+
+// Reading is a periodic message from a sigbug indicating that it is alive
+type Reading struct {
+	Id string `json:"id" bson:"id"`
+
+	// Device Details
+	DeviceId id.Identifier `json:"deviceId" bson:"deviceId"`
+
+	// Owner Details
+	OwnerPartyType    party.Type    `json:"ownerPartyType" bson:"ownerPartyType"`
+	OwnerId           id.Identifier `json:"ownerId" bson:"ownerId"`
+	AssignedPartyType party.Type    `json:"assignedPartyType" bson:"assignedPartyType"`
+	AssignedId        id.Identifier `json:"assignedId" bson:"assignedId"`
+
+	// Reading Details
+	MessageId id.Identifier `json:"messageId" bson:"messageId"`
+	TimeStamp int64         `json:"timeStamp" bson:"timeStamp"`
+}
+
+func (r *Reading) SetId(id string) {
+	r.Id = id
+}
+
+func IsValidIdentifier(id identifier.Identifier) bool {
+	if id == nil {
+		return false
+	}
+
+	switch id.Type() {
+	case identifier.Id:
+		return true
+	default:
+		return false
+	}
+}
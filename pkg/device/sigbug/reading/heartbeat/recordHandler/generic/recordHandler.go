@@ -0,0 +1,138 @@
+package sigbugHeartbeatReadingRecordHandler
+
+import (
+	brainException "github.com/iot-my-world/brain/internal/exception"
+	sigbugHeartbeatReading "github.com/iot-my-world/brain/pkg/device/sigbug/reading/heartbeat"
+	sigbugHeartbeatReadingRecordHandler "github.com/iot-my-world/brain/pkg/device/sigbug/reading/heartbeat/recordHandler"
+	sigbugHeartbeatReadingRecordHandlerException "github.com/iot-my-world/brain/pkg/device/sigbug/reading/heartbeat/recordHandler/exception"
+	brainRecordHandler "github.com/iot-my-world/brain/pkg/recordHandler"
+	brainRecordHandlerException "github.com/iot-my-world/brain/pkg/recordHandler/exception"
+)
+
+type RecordHandler struct {
+	sigbugHeartbeatReadingRecordHandler brainRecordHandler.RecordHandler
+}
+
+func New(
+	brainReadingRecordHandler brainRecordHandler.RecordHandler,
+) sigbugHeartbeatReadingRecordHandler.RecordHandler {
+
+	return &RecordHandler{
+		sigbugHeartbeatReadingRecordHandler: brainReadingRecordHandler,
+	}
+}
+
+type CreateRequest struct {
+	Reading sigbugHeartbeatReading.Reading
+}
+
+type CreateResponse struct {
+	Reading sigbugHeartbeatReading.Reading
+}
+
+func (r *RecordHandler) ValidateCreateRequest(request *sigbugHeartbeatReadingRecordHandler.CreateRequest) error {
+	reasonsInvalid := make([]string, 0)
+
+	if len(reasonsInvalid) > 0 {
+		return brainException.RequestInvalid{Reasons: reasonsInvalid}
+	}
+
+	return nil
+}
+
+func (r *RecordHandler) Create(request *sigbugHeartbeatReadingRecordHandler.CreateRequest) (*sigbugHeartbeatReadingRecordHandler.CreateResponse, error) {
+	if err := r.ValidateCreateRequest(request); err != nil {
+		return nil, err
+	}
+
+	createResponse := brainRecordHandler.CreateResponse{}
+	if err := r.sigbugHeartbeatReadingRecordHandler.Create(&brainRecordHandler.CreateRequest{
+		Entity: &request.Reading,
+	}, &createResponse); err != nil {
+		switch err.(type) {
+		case brainRecordHandlerException.Duplicate:
+			return nil, sigbugHeartbeatReadingRecordHandlerException.Duplicate{Reasons: []string{err.Error()}}
+		default:
+			return nil, sigbugHeartbeatReadingRecordHandlerException.Create{Reasons: []string{err.Error()}}
+		}
+	}
+	createdReading, ok := createResponse.Entity.(*sigbugHeartbeatReading.Reading)
+	if !ok {
+		return nil, sigbugHeartbeatReadingRecordHandlerException.Create{Reasons: []string{"could not cast created entity to message"}}
+	}
+
+	return &sigbugHeartbeatReadingRecordHandler.CreateResponse{
+		Reading: *createdReading,
+	}, nil
+}
+
+func (r *RecordHandler) Retrieve(request *sigbugHeartbeatReadingRecordHandler.RetrieveRequest) (*sigbugHeartbeatReadingRecordHandler.RetrieveResponse, error) {
+	retrievedReading := sigbugHeartbeatReading.Reading{}
+	retrieveResponse := brainRecordHandler.RetrieveResponse{
+		Entity: &retrievedReading,
+	}
+	if err := r.sigbugHeartbeatReadingRecordHandler.Retrieve(&brainRecordHandler.RetrieveRequest{
+		Claims:     request.Claims,
+		Identifier: request.Identifier,
+	}, &retrieveResponse); err != nil {
+		switch err.(type) {
+		case brainRecordHandlerException.NotFound:
+			return nil, sigbugHeartbeatReadingRecordHandlerException.NotFound{}
+		default:
+			return nil, err
+		}
+	}
+
+	return &sigbugHeartbeatReadingRecordHandler.RetrieveResponse{
+		Reading: retrievedReading,
+	}, nil
+}
+
+func (r *RecordHandler) Update(request *sigbugHeartbeatReadingRecordHandler.UpdateRequest) (*sigbugHeartbeatReadingRecordHandler.UpdateResponse, error) {
+	updateResponse := brainRecordHandler.UpdateResponse{}
+	if err := r.sigbugHeartbeatReadingRecordHandler.Update(&brainRecordHandler.UpdateRequest{
+		Claims:     request.Claims,
+		Identifier: request.Identifier,
+		Entity:     &request.Reading,
+	}, &updateResponse); err != nil {
+		return nil, sigbugHeartbeatReadingRecordHandlerException.Update{Reasons: []string{err.Error()}}
+	}
+
+	return &sigbugHeartbeatReadingRecordHandler.UpdateResponse{}, nil
+}
+
+func (r *RecordHandler) Delete(request *sigbugHeartbeatReadingRecordHandler.DeleteRequest) (*sigbugHeartbeatReadingRecordHandler.DeleteResponse, error) {
+	deleteResponse := brainRecordHandler.DeleteResponse{}
+	if err := r.sigbugHeartbeatReadingRecordHandler.Delete(&brainRecordHandler.DeleteRequest{
+		Claims:     request.Claims,
+		Identifier: request.Identifier,
+	}, &deleteResponse); err != nil {
+		return nil, sigbugHeartbeatReadingRecordHandlerException.Delete{Reasons: []string{err.Error()}}
+	}
+
+	return &sigbugHeartbeatReadingRecordHandler.DeleteResponse{}, nil
+}
+
+func (r *RecordHandler) Collect(request *sigbugHeartbeatReadingRecordHandler.CollectRequest) (*sigbugHeartbeatReadingRecordHandler.CollectResponse, error) {
+	var collectedReading []sigbugHeartbeatReading.Reading
+	collectResponse := brainRecordHandler.CollectResponse{
+		Records: &collectedReading,
+	}
+	err := r.sigbugHeartbeatReadingRecordHandler.Collect(&brainRecordHandler.CollectRequest{
+		Claims:   request.Claims,
+		Criteria: request.Criteria,
+		Query:    request.Query,
+	}, &collectResponse)
+	if err != nil {
+		return nil, sigbugHeartbeatReadingRecordHandlerException.Collect{Reasons: []string{err.Error()}}
+	}
+
+	if collectedReading == nil {
+		collectedReading = make([]sigbugHeartbeatReading.Reading, 0)
+	}
+
+	return &sigbugHeartbeatReadingRecordHandler.CollectResponse{
+		Records: collectedReading,
+		Total:   collectResponse.Total,
+	}, nil
+}
@@ -0,0 +1,88 @@
+package jsonRpc
+
+import (
+	brainException "github.com/iot-my-world/brain/internal/exception"
+	"github.com/iot-my-world/brain/internal/log"
+	jsonRpcClient "github.com/iot-my-world/brain/pkg/api/jsonRpc/client"
+	sigbugHeartbeatReadingRecordHandler "github.com/iot-my-world/brain/pkg/device/sigbug/reading/heartbeat/recordHandler"
+	sigbugHeartbeatReadingRecordHandlerJsonRpcAdaptor "github.com/iot-my-world/brain/pkg/device/sigbug/reading/heartbeat/recordHandler/adaptor/jsonRpc"
+	wrappedCriterion "github.com/iot-my-world/brain/pkg/search/criterion/wrapped"
+)
+
+type recordHandler struct {
+	jsonRpcClient jsonRpcClient.Client
+}
+
+func New(
+	jsonRpcClient jsonRpcClient.Client,
+) sigbugHeartbeatReadingRecordHandler.RecordHandler {
+	return &recordHandler{
+		jsonRpcClient: jsonRpcClient,
+	}
+}
+
+func (r *recordHandler) Create(request *sigbugHeartbeatReadingRecordHandler.CreateRequest) (*sigbugHeartbeatReadingRecordHandler.CreateResponse, error) {
+	return nil, brainException.NotImplemented{}
+}
+
+func (r *recordHandler) Retrieve(request *sigbugHeartbeatReadingRecordHandler.RetrieveRequest) (*sigbugHeartbeatReadingRecordHandler.RetrieveResponse, error) {
+	return nil, brainException.NotImplemented{}
+}
+func (r *recordHandler) Update(request *sigbugHeartbeatReadingRecordHandler.UpdateRequest) (*sigbugHeartbeatReadingRecordHandler.UpdateResponse, error) {
+	return nil, brainException.NotImplemented{}
+}
+func (r *recordHandler) Delete(request *sigbugHeartbeatReadingRecordHandler.DeleteRequest) (*sigbugHeartbeatReadingRecordHandler.DeleteResponse, error) {
+	return nil, brainException.NotImplemented{}
+}
+
+func (r *recordHandler) ValidateCollectRequest(request *sigbugHeartbeatReadingRecordHandler.CollectRequest) error {
+	reasonsInvalid := make([]string, 0)
+
+	if request.Criteria == nil {
+		reasonsInvalid = append(reasonsInvalid, "criteria is nil")
+	} else {
+		for _, crit := range request.Criteria {
+			if crit == nil {
+				reasonsInvalid = append(reasonsInvalid, "a criterion is nil")
+			}
+		}
+	}
+
+	if len(reasonsInvalid) > 0 {
+		return brainException.RequestInvalid{Reasons: reasonsInvalid}
+	}
+	return nil
+}
+
+func (r *recordHandler) Collect(request *sigbugHeartbeatReadingRecordHandler.CollectRequest) (*sigbugHeartbeatReadingRecordHandler.CollectResponse, error) {
+	if err := r.ValidateCollectRequest(request); err != nil {
+		return nil, err
+	}
+
+	// wrap criteria
+	criteria := make([]wrappedCriterion.Wrapped, 0)
+	for _, crit := range request.Criteria {
+		wrapped, err := wrappedCriterion.Wrap(crit)
+		if err != nil {
+			log.Error(err.Error())
+			return nil, err
+		}
+		criteria = append(criteria, *wrapped)
+	}
+
+	collectResponse := sigbugHeartbeatReadingRecordHandlerJsonRpcAdaptor.CollectResponse{}
+	if err := r.jsonRpcClient.JsonRpcRequest(
+		sigbugHeartbeatReadingRecordHandler.CollectService,
+		sigbugHeartbeatReadingRecordHandlerJsonRpcAdaptor.CollectRequest{
+			Criteria: criteria,
+			Query:    request.Query,
+		},
+		&collectResponse); err != nil {
+		return nil, err
+	}
+
+	return &sigbugHeartbeatReadingRecordHandler.CollectResponse{
+		Records: collectResponse.Records,
+		Total:   collectResponse.Total,
+	}, nil
+}
@@ -0,0 +1,60 @@
+package jsonRpc
+
+import (
+	"github.com/iot-my-world/brain/internal/log"
+	"github.com/iot-my-world/brain/pkg/action"
+	jsonRpcServiceProvider "github.com/iot-my-world/brain/pkg/api/jsonRpc/service/provider"
+	sigbugHeartbeatReading "github.com/iot-my-world/brain/pkg/device/sigbug/reading/heartbeat"
+	sigbugHeartbeatReadingValidator "github.com/iot-my-world/brain/pkg/device/sigbug/reading/heartbeat/validator"
+	wrappedClaims "github.com/iot-my-world/brain/pkg/security/claims/wrapped"
+	"github.com/iot-my-world/brain/pkg/validate/reasonInvalid"
+	"net/http"
+)
+
+type adaptor struct {
+	sigbugHeartbeatReadingValidator sigbugHeartbeatReadingValidator.Validator
+}
+
+func New(sigbugHeartbeatReadingValidator sigbugHeartbeatReadingValidator.Validator) *adaptor {
+	return &adaptor{
+		sigbugHeartbeatReadingValidator: sigbugHeartbeatReadingValidator,
+	}
+}
+
+func (a *adaptor) Name() jsonRpcServiceProvider.Name {
+	return sigbugHeartbeatReadingValidator.ServiceProvider
+}
+
+func (a *adaptor) MethodRequiresAuthorization(string) bool {
+	return true
+}
+
+type ValidateRequest struct {
+	Reading sigbugHeartbeatReading.Reading `json:"reading"`
+	Action  action.Action                  `json:"action"`
+}
+
+type ValidateResponse struct {
+	ReasonsInvalid []reasonInvalid.ReasonInvalid `json:"reasonsInvalid"`
+}
+
+func (a *adaptor) Validate(r *http.Request, request *ValidateRequest, response *ValidateResponse) error {
+	claims, err := wrappedClaims.UnwrapClaimsFromContext(r)
+	if err != nil {
+		log.Warn(err.Error())
+		return err
+	}
+
+	validateReadingDeviceResponse, err := a.sigbugHeartbeatReadingValidator.Validate(&sigbugHeartbeatReadingValidator.ValidateRequest{
+		Claims:  claims,
+		Reading: request.Reading,
+		Action:  request.Action,
+	})
+	if err != nil {
+		return err
+	}
+
+	response.ReasonsInvalid = validateReadingDeviceResponse.ReasonsInvalid
+
+	return nil
+}
@@ -0,0 +1,5 @@
+package action
+
+import "github.com/iot-my-world/brain/pkg/action"
+
+const Create action.Action = "Create"
@@ -0,0 +1,56 @@
+package jsonRpc
+
+import (
+	"github.com/iot-my-world/brain/internal/log"
+	jsonRpcServiceProvider "github.com/iot-my-world/brain/pkg/api/jsonRpc/service/provider"
+	sigbugTemperatureReading "github.com/iot-my-world/brain/pkg/device/sigbug/reading/temperature"
+	sigbugTemperatureReadingAdministrator "github.com/iot-my-world/brain/pkg/device/sigbug/reading/temperature/administrator"
+	wrappedClaims "github.com/iot-my-world/brain/pkg/security/claims/wrapped"
+	"net/http"
+)
+
+type adaptor struct {
+	sigbugTemperatureReadingAdministrator sigbugTemperatureReadingAdministrator.Administrator
+}
+
+func New(administrator sigbugTemperatureReadingAdministrator.Administrator) *adaptor {
+	return &adaptor{
+		sigbugTemperatureReadingAdministrator: administrator,
+	}
+}
+
+func (a *adaptor) Name() jsonRpcServiceProvider.Name {
+	return sigbugTemperatureReadingAdministrator.ServiceProvider
+}
+
+func (a *adaptor) MethodRequiresAuthorization(string) bool {
+	return true
+}
+
+type CreateRequest struct {
+	Reading sigbugTemperatureReading.Reading `json:"reading"`
+}
+
+type CreateResponse struct {
+	Reading sigbugTemperatureReading.Reading `json:"reading"`
+}
+
+func (a *adaptor) Create(r *http.Request, request *CreateRequest, response *CreateResponse) error {
+	claims, err := wrappedClaims.UnwrapClaimsFromContext(r)
+	if err != nil {
+		log.Warn(err.Error())
+		return err
+	}
+
+	createResponse, err := a.sigbugTemperatureReadingAdministrator.Create(&sigbugTemperatureReadingAdministrator.CreateRequest{
+		Claims:  claims,
+		Reading: request.Reading,
+	})
+	if err != nil {
+		return err
+	}
+
+	response.Reading = createResponse.Reading
+
+	return nil
+}
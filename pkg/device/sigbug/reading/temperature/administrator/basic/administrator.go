@@ -0,0 +1,79 @@
+package basic
+
+import (
+	"fmt"
+	brainException "github.com/iot-my-world/brain/internal/exception"
+	sigbugTemperatureReadingAction "github.com/iot-my-world/brain/pkg/device/sigbug/reading/temperature/action"
+	sigbugTemperatureReadingAdministrator "github.com/iot-my-world/brain/pkg/device/sigbug/reading/temperature/administrator"
+	sigbugTemperatureReadingAdministratorException "github.com/iot-my-world/brain/pkg/device/sigbug/reading/temperature/administrator/exception"
+	sigbugTemperatureReadingRecordHandler "github.com/iot-my-world/brain/pkg/device/sigbug/reading/temperature/recordHandler"
+	sigbugTemperatureReadingRecordHandlerException "github.com/iot-my-world/brain/pkg/device/sigbug/reading/temperature/recordHandler/exception"
+	sigbugTemperatureReadingValidator "github.com/iot-my-world/brain/pkg/device/sigbug/reading/temperature/validator"
+)
+
+type administrator struct {
+	sigfoxBackendDataCallbackReadingValidator sigbugTemperatureReadingValidator.Validator
+	sigbugTemperatureReadingRecordHandler     sigbugTemperatureReadingRecordHandler.RecordHandler
+}
+
+func New(
+	sigfoxBackendDataCallbackReadingValidator sigbugTemperatureReadingValidator.Validator,
+	sigbugTemperatureReadingRecordHandler sigbugTemperatureReadingRecordHandler.RecordHandler,
+) sigbugTemperatureReadingAdministrator.Administrator {
+	return &administrator{
+		sigfoxBackendDataCallbackReadingValidator: sigfoxBackendDataCallbackReadingValidator,
+		sigbugTemperatureReadingRecordHandler:     sigbugTemperatureReadingRecordHandler,
+	}
+}
+
+func (a *administrator) ValidateCreateRequest(request *sigbugTemperatureReadingAdministrator.CreateRequest) error {
+	reasonsInvalid := make([]string, 0)
+
+	if request.Claims == nil {
+		reasonsInvalid = append(reasonsInvalid, "claims are nil")
+	} else {
+		sigfoxBackendDataCallbackReadingValidateResponse, err := a.sigfoxBackendDataCallbackReadingValidator.Validate(&sigbugTemperatureReadingValidator.ValidateRequest{
+			Claims:  request.Claims,
+			Reading: request.Reading,
+			Action:  sigbugTemperatureReadingAction.Create,
+		})
+		if err != nil {
+			reasonsInvalid = append(reasonsInvalid, "error validating reading reading: "+err.Error())
+		} else {
+			if len(sigfoxBackendDataCallbackReadingValidateResponse.ReasonsInvalid) > 0 {
+				for _, reason := range sigfoxBackendDataCallbackReadingValidateResponse.ReasonsInvalid {
+					reasonsInvalid = append(reasonsInvalid, fmt.Sprintf("reading invalid: %s - %s - %s", reason.Field, reason.Type, reason.Help))
+				}
+			}
+		}
+	}
+
+	if len(reasonsInvalid) > 0 {
+		return brainException.RequestInvalid{Reasons: reasonsInvalid}
+	}
+
+	return nil
+}
+
+func (a *administrator) Create(request *sigbugTemperatureReadingAdministrator.CreateRequest) (*sigbugTemperatureReadingAdministrator.CreateResponse, error) {
+	if err := a.ValidateCreateRequest(request); err != nil {
+		return nil, err
+	}
+
+	createResponse, err := a.sigbugTemperatureReadingRecordHandler.Create(&sigbugTemperatureReadingRecordHandler.CreateRequest{
+		Reading: request.Reading,
+	})
+	if err != nil {
+		switch err.(type) {
+		case sigbugTemperatureReadingRecordHandlerException.Duplicate:
+			// duplicate readings are reported as such so that they can be ignored
+			return nil, err
+		default:
+			return nil, sigbugTemperatureReadingAdministratorException.DeviceCreation{Reasons: []string{err.Error()}}
+		}
+	}
+
+	return &sigbugTemperatureReadingAdministrator.CreateResponse{
+		Reading: createResponse.Reading,
+	}, nil
+}
@@ -0,0 +1,50 @@
+package jsonRpc
+
+import (
+	brainException "github.com/iot-my-world/brain/internal/exception"
+	"github.com/iot-my-world/brain/internal/log"
+	jsonRpcClient "github.com/iot-my-world/brain/pkg/api/jsonRpc/client"
+	sigbugTemperatureReadingAdministrator "github.com/iot-my-world/brain/pkg/device/sigbug/reading/temperature/administrator"
+	sigbugTemperatureReadingAdministratorJsonRpcAdaptor "github.com/iot-my-world/brain/pkg/device/sigbug/reading/temperature/administrator/adaptor/jsonRpc"
+)
+
+type administrator struct {
+	jsonRpcClient jsonRpcClient.Client
+}
+
+func New(
+	jsonRpcClient jsonRpcClient.Client,
+) sigbugTemperatureReadingAdministrator.Administrator {
+	return &administrator{
+		jsonRpcClient: jsonRpcClient,
+	}
+}
+
+func (a *administrator) ValidateCreateRequest(request *sigbugTemperatureReadingAdministrator.CreateRequest) error {
+	reasonsInvalid := make([]string, 0)
+
+	if len(reasonsInvalid) > 0 {
+		return brainException.RequestInvalid{Reasons: reasonsInvalid}
+	}
+	return nil
+}
+
+func (a *administrator) Create(request *sigbugTemperatureReadingAdministrator.CreateRequest) (*sigbugTemperatureReadingAdministrator.CreateResponse, error) {
+	if err := a.ValidateCreateRequest(request); err != nil {
+		return nil, err
+	}
+
+	messageCreateResponse := sigbugTemperatureReadingAdministratorJsonRpcAdaptor.CreateResponse{}
+	if err := a.jsonRpcClient.JsonRpcRequest(
+		sigbugTemperatureReadingAdministrator.CreateService,
+		sigbugTemperatureReadingAdministratorJsonRpcAdaptor.CreateRequest{
+			Reading: request.Reading,
+		},
+		&messageCreateResponse,
+	); err != nil {
+		log.Error(err.Error())
+		return nil, err
+	}
+
+	return &sigbugTemperatureReadingAdministrator.CreateResponse{Reading: messageCreateResponse.Reading}, nil
+}
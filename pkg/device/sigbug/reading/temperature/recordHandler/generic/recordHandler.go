@@ -0,0 +1,138 @@
+package sigbugTemperatureReadingRecordHandler
+
+import (
+	brainException "github.com/iot-my-world/brain/internal/exception"
+	sigbugTemperatureReading "github.com/iot-my-world/brain/pkg/device/sigbug/reading/temperature"
+	sigbugTemperatureReadingRecordHandler "github.com/iot-my-world/brain/pkg/device/sigbug/reading/temperature/recordHandler"
+	sigbugTemperatureReadingRecordHandlerException "github.com/iot-my-world/brain/pkg/device/sigbug/reading/temperature/recordHandler/exception"
+	brainRecordHandler "github.com/iot-my-world/brain/pkg/recordHandler"
+	brainRecordHandlerException "github.com/iot-my-world/brain/pkg/recordHandler/exception"
+)
+
+type RecordHandler struct {
+	sigbugTemperatureReadingRecordHandler brainRecordHandler.RecordHandler
+}
+
+func New(
+	brainReadingRecordHandler brainRecordHandler.RecordHandler,
+) sigbugTemperatureReadingRecordHandler.RecordHandler {
+
+	return &RecordHandler{
+		sigbugTemperatureReadingRecordHandler: brainReadingRecordHandler,
+	}
+}
+
+type CreateRequest struct {
+	Reading sigbugTemperatureReading.Reading
+}
+
+type CreateResponse struct {
+	Reading sigbugTemperatureReading.Reading
+}
+
+func (r *RecordHandler) ValidateCreateRequest(request *sigbugTemperatureReadingRecordHandler.CreateRequest) error {
+	reasonsInvalid := make([]string, 0)
+
+	if len(reasonsInvalid) > 0 {
+		return brainException.RequestInvalid{Reasons: reasonsInvalid}
+	}
+
+	return nil
+}
+
+func (r *RecordHandler) Create(request *sigbugTemperatureReadingRecordHandler.CreateRequest) (*sigbugTemperatureReadingRecordHandler.CreateResponse, error) {
+	if err := r.ValidateCreateRequest(request); err != nil {
+		return nil, err
+	}
+
+	createResponse := brainRecordHandler.CreateResponse{}
+	if err := r.sigbugTemperatureReadingRecordHandler.Create(&brainRecordHandler.CreateRequest{
+		Entity: &request.Reading,
+	}, &createResponse); err != nil {
+		switch err.(type) {
+		case brainRecordHandlerException.Duplicate:
+			return nil, sigbugTemperatureReadingRecordHandlerException.Duplicate{Reasons: []string{err.Error()}}
+		default:
+			return nil, sigbugTemperatureReadingRecordHandlerException.Create{Reasons: []string{err.Error()}}
+		}
+	}
+	createdReading, ok := createResponse.Entity.(*sigbugTemperatureReading.Reading)
+	if !ok {
+		return nil, sigbugTemperatureReadingRecordHandlerException.Create{Reasons: []string{"could not cast created entity to message"}}
+	}
+
+	return &sigbugTemperatureReadingRecordHandler.CreateResponse{
+		Reading: *createdReading,
+	}, nil
+}
+
+func (r *RecordHandler) Retrieve(request *sigbugTemperatureReadingRecordHandler.RetrieveRequest) (*sigbugTemperatureReadingRecordHandler.RetrieveResponse, error) {
+	retrievedReading := sigbugTemperatureReading.Reading{}
+	retrieveResponse := brainRecordHandler.RetrieveResponse{
+		Entity: &retrievedReading,
+	}
+	if err := r.sigbugTemperatureReadingRecordHandler.Retrieve(&brainRecordHandler.RetrieveRequest{
+		Claims:     request.Claims,
+		Identifier: request.Identifier,
+	}, &retrieveResponse); err != nil {
+		switch err.(type) {
+		case brainRecordHandlerException.NotFound:
+			return nil, sigbugTemperatureReadingRecordHandlerException.NotFound{}
+		default:
+			return nil, err
+		}
+	}
+
+	return &sigbugTemperatureReadingRecordHandler.RetrieveResponse{
+		Reading: retrievedReading,
+	}, nil
+}
+
+func (r *RecordHandler) Update(request *sigbugTemperatureReadingRecordHandler.UpdateRequest) (*sigbugTemperatureReadingRecordHandler.UpdateResponse, error) {
+	updateResponse := brainRecordHandler.UpdateResponse{}
+	if err := r.sigbugTemperatureReadingRecordHandler.Update(&brainRecordHandler.UpdateRequest{
+		Claims:     request.Claims,
+		Identifier: request.Identifier,
+		Entity:     &request.Reading,
+	}, &updateResponse); err != nil {
+		return nil, sigbugTemperatureReadingRecordHandlerException.Update{Reasons: []string{err.Error()}}
+	}
+
+	return &sigbugTemperatureReadingRecordHandler.UpdateResponse{}, nil
+}
+
+func (r *RecordHandler) Delete(request *sigbugTemperatureReadingRecordHandler.DeleteRequest) (*sigbugTemperatureReadingRecordHandler.DeleteResponse, error) {
+	deleteResponse := brainRecordHandler.DeleteResponse{}
+	if err := r.sigbugTemperatureReadingRecordHandler.Delete(&brainRecordHandler.DeleteRequest{
+		Claims:     request.Claims,
+		Identifier: request.Identifier,
+	}, &deleteResponse); err != nil {
+		return nil, sigbugTemperatureReadingRecordHandlerException.Delete{Reasons: []string{err.Error()}}
+	}
+
+	return &sigbugTemperatureReadingRecordHandler.DeleteResponse{}, nil
+}
+
+func (r *RecordHandler) Collect(request *sigbugTemperatureReadingRecordHandler.CollectRequest) (*sigbugTemperatureReadingRecordHandler.CollectResponse, error) {
+	var collectedReading []sigbugTemperatureReading.Reading
+	collectResponse := brainRecordHandler.CollectResponse{
+		Records: &collectedReading,
+	}
+	err := r.sigbugTemperatureReadingRecordHandler.Collect(&brainRecordHandler.CollectRequest{
+		Claims:   request.Claims,
+		Criteria: request.Criteria,
+		Query:    request.Query,
+	}, &collectResponse)
+	if err != nil {
+		return nil, sigbugTemperatureReadingRecordHandlerException.Collect{Reasons: []string{err.Error()}}
+	}
+
+	if collectedReading == nil {
+		collectedReading = make([]sigbugTemperatureReading.Reading, 0)
+	}
+
+	return &sigbugTemperatureReadingRecordHandler.CollectResponse{
+		Records: collectedReading,
+		Total:   collectResponse.Total,
+	}, nil
+}
@@ -0,0 +1,40 @@
+package mongo
+
+import (
+	sigbugTemperatureReading "github.com/iot-my-world/brain/pkg/device/sigbug/reading/temperature"
+	sigbugTemperatureReadingRecordHandler "github.com/iot-my-world/brain/pkg/device/sigbug/reading/temperature/recordHandler"
+	sigbugTemperatureReadingGenericRecordHandler "github.com/iot-my-world/brain/pkg/device/sigbug/reading/temperature/recordHandler/generic"
+	brainMongoRecordHandler "github.com/iot-my-world/brain/pkg/recordHandler/mongo"
+	"github.com/iot-my-world/brain/pkg/security/claims"
+	"gopkg.in/mgo.v2"
+)
+
+func New(
+	mongoSession *mgo.Session,
+	databaseName string,
+	collectionName string,
+) sigbugTemperatureReadingRecordHandler.RecordHandler {
+	mongoRecordHandler := brainMongoRecordHandler.New(
+		mongoSession,
+		databaseName,
+		collectionName,
+		[]mgo.Index{
+			{
+				Key:    []string{"id"},
+				Unique: true,
+			},
+			{
+				// a message is read once, and so the readings from messages
+				// which are handled again are recognised as duplicates
+				Key:    []string{"messageId.id"},
+				Unique: true,
+			},
+		},
+		sigbugTemperatureReading.IsValidIdentifier,
+		claims.ContextualiseFilter,
+	)
+
+	return sigbugTemperatureReadingGenericRecordHandler.New(
+		mongoRecordHandler,
+	)
+}
@@ -0,0 +1,169 @@
+package validator
+
+import (
+	brainException "github.com/iot-my-world/brain/internal/exception"
+	"github.com/iot-my-world/brain/internal/log"
+	"github.com/iot-my-world/brain/pkg/action"
+	sigbugTemperatureReadingAction "github.com/iot-my-world/brain/pkg/device/sigbug/reading/temperature/action"
+	sigbugTemperatureReadingValidator "github.com/iot-my-world/brain/pkg/device/sigbug/reading/temperature/validator"
+	sigbugTemperatureReadingValidatorException "github.com/iot-my-world/brain/pkg/device/sigbug/reading/temperature/validator/exception"
+	sigbugRecordHandler "github.com/iot-my-world/brain/pkg/device/sigbug/recordHandler"
+	sigbugRecordHandlerException "github.com/iot-my-world/brain/pkg/device/sigbug/recordHandler/exception"
+	partyAdministrator "github.com/iot-my-world/brain/pkg/party/administrator"
+	humanUserLoginClaims "github.com/iot-my-world/brain/pkg/security/claims/login/user/human"
+	"github.com/iot-my-world/brain/pkg/validate/reasonInvalid"
+)
+
+type validator struct {
+	actionIgnoredReasons map[action.Action]reasonInvalid.IgnoredReasonsInvalid
+	systemClaims         *humanUserLoginClaims.Login
+	sigbugRecordHandler  sigbugRecordHandler.RecordHandler
+}
+
+func New(
+	sigbugRecordHandler sigbugRecordHandler.RecordHandler,
+	partyAdministrator partyAdministrator.Administrator,
+	systemClaims *humanUserLoginClaims.Login,
+) sigbugTemperatureReadingValidator.Validator {
+
+	actionIgnoredReasons := map[action.Action]reasonInvalid.IgnoredReasonsInvalid{
+		sigbugTemperatureReadingAction.Create: {
+			ReasonsInvalid: map[string][]reasonInvalid.Type{
+				"id": {
+					reasonInvalid.Blank,
+				},
+			},
+		},
+	}
+
+	return &validator{
+		sigbugRecordHandler:  sigbugRecordHandler,
+		actionIgnoredReasons: actionIgnoredReasons,
+		systemClaims:         systemClaims,
+	}
+}
+
+func (v *validator) ValidateValidateRequest(request *sigbugTemperatureReadingValidator.ValidateRequest) error {
+	reasonsInvalid := make([]string, 0)
+
+	if request.Claims == nil {
+		reasonsInvalid = append(reasonsInvalid, "claims are nil")
+	}
+
+	if len(reasonsInvalid) > 0 {
+		return brainException.RequestInvalid{Reasons: reasonsInvalid}
+	}
+	return nil
+}
+
+func (v *validator) Validate(request *sigbugTemperatureReadingValidator.ValidateRequest) (*sigbugTemperatureReadingValidator.ValidateResponse, error) {
+	if err := v.ValidateValidateRequest(request); err != nil {
+		return nil, err
+	}
+
+	allReasonsInvalid := make([]reasonInvalid.ReasonInvalid, 0)
+	temperatureReadingToValidate := &request.Reading
+
+	if (*temperatureReadingToValidate).Id == "" {
+		allReasonsInvalid = append(allReasonsInvalid, reasonInvalid.ReasonInvalid{
+			Field: "id",
+			Type:  reasonInvalid.Blank,
+			Help:  "cannot be blank",
+			Data:  (*temperatureReadingToValidate).Id,
+		})
+	}
+
+	if (*temperatureReadingToValidate).MessageId.Id == "" {
+		allReasonsInvalid = append(allReasonsInvalid, reasonInvalid.ReasonInvalid{
+			Field: "messageId",
+			Type:  reasonInvalid.Blank,
+			Help:  "cannot be blank",
+			Data:  (*temperatureReadingToValidate).MessageId,
+		})
+	}
+
+	if (*temperatureReadingToValidate).DeviceId.Id == "" {
+		allReasonsInvalid = append(allReasonsInvalid, reasonInvalid.ReasonInvalid{
+			Field: "deviceId",
+			Type:  reasonInvalid.Blank,
+			Help:  "cannot be blank",
+			Data:  (*temperatureReadingToValidate).DeviceId,
+		})
+	} else {
+		// device must exist
+		sigbugRetrieveResponse, err := v.sigbugRecordHandler.Retrieve(&sigbugRecordHandler.RetrieveRequest{
+			Claims:     request.Claims,
+			Identifier: request.Reading.DeviceId,
+		})
+		if err != nil {
+			switch err.(type) {
+			case sigbugRecordHandlerException.NotFound:
+				allReasonsInvalid = append(allReasonsInvalid, reasonInvalid.ReasonInvalid{
+					Field: "deviceId",
+					Type:  reasonInvalid.MustExist,
+					Help:  "associated device must exist",
+					Data:  (*temperatureReadingToValidate).DeviceId,
+				})
+			default:
+				err = sigbugTemperatureReadingValidatorException.Validate{
+					Reasons: []string{
+						"retrieving sigbug",
+						err.Error(),
+					},
+				}
+				log.Error(err)
+				return nil, err
+			}
+		} else {
+			// device and message must have same owner and assigned party details
+			if sigbugRetrieveResponse.Sigbug.OwnerId != (*temperatureReadingToValidate).OwnerId {
+				allReasonsInvalid = append(allReasonsInvalid, reasonInvalid.ReasonInvalid{
+					Field: "ownerId",
+					Type:  reasonInvalid.Invalid,
+					Help:  "owner id must be same as associated sigbug",
+					Data:  (*temperatureReadingToValidate).OwnerId,
+				})
+			}
+			if sigbugRetrieveResponse.Sigbug.OwnerPartyType != (*temperatureReadingToValidate).OwnerPartyType {
+				allReasonsInvalid = append(allReasonsInvalid, reasonInvalid.ReasonInvalid{
+					Field: "ownerPartyType",
+					Type:  reasonInvalid.Invalid,
+					Help:  "owner party type must be same as associated sigbug",
+					Data:  (*temperatureReadingToValidate).OwnerPartyType,
+				})
+			}
+			if sigbugRetrieveResponse.Sigbug.AssignedId != (*temperatureReadingToValidate).AssignedId {
+				allReasonsInvalid = append(allReasonsInvalid, reasonInvalid.ReasonInvalid{
+					Field: "assignedId",
+					Type:  reasonInvalid.Invalid,
+					Help:  "assigned id must be same as associated sigbug",
+					Data:  (*temperatureReadingToValidate).AssignedId,
+				})
+			}
+			if sigbugRetrieveResponse.Sigbug.AssignedPartyType != (*temperatureReadingToValidate).AssignedPartyType {
+				allReasonsInvalid = append(allReasonsInvalid, reasonInvalid.ReasonInvalid{
+					Field: "assignedPartyType",
+					Type:  reasonInvalid.Invalid,
+					Help:  "assigned party type must be same as associated sigbug",
+					Data:  (*temperatureReadingToValidate).AssignedPartyType,
+				})
+			}
+		}
+	}
+
+	// Make list of reasons invalid to return
+	returnedReasonsInvalid := make([]reasonInvalid.ReasonInvalid, 0)
+
+	// Add all reasons that cannot be ignored for the given action
+	if v.actionIgnoredReasons[request.Action].ReasonsInvalid != nil {
+		for _, reason := range allReasonsInvalid {
+			if !v.actionIgnoredReasons[request.Action].CanIgnore(reason) {
+				returnedReasonsInvalid = append(returnedReasonsInvalid, reason)
+			}
+		}
+	}
+
+	return &sigbugTemperatureReadingValidator.ValidateResponse{
+		ReasonsInvalid: returnedReasonsInvalid,
+	}, nil
+}
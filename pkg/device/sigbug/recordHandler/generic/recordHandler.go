@@ -131,3 +131,27 @@ func (r *RecordHandler) Collect(request *sigbugRecordHandler.CollectRequest) (*s
 		Total:   collectResponse.Total,
 	}, nil
 }
+
+func (r *RecordHandler) UpdateFields(request *sigbugRecordHandler.UpdateFieldsRequest) (*sigbugRecordHandler.UpdateFieldsResponse, error) {
+	updatedSigbug := sigbug.Sigbug{}
+	updateFieldsResponse := brainRecordHandler.UpdateFieldsResponse{
+		Entity: &updatedSigbug,
+	}
+	if err := r.sigbugRecordHandler.UpdateFields(&brainRecordHandler.UpdateFieldsRequest{
+		Claims:   request.Claims,
+		Criteria: request.Criteria,
+		Query:    request.Query,
+		Update:   request.Update,
+	}, &updateFieldsResponse); err != nil {
+		switch err.(type) {
+		case brainRecordHandlerException.NotFound:
+			return nil, sigbugRecordHandlerException.NotFound{}
+		default:
+			return nil, sigbugRecordHandlerException.Update{Reasons: []string{err.Error()}}
+		}
+	}
+
+	return &sigbugRecordHandler.UpdateFieldsResponse{
+		Sigbug: updatedSigbug,
+	}, nil
+}
@@ -86,3 +86,7 @@ func (r *recordHandler) Collect(request *sigbugRecordHandler.CollectRequest) (*s
 		Total:   collectResponse.Total,
 	}, nil
 }
+
+func (r *recordHandler) UpdateFields(request *sigbugRecordHandler.UpdateFieldsRequest) (*sigbugRecordHandler.UpdateFieldsResponse, error) {
+	return nil, brainException.NotImplemented{}
+}
@@ -2,6 +2,7 @@ package recordHandler
 
 import (
 	"github.com/iot-my-world/brain/pkg/device/sigbug"
+	brainRecordHandler "github.com/iot-my-world/brain/pkg/recordHandler"
 	"github.com/iot-my-world/brain/pkg/search/criterion"
 	"github.com/iot-my-world/brain/pkg/search/identifier"
 	"github.com/iot-my-world/brain/pkg/search/query"
@@ -15,6 +16,7 @@ type RecordHandler interface {
 	Update(*UpdateRequest) (*UpdateResponse, error)
 	Delete(*DeleteRequest) (*DeleteResponse, error)
 	Collect(*CollectRequest) (*CollectResponse, error)
+	UpdateFields(*UpdateFieldsRequest) (*UpdateFieldsResponse, error)
 }
 
 const ServiceProvider = "SigbugDevice-RecordHandler"
@@ -89,3 +91,16 @@ type CollectResponse struct {
 	Records []sigbug.Sigbug
 	Total   int
 }
+
+// UpdateFieldsRequest atomically updates the fields of the first
+// sigbug matching the criteria, in the sort order of the query
+type UpdateFieldsRequest struct {
+	Claims   claims.Claims
+	Criteria []criterion.Criterion
+	Query    query.Query
+	Update   brainRecordHandler.FieldsUpdate
+}
+
+type UpdateFieldsResponse struct {
+	Sigbug sigbug.Sigbug
+}
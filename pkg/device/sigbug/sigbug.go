@@ -16,6 +16,13 @@ type Sigbug struct {
 	AssignedId        id.Identifier `json:"assignedId" bson:"assignedId"`
 
 	LastMessage sigfoxBackendDataCallbackMessage.Message `json:"lastMessage" bson:"lastMessage"`
+
+	// SilenceThreshold is the number of seconds after which the device is taken
+	// to be offline if it has not sent a message. If it is 0 the threshold of
+	// the owner party of the device is used.
+	SilenceThreshold int64 `json:"silenceThreshold" bson:"silenceThreshold"`
+	// Offline is set once the device has been silent for longer than its threshold
+	Offline bool `json:"offline" bson:"offline"`
 }
 
 func (s *Sigbug) SetId(id string) {
@@ -0,0 +1,26 @@
+package decoder
+
+import (
+	"encoding/binary"
+	"github.com/iot-my-world/brain/pkg/device/sigbug/reading/battery"
+	"github.com/iot-my-world/brain/pkg/device/sigbug/sigfox/message"
+	sigfoxBackendDataCallbackMessageDecoder "github.com/iot-my-world/brain/pkg/sigfox/backend/callback/data/message/decoder"
+)
+
+type batteryReadingDecoder struct {
+}
+
+func NewBatteryReading() sigfoxBackendDataCallbackMessageDecoder.Decoder {
+	return &batteryReadingDecoder{}
+}
+
+// Decode returns a battery.Reading with the voltage set
+func (d *batteryReadingDecoder) Decode(data []byte) (interface{}, error) {
+	if err := validateData(data, message.BatteryReading, message.BatteryReadingLength); err != nil {
+		return nil, err
+	}
+
+	return battery.Reading{
+		Voltage: float32(binary.LittleEndian.Uint16(data[1:3])) / 1000,
+	}, nil
+}
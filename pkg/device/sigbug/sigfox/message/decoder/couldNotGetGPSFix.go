@@ -0,0 +1,25 @@
+package decoder
+
+import (
+	sigbugEvent "github.com/iot-my-world/brain/pkg/device/sigbug/event"
+	"github.com/iot-my-world/brain/pkg/device/sigbug/sigfox/message"
+	sigfoxBackendDataCallbackMessageDecoder "github.com/iot-my-world/brain/pkg/sigfox/backend/callback/data/message/decoder"
+)
+
+type couldNotGetGPSFixDecoder struct {
+}
+
+func NewCouldNotGetGPSFix() sigfoxBackendDataCallbackMessageDecoder.Decoder {
+	return &couldNotGetGPSFixDecoder{}
+}
+
+// Decode returns a CouldNotGetGPSFix event
+func (d *couldNotGetGPSFixDecoder) Decode(data []byte) (interface{}, error) {
+	if err := validateData(data, message.CouldNotGetGPSFixMessageType, 0); err != nil {
+		return nil, err
+	}
+
+	return sigbugEvent.Event{
+		Type: sigbugEvent.CouldNotGetGPSFix,
+	}, nil
+}
@@ -0,0 +1,20 @@
+package decoder
+
+import (
+	sigfoxBackendDataCallbackMessageDecoderException "github.com/iot-my-world/brain/pkg/sigfox/backend/callback/data/message/decoder/exception"
+)
+
+// validateData confirms that the given message data is of the given
+// message type and, if length is not 0, that it is of the given length
+func validateData(data []byte, messageType byte, length int) error {
+	if len(data) == 0 {
+		return sigfoxBackendDataCallbackMessageDecoderException.InvalidDataLength{Expected: length, Actual: 0}
+	}
+	if data[0] != messageType {
+		return sigfoxBackendDataCallbackMessageDecoderException.InvalidMessageType{Expected: messageType, Actual: data[0]}
+	}
+	if length != 0 && len(data) != length {
+		return sigfoxBackendDataCallbackMessageDecoderException.InvalidDataLength{Expected: length, Actual: len(data)}
+	}
+	return nil
+}
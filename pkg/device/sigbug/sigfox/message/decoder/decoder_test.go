@@ -0,0 +1,174 @@
+package decoder
+
+import (
+	"encoding/hex"
+	sigbugEvent "github.com/iot-my-world/brain/pkg/device/sigbug/event"
+	"github.com/iot-my-world/brain/pkg/device/sigbug/reading/battery"
+	"github.com/iot-my-world/brain/pkg/device/sigbug/reading/gps"
+	"github.com/iot-my-world/brain/pkg/device/sigbug/reading/heartbeat"
+	"github.com/iot-my-world/brain/pkg/device/sigbug/reading/temperature"
+	sigfoxBackendDataCallbackMessageDecoder "github.com/iot-my-world/brain/pkg/sigfox/backend/callback/data/message/decoder"
+	sigfoxBackendDataCallbackMessageDecoderException "github.com/iot-my-world/brain/pkg/sigfox/backend/callback/data/message/decoder/exception"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+type goldenTestCase struct {
+	name    string
+	data    string
+	reading interface{}
+	err     error
+}
+
+func runGoldenTests(t *testing.T, decoder sigfoxBackendDataCallbackMessageDecoder.Decoder, testCases []goldenTestCase) {
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			data, err := hex.DecodeString(testCase.data)
+			if err != nil {
+				t.Fatalf("error decoding test case data: %s", err.Error())
+			}
+
+			reading, err := decoder.Decode(data)
+			assert.Equal(t, testCase.err, err, "decode error")
+			assert.Equal(t, testCase.reading, reading, "decoded reading")
+		})
+	}
+}
+
+func TestGPSReadingDecoder(t *testing.T) {
+	runGoldenTests(t, NewGPSReading(), []goldenTestCase{
+		{
+			name: "johannesburg",
+			data: "02b9fcd0c11f74e041",
+			reading: gps.Reading{
+				Latitude:  -26.1234,
+				Longitude: 28.0567,
+			},
+		},
+		{
+			name:    "zeros",
+			data:    "020000000000000000",
+			reading: gps.Reading{},
+		},
+		{
+			name: "too short",
+			data: "02b9fcd0c11f74e0",
+			err:  sigfoxBackendDataCallbackMessageDecoderException.InvalidDataLength{Expected: 9, Actual: 8},
+		},
+		{
+			name: "too long",
+			data: "02b9fcd0c11f74e04100",
+			err:  sigfoxBackendDataCallbackMessageDecoderException.InvalidDataLength{Expected: 9, Actual: 10},
+		},
+		{
+			name: "wrong message type",
+			data: "03b9fcd0c11f74e041",
+			err:  sigfoxBackendDataCallbackMessageDecoderException.InvalidMessageType{Expected: 2, Actual: 3},
+		},
+		{
+			name: "empty",
+			data: "",
+			err:  sigfoxBackendDataCallbackMessageDecoderException.InvalidDataLength{Expected: 9, Actual: 0},
+		},
+	})
+}
+
+func TestBatteryReadingDecoder(t *testing.T) {
+	runGoldenTests(t, NewBatteryReading(), []goldenTestCase{
+		{
+			name: "3.712 volts",
+			data: "03800e",
+			reading: battery.Reading{
+				Voltage: 3.712,
+			},
+		},
+		{
+			name: "maximum",
+			data: "03ffff",
+			reading: battery.Reading{
+				Voltage: 65.535,
+			},
+		},
+		{
+			name: "too short",
+			data: "0380",
+			err:  sigfoxBackendDataCallbackMessageDecoderException.InvalidDataLength{Expected: 3, Actual: 2},
+		},
+		{
+			name: "wrong message type",
+			data: "04800e",
+			err:  sigfoxBackendDataCallbackMessageDecoderException.InvalidMessageType{Expected: 3, Actual: 4},
+		},
+	})
+}
+
+func TestTemperatureReadingDecoder(t *testing.T) {
+	runGoldenTests(t, NewTemperatureReading(), []goldenTestCase{
+		{
+			name: "positive",
+			data: "042909",
+			reading: temperature.Reading{
+				Temperature: 23.45,
+			},
+		},
+		{
+			name: "negative",
+			data: "04e6fb",
+			reading: temperature.Reading{
+				Temperature: -10.5,
+			},
+		},
+		{
+			name: "too long",
+			data: "04290900",
+			err:  sigfoxBackendDataCallbackMessageDecoderException.InvalidDataLength{Expected: 3, Actual: 4},
+		},
+		{
+			name: "wrong message type",
+			data: "032909",
+			err:  sigfoxBackendDataCallbackMessageDecoderException.InvalidMessageType{Expected: 4, Actual: 3},
+		},
+	})
+}
+
+func TestHeartbeatDecoder(t *testing.T) {
+	runGoldenTests(t, NewHeartbeat(), []goldenTestCase{
+		{
+			name:    "heartbeat",
+			data:    "05",
+			reading: heartbeat.Reading{},
+		},
+		{
+			name: "too long",
+			data: "05ff",
+			err:  sigfoxBackendDataCallbackMessageDecoderException.InvalidDataLength{Expected: 1, Actual: 2},
+		},
+		{
+			name: "empty",
+			data: "",
+			err:  sigfoxBackendDataCallbackMessageDecoderException.InvalidDataLength{Expected: 1, Actual: 0},
+		},
+		{
+			name: "wrong message type",
+			data: "01",
+			err:  sigfoxBackendDataCallbackMessageDecoderException.InvalidMessageType{Expected: 5, Actual: 1},
+		},
+	})
+}
+
+func TestCouldNotGetGPSFixDecoder(t *testing.T) {
+	runGoldenTests(t, NewCouldNotGetGPSFix(), []goldenTestCase{
+		{
+			name: "could not get gps fix",
+			data: "01",
+			reading: sigbugEvent.Event{
+				Type: sigbugEvent.CouldNotGetGPSFix,
+			},
+		},
+		{
+			name: "wrong message type",
+			data: "05",
+			err:  sigfoxBackendDataCallbackMessageDecoderException.InvalidMessageType{Expected: 1, Actual: 5},
+		},
+	})
+}
@@ -0,0 +1,28 @@
+package decoder
+
+import (
+	"encoding/binary"
+	"github.com/iot-my-world/brain/pkg/device/sigbug/reading/gps"
+	"github.com/iot-my-world/brain/pkg/device/sigbug/sigfox/message"
+	sigfoxBackendDataCallbackMessageDecoder "github.com/iot-my-world/brain/pkg/sigfox/backend/callback/data/message/decoder"
+	"math"
+)
+
+type gpsReadingDecoder struct {
+}
+
+func NewGPSReading() sigfoxBackendDataCallbackMessageDecoder.Decoder {
+	return &gpsReadingDecoder{}
+}
+
+// Decode returns a gps.Reading with the latitude and longitude set
+func (d *gpsReadingDecoder) Decode(data []byte) (interface{}, error) {
+	if err := validateData(data, message.GPSReading, message.GPSReadingLength); err != nil {
+		return nil, err
+	}
+
+	return gps.Reading{
+		Latitude:  math.Float32frombits(binary.LittleEndian.Uint32(data[1:5])),
+		Longitude: math.Float32frombits(binary.LittleEndian.Uint32(data[5:9])),
+	}, nil
+}
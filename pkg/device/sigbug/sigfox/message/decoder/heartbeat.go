@@ -0,0 +1,23 @@
+package decoder
+
+import (
+	"github.com/iot-my-world/brain/pkg/device/sigbug/reading/heartbeat"
+	"github.com/iot-my-world/brain/pkg/device/sigbug/sigfox/message"
+	sigfoxBackendDataCallbackMessageDecoder "github.com/iot-my-world/brain/pkg/sigfox/backend/callback/data/message/decoder"
+)
+
+type heartbeatDecoder struct {
+}
+
+func NewHeartbeat() sigfoxBackendDataCallbackMessageDecoder.Decoder {
+	return &heartbeatDecoder{}
+}
+
+// Decode returns an empty heartbeat.Reading
+func (d *heartbeatDecoder) Decode(data []byte) (interface{}, error) {
+	if err := validateData(data, message.Heartbeat, message.HeartbeatLength); err != nil {
+		return nil, err
+	}
+
+	return heartbeat.Reading{}, nil
+}
@@ -0,0 +1,26 @@
+package decoder
+
+import (
+	"encoding/binary"
+	"github.com/iot-my-world/brain/pkg/device/sigbug/reading/temperature"
+	"github.com/iot-my-world/brain/pkg/device/sigbug/sigfox/message"
+	sigfoxBackendDataCallbackMessageDecoder "github.com/iot-my-world/brain/pkg/sigfox/backend/callback/data/message/decoder"
+)
+
+type temperatureReadingDecoder struct {
+}
+
+func NewTemperatureReading() sigfoxBackendDataCallbackMessageDecoder.Decoder {
+	return &temperatureReadingDecoder{}
+}
+
+// Decode returns a temperature.Reading with the temperature set
+func (d *temperatureReadingDecoder) Decode(data []byte) (interface{}, error) {
+	if err := validateData(data, message.TemperatureReading, message.TemperatureReadingLength); err != nil {
+		return nil, err
+	}
+
+	return temperature.Reading{
+		Temperature: float32(int16(binary.LittleEndian.Uint16(data[1:3]))) / 100,
+	}, nil
+}
@@ -0,0 +1,67 @@
+package exception
+
+import "strings"
+
+type RetrieveDevice struct {
+	Reasons []string
+}
+
+func (e RetrieveDevice) Error() string {
+	return "error retrieving sigbug device: " + strings.Join(e.Reasons, "; ")
+}
+
+type LastMessageUpdate struct {
+	Reasons []string
+}
+
+func (e LastMessageUpdate) Error() string {
+	return "error updating last message on sigbug device: " + strings.Join(e.Reasons, "; ")
+}
+
+type UnsupportedReading struct {
+	Reasons []string
+}
+
+func (e UnsupportedReading) Error() string {
+	return "unsupported reading type: " + strings.Join(e.Reasons, "; ")
+}
+
+type SinkGPSReading struct {
+	Reasons []string
+}
+
+func (e SinkGPSReading) Error() string {
+	return "error sinking gps reading: " + strings.Join(e.Reasons, "; ")
+}
+
+type SinkEvent struct {
+	Reasons []string
+}
+
+func (e SinkEvent) Error() string {
+	return "error sinking event: " + strings.Join(e.Reasons, "; ")
+}
+
+type SinkBatteryReading struct {
+	Reasons []string
+}
+
+func (e SinkBatteryReading) Error() string {
+	return "error sinking battery reading: " + strings.Join(e.Reasons, "; ")
+}
+
+type SinkTemperatureReading struct {
+	Reasons []string
+}
+
+func (e SinkTemperatureReading) Error() string {
+	return "error sinking temperature reading: " + strings.Join(e.Reasons, "; ")
+}
+
+type SinkHeartbeatReading struct {
+	Reasons []string
+}
+
+func (e SinkHeartbeatReading) Error() string {
+	return "error sinking heartbeat reading: " + strings.Join(e.Reasons, "; ")
+}
@@ -0,0 +1,515 @@
+package sink
+
+import (
+	"encoding/json"
+	"fmt"
+	brainException "github.com/iot-my-world/brain/internal/exception"
+	"github.com/iot-my-world/brain/internal/log"
+	alertEngine "github.com/iot-my-world/brain/pkg/alert/engine"
+	"github.com/iot-my-world/brain/pkg/alert/rule"
+	"github.com/iot-my-world/brain/pkg/communication/websocket"
+	websocketMessage "github.com/iot-my-world/brain/pkg/communication/websocket/message"
+	"github.com/iot-my-world/brain/pkg/device/sigbug"
+	sigbugAdministrator "github.com/iot-my-world/brain/pkg/device/sigbug/administrator"
+	sigbugDownlinkAdministrator "github.com/iot-my-world/brain/pkg/device/sigbug/downlink/administrator"
+	sigbugEvent "github.com/iot-my-world/brain/pkg/device/sigbug/event"
+	sigbugEventRecordHandler "github.com/iot-my-world/brain/pkg/device/sigbug/event/recordHandler"
+	sigbugMonitor "github.com/iot-my-world/brain/pkg/device/sigbug/monitor"
+	"github.com/iot-my-world/brain/pkg/device/sigbug/reading/battery"
+	sigbugBatteryReadingAdministrator "github.com/iot-my-world/brain/pkg/device/sigbug/reading/battery/administrator"
+	sigbugBatteryReadingRecordHandlerException "github.com/iot-my-world/brain/pkg/device/sigbug/reading/battery/recordHandler/exception"
+	"github.com/iot-my-world/brain/pkg/device/sigbug/reading/gps"
+	sigbugGPSReadingAdministrator "github.com/iot-my-world/brain/pkg/device/sigbug/reading/gps/administrator"
+	sigbugGPSReadingRecordHandler "github.com/iot-my-world/brain/pkg/device/sigbug/reading/gps/recordHandler"
+	sigbugGPSReadingRecordHandlerException "github.com/iot-my-world/brain/pkg/device/sigbug/reading/gps/recordHandler/exception"
+	"github.com/iot-my-world/brain/pkg/device/sigbug/reading/heartbeat"
+	sigbugHeartbeatReadingAdministrator "github.com/iot-my-world/brain/pkg/device/sigbug/reading/heartbeat/administrator"
+	sigbugHeartbeatReadingRecordHandlerException "github.com/iot-my-world/brain/pkg/device/sigbug/reading/heartbeat/recordHandler/exception"
+	"github.com/iot-my-world/brain/pkg/device/sigbug/reading/temperature"
+	sigbugTemperatureReadingAdministrator "github.com/iot-my-world/brain/pkg/device/sigbug/reading/temperature/administrator"
+	sigbugTemperatureReadingRecordHandlerException "github.com/iot-my-world/brain/pkg/device/sigbug/reading/temperature/recordHandler/exception"
+	sigbugRecordHandler "github.com/iot-my-world/brain/pkg/device/sigbug/recordHandler"
+	sigbugSigfoxMessageSinkException "github.com/iot-my-world/brain/pkg/device/sigbug/sigfox/message/sink/exception"
+	sigbugTripSegmenter "github.com/iot-my-world/brain/pkg/device/sigbug/trip/segmenter"
+	geofenceEvaluator "github.com/iot-my-world/brain/pkg/geofence/evaluator"
+	geofenceEvent "github.com/iot-my-world/brain/pkg/geofence/event"
+	"github.com/iot-my-world/brain/pkg/search/criterion"
+	exactTextCriterion "github.com/iot-my-world/brain/pkg/search/criterion/exact/text"
+	dateRangeCriterion "github.com/iot-my-world/brain/pkg/search/criterion/range/date"
+	"github.com/iot-my-world/brain/pkg/search/identifier/id"
+	"github.com/iot-my-world/brain/pkg/search/query"
+	sigfoxBackendDataCallbackMessageDecoder "github.com/iot-my-world/brain/pkg/sigfox/backend/callback/data/message/decoder"
+	webhookEvent "github.com/iot-my-world/brain/pkg/webhook/event"
+	webhookPublisher "github.com/iot-my-world/brain/pkg/webhook/publisher"
+)
+
+// sink persists the readings decoded from sigbug sigfox data messages
+type sink struct {
+	sigbugRecordHandler                   sigbugRecordHandler.RecordHandler
+	sigbugAdministrator                   sigbugAdministrator.Administrator
+	sigbugDownlinkAdministrator           sigbugDownlinkAdministrator.Administrator
+	sigbugGPSReadingAdministrator         sigbugGPSReadingAdministrator.Administrator
+	sigbugGPSReadingRecordHandler         sigbugGPSReadingRecordHandler.RecordHandler
+	sigbugBatteryReadingAdministrator     sigbugBatteryReadingAdministrator.Administrator
+	sigbugTemperatureReadingAdministrator sigbugTemperatureReadingAdministrator.Administrator
+	sigbugHeartbeatReadingAdministrator   sigbugHeartbeatReadingAdministrator.Administrator
+	sigbugEventRecordHandler              sigbugEventRecordHandler.RecordHandler
+	sigbugMonitor                         sigbugMonitor.Monitor
+	websocketPublisher                    websocket.Publisher
+	geofenceEvaluator                     geofenceEvaluator.Evaluator
+	sigbugTripSegmenter                   sigbugTripSegmenter.Segmenter
+	alertEngine                           alertEngine.Engine
+	webhookPublisher                      webhookPublisher.Publisher
+}
+
+func New(
+	sigbugRecordHandler sigbugRecordHandler.RecordHandler,
+	sigbugAdministrator sigbugAdministrator.Administrator,
+	sigbugDownlinkAdministrator sigbugDownlinkAdministrator.Administrator,
+	sigbugGPSReadingAdministrator sigbugGPSReadingAdministrator.Administrator,
+	sigbugGPSReadingRecordHandler sigbugGPSReadingRecordHandler.RecordHandler,
+	sigbugBatteryReadingAdministrator sigbugBatteryReadingAdministrator.Administrator,
+	sigbugTemperatureReadingAdministrator sigbugTemperatureReadingAdministrator.Administrator,
+	sigbugHeartbeatReadingAdministrator sigbugHeartbeatReadingAdministrator.Administrator,
+	sigbugEventRecordHandler sigbugEventRecordHandler.RecordHandler,
+	sigbugMonitor sigbugMonitor.Monitor,
+	websocketPublisher websocket.Publisher,
+	geofenceEvaluator geofenceEvaluator.Evaluator,
+	sigbugTripSegmenter sigbugTripSegmenter.Segmenter,
+	alertEngine alertEngine.Engine,
+	webhookPublisher webhookPublisher.Publisher,
+) sigfoxBackendDataCallbackMessageDecoder.Sink {
+	return &sink{
+		sigbugRecordHandler:                   sigbugRecordHandler,
+		sigbugAdministrator:                   sigbugAdministrator,
+		sigbugDownlinkAdministrator:           sigbugDownlinkAdministrator,
+		sigbugGPSReadingAdministrator:         sigbugGPSReadingAdministrator,
+		sigbugGPSReadingRecordHandler:         sigbugGPSReadingRecordHandler,
+		sigbugBatteryReadingAdministrator:     sigbugBatteryReadingAdministrator,
+		sigbugTemperatureReadingAdministrator: sigbugTemperatureReadingAdministrator,
+		sigbugHeartbeatReadingAdministrator:   sigbugHeartbeatReadingAdministrator,
+		sigbugEventRecordHandler:              sigbugEventRecordHandler,
+		sigbugMonitor:                         sigbugMonitor,
+		websocketPublisher:                    websocketPublisher,
+		geofenceEvaluator:                     geofenceEvaluator,
+		sigbugTripSegmenter:                   sigbugTripSegmenter,
+		alertEngine:                           alertEngine,
+		webhookPublisher:                      webhookPublisher,
+	}
+}
+
+func (s *sink) ValidateSinkRequest(request *sigfoxBackendDataCallbackMessageDecoder.SinkRequest) error {
+	reasonsInvalid := make([]string, 0)
+
+	if request.Claims == nil {
+		reasonsInvalid = append(reasonsInvalid, "claims are nil")
+	}
+
+	if request.Reading == nil {
+		reasonsInvalid = append(reasonsInvalid, "reading is nil")
+	}
+
+	if len(reasonsInvalid) > 0 {
+		return brainException.RequestInvalid{Reasons: reasonsInvalid}
+	}
+	return nil
+}
+
+func (s *sink) Sink(request *sigfoxBackendDataCallbackMessageDecoder.SinkRequest) (*sigfoxBackendDataCallbackMessageDecoder.SinkResponse, error) {
+	if err := s.ValidateSinkRequest(request); err != nil {
+		log.Error(err.Error())
+		return nil, err
+	}
+
+	// try and retrieve the device which this data message was generated by
+	retrieveSigbugResponse, err := s.sigbugRecordHandler.Retrieve(&sigbugRecordHandler.RetrieveRequest{
+		Claims: request.Claims,
+		Identifier: sigbug.Identifier{
+			DeviceId: request.DataMessage.DeviceId,
+		},
+	})
+	if err != nil {
+		err = sigbugSigfoxMessageSinkException.RetrieveDevice{Reasons: []string{err.Error()}}
+		log.Error(err)
+		return nil, err
+	}
+
+	// update last message timestamp on sigbug
+	lastMessageUpdateResponse, err := s.sigbugAdministrator.LastMessageUpdate(&sigbugAdministrator.LastMessageUpdateRequest{
+		Claims: request.Claims,
+		Identifier: sigbug.Identifier{
+			DeviceId: request.DataMessage.DeviceId,
+		},
+		Message: request.DataMessage,
+	})
+	if err != nil {
+		err = sigbugSigfoxMessageSinkException.LastMessageUpdate{Reasons: []string{err.Error()}}
+		log.Error(err)
+		return nil, err
+	}
+	s.publish(
+		websocketMessage.SigbugLastMessageUpdate,
+		lastMessageUpdateResponse.Sigbug,
+		id.Identifier{Id: lastMessageUpdateResponse.Sigbug.Id},
+		lastMessageUpdateResponse.Sigbug.OwnerId,
+		lastMessageUpdateResponse.Sigbug.AssignedId,
+	)
+
+	// a device which was offline has come back online. failure to mark it as
+	// online is logged, leaving it to be marked by the next check of devices.
+	if lastMessageUpdateResponse.Sigbug.Offline {
+		if _, err := s.sigbugMonitor.CheckDevice(&sigbugMonitor.CheckDeviceRequest{
+			Claims: request.Claims,
+			Sigbug: lastMessageUpdateResponse.Sigbug,
+		}); err != nil {
+			log.Error("error checking status of device: " + err.Error())
+		}
+	}
+
+	// sink reading according to its type
+	switch typedReading := request.Reading.(type) {
+	case sigbugEvent.Event:
+		err = s.sinkEvent(request, &retrieveSigbugResponse.Sigbug, typedReading)
+
+	case gps.Reading:
+		err = s.sinkGPSReading(request, &retrieveSigbugResponse.Sigbug, typedReading)
+
+	case battery.Reading:
+		err = s.sinkBatteryReading(request, &retrieveSigbugResponse.Sigbug, typedReading)
+
+	case temperature.Reading:
+		err = s.sinkTemperatureReading(request, &retrieveSigbugResponse.Sigbug, typedReading)
+
+	case heartbeat.Reading:
+		err = s.sinkHeartbeatReading(request, &retrieveSigbugResponse.Sigbug, typedReading)
+
+	default:
+		err = sigbugSigfoxMessageSinkException.UnsupportedReading{Reasons: []string{fmt.Sprintf("%T", request.Reading)}}
+		log.Error(err)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// if the device requested an acknowledgement send it the next queued downlink.
+	// the reading has already been stored and so failure to do so is logged
+	// but does not fail the sinking of the reading, leaving the downlink queued.
+	if !request.DataMessage.Ack {
+		return &sigfoxBackendDataCallbackMessageDecoder.SinkResponse{}, nil
+	}
+	sendNextResponse, err := s.sigbugDownlinkAdministrator.SendNext(&sigbugDownlinkAdministrator.SendNextRequest{
+		Claims:     request.Claims,
+		Identifier: id.Identifier{Id: retrieveSigbugResponse.Sigbug.Id},
+		MessageId:  request.DataMessage.Id,
+	})
+	if err != nil {
+		log.Error("error sending next downlink: " + err.Error())
+		return &sigfoxBackendDataCallbackMessageDecoder.SinkResponse{}, nil
+	}
+	if !sendNextResponse.Sent {
+		return &sigfoxBackendDataCallbackMessageDecoder.SinkResponse{}, nil
+	}
+
+	return &sigfoxBackendDataCallbackMessageDecoder.SinkResponse{
+		DownlinkData: sendNextResponse.Downlink.Data,
+	}, nil
+}
+
+//
+// Individual reading type sinks
+//
+func (s *sink) sinkEvent(request *sigfoxBackendDataCallbackMessageDecoder.SinkRequest, sigbugDevice *sigbug.Sigbug, event sigbugEvent.Event) error {
+	event.DeviceId = id.Identifier{Id: sigbugDevice.Id}
+	event.OwnerPartyType = sigbugDevice.OwnerPartyType
+	event.OwnerId = sigbugDevice.OwnerId
+	event.AssignedPartyType = sigbugDevice.AssignedPartyType
+	event.AssignedId = sigbugDevice.AssignedId
+	event.MessageId = id.Identifier{Id: request.DataMessage.Id}
+	event.TimeStamp = request.DataMessage.SentTime()
+
+	if _, err := s.sigbugEventRecordHandler.Create(&sigbugEventRecordHandler.CreateRequest{
+		Claims: request.Claims,
+		Event:  event,
+	}); err != nil {
+		err = sigbugSigfoxMessageSinkException.SinkEvent{Reasons: []string{"create event", err.Error()}}
+		log.Error(err)
+		return err
+	}
+
+	if event.Type == sigbugEvent.CouldNotGetGPSFix {
+		s.fireAlert(&alertEngine.FireRequest{
+			Claims:      request.Claims,
+			TriggerType: rule.NoGPSFix,
+			Device:      *sigbugDevice,
+			TimeStamp:   event.TimeStamp,
+		})
+	}
+
+	return nil
+}
+
+func (s *sink) sinkBatteryReading(request *sigfoxBackendDataCallbackMessageDecoder.SinkRequest, sigbugDevice *sigbug.Sigbug, reading battery.Reading) error {
+	reading.DeviceId = id.Identifier{Id: sigbugDevice.Id}
+	reading.OwnerPartyType = sigbugDevice.OwnerPartyType
+	reading.OwnerId = sigbugDevice.OwnerId
+	reading.AssignedPartyType = sigbugDevice.AssignedPartyType
+	reading.AssignedId = sigbugDevice.AssignedId
+	reading.MessageId = id.Identifier{Id: request.DataMessage.Id}
+	reading.TimeStamp = request.DataMessage.SentTime()
+
+	createReadingResponse, err := s.sigbugBatteryReadingAdministrator.Create(&sigbugBatteryReadingAdministrator.CreateRequest{
+		Claims:  request.Claims,
+		Reading: reading,
+	})
+	if err != nil {
+		switch err.(type) {
+		case sigbugBatteryReadingRecordHandlerException.Duplicate:
+			// the message has already been sunk
+			log.Info(fmt.Sprintf("ignoring battery reading already recorded for message %s", request.DataMessage.Id))
+			return nil
+		default:
+			err = sigbugSigfoxMessageSinkException.SinkBatteryReading{Reasons: []string{"create battery reading", err.Error()}}
+			log.Error(err)
+			return err
+		}
+	}
+	s.publishWebhook(request, sigbugDevice, webhookEvent.BatteryReading, createReadingResponse.Reading)
+
+	return nil
+}
+
+func (s *sink) sinkTemperatureReading(request *sigfoxBackendDataCallbackMessageDecoder.SinkRequest, sigbugDevice *sigbug.Sigbug, reading temperature.Reading) error {
+	reading.DeviceId = id.Identifier{Id: sigbugDevice.Id}
+	reading.OwnerPartyType = sigbugDevice.OwnerPartyType
+	reading.OwnerId = sigbugDevice.OwnerId
+	reading.AssignedPartyType = sigbugDevice.AssignedPartyType
+	reading.AssignedId = sigbugDevice.AssignedId
+	reading.MessageId = id.Identifier{Id: request.DataMessage.Id}
+	reading.TimeStamp = request.DataMessage.SentTime()
+
+	createReadingResponse, err := s.sigbugTemperatureReadingAdministrator.Create(&sigbugTemperatureReadingAdministrator.CreateRequest{
+		Claims:  request.Claims,
+		Reading: reading,
+	})
+	if err != nil {
+		switch err.(type) {
+		case sigbugTemperatureReadingRecordHandlerException.Duplicate:
+			// the message has already been sunk
+			log.Info(fmt.Sprintf("ignoring temperature reading already recorded for message %s", request.DataMessage.Id))
+			return nil
+		default:
+			err = sigbugSigfoxMessageSinkException.SinkTemperatureReading{Reasons: []string{"create temperature reading", err.Error()}}
+			log.Error(err)
+			return err
+		}
+	}
+	s.publishWebhook(request, sigbugDevice, webhookEvent.TemperatureReading, createReadingResponse.Reading)
+
+	return nil
+}
+
+func (s *sink) sinkHeartbeatReading(request *sigfoxBackendDataCallbackMessageDecoder.SinkRequest, sigbugDevice *sigbug.Sigbug, reading heartbeat.Reading) error {
+	reading.DeviceId = id.Identifier{Id: sigbugDevice.Id}
+	reading.OwnerPartyType = sigbugDevice.OwnerPartyType
+	reading.OwnerId = sigbugDevice.OwnerId
+	reading.AssignedPartyType = sigbugDevice.AssignedPartyType
+	reading.AssignedId = sigbugDevice.AssignedId
+	reading.MessageId = id.Identifier{Id: request.DataMessage.Id}
+	reading.TimeStamp = request.DataMessage.SentTime()
+
+	createReadingResponse, err := s.sigbugHeartbeatReadingAdministrator.Create(&sigbugHeartbeatReadingAdministrator.CreateRequest{
+		Claims:  request.Claims,
+		Reading: reading,
+	})
+	if err != nil {
+		switch err.(type) {
+		case sigbugHeartbeatReadingRecordHandlerException.Duplicate:
+			// the message has already been sunk
+			log.Info(fmt.Sprintf("ignoring heartbeat reading already recorded for message %s", request.DataMessage.Id))
+			return nil
+		default:
+			err = sigbugSigfoxMessageSinkException.SinkHeartbeatReading{Reasons: []string{"create heartbeat reading", err.Error()}}
+			log.Error(err)
+			return err
+		}
+	}
+	s.publishWebhook(request, sigbugDevice, webhookEvent.HeartbeatReading, createReadingResponse.Reading)
+
+	return nil
+}
+
+func (s *sink) sinkGPSReading(request *sigfoxBackendDataCallbackMessageDecoder.SinkRequest, sigbugDevice *sigbug.Sigbug, reading gps.Reading) error {
+	reading.DeviceId = id.Identifier{Id: sigbugDevice.Id}
+	reading.OwnerPartyType = sigbugDevice.OwnerPartyType
+	reading.OwnerId = sigbugDevice.OwnerId
+	reading.AssignedPartyType = sigbugDevice.AssignedPartyType
+	reading.AssignedId = sigbugDevice.AssignedId
+	reading.MessageId = id.Identifier{Id: request.DataMessage.Id}
+	reading.TimeStamp = request.DataMessage.SentTime()
+
+	// retrieve the reading which preceded this one to determine the speed of the device
+	previousReadingCollectResponse, err := s.sigbugGPSReadingRecordHandler.Collect(&sigbugGPSReadingRecordHandler.CollectRequest{
+		Claims: request.Claims,
+		Criteria: []criterion.Criterion{
+			exactTextCriterion.Criterion{
+				Field: "deviceId.id",
+				Text:  reading.DeviceId.Id,
+			},
+			dateRangeCriterion.Criterion{
+				Field: "timeStamp",
+				StartDate: dateRangeCriterion.RangeValue{
+					Ignore: true,
+				},
+				EndDate: dateRangeCriterion.RangeValue{
+					Date: reading.TimeStamp,
+				},
+			},
+		},
+		Query: query.Query{
+			Limit:  1,
+			Order:  []query.SortOrder{query.SortOrderDescending},
+			SortBy: []string{"timeStamp"},
+		},
+	})
+	if err != nil {
+		err = sigbugSigfoxMessageSinkException.SinkGPSReading{Reasons: []string{"retrieve previous gps reading", err.Error()}}
+		log.Error(err)
+		return err
+	}
+
+	// create gps reading
+	createReadingResponse, err := s.sigbugGPSReadingAdministrator.Create(&sigbugGPSReadingAdministrator.CreateRequest{
+		Claims:  request.Claims,
+		Reading: reading,
+	})
+	if err != nil {
+		switch err.(type) {
+		case sigbugGPSReadingRecordHandlerException.Duplicate:
+			// the message has already been sunk
+			log.Info(fmt.Sprintf("ignoring gps reading already recorded for message %s", request.DataMessage.Id))
+			return nil
+		default:
+			err = sigbugSigfoxMessageSinkException.SinkGPSReading{Reasons: []string{"create gps reading", err.Error()}}
+			log.Error(err)
+			return err
+		}
+	}
+	s.publish(
+		websocketMessage.SigbugGPSReading,
+		createReadingResponse.Reading,
+		createReadingResponse.Reading.DeviceId,
+		sigbugDevice.OwnerId,
+		sigbugDevice.AssignedId,
+	)
+	s.publishWebhook(request, sigbugDevice, webhookEvent.GPSReading, createReadingResponse.Reading)
+
+	// the speed of the device is only known if both readings have a location
+	if len(previousReadingCollectResponse.Records) > 0 &&
+		previousReadingCollectResponse.Records[0].Location != nil &&
+		createReadingResponse.Reading.Location != nil {
+		s.fireAlert(&alertEngine.FireRequest{
+			Claims:      request.Claims,
+			TriggerType: rule.SpeedOverThreshold,
+			Device:      *sigbugDevice,
+			TimeStamp:   createReadingResponse.Reading.TimeStamp,
+			Latitude:    createReadingResponse.Reading.Latitude,
+			Longitude:   createReadingResponse.Reading.Longitude,
+			Speed:       gps.SpeedBetween(&previousReadingCollectResponse.Records[0], &createReadingResponse.Reading),
+		})
+	}
+
+	// update the trips of the device with the reading, if it has a location.
+	// the reading has already been stored and so failure to do so is logged
+	// but does not fail the sinking of the reading.
+	if createReadingResponse.Reading.Location != nil {
+		if _, err := s.sigbugTripSegmenter.Segment(&sigbugTripSegmenter.SegmentRequest{
+			Claims:  request.Claims,
+			Reading: createReadingResponse.Reading,
+		}); err != nil {
+			log.Error("error segmenting gps reading into trips: " + err.Error())
+		}
+	}
+
+	// evaluate reading against the geofences of the device.
+	// the reading has already been stored and so failure to evaluate
+	// is logged but does not fail the sinking of the reading.
+	evaluateResponse, err := s.geofenceEvaluator.Evaluate(&geofenceEvaluator.EvaluateRequest{
+		Claims:  request.Claims,
+		Reading: createReadingResponse.Reading,
+	})
+	if err != nil {
+		log.Error("error evaluating gps reading against geofences: " + err.Error())
+		return nil
+	}
+	for _, evaluatedEvent := range evaluateResponse.Events {
+		s.publish(
+			websocketMessage.GeofenceEvent,
+			evaluatedEvent,
+			evaluatedEvent.DeviceId,
+			evaluatedEvent.OwnerId,
+			id.Identifier{},
+		)
+		if evaluatedEvent.Type == geofenceEvent.Exit {
+			s.fireAlert(&alertEngine.FireRequest{
+				Claims:      request.Claims,
+				TriggerType: rule.GeofenceExit,
+				Device:      *sigbugDevice,
+				TimeStamp:   evaluatedEvent.TimeStamp,
+				Latitude:    evaluatedEvent.Latitude,
+				Longitude:   evaluatedEvent.Longitude,
+				GeofenceId:  evaluatedEvent.GeofenceId,
+			})
+		}
+	}
+
+	return nil
+}
+
+// fireAlert gives the given request to the alert engine. Failure to fire
+// alert rules is logged but does not fail the sinking of a reading.
+func (s *sink) fireAlert(request *alertEngine.FireRequest) {
+	if _, err := s.alertEngine.Fire(request); err != nil {
+		log.Error("error firing alert rules: " + err.Error())
+	}
+}
+
+// publishWebhook gives the given reading to the webhook publisher to be posted to the
+// webhook subscriptions of the parties of the given device. Failure to publish is
+// logged but does not fail the sinking of a reading.
+func (s *sink) publishWebhook(request *sigfoxBackendDataCallbackMessageDecoder.SinkRequest, sigbugDevice *sigbug.Sigbug, readingType webhookEvent.ReadingType, reading interface{}) {
+	if _, err := s.webhookPublisher.Publish(&webhookPublisher.PublishRequest{
+		Claims:    request.Claims,
+		EventType: webhookEvent.NewReading,
+		Device:    *sigbugDevice,
+		TimeStamp: request.DataMessage.SentTime(),
+		Data: webhookEvent.ReadingData{
+			ReadingType: readingType,
+			Reading:     reading,
+		},
+	}); err != nil {
+		log.Error("error publishing reading to webhooks: " + err.Error())
+	}
+}
+
+// publish gives the given data to the websocket publisher to be broadcast to
+// the subscribers of the given device. Failure to publish is logged but does
+// not fail the sinking of a reading.
+func (s *sink) publish(messageType websocketMessage.ExoWSMsgType, data interface{}, deviceId, ownerId, assignedId id.Identifier) {
+	serialData, err := json.Marshal(data)
+	if err != nil {
+		log.Error("error marshalling websocket publication data: " + err.Error())
+		return
+	}
+
+	if err := s.websocketPublisher.Publish(websocket.Publication{
+		Message: websocket.Message{
+			Type:       messageType,
+			SerialData: string(serialData),
+		},
+		DeviceId:   deviceId,
+		OwnerId:    ownerId,
+		AssignedId: assignedId,
+	}); err != nil {
+		log.Error("error publishing to websocket hub: " + err.Error())
+	}
+}
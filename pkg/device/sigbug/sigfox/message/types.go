@@ -1,4 +1,30 @@
 package message
 
+import (
+	sigfoxBackendDataCallbackMessageDecoder "github.com/iot-my-world/brain/pkg/sigfox/backend/callback/data/message/decoder"
+)
+
+// DeviceType is the type with which sigbug message decoders are registered
+const DeviceType sigfoxBackendDataCallbackMessageDecoder.DeviceType = "Sigbug"
+
+// The first byte of the data of every sigbug message gives its type.
+// Multi byte values which follow are little endian.
+
+// CouldNotGetGPSFixMessageType has no data following the type
 const CouldNotGetGPSFixMessageType byte = 1
+
+// GPSReading is followed by the float32 latitude and longitude
 const GPSReading byte = 2
+const GPSReadingLength = 9
+
+// BatteryReading is followed by the uint16 battery voltage in millivolts
+const BatteryReading byte = 3
+const BatteryReadingLength = 3
+
+// TemperatureReading is followed by the int16 temperature in hundredths of a degree celsius
+const TemperatureReading byte = 4
+const TemperatureReadingLength = 3
+
+// Heartbeat has no data following the type
+const Heartbeat byte = 5
+const HeartbeatLength = 1
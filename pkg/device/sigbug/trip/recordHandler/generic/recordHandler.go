@@ -0,0 +1,147 @@
+package sigbugTripRecordHandler
+
+import (
+	brainException "github.com/iot-my-world/brain/internal/exception"
+	"github.com/iot-my-world/brain/pkg/device/sigbug/trip"
+	sigbugTripRecordHandler "github.com/iot-my-world/brain/pkg/device/sigbug/trip/recordHandler"
+	sigbugTripRecordHandlerException "github.com/iot-my-world/brain/pkg/device/sigbug/trip/recordHandler/exception"
+	brainRecordHandler "github.com/iot-my-world/brain/pkg/recordHandler"
+	brainRecordHandlerException "github.com/iot-my-world/brain/pkg/recordHandler/exception"
+)
+
+type RecordHandler struct {
+	sigbugTripRecordHandler brainRecordHandler.RecordHandler
+}
+
+func New(
+	brainTripRecordHandler brainRecordHandler.RecordHandler,
+) sigbugTripRecordHandler.RecordHandler {
+
+	return &RecordHandler{
+		sigbugTripRecordHandler: brainTripRecordHandler,
+	}
+}
+
+type CreateRequest struct {
+	Trip trip.Trip
+}
+
+type CreateResponse struct {
+	Trip trip.Trip
+}
+
+func (r *RecordHandler) ValidateCreateRequest(request *sigbugTripRecordHandler.CreateRequest) error {
+	reasonsInvalid := make([]string, 0)
+
+	if len(reasonsInvalid) > 0 {
+		return brainException.RequestInvalid{Reasons: reasonsInvalid}
+	}
+
+	return nil
+}
+
+func (r *RecordHandler) Create(request *sigbugTripRecordHandler.CreateRequest) (*sigbugTripRecordHandler.CreateResponse, error) {
+	if err := r.ValidateCreateRequest(request); err != nil {
+		return nil, err
+	}
+
+	createResponse := brainRecordHandler.CreateResponse{}
+	if err := r.sigbugTripRecordHandler.Create(&brainRecordHandler.CreateRequest{
+		Entity: &request.Trip,
+	}, &createResponse); err != nil {
+		return nil, sigbugTripRecordHandlerException.Create{Reasons: []string{err.Error()}}
+	}
+	createdTrip, ok := createResponse.Entity.(*trip.Trip)
+	if !ok {
+		return nil, sigbugTripRecordHandlerException.Create{Reasons: []string{"could not cast created entity to sigbug trip"}}
+	}
+
+	return &sigbugTripRecordHandler.CreateResponse{
+		Trip: *createdTrip,
+	}, nil
+}
+
+func (r *RecordHandler) Retrieve(request *sigbugTripRecordHandler.RetrieveRequest) (*sigbugTripRecordHandler.RetrieveResponse, error) {
+	retrievedTrip := trip.Trip{}
+	retrieveResponse := brainRecordHandler.RetrieveResponse{
+		Entity: &retrievedTrip,
+	}
+	if err := r.sigbugTripRecordHandler.Retrieve(&brainRecordHandler.RetrieveRequest{
+		Claims:     request.Claims,
+		Identifier: request.Identifier,
+	}, &retrieveResponse); err != nil {
+		switch err.(type) {
+		case brainRecordHandlerException.NotFound:
+			return nil, sigbugTripRecordHandlerException.NotFound{}
+		default:
+			return nil, err
+		}
+	}
+
+	return &sigbugTripRecordHandler.RetrieveResponse{
+		Trip: retrievedTrip,
+	}, nil
+}
+
+func (r *RecordHandler) Update(request *sigbugTripRecordHandler.UpdateRequest) (*sigbugTripRecordHandler.UpdateResponse, error) {
+	updateResponse := brainRecordHandler.UpdateResponse{}
+	if err := r.sigbugTripRecordHandler.Update(&brainRecordHandler.UpdateRequest{
+		Claims:     request.Claims,
+		Identifier: request.Identifier,
+		Entity:     &request.Trip,
+	}, &updateResponse); err != nil {
+		return nil, sigbugTripRecordHandlerException.Update{Reasons: []string{err.Error()}}
+	}
+
+	return &sigbugTripRecordHandler.UpdateResponse{}, nil
+}
+
+func (r *RecordHandler) Delete(request *sigbugTripRecordHandler.DeleteRequest) (*sigbugTripRecordHandler.DeleteResponse, error) {
+	deleteResponse := brainRecordHandler.DeleteResponse{}
+	if err := r.sigbugTripRecordHandler.Delete(&brainRecordHandler.DeleteRequest{
+		Claims:     request.Claims,
+		Identifier: request.Identifier,
+	}, &deleteResponse); err != nil {
+		return nil, sigbugTripRecordHandlerException.Delete{Reasons: []string{err.Error()}}
+	}
+
+	return &sigbugTripRecordHandler.DeleteResponse{}, nil
+}
+
+func (r *RecordHandler) Collect(request *sigbugTripRecordHandler.CollectRequest) (*sigbugTripRecordHandler.CollectResponse, error) {
+	var collectedTrip []trip.Trip
+	collectResponse := brainRecordHandler.CollectResponse{
+		Records: &collectedTrip,
+	}
+	err := r.sigbugTripRecordHandler.Collect(&brainRecordHandler.CollectRequest{
+		Claims:   request.Claims,
+		Criteria: request.Criteria,
+		Query:    request.Query,
+	}, &collectResponse)
+	if err != nil {
+		return nil, sigbugTripRecordHandlerException.Collect{Reasons: []string{err.Error()}}
+	}
+
+	if collectedTrip == nil {
+		collectedTrip = make([]trip.Trip, 0)
+	}
+
+	return &sigbugTripRecordHandler.CollectResponse{
+		Records: collectedTrip,
+		Total:   collectResponse.Total,
+	}, nil
+}
+
+func (r *RecordHandler) DeleteAll(request *sigbugTripRecordHandler.DeleteAllRequest) (*sigbugTripRecordHandler.DeleteAllResponse, error) {
+	deleteAllResponse := brainRecordHandler.DeleteAllResponse{}
+	if err := r.sigbugTripRecordHandler.DeleteAll(&brainRecordHandler.DeleteAllRequest{
+		Claims:   request.Claims,
+		Criteria: request.Criteria,
+	}, &deleteAllResponse); err != nil {
+		return nil, sigbugTripRecordHandlerException.Delete{Reasons: []string{err.Error()}}
+	}
+
+	return &sigbugTripRecordHandler.DeleteAllResponse{
+		NoDeleted: deleteAllResponse.NoDeleted,
+	}, nil
+}
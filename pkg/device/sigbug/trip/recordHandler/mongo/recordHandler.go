@@ -0,0 +1,40 @@
+package mongo
+
+import (
+	"github.com/iot-my-world/brain/pkg/device/sigbug/trip"
+	sigbugTripRecordHandler "github.com/iot-my-world/brain/pkg/device/sigbug/trip/recordHandler"
+	sigbugTripGenericRecordHandler "github.com/iot-my-world/brain/pkg/device/sigbug/trip/recordHandler/generic"
+	brainMongoRecordHandler "github.com/iot-my-world/brain/pkg/recordHandler/mongo"
+	"github.com/iot-my-world/brain/pkg/security/claims"
+	"gopkg.in/mgo.v2"
+)
+
+func New(
+	mongoSession *mgo.Session,
+	databaseName string,
+	collectionName string,
+) sigbugTripRecordHandler.RecordHandler {
+	mongoRecordHandler := brainMongoRecordHandler.New(
+		mongoSession,
+		databaseName,
+		collectionName,
+		[]mgo.Index{
+			{
+				Key:    []string{"id"},
+				Unique: true,
+			},
+			{
+				Key: []string{"deviceId.id", "endTimeStamp"},
+			},
+			{
+				Key: []string{"deviceId.id", "startTimeStamp"},
+			},
+		},
+		trip.IsValidIdentifier,
+		claims.ContextualiseFilter,
+	)
+
+	return sigbugTripGenericRecordHandler.New(
+		mongoRecordHandler,
+	)
+}
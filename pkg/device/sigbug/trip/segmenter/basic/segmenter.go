@@ -0,0 +1,524 @@
+package basic
+
+import (
+	brainException "github.com/iot-my-world/brain/internal/exception"
+	"github.com/iot-my-world/brain/internal/log"
+	"github.com/iot-my-world/brain/pkg/device/sigbug/reading/gps"
+	sigbugGPSReadingRecordHandler "github.com/iot-my-world/brain/pkg/device/sigbug/reading/gps/recordHandler"
+	"github.com/iot-my-world/brain/pkg/device/sigbug/trip"
+	sigbugTripRecordHandler "github.com/iot-my-world/brain/pkg/device/sigbug/trip/recordHandler"
+	sigbugTripSegmenter "github.com/iot-my-world/brain/pkg/device/sigbug/trip/segmenter"
+	sigbugTripSegmenterException "github.com/iot-my-world/brain/pkg/device/sigbug/trip/segmenter/exception"
+	"github.com/iot-my-world/brain/pkg/search/criterion"
+	exactTextCriterion "github.com/iot-my-world/brain/pkg/search/criterion/exact/text"
+	listTextCriterion "github.com/iot-my-world/brain/pkg/search/criterion/list/text"
+	numberRangeCriterion "github.com/iot-my-world/brain/pkg/search/criterion/range/number"
+	"github.com/iot-my-world/brain/pkg/search/identifier/id"
+	"github.com/iot-my-world/brain/pkg/search/query"
+	"github.com/iot-my-world/brain/pkg/security/claims"
+	"hash/fnv"
+	"sync"
+	"time"
+)
+
+// deviceLockShards is the number of locks over which the
+// segmenting of the readings of devices is serialised
+const deviceLockShards = 64
+
+// replayBatchSize is the number of readings collected at a time
+// when the trips of a device are segmented again
+const replayBatchSize = 500
+
+type segmenter struct {
+	sigbugTripRecordHandler       sigbugTripRecordHandler.RecordHandler
+	sigbugGPSReadingRecordHandler sigbugGPSReadingRecordHandler.RecordHandler
+	dwellRadius                   float32
+	dwellTime                     int64
+	maxGap                        int64
+
+	// deviceLocks serialise the segmenting of the readings of each device,
+	// since the latest trip of a device is read, updated and written back
+	deviceLocks [deviceLockShards]sync.Mutex
+}
+
+// New creates a segmenter which takes a device to have stopped once its
+// readings have remained within dwellRadius metres of one place for at least
+// dwellTime. A gap of more than maxGap between two readings ends the trip of
+// a device, with the next reading starting a new one. Readings which arrive
+// out of order are segmented by segmenting the readings of the device again
+// from the start of the trip in which they fall.
+func New(
+	sigbugTripRecordHandler sigbugTripRecordHandler.RecordHandler,
+	sigbugGPSReadingRecordHandler sigbugGPSReadingRecordHandler.RecordHandler,
+	dwellRadius float32,
+	dwellTime time.Duration,
+	maxGap time.Duration,
+) sigbugTripSegmenter.Segmenter {
+	return &segmenter{
+		sigbugTripRecordHandler:       sigbugTripRecordHandler,
+		sigbugGPSReadingRecordHandler: sigbugGPSReadingRecordHandler,
+		dwellRadius:                   dwellRadius,
+		dwellTime:                     int64(dwellTime.Seconds()),
+		maxGap:                        int64(maxGap.Seconds()),
+	}
+}
+
+func (s *segmenter) ValidateSegmentRequest(request *sigbugTripSegmenter.SegmentRequest) error {
+	reasonsInvalid := make([]string, 0)
+
+	if request.Claims == nil {
+		reasonsInvalid = append(reasonsInvalid, "claims are nil")
+	}
+
+	if request.Reading.DeviceId.Id == "" {
+		reasonsInvalid = append(reasonsInvalid, "reading device id is blank")
+	}
+
+	if len(reasonsInvalid) > 0 {
+		return brainException.RequestInvalid{Reasons: reasonsInvalid}
+	}
+	return nil
+}
+
+func (s *segmenter) Segment(request *sigbugTripSegmenter.SegmentRequest) (*sigbugTripSegmenter.SegmentResponse, error) {
+	if err := s.ValidateSegmentRequest(request); err != nil {
+		log.Error(err.Error())
+		return nil, err
+	}
+
+	unlock := s.lockDevice(request.Reading.DeviceId.Id)
+	defer unlock()
+
+	// retrieve the latest trip of the device
+	latestTrip, err := s.tripBefore(request.Claims, request.Reading.DeviceId.Id, "endTimeStamp", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// a reading which arrives after readings taken later than it
+	// changes the trips which were segmented from those readings
+	if latestTrip != nil && request.Reading.TimeStamp <= latestTrip.EndTimeStamp {
+		return s.resegment(request)
+	}
+
+	updatedTrip, startedTrip := s.next(latestTrip, &request.Reading)
+
+	trips := make([]trip.Trip, 0)
+	if updatedTrip != nil {
+		if err := s.updateTrip(request.Claims, updatedTrip); err != nil {
+			return nil, err
+		}
+		trips = append(trips, *updatedTrip)
+	}
+
+	if startedTrip != nil {
+		createdTrip, err := s.createTrip(startedTrip)
+		if err != nil {
+			return nil, err
+		}
+		trips = append(trips, *createdTrip)
+	}
+
+	return &sigbugTripSegmenter.SegmentResponse{Trips: trips}, nil
+}
+
+// resegment segments the readings of the device of the given late reading
+// again, from the start of the trip in which the reading falls. The trips
+// from then on are replaced with those segmented again, with the trip before
+// them continued as it was when the readings were first segmented.
+func (s *segmenter) resegment(request *sigbugTripSegmenter.SegmentRequest) (*sigbugTripSegmenter.SegmentResponse, error) {
+	deviceId := request.Reading.DeviceId.Id
+
+	// find the trip in which the reading falls and the trip before it
+	replayFrom := request.Reading.TimeStamp
+	readingTrip, err := s.tripBefore(request.Claims, deviceId, "startTimeStamp", &numberRangeCriterion.RangeValue{
+		Number:    float64(request.Reading.TimeStamp),
+		Inclusive: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+	var previousTrip *trip.Trip
+	if readingTrip != nil {
+		replayFrom = readingTrip.StartTimeStamp
+		if previousTrip, err = s.tripBefore(request.Claims, deviceId, "startTimeStamp", &numberRangeCriterion.RangeValue{
+			Number: float64(replayFrom),
+		}); err != nil {
+			return nil, err
+		}
+	}
+
+	// segment the readings from then on again, continuing the previous trip.
+	// readings at the end of the previous trip are already part of it.
+	segmentedTrips := make([]*trip.Trip, 0)
+	latestTrip := previousTrip
+	previousTripUpdated := false
+	for offset := 0; ; offset += replayBatchSize {
+		readingCollectResponse, err := s.sigbugGPSReadingRecordHandler.Collect(&sigbugGPSReadingRecordHandler.CollectRequest{
+			Claims: request.Claims,
+			Criteria: []criterion.Criterion{
+				exactTextCriterion.Criterion{
+					Field: "deviceId.id",
+					Text:  deviceId,
+				},
+				numberRangeCriterion.Criterion{
+					Field: "timeStamp",
+					Start: numberRangeCriterion.RangeValue{
+						Number:    float64(replayFrom),
+						Inclusive: true,
+					},
+					End: numberRangeCriterion.RangeValue{Ignore: true},
+				},
+			},
+			Query: query.Query{
+				Limit:  replayBatchSize,
+				Offset: offset,
+				Order:  []query.SortOrder{query.SortOrderAscending, query.SortOrderAscending},
+				SortBy: []string{"timeStamp", "id"},
+			},
+		})
+		if err != nil {
+			err = sigbugTripSegmenterException.Resegmenting{Reasons: []string{"collecting readings", err.Error()}}
+			log.Error(err.Error())
+			return nil, err
+		}
+
+		for readingIdx := range readingCollectResponse.Records {
+			updatedTrip, startedTrip := s.next(latestTrip, &readingCollectResponse.Records[readingIdx])
+			if updatedTrip != nil && updatedTrip == previousTrip {
+				previousTripUpdated = true
+			}
+			if startedTrip != nil {
+				segmentedTrips = append(segmentedTrips, startedTrip)
+				latestTrip = startedTrip
+			}
+		}
+
+		if len(readingCollectResponse.Records) < replayBatchSize {
+			break
+		}
+	}
+
+	// the trips from then on are replaced with those segmented again. those
+	// segmented again keep the ids of the trips which started at the same
+	// time, and only the trips which are left over are deleted once those
+	// segmented again have been stored.
+	replacedTrips, err := s.tripsFrom(request.Claims, deviceId, replayFrom)
+	if err != nil {
+		return nil, err
+	}
+	replacedTripIds := make(map[int64]string)
+	for _, replacedTrip := range replacedTrips {
+		replacedTripIds[replacedTrip.StartTimeStamp] = replacedTrip.Id
+	}
+
+	trips := make([]trip.Trip, 0)
+	if previousTripUpdated {
+		if err := s.updateTrip(request.Claims, previousTrip); err != nil {
+			return nil, err
+		}
+		trips = append(trips, *previousTrip)
+	}
+	keptTripIds := make(map[string]bool)
+	for _, segmentedTrip := range segmentedTrips {
+		if replacedTripId, found := replacedTripIds[segmentedTrip.StartTimeStamp]; found && !keptTripIds[replacedTripId] {
+			segmentedTrip.Id = replacedTripId
+			if err := s.updateTrip(request.Claims, segmentedTrip); err != nil {
+				return nil, err
+			}
+			keptTripIds[replacedTripId] = true
+			trips = append(trips, *segmentedTrip)
+			continue
+		}
+		createdTrip, err := s.createTrip(segmentedTrip)
+		if err != nil {
+			return nil, err
+		}
+		trips = append(trips, *createdTrip)
+	}
+
+	deletedTripIds := make([]string, 0)
+	for _, replacedTrip := range replacedTrips {
+		if !keptTripIds[replacedTrip.Id] {
+			deletedTripIds = append(deletedTripIds, replacedTrip.Id)
+		}
+	}
+	if len(deletedTripIds) > 0 {
+		if _, err := s.sigbugTripRecordHandler.DeleteAll(&sigbugTripRecordHandler.DeleteAllRequest{
+			Claims: request.Claims,
+			Criteria: []criterion.Criterion{
+				exactTextCriterion.Criterion{
+					Field: "deviceId.id",
+					Text:  deviceId,
+				},
+				listTextCriterion.Criterion{
+					Field: "id",
+					List:  deletedTripIds,
+				},
+			},
+		}); err != nil {
+			err = sigbugTripSegmenterException.Resegmenting{Reasons: []string{"deleting trips", err.Error()}}
+			log.Error(err.Error())
+			return nil, err
+		}
+	}
+
+	return &sigbugTripSegmenter.SegmentResponse{Trips: trips}, nil
+}
+
+// tripBefore returns the trip of the device with the greatest value of the
+// given time stamp field, which is before the given time if it is given.
+// nil is returned if the device has no such trip.
+func (s *segmenter) tripBefore(claims claims.Claims, deviceId, timeStampField string, before *numberRangeCriterion.RangeValue) (*trip.Trip, error) {
+	criteria := []criterion.Criterion{
+		exactTextCriterion.Criterion{
+			Field: "deviceId.id",
+			Text:  deviceId,
+		},
+	}
+	if before != nil {
+		criteria = append(criteria, numberRangeCriterion.Criterion{
+			Field: timeStampField,
+			Start: numberRangeCriterion.RangeValue{Ignore: true},
+			End:   *before,
+		})
+	}
+
+	tripCollectResponse, err := s.sigbugTripRecordHandler.Collect(&sigbugTripRecordHandler.CollectRequest{
+		Claims:   claims,
+		Criteria: criteria,
+		Query: query.Query{
+			Limit:  1,
+			Order:  []query.SortOrder{query.SortOrderDescending},
+			SortBy: []string{timeStampField},
+		},
+	})
+	if err != nil {
+		err = sigbugTripSegmenterException.RetrievingLatestTrip{Reasons: []string{err.Error()}}
+		log.Error(err.Error())
+		return nil, err
+	}
+	if len(tripCollectResponse.Records) == 0 {
+		return nil, nil
+	}
+	return &tripCollectResponse.Records[0], nil
+}
+
+// tripsFrom returns the trips of the device which start at or after the given time
+func (s *segmenter) tripsFrom(claims claims.Claims, deviceId string, from int64) ([]trip.Trip, error) {
+	trips := make([]trip.Trip, 0)
+	for offset := 0; ; offset += replayBatchSize {
+		tripCollectResponse, err := s.sigbugTripRecordHandler.Collect(&sigbugTripRecordHandler.CollectRequest{
+			Claims: claims,
+			Criteria: []criterion.Criterion{
+				exactTextCriterion.Criterion{
+					Field: "deviceId.id",
+					Text:  deviceId,
+				},
+				numberRangeCriterion.Criterion{
+					Field: "startTimeStamp",
+					Start: numberRangeCriterion.RangeValue{
+						Number:    float64(from),
+						Inclusive: true,
+					},
+					End: numberRangeCriterion.RangeValue{Ignore: true},
+				},
+			},
+			Query: query.Query{
+				Limit:  replayBatchSize,
+				Offset: offset,
+				Order:  []query.SortOrder{query.SortOrderAscending, query.SortOrderAscending},
+				SortBy: []string{"startTimeStamp", "id"},
+			},
+		})
+		if err != nil {
+			err = sigbugTripSegmenterException.Resegmenting{Reasons: []string{"collecting trips", err.Error()}}
+			log.Error(err.Error())
+			return nil, err
+		}
+		trips = append(trips, tripCollectResponse.Records...)
+		if len(tripCollectResponse.Records) < replayBatchSize {
+			return trips, nil
+		}
+	}
+}
+
+func (s *segmenter) updateTrip(claims claims.Claims, tripToUpdate *trip.Trip) error {
+	if _, err := s.sigbugTripRecordHandler.Update(&sigbugTripRecordHandler.UpdateRequest{
+		Claims:     claims,
+		Identifier: id.Identifier{Id: tripToUpdate.Id},
+		Trip:       *tripToUpdate,
+	}); err != nil {
+		err = sigbugTripSegmenterException.UpdatingTrip{Reasons: []string{err.Error()}}
+		log.Error(err.Error())
+		return err
+	}
+	return nil
+}
+
+func (s *segmenter) createTrip(tripToCreate *trip.Trip) (*trip.Trip, error) {
+	createResponse, err := s.sigbugTripRecordHandler.Create(&sigbugTripRecordHandler.CreateRequest{
+		Trip: *tripToCreate,
+	})
+	if err != nil {
+		err = sigbugTripSegmenterException.CreatingTrip{Reasons: []string{err.Error()}}
+		log.Error(err.Error())
+		return nil, err
+	}
+	return &createResponse.Trip, nil
+}
+
+// lockDevice locks the segmenting of the readings of the given
+// device, returning the function with which to unlock it
+func (s *segmenter) lockDevice(deviceId string) func() {
+	hash := fnv.New32a()
+	_, _ = hash.Write([]byte(deviceId))
+	deviceLock := &s.deviceLocks[hash.Sum32()%deviceLockShards]
+	deviceLock.Lock()
+	return deviceLock.Unlock
+}
+
+// next returns the given latest trip of a device updated with the given
+// reading, if it was, and the new trip started by the reading, if any
+func (s *segmenter) next(latestTrip *trip.Trip, reading *gps.Reading) (*trip.Trip, *trip.Trip) {
+	// readings without a location do not place the device anywhere
+	if reading.Location == nil {
+		return nil, nil
+	}
+
+	// a device is taken to be stopped when it is first seen or
+	// reappears after a gap, until it is seen to move
+	if latestTrip == nil || reading.TimeStamp-latestTrip.EndTimeStamp > s.maxGap {
+		return nil, newTrip(trip.Stopped, reading)
+	}
+
+	// readings already segmented into the trip are ignored
+	if reading.TimeStamp <= latestTrip.EndTimeStamp {
+		return nil, nil
+	}
+
+	switch latestTrip.Type {
+	case trip.Stopped:
+		startReading := gps.Reading{
+			Latitude:  latestTrip.StartLatitude,
+			Longitude: latestTrip.StartLongitude,
+		}
+		if gps.DifferenceBetween(&startReading, reading) <= s.dwellRadius {
+			extend(latestTrip, reading)
+			return latestTrip, nil
+		}
+
+		// a stop which was never long enough to count as one was
+		// really the start of a moving trip
+		if latestTrip.Duration < s.dwellTime {
+			latestTrip.Type = trip.Moving
+			travel(latestTrip, reading)
+			startDwell(latestTrip, reading)
+			return latestTrip, nil
+		}
+
+		// the device has left the stop, and so the moving
+		// trip starts where the stop ended
+		stopEndReading := *reading
+		stopEndReading.TimeStamp = latestTrip.EndTimeStamp
+		stopEndReading.Latitude = latestTrip.EndLatitude
+		stopEndReading.Longitude = latestTrip.EndLongitude
+		movingTrip := newTrip(trip.Moving, &stopEndReading)
+		travel(movingTrip, reading)
+		startDwell(movingTrip, reading)
+		return nil, movingTrip
+
+	default:
+		distance := travel(latestTrip, reading)
+
+		dwellReading := gps.Reading{
+			Latitude:  latestTrip.DwellLatitude,
+			Longitude: latestTrip.DwellLongitude,
+		}
+		if gps.DifferenceBetween(&dwellReading, reading) > s.dwellRadius {
+			startDwell(latestTrip, reading)
+			return latestTrip, nil
+		}
+		latestTrip.DwellDistance += distance
+		latestTrip.DwellNoReadings++
+		if reading.TimeStamp-latestTrip.DwellTimeStamp < s.dwellTime {
+			return latestTrip, nil
+		}
+
+		// the device has dwelt in one place for long enough to
+		// have stopped, so the dwell is split off into a stop
+		dwellStartReading := *reading
+		dwellStartReading.TimeStamp = latestTrip.DwellTimeStamp
+		dwellStartReading.Latitude = latestTrip.DwellLatitude
+		dwellStartReading.Longitude = latestTrip.DwellLongitude
+		stoppedTrip := newTrip(trip.Stopped, &dwellStartReading)
+		extend(stoppedTrip, reading)
+		stoppedTrip.NoReadings = latestTrip.DwellNoReadings + 1
+
+		latestTrip.EndTimeStamp = dwellStartReading.TimeStamp
+		latestTrip.EndLatitude = dwellStartReading.Latitude
+		latestTrip.EndLongitude = dwellStartReading.Longitude
+		latestTrip.Duration = latestTrip.EndTimeStamp - latestTrip.StartTimeStamp
+		latestTrip.Distance -= latestTrip.DwellDistance
+		latestTrip.NoReadings -= latestTrip.DwellNoReadings
+		startDwell(latestTrip, &dwellStartReading)
+
+		return latestTrip, stoppedTrip
+	}
+}
+
+// newTrip returns a trip of the given type which starts and ends with the given reading
+func newTrip(tripType trip.Type, reading *gps.Reading) *trip.Trip {
+	newTrip := &trip.Trip{
+		Type:              tripType,
+		DeviceId:          reading.DeviceId,
+		OwnerPartyType:    reading.OwnerPartyType,
+		OwnerId:           reading.OwnerId,
+		AssignedPartyType: reading.AssignedPartyType,
+		AssignedId:        reading.AssignedId,
+		StartTimeStamp:    reading.TimeStamp,
+		StartLatitude:     reading.Latitude,
+		StartLongitude:    reading.Longitude,
+		EndTimeStamp:      reading.TimeStamp,
+		EndLatitude:       reading.Latitude,
+		EndLongitude:      reading.Longitude,
+		NoReadings:        1,
+	}
+	startDwell(newTrip, reading)
+	return newTrip
+}
+
+// extend ends the given trip at the given reading
+func extend(tripToExtend *trip.Trip, reading *gps.Reading) {
+	tripToExtend.EndTimeStamp = reading.TimeStamp
+	tripToExtend.EndLatitude = reading.Latitude
+	tripToExtend.EndLongitude = reading.Longitude
+	tripToExtend.Duration = tripToExtend.EndTimeStamp - tripToExtend.StartTimeStamp
+	tripToExtend.NoReadings++
+}
+
+// travel extends the given trip to the given reading, adding the distance
+// travelled from the previous end of the trip, which is returned
+func travel(tripToExtend *trip.Trip, reading *gps.Reading) float32 {
+	previousReading := gps.Reading{
+		TimeStamp: tripToExtend.EndTimeStamp,
+		Latitude:  tripToExtend.EndLatitude,
+		Longitude: tripToExtend.EndLongitude,
+	}
+	distance := gps.DifferenceBetween(&previousReading, reading)
+	if speed := gps.SpeedBetween(&previousReading, reading); speed > tripToExtend.MaxSpeed {
+		tripToExtend.MaxSpeed = speed
+	}
+	tripToExtend.Distance += distance
+	extend(tripToExtend, reading)
+	return distance
+}
+
+// startDwell starts a new dwell on the given trip at the given reading
+func startDwell(tripToDwell *trip.Trip, reading *gps.Reading) {
+	tripToDwell.DwellTimeStamp = reading.TimeStamp
+	tripToDwell.DwellLatitude = reading.Latitude
+	tripToDwell.DwellLongitude = reading.Longitude
+	tripToDwell.DwellDistance = 0
+	tripToDwell.DwellNoReadings = 0
+}
@@ -0,0 +1,341 @@
+package basic
+
+import (
+	"github.com/iot-my-world/brain/pkg/device/sigbug/reading/gps"
+	sigbugGPSReadingRecordHandler "github.com/iot-my-world/brain/pkg/device/sigbug/reading/gps/recordHandler"
+	"github.com/iot-my-world/brain/pkg/device/sigbug/trip"
+	sigbugTripRecordHandler "github.com/iot-my-world/brain/pkg/device/sigbug/trip/recordHandler"
+	sigbugTripSegmenter "github.com/iot-my-world/brain/pkg/device/sigbug/trip/segmenter"
+	"github.com/iot-my-world/brain/pkg/search/criterion"
+	listTextCriterion "github.com/iot-my-world/brain/pkg/search/criterion/list/text"
+	numberRangeCriterion "github.com/iot-my-world/brain/pkg/search/criterion/range/number"
+	"github.com/iot-my-world/brain/pkg/search/identifier/id"
+	"github.com/iot-my-world/brain/pkg/search/query"
+	humanUserLoginClaims "github.com/iot-my-world/brain/pkg/security/claims/login/user/human"
+	"github.com/stretchr/testify/assert"
+	"sort"
+	"strconv"
+	"testing"
+	"time"
+)
+
+// fakeTripRecordHandler stores the trips of a single device in memory. It
+// supports the time stamp range criteria and ordering used by the segmenter.
+type fakeTripRecordHandler struct {
+	sigbugTripRecordHandler.RecordHandler
+	trips  []trip.Trip
+	nextId int
+}
+
+func (r *fakeTripRecordHandler) Create(request *sigbugTripRecordHandler.CreateRequest) (*sigbugTripRecordHandler.CreateResponse, error) {
+	r.nextId++
+	request.Trip.Id = strconv.Itoa(r.nextId)
+	r.trips = append(r.trips, request.Trip)
+	return &sigbugTripRecordHandler.CreateResponse{Trip: request.Trip}, nil
+}
+
+func (r *fakeTripRecordHandler) Update(request *sigbugTripRecordHandler.UpdateRequest) (*sigbugTripRecordHandler.UpdateResponse, error) {
+	for tripIdx := range r.trips {
+		if r.trips[tripIdx].Id == request.Identifier.(id.Identifier).Id {
+			r.trips[tripIdx] = request.Trip
+		}
+	}
+	return &sigbugTripRecordHandler.UpdateResponse{}, nil
+}
+
+func timeStampField(t trip.Trip, field string) int64 {
+	switch field {
+	case "startTimeStamp":
+		return t.StartTimeStamp
+	case "endTimeStamp":
+		return t.EndTimeStamp
+	default:
+		panic("unsupported field " + field)
+	}
+}
+
+func (r *fakeTripRecordHandler) matches(t trip.Trip, criteria []criterion.Criterion) bool {
+	for _, c := range criteria {
+		switch typedCriterion := c.(type) {
+		case numberRangeCriterion.Criterion:
+			timeStamp := float64(timeStampField(t, typedCriterion.Field))
+			if !typedCriterion.Start.Ignore && timeStamp < typedCriterion.Start.Number {
+				return false
+			}
+			if !typedCriterion.End.Ignore && (timeStamp > typedCriterion.End.Number ||
+				(!typedCriterion.End.Inclusive && timeStamp == typedCriterion.End.Number)) {
+				return false
+			}
+		case listTextCriterion.Criterion:
+			listed := false
+			for _, tripId := range typedCriterion.List {
+				listed = listed || tripId == t.Id
+			}
+			if !listed {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// Collect returns a page of the matching trips ordered by the first sort field
+func (r *fakeTripRecordHandler) Collect(request *sigbugTripRecordHandler.CollectRequest) (*sigbugTripRecordHandler.CollectResponse, error) {
+	records := make([]trip.Trip, 0)
+	for _, t := range r.trips {
+		if r.matches(t, request.Criteria) {
+			records = append(records, t)
+		}
+	}
+	sort.SliceStable(records, func(i, j int) bool {
+		if request.Query.Order[0] == query.SortOrderDescending {
+			return timeStampField(records[i], request.Query.SortBy[0]) > timeStampField(records[j], request.Query.SortBy[0])
+		}
+		return timeStampField(records[i], request.Query.SortBy[0]) < timeStampField(records[j], request.Query.SortBy[0])
+	})
+	total := len(records)
+	if request.Query.Offset >= len(records) {
+		records = make([]trip.Trip, 0)
+	} else {
+		records = records[request.Query.Offset:]
+	}
+	if len(records) > request.Query.Limit {
+		records = records[:request.Query.Limit]
+	}
+	return &sigbugTripRecordHandler.CollectResponse{Records: records, Total: total}, nil
+}
+
+func (r *fakeTripRecordHandler) DeleteAll(request *sigbugTripRecordHandler.DeleteAllRequest) (*sigbugTripRecordHandler.DeleteAllResponse, error) {
+	remainingTrips := make([]trip.Trip, 0)
+	for _, t := range r.trips {
+		if !r.matches(t, request.Criteria) {
+			remainingTrips = append(remainingTrips, t)
+		}
+	}
+	noDeleted := len(r.trips) - len(remainingTrips)
+	r.trips = remainingTrips
+	return &sigbugTripRecordHandler.DeleteAllResponse{NoDeleted: noDeleted}, nil
+}
+
+// fakeGPSReadingRecordHandler stores the readings of a single device in memory
+type fakeGPSReadingRecordHandler struct {
+	sigbugGPSReadingRecordHandler.RecordHandler
+	readings []gps.Reading
+}
+
+// Collect returns the readings from the start of the time stamp range criterion in time order
+func (r *fakeGPSReadingRecordHandler) Collect(request *sigbugGPSReadingRecordHandler.CollectRequest) (*sigbugGPSReadingRecordHandler.CollectResponse, error) {
+	from := int64(0)
+	for _, c := range request.Criteria {
+		if rangeCriterion, ok := c.(numberRangeCriterion.Criterion); ok {
+			from = int64(rangeCriterion.Start.Number)
+		}
+	}
+	records := make([]gps.Reading, 0)
+	for _, r := range r.readings {
+		if r.TimeStamp >= from {
+			records = append(records, r)
+		}
+	}
+	sort.Slice(records, func(i, j int) bool {
+		return records[i].TimeStamp < records[j].TimeStamp
+	})
+	if request.Query.Offset >= len(records) {
+		records = make([]gps.Reading, 0)
+	} else {
+		records = records[request.Query.Offset:]
+	}
+	if len(records) > request.Query.Limit {
+		records = records[:request.Query.Limit]
+	}
+	return &sigbugGPSReadingRecordHandler.CollectResponse{Records: records, Total: len(records)}, nil
+}
+
+func reading(timeStamp int64, longitude float32) gps.Reading {
+	r := gps.Reading{
+		DeviceId:  id.Identifier{Id: "1234"},
+		TimeStamp: timeStamp,
+		Latitude:  -26.2041,
+		Longitude: longitude,
+	}
+	r.SetLocation()
+	return r
+}
+
+func distance(from, to gps.Reading) float32 {
+	return gps.DifferenceBetween(&from, &to)
+}
+
+// newTestSegmenter returns a segmenter and a function which stores
+// and segments a reading, as the sink does, returning the trips
+// created or updated
+func newTestSegmenter(t *testing.T) (*fakeTripRecordHandler, func(r gps.Reading) []trip.Trip) {
+	recordHandler := &fakeTripRecordHandler{trips: make([]trip.Trip, 0)}
+	readingRecordHandler := &fakeGPSReadingRecordHandler{readings: make([]gps.Reading, 0)}
+	segmenter := New(recordHandler, readingRecordHandler, 100, 5*time.Minute, 30*time.Minute)
+
+	return recordHandler, func(r gps.Reading) []trip.Trip {
+		readingRecordHandler.readings = append(readingRecordHandler.readings, r)
+		response, err := segmenter.Segment(&sigbugTripSegmenter.SegmentRequest{
+			Claims:  humanUserLoginClaims.Login{},
+			Reading: r,
+		})
+		assert.Nil(t, err, "segment error")
+		return response.Trips
+	}
+}
+
+func TestSegment(t *testing.T) {
+	recordHandler, segment := newTestSegmenter(t)
+
+	// a device is stopped when first seen
+	trips := segment(reading(0, 0))
+	if assert.Len(t, trips, 1) {
+		assert.Equal(t, trip.Stopped, trips[0].Type)
+	}
+	segment(reading(60, 0.0001))
+	assert.Equal(t, int64(60), recordHandler.trips[0].Duration, "stop duration")
+
+	// moving before the dwell time has passed makes the stop a moving trip
+	segment(reading(120, 0.01))
+	segment(reading(180, 0.02))
+	assert.Len(t, recordHandler.trips, 1)
+	assert.Equal(t, trip.Moving, recordHandler.trips[0].Type)
+	assert.Equal(t, 4, recordHandler.trips[0].NoReadings, "no readings")
+
+	// dwelling for the dwell time splits the dwell off into a stop
+	segment(reading(240, 0.0201))
+	trips = segment(reading(480, 0.02))
+	assert.Len(t, trips, 2)
+	if assert.Len(t, recordHandler.trips, 2) {
+		movingTrip := recordHandler.trips[0]
+		assert.Equal(t, int64(180), movingTrip.EndTimeStamp, "moving trip end")
+		assert.Equal(t, int64(180), movingTrip.Duration, "moving trip duration")
+		assert.Equal(t, 4, movingTrip.NoReadings, "moving trip no readings")
+		assert.InDelta(t,
+			distance(reading(60, 0.0001), reading(120, 0.01))+distance(reading(120, 0.01), reading(180, 0.02)),
+			movingTrip.Distance, 0.01, "moving trip distance")
+		fastestFrom, fastestTo := reading(120, 0.01), reading(180, 0.02)
+		assert.InDelta(t, gps.SpeedBetween(&fastestFrom, &fastestTo), movingTrip.MaxSpeed, 0.01, "moving trip max speed")
+
+		stoppedTrip := recordHandler.trips[1]
+		assert.Equal(t, trip.Stopped, stoppedTrip.Type)
+		assert.Equal(t, int64(180), stoppedTrip.StartTimeStamp, "stop start")
+		assert.Equal(t, int64(300), stoppedTrip.Duration, "stop duration")
+		assert.Equal(t, 3, stoppedTrip.NoReadings, "stop no readings")
+	}
+
+	// leaving a stop starts a moving trip where the stop ended
+	segment(reading(540, 0.0205))
+	trips = segment(reading(600, 0.03))
+	if assert.Len(t, trips, 1) {
+		assert.Equal(t, trip.Moving, trips[0].Type)
+		assert.Equal(t, int64(540), trips[0].StartTimeStamp, "moving trip start")
+		assert.InDelta(t, distance(reading(540, 0.0205), reading(600, 0.03)), trips[0].Distance, 0.01, "moving trip distance")
+	}
+	assert.Equal(t, int64(540), recordHandler.trips[1].EndTimeStamp, "stop end")
+
+	// a reading out of order segments the trip in which it falls again
+	trips = segment(reading(590, 0.04))
+	if assert.Len(t, trips, 1) {
+		assert.Equal(t, trip.Moving, trips[0].Type)
+		assert.Equal(t, int64(540), trips[0].StartTimeStamp, "moving trip start")
+		assert.Equal(t, int64(600), trips[0].EndTimeStamp, "moving trip end")
+		assert.Equal(t, 3, trips[0].NoReadings, "moving trip no readings")
+		assert.InDelta(t,
+			distance(reading(540, 0.0205), reading(590, 0.04))+distance(reading(590, 0.04), reading(600, 0.03)),
+			trips[0].Distance, 0.01, "moving trip distance")
+	}
+	assert.Len(t, recordHandler.trips, 3)
+
+	// a gap ends the trip and the next reading starts a new one
+	trips = segment(reading(600+3600, 0.05))
+	if assert.Len(t, trips, 1) {
+		assert.Equal(t, trip.Stopped, trips[0].Type)
+	}
+	assert.Len(t, recordHandler.trips, 4)
+	assert.Equal(t, int64(600), recordHandler.trips[2].EndTimeStamp, "moving trip end")
+}
+
+func TestSegmentLateReadings(t *testing.T) {
+	readings := []gps.Reading{
+		reading(0, 0),
+		reading(60, 0.0001),
+		reading(120, 0.01),
+		reading(180, 0.02),
+		reading(240, 0.0201),
+		reading(480, 0.02),
+		reading(540, 0.0205),
+		reading(600, 0.03),
+		reading(660, 0.04),
+	}
+
+	inOrderRecordHandler, segmentInOrder := newTestSegmenter(t)
+	for _, r := range readings {
+		segmentInOrder(r)
+	}
+
+	// readings arriving late, including one before all of the others,
+	// are segmented into the same trips as those arriving in order
+	lateRecordHandler, segmentLate := newTestSegmenter(t)
+	for _, readingIdx := range []int{1, 2, 4, 3, 6, 7, 8, 5, 0} {
+		segmentLate(readings[readingIdx])
+	}
+
+	withoutIds := func(trips []trip.Trip) []trip.Trip {
+		sortedTrips := make([]trip.Trip, 0)
+		for _, t := range trips {
+			t.Id = ""
+			sortedTrips = append(sortedTrips, t)
+		}
+		sort.Slice(sortedTrips, func(i, j int) bool {
+			return sortedTrips[i].StartTimeStamp < sortedTrips[j].StartTimeStamp
+		})
+		return sortedTrips
+	}
+	assert.Len(t, inOrderRecordHandler.trips, 3)
+	assert.Equal(t, withoutIds(inOrderRecordHandler.trips), withoutIds(lateRecordHandler.trips))
+}
+
+func TestSegmentReadingWithoutLocation(t *testing.T) {
+	recordHandler, segment := newTestSegmenter(t)
+
+	segment(reading(0, 0.01))
+	noLocationReading := reading(60, 0.02)
+	noLocationReading.Latitude, noLocationReading.Longitude = 0, 0
+	noLocationReading.SetLocation()
+	assert.Empty(t, segment(noLocationReading))
+	if assert.Len(t, recordHandler.trips, 1) {
+		assert.Equal(t, int64(0), recordHandler.trips[0].EndTimeStamp, "stop end")
+		assert.Equal(t, 1, recordHandler.trips[0].NoReadings, "stop no readings")
+	}
+}
+
+func TestResegmentKeepsTripIds(t *testing.T) {
+	recordHandler, segment := newTestSegmenter(t)
+	for _, r := range []gps.Reading{
+		reading(0, 0.01),
+		reading(360, 0.01),
+		reading(420, 0.02),
+		reading(480, 0.03),
+	} {
+		segment(r)
+	}
+	tripIds := make(map[int64]string)
+	for _, segmentedTrip := range recordHandler.trips {
+		tripIds[segmentedTrip.StartTimeStamp] = segmentedTrip.Id
+	}
+	assert.Len(t, tripIds, 2)
+
+	// a late reading in the moving trip changes it but the trips keep their ids
+	trips := segment(reading(450, 0.05))
+	if assert.Len(t, trips, 1) {
+		assert.Equal(t, tripIds[trips[0].StartTimeStamp], trips[0].Id)
+		assert.Equal(t, 4, trips[0].NoReadings, "moving trip no readings")
+	}
+	if assert.Len(t, recordHandler.trips, 2) {
+		for _, resegmentedTrip := range recordHandler.trips {
+			assert.Equal(t, tripIds[resegmentedTrip.StartTimeStamp], resegmentedTrip.Id)
+		}
+	}
+}
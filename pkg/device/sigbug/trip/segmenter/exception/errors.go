@@ -0,0 +1,38 @@
+package exception
+
+import (
+	"fmt"
+	"strings"
+)
+
+type RetrievingLatestTrip struct {
+	Reasons []string
+}
+
+func (e RetrievingLatestTrip) Error() string {
+	return fmt.Sprintf("error retrieving latest trip: %s", strings.Join(e.Reasons, "; "))
+}
+
+type CreatingTrip struct {
+	Reasons []string
+}
+
+func (e CreatingTrip) Error() string {
+	return fmt.Sprintf("error creating trip: %s", strings.Join(e.Reasons, "; "))
+}
+
+type UpdatingTrip struct {
+	Reasons []string
+}
+
+func (e UpdatingTrip) Error() string {
+	return fmt.Sprintf("error updating trip: %s", strings.Join(e.Reasons, "; "))
+}
+
+type Resegmenting struct {
+	Reasons []string
+}
+
+func (e Resegmenting) Error() string {
+	return fmt.Sprintf("error segmenting readings again: %s", strings.Join(e.Reasons, "; "))
+}
@@ -0,0 +1,23 @@
+package segmenter
+
+import (
+	"github.com/iot-my-world/brain/pkg/device/sigbug/reading/gps"
+	"github.com/iot-my-world/brain/pkg/device/sigbug/trip"
+	"github.com/iot-my-world/brain/pkg/security/claims"
+)
+
+// Segmenter groups the gps readings of each device into trips and stops,
+// updating the trip summaries of a device as each new reading arrives
+type Segmenter interface {
+	Segment(request *SegmentRequest) (*SegmentResponse, error)
+}
+
+type SegmentRequest struct {
+	Claims  claims.Claims
+	Reading gps.Reading
+}
+
+type SegmentResponse struct {
+	// Trips which were created or updated with the reading
+	Trips []trip.Trip
+}
@@ -0,0 +1,60 @@
+package trip
+
+import (
+	"github.com/iot-my-world/brain/pkg/party"
+	"github.com/iot-my-world/brain/pkg/search/identifier/id"
+)
+
+type Type string
+
+// Moving is a trip over which a sigbug travelled from one place to another
+const Moving Type = "Moving"
+
+// Stopped is a trip over which a sigbug remained in one place, i.e. a stop
+const Stopped Type = "Stopped"
+
+// Trip summarises a run of consecutive gps readings over
+// which a sigbug was either moving or stopped
+type Trip struct {
+	Id   string `json:"id" bson:"id"`
+	Type Type   `json:"type" bson:"type"`
+
+	// Device Details
+	DeviceId id.Identifier `json:"deviceId" bson:"deviceId"`
+
+	// Owner Details, taken from the device
+	OwnerPartyType    party.Type    `json:"ownerPartyType" bson:"ownerPartyType"`
+	OwnerId           id.Identifier `json:"ownerId" bson:"ownerId"`
+	AssignedPartyType party.Type    `json:"assignedPartyType" bson:"assignedPartyType"`
+	AssignedId        id.Identifier `json:"assignedId" bson:"assignedId"`
+
+	// Start and End Details
+	StartTimeStamp int64   `json:"startTimeStamp" bson:"startTimeStamp"`
+	StartLatitude  float32 `json:"startLatitude" bson:"startLatitude"`
+	StartLongitude float32 `json:"startLongitude" bson:"startLongitude"`
+	EndTimeStamp   int64   `json:"endTimeStamp" bson:"endTimeStamp"`
+	EndLatitude    float32 `json:"endLatitude" bson:"endLatitude"`
+	EndLongitude   float32 `json:"endLongitude" bson:"endLongitude"`
+
+	// Summary Details
+	// Distance is in metres, Duration in seconds and MaxSpeed, the highest
+	// speed implied by any two consecutive readings, in km/h
+	Distance   float32 `json:"distance" bson:"distance"`
+	Duration   int64   `json:"duration" bson:"duration"`
+	MaxSpeed   float32 `json:"maxSpeed" bson:"maxSpeed"`
+	NoReadings int     `json:"noReadings" bson:"noReadings"`
+
+	// Dwell Details
+	// The readings at the end of a moving trip which have all been within the
+	// dwell radius of the first of them. Once they span the dwell time they are
+	// split off from the moving trip into a stop.
+	DwellTimeStamp  int64   `json:"dwellTimeStamp" bson:"dwellTimeStamp"`
+	DwellLatitude   float32 `json:"dwellLatitude" bson:"dwellLatitude"`
+	DwellLongitude  float32 `json:"dwellLongitude" bson:"dwellLongitude"`
+	DwellDistance   float32 `json:"dwellDistance" bson:"dwellDistance"`
+	DwellNoReadings int     `json:"dwellNoReadings" bson:"dwellNoReadings"`
+}
+
+func (t *Trip) SetId(id string) {
+	t.Id = id
+}
@@ -102,6 +102,15 @@ func (v *validator) Validate(request *sigbugValidator.ValidateRequest) (*sigbugV
 		})
 	}
 
+	if (*sigbugToValidate).SilenceThreshold < 0 {
+		allReasonsInvalid = append(allReasonsInvalid, reasonInvalid.ReasonInvalid{
+			Field: "silenceThreshold",
+			Type:  reasonInvalid.Invalid,
+			Help:  "cannot be negative",
+			Data:  (*sigbugToValidate).SilenceThreshold,
+		})
+	}
+
 	// action specific checks
 	switch request.Action {
 	case sigbugAction.Create:
@@ -0,0 +1,6 @@
+package action
+
+import "github.com/iot-my-world/brain/pkg/action"
+
+const Create action.Action = "Create"
+const UpdateAllowedFields action.Action = "UpdateAllowedFields"
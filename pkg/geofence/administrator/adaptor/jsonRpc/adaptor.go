@@ -0,0 +1,84 @@
+package jsonRpc
+
+import (
+	"github.com/iot-my-world/brain/internal/log"
+	jsonRpcServiceProvider "github.com/iot-my-world/brain/pkg/api/jsonRpc/service/provider"
+	"github.com/iot-my-world/brain/pkg/geofence"
+	"github.com/iot-my-world/brain/pkg/geofence/administrator"
+	wrappedClaims "github.com/iot-my-world/brain/pkg/security/claims/wrapped"
+	"net/http"
+)
+
+type adaptor struct {
+	administrator administrator.Administrator
+}
+
+func New(administrator administrator.Administrator) *adaptor {
+	return &adaptor{
+		administrator: administrator,
+	}
+}
+
+func (a *adaptor) Name() jsonRpcServiceProvider.Name {
+	return jsonRpcServiceProvider.Name(administrator.ServiceProvider)
+}
+
+func (a *adaptor) MethodRequiresAuthorization(string) bool {
+	return true
+}
+
+type CreateRequest struct {
+	Geofence geofence.Geofence `json:"geofence"`
+}
+
+type CreateResponse struct {
+	Geofence geofence.Geofence `json:"geofence"`
+}
+
+func (a *adaptor) Create(r *http.Request, request *CreateRequest, response *CreateResponse) error {
+	claims, err := wrappedClaims.UnwrapClaimsFromContext(r)
+	if err != nil {
+		log.Warn(err.Error())
+		return err
+	}
+
+	createResponse, err := a.administrator.Create(&administrator.CreateRequest{
+		Claims:   claims,
+		Geofence: request.Geofence,
+	})
+	if err != nil {
+		return err
+	}
+
+	response.Geofence = createResponse.Geofence
+
+	return nil
+}
+
+type UpdateAllowedFieldsRequest struct {
+	Geofence geofence.Geofence `json:"geofence"`
+}
+
+type UpdateAllowedFieldsResponse struct {
+	Geofence geofence.Geofence `json:"geofence"`
+}
+
+func (a *adaptor) UpdateAllowedFields(r *http.Request, request *UpdateAllowedFieldsRequest, response *UpdateAllowedFieldsResponse) error {
+	claims, err := wrappedClaims.UnwrapClaimsFromContext(r)
+	if err != nil {
+		log.Warn(err.Error())
+		return err
+	}
+
+	updateAllowedFieldsResponse, err := a.administrator.UpdateAllowedFields(&administrator.UpdateAllowedFieldsRequest{
+		Claims:   claims,
+		Geofence: request.Geofence,
+	})
+	if err != nil {
+		return err
+	}
+
+	response.Geofence = updateAllowedFieldsResponse.Geofence
+
+	return nil
+}
@@ -0,0 +1,142 @@
+package basic
+
+import (
+	"fmt"
+	brainException "github.com/iot-my-world/brain/internal/exception"
+	"github.com/iot-my-world/brain/internal/log"
+	"github.com/iot-my-world/brain/pkg/geofence/action"
+	geofenceAdministrator "github.com/iot-my-world/brain/pkg/geofence/administrator"
+	"github.com/iot-my-world/brain/pkg/geofence/administrator/exception"
+	"github.com/iot-my-world/brain/pkg/geofence/recordHandler"
+	"github.com/iot-my-world/brain/pkg/geofence/validator"
+	"github.com/iot-my-world/brain/pkg/search/identifier/id"
+)
+
+type administrator struct {
+	geofenceValidator     validator.Validator
+	geofenceRecordHandler recordHandler.RecordHandler
+}
+
+func New(
+	geofenceValidator validator.Validator,
+	geofenceRecordHandler recordHandler.RecordHandler,
+) geofenceAdministrator.Administrator {
+	return &administrator{
+		geofenceValidator:     geofenceValidator,
+		geofenceRecordHandler: geofenceRecordHandler,
+	}
+}
+
+func (a *administrator) ValidateCreateRequest(request *geofenceAdministrator.CreateRequest) error {
+	reasonsInvalid := make([]string, 0)
+
+	if request.Claims == nil {
+		reasonsInvalid = append(reasonsInvalid, "claims are nil")
+	} else {
+		geofenceValidateResponse, err := a.geofenceValidator.Validate(&validator.ValidateRequest{
+			Claims:   request.Claims,
+			Geofence: request.Geofence,
+			Action:   action.Create,
+		})
+		if err != nil {
+			reasonsInvalid = append(reasonsInvalid, "error validating geofence: "+err.Error())
+		} else {
+			if len(geofenceValidateResponse.ReasonsInvalid) > 0 {
+				for _, reason := range geofenceValidateResponse.ReasonsInvalid {
+					reasonsInvalid = append(reasonsInvalid, fmt.Sprintf("geofence invalid: %s - %s - %s", reason.Field, reason.Type, reason.Help))
+				}
+			}
+		}
+	}
+
+	if len(reasonsInvalid) > 0 {
+		return brainException.RequestInvalid{Reasons: reasonsInvalid}
+	}
+
+	return nil
+}
+
+func (a *administrator) Create(request *geofenceAdministrator.CreateRequest) (*geofenceAdministrator.CreateResponse, error) {
+	if err := a.ValidateCreateRequest(request); err != nil {
+		return nil, err
+	}
+
+	createResponse, err := a.geofenceRecordHandler.Create(&recordHandler.CreateRequest{
+		Geofence: request.Geofence,
+	})
+	if err != nil {
+		err = exception.GeofenceCreation{Reasons: []string{err.Error()}}
+		log.Error(err.Error())
+		return nil, err
+	}
+
+	return &geofenceAdministrator.CreateResponse{
+		Geofence: createResponse.Geofence,
+	}, nil
+}
+
+func (a *administrator) ValidateUpdateAllowedFieldsRequest(request *geofenceAdministrator.UpdateAllowedFieldsRequest) error {
+	reasonsInvalid := make([]string, 0)
+
+	if request.Claims == nil {
+		reasonsInvalid = append(reasonsInvalid, "claims are nil")
+	} else {
+		// geofence must be valid
+		validationResponse, err := a.geofenceValidator.Validate(&validator.ValidateRequest{
+			Claims:   request.Claims,
+			Geofence: request.Geofence,
+			Action:   action.UpdateAllowedFields,
+		})
+		if err != nil {
+			reasonsInvalid = append(reasonsInvalid, "error validating geofence: "+err.Error())
+		} else {
+			if len(validationResponse.ReasonsInvalid) > 0 {
+				for _, reason := range validationResponse.ReasonsInvalid {
+					reasonsInvalid = append(reasonsInvalid, fmt.Sprintf("geofence invalid: %s - %s - %s", reason.Field, reason.Type, reason.Help))
+				}
+			}
+		}
+	}
+
+	if len(reasonsInvalid) > 0 {
+		return brainException.RequestInvalid{Reasons: reasonsInvalid}
+	}
+	return nil
+}
+
+func (a *administrator) UpdateAllowedFields(request *geofenceAdministrator.UpdateAllowedFieldsRequest) (*geofenceAdministrator.UpdateAllowedFieldsResponse, error) {
+	if err := a.ValidateUpdateAllowedFieldsRequest(request); err != nil {
+		return nil, err
+	}
+
+	// retrieve the geofence
+	geofenceRetrieveResponse, err := a.geofenceRecordHandler.Retrieve(&recordHandler.RetrieveRequest{
+		Claims:     request.Claims,
+		Identifier: id.Identifier{Id: request.Geofence.Id},
+	})
+	if err != nil {
+		return nil, exception.GeofenceRetrieval{Reasons: []string{err.Error()}}
+	}
+
+	// update the allowed fields on the geofence
+	geofenceRetrieveResponse.Geofence.Name = request.Geofence.Name
+	geofenceRetrieveResponse.Geofence.Type = request.Geofence.Type
+	geofenceRetrieveResponse.Geofence.Centre = request.Geofence.Centre
+	geofenceRetrieveResponse.Geofence.Radius = request.Geofence.Radius
+	geofenceRetrieveResponse.Geofence.Points = request.Geofence.Points
+	geofenceRetrieveResponse.Geofence.DeviceIds = request.Geofence.DeviceIds
+
+	// update the geofence
+	_, err = a.geofenceRecordHandler.Update(&recordHandler.UpdateRequest{
+		Claims:     request.Claims,
+		Identifier: id.Identifier{Id: request.Geofence.Id},
+		Geofence:   geofenceRetrieveResponse.Geofence,
+	})
+	if err != nil {
+		return nil, exception.GeofenceUpdate{Reasons: []string{err.Error()}}
+	}
+
+	return &geofenceAdministrator.UpdateAllowedFieldsResponse{
+		Geofence: geofenceRetrieveResponse.Geofence,
+	}, nil
+}
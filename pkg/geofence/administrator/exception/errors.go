@@ -0,0 +1,29 @@
+package exception
+
+import (
+	"strings"
+)
+
+type GeofenceCreation struct {
+	Reasons []string
+}
+
+func (e GeofenceCreation) Error() string {
+	return "error creating geofence: " + strings.Join(e.Reasons, "; ")
+}
+
+type GeofenceRetrieval struct {
+	Reasons []string
+}
+
+func (e GeofenceRetrieval) Error() string {
+	return "error retrieving geofence: " + strings.Join(e.Reasons, "; ")
+}
+
+type GeofenceUpdate struct {
+	Reasons []string
+}
+
+func (e GeofenceUpdate) Error() string {
+	return "error updating geofence: " + strings.Join(e.Reasons, "; ")
+}
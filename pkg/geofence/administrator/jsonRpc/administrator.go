@@ -0,0 +1,81 @@
+package jsonRpc
+
+import (
+	brainException "github.com/iot-my-world/brain/internal/exception"
+	"github.com/iot-my-world/brain/internal/log"
+	jsonRpcClient "github.com/iot-my-world/brain/pkg/api/jsonRpc/client"
+	geofenceAdministrator "github.com/iot-my-world/brain/pkg/geofence/administrator"
+	geofenceAdministratorJsonRpcAdaptor "github.com/iot-my-world/brain/pkg/geofence/administrator/adaptor/jsonRpc"
+)
+
+type administrator struct {
+	jsonRpcClient jsonRpcClient.Client
+}
+
+func New(
+	jsonRpcClient jsonRpcClient.Client,
+) geofenceAdministrator.Administrator {
+	return &administrator{
+		jsonRpcClient: jsonRpcClient,
+	}
+}
+
+func (a *administrator) ValidateCreateRequest(request *geofenceAdministrator.CreateRequest) error {
+	reasonsInvalid := make([]string, 0)
+
+	if len(reasonsInvalid) > 0 {
+		return brainException.RequestInvalid{Reasons: reasonsInvalid}
+	}
+	return nil
+}
+
+func (a *administrator) Create(request *geofenceAdministrator.CreateRequest) (*geofenceAdministrator.CreateResponse, error) {
+	if err := a.ValidateCreateRequest(request); err != nil {
+		return nil, err
+	}
+
+	geofenceCreateResponse := geofenceAdministratorJsonRpcAdaptor.CreateResponse{}
+	if err := a.jsonRpcClient.JsonRpcRequest(
+		geofenceAdministrator.CreateService,
+		geofenceAdministratorJsonRpcAdaptor.CreateRequest{
+			Geofence: request.Geofence,
+		},
+		&geofenceCreateResponse,
+	); err != nil {
+		log.Error(err.Error())
+		return nil, err
+	}
+
+	return &geofenceAdministrator.CreateResponse{Geofence: geofenceCreateResponse.Geofence}, nil
+}
+
+func (a *administrator) ValidateUpdateAllowedFieldsRequest(request *geofenceAdministrator.UpdateAllowedFieldsRequest) error {
+	reasonsInvalid := make([]string, 0)
+
+	if len(reasonsInvalid) > 0 {
+		return brainException.RequestInvalid{Reasons: reasonsInvalid}
+	}
+	return nil
+}
+
+func (a *administrator) UpdateAllowedFields(request *geofenceAdministrator.UpdateAllowedFieldsRequest) (*geofenceAdministrator.UpdateAllowedFieldsResponse, error) {
+	if err := a.ValidateUpdateAllowedFieldsRequest(request); err != nil {
+		return nil, err
+	}
+
+	geofenceUpdateAllowedFieldsResponse := geofenceAdministratorJsonRpcAdaptor.UpdateAllowedFieldsResponse{}
+	if err := a.jsonRpcClient.JsonRpcRequest(
+		geofenceAdministrator.UpdateAllowedFieldsService,
+		geofenceAdministratorJsonRpcAdaptor.UpdateAllowedFieldsRequest{
+			Geofence: request.Geofence,
+		},
+		&geofenceUpdateAllowedFieldsResponse,
+	); err != nil {
+		log.Error(err.Error())
+		return nil, err
+	}
+
+	return &geofenceAdministrator.UpdateAllowedFieldsResponse{
+		Geofence: geofenceUpdateAllowedFieldsResponse.Geofence,
+	}, nil
+}
@@ -0,0 +1,168 @@
+package basic
+
+import (
+	brainException "github.com/iot-my-world/brain/internal/exception"
+	"github.com/iot-my-world/brain/internal/log"
+	sigbugGPSReadingRecordHandler "github.com/iot-my-world/brain/pkg/device/sigbug/reading/gps/recordHandler"
+	geofenceEvaluator "github.com/iot-my-world/brain/pkg/geofence/evaluator"
+	geofenceEvaluatorException "github.com/iot-my-world/brain/pkg/geofence/evaluator/exception"
+	"github.com/iot-my-world/brain/pkg/geofence/event"
+	geofenceEventRecordHandler "github.com/iot-my-world/brain/pkg/geofence/event/recordHandler"
+	geofenceRecordHandler "github.com/iot-my-world/brain/pkg/geofence/recordHandler"
+	"github.com/iot-my-world/brain/pkg/search/criterion"
+	exactTextCriterion "github.com/iot-my-world/brain/pkg/search/criterion/exact/text"
+	existsCriterion "github.com/iot-my-world/brain/pkg/search/criterion/exists"
+	dateRangeCriterion "github.com/iot-my-world/brain/pkg/search/criterion/range/date"
+	"github.com/iot-my-world/brain/pkg/search/identifier/id"
+	"github.com/iot-my-world/brain/pkg/search/query"
+	humanUserLoginClaims "github.com/iot-my-world/brain/pkg/security/claims/login/user/human"
+)
+
+type evaluator struct {
+	geofenceRecordHandler         geofenceRecordHandler.RecordHandler
+	geofenceEventRecordHandler    geofenceEventRecordHandler.RecordHandler
+	sigbugGPSReadingRecordHandler sigbugGPSReadingRecordHandler.RecordHandler
+	systemClaims                  *humanUserLoginClaims.Login
+}
+
+func New(
+	geofenceRecordHandler geofenceRecordHandler.RecordHandler,
+	geofenceEventRecordHandler geofenceEventRecordHandler.RecordHandler,
+	sigbugGPSReadingRecordHandler sigbugGPSReadingRecordHandler.RecordHandler,
+	systemClaims *humanUserLoginClaims.Login,
+) geofenceEvaluator.Evaluator {
+	return &evaluator{
+		geofenceRecordHandler:         geofenceRecordHandler,
+		geofenceEventRecordHandler:    geofenceEventRecordHandler,
+		sigbugGPSReadingRecordHandler: sigbugGPSReadingRecordHandler,
+		systemClaims:                  systemClaims,
+	}
+}
+
+func (e *evaluator) ValidateEvaluateRequest(request *geofenceEvaluator.EvaluateRequest) error {
+	reasonsInvalid := make([]string, 0)
+
+	if request.Claims == nil {
+		reasonsInvalid = append(reasonsInvalid, "claims are nil")
+	}
+
+	if request.Reading.DeviceId.Id == "" {
+		reasonsInvalid = append(reasonsInvalid, "reading device id is blank")
+	}
+
+	if len(reasonsInvalid) > 0 {
+		return brainException.RequestInvalid{Reasons: reasonsInvalid}
+	}
+	return nil
+}
+
+func (e *evaluator) Evaluate(request *geofenceEvaluator.EvaluateRequest) (*geofenceEvaluator.EvaluateResponse, error) {
+	if err := e.ValidateEvaluateRequest(request); err != nil {
+		log.Error(err.Error())
+		return nil, err
+	}
+
+	events := make([]event.Event, 0)
+
+	// a reading without a location can not be inside or outside a geofence
+	if request.Reading.Location == nil {
+		return &geofenceEvaluator.EvaluateResponse{Events: events}, nil
+	}
+
+	// collect the geofences which this device is evaluated against.
+	// system claims are used since geofences need not be owned by the
+	// party that owns the device.
+	geofenceCollectResponse, err := e.geofenceRecordHandler.Collect(&geofenceRecordHandler.CollectRequest{
+		Claims: e.systemClaims,
+		Criteria: []criterion.Criterion{
+			exactTextCriterion.Criterion{
+				Field: "deviceIds.id",
+				Text:  request.Reading.DeviceId.Id,
+			},
+		},
+	})
+	if err != nil {
+		err = geofenceEvaluatorException.CollectingGeofences{Reasons: []string{err.Error()}}
+		log.Error(err.Error())
+		return nil, err
+	}
+	if len(geofenceCollectResponse.Records) == 0 {
+		return &geofenceEvaluator.EvaluateResponse{Events: events}, nil
+	}
+
+	// retrieve the reading with a location which preceded this one
+	readingCollectResponse, err := e.sigbugGPSReadingRecordHandler.Collect(&sigbugGPSReadingRecordHandler.CollectRequest{
+		Claims: e.systemClaims,
+		Criteria: []criterion.Criterion{
+			exactTextCriterion.Criterion{
+				Field: "deviceId.id",
+				Text:  request.Reading.DeviceId.Id,
+			},
+			existsCriterion.Criterion{
+				Field:  "location",
+				Exists: true,
+			},
+			dateRangeCriterion.Criterion{
+				Field: "timeStamp",
+				StartDate: dateRangeCriterion.RangeValue{
+					Ignore: true,
+				},
+				EndDate: dateRangeCriterion.RangeValue{
+					Date: request.Reading.TimeStamp,
+				},
+			},
+		},
+		Query: query.Query{
+			Limit:  1,
+			Order:  []query.SortOrder{query.SortOrderDescending},
+			SortBy: []string{"timeStamp"},
+		},
+	})
+	if err != nil {
+		err = geofenceEvaluatorException.RetrievingPreviousReading{Reasons: []string{err.Error()}}
+		log.Error(err.Error())
+		return nil, err
+	}
+	// with no previous reading there can be no transitions
+	if len(readingCollectResponse.Records) == 0 {
+		return &geofenceEvaluator.EvaluateResponse{Events: events}, nil
+	}
+	previousReading := &readingCollectResponse.Records[0]
+
+	// look for a transition in each geofence
+	for geofenceIdx := range geofenceCollectResponse.Records {
+		geofenceToEvaluate := &geofenceCollectResponse.Records[geofenceIdx]
+		wasInside := geofenceToEvaluate.Contains(previousReading)
+		isInside := geofenceToEvaluate.Contains(&request.Reading)
+		if wasInside == isInside {
+			continue
+		}
+
+		eventType := event.Exit
+		if isInside {
+			eventType = event.Entry
+		}
+
+		createResponse, err := e.geofenceEventRecordHandler.Create(&geofenceEventRecordHandler.CreateRequest{
+			Event: event.Event{
+				Type:           eventType,
+				OwnerPartyType: geofenceToEvaluate.OwnerPartyType,
+				OwnerId:        geofenceToEvaluate.OwnerId,
+				GeofenceId:     id.Identifier{Id: geofenceToEvaluate.Id},
+				DeviceId:       request.Reading.DeviceId,
+				ReadingId:      id.Identifier{Id: request.Reading.Id},
+				TimeStamp:      request.Reading.TimeStamp,
+				Latitude:       request.Reading.Latitude,
+				Longitude:      request.Reading.Longitude,
+			},
+		})
+		if err != nil {
+			err = geofenceEvaluatorException.CreatingEvent{Reasons: []string{err.Error()}}
+			log.Error(err.Error())
+			return nil, err
+		}
+		events = append(events, createResponse.Event)
+	}
+
+	return &geofenceEvaluator.EvaluateResponse{Events: events}, nil
+}
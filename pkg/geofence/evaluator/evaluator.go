@@ -0,0 +1,22 @@
+package evaluator
+
+import (
+	"github.com/iot-my-world/brain/pkg/device/sigbug/reading/gps"
+	"github.com/iot-my-world/brain/pkg/geofence/event"
+	"github.com/iot-my-world/brain/pkg/security/claims"
+)
+
+// Evaluator evaluates gps readings against the geofences of the device which
+// generated them and records any geofence transitions as events
+type Evaluator interface {
+	Evaluate(request *EvaluateRequest) (*EvaluateResponse, error)
+}
+
+type EvaluateRequest struct {
+	Claims  claims.Claims
+	Reading gps.Reading
+}
+
+type EvaluateResponse struct {
+	Events []event.Event
+}
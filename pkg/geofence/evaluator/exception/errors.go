@@ -0,0 +1,30 @@
+package exception
+
+import (
+	"fmt"
+	"strings"
+)
+
+type CollectingGeofences struct {
+	Reasons []string
+}
+
+func (e CollectingGeofences) Error() string {
+	return fmt.Sprintf("error collecting geofences: %s", strings.Join(e.Reasons, "; "))
+}
+
+type RetrievingPreviousReading struct {
+	Reasons []string
+}
+
+func (e RetrievingPreviousReading) Error() string {
+	return fmt.Sprintf("error retrieving previous reading: %s", strings.Join(e.Reasons, "; "))
+}
+
+type CreatingEvent struct {
+	Reasons []string
+}
+
+func (e CreatingEvent) Error() string {
+	return fmt.Sprintf("error creating geofence event: %s", strings.Join(e.Reasons, "; "))
+}
@@ -0,0 +1,32 @@
+package event
+
+import (
+	"github.com/iot-my-world/brain/pkg/party"
+	"github.com/iot-my-world/brain/pkg/search/identifier/id"
+)
+
+type Type string
+
+const Entry Type = "Entry"
+const Exit Type = "Exit"
+
+type Event struct {
+	Id   string `json:"id" bson:"id"`
+	Type Type   `json:"type" bson:"type"`
+
+	// Owner Details, taken from the geofence
+	OwnerPartyType party.Type    `json:"ownerPartyType" bson:"ownerPartyType"`
+	OwnerId        id.Identifier `json:"ownerId" bson:"ownerId"`
+
+	// Event Details
+	GeofenceId id.Identifier `json:"geofenceId" bson:"geofenceId"`
+	DeviceId   id.Identifier `json:"deviceId" bson:"deviceId"`
+	ReadingId  id.Identifier `json:"readingId" bson:"readingId"`
+	TimeStamp  int64         `json:"timeStamp" bson:"timeStamp"`
+	Latitude   float32       `json:"latitude" bson:"latitude"`
+	Longitude  float32       `json:"longitude" bson:"longitude"`
+}
+
+func (e *Event) SetId(id string) {
+	e.Id = id
+}
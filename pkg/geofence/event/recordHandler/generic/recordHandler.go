@@ -0,0 +1,133 @@
+package geofenceEventRecordHandler
+
+import (
+	brainException "github.com/iot-my-world/brain/internal/exception"
+	"github.com/iot-my-world/brain/pkg/geofence/event"
+	geofenceEventRecordHandler "github.com/iot-my-world/brain/pkg/geofence/event/recordHandler"
+	geofenceEventRecordHandlerException "github.com/iot-my-world/brain/pkg/geofence/event/recordHandler/exception"
+	brainRecordHandler "github.com/iot-my-world/brain/pkg/recordHandler"
+	brainRecordHandlerException "github.com/iot-my-world/brain/pkg/recordHandler/exception"
+)
+
+type RecordHandler struct {
+	geofenceEventRecordHandler brainRecordHandler.RecordHandler
+}
+
+func New(
+	brainEventRecordHandler brainRecordHandler.RecordHandler,
+) geofenceEventRecordHandler.RecordHandler {
+
+	return &RecordHandler{
+		geofenceEventRecordHandler: brainEventRecordHandler,
+	}
+}
+
+type CreateRequest struct {
+	Event event.Event
+}
+
+type CreateResponse struct {
+	Event event.Event
+}
+
+func (r *RecordHandler) ValidateCreateRequest(request *geofenceEventRecordHandler.CreateRequest) error {
+	reasonsInvalid := make([]string, 0)
+
+	if len(reasonsInvalid) > 0 {
+		return brainException.RequestInvalid{Reasons: reasonsInvalid}
+	}
+
+	return nil
+}
+
+func (r *RecordHandler) Create(request *geofenceEventRecordHandler.CreateRequest) (*geofenceEventRecordHandler.CreateResponse, error) {
+	if err := r.ValidateCreateRequest(request); err != nil {
+		return nil, err
+	}
+
+	createResponse := brainRecordHandler.CreateResponse{}
+	if err := r.geofenceEventRecordHandler.Create(&brainRecordHandler.CreateRequest{
+		Entity: &request.Event,
+	}, &createResponse); err != nil {
+		return nil, geofenceEventRecordHandlerException.Create{Reasons: []string{err.Error()}}
+	}
+	createdEvent, ok := createResponse.Entity.(*event.Event)
+	if !ok {
+		return nil, geofenceEventRecordHandlerException.Create{Reasons: []string{"could not cast created entity to geofence event"}}
+	}
+
+	return &geofenceEventRecordHandler.CreateResponse{
+		Event: *createdEvent,
+	}, nil
+}
+
+func (r *RecordHandler) Retrieve(request *geofenceEventRecordHandler.RetrieveRequest) (*geofenceEventRecordHandler.RetrieveResponse, error) {
+	retrievedEvent := event.Event{}
+	retrieveResponse := brainRecordHandler.RetrieveResponse{
+		Entity: &retrievedEvent,
+	}
+	if err := r.geofenceEventRecordHandler.Retrieve(&brainRecordHandler.RetrieveRequest{
+		Claims:     request.Claims,
+		Identifier: request.Identifier,
+	}, &retrieveResponse); err != nil {
+		switch err.(type) {
+		case brainRecordHandlerException.NotFound:
+			return nil, geofenceEventRecordHandlerException.NotFound{}
+		default:
+			return nil, err
+		}
+	}
+
+	return &geofenceEventRecordHandler.RetrieveResponse{
+		Event: retrievedEvent,
+	}, nil
+}
+
+func (r *RecordHandler) Update(request *geofenceEventRecordHandler.UpdateRequest) (*geofenceEventRecordHandler.UpdateResponse, error) {
+	updateResponse := brainRecordHandler.UpdateResponse{}
+	if err := r.geofenceEventRecordHandler.Update(&brainRecordHandler.UpdateRequest{
+		Claims:     request.Claims,
+		Identifier: request.Identifier,
+		Entity:     &request.Event,
+	}, &updateResponse); err != nil {
+		return nil, geofenceEventRecordHandlerException.Update{Reasons: []string{err.Error()}}
+	}
+
+	return &geofenceEventRecordHandler.UpdateResponse{}, nil
+}
+
+func (r *RecordHandler) Delete(request *geofenceEventRecordHandler.DeleteRequest) (*geofenceEventRecordHandler.DeleteResponse, error) {
+	deleteResponse := brainRecordHandler.DeleteResponse{}
+	if err := r.geofenceEventRecordHandler.Delete(&brainRecordHandler.DeleteRequest{
+		Claims:     request.Claims,
+		Identifier: request.Identifier,
+	}, &deleteResponse); err != nil {
+		return nil, geofenceEventRecordHandlerException.Delete{Reasons: []string{err.Error()}}
+	}
+
+	return &geofenceEventRecordHandler.DeleteResponse{}, nil
+}
+
+func (r *RecordHandler) Collect(request *geofenceEventRecordHandler.CollectRequest) (*geofenceEventRecordHandler.CollectResponse, error) {
+	var collectedEvent []event.Event
+	collectResponse := brainRecordHandler.CollectResponse{
+		Records: &collectedEvent,
+	}
+	err := r.geofenceEventRecordHandler.Collect(&brainRecordHandler.CollectRequest{
+		Claims:   request.Claims,
+		Criteria: request.Criteria,
+		Query:    request.Query,
+	}, &collectResponse)
+	if err != nil {
+		return nil, geofenceEventRecordHandlerException.Collect{Reasons: []string{err.Error()}}
+	}
+
+	if collectedEvent == nil {
+		collectedEvent = make([]event.Event, 0)
+	}
+
+	return &geofenceEventRecordHandler.CollectResponse{
+		Records: collectedEvent,
+		Total:   collectResponse.Total,
+	}, nil
+}
@@ -0,0 +1,34 @@
+package mongo
+
+import (
+	"github.com/iot-my-world/brain/pkg/geofence/event"
+	geofenceEventRecordHandler "github.com/iot-my-world/brain/pkg/geofence/event/recordHandler"
+	geofenceEventGenericRecordHandler "github.com/iot-my-world/brain/pkg/geofence/event/recordHandler/generic"
+	brainMongoRecordHandler "github.com/iot-my-world/brain/pkg/recordHandler/mongo"
+	"github.com/iot-my-world/brain/pkg/security/claims"
+	"gopkg.in/mgo.v2"
+)
+
+func New(
+	mongoSession *mgo.Session,
+	databaseName string,
+	collectionName string,
+) geofenceEventRecordHandler.RecordHandler {
+	mongoRecordHandler := brainMongoRecordHandler.New(
+		mongoSession,
+		databaseName,
+		collectionName,
+		[]mgo.Index{
+			{
+				Key:    []string{"id"},
+				Unique: true,
+			},
+		},
+		event.IsValidIdentifier,
+		claims.ContextualiseFilter,
+	)
+
+	return geofenceEventGenericRecordHandler.New(
+		mongoRecordHandler,
+	)
+}
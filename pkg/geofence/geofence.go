@@ -0,0 +1,73 @@
+package geofence
+
+import (
+	"github.com/iot-my-world/brain/pkg/device/sigbug/reading/gps"
+	"github.com/iot-my-world/brain/pkg/party"
+	"github.com/iot-my-world/brain/pkg/search/identifier/id"
+)
+
+type Type string
+
+const Circle Type = "Circle"
+const Polygon Type = "Polygon"
+
+type Point struct {
+	Latitude  float32 `json:"latitude" bson:"latitude"`
+	Longitude float32 `json:"longitude" bson:"longitude"`
+}
+
+type Geofence struct {
+	Id string `json:"id" bson:"id"`
+
+	OwnerPartyType party.Type    `json:"ownerPartyType" bson:"ownerPartyType"`
+	OwnerId        id.Identifier `json:"ownerId" bson:"ownerId"`
+	Name           string        `json:"name" bson:"name"`
+	Type           Type          `json:"type" bson:"type"`
+
+	// Circle Details
+	Centre Point `json:"centre" bson:"centre"`
+	// Radius of the circle in metres
+	Radius float32 `json:"radius" bson:"radius"`
+
+	// Polygon Details
+	Points []Point `json:"points" bson:"points"`
+
+	// Devices which are evaluated against this geofence
+	DeviceIds []id.Identifier `json:"deviceIds" bson:"deviceIds"`
+}
+
+func (g *Geofence) SetId(id string) {
+	g.Id = id
+}
+
+// Contains returns true if the position of the given reading lies
+// within the geofence
+func (g *Geofence) Contains(reading *gps.Reading) bool {
+	switch g.Type {
+	case Circle:
+		return gps.DifferenceBetween(
+			&gps.Reading{
+				Latitude:  g.Centre.Latitude,
+				Longitude: g.Centre.Longitude,
+			},
+			reading,
+		) <= g.Radius
+
+	case Polygon:
+		// ray casting: count the number of polygon edges crossed by a ray
+		// extending from the reading position in the direction of increasing longitude
+		inside := false
+		for i, j := 0, len(g.Points)-1; i < len(g.Points); j, i = i, i+1 {
+			pi := g.Points[i]
+			pj := g.Points[j]
+			if (pi.Latitude > reading.Latitude) != (pj.Latitude > reading.Latitude) &&
+				reading.Longitude < (pj.Longitude-pi.Longitude)*(reading.Latitude-pi.Latitude)/(pj.Latitude-pi.Latitude)+pi.Longitude {
+				inside = !inside
+			}
+		}
+		return inside
+
+	default:
+		return false
+	}
+}
@@ -0,0 +1,133 @@
+package geofenceRecordHandler
+
+import (
+	brainException "github.com/iot-my-world/brain/internal/exception"
+	"github.com/iot-my-world/brain/pkg/geofence"
+	geofenceRecordHandler "github.com/iot-my-world/brain/pkg/geofence/recordHandler"
+	geofenceRecordHandlerException "github.com/iot-my-world/brain/pkg/geofence/recordHandler/exception"
+	brainRecordHandler "github.com/iot-my-world/brain/pkg/recordHandler"
+	brainRecordHandlerException "github.com/iot-my-world/brain/pkg/recordHandler/exception"
+)
+
+type RecordHandler struct {
+	geofenceRecordHandler brainRecordHandler.RecordHandler
+}
+
+func New(
+	brainGeofenceRecordHandler brainRecordHandler.RecordHandler,
+) geofenceRecordHandler.RecordHandler {
+
+	return &RecordHandler{
+		geofenceRecordHandler: brainGeofenceRecordHandler,
+	}
+}
+
+type CreateRequest struct {
+	Geofence geofence.Geofence
+}
+
+type CreateResponse struct {
+	Geofence geofence.Geofence
+}
+
+func (r *RecordHandler) ValidateCreateRequest(request *geofenceRecordHandler.CreateRequest) error {
+	reasonsInvalid := make([]string, 0)
+
+	if len(reasonsInvalid) > 0 {
+		return brainException.RequestInvalid{Reasons: reasonsInvalid}
+	}
+
+	return nil
+}
+
+func (r *RecordHandler) Create(request *geofenceRecordHandler.CreateRequest) (*geofenceRecordHandler.CreateResponse, error) {
+	if err := r.ValidateCreateRequest(request); err != nil {
+		return nil, err
+	}
+
+	createResponse := brainRecordHandler.CreateResponse{}
+	if err := r.geofenceRecordHandler.Create(&brainRecordHandler.CreateRequest{
+		Entity: &request.Geofence,
+	}, &createResponse); err != nil {
+		return nil, geofenceRecordHandlerException.Create{Reasons: []string{err.Error()}}
+	}
+	createdGeofence, ok := createResponse.Entity.(*geofence.Geofence)
+	if !ok {
+		return nil, geofenceRecordHandlerException.Create{Reasons: []string{"could not cast created entity to geofence"}}
+	}
+
+	return &geofenceRecordHandler.CreateResponse{
+		Geofence: *createdGeofence,
+	}, nil
+}
+
+func (r *RecordHandler) Retrieve(request *geofenceRecordHandler.RetrieveRequest) (*geofenceRecordHandler.RetrieveResponse, error) {
+	retrievedGeofence := geofence.Geofence{}
+	retrieveResponse := brainRecordHandler.RetrieveResponse{
+		Entity: &retrievedGeofence,
+	}
+	if err := r.geofenceRecordHandler.Retrieve(&brainRecordHandler.RetrieveRequest{
+		Claims:     request.Claims,
+		Identifier: request.Identifier,
+	}, &retrieveResponse); err != nil {
+		switch err.(type) {
+		case brainRecordHandlerException.NotFound:
+			return nil, geofenceRecordHandlerException.NotFound{}
+		default:
+			return nil, err
+		}
+	}
+
+	return &geofenceRecordHandler.RetrieveResponse{
+		Geofence: retrievedGeofence,
+	}, nil
+}
+
+func (r *RecordHandler) Update(request *geofenceRecordHandler.UpdateRequest) (*geofenceRecordHandler.UpdateResponse, error) {
+	updateResponse := brainRecordHandler.UpdateResponse{}
+	if err := r.geofenceRecordHandler.Update(&brainRecordHandler.UpdateRequest{
+		Claims:     request.Claims,
+		Identifier: request.Identifier,
+		Entity:     &request.Geofence,
+	}, &updateResponse); err != nil {
+		return nil, geofenceRecordHandlerException.Update{Reasons: []string{err.Error()}}
+	}
+
+	return &geofenceRecordHandler.UpdateResponse{}, nil
+}
+
+func (r *RecordHandler) Delete(request *geofenceRecordHandler.DeleteRequest) (*geofenceRecordHandler.DeleteResponse, error) {
+	deleteResponse := brainRecordHandler.DeleteResponse{}
+	if err := r.geofenceRecordHandler.Delete(&brainRecordHandler.DeleteRequest{
+		Claims:     request.Claims,
+		Identifier: request.Identifier,
+	}, &deleteResponse); err != nil {
+		return nil, geofenceRecordHandlerException.Delete{Reasons: []string{err.Error()}}
+	}
+
+	return &geofenceRecordHandler.DeleteResponse{}, nil
+}
+
+func (r *RecordHandler) Collect(request *geofenceRecordHandler.CollectRequest) (*geofenceRecordHandler.CollectResponse, error) {
+	var collectedGeofence []geofence.Geofence
+	collectResponse := brainRecordHandler.CollectResponse{
+		Records: &collectedGeofence,
+	}
+	err := r.geofenceRecordHandler.Collect(&brainRecordHandler.CollectRequest{
+		Claims:   request.Claims,
+		Criteria: request.Criteria,
+		Query:    request.Query,
+	}, &collectResponse)
+	if err != nil {
+		return nil, geofenceRecordHandlerException.Collect{Reasons: []string{err.Error()}}
+	}
+
+	if collectedGeofence == nil {
+		collectedGeofence = make([]geofence.Geofence, 0)
+	}
+
+	return &geofenceRecordHandler.CollectResponse{
+		Records: collectedGeofence,
+		Total:   collectResponse.Total,
+	}, nil
+}
@@ -0,0 +1,60 @@
+package jsonRpc
+
+import (
+	"github.com/iot-my-world/brain/internal/log"
+	"github.com/iot-my-world/brain/pkg/action"
+	jsonRpcServiceProvider "github.com/iot-my-world/brain/pkg/api/jsonRpc/service/provider"
+	"github.com/iot-my-world/brain/pkg/geofence"
+	"github.com/iot-my-world/brain/pkg/geofence/validator"
+	wrappedClaims "github.com/iot-my-world/brain/pkg/security/claims/wrapped"
+	"github.com/iot-my-world/brain/pkg/validate/reasonInvalid"
+	"net/http"
+)
+
+type adaptor struct {
+	geofenceValidator validator.Validator
+}
+
+func New(geofenceValidator validator.Validator) *adaptor {
+	return &adaptor{
+		geofenceValidator: geofenceValidator,
+	}
+}
+
+func (a *adaptor) Name() jsonRpcServiceProvider.Name {
+	return jsonRpcServiceProvider.Name(validator.ServiceProvider)
+}
+
+func (a *adaptor) MethodRequiresAuthorization(string) bool {
+	return true
+}
+
+type ValidateRequest struct {
+	Geofence geofence.Geofence `json:"geofence"`
+	Action   action.Action     `json:"action"`
+}
+
+type ValidateResponse struct {
+	ReasonsInvalid []reasonInvalid.ReasonInvalid `json:"reasonsInvalid"`
+}
+
+func (a *adaptor) Validate(r *http.Request, request *ValidateRequest, response *ValidateResponse) error {
+	claims, err := wrappedClaims.UnwrapClaimsFromContext(r)
+	if err != nil {
+		log.Warn(err.Error())
+		return err
+	}
+
+	validateGeofenceResponse, err := a.geofenceValidator.Validate(&validator.ValidateRequest{
+		Claims:   claims,
+		Geofence: request.Geofence,
+		Action:   request.Action,
+	})
+	if err != nil {
+		return err
+	}
+
+	response.ReasonsInvalid = validateGeofenceResponse.ReasonsInvalid
+
+	return nil
+}
@@ -0,0 +1,243 @@
+package validator
+
+import (
+	brainException "github.com/iot-my-world/brain/internal/exception"
+	"github.com/iot-my-world/brain/internal/log"
+	"github.com/iot-my-world/brain/pkg/action"
+	sigbugRecordHandler "github.com/iot-my-world/brain/pkg/device/sigbug/recordHandler"
+	sigbugRecordHandlerException "github.com/iot-my-world/brain/pkg/device/sigbug/recordHandler/exception"
+	"github.com/iot-my-world/brain/pkg/geofence"
+	geofenceAction "github.com/iot-my-world/brain/pkg/geofence/action"
+	geofenceValidator "github.com/iot-my-world/brain/pkg/geofence/validator"
+	geofenceValidatorException "github.com/iot-my-world/brain/pkg/geofence/validator/exception"
+	"github.com/iot-my-world/brain/pkg/party"
+	partyAdministrator "github.com/iot-my-world/brain/pkg/party/administrator"
+	partyAdministratorException "github.com/iot-my-world/brain/pkg/party/administrator/exception"
+	"github.com/iot-my-world/brain/pkg/validate/reasonInvalid"
+)
+
+type validator struct {
+	partyAdministrator   partyAdministrator.Administrator
+	sigbugRecordHandler  sigbugRecordHandler.RecordHandler
+	actionIgnoredReasons map[action.Action]reasonInvalid.IgnoredReasonsInvalid
+}
+
+func New(
+	partyAdministrator partyAdministrator.Administrator,
+	sigbugRecordHandler sigbugRecordHandler.RecordHandler,
+) geofenceValidator.Validator {
+
+	actionIgnoredReasons := map[action.Action]reasonInvalid.IgnoredReasonsInvalid{
+		geofenceAction.Create: {
+			ReasonsInvalid: map[string][]reasonInvalid.Type{
+				"id": {
+					reasonInvalid.Blank,
+				},
+			},
+		},
+		geofenceAction.UpdateAllowedFields: {
+			ReasonsInvalid: map[string][]reasonInvalid.Type{},
+		},
+	}
+
+	return &validator{
+		partyAdministrator:   partyAdministrator,
+		sigbugRecordHandler:  sigbugRecordHandler,
+		actionIgnoredReasons: actionIgnoredReasons,
+	}
+}
+
+func (v *validator) ValidateValidateRequest(request *geofenceValidator.ValidateRequest) error {
+	reasonsInvalid := make([]string, 0)
+
+	if request.Claims == nil {
+		reasonsInvalid = append(reasonsInvalid, "claims are nil")
+	}
+
+	if len(reasonsInvalid) > 0 {
+		return brainException.RequestInvalid{Reasons: reasonsInvalid}
+	}
+	return nil
+}
+
+func (v *validator) Validate(request *geofenceValidator.ValidateRequest) (*geofenceValidator.ValidateResponse, error) {
+	if err := v.ValidateValidateRequest(request); err != nil {
+		return nil, err
+	}
+
+	allReasonsInvalid := make([]reasonInvalid.ReasonInvalid, 0)
+	geofenceToValidate := &request.Geofence
+
+	if (*geofenceToValidate).Id == "" {
+		allReasonsInvalid = append(allReasonsInvalid, reasonInvalid.ReasonInvalid{
+			Field: "id",
+			Type:  reasonInvalid.Blank,
+			Help:  "cannot be blank",
+			Data:  (*geofenceToValidate).Id,
+		})
+	}
+
+	if (*geofenceToValidate).OwnerPartyType == "" {
+		allReasonsInvalid = append(allReasonsInvalid, reasonInvalid.ReasonInvalid{
+			Field: "ownerPartyType",
+			Type:  reasonInvalid.Blank,
+			Help:  "cannot be blank",
+			Data:  (*geofenceToValidate).OwnerPartyType,
+		})
+	}
+
+	if (*geofenceToValidate).OwnerId.Id == "" {
+		allReasonsInvalid = append(allReasonsInvalid, reasonInvalid.ReasonInvalid{
+			Field: "ownerId",
+			Type:  reasonInvalid.Blank,
+			Help:  "cannot be blank",
+			Data:  (*geofenceToValidate).OwnerId,
+		})
+	}
+
+	// if neither owner party type nor owner id are blank
+	if (*geofenceToValidate).OwnerPartyType != "" && (*geofenceToValidate).OwnerId.Id != "" {
+		// owner party type must be valid. i.e. must be of a valid type and the party must exist
+		switch (*geofenceToValidate).OwnerPartyType {
+		case party.System, party.Client, party.Company:
+			_, err := v.partyAdministrator.RetrieveParty(&partyAdministrator.RetrievePartyRequest{
+				Claims:     request.Claims,
+				PartyType:  (*geofenceToValidate).OwnerPartyType,
+				Identifier: (*geofenceToValidate).OwnerId,
+			})
+			if err != nil {
+				switch err.(type) {
+				case partyAdministratorException.NotFound:
+					allReasonsInvalid = append(allReasonsInvalid, reasonInvalid.ReasonInvalid{
+						Field: "ownerId",
+						Type:  reasonInvalid.MustExist,
+						Help:  "owner party must exist",
+						Data:  (*geofenceToValidate).OwnerId,
+					})
+				default:
+					err = geofenceValidatorException.Validate{Reasons: []string{"retrieving owner party", err.Error()}}
+					log.Error(err.Error())
+					return nil, err
+				}
+			}
+
+		default:
+			allReasonsInvalid = append(allReasonsInvalid, reasonInvalid.ReasonInvalid{
+				Field: "ownerPartyType",
+				Type:  reasonInvalid.Invalid,
+				Help:  "must be a valid type",
+				Data:  (*geofenceToValidate).OwnerPartyType,
+			})
+		}
+	}
+
+	if (*geofenceToValidate).Name == "" {
+		allReasonsInvalid = append(allReasonsInvalid, reasonInvalid.ReasonInvalid{
+			Field: "name",
+			Type:  reasonInvalid.Blank,
+			Help:  "cannot be blank",
+			Data:  (*geofenceToValidate).Name,
+		})
+	}
+
+	// the shape of the geofence must be valid for its type
+	switch (*geofenceToValidate).Type {
+	case geofence.Circle:
+		if !validPoint((*geofenceToValidate).Centre) {
+			allReasonsInvalid = append(allReasonsInvalid, reasonInvalid.ReasonInvalid{
+				Field: "centre",
+				Type:  reasonInvalid.Invalid,
+				Help:  "must be a valid position",
+				Data:  (*geofenceToValidate).Centre,
+			})
+		}
+		if (*geofenceToValidate).Radius <= 0 {
+			allReasonsInvalid = append(allReasonsInvalid, reasonInvalid.ReasonInvalid{
+				Field: "radius",
+				Type:  reasonInvalid.Invalid,
+				Help:  "must be greater than 0",
+				Data:  (*geofenceToValidate).Radius,
+			})
+		}
+
+	case geofence.Polygon:
+		if len((*geofenceToValidate).Points) < 3 {
+			allReasonsInvalid = append(allReasonsInvalid, reasonInvalid.ReasonInvalid{
+				Field: "points",
+				Type:  reasonInvalid.Invalid,
+				Help:  "must have at least 3 points",
+				Data:  (*geofenceToValidate).Points,
+			})
+		}
+		for _, point := range (*geofenceToValidate).Points {
+			if !validPoint(point) {
+				allReasonsInvalid = append(allReasonsInvalid, reasonInvalid.ReasonInvalid{
+					Field: "points",
+					Type:  reasonInvalid.Invalid,
+					Help:  "must all be valid positions",
+					Data:  point,
+				})
+				break
+			}
+		}
+
+	case "":
+		allReasonsInvalid = append(allReasonsInvalid, reasonInvalid.ReasonInvalid{
+			Field: "type",
+			Type:  reasonInvalid.Blank,
+			Help:  "cannot be blank",
+			Data:  (*geofenceToValidate).Type,
+		})
+
+	default:
+		allReasonsInvalid = append(allReasonsInvalid, reasonInvalid.ReasonInvalid{
+			Field: "type",
+			Type:  reasonInvalid.Invalid,
+			Help:  "must be a valid type",
+			Data:  (*geofenceToValidate).Type,
+		})
+	}
+
+	// each of the devices on the geofence must exist
+	for _, deviceId := range (*geofenceToValidate).DeviceIds {
+		if _, err := v.sigbugRecordHandler.Retrieve(&sigbugRecordHandler.RetrieveRequest{
+			Claims:     request.Claims,
+			Identifier: deviceId,
+		}); err != nil {
+			switch err.(type) {
+			case sigbugRecordHandlerException.NotFound:
+				allReasonsInvalid = append(allReasonsInvalid, reasonInvalid.ReasonInvalid{
+					Field: "deviceIds",
+					Type:  reasonInvalid.MustExist,
+					Help:  "device must exist",
+					Data:  deviceId,
+				})
+			default:
+				err = geofenceValidatorException.Validate{Reasons: []string{"retrieving device", err.Error()}}
+				log.Error(err.Error())
+				return nil, err
+			}
+		}
+	}
+
+	// Make list of reasons invalid to return
+	returnedReasonsInvalid := make([]reasonInvalid.ReasonInvalid, 0)
+
+	// Add all reasons that cannot be ignored for the given action
+	if v.actionIgnoredReasons[request.Action].ReasonsInvalid != nil {
+		for _, reason := range allReasonsInvalid {
+			if !v.actionIgnoredReasons[request.Action].CanIgnore(reason) {
+				returnedReasonsInvalid = append(returnedReasonsInvalid, reason)
+			}
+		}
+	}
+
+	return &geofenceValidator.ValidateResponse{
+		ReasonsInvalid: returnedReasonsInvalid,
+	}, nil
+}
+
+func validPoint(point geofence.Point) bool {
+	return point.Latitude >= -90 && point.Latitude <= 90 &&
+		point.Longitude >= -180 && point.Longitude <= 180
+}
@@ -129,6 +129,10 @@ func (a *administrator) ValidateUpdateAllowedFieldsRequest(request *clientAdmini
 		reasonsInvalid = append(reasonsInvalid, "claims are nil")
 	}
 
+	if request.Client.DeviceSilenceThreshold < 0 {
+		reasonsInvalid = append(reasonsInvalid, "device silence threshold cannot be negative")
+	}
+
 	if len(reasonsInvalid) > 0 {
 		return brainException.RequestInvalid{Reasons: reasonsInvalid}
 	}
@@ -154,6 +158,8 @@ func (a *administrator) UpdateAllowedFields(request *clientAdministrator.UpdateA
 	//clientRetrieveResponse.Client.ParentId = request.Client.ParentId
 	//clientRetrieveResponse.Client.ParentPartyType = request.Client.ParentPartyType
 	clientRetrieveResponse.Client.Name = request.Client.Name
+	clientRetrieveResponse.Client.DeviceSilenceThreshold = request.Client.DeviceSilenceThreshold
+	clientRetrieveResponse.Client.TwoFactorRequired = request.Client.TwoFactorRequired
 	//clientRetrieveResponse.Client.AdminEmailAddress = request.Client.AdminEmailAddress
 
 	// update the client
@@ -26,6 +26,15 @@ type Client struct {
 
 	ParentPartyType party.Type    `json:"parentPartyType" bson:"parentPartyType"`
 	ParentId        id.Identifier `json:"parentId" bson:"parentId"`
+
+	// DeviceSilenceThreshold is the number of seconds after which the devices
+	// owned by the client are taken to be offline if they have not sent a message.
+	// If it is 0 the system default is used.
+	DeviceSilenceThreshold int64 `json:"deviceSilenceThreshold" bson:"deviceSilenceThreshold"`
+
+	// TwoFactorRequired makes two-factor authentication
+	// mandatory for the users of the client
+	TwoFactorRequired bool `json:"twoFactorRequired" bson:"twoFactorRequired"`
 }
 
 // Details returns the party details of the client party
@@ -162,6 +162,15 @@ func (v *validator) Validate(request *clientValidator.ValidateRequest) (*clientV
 		})
 	}
 
+	if (*clientToValidate).DeviceSilenceThreshold < 0 {
+		allReasonsInvalid = append(allReasonsInvalid, reasonInvalid.ReasonInvalid{
+			Field: "deviceSilenceThreshold",
+			Type:  reasonInvalid.Invalid,
+			Help:  "cannot be negative",
+			Data:  (*clientToValidate).DeviceSilenceThreshold,
+		})
+	}
+
 	// Perform additional checks/ignores considering method field
 	switch request.Action {
 	case clientAction.Create:
@@ -109,6 +109,10 @@ func (a *administrator) ValidateUpdateAllowedFieldsRequest(request *administrato
 		reasonsInvalid = append(reasonsInvalid, "claims are nil")
 	}
 
+	if request.Company.DeviceSilenceThreshold < 0 {
+		reasonsInvalid = append(reasonsInvalid, "device silence threshold cannot be negative")
+	}
+
 	if len(reasonsInvalid) > 0 {
 		return brainException.RequestInvalid{Reasons: reasonsInvalid}
 	}
@@ -134,6 +138,8 @@ func (a *administrator) UpdateAllowedFields(request *administrator2.UpdateAllowe
 	//companyRetrieveResponse.Company.ParentId = request.Company.ParentId
 	//companyRetrieveResponse.Company.ParentPartyType = request.Company.ParentPartyType
 	companyRetrieveResponse.Company.Name = request.Company.Name
+	companyRetrieveResponse.Company.DeviceSilenceThreshold = request.Company.DeviceSilenceThreshold
+	companyRetrieveResponse.Company.TwoFactorRequired = request.Company.TwoFactorRequired
 	//companyRetrieveResponse.Company.AdminEmailAddress = request.Company.AdminEmailAddress
 
 	// update the company
@@ -15,6 +15,15 @@ type Company struct {
 
 	ParentPartyType party.Type    `json:"parentPartyType" bson:"parentPartyType"`
 	ParentId        id.Identifier `json:"parentId" bson:"parentId"`
+
+	// DeviceSilenceThreshold is the number of seconds after which the devices
+	// owned by the company are taken to be offline if they have not sent a message.
+	// If it is 0 the system default is used.
+	DeviceSilenceThreshold int64 `json:"deviceSilenceThreshold" bson:"deviceSilenceThreshold"`
+
+	// TwoFactorRequired makes two-factor authentication
+	// mandatory for the users of the company
+	TwoFactorRequired bool `json:"twoFactorRequired" bson:"twoFactorRequired"`
 }
 
 func (c Company) Details() party.Details {
@@ -142,6 +142,15 @@ func (v *validator) Validate(request *companyValidator.ValidateRequest) (*compan
 		})
 	}
 
+	if (*companyToValidate).DeviceSilenceThreshold < 0 {
+		allReasonsInvalid = append(allReasonsInvalid, reasonInvalid.ReasonInvalid{
+			Field: "deviceSilenceThreshold",
+			Type:  reasonInvalid.Invalid,
+			Help:  "cannot be negative",
+			Data:  (*companyToValidate).DeviceSilenceThreshold,
+		})
+	}
+
 	// Perform additional checks/ignores considering method field
 	switch request.Action {
 	case companyAction.Create:
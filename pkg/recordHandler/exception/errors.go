@@ -16,6 +16,16 @@ func (e Create) Error() string {
 	return "creation error: " + strings.Join(e.Reasons, "; ")
 }
 
+// Duplicate is returned when a record cannot be created
+// since it would violate a unique index
+type Duplicate struct {
+	Reasons []string
+}
+
+func (e Duplicate) Error() string {
+	return "duplicate record: " + strings.Join(e.Reasons, "; ")
+}
+
 type Update struct {
 	Reasons []string
 }
@@ -23,3 +33,11 @@ type Update struct {
 func (e Update) Error() string {
 	return "update error: " + strings.Join(e.Reasons, "; ")
 }
+
+type Delete struct {
+	Reasons []string
+}
+
+func (e Delete) Error() string {
+	return "delete error: " + strings.Join(e.Reasons, "; ")
+}
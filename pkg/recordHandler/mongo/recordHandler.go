@@ -88,6 +88,9 @@ func (r *recordHandler) Create(request *recordHandler2.CreateRequest, response *
 	request.Entity.SetId(newId.String())
 
 	if err := collection.Insert(request.Entity); err != nil {
+		if mgo.IsDup(err) {
+			return exception.Duplicate{Reasons: []string{err.Error()}}
+		}
 		return exception.Create{Reasons: []string{"inserting record", err.Error()}}
 	}
 
@@ -259,10 +262,12 @@ func (r *recordHandler) Collect(request *recordHandler2.CollectRequest, response
 	query := collection.Find(filter)
 
 	// Apply the count
-	if total, err := query.Count(); err == nil {
-		response.Total = total
-	} else {
-		return err
+	if !request.SkipCount {
+		if total, err := query.Count(); err == nil {
+			response.Total = total
+		} else {
+			return err
+		}
 	}
 
 	// Apply limit if applicable
@@ -283,3 +288,200 @@ func (r *recordHandler) Collect(request *recordHandler2.CollectRequest, response
 
 	return nil
 }
+
+// toMongoUpdate builds a mongo update document from the given fields update
+func toMongoUpdate(update recordHandler2.FieldsUpdate) bson.M {
+	mongoUpdate := bson.M{}
+	if len(update.Set) > 0 {
+		mongoUpdate["$set"] = update.Set
+	}
+	if len(update.Increment) > 0 {
+		mongoUpdate["$inc"] = update.Increment
+	}
+	if len(update.Maximum) > 0 {
+		mongoUpdate["$max"] = update.Maximum
+	}
+	if len(update.SetOnInsert) > 0 {
+		mongoUpdate["$setOnInsert"] = update.SetOnInsert
+	}
+	if len(update.Pull) > 0 {
+		mongoUpdate["$pull"] = update.Pull
+	}
+	return mongoUpdate
+}
+
+// validateFieldsUpdate returns the reasons that the given fields update is invalid
+func validateFieldsUpdate(update recordHandler2.FieldsUpdate) []string {
+	reasonsInvalid := make([]string, 0)
+	if len(update.Set) == 0 && len(update.Increment) == 0 && len(update.Maximum) == 0 && len(update.SetOnInsert) == 0 && len(update.Pull) == 0 {
+		reasonsInvalid = append(reasonsInvalid, "update is empty")
+	}
+	if _, found := update.Set["id"]; found {
+		reasonsInvalid = append(reasonsInvalid, "id cannot be updated")
+	}
+	if _, found := update.Increment["id"]; found {
+		reasonsInvalid = append(reasonsInvalid, "id cannot be updated")
+	}
+	if _, found := update.Maximum["id"]; found {
+		reasonsInvalid = append(reasonsInvalid, "id cannot be updated")
+	}
+	if _, found := update.Pull["id"]; found {
+		reasonsInvalid = append(reasonsInvalid, "id cannot be updated")
+	}
+	return reasonsInvalid
+}
+
+// validateCriteria returns the reasons that the given criteria are invalid
+func validateCriteria(criteria []criterion.Criterion) []string {
+	reasonsInvalid := make([]string, 0)
+	if criteria == nil {
+		return append(reasonsInvalid, "criteria is nil")
+	}
+	for _, c := range criteria {
+		if c == nil {
+			reasonsInvalid = append(reasonsInvalid, "a criterion is nil")
+		}
+	}
+	return reasonsInvalid
+}
+
+func (r *recordHandler) ValidateUpdateFieldsRequest(request *recordHandler2.UpdateFieldsRequest) error {
+	reasonsInvalid := make([]string, 0)
+
+	if request.Claims == nil {
+		reasonsInvalid = append(reasonsInvalid, "claims are nil")
+	}
+
+	reasonsInvalid = append(reasonsInvalid, validateCriteria(request.Criteria)...)
+	reasonsInvalid = append(reasonsInvalid, validateFieldsUpdate(request.Update)...)
+
+	if len(reasonsInvalid) > 0 {
+		return brainException.RequestInvalid{Reasons: reasonsInvalid}
+	}
+	return nil
+}
+
+func (r *recordHandler) UpdateFields(request *recordHandler2.UpdateFieldsRequest, response *recordHandler2.UpdateFieldsResponse) error {
+	if err := r.ValidateUpdateFieldsRequest(request); err != nil {
+		return err
+	}
+
+	filter := criterion.CriteriaToFilter(request.Criteria)
+	filter = r.contextualiseFilter(filter, request.Claims)
+
+	update := request.Update
+	if request.Upsert {
+		// records created by the upsert are given a new id
+		newId, err := uuid.NewV4()
+		if err != nil {
+			return brainException.UUIDGeneration{Reasons: []string{err.Error()}}
+		}
+		setOnInsert := map[string]interface{}{"id": newId.String()}
+		for field, value := range update.SetOnInsert {
+			setOnInsert[field] = value
+		}
+		update.SetOnInsert = setOnInsert
+	}
+
+	mgoSession := r.mongoSession.Copy()
+	defer mgoSession.Close()
+	collection := mgoSession.DB(r.database).C(r.collection)
+
+	if _, err := collection.Find(filter).
+		Sort(request.Query.ToMongoSortFormat()...).
+		Apply(mgo.Change{
+			Update:    toMongoUpdate(update),
+			Upsert:    request.Upsert,
+			ReturnNew: true,
+		}, response.Entity); err != nil {
+		if err == mgo.ErrNotFound {
+			return exception.NotFound{}
+		}
+		if mgo.IsDup(err) {
+			return exception.Duplicate{Reasons: []string{err.Error()}}
+		}
+		return exception.Update{Reasons: []string{"updating record fields", err.Error()}}
+	}
+
+	return nil
+}
+
+func (r *recordHandler) ValidateUpdateAllRequest(request *recordHandler2.UpdateAllRequest) error {
+	reasonsInvalid := make([]string, 0)
+
+	if request.Claims == nil {
+		reasonsInvalid = append(reasonsInvalid, "claims are nil")
+	}
+
+	reasonsInvalid = append(reasonsInvalid, validateCriteria(request.Criteria)...)
+	reasonsInvalid = append(reasonsInvalid, validateFieldsUpdate(request.Update)...)
+	if len(request.Update.SetOnInsert) > 0 {
+		reasonsInvalid = append(reasonsInvalid, "set on insert not supported when updating all")
+	}
+
+	if len(reasonsInvalid) > 0 {
+		return brainException.RequestInvalid{Reasons: reasonsInvalid}
+	}
+	return nil
+}
+
+func (r *recordHandler) UpdateAll(request *recordHandler2.UpdateAllRequest, response *recordHandler2.UpdateAllResponse) error {
+	if err := r.ValidateUpdateAllRequest(request); err != nil {
+		return err
+	}
+
+	filter := criterion.CriteriaToFilter(request.Criteria)
+	filter = r.contextualiseFilter(filter, request.Claims)
+
+	mgoSession := r.mongoSession.Copy()
+	defer mgoSession.Close()
+	collection := mgoSession.DB(r.database).C(r.collection)
+
+	changeInfo, err := collection.UpdateAll(filter, toMongoUpdate(request.Update))
+	if err != nil {
+		return exception.Update{Reasons: []string{"updating records", err.Error()}}
+	}
+	response.NoUpdated = changeInfo.Updated
+
+	return nil
+}
+
+func (r *recordHandler) ValidateDeleteAllRequest(request *recordHandler2.DeleteAllRequest) error {
+	reasonsInvalid := make([]string, 0)
+
+	if request.Claims == nil {
+		reasonsInvalid = append(reasonsInvalid, "claims are nil")
+	}
+
+	reasonsInvalid = append(reasonsInvalid, validateCriteria(request.Criteria)...)
+	// guard against accidentally deleting the whole collection
+	if request.Criteria != nil && len(request.Criteria) == 0 {
+		reasonsInvalid = append(reasonsInvalid, "at least one criterion is required")
+	}
+
+	if len(reasonsInvalid) > 0 {
+		return brainException.RequestInvalid{Reasons: reasonsInvalid}
+	}
+	return nil
+}
+
+func (r *recordHandler) DeleteAll(request *recordHandler2.DeleteAllRequest, response *recordHandler2.DeleteAllResponse) error {
+	if err := r.ValidateDeleteAllRequest(request); err != nil {
+		return err
+	}
+
+	filter := criterion.CriteriaToFilter(request.Criteria)
+	filter = r.contextualiseFilter(filter, request.Claims)
+
+	mgoSession := r.mongoSession.Copy()
+	defer mgoSession.Close()
+	collection := mgoSession.DB(r.database).C(r.collection)
+
+	changeInfo, err := collection.RemoveAll(filter)
+	if err != nil {
+		return exception.Delete{Reasons: []string{"deleting records", err.Error()}}
+	}
+	response.NoDeleted = changeInfo.Removed
+
+	return nil
+}
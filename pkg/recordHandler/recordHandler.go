@@ -14,12 +14,18 @@ type RecordHandler interface {
 	Update(request *UpdateRequest, response *UpdateResponse) error
 	Delete(request *DeleteRequest, response *DeleteResponse) error
 	Collect(request *CollectRequest, response *CollectResponse) error
+	UpdateFields(request *UpdateFieldsRequest, response *UpdateFieldsResponse) error
+	UpdateAll(request *UpdateAllRequest, response *UpdateAllResponse) error
+	DeleteAll(request *DeleteAllRequest, response *DeleteAllResponse) error
 }
 
 type CollectRequest struct {
 	Claims   claims.Claims
 	Criteria []criterion.Criterion
 	Query    query.Query
+	// SkipCount leaves the total number of matching records uncounted,
+	// e.g. when paging through records where the total is not needed
+	SkipCount bool
 }
 
 type CollectResponse struct {
@@ -58,3 +64,55 @@ type RetrieveRequest struct {
 type RetrieveResponse struct {
 	Entity entity.Entity
 }
+
+// FieldsUpdate is a partial update of the fields of a record.
+// Set fields are overwritten, Increment fields are incremented by
+// the given amount, Maximum fields are only overwritten by greater
+// values, SetOnInsert fields are only set when the record is
+// created by an upsert and Pull fields are arrays from which the
+// given value is removed.
+type FieldsUpdate struct {
+	Set         map[string]interface{}
+	Increment   map[string]interface{}
+	Maximum     map[string]interface{}
+	SetOnInsert map[string]interface{}
+	Pull        map[string]interface{}
+}
+
+// UpdateFieldsRequest atomically applies Update to the first record
+// matching Criteria, in the sort order of Query. If Upsert is set a
+// record is created when none match.
+type UpdateFieldsRequest struct {
+	Claims   claims.Claims
+	Criteria []criterion.Criterion
+	Query    query.Query
+	Update   FieldsUpdate
+	Upsert   bool
+}
+
+// UpdateFieldsResponse is populated with the record as it is after
+// the update. Entity must be set to the entity to populate.
+type UpdateFieldsResponse struct {
+	Entity entity.Entity
+}
+
+// UpdateAllRequest applies Update to all records matching Criteria
+type UpdateAllRequest struct {
+	Claims   claims.Claims
+	Criteria []criterion.Criterion
+	Update   FieldsUpdate
+}
+
+type UpdateAllResponse struct {
+	NoUpdated int
+}
+
+// DeleteAllRequest deletes all records matching Criteria
+type DeleteAllRequest struct {
+	Claims   claims.Claims
+	Criteria []criterion.Criterion
+}
+
+type DeleteAllResponse struct {
+	NoDeleted int
+}
@@ -38,7 +38,7 @@ type LiveRequest struct {
 }
 
 type LiveResponse struct {
-	ZX303TrackerGPSReadings []sigbugReading.Reading `json:"zx303TrackerGPSReadings"`
+	SigbugGPSReadings []sigbugReading.Reading `json:"sigbugGPSReadings"`
 }
 
 func (a *adaptor) Live(r *http.Request, request *LiveRequest, response *LiveResponse) error {
@@ -67,18 +67,20 @@ func (a *adaptor) Live(r *http.Request, request *LiveRequest, response *LiveResp
 		return err
 	}
 
-	response.ZX303TrackerGPSReadings = liveTrackingReportResponse.ZX303TrackerGPSReadings
+	response.SigbugGPSReadings = liveTrackingReportResponse.SigbugGPSReadings
 
 	return nil
 }
 
 type HistoricalRequest struct {
-	WrappedCompanyIdentifiers []wrappedIdentifier.Wrapped `json:"companyIdentifiers"`
-	WrappedClientIdentifiers  []wrappedIdentifier.Wrapped `json:"clientIdentifiers"`
+	WrappedPartyIdentifiers  []wrappedIdentifier.Wrapped `json:"partyIdentifiers"`
+	WrappedDeviceIdentifiers []wrappedIdentifier.Wrapped `json:"deviceIdentifiers"`
+	StartDate                int64                       `json:"startDate"`
+	EndDate                  int64                       `json:"endDate"`
 }
 
 type HistoricalResponse struct {
-	ZX303TrackerGPSReadings []sigbugReading.Reading `json:"zx303TrackerGPSReadings"`
+	SigbugGPSReadings []sigbugReading.Reading `json:"sigbugGPSReadings"`
 }
 
 func (a *adaptor) Historical(r *http.Request, request *HistoricalRequest, response *HistoricalResponse) error {
@@ -88,34 +90,34 @@ func (a *adaptor) Historical(r *http.Request, request *HistoricalRequest, respon
 		return err
 	}
 
-	// unwrap company identifiers
-	companyIdentifiers := make([]identifier.Identifier, 0)
-	for i := range request.WrappedCompanyIdentifiers {
-		partyIdentifier, ok := request.WrappedCompanyIdentifiers[i].Identifier.(party.Identifier)
+	// unwrap party identifiers
+	partyIdentifiers := make([]party.Identifier, 0)
+	for i := range request.WrappedPartyIdentifiers {
+		partyIdentifier, ok := request.WrappedPartyIdentifiers[i].Identifier.(party.Identifier)
 		if !ok {
 			return errors.New("could not cast identifier.Identifier to party.Identifier")
 		}
-		companyIdentifiers = append(companyIdentifiers, partyIdentifier)
+		partyIdentifiers = append(partyIdentifiers, partyIdentifier)
 	}
-	// unwrap client criteria
-	clientIdentifiers := make([]identifier.Identifier, 0)
-	for i := range request.WrappedClientIdentifiers {
-		partyIdentifier, ok := request.WrappedClientIdentifiers[i].Identifier.(party.Identifier)
-		if !ok {
-			return errors.New("could not cast identifier.Identifier to party.Identifier")
-		}
-		clientIdentifiers = append(clientIdentifiers, partyIdentifier)
+	// unwrap device identifiers
+	deviceIdentifiers := make([]identifier.Identifier, 0)
+	for i := range request.WrappedDeviceIdentifiers {
+		deviceIdentifiers = append(deviceIdentifiers, request.WrappedDeviceIdentifiers[i].Identifier)
 	}
 
 	// get report
 	historicalTrackingReportResponse, err := a.trackingReport.Historical(&tracking.HistoricalRequest{
-		Claims: claims,
+		Claims:            claims,
+		PartyIdentifiers:  partyIdentifiers,
+		DeviceIdentifiers: deviceIdentifiers,
+		StartDate:         request.StartDate,
+		EndDate:           request.EndDate,
 	})
 	if err != nil {
 		return err
 	}
 
-	response.ZX303TrackerGPSReadings = historicalTrackingReportResponse.ZX303TrackerGPSReadings
+	response.SigbugGPSReadings = historicalTrackingReportResponse.SigbugGPSReadings
 
 	return nil
 }
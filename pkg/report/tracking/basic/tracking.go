@@ -2,20 +2,38 @@ package basic
 
 import (
 	brainException "github.com/iot-my-world/brain/internal/exception"
+	"github.com/iot-my-world/brain/pkg/device/sigbug"
 	sigbugReading "github.com/iot-my-world/brain/pkg/device/sigbug/reading/gps"
+	sigbugGPSReadingRecordHandler "github.com/iot-my-world/brain/pkg/device/sigbug/reading/gps/recordHandler"
+	sigbugRecordHandler "github.com/iot-my-world/brain/pkg/device/sigbug/recordHandler"
 	partyAdministrator "github.com/iot-my-world/brain/pkg/party/administrator"
 	"github.com/iot-my-world/brain/pkg/report/tracking"
+	trackingReportException "github.com/iot-my-world/brain/pkg/report/tracking/exception"
+	"github.com/iot-my-world/brain/pkg/search/criterion"
+	exactTextCriterion "github.com/iot-my-world/brain/pkg/search/criterion/exact/text"
+	textListCriterion "github.com/iot-my-world/brain/pkg/search/criterion/list/text"
+	"github.com/iot-my-world/brain/pkg/search/criterion/or"
+	dateRangeCriterion "github.com/iot-my-world/brain/pkg/search/criterion/range/date"
+	"github.com/iot-my-world/brain/pkg/search/identifier/party"
+	"github.com/iot-my-world/brain/pkg/search/query"
+	"github.com/iot-my-world/brain/pkg/security/claims"
 )
 
 type basicTrackingReport struct {
-	partyAdministrator partyAdministrator.Administrator
+	partyAdministrator            partyAdministrator.Administrator
+	sigbugRecordHandler           sigbugRecordHandler.RecordHandler
+	sigbugGPSReadingRecordHandler sigbugGPSReadingRecordHandler.RecordHandler
 }
 
 func New(
 	partyAdministrator partyAdministrator.Administrator,
+	sigbugRecordHandler sigbugRecordHandler.RecordHandler,
+	sigbugGPSReadingRecordHandler sigbugGPSReadingRecordHandler.RecordHandler,
 ) tracking.Report {
 	return &basicTrackingReport{
-		partyAdministrator: partyAdministrator,
+		partyAdministrator:            partyAdministrator,
+		sigbugRecordHandler:           sigbugRecordHandler,
+		sigbugGPSReadingRecordHandler: sigbugGPSReadingRecordHandler,
 	}
 }
 
@@ -48,98 +66,100 @@ func (btr *basicTrackingReport) Live(request *tracking.LiveRequest) (*tracking.L
 	}
 
 	// records to return
-	zx303GPSLiveReportReadings := make([]sigbugReading.Reading, 0)
-	//
-	//// query for collecting only latest reading
-	//collectQuery := query.Query{
-	//	Limit:  1,
-	//	Offset: 0,
-	//	Order:  []query.SortOrder{query.SortOrderDescending},
-	//	SortBy: []string{"timeStamp"},
-	//}
-	//
-	//// retrieve each party with provided identifiers
-	//for _, partyIdentifier := range request.PartyIdentifiers {
-	//	retrieveResponse, err := btr.partyAdministrator.RetrieveParty(&partyAdministrator.RetrievePartyRequest{
-	//		Claims:     request.Claims,
-	//		Identifier: partyIdentifier.PartyIdIdentifier,
-	//		PartyType:  partyIdentifier.PartyType,
-	//	})
-	//	if err != nil {
-	//		return nil, trackingReportException.RetrievingParty{Reasons: []string{string(partyIdentifier.PartyType), err.Error()}}
-	//	}
-	//
-	//	// criterion to collect all devices either owned by or assigned to the party
-	//	collectCriterion := or.Criterion{
-	//		Criteria: []criterion.Criterion{
-	//			textListCriterion.Criterion{
-	//				Field: "ownerId.id",
-	//				List:  []string{retrieveResponse.Party.Details().PartyId.Id},
-	//			},
-	//			textListCriterion.Criterion{
-	//				Field: "assignedId.id",
-	//				List:  []string{retrieveResponse.Party.Details().PartyId.Id},
-	//			},
-	//		},
-	//	}
-	//
-	//	// collect all the zx303 devices
-	//	zx303TrackerCollectResponse, err := btr.zx303TrackerRecordHandler.Collect(&zx303TrackerRecordHandler.CollectRequest{
-	//		Claims:   request.Claims,
-	//		Criteria: []criterion.Criterion{collectCriterion},
-	//		// Query: left blank to collect all. i.e. no limit
-	//	})
-	//	if err != nil {
-	//		return nil, trackingReportException.CollectingDevices{Reasons: []string{"zx303 devices", err.Error()}}
-	//	}
-	//
-	//	// collect the last reading associated with each of these devices
-	//	for devIdx := range zx303TrackerCollectResponse.Records {
-	//		// exact text criterion for this device
-	//		deviceIDExactTextCriterion := exactTextCriterion.Criterion{
-	//			Field: "deviceId.id",
-	//			Text:  zx303TrackerCollectResponse.Records[devIdx].Id,
-	//		}
-	//
-	//		// collect the latest reading for this device
-	//		readingCollectResponse, err := btr.zx303TrackerReadingRecordHandler.Collect(&zx303TrackerReadingRecordHandler.CollectRequest{
-	//			Claims:   request.Claims,
-	//			Query:    collectQuery,
-	//			Criteria: []criterion.Criterion{deviceIDExactTextCriterion},
-	//		})
-	//		if err != nil {
-	//			return nil, trackingReportException.CollectingReadings{Reasons: []string{"tk102 device readings", err.Error()}}
-	//		}
-	//		// if any readings have been collected for this device
-	//		if len(readingCollectResponse.Records) > 0 {
-	//			if len(zx303GPSLiveReportReadings) == 0 {
-	//				// if noting has been added to the live report readings yet, add it now
-	//				zx303GPSLiveReportReadings = append(zx303GPSLiveReportReadings, readingCollectResponse.Records[0])
-	//			} else {
-	//				// otherwise check if the reading has not yet been added
-	//				for readingIdx := range zx303GPSLiveReportReadings {
-	//					if zx303GPSLiveReportReadings[readingIdx].Id == readingCollectResponse.Records[0].Id {
-	//						// it has already been added, break
-	//						break
-	//					}
-	//					if readingIdx == len(zx303GPSLiveReportReadings)-1 {
-	//						// it has not been added, add it now
-	//						zx303GPSLiveReportReadings = append(zx303GPSLiveReportReadings, readingCollectResponse.Records[0])
-	//					}
-	//				}
-	//			}
-	//		}
-	//	}
-	//}
+	sigbugGPSLiveReportReadings := make([]sigbugReading.Reading, 0)
+
+	// query for collecting only latest reading
+	collectQuery := query.Query{
+		Limit:  1,
+		Offset: 0,
+		Order:  []query.SortOrder{query.SortOrderDescending},
+		SortBy: []string{"timeStamp"},
+	}
+
+	// keep track of devices for which the latest reading has already been added
+	devicesAdded := make(map[string]bool)
+
+	// retrieve each party with provided identifiers
+	for _, partyIdentifier := range request.PartyIdentifiers {
+		sigbugDevices, err := btr.collectPartyDevices(request.Claims, partyIdentifier)
+		if err != nil {
+			return nil, err
+		}
+
+		// collect the last reading associated with each of these devices
+		for devIdx := range sigbugDevices {
+			if devicesAdded[sigbugDevices[devIdx].Id] {
+				continue
+			}
+
+			// collect the latest reading for this device
+			readingCollectResponse, err := btr.sigbugGPSReadingRecordHandler.Collect(&sigbugGPSReadingRecordHandler.CollectRequest{
+				Claims: request.Claims,
+				Query:  collectQuery,
+				Criteria: []criterion.Criterion{
+					exactTextCriterion.Criterion{
+						Field: "deviceId.id",
+						Text:  sigbugDevices[devIdx].Id,
+					},
+				},
+			})
+			if err != nil {
+				return nil, trackingReportException.CollectingReadings{Reasons: []string{"sigbug gps readings", err.Error()}}
+			}
+
+			// if any readings have been collected for this device, add the latest one
+			if len(readingCollectResponse.Records) > 0 {
+				sigbugGPSLiveReportReadings = append(sigbugGPSLiveReportReadings, readingCollectResponse.Records[0])
+			}
+			devicesAdded[sigbugDevices[devIdx].Id] = true
+		}
+	}
 
 	return &tracking.LiveResponse{
-		ZX303TrackerGPSReadings: zx303GPSLiveReportReadings,
+		SigbugGPSReadings: sigbugGPSLiveReportReadings,
 	}, nil
 }
 
 func (btr *basicTrackingReport) ValidateHistoricalRequest(request *tracking.HistoricalRequest) error {
 	reasonsInvalid := make([]string, 0)
 
+	// confirm that the claims are not nil
+	if request.Claims == nil {
+		reasonsInvalid = append(reasonsInvalid, "claims are nil")
+	}
+
+	if len(request.PartyIdentifiers) == 0 && len(request.DeviceIdentifiers) == 0 {
+		reasonsInvalid = append(reasonsInvalid, "no party or device identifiers given")
+	}
+
+	// confirm that all of the party identifiers are valid
+	for idIdx := range request.PartyIdentifiers {
+		if err := request.PartyIdentifiers[idIdx].IsValid(); err != nil {
+			reasonsInvalid = append(reasonsInvalid, "invalid party identifier; "+err.Error())
+			break
+		}
+	}
+
+	// confirm that all of the device identifiers are valid
+	for idIdx := range request.DeviceIdentifiers {
+		if request.DeviceIdentifiers[idIdx] == nil {
+			reasonsInvalid = append(reasonsInvalid, "device identifier is nil")
+			break
+		}
+		if err := request.DeviceIdentifiers[idIdx].IsValid(); err != nil {
+			reasonsInvalid = append(reasonsInvalid, "invalid device identifier; "+err.Error())
+			break
+		}
+	}
+
+	// confirm that the date range is valid
+	if request.StartDate < 0 {
+		reasonsInvalid = append(reasonsInvalid, "start date is negative")
+	}
+	if request.EndDate < request.StartDate {
+		reasonsInvalid = append(reasonsInvalid, "end date is before start date")
+	}
+
 	if len(reasonsInvalid) > 0 {
 		return brainException.RequestInvalid{Reasons: reasonsInvalid}
 	}
@@ -152,7 +172,115 @@ func (btr *basicTrackingReport) Historical(request *tracking.HistoricalRequest)
 		return nil, err
 	}
 
+	// ids of devices for which readings are to be collected
+	deviceIds := make([]string, 0)
+	devicesAdded := make(map[string]bool)
+
+	// retrieve each device with provided identifiers
+	for _, deviceIdentifier := range request.DeviceIdentifiers {
+		retrieveResponse, err := btr.sigbugRecordHandler.Retrieve(&sigbugRecordHandler.RetrieveRequest{
+			Claims:     request.Claims,
+			Identifier: deviceIdentifier,
+		})
+		if err != nil {
+			return nil, trackingReportException.RetrievingDevice{Reasons: []string{"sigbug device", err.Error()}}
+		}
+		if !devicesAdded[retrieveResponse.Sigbug.Id] {
+			deviceIds = append(deviceIds, retrieveResponse.Sigbug.Id)
+			devicesAdded[retrieveResponse.Sigbug.Id] = true
+		}
+	}
+
+	// collect all of the devices owned by or assigned to each party with provided identifiers
+	for _, partyIdentifier := range request.PartyIdentifiers {
+		sigbugDevices, err := btr.collectPartyDevices(request.Claims, partyIdentifier)
+		if err != nil {
+			return nil, err
+		}
+		for devIdx := range sigbugDevices {
+			if !devicesAdded[sigbugDevices[devIdx].Id] {
+				deviceIds = append(deviceIds, sigbugDevices[devIdx].Id)
+				devicesAdded[sigbugDevices[devIdx].Id] = true
+			}
+		}
+	}
+
+	// if no devices were found there are no readings to collect
+	if len(deviceIds) == 0 {
+		return &tracking.HistoricalResponse{
+			SigbugGPSReadings: make([]sigbugReading.Reading, 0),
+		}, nil
+	}
+
+	// collect all of the readings for these devices in the given date range
+	readingCollectResponse, err := btr.sigbugGPSReadingRecordHandler.Collect(&sigbugGPSReadingRecordHandler.CollectRequest{
+		Claims: request.Claims,
+		Criteria: []criterion.Criterion{
+			textListCriterion.Criterion{
+				Field: "deviceId.id",
+				List:  deviceIds,
+			},
+			dateRangeCriterion.Criterion{
+				Field: "timeStamp",
+				StartDate: dateRangeCriterion.RangeValue{
+					Date:      request.StartDate,
+					Inclusive: true,
+				},
+				EndDate: dateRangeCriterion.RangeValue{
+					Date:      request.EndDate,
+					Inclusive: true,
+				},
+			},
+		},
+		Query: query.Query{
+			Order:  []query.SortOrder{query.SortOrderAscending},
+			SortBy: []string{"timeStamp"},
+		},
+	})
+	if err != nil {
+		return nil, trackingReportException.CollectingReadings{Reasons: []string{"sigbug gps readings", err.Error()}}
+	}
+
 	return &tracking.HistoricalResponse{
-		//Readings: make([]reading.Reading, 0),
+		SigbugGPSReadings: readingCollectResponse.Records,
 	}, nil
 }
+
+// collectPartyDevices retrieves the party with the given identifier and
+// collects all of the sigbug devices either owned by or assigned to it
+func (btr *basicTrackingReport) collectPartyDevices(claims claims.Claims, partyIdentifier party.Identifier) ([]sigbug.Sigbug, error) {
+	retrieveResponse, err := btr.partyAdministrator.RetrieveParty(&partyAdministrator.RetrievePartyRequest{
+		Claims:     claims,
+		Identifier: partyIdentifier.PartyIdIdentifier,
+		PartyType:  partyIdentifier.PartyType,
+	})
+	if err != nil {
+		return nil, trackingReportException.RetrievingParty{Reasons: []string{string(partyIdentifier.PartyType), err.Error()}}
+	}
+
+	// criterion to collect all devices either owned by or assigned to the party
+	collectCriterion := or.Criterion{
+		Criteria: []criterion.Criterion{
+			textListCriterion.Criterion{
+				Field: "ownerId.id",
+				List:  []string{retrieveResponse.Party.Details().PartyId.Id},
+			},
+			textListCriterion.Criterion{
+				Field: "assignedId.id",
+				List:  []string{retrieveResponse.Party.Details().PartyId.Id},
+			},
+		},
+	}
+
+	// collect all the sigbug devices
+	sigbugCollectResponse, err := btr.sigbugRecordHandler.Collect(&sigbugRecordHandler.CollectRequest{
+		Claims:   claims,
+		Criteria: []criterion.Criterion{collectCriterion},
+		// Query: left blank to collect all. i.e. no limit
+	})
+	if err != nil {
+		return nil, trackingReportException.CollectingDevices{Reasons: []string{"sigbug devices", err.Error()}}
+	}
+
+	return sigbugCollectResponse.Records, nil
+}
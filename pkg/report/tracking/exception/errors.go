@@ -37,6 +37,14 @@ func (e RetrievingClient) Error() string {
 	return fmt.Sprintf("error retrieving client: %s", strings.Join(e.Reasons, "; "))
 }
 
+type RetrievingDevice struct {
+	Reasons []string
+}
+
+func (e RetrievingDevice) Error() string {
+	return fmt.Sprintf("error retrieving device: %s", strings.Join(e.Reasons, "; "))
+}
+
 type CollectingDevices struct {
 	Reasons []string
 }
@@ -2,6 +2,7 @@ package tracking
 
 import (
 	sigbugReading "github.com/iot-my-world/brain/pkg/device/sigbug/reading/gps"
+	"github.com/iot-my-world/brain/pkg/search/identifier"
 	"github.com/iot-my-world/brain/pkg/search/identifier/party"
 	"github.com/iot-my-world/brain/pkg/security/claims"
 	"github.com/iot-my-world/brain/pkg/security/permission/api"
@@ -44,13 +45,17 @@ type LiveRequest struct {
 }
 
 type LiveResponse struct {
-	ZX303TrackerGPSReadings []sigbugReading.Reading
+	SigbugGPSReadings []sigbugReading.Reading
 }
 
 type HistoricalRequest struct {
-	Claims claims.Claims
+	Claims            claims.Claims
+	PartyIdentifiers  []party.Identifier
+	DeviceIdentifiers []identifier.Identifier
+	StartDate         int64
+	EndDate           int64
 }
 
 type HistoricalResponse struct {
-	ZX303TrackerGPSReadings []sigbugReading.Reading
+	SigbugGPSReadings []sigbugReading.Reading
 }
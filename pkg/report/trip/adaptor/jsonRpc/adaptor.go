@@ -0,0 +1,73 @@
+package jsonRpc
+
+import (
+	"github.com/iot-my-world/brain/internal/log"
+	jsonRpcServiceProvider "github.com/iot-my-world/brain/pkg/api/jsonRpc/service/provider"
+	"github.com/iot-my-world/brain/pkg/device/sigbug/trip"
+	tripReport "github.com/iot-my-world/brain/pkg/report/trip"
+	"github.com/iot-my-world/brain/pkg/search/identifier"
+	wrappedIdentifier "github.com/iot-my-world/brain/pkg/search/identifier/wrapped"
+	wrappedClaims "github.com/iot-my-world/brain/pkg/security/claims/wrapped"
+	"net/http"
+)
+
+type adaptor struct {
+	tripReport tripReport.Report
+}
+
+func New(
+	tripReport tripReport.Report,
+) *adaptor {
+	return &adaptor{
+		tripReport: tripReport,
+	}
+}
+
+func (a *adaptor) Name() jsonRpcServiceProvider.Name {
+	return jsonRpcServiceProvider.Name(tripReport.ServiceProvider)
+}
+
+func (a *adaptor) MethodRequiresAuthorization(string) bool {
+	return true
+}
+
+type SummaryRequest struct {
+	WrappedDeviceIdentifiers []wrappedIdentifier.Wrapped `json:"deviceIdentifiers"`
+	StartDate                int64                       `json:"startDate"`
+	EndDate                  int64                       `json:"endDate"`
+}
+
+type SummaryResponse struct {
+	Trips   []trip.Trip                `json:"trips"`
+	Devices []tripReport.DeviceSummary `json:"devices"`
+}
+
+func (a *adaptor) Summary(r *http.Request, request *SummaryRequest, response *SummaryResponse) error {
+	claims, err := wrappedClaims.UnwrapClaimsFromContext(r)
+	if err != nil {
+		log.Warn(err.Error())
+		return err
+	}
+
+	// unwrap device identifiers
+	deviceIdentifiers := make([]identifier.Identifier, 0)
+	for i := range request.WrappedDeviceIdentifiers {
+		deviceIdentifiers = append(deviceIdentifiers, request.WrappedDeviceIdentifiers[i].Identifier)
+	}
+
+	// get report
+	summaryResponse, err := a.tripReport.Summary(&tripReport.SummaryRequest{
+		Claims:            claims,
+		DeviceIdentifiers: deviceIdentifiers,
+		StartDate:         request.StartDate,
+		EndDate:           request.EndDate,
+	})
+	if err != nil {
+		return err
+	}
+
+	response.Trips = summaryResponse.Trips
+	response.Devices = summaryResponse.Devices
+
+	return nil
+}
@@ -0,0 +1,162 @@
+package basic
+
+import (
+	brainException "github.com/iot-my-world/brain/internal/exception"
+	sigbugRecordHandler "github.com/iot-my-world/brain/pkg/device/sigbug/recordHandler"
+	"github.com/iot-my-world/brain/pkg/device/sigbug/trip"
+	sigbugTripRecordHandler "github.com/iot-my-world/brain/pkg/device/sigbug/trip/recordHandler"
+	tripReport "github.com/iot-my-world/brain/pkg/report/trip"
+	tripReportException "github.com/iot-my-world/brain/pkg/report/trip/exception"
+	"github.com/iot-my-world/brain/pkg/search/criterion"
+	textListCriterion "github.com/iot-my-world/brain/pkg/search/criterion/list/text"
+	dateRangeCriterion "github.com/iot-my-world/brain/pkg/search/criterion/range/date"
+	"github.com/iot-my-world/brain/pkg/search/query"
+)
+
+type basicTripReport struct {
+	sigbugRecordHandler     sigbugRecordHandler.RecordHandler
+	sigbugTripRecordHandler sigbugTripRecordHandler.RecordHandler
+}
+
+func New(
+	sigbugRecordHandler sigbugRecordHandler.RecordHandler,
+	sigbugTripRecordHandler sigbugTripRecordHandler.RecordHandler,
+) tripReport.Report {
+	return &basicTripReport{
+		sigbugRecordHandler:     sigbugRecordHandler,
+		sigbugTripRecordHandler: sigbugTripRecordHandler,
+	}
+}
+
+func (btr *basicTripReport) ValidateSummaryRequest(request *tripReport.SummaryRequest) error {
+	reasonsInvalid := make([]string, 0)
+
+	// confirm that the claims are not nil
+	if request.Claims == nil {
+		reasonsInvalid = append(reasonsInvalid, "claims are nil")
+	}
+
+	// confirm that all of the device identifiers are valid
+	for idIdx := range request.DeviceIdentifiers {
+		if request.DeviceIdentifiers[idIdx] == nil {
+			reasonsInvalid = append(reasonsInvalid, "device identifier is nil")
+			break
+		}
+		if err := request.DeviceIdentifiers[idIdx].IsValid(); err != nil {
+			reasonsInvalid = append(reasonsInvalid, "invalid device identifier; "+err.Error())
+			break
+		}
+	}
+
+	// confirm that the date range is valid
+	if request.StartDate < 0 {
+		reasonsInvalid = append(reasonsInvalid, "start date is negative")
+	}
+	if request.EndDate < request.StartDate {
+		reasonsInvalid = append(reasonsInvalid, "end date is before start date")
+	}
+
+	if len(reasonsInvalid) > 0 {
+		return brainException.RequestInvalid{Reasons: reasonsInvalid}
+	}
+
+	return nil
+}
+
+func (btr *basicTripReport) Summary(request *tripReport.SummaryRequest) (*tripReport.SummaryResponse, error) {
+	if err := btr.ValidateSummaryRequest(request); err != nil {
+		return nil, err
+	}
+
+	// collect trips which overlap the date range
+	collectCriteria := []criterion.Criterion{
+		dateRangeCriterion.Criterion{
+			Field: "endTimeStamp",
+			StartDate: dateRangeCriterion.RangeValue{
+				Date:      request.StartDate,
+				Inclusive: true,
+			},
+			EndDate: dateRangeCriterion.RangeValue{
+				Ignore: true,
+			},
+		},
+		dateRangeCriterion.Criterion{
+			Field: "startTimeStamp",
+			StartDate: dateRangeCriterion.RangeValue{
+				Ignore: true,
+			},
+			EndDate: dateRangeCriterion.RangeValue{
+				Date:      request.EndDate,
+				Inclusive: true,
+			},
+		},
+	}
+
+	// if device identifiers are given only collect the trips of those devices
+	if len(request.DeviceIdentifiers) > 0 {
+		deviceIds := make([]string, 0)
+		devicesAdded := make(map[string]bool)
+		for _, deviceIdentifier := range request.DeviceIdentifiers {
+			retrieveResponse, err := btr.sigbugRecordHandler.Retrieve(&sigbugRecordHandler.RetrieveRequest{
+				Claims:     request.Claims,
+				Identifier: deviceIdentifier,
+			})
+			if err != nil {
+				return nil, tripReportException.RetrievingDevice{Reasons: []string{"sigbug device", err.Error()}}
+			}
+			if !devicesAdded[retrieveResponse.Sigbug.Id] {
+				deviceIds = append(deviceIds, retrieveResponse.Sigbug.Id)
+				devicesAdded[retrieveResponse.Sigbug.Id] = true
+			}
+		}
+		collectCriteria = append(collectCriteria, textListCriterion.Criterion{
+			Field: "deviceId.id",
+			List:  deviceIds,
+		})
+	}
+
+	tripCollectResponse, err := btr.sigbugTripRecordHandler.Collect(&sigbugTripRecordHandler.CollectRequest{
+		Claims:   request.Claims,
+		Criteria: collectCriteria,
+		Query: query.Query{
+			Order:  []query.SortOrder{query.SortOrderAscending},
+			SortBy: []string{"startTimeStamp"},
+		},
+	})
+	if err != nil {
+		return nil, tripReportException.CollectingTrips{Reasons: []string{"sigbug trips", err.Error()}}
+	}
+
+	// total the trips of each device, in the order in which devices are first seen
+	deviceSummaries := make([]tripReport.DeviceSummary, 0)
+	deviceSummaryIdx := make(map[string]int)
+	for _, tripToSummarise := range tripCollectResponse.Records {
+		summaryIdx, found := deviceSummaryIdx[tripToSummarise.DeviceId.Id]
+		if !found {
+			summaryIdx = len(deviceSummaries)
+			deviceSummaryIdx[tripToSummarise.DeviceId.Id] = summaryIdx
+			deviceSummaries = append(deviceSummaries, tripReport.DeviceSummary{
+				DeviceId: tripToSummarise.DeviceId,
+			})
+		}
+		deviceSummary := &deviceSummaries[summaryIdx]
+
+		switch tripToSummarise.Type {
+		case trip.Moving:
+			deviceSummary.NoTrips++
+			deviceSummary.Distance += tripToSummarise.Distance
+			deviceSummary.MovingDuration += tripToSummarise.Duration
+			if tripToSummarise.MaxSpeed > deviceSummary.MaxSpeed {
+				deviceSummary.MaxSpeed = tripToSummarise.MaxSpeed
+			}
+		case trip.Stopped:
+			deviceSummary.NoStops++
+			deviceSummary.StoppedDuration += tripToSummarise.Duration
+		}
+	}
+
+	return &tripReport.SummaryResponse{
+		Trips:   tripCollectResponse.Records,
+		Devices: deviceSummaries,
+	}, nil
+}
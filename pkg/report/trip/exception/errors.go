@@ -0,0 +1,22 @@
+package exception
+
+import (
+	"fmt"
+	"strings"
+)
+
+type RetrievingDevice struct {
+	Reasons []string
+}
+
+func (e RetrievingDevice) Error() string {
+	return fmt.Sprintf("error retrieving device: %s", strings.Join(e.Reasons, "; "))
+}
+
+type CollectingTrips struct {
+	Reasons []string
+}
+
+func (e CollectingTrips) Error() string {
+	return fmt.Sprintf("error collecting trips: %s", strings.Join(e.Reasons, "; "))
+}
@@ -0,0 +1,61 @@
+package trip
+
+import (
+	"github.com/iot-my-world/brain/pkg/device/sigbug/trip"
+	"github.com/iot-my-world/brain/pkg/search/identifier"
+	"github.com/iot-my-world/brain/pkg/search/identifier/id"
+	"github.com/iot-my-world/brain/pkg/security/claims"
+	"github.com/iot-my-world/brain/pkg/security/permission/api"
+)
+
+type Report interface {
+	Summary(request *SummaryRequest) (*SummaryResponse, error)
+}
+
+const ServiceProvider = "Trip-Report"
+const SummaryService = ServiceProvider + ".Summary"
+
+var SystemUserPermissions = make([]api.Permission, 0)
+
+var CompanyAdminUserPermissions = []api.Permission{
+	SummaryService,
+}
+
+var CompanyUserPermissions = []api.Permission{
+	SummaryService,
+}
+
+var ClientAdminUserPermissions = []api.Permission{
+	SummaryService,
+}
+
+var ClientUserPermissions = []api.Permission{
+	SummaryService,
+}
+
+// SummaryRequest is a request for the trips and stops of the devices with the
+// given identifiers, or of all devices if none are given, which overlap the
+// given date range
+type SummaryRequest struct {
+	Claims            claims.Claims
+	DeviceIdentifiers []identifier.Identifier
+	StartDate         int64
+	EndDate           int64
+}
+
+type SummaryResponse struct {
+	Trips   []trip.Trip
+	Devices []DeviceSummary
+}
+
+// DeviceSummary totals the trips and stops of a device. Distance is in
+// metres, durations in seconds and MaxSpeed in km/h.
+type DeviceSummary struct {
+	DeviceId        id.Identifier `json:"deviceId"`
+	NoTrips         int           `json:"noTrips"`
+	NoStops         int           `json:"noStops"`
+	Distance        float32       `json:"distance"`
+	MovingDuration  int64         `json:"movingDuration"`
+	StoppedDuration int64         `json:"stoppedDuration"`
+	MaxSpeed        float32       `json:"maxSpeed"`
+}
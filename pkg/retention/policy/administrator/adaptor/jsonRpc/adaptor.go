@@ -0,0 +1,108 @@
+package jsonRpc
+
+import (
+	"github.com/iot-my-world/brain/internal/log"
+	jsonRpcServiceProvider "github.com/iot-my-world/brain/pkg/api/jsonRpc/service/provider"
+	"github.com/iot-my-world/brain/pkg/retention/policy"
+	"github.com/iot-my-world/brain/pkg/retention/policy/administrator"
+	wrappedIdentifier "github.com/iot-my-world/brain/pkg/search/identifier/wrapped"
+	wrappedClaims "github.com/iot-my-world/brain/pkg/security/claims/wrapped"
+	"net/http"
+)
+
+type adaptor struct {
+	administrator administrator.Administrator
+}
+
+func New(administrator administrator.Administrator) *adaptor {
+	return &adaptor{
+		administrator: administrator,
+	}
+}
+
+func (a *adaptor) Name() jsonRpcServiceProvider.Name {
+	return jsonRpcServiceProvider.Name(administrator.ServiceProvider)
+}
+
+func (a *adaptor) MethodRequiresAuthorization(string) bool {
+	return true
+}
+
+type CreateRequest struct {
+	Policy policy.Policy `json:"policy"`
+}
+
+type CreateResponse struct {
+	Policy policy.Policy `json:"policy"`
+}
+
+func (a *adaptor) Create(r *http.Request, request *CreateRequest, response *CreateResponse) error {
+	claims, err := wrappedClaims.UnwrapClaimsFromContext(r)
+	if err != nil {
+		log.Warn(err.Error())
+		return err
+	}
+
+	createResponse, err := a.administrator.Create(&administrator.CreateRequest{
+		Claims: claims,
+		Policy: request.Policy,
+	})
+	if err != nil {
+		return err
+	}
+
+	response.Policy = createResponse.Policy
+
+	return nil
+}
+
+type UpdateAllowedFieldsRequest struct {
+	Policy policy.Policy `json:"policy"`
+}
+
+type UpdateAllowedFieldsResponse struct {
+	Policy policy.Policy `json:"policy"`
+}
+
+func (a *adaptor) UpdateAllowedFields(r *http.Request, request *UpdateAllowedFieldsRequest, response *UpdateAllowedFieldsResponse) error {
+	claims, err := wrappedClaims.UnwrapClaimsFromContext(r)
+	if err != nil {
+		log.Warn(err.Error())
+		return err
+	}
+
+	updateAllowedFieldsResponse, err := a.administrator.UpdateAllowedFields(&administrator.UpdateAllowedFieldsRequest{
+		Claims: claims,
+		Policy: request.Policy,
+	})
+	if err != nil {
+		return err
+	}
+
+	response.Policy = updateAllowedFieldsResponse.Policy
+
+	return nil
+}
+
+type DeleteRequest struct {
+	WrappedIdentifier wrappedIdentifier.Wrapped `json:"identifier"`
+}
+
+type DeleteResponse struct{}
+
+func (a *adaptor) Delete(r *http.Request, request *DeleteRequest, response *DeleteResponse) error {
+	claims, err := wrappedClaims.UnwrapClaimsFromContext(r)
+	if err != nil {
+		log.Warn(err.Error())
+		return err
+	}
+
+	if _, err := a.administrator.Delete(&administrator.DeleteRequest{
+		Claims:     claims,
+		Identifier: request.WrappedIdentifier.Identifier,
+	}); err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,58 @@
+package administrator
+
+import (
+	"github.com/iot-my-world/brain/pkg/retention/policy"
+	"github.com/iot-my-world/brain/pkg/search/identifier"
+	"github.com/iot-my-world/brain/pkg/security/claims"
+	"github.com/iot-my-world/brain/pkg/security/permission/api"
+)
+
+type Administrator interface {
+	Create(request *CreateRequest) (*CreateResponse, error)
+	UpdateAllowedFields(request *UpdateAllowedFieldsRequest) (*UpdateAllowedFieldsResponse, error)
+	Delete(request *DeleteRequest) (*DeleteResponse, error)
+}
+
+const ServiceProvider = "RetentionPolicy-Administrator"
+const UpdateAllowedFieldsService = ServiceProvider + ".UpdateAllowedFields"
+const CreateService = ServiceProvider + ".Create"
+const DeleteService = ServiceProvider + ".Delete"
+
+var SystemUserPermissions = []api.Permission{
+	CreateService,
+	UpdateAllowedFieldsService,
+	DeleteService,
+}
+
+var CompanyAdminUserPermissions = make([]api.Permission, 0)
+
+var CompanyUserPermissions = make([]api.Permission, 0)
+
+var ClientAdminUserPermissions = make([]api.Permission, 0)
+
+var ClientUserPermissions = make([]api.Permission, 0)
+
+type CreateRequest struct {
+	Claims claims.Claims
+	Policy policy.Policy
+}
+
+type CreateResponse struct {
+	Policy policy.Policy
+}
+
+type UpdateAllowedFieldsRequest struct {
+	Claims claims.Claims
+	Policy policy.Policy
+}
+
+type UpdateAllowedFieldsResponse struct {
+	Policy policy.Policy
+}
+
+type DeleteRequest struct {
+	Claims     claims.Claims
+	Identifier identifier.Identifier
+}
+
+type DeleteResponse struct{}
@@ -0,0 +1,184 @@
+package basic
+
+import (
+	"fmt"
+	brainException "github.com/iot-my-world/brain/internal/exception"
+	"github.com/iot-my-world/brain/internal/log"
+	"github.com/iot-my-world/brain/pkg/retention/policy"
+	"github.com/iot-my-world/brain/pkg/retention/policy/action"
+	retentionPolicyAdministrator "github.com/iot-my-world/brain/pkg/retention/policy/administrator"
+	"github.com/iot-my-world/brain/pkg/retention/policy/administrator/exception"
+	"github.com/iot-my-world/brain/pkg/retention/policy/recordHandler"
+	"github.com/iot-my-world/brain/pkg/retention/policy/validator"
+	"github.com/iot-my-world/brain/pkg/search/identifier/id"
+)
+
+type administrator struct {
+	retentionPolicyValidator     validator.Validator
+	retentionPolicyRecordHandler recordHandler.RecordHandler
+}
+
+func New(
+	retentionPolicyValidator validator.Validator,
+	retentionPolicyRecordHandler recordHandler.RecordHandler,
+) retentionPolicyAdministrator.Administrator {
+	return &administrator{
+		retentionPolicyValidator:     retentionPolicyValidator,
+		retentionPolicyRecordHandler: retentionPolicyRecordHandler,
+	}
+}
+
+func (a *administrator) ValidateCreateRequest(request *retentionPolicyAdministrator.CreateRequest) error {
+	reasonsInvalid := make([]string, 0)
+
+	if request.Claims == nil {
+		reasonsInvalid = append(reasonsInvalid, "claims are nil")
+	} else {
+		retentionPolicyValidateResponse, err := a.retentionPolicyValidator.Validate(&validator.ValidateRequest{
+			Claims: request.Claims,
+			Policy: request.Policy,
+			Action: action.Create,
+		})
+		if err != nil {
+			reasonsInvalid = append(reasonsInvalid, "error validating retention policy: "+err.Error())
+		} else {
+			if len(retentionPolicyValidateResponse.ReasonsInvalid) > 0 {
+				for _, reason := range retentionPolicyValidateResponse.ReasonsInvalid {
+					reasonsInvalid = append(reasonsInvalid, fmt.Sprintf("retention policy invalid: %s - %s - %s", reason.Field, reason.Type, reason.Help))
+				}
+			}
+		}
+	}
+
+	if len(reasonsInvalid) > 0 {
+		return brainException.RequestInvalid{Reasons: reasonsInvalid}
+	}
+
+	return nil
+}
+
+func (a *administrator) Create(request *retentionPolicyAdministrator.CreateRequest) (*retentionPolicyAdministrator.CreateResponse, error) {
+	if err := a.ValidateCreateRequest(request); err != nil {
+		return nil, err
+	}
+
+	createResponse, err := a.retentionPolicyRecordHandler.Create(&recordHandler.CreateRequest{
+		Policy: request.Policy,
+	})
+	if err != nil {
+		err = exception.PolicyCreation{Reasons: []string{err.Error()}}
+		log.Error(err.Error())
+		return nil, err
+	}
+
+	return &retentionPolicyAdministrator.CreateResponse{
+		Policy: createResponse.Policy,
+	}, nil
+}
+
+func (a *administrator) ValidateUpdateAllowedFieldsRequest(request *retentionPolicyAdministrator.UpdateAllowedFieldsRequest) error {
+	reasonsInvalid := make([]string, 0)
+
+	if request.Claims == nil {
+		reasonsInvalid = append(reasonsInvalid, "claims are nil")
+	} else {
+		// retention policy must be valid
+		validationResponse, err := a.retentionPolicyValidator.Validate(&validator.ValidateRequest{
+			Claims: request.Claims,
+			Policy: request.Policy,
+			Action: action.UpdateAllowedFields,
+		})
+		if err != nil {
+			reasonsInvalid = append(reasonsInvalid, "error validating retention policy: "+err.Error())
+		} else {
+			if len(validationResponse.ReasonsInvalid) > 0 {
+				for _, reason := range validationResponse.ReasonsInvalid {
+					reasonsInvalid = append(reasonsInvalid, fmt.Sprintf("retention policy invalid: %s - %s - %s", reason.Field, reason.Type, reason.Help))
+				}
+			}
+		}
+	}
+
+	if len(reasonsInvalid) > 0 {
+		return brainException.RequestInvalid{Reasons: reasonsInvalid}
+	}
+	return nil
+}
+
+func (a *administrator) UpdateAllowedFields(request *retentionPolicyAdministrator.UpdateAllowedFieldsRequest) (*retentionPolicyAdministrator.UpdateAllowedFieldsResponse, error) {
+	if err := a.ValidateUpdateAllowedFieldsRequest(request); err != nil {
+		return nil, err
+	}
+
+	// retrieve the retention policy
+	retentionPolicyRetrieveResponse, err := a.retentionPolicyRecordHandler.Retrieve(&recordHandler.RetrieveRequest{
+		Claims:     request.Claims,
+		Identifier: id.Identifier{Id: request.Policy.Id},
+	})
+	if err != nil {
+		return nil, exception.PolicyRetrieval{Reasons: []string{err.Error()}}
+	}
+
+	// update the allowed fields on the retention policy. the collection
+	// and party of a policy cannot be changed.
+	retentionPolicyRetrieveResponse.Policy.Period = request.Policy.Period
+	retentionPolicyRetrieveResponse.Policy.Archive = request.Policy.Archive
+
+	// update the retention policy
+	_, err = a.retentionPolicyRecordHandler.Update(&recordHandler.UpdateRequest{
+		Claims:     request.Claims,
+		Identifier: id.Identifier{Id: request.Policy.Id},
+		Policy:     retentionPolicyRetrieveResponse.Policy,
+	})
+	if err != nil {
+		return nil, exception.PolicyUpdate{Reasons: []string{err.Error()}}
+	}
+
+	return &retentionPolicyAdministrator.UpdateAllowedFieldsResponse{
+		Policy: retentionPolicyRetrieveResponse.Policy,
+	}, nil
+}
+
+func (a *administrator) ValidateDeleteRequest(request *retentionPolicyAdministrator.DeleteRequest) error {
+	reasonsInvalid := make([]string, 0)
+
+	if request.Claims == nil {
+		reasonsInvalid = append(reasonsInvalid, "claims are nil")
+	}
+
+	if request.Identifier == nil {
+		reasonsInvalid = append(reasonsInvalid, "identifier is nil")
+	} else if !policy.IsValidIdentifier(request.Identifier) {
+		reasonsInvalid = append(reasonsInvalid, fmt.Sprintf("identifier of type %s not supported for retention policy", request.Identifier.Type()))
+	}
+
+	if len(reasonsInvalid) > 0 {
+		return brainException.RequestInvalid{Reasons: reasonsInvalid}
+	}
+	return nil
+}
+
+func (a *administrator) Delete(request *retentionPolicyAdministrator.DeleteRequest) (*retentionPolicyAdministrator.DeleteResponse, error) {
+	if err := a.ValidateDeleteRequest(request); err != nil {
+		return nil, err
+	}
+
+	// confirm that the retention policy exists
+	if _, err := a.retentionPolicyRecordHandler.Retrieve(&recordHandler.RetrieveRequest{
+		Claims:     request.Claims,
+		Identifier: request.Identifier,
+	}); err != nil {
+		return nil, exception.PolicyRetrieval{Reasons: []string{err.Error()}}
+	}
+
+	if _, err := a.retentionPolicyRecordHandler.Delete(&recordHandler.DeleteRequest{
+		Claims:     request.Claims,
+		Identifier: request.Identifier,
+	}); err != nil {
+		err = exception.PolicyDeletion{Reasons: []string{err.Error()}}
+		log.Error(err.Error())
+		return nil, err
+	}
+
+	return &retentionPolicyAdministrator.DeleteResponse{}, nil
+}
@@ -0,0 +1,37 @@
+package exception
+
+import (
+	"strings"
+)
+
+type PolicyCreation struct {
+	Reasons []string
+}
+
+func (e PolicyCreation) Error() string {
+	return "error creating retention policy: " + strings.Join(e.Reasons, "; ")
+}
+
+type PolicyRetrieval struct {
+	Reasons []string
+}
+
+func (e PolicyRetrieval) Error() string {
+	return "error retrieving retention policy: " + strings.Join(e.Reasons, "; ")
+}
+
+type PolicyUpdate struct {
+	Reasons []string
+}
+
+func (e PolicyUpdate) Error() string {
+	return "error updating retention policy: " + strings.Join(e.Reasons, "; ")
+}
+
+type PolicyDeletion struct {
+	Reasons []string
+}
+
+func (e PolicyDeletion) Error() string {
+	return "error deleting retention policy: " + strings.Join(e.Reasons, "; ")
+}
@@ -0,0 +1,122 @@
+package jsonRpc
+
+import (
+	brainException "github.com/iot-my-world/brain/internal/exception"
+	"github.com/iot-my-world/brain/internal/log"
+	jsonRpcClient "github.com/iot-my-world/brain/pkg/api/jsonRpc/client"
+	retentionPolicyAdministrator "github.com/iot-my-world/brain/pkg/retention/policy/administrator"
+	retentionPolicyAdministratorJsonRpcAdaptor "github.com/iot-my-world/brain/pkg/retention/policy/administrator/adaptor/jsonRpc"
+	wrappedIdentifier "github.com/iot-my-world/brain/pkg/search/identifier/wrapped"
+)
+
+type administrator struct {
+	jsonRpcClient jsonRpcClient.Client
+}
+
+func New(
+	jsonRpcClient jsonRpcClient.Client,
+) retentionPolicyAdministrator.Administrator {
+	return &administrator{
+		jsonRpcClient: jsonRpcClient,
+	}
+}
+
+func (a *administrator) ValidateCreateRequest(request *retentionPolicyAdministrator.CreateRequest) error {
+	reasonsInvalid := make([]string, 0)
+
+	if len(reasonsInvalid) > 0 {
+		return brainException.RequestInvalid{Reasons: reasonsInvalid}
+	}
+	return nil
+}
+
+func (a *administrator) Create(request *retentionPolicyAdministrator.CreateRequest) (*retentionPolicyAdministrator.CreateResponse, error) {
+	if err := a.ValidateCreateRequest(request); err != nil {
+		return nil, err
+	}
+
+	retentionPolicyCreateResponse := retentionPolicyAdministratorJsonRpcAdaptor.CreateResponse{}
+	if err := a.jsonRpcClient.JsonRpcRequest(
+		retentionPolicyAdministrator.CreateService,
+		retentionPolicyAdministratorJsonRpcAdaptor.CreateRequest{
+			Policy: request.Policy,
+		},
+		&retentionPolicyCreateResponse,
+	); err != nil {
+		log.Error(err.Error())
+		return nil, err
+	}
+
+	return &retentionPolicyAdministrator.CreateResponse{Policy: retentionPolicyCreateResponse.Policy}, nil
+}
+
+func (a *administrator) ValidateUpdateAllowedFieldsRequest(request *retentionPolicyAdministrator.UpdateAllowedFieldsRequest) error {
+	reasonsInvalid := make([]string, 0)
+
+	if len(reasonsInvalid) > 0 {
+		return brainException.RequestInvalid{Reasons: reasonsInvalid}
+	}
+	return nil
+}
+
+func (a *administrator) UpdateAllowedFields(request *retentionPolicyAdministrator.UpdateAllowedFieldsRequest) (*retentionPolicyAdministrator.UpdateAllowedFieldsResponse, error) {
+	if err := a.ValidateUpdateAllowedFieldsRequest(request); err != nil {
+		return nil, err
+	}
+
+	retentionPolicyUpdateAllowedFieldsResponse := retentionPolicyAdministratorJsonRpcAdaptor.UpdateAllowedFieldsResponse{}
+	if err := a.jsonRpcClient.JsonRpcRequest(
+		retentionPolicyAdministrator.UpdateAllowedFieldsService,
+		retentionPolicyAdministratorJsonRpcAdaptor.UpdateAllowedFieldsRequest{
+			Policy: request.Policy,
+		},
+		&retentionPolicyUpdateAllowedFieldsResponse,
+	); err != nil {
+		log.Error(err.Error())
+		return nil, err
+	}
+
+	return &retentionPolicyAdministrator.UpdateAllowedFieldsResponse{
+		Policy: retentionPolicyUpdateAllowedFieldsResponse.Policy,
+	}, nil
+}
+
+func (a *administrator) ValidateDeleteRequest(request *retentionPolicyAdministrator.DeleteRequest) error {
+	reasonsInvalid := make([]string, 0)
+
+	if request.Identifier == nil {
+		reasonsInvalid = append(reasonsInvalid, "identifier is nil")
+	}
+
+	if len(reasonsInvalid) > 0 {
+		return brainException.RequestInvalid{Reasons: reasonsInvalid}
+	}
+	return nil
+}
+
+func (a *administrator) Delete(request *retentionPolicyAdministrator.DeleteRequest) (*retentionPolicyAdministrator.DeleteResponse, error) {
+	if err := a.ValidateDeleteRequest(request); err != nil {
+		return nil, err
+	}
+
+	// wrap identifier
+	id, err := wrappedIdentifier.Wrap(request.Identifier)
+	if err != nil {
+		log.Error(err.Error())
+		return nil, err
+	}
+
+	retentionPolicyDeleteResponse := retentionPolicyAdministratorJsonRpcAdaptor.DeleteResponse{}
+	if err := a.jsonRpcClient.JsonRpcRequest(
+		retentionPolicyAdministrator.DeleteService,
+		retentionPolicyAdministratorJsonRpcAdaptor.DeleteRequest{
+			WrappedIdentifier: *id,
+		},
+		&retentionPolicyDeleteResponse,
+	); err != nil {
+		log.Error(err.Error())
+		return nil, err
+	}
+
+	return &retentionPolicyAdministrator.DeleteResponse{}, nil
+}
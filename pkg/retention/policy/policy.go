@@ -0,0 +1,40 @@
+package policy
+
+import (
+	databaseCollection "github.com/iot-my-world/brain/pkg/database/collection"
+	"github.com/iot-my-world/brain/pkg/party"
+	"github.com/iot-my-world/brain/pkg/search/identifier/id"
+)
+
+// Collection is a collection whose records are subject to retention policies
+type Collection string
+
+const SigfoxBackendDataCallbackMessage Collection = databaseCollection.SigfoxBackendDataCallbackMessage
+const SigbugGPSReading Collection = databaseCollection.SigbugGPSReading
+
+// Policy determines how long the records of a collection are kept for before
+// they are purged. A policy with a blank party is the default for the collection
+// and applies to the records of every party which does not have its own policy.
+type Policy struct {
+	Id         string     `json:"id" bson:"id"`
+	Collection Collection `json:"collection" bson:"collection"`
+
+	// Party Details, the party owning the records to which the policy applies
+	PartyType party.Type    `json:"partyType" bson:"partyType"`
+	PartyId   id.Identifier `json:"partyId" bson:"partyId"`
+
+	// Period is the number of seconds for which records are kept
+	Period int64 `json:"period" bson:"period"`
+
+	// Archive is set if records are to be archived before they are purged
+	Archive bool `json:"archive" bson:"archive"`
+}
+
+func (p *Policy) SetId(id string) {
+	p.Id = id
+}
+
+// Default returns true if the policy is the default for its collection
+func (p *Policy) Default() bool {
+	return p.PartyId.Id == ""
+}
@@ -0,0 +1,133 @@
+package retentionPolicyRecordHandler
+
+import (
+	brainException "github.com/iot-my-world/brain/internal/exception"
+	brainRecordHandler "github.com/iot-my-world/brain/pkg/recordHandler"
+	brainRecordHandlerException "github.com/iot-my-world/brain/pkg/recordHandler/exception"
+	"github.com/iot-my-world/brain/pkg/retention/policy"
+	retentionPolicyRecordHandler "github.com/iot-my-world/brain/pkg/retention/policy/recordHandler"
+	retentionPolicyRecordHandlerException "github.com/iot-my-world/brain/pkg/retention/policy/recordHandler/exception"
+)
+
+type RecordHandler struct {
+	retentionPolicyRecordHandler brainRecordHandler.RecordHandler
+}
+
+func New(
+	brainRetentionPolicyRecordHandler brainRecordHandler.RecordHandler,
+) retentionPolicyRecordHandler.RecordHandler {
+
+	return &RecordHandler{
+		retentionPolicyRecordHandler: brainRetentionPolicyRecordHandler,
+	}
+}
+
+type CreateRequest struct {
+	Policy policy.Policy
+}
+
+type CreateResponse struct {
+	Policy policy.Policy
+}
+
+func (r *RecordHandler) ValidateCreateRequest(request *retentionPolicyRecordHandler.CreateRequest) error {
+	reasonsInvalid := make([]string, 0)
+
+	if len(reasonsInvalid) > 0 {
+		return brainException.RequestInvalid{Reasons: reasonsInvalid}
+	}
+
+	return nil
+}
+
+func (r *RecordHandler) Create(request *retentionPolicyRecordHandler.CreateRequest) (*retentionPolicyRecordHandler.CreateResponse, error) {
+	if err := r.ValidateCreateRequest(request); err != nil {
+		return nil, err
+	}
+
+	createResponse := brainRecordHandler.CreateResponse{}
+	if err := r.retentionPolicyRecordHandler.Create(&brainRecordHandler.CreateRequest{
+		Entity: &request.Policy,
+	}, &createResponse); err != nil {
+		return nil, retentionPolicyRecordHandlerException.Create{Reasons: []string{err.Error()}}
+	}
+	createdPolicy, ok := createResponse.Entity.(*policy.Policy)
+	if !ok {
+		return nil, retentionPolicyRecordHandlerException.Create{Reasons: []string{"could not cast created entity to retention policy"}}
+	}
+
+	return &retentionPolicyRecordHandler.CreateResponse{
+		Policy: *createdPolicy,
+	}, nil
+}
+
+func (r *RecordHandler) Retrieve(request *retentionPolicyRecordHandler.RetrieveRequest) (*retentionPolicyRecordHandler.RetrieveResponse, error) {
+	retrievedPolicy := policy.Policy{}
+	retrieveResponse := brainRecordHandler.RetrieveResponse{
+		Entity: &retrievedPolicy,
+	}
+	if err := r.retentionPolicyRecordHandler.Retrieve(&brainRecordHandler.RetrieveRequest{
+		Claims:     request.Claims,
+		Identifier: request.Identifier,
+	}, &retrieveResponse); err != nil {
+		switch err.(type) {
+		case brainRecordHandlerException.NotFound:
+			return nil, retentionPolicyRecordHandlerException.NotFound{}
+		default:
+			return nil, err
+		}
+	}
+
+	return &retentionPolicyRecordHandler.RetrieveResponse{
+		Policy: retrievedPolicy,
+	}, nil
+}
+
+func (r *RecordHandler) Update(request *retentionPolicyRecordHandler.UpdateRequest) (*retentionPolicyRecordHandler.UpdateResponse, error) {
+	updateResponse := brainRecordHandler.UpdateResponse{}
+	if err := r.retentionPolicyRecordHandler.Update(&brainRecordHandler.UpdateRequest{
+		Claims:     request.Claims,
+		Identifier: request.Identifier,
+		Entity:     &request.Policy,
+	}, &updateResponse); err != nil {
+		return nil, retentionPolicyRecordHandlerException.Update{Reasons: []string{err.Error()}}
+	}
+
+	return &retentionPolicyRecordHandler.UpdateResponse{}, nil
+}
+
+func (r *RecordHandler) Delete(request *retentionPolicyRecordHandler.DeleteRequest) (*retentionPolicyRecordHandler.DeleteResponse, error) {
+	deleteResponse := brainRecordHandler.DeleteResponse{}
+	if err := r.retentionPolicyRecordHandler.Delete(&brainRecordHandler.DeleteRequest{
+		Claims:     request.Claims,
+		Identifier: request.Identifier,
+	}, &deleteResponse); err != nil {
+		return nil, retentionPolicyRecordHandlerException.Delete{Reasons: []string{err.Error()}}
+	}
+
+	return &retentionPolicyRecordHandler.DeleteResponse{}, nil
+}
+
+func (r *RecordHandler) Collect(request *retentionPolicyRecordHandler.CollectRequest) (*retentionPolicyRecordHandler.CollectResponse, error) {
+	var collectedPolicy []policy.Policy
+	collectResponse := brainRecordHandler.CollectResponse{
+		Records: &collectedPolicy,
+	}
+	err := r.retentionPolicyRecordHandler.Collect(&brainRecordHandler.CollectRequest{
+		Claims:   request.Claims,
+		Criteria: request.Criteria,
+		Query:    request.Query,
+	}, &collectResponse)
+	if err != nil {
+		return nil, retentionPolicyRecordHandlerException.Collect{Reasons: []string{err.Error()}}
+	}
+
+	if collectedPolicy == nil {
+		collectedPolicy = make([]policy.Policy, 0)
+	}
+
+	return &retentionPolicyRecordHandler.CollectResponse{
+		Records: collectedPolicy,
+		Total:   collectResponse.Total,
+	}, nil
+}
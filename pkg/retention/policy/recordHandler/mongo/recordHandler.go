@@ -0,0 +1,39 @@
+package mongo
+
+import (
+	brainMongoRecordHandler "github.com/iot-my-world/brain/pkg/recordHandler/mongo"
+	"github.com/iot-my-world/brain/pkg/retention/policy"
+	retentionPolicyRecordHandler "github.com/iot-my-world/brain/pkg/retention/policy/recordHandler"
+	retentionPolicyGenericRecordHandler "github.com/iot-my-world/brain/pkg/retention/policy/recordHandler/generic"
+	"github.com/iot-my-world/brain/pkg/security/claims"
+	"gopkg.in/mgo.v2"
+)
+
+func New(
+	mongoSession *mgo.Session,
+	databaseName string,
+	collectionName string,
+) retentionPolicyRecordHandler.RecordHandler {
+	mongoRecordHandler := brainMongoRecordHandler.New(
+		mongoSession,
+		databaseName,
+		collectionName,
+		[]mgo.Index{
+			{
+				Key:    []string{"id"},
+				Unique: true,
+			},
+			{
+				// a party has at most one policy for each collection
+				Key:    []string{"collection", "partyId.id"},
+				Unique: true,
+			},
+		},
+		policy.IsValidIdentifier,
+		claims.ContextualiseFilter,
+	)
+
+	return retentionPolicyGenericRecordHandler.New(
+		mongoRecordHandler,
+	)
+}
@@ -0,0 +1,219 @@
+package basic
+
+import (
+	brainException "github.com/iot-my-world/brain/internal/exception"
+	"github.com/iot-my-world/brain/internal/log"
+	"github.com/iot-my-world/brain/pkg/action"
+	"github.com/iot-my-world/brain/pkg/party"
+	partyAdministrator "github.com/iot-my-world/brain/pkg/party/administrator"
+	partyAdministratorException "github.com/iot-my-world/brain/pkg/party/administrator/exception"
+	"github.com/iot-my-world/brain/pkg/retention/policy"
+	retentionPolicyAction "github.com/iot-my-world/brain/pkg/retention/policy/action"
+	retentionPolicyRecordHandler "github.com/iot-my-world/brain/pkg/retention/policy/recordHandler"
+	retentionPolicyValidator "github.com/iot-my-world/brain/pkg/retention/policy/validator"
+	retentionPolicyValidatorException "github.com/iot-my-world/brain/pkg/retention/policy/validator/exception"
+	"github.com/iot-my-world/brain/pkg/search/criterion"
+	exactTextCriterion "github.com/iot-my-world/brain/pkg/search/criterion/exact/text"
+	"github.com/iot-my-world/brain/pkg/validate/reasonInvalid"
+)
+
+type validator struct {
+	partyAdministrator           partyAdministrator.Administrator
+	retentionPolicyRecordHandler retentionPolicyRecordHandler.RecordHandler
+	actionIgnoredReasons         map[action.Action]reasonInvalid.IgnoredReasonsInvalid
+	archiveDirectory             string
+}
+
+// New returns a validator which finds policies that require archiving
+// to be invalid if the given archive directory is blank, since the
+// purger would not be able to enforce them
+func New(
+	partyAdministrator partyAdministrator.Administrator,
+	retentionPolicyRecordHandler retentionPolicyRecordHandler.RecordHandler,
+	archiveDirectory string,
+) retentionPolicyValidator.Validator {
+
+	actionIgnoredReasons := map[action.Action]reasonInvalid.IgnoredReasonsInvalid{
+		retentionPolicyAction.Create: {
+			ReasonsInvalid: map[string][]reasonInvalid.Type{
+				"id": {
+					reasonInvalid.Blank,
+				},
+			},
+		},
+		retentionPolicyAction.UpdateAllowedFields: {
+			ReasonsInvalid: map[string][]reasonInvalid.Type{},
+		},
+	}
+
+	return &validator{
+		partyAdministrator:           partyAdministrator,
+		retentionPolicyRecordHandler: retentionPolicyRecordHandler,
+		actionIgnoredReasons:         actionIgnoredReasons,
+		archiveDirectory:             archiveDirectory,
+	}
+}
+
+func (v *validator) ValidateValidateRequest(request *retentionPolicyValidator.ValidateRequest) error {
+	reasonsInvalid := make([]string, 0)
+
+	if request.Claims == nil {
+		reasonsInvalid = append(reasonsInvalid, "claims are nil")
+	}
+
+	if len(reasonsInvalid) > 0 {
+		return brainException.RequestInvalid{Reasons: reasonsInvalid}
+	}
+	return nil
+}
+
+func (v *validator) Validate(request *retentionPolicyValidator.ValidateRequest) (*retentionPolicyValidator.ValidateResponse, error) {
+	if err := v.ValidateValidateRequest(request); err != nil {
+		return nil, err
+	}
+
+	allReasonsInvalid := make([]reasonInvalid.ReasonInvalid, 0)
+	policyToValidate := &request.Policy
+
+	if (*policyToValidate).Id == "" {
+		allReasonsInvalid = append(allReasonsInvalid, reasonInvalid.ReasonInvalid{
+			Field: "id",
+			Type:  reasonInvalid.Blank,
+			Help:  "cannot be blank",
+			Data:  (*policyToValidate).Id,
+		})
+	}
+
+	switch (*policyToValidate).Collection {
+	case policy.SigfoxBackendDataCallbackMessage, policy.SigbugGPSReading:
+		// a party may only have one policy for each collection
+		policyCollectResponse, err := v.retentionPolicyRecordHandler.Collect(&retentionPolicyRecordHandler.CollectRequest{
+			Claims: request.Claims,
+			Criteria: []criterion.Criterion{
+				exactTextCriterion.Criterion{
+					Field: "collection",
+					Text:  string((*policyToValidate).Collection),
+				},
+			},
+		})
+		if err != nil {
+			err = retentionPolicyValidatorException.Validate{Reasons: []string{"collecting policies", err.Error()}}
+			log.Error(err.Error())
+			return nil, err
+		}
+		for _, existingPolicy := range policyCollectResponse.Records {
+			if existingPolicy.PartyId.Id == (*policyToValidate).PartyId.Id &&
+				existingPolicy.Id != (*policyToValidate).Id {
+				allReasonsInvalid = append(allReasonsInvalid, reasonInvalid.ReasonInvalid{
+					Field: "collection",
+					Type:  reasonInvalid.Duplicate,
+					Help:  "party already has a policy for collection",
+					Data:  (*policyToValidate).Collection,
+				})
+				break
+			}
+		}
+
+	case "":
+		allReasonsInvalid = append(allReasonsInvalid, reasonInvalid.ReasonInvalid{
+			Field: "collection",
+			Type:  reasonInvalid.Blank,
+			Help:  "cannot be blank",
+			Data:  (*policyToValidate).Collection,
+		})
+
+	default:
+		allReasonsInvalid = append(allReasonsInvalid, reasonInvalid.ReasonInvalid{
+			Field: "collection",
+			Type:  reasonInvalid.Invalid,
+			Help:  "retention not supported for collection",
+			Data:  (*policyToValidate).Collection,
+		})
+	}
+
+	if (*policyToValidate).Period <= 0 {
+		allReasonsInvalid = append(allReasonsInvalid, reasonInvalid.ReasonInvalid{
+			Field: "period",
+			Type:  reasonInvalid.Invalid,
+			Help:  "must be greater than 0",
+			Data:  (*policyToValidate).Period,
+		})
+	}
+
+	if (*policyToValidate).Archive && v.archiveDirectory == "" {
+		allReasonsInvalid = append(allReasonsInvalid, reasonInvalid.ReasonInvalid{
+			Field: "archive",
+			Type:  reasonInvalid.Invalid,
+			Help:  "no archive directory is configured",
+			Data:  (*policyToValidate).Archive,
+		})
+	}
+
+	// the party of a policy is blank for the default policy of a collection
+	if (*policyToValidate).PartyId.Id == "" {
+		if (*policyToValidate).PartyType != "" {
+			allReasonsInvalid = append(allReasonsInvalid, reasonInvalid.ReasonInvalid{
+				Field: "partyType",
+				Type:  reasonInvalid.MustNotBeSet,
+				Help:  "must be blank if party id is blank",
+				Data:  (*policyToValidate).PartyType,
+			})
+		}
+	} else {
+		switch (*policyToValidate).PartyType {
+		case party.Company, party.Client:
+			_, err := v.partyAdministrator.RetrieveParty(&partyAdministrator.RetrievePartyRequest{
+				Claims:     request.Claims,
+				PartyType:  (*policyToValidate).PartyType,
+				Identifier: (*policyToValidate).PartyId,
+			})
+			if err != nil {
+				switch err.(type) {
+				case partyAdministratorException.NotFound:
+					allReasonsInvalid = append(allReasonsInvalid, reasonInvalid.ReasonInvalid{
+						Field: "partyId",
+						Type:  reasonInvalid.MustExist,
+						Help:  "party must exist",
+						Data:  (*policyToValidate).PartyId,
+					})
+				default:
+					err = retentionPolicyValidatorException.Validate{Reasons: []string{"retrieving party", err.Error()}}
+					log.Error(err.Error())
+					return nil, err
+				}
+			}
+
+		case "":
+			allReasonsInvalid = append(allReasonsInvalid, reasonInvalid.ReasonInvalid{
+				Field: "partyType",
+				Type:  reasonInvalid.Blank,
+				Help:  "cannot be blank if party id is set",
+				Data:  (*policyToValidate).PartyType,
+			})
+
+		default:
+			allReasonsInvalid = append(allReasonsInvalid, reasonInvalid.ReasonInvalid{
+				Field: "partyType",
+				Type:  reasonInvalid.Invalid,
+				Help:  "must be a company or client",
+				Data:  (*policyToValidate).PartyType,
+			})
+		}
+	}
+
+	// Make list of reasons invalid to return
+	returnedReasonsInvalid := make([]reasonInvalid.ReasonInvalid, 0)
+
+	// Add all reasons that cannot be ignored for the given action
+	if v.actionIgnoredReasons[request.Action].ReasonsInvalid != nil {
+		for _, reason := range allReasonsInvalid {
+			if !v.actionIgnoredReasons[request.Action].CanIgnore(reason) {
+				returnedReasonsInvalid = append(returnedReasonsInvalid, reason)
+			}
+		}
+	}
+
+	return &retentionPolicyValidator.ValidateResponse{
+		ReasonsInvalid: returnedReasonsInvalid,
+	}, nil
+}
@@ -0,0 +1,49 @@
+package basic
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"os"
+)
+
+// archive writes records to a gzipped file with one json record per line
+type archive struct {
+	file       *os.File
+	gzipWriter *gzip.Writer
+	encoder    *json.Encoder
+}
+
+func newArchive(path string) (*archive, error) {
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0640)
+	if err != nil {
+		return nil, err
+	}
+	gzipWriter := gzip.NewWriter(file)
+	return &archive{
+		file:       file,
+		gzipWriter: gzipWriter,
+		encoder:    json.NewEncoder(gzipWriter),
+	}, nil
+}
+
+// write writes the given records to the archive and flushes them
+// to the file so that they are archived before being deleted
+func (a *archive) write(records []record) error {
+	for _, recordToWrite := range records {
+		if err := a.encoder.Encode(recordToWrite.entity); err != nil {
+			return err
+		}
+	}
+	if err := a.gzipWriter.Flush(); err != nil {
+		return err
+	}
+	return a.file.Sync()
+}
+
+func (a *archive) close() error {
+	if err := a.gzipWriter.Close(); err != nil {
+		_ = a.file.Close()
+		return err
+	}
+	return a.file.Close()
+}
@@ -0,0 +1,261 @@
+package basic
+
+import (
+	"fmt"
+	brainException "github.com/iot-my-world/brain/internal/exception"
+	"github.com/iot-my-world/brain/internal/log"
+	sigbugGPSReadingRecordHandler "github.com/iot-my-world/brain/pkg/device/sigbug/reading/gps/recordHandler"
+	sigbugRecordHandler "github.com/iot-my-world/brain/pkg/device/sigbug/recordHandler"
+	"github.com/iot-my-world/brain/pkg/retention/policy"
+	retentionPolicyRecordHandler "github.com/iot-my-world/brain/pkg/retention/policy/recordHandler"
+	retentionPurger "github.com/iot-my-world/brain/pkg/retention/purger"
+	retentionPurgerException "github.com/iot-my-world/brain/pkg/retention/purger/exception"
+	"github.com/iot-my-world/brain/pkg/search/criterion"
+	"github.com/iot-my-world/brain/pkg/search/query"
+	"github.com/iot-my-world/brain/pkg/security/claims"
+	sigfoxBackendDataCallbackMessageRecordHandler "github.com/iot-my-world/brain/pkg/sigfox/backend/callback/data/message/recordHandler"
+	"path/filepath"
+	"time"
+)
+
+// pageSize is the number of records collected at a time so
+// that large purges are not loaded into memory all at once
+const pageSize = 1000
+
+type purger struct {
+	retentionPolicyRecordHandler                  retentionPolicyRecordHandler.RecordHandler
+	sigbugRecordHandler                           sigbugRecordHandler.RecordHandler
+	sigfoxBackendDataCallbackMessageRecordHandler sigfoxBackendDataCallbackMessageRecordHandler.RecordHandler
+	sigbugGPSReadingRecordHandler                 sigbugGPSReadingRecordHandler.RecordHandler
+	archiveDirectory                              string
+}
+
+// New returns a purger which archives records to files in the given
+// directory. Policies which require archiving are not enforced if
+// no directory is given.
+func New(
+	retentionPolicyRecordHandler retentionPolicyRecordHandler.RecordHandler,
+	sigbugRecordHandler sigbugRecordHandler.RecordHandler,
+	sigfoxBackendDataCallbackMessageRecordHandler sigfoxBackendDataCallbackMessageRecordHandler.RecordHandler,
+	sigbugGPSReadingRecordHandler sigbugGPSReadingRecordHandler.RecordHandler,
+	archiveDirectory string,
+) retentionPurger.Purger {
+	return &purger{
+		retentionPolicyRecordHandler:                  retentionPolicyRecordHandler,
+		sigbugRecordHandler:                           sigbugRecordHandler,
+		sigfoxBackendDataCallbackMessageRecordHandler: sigfoxBackendDataCallbackMessageRecordHandler,
+		sigbugGPSReadingRecordHandler:                 sigbugGPSReadingRecordHandler,
+		archiveDirectory:                              archiveDirectory,
+	}
+}
+
+func (p *purger) ValidatePurgeRequest(request *retentionPurger.PurgeRequest) error {
+	reasonsInvalid := make([]string, 0)
+
+	if request.Claims == nil {
+		reasonsInvalid = append(reasonsInvalid, "claims are nil")
+	}
+
+	if len(reasonsInvalid) > 0 {
+		return brainException.RequestInvalid{Reasons: reasonsInvalid}
+	}
+	return nil
+}
+
+func (p *purger) Purge(request *retentionPurger.PurgeRequest) (*retentionPurger.PurgeResponse, error) {
+	if err := p.ValidatePurgeRequest(request); err != nil {
+		log.Error(err.Error())
+		return nil, err
+	}
+
+	collectPoliciesResponse, err := p.retentionPolicyRecordHandler.Collect(&retentionPolicyRecordHandler.CollectRequest{
+		Claims:   request.Claims,
+		Criteria: make([]criterion.Criterion, 0),
+	})
+	if err != nil {
+		err = retentionPurgerException.CollectingPolicies{Reasons: []string{err.Error()}}
+		log.Error(err.Error())
+		return nil, err
+	}
+
+	sources, err := p.recordSources(request.Claims)
+	if err != nil {
+		log.Error(err.Error())
+		return nil, err
+	}
+
+	// parties with their own policy for a collection are
+	// not subject to the default policy for that collection
+	partiesWithPolicy := make(map[policy.Collection]map[string]bool)
+	for _, policyToEnforce := range collectPoliciesResponse.Records {
+		if policyToEnforce.Default() {
+			continue
+		}
+		if partiesWithPolicy[policyToEnforce.Collection] == nil {
+			partiesWithPolicy[policyToEnforce.Collection] = make(map[string]bool)
+		}
+		partiesWithPolicy[policyToEnforce.Collection][policyToEnforce.PartyId.Id] = true
+	}
+
+	now := time.Now().UTC().Unix()
+	results := make([]retentionPurger.Result, 0)
+	for _, policyToEnforce := range collectPoliciesResponse.Records {
+		source, found := sources[policyToEnforce.Collection]
+		if !found {
+			log.Warn(fmt.Sprintf("no records to purge for retention policy %s on collection '%s'", policyToEnforce.Id, policyToEnforce.Collection))
+			continue
+		}
+		if policyToEnforce.Archive && p.archiveDirectory == "" {
+			log.Warn(fmt.Sprintf("retention policy %s requires archiving but no archive directory is configured", policyToEnforce.Id))
+			continue
+		}
+
+		// failure to enforce one policy does not stop the others from being enforced
+		result, err := p.enforce(
+			request.Claims,
+			policyToEnforce,
+			source,
+			partiesWithPolicy[policyToEnforce.Collection],
+			now,
+		)
+		if err != nil {
+			log.Error(fmt.Sprintf("error enforcing retention policy %s: %s", policyToEnforce.Id, err.Error()))
+		}
+		if result.NoPurged > 0 {
+			log.Info(fmt.Sprintf("purged %d records of collection '%s' under retention policy %s", result.NoPurged, policyToEnforce.Collection, policyToEnforce.Id))
+		}
+		results = append(results, result)
+	}
+
+	return &retentionPurger.PurgeResponse{
+		Results: results,
+	}, nil
+}
+
+// recordSources returns the source of the records of each collection
+// which is subject to retention policies
+func (p *purger) recordSources(claims claims.Claims) (map[policy.Collection]recordSource, error) {
+	collectSigbugsResponse, err := p.sigbugRecordHandler.Collect(&sigbugRecordHandler.CollectRequest{
+		Claims:   claims,
+		Criteria: make([]criterion.Criterion, 0),
+	})
+	if err != nil {
+		return nil, retentionPurgerException.CollectingDevices{Reasons: []string{err.Error()}}
+	}
+	deviceOwners := make(map[string]string)
+	partyDevices := make(map[string][]string)
+	for _, device := range collectSigbugsResponse.Records {
+		deviceOwners[device.DeviceId] = device.OwnerId.Id
+		partyDevices[device.OwnerId.Id] = append(partyDevices[device.OwnerId.Id], device.DeviceId)
+	}
+
+	return map[policy.Collection]recordSource{
+		policy.SigfoxBackendDataCallbackMessage: &sigfoxBackendDataCallbackMessageSource{
+			sigfoxBackendDataCallbackMessageRecordHandler: p.sigfoxBackendDataCallbackMessageRecordHandler,
+			deviceOwners: deviceOwners,
+			partyDevices: partyDevices,
+		},
+		policy.SigbugGPSReading: &sigbugGPSReadingSource{
+			sigbugGPSReadingRecordHandler: p.sigbugGPSReadingRecordHandler,
+		},
+	}, nil
+}
+
+// enforce purges the records from the given source which have outlived the given policy
+func (p *purger) enforce(
+	claims claims.Claims,
+	policyToEnforce policy.Policy,
+	source recordSource,
+	partiesWithPolicy map[string]bool,
+	now int64,
+) (retentionPurger.Result, error) {
+	result := retentionPurger.Result{
+		Policy: policyToEnforce,
+	}
+	before := now - policyToEnforce.Period
+
+	var recordArchive *archive
+	defer func() {
+		if recordArchive != nil {
+			if err := recordArchive.close(); err != nil {
+				log.Error(retentionPurgerException.Archive{Reasons: []string{"closing", result.ArchiveFile, err.Error()}}.Error())
+			}
+		}
+	}()
+
+	// records which are skipped are not deleted, and so
+	// each page begins after the records skipped so far
+	noSkipped := 0
+	for {
+		records, err := source.collect(
+			claims,
+			before,
+			policyToEnforce.PartyId.Id,
+			query.Query{
+				Limit:  pageSize,
+				Offset: noSkipped,
+				SortBy: []string{"timeStamp"},
+				Order:  []query.SortOrder{query.SortOrderAscending},
+			},
+		)
+		if err != nil {
+			return result, retentionPurgerException.CollectingRecords{Reasons: []string{err.Error()}}
+		}
+
+		recordsToPurge := make([]record, 0)
+		for _, recordToPurge := range records {
+			if policyToEnforce.Default() && partiesWithPolicy[recordToPurge.ownerId] {
+				noSkipped++
+				continue
+			}
+			recordsToPurge = append(recordsToPurge, recordToPurge)
+		}
+
+		// records are archived before they are deleted so that
+		// none are lost should the purge fail part way through
+		if policyToEnforce.Archive && len(recordsToPurge) > 0 {
+			if recordArchive == nil {
+				result.ArchiveFile = filepath.Join(
+					p.archiveDirectory,
+					archiveFileName(policyToEnforce, now),
+				)
+				recordArchive, err = newArchive(result.ArchiveFile)
+				if err != nil {
+					recordArchive = nil
+					return result, retentionPurgerException.Archive{Reasons: []string{"creating", result.ArchiveFile, err.Error()}}
+				}
+			}
+			if err := recordArchive.write(recordsToPurge); err != nil {
+				return result, retentionPurgerException.Archive{Reasons: []string{"writing", result.ArchiveFile, err.Error()}}
+			}
+		}
+
+		if len(recordsToPurge) > 0 {
+			recordIds := make([]string, len(recordsToPurge))
+			for recordIdx := range recordsToPurge {
+				recordIds[recordIdx] = recordsToPurge[recordIdx].id
+			}
+			noDeleted, err := source.delete(claims, recordIds)
+			if err != nil {
+				return result, retentionPurgerException.DeletingRecords{Reasons: []string{err.Error()}}
+			}
+			result.NoPurged += noDeleted
+		}
+
+		if len(records) < pageSize {
+			break
+		}
+	}
+
+	return result, nil
+}
+
+// archiveFileName returns the name of the file to which the records
+// purged under the given policy at the given time are archived
+func archiveFileName(policyToArchive policy.Policy, now int64) string {
+	partyId := policyToArchive.PartyId.Id
+	if policyToArchive.Default() {
+		partyId = "default"
+	}
+	return fmt.Sprintf("%s-%s-%d.ndjson.gz", policyToArchive.Collection, partyId, now)
+}
@@ -0,0 +1,157 @@
+package basic
+
+import (
+	"bufio"
+	"compress/gzip"
+	"encoding/json"
+	"github.com/iot-my-world/brain/pkg/retention/policy"
+	"github.com/iot-my-world/brain/pkg/search/identifier/id"
+	"github.com/iot-my-world/brain/pkg/search/query"
+	"github.com/iot-my-world/brain/pkg/security/claims"
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"os"
+	"strconv"
+	"testing"
+)
+
+// fakeRecord is a record kept by a fakeSource
+type fakeRecord struct {
+	Id        string `json:"id"`
+	TimeStamp int64  `json:"timeStamp"`
+	OwnerId   string `json:"ownerId"`
+}
+
+// fakeSource keeps records in memory, sorted by time stamp
+type fakeSource struct {
+	records   []fakeRecord
+	noDeletes int
+}
+
+func (s *fakeSource) collect(claims claims.Claims, before int64, ownerId string, collectQuery query.Query) ([]record, error) {
+	matching := make([]record, 0)
+	for _, r := range s.records {
+		if r.TimeStamp < before && (ownerId == "" || r.OwnerId == ownerId) {
+			matching = append(matching, record{id: r.Id, ownerId: r.OwnerId, entity: r})
+		}
+	}
+	if collectQuery.Offset >= len(matching) {
+		return make([]record, 0), nil
+	}
+	matching = matching[collectQuery.Offset:]
+	if len(matching) > collectQuery.Limit {
+		matching = matching[:collectQuery.Limit]
+	}
+	return matching, nil
+}
+
+func (s *fakeSource) delete(claims claims.Claims, recordIds []string) (int, error) {
+	s.noDeletes++
+	toDelete := make(map[string]bool)
+	for _, recordId := range recordIds {
+		toDelete[recordId] = true
+	}
+	remaining := make([]fakeRecord, 0)
+	for _, r := range s.records {
+		if !toDelete[r.Id] {
+			remaining = append(remaining, r)
+		}
+	}
+	noDeleted := len(s.records) - len(remaining)
+	s.records = remaining
+	return noDeleted, nil
+}
+
+func newFakeSource(noRecords int, ownerIds ...string) *fakeSource {
+	source := &fakeSource{}
+	for recordIdx := 0; recordIdx < noRecords; recordIdx++ {
+		source.records = append(source.records, fakeRecord{
+			Id:        strconv.Itoa(recordIdx),
+			TimeStamp: int64(recordIdx),
+			OwnerId:   ownerIds[recordIdx%len(ownerIds)],
+		})
+	}
+	return source
+}
+
+func TestEnforce(t *testing.T) {
+	p := &purger{}
+	now := int64(pageSize * 3)
+
+	// the default policy purges old records of parties without their own policy
+	source := newFakeSource(pageSize*3, "a", "b")
+	result, err := p.enforce(
+		nil,
+		policy.Policy{Collection: policy.SigbugGPSReading, Period: pageSize},
+		source,
+		map[string]bool{"b": true},
+		now,
+	)
+	assert.NoError(t, err)
+	assert.Equal(t, pageSize, result.NoPurged)
+	assert.Equal(t, "", result.ArchiveFile)
+	// the records of each page are deleted at once
+	assert.Equal(t, 2, source.noDeletes)
+	for _, r := range source.records {
+		assert.True(t, r.OwnerId == "b" || r.TimeStamp >= pageSize*2, "record %s not purged", r.Id)
+	}
+
+	// a party's policy purges only the records of that party
+	result, err = p.enforce(
+		nil,
+		policy.Policy{Collection: policy.SigbugGPSReading, PartyId: id.Identifier{Id: "b"}, Period: 10},
+		source,
+		nil,
+		now,
+	)
+	assert.NoError(t, err)
+	assert.Equal(t, pageSize*3/2-5, result.NoPurged)
+	for _, r := range source.records {
+		assert.True(t, r.OwnerId == "a" || r.TimeStamp >= now-10, "record %s not purged", r.Id)
+	}
+}
+
+func TestEnforceArchive(t *testing.T) {
+	archiveDirectory, err := ioutil.TempDir("", "retention")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(archiveDirectory)
+
+	p := &purger{archiveDirectory: archiveDirectory}
+	source := newFakeSource(10, "a")
+	result, err := p.enforce(
+		nil,
+		policy.Policy{Collection: policy.SigbugGPSReading, Period: 5, Archive: true},
+		source,
+		nil,
+		10,
+	)
+	assert.NoError(t, err)
+	assert.Equal(t, 5, result.NoPurged)
+	assert.Len(t, source.records, 5)
+
+	// every purged record is archived on its own line
+	file, err := os.Open(result.ArchiveFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+	gzipReader, err := gzip.NewReader(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	archived := make([]fakeRecord, 0)
+	scanner := bufio.NewScanner(gzipReader)
+	for scanner.Scan() {
+		var r fakeRecord
+		assert.NoError(t, json.Unmarshal(scanner.Bytes(), &r))
+		archived = append(archived, r)
+	}
+	assert.NoError(t, scanner.Err())
+	if assert.Len(t, archived, 5) {
+		for recordIdx, r := range archived {
+			assert.Equal(t, int64(recordIdx), r.TimeStamp)
+		}
+	}
+}
@@ -0,0 +1,147 @@
+package basic
+
+import (
+	sigbugGPSReadingRecordHandler "github.com/iot-my-world/brain/pkg/device/sigbug/reading/gps/recordHandler"
+	"github.com/iot-my-world/brain/pkg/search/criterion"
+	exactTextCriterion "github.com/iot-my-world/brain/pkg/search/criterion/exact/text"
+	textListCriterion "github.com/iot-my-world/brain/pkg/search/criterion/list/text"
+	dateRangeCriterion "github.com/iot-my-world/brain/pkg/search/criterion/range/date"
+	"github.com/iot-my-world/brain/pkg/search/query"
+	"github.com/iot-my-world/brain/pkg/security/claims"
+	sigfoxBackendDataCallbackMessageRecordHandler "github.com/iot-my-world/brain/pkg/sigfox/backend/callback/data/message/recordHandler"
+)
+
+// record is a record which may be purged
+type record struct {
+	id      string
+	ownerId string
+	entity  interface{}
+}
+
+// recordSource collects and deletes the records of a collection
+type recordSource interface {
+	// collect collects the records from before the given time, optionally
+	// only those owned by the party with the given id
+	collect(claims claims.Claims, before int64, ownerId string, collectQuery query.Query) ([]record, error)
+	// delete deletes the records with the given ids at once,
+	// returning the number deleted
+	delete(claims claims.Claims, recordIds []string) (int, error)
+}
+
+// idsCriterion is a criterion for the records with the given ids
+func idsCriterion(recordIds []string) criterion.Criterion {
+	return textListCriterion.Criterion{
+		Field: "id",
+		List:  recordIds,
+	}
+}
+
+// beforeCriterion is a criterion for records from before the given time
+func beforeCriterion(before int64) criterion.Criterion {
+	return dateRangeCriterion.Criterion{
+		Field: "timeStamp",
+		StartDate: dateRangeCriterion.RangeValue{
+			Ignore: true,
+		},
+		EndDate: dateRangeCriterion.RangeValue{
+			Date: before,
+		},
+	}
+}
+
+type sigbugGPSReadingSource struct {
+	sigbugGPSReadingRecordHandler sigbugGPSReadingRecordHandler.RecordHandler
+}
+
+func (s *sigbugGPSReadingSource) collect(claims claims.Claims, before int64, ownerId string, collectQuery query.Query) ([]record, error) {
+	collectCriteria := []criterion.Criterion{beforeCriterion(before)}
+	if ownerId != "" {
+		collectCriteria = append(collectCriteria, exactTextCriterion.Criterion{
+			Field: "ownerId.id",
+			Text:  ownerId,
+		})
+	}
+
+	collectResponse, err := s.sigbugGPSReadingRecordHandler.Collect(&sigbugGPSReadingRecordHandler.CollectRequest{
+		Claims:   claims,
+		Criteria: collectCriteria,
+		Query:    collectQuery,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	records := make([]record, len(collectResponse.Records))
+	for readingIdx, reading := range collectResponse.Records {
+		records[readingIdx] = record{
+			id:      reading.Id,
+			ownerId: reading.OwnerId.Id,
+			entity:  reading,
+		}
+	}
+	return records, nil
+}
+
+func (s *sigbugGPSReadingSource) delete(claims claims.Claims, recordIds []string) (int, error) {
+	deleteAllResponse, err := s.sigbugGPSReadingRecordHandler.DeleteAll(&sigbugGPSReadingRecordHandler.DeleteAllRequest{
+		Claims:   claims,
+		Criteria: []criterion.Criterion{idsCriterion(recordIds)},
+	})
+	if err != nil {
+		return 0, err
+	}
+	return deleteAllResponse.NoDeleted, nil
+}
+
+// sigfoxBackendDataCallbackMessageSource is a source of raw sigfox messages. Messages
+// do not record an owner, and so are taken to be owned by the owner of their device.
+type sigfoxBackendDataCallbackMessageSource struct {
+	sigfoxBackendDataCallbackMessageRecordHandler sigfoxBackendDataCallbackMessageRecordHandler.RecordHandler
+	// deviceOwners are the ids of the owners of devices by device id,
+	// and partyDevices the ids of the devices of each party
+	deviceOwners map[string]string
+	partyDevices map[string][]string
+}
+
+func (s *sigfoxBackendDataCallbackMessageSource) collect(claims claims.Claims, before int64, ownerId string, collectQuery query.Query) ([]record, error) {
+	collectCriteria := []criterion.Criterion{beforeCriterion(before)}
+	if ownerId != "" {
+		if len(s.partyDevices[ownerId]) == 0 {
+			return make([]record, 0), nil
+		}
+		collectCriteria = append(collectCriteria, textListCriterion.Criterion{
+			Field: "deviceId",
+			List:  s.partyDevices[ownerId],
+		})
+	}
+
+	collectResponse, err := s.sigfoxBackendDataCallbackMessageRecordHandler.Collect(&sigfoxBackendDataCallbackMessageRecordHandler.CollectRequest{
+		Claims:   claims,
+		Criteria: collectCriteria,
+		Query:    collectQuery,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	records := make([]record, len(collectResponse.Records))
+	for messageIdx, message := range collectResponse.Records {
+		records[messageIdx] = record{
+			id:      message.Id,
+			ownerId: s.deviceOwners[message.DeviceId],
+			entity:  message,
+		}
+	}
+	return records, nil
+}
+
+func (s *sigfoxBackendDataCallbackMessageSource) delete(claims claims.Claims, recordIds []string) (int, error) {
+	deleteAllResponse, err := s.sigfoxBackendDataCallbackMessageRecordHandler.DeleteAll(&sigfoxBackendDataCallbackMessageRecordHandler.DeleteAllRequest{
+		Claims:   claims,
+		Criteria: []criterion.Criterion{idsCriterion(recordIds)},
+	})
+	if err != nil {
+		return 0, err
+	}
+	return deleteAllResponse.NoDeleted, nil
+}
@@ -0,0 +1,46 @@
+package exception
+
+import (
+	"fmt"
+	"strings"
+)
+
+type CollectingPolicies struct {
+	Reasons []string
+}
+
+func (e CollectingPolicies) Error() string {
+	return fmt.Sprintf("error collecting retention policies: %s", strings.Join(e.Reasons, "; "))
+}
+
+type CollectingDevices struct {
+	Reasons []string
+}
+
+func (e CollectingDevices) Error() string {
+	return fmt.Sprintf("error collecting devices: %s", strings.Join(e.Reasons, "; "))
+}
+
+type CollectingRecords struct {
+	Reasons []string
+}
+
+func (e CollectingRecords) Error() string {
+	return fmt.Sprintf("error collecting records: %s", strings.Join(e.Reasons, "; "))
+}
+
+type Archive struct {
+	Reasons []string
+}
+
+func (e Archive) Error() string {
+	return fmt.Sprintf("error archiving records: %s", strings.Join(e.Reasons, "; "))
+}
+
+type DeletingRecords struct {
+	Reasons []string
+}
+
+func (e DeletingRecords) Error() string {
+	return fmt.Sprintf("error deleting records: %s", strings.Join(e.Reasons, "; "))
+}
@@ -0,0 +1,29 @@
+package purger
+
+import (
+	"github.com/iot-my-world/brain/pkg/retention/policy"
+	"github.com/iot-my-world/brain/pkg/security/claims"
+)
+
+// Purger deletes the records which have outlived the retention policy
+// that applies to them, archiving them first if the policy requires it
+type Purger interface {
+	Purge(request *PurgeRequest) (*PurgeResponse, error)
+}
+
+type PurgeRequest struct {
+	Claims claims.Claims
+}
+
+type PurgeResponse struct {
+	Results []Result
+}
+
+// Result is the outcome of enforcing a policy
+type Result struct {
+	Policy   policy.Policy
+	NoPurged int
+	// ArchiveFile is the file to which purged records were
+	// archived, blank if none were
+	ArchiveFile string
+}
@@ -0,0 +1,43 @@
+package boolean
+
+import (
+	"github.com/go-errors/errors"
+	criterion2 "github.com/iot-my-world/brain/pkg/search/criterion"
+	"gopkg.in/mgo.v2/bson"
+	"strings"
+)
+
+const Type = criterion2.ExactBoolean
+
+type Criterion struct {
+	Field   string `json:"field"`
+	Boolean bool   `json:"boolean"`
+}
+
+func (c Criterion) IsValid() error {
+
+	reasonsInvalid := make([]string, 0)
+
+	if c.Field == "" {
+		reasonsInvalid = append(reasonsInvalid, "field is blank")
+	}
+
+	if len(reasonsInvalid) > 0 {
+		return errors.New(strings.Join(reasonsInvalid, "; "))
+	}
+
+	return nil
+}
+
+func (c Criterion) Type() criterion2.Type {
+	return Type
+}
+
+// ToFilter matches records without the field when looking for false,
+// since that is the value they are given when they are retrieved
+func (c Criterion) ToFilter() map[string]interface{} {
+	if c.Boolean {
+		return bson.M{c.Field: true}
+	}
+	return bson.M{c.Field: bson.M{"$ne": true}}
+}
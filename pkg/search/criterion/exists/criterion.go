@@ -0,0 +1,49 @@
+package exists
+
+import (
+	"github.com/go-errors/errors"
+	criterion2 "github.com/iot-my-world/brain/pkg/search/criterion"
+	"gopkg.in/mgo.v2/bson"
+	"strings"
+)
+
+const Type = criterion2.Exists
+
+// Criterion matches records on which Field is set if Exists is true,
+// and those on which it is not set otherwise. A field is taken to be
+// set if it exists and is neither null nor blank. Identifiers which are
+// not set are stored as a sub-document with a blank id, and so Field may
+// be given either as the identifier (e.g. assignedId) or as its id
+// (e.g. assignedId.id).
+type Criterion struct {
+	Field  string `json:"field"`
+	Exists bool   `json:"exists"`
+}
+
+func (c Criterion) IsValid() error {
+
+	reasonsInvalid := make([]string, 0)
+
+	if c.Field == "" {
+		reasonsInvalid = append(reasonsInvalid, "field is blank")
+	}
+
+	if len(reasonsInvalid) > 0 {
+		return errors.New(strings.Join(reasonsInvalid, "; "))
+	}
+
+	return nil
+}
+
+func (c Criterion) Type() criterion2.Type {
+	return Type
+}
+
+func (c Criterion) ToFilter() map[string]interface{} {
+	// null also matches records without the field
+	notSet := []interface{}{nil, "", bson.M{"id": ""}}
+	if c.Exists {
+		return bson.M{c.Field: bson.M{"$nin": notSet}}
+	}
+	return bson.M{c.Field: bson.M{"$in": notSet}}
+}
@@ -0,0 +1,67 @@
+package box
+
+import (
+	"github.com/go-errors/errors"
+	criterion2 "github.com/iot-my-world/brain/pkg/search/criterion"
+	"github.com/iot-my-world/brain/pkg/search/criterion/geo"
+	"gopkg.in/mgo.v2/bson"
+	"strings"
+)
+
+const Type = criterion2.WithinBox
+
+// Criterion matches GeoJSON points in Field which lie within the
+// bounding box with the given south west and north east corners
+type Criterion struct {
+	Field     string    `json:"field"`
+	SouthWest geo.Point `json:"southWest"`
+	NorthEast geo.Point `json:"northEast"`
+}
+
+func (c Criterion) IsValid() error {
+
+	reasonsInvalid := make([]string, 0)
+
+	if c.Field == "" {
+		reasonsInvalid = append(reasonsInvalid, "field is blank")
+	}
+
+	reasonsInvalid = append(reasonsInvalid, c.SouthWest.IsValid()...)
+	reasonsInvalid = append(reasonsInvalid, c.NorthEast.IsValid()...)
+
+	if c.SouthWest.Latitude >= c.NorthEast.Latitude {
+		reasonsInvalid = append(reasonsInvalid, "south west latitude must be less than north east latitude")
+	}
+
+	// wider boxes would be taken to be the smaller polygon on the other side of the earth
+	if c.SouthWest.Longitude >= c.NorthEast.Longitude ||
+		c.NorthEast.Longitude-c.SouthWest.Longitude >= 180 {
+		reasonsInvalid = append(reasonsInvalid, "box must span between 0 and 180 degrees of longitude from south west to north east")
+	}
+
+	if len(reasonsInvalid) > 0 {
+		return errors.New(strings.Join(reasonsInvalid, "; "))
+	}
+
+	return nil
+}
+
+func (c Criterion) Type() criterion2.Type {
+	return Type
+}
+
+// ToFilter gives the box as a GeoJSON polygon so that it can be used with
+// 2dsphere indexes. The edges of the polygon are great circle arcs and so
+// the box bulges slightly toward the poles.
+func (c Criterion) ToFilter() map[string]interface{} {
+	return bson.M{c.Field: bson.M{
+		"$geoWithin": bson.M{
+			"$geometry": geo.PolygonGeometry([]geo.Point{
+				c.SouthWest,
+				{Latitude: c.SouthWest.Latitude, Longitude: c.NorthEast.Longitude},
+				c.NorthEast,
+				{Latitude: c.NorthEast.Latitude, Longitude: c.SouthWest.Longitude},
+			}),
+		},
+	}}
+}
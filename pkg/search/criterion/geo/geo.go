@@ -0,0 +1,55 @@
+package geo
+
+import (
+	"fmt"
+	"math"
+)
+
+// earthRadiusInMetres is the radius used by mongo for spherical geometry
+const earthRadiusInMetres float64 = 6378100
+
+// Point is a position given to a geospatial criterion
+type Point struct {
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+}
+
+// IsValid returns the reasons that the point is invalid, if any
+func (p Point) IsValid() []string {
+	reasonsInvalid := make([]string, 0)
+	if math.IsNaN(p.Latitude) || p.Latitude < -90 || p.Latitude > 90 {
+		reasonsInvalid = append(reasonsInvalid, fmt.Sprintf("latitude %v not between -90 and 90", p.Latitude))
+	}
+	if math.IsNaN(p.Longitude) || p.Longitude < -180 || p.Longitude > 180 {
+		reasonsInvalid = append(reasonsInvalid, fmt.Sprintf("longitude %v not between -180 and 180", p.Longitude))
+	}
+	return reasonsInvalid
+}
+
+// Coordinates returns the GeoJSON coordinates of the point,
+// which are given as longitude then latitude
+func (p Point) Coordinates() []float64 {
+	return []float64{p.Longitude, p.Latitude}
+}
+
+// RadiusToRadians converts a distance in metres along the
+// surface of the earth to an angle in radians
+func RadiusToRadians(radius float64) float64 {
+	return radius / earthRadiusInMetres
+}
+
+// PolygonGeometry returns a GeoJSON polygon with the given points as its
+// exterior ring. The ring is closed if the last point is not the first.
+func PolygonGeometry(points []Point) map[string]interface{} {
+	ring := make([][]float64, 0, len(points)+1)
+	for _, point := range points {
+		ring = append(ring, point.Coordinates())
+	}
+	if len(points) > 0 && points[0] != points[len(points)-1] {
+		ring = append(ring, points[0].Coordinates())
+	}
+	return map[string]interface{}{
+		"type":        "Polygon",
+		"coordinates": [][][]float64{ring},
+	}
+}
@@ -0,0 +1,57 @@
+package near
+
+import (
+	"github.com/go-errors/errors"
+	criterion2 "github.com/iot-my-world/brain/pkg/search/criterion"
+	"github.com/iot-my-world/brain/pkg/search/criterion/geo"
+	"gopkg.in/mgo.v2/bson"
+	"strings"
+)
+
+const Type = criterion2.NearPoint
+
+// Criterion matches GeoJSON points in Field which lie
+// within Radius metres of Point
+type Criterion struct {
+	Field  string    `json:"field"`
+	Point  geo.Point `json:"point"`
+	Radius float64   `json:"radius"`
+}
+
+func (c Criterion) IsValid() error {
+
+	reasonsInvalid := make([]string, 0)
+
+	if c.Field == "" {
+		reasonsInvalid = append(reasonsInvalid, "field is blank")
+	}
+
+	reasonsInvalid = append(reasonsInvalid, c.Point.IsValid()...)
+
+	if !(c.Radius > 0) {
+		reasonsInvalid = append(reasonsInvalid, "radius must be greater than 0")
+	}
+
+	if len(reasonsInvalid) > 0 {
+		return errors.New(strings.Join(reasonsInvalid, "; "))
+	}
+
+	return nil
+}
+
+func (c Criterion) Type() criterion2.Type {
+	return Type
+}
+
+// ToFilter uses $centerSphere rather than $near since collect counts
+// and sorts the records it finds, which cannot be done with $near
+func (c Criterion) ToFilter() map[string]interface{} {
+	return bson.M{c.Field: bson.M{
+		"$geoWithin": bson.M{
+			"$centerSphere": []interface{}{
+				c.Point.Coordinates(),
+				geo.RadiusToRadians(c.Radius),
+			},
+		},
+	}}
+}
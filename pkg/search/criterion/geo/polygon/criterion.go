@@ -0,0 +1,58 @@
+package polygon
+
+import (
+	"github.com/go-errors/errors"
+	criterion2 "github.com/iot-my-world/brain/pkg/search/criterion"
+	"github.com/iot-my-world/brain/pkg/search/criterion/geo"
+	"gopkg.in/mgo.v2/bson"
+	"strings"
+)
+
+const Type = criterion2.WithinPolygon
+
+// Criterion matches GeoJSON points in Field which lie within
+// the polygon with the given Points as its vertices
+type Criterion struct {
+	Field  string      `json:"field"`
+	Points []geo.Point `json:"points"`
+}
+
+func (c Criterion) IsValid() error {
+
+	reasonsInvalid := make([]string, 0)
+
+	if c.Field == "" {
+		reasonsInvalid = append(reasonsInvalid, "field is blank")
+	}
+
+	// the polygon may be given closed, with the first point repeated at the end
+	noVertices := len(c.Points)
+	if noVertices > 0 && c.Points[0] == c.Points[noVertices-1] {
+		noVertices--
+	}
+	if noVertices < 3 {
+		reasonsInvalid = append(reasonsInvalid, "polygon must have at least 3 points")
+	}
+
+	for _, point := range c.Points {
+		reasonsInvalid = append(reasonsInvalid, point.IsValid()...)
+	}
+
+	if len(reasonsInvalid) > 0 {
+		return errors.New(strings.Join(reasonsInvalid, "; "))
+	}
+
+	return nil
+}
+
+func (c Criterion) Type() criterion2.Type {
+	return Type
+}
+
+func (c Criterion) ToFilter() map[string]interface{} {
+	return bson.M{c.Field: bson.M{
+		"$geoWithin": bson.M{
+			"$geometry": geo.PolygonGeometry(c.Points),
+		},
+	}}
+}
@@ -0,0 +1,31 @@
+package not
+
+import (
+	"github.com/go-errors/errors"
+	criterion2 "github.com/iot-my-world/brain/pkg/search/criterion"
+	"gopkg.in/mgo.v2/bson"
+)
+
+const Type = criterion2.Not
+
+// Criterion matches the records which are not matched by Criterion
+type Criterion struct {
+	Criterion criterion2.Criterion `json:"criterion"`
+}
+
+func (c Criterion) IsValid() error {
+	if c.Criterion == nil {
+		return errors.New("criterion is nil")
+	}
+	return c.Criterion.IsValid()
+}
+
+func (c Criterion) Type() criterion2.Type {
+	return Type
+}
+
+// ToFilter uses $nor since $not can only negate the operators on a
+// single field, whereas the criterion may be compound, e.g. an or
+func (c Criterion) ToFilter() map[string]interface{} {
+	return bson.M{"$nor": []bson.M{c.Criterion.ToFilter()}}
+}
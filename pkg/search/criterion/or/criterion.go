@@ -19,6 +19,14 @@ func (c Criterion) IsValid() error {
 		reasonsInvalid = append(reasonsInvalid, "criteria array has no elements")
 	}
 
+	for criterionIdx := range c.Criteria {
+		if c.Criteria[criterionIdx] == nil {
+			reasonsInvalid = append(reasonsInvalid, "a criterion is nil")
+		} else if err := c.Criteria[criterionIdx].IsValid(); err != nil {
+			reasonsInvalid = append(reasonsInvalid, err.Error())
+		}
+	}
+
 	if len(reasonsInvalid) > 0 {
 		return errors.New(strings.Join(reasonsInvalid, "; "))
 	}
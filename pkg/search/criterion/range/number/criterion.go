@@ -0,0 +1,76 @@
+package number
+
+import (
+	"github.com/go-errors/errors"
+	criterion2 "github.com/iot-my-world/brain/pkg/search/criterion"
+	"gopkg.in/mgo.v2/bson"
+	"math"
+	"strings"
+)
+
+const Type = criterion2.NumberRange
+
+type Criterion struct {
+	Field string     `json:"field"`
+	Start RangeValue `json:"start"`
+	End   RangeValue `json:"end"`
+}
+
+type RangeValue struct {
+	Number    float64 `json:"number"`
+	Inclusive bool    `json:"inclusive"`
+	Ignore    bool    `json:"ignore"`
+}
+
+func (c Criterion) IsValid() error {
+
+	reasonsInvalid := make([]string, 0)
+
+	if c.Field == "" {
+		reasonsInvalid = append(reasonsInvalid, "field is blank")
+	}
+
+	if c.Start.Ignore && c.End.Ignore {
+		reasonsInvalid = append(reasonsInvalid, "start and end are both ignored")
+	}
+
+	if (!c.Start.Ignore && math.IsNaN(c.Start.Number)) || (!c.End.Ignore && math.IsNaN(c.End.Number)) {
+		reasonsInvalid = append(reasonsInvalid, "number is not a number")
+	}
+
+	if !(c.Start.Ignore || c.End.Ignore) && c.Start.Number > c.End.Number {
+		reasonsInvalid = append(reasonsInvalid, "start is greater than end")
+	}
+
+	if len(reasonsInvalid) > 0 {
+		return errors.New(strings.Join(reasonsInvalid, "; "))
+	}
+
+	return nil
+}
+
+func (c Criterion) Type() criterion2.Type {
+	return Type
+}
+
+func (c Criterion) ToFilter() map[string]interface{} {
+	rangeFilter := bson.M{}
+
+	if !c.Start.Ignore {
+		if c.Start.Inclusive {
+			rangeFilter["$gte"] = c.Start.Number
+		} else {
+			rangeFilter["$gt"] = c.Start.Number
+		}
+	}
+
+	if !c.End.Ignore {
+		if c.End.Inclusive {
+			rangeFilter["$lte"] = c.End.Number
+		} else {
+			rangeFilter["$lt"] = c.End.Number
+		}
+	}
+
+	return bson.M{c.Field: rangeFilter}
+}
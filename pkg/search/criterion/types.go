@@ -4,15 +4,24 @@ type Type string
 
 // basic criteria
 const Text Type = "Text"
+const Exists Type = "Exists"
 
 // compound criteria
 const Or Type = "Or"
+const Not Type = "Not"
 
 // list criteria
 const ListText Type = "ListText"
 
 // exact criteria
 const ExactText Type = "ExactText"
+const ExactBoolean Type = "ExactBoolean"
 
 // range criteria
 const DateRange Type = "DateRange"
+const NumberRange Type = "NumberRange"
+
+// geospatial criteria
+const NearPoint Type = "NearPoint"
+const WithinPolygon Type = "WithinPolygon"
+const WithinBox Type = "WithinBox"
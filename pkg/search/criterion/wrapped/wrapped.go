@@ -4,10 +4,17 @@ import (
 	"encoding/json"
 	brainException "github.com/iot-my-world/brain/internal/exception"
 	criterion2 "github.com/iot-my-world/brain/pkg/search/criterion"
+	"github.com/iot-my-world/brain/pkg/search/criterion/exact/boolean"
 	"github.com/iot-my-world/brain/pkg/search/criterion/exact/text"
 	"github.com/iot-my-world/brain/pkg/search/criterion/exception"
+	"github.com/iot-my-world/brain/pkg/search/criterion/exists"
+	"github.com/iot-my-world/brain/pkg/search/criterion/geo/box"
+	"github.com/iot-my-world/brain/pkg/search/criterion/geo/near"
+	"github.com/iot-my-world/brain/pkg/search/criterion/geo/polygon"
 	text2 "github.com/iot-my-world/brain/pkg/search/criterion/list/text"
+	"github.com/iot-my-world/brain/pkg/search/criterion/not"
 	or2 "github.com/iot-my-world/brain/pkg/search/criterion/or"
+	"github.com/iot-my-world/brain/pkg/search/criterion/range/number"
 	text3 "github.com/iot-my-world/brain/pkg/search/criterion/text"
 )
 
@@ -20,8 +27,45 @@ type OrWrapped struct {
 	Criteria []Wrapped `json:"criteria"`
 }
 
+type NotWrapped struct {
+	Criterion Wrapped `json:"criterion"`
+}
+
 func Wrap(criterion criterion2.Criterion) (*Wrapped, error) {
-	value, err := json.Marshal(criterion)
+	if criterion == nil {
+		return nil, exception.Wrapping{Reasons: []string{"criterion is nil"}}
+	}
+
+	// the criteria nested in compound criteria are wrapped
+	// so that their types are known when they are unwrapped
+	var value []byte
+	var err error
+	switch typedCriterion := criterion.(type) {
+	case or2.Criterion:
+		orWrapped := OrWrapped{
+			Criteria: make([]Wrapped, 0),
+		}
+		for critIdx := range typedCriterion.Criteria {
+			wrappedCrit, wrapErr := Wrap(typedCriterion.Criteria[critIdx])
+			if wrapErr != nil {
+				return nil, wrapErr
+			}
+			orWrapped.Criteria = append(orWrapped.Criteria, *wrappedCrit)
+		}
+		value, err = json.Marshal(orWrapped)
+
+	case not.Criterion:
+		wrappedCrit, wrapErr := Wrap(typedCriterion.Criterion)
+		if wrapErr != nil {
+			return nil, wrapErr
+		}
+		value, err = json.Marshal(NotWrapped{
+			Criterion: *wrappedCrit,
+		})
+
+	default:
+		value, err = json.Marshal(criterion)
+	}
 	if err != nil {
 		return nil, exception.Wrapping{Reasons: []string{
 			"json marshalling",
@@ -59,6 +103,48 @@ func (cw Wrapped) UnWrap() (criterion2.Criterion, error) {
 		}
 		result = unmarshalledCriterion
 
+	case criterion2.ExactBoolean:
+		var unmarshalledCriterion boolean.Criterion
+		if err := json.Unmarshal(cw.Value, &unmarshalledCriterion); err != nil {
+			return nil, exception.Unwrapping{Reasons: []string{"unmarshalling", err.Error()}}
+		}
+		result = unmarshalledCriterion
+
+	case criterion2.Exists:
+		var unmarshalledCriterion exists.Criterion
+		if err := json.Unmarshal(cw.Value, &unmarshalledCriterion); err != nil {
+			return nil, exception.Unwrapping{Reasons: []string{"unmarshalling", err.Error()}}
+		}
+		result = unmarshalledCriterion
+
+	case criterion2.NumberRange:
+		var unmarshalledCriterion number.Criterion
+		if err := json.Unmarshal(cw.Value, &unmarshalledCriterion); err != nil {
+			return nil, exception.Unwrapping{Reasons: []string{"unmarshalling", err.Error()}}
+		}
+		result = unmarshalledCriterion
+
+	case criterion2.NearPoint:
+		var unmarshalledCriterion near.Criterion
+		if err := json.Unmarshal(cw.Value, &unmarshalledCriterion); err != nil {
+			return nil, exception.Unwrapping{Reasons: []string{"unmarshalling", err.Error()}}
+		}
+		result = unmarshalledCriterion
+
+	case criterion2.WithinPolygon:
+		var unmarshalledCriterion polygon.Criterion
+		if err := json.Unmarshal(cw.Value, &unmarshalledCriterion); err != nil {
+			return nil, exception.Unwrapping{Reasons: []string{"unmarshalling", err.Error()}}
+		}
+		result = unmarshalledCriterion
+
+	case criterion2.WithinBox:
+		var unmarshalledCriterion box.Criterion
+		if err := json.Unmarshal(cw.Value, &unmarshalledCriterion); err != nil {
+			return nil, exception.Unwrapping{Reasons: []string{"unmarshalling", err.Error()}}
+		}
+		result = unmarshalledCriterion
+
 	case criterion2.Or:
 		var wrappedOrCriterion OrWrapped
 		var unmarshalledCriterion or2.Criterion
@@ -75,6 +161,17 @@ func (cw Wrapped) UnWrap() (criterion2.Criterion, error) {
 		}
 		result = unmarshalledCriterion
 
+	case criterion2.Not:
+		var wrappedNotCriterion NotWrapped
+		if err := json.Unmarshal(cw.Value, &wrappedNotCriterion); err != nil {
+			return nil, exception.Unwrapping{Reasons: []string{"unmarshalling", err.Error()}}
+		}
+		crit, err := wrappedNotCriterion.Criterion.UnWrap()
+		if err != nil {
+			return nil, err
+		}
+		result = not.Criterion{Criterion: crit}
+
 	default:
 		return nil, exception.Invalid{Reasons: []string{"invalid type"}}
 	}
@@ -83,5 +180,11 @@ func (cw Wrapped) UnWrap() (criterion2.Criterion, error) {
 		return nil, brainException.Unexpected{Reasons: []string{"identifier still nil"}}
 	}
 
+	// criteria are validated as they are received so that invalid
+	// criteria are not used to build queries
+	if err := result.IsValid(); err != nil {
+		return nil, exception.Invalid{Reasons: []string{err.Error()}}
+	}
+
 	return result, nil
 }
@@ -0,0 +1,221 @@
+package wrapped
+
+import (
+	"encoding/json"
+	criterion2 "github.com/iot-my-world/brain/pkg/search/criterion"
+	"github.com/iot-my-world/brain/pkg/search/criterion/exact/boolean"
+	"github.com/iot-my-world/brain/pkg/search/criterion/exists"
+	"github.com/iot-my-world/brain/pkg/search/criterion/geo"
+	"github.com/iot-my-world/brain/pkg/search/criterion/geo/box"
+	"github.com/iot-my-world/brain/pkg/search/criterion/geo/near"
+	"github.com/iot-my-world/brain/pkg/search/criterion/geo/polygon"
+	text2 "github.com/iot-my-world/brain/pkg/search/criterion/list/text"
+	"github.com/iot-my-world/brain/pkg/search/criterion/not"
+	or2 "github.com/iot-my-world/brain/pkg/search/criterion/or"
+	"github.com/iot-my-world/brain/pkg/search/criterion/range/number"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/mgo.v2/bson"
+	"testing"
+)
+
+// roundTrip wraps the given criterion and unwraps it as
+// it would be sent to and received by a json rpc adaptor
+func roundTrip(t *testing.T, criterion criterion2.Criterion) criterion2.Criterion {
+	wrapped, err := Wrap(criterion)
+	if err != nil {
+		t.Fatal(err)
+	}
+	marshalled, err := json.Marshal(wrapped)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var unmarshalled Wrapped
+	if err := json.Unmarshal(marshalled, &unmarshalled); err != nil {
+		t.Fatal(err)
+	}
+	unwrapped, err := unmarshalled.UnWrap()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return unwrapped
+}
+
+func TestGeoCriteria(t *testing.T) {
+	criteria := []criterion2.Criterion{
+		near.Criterion{
+			Field:  "location",
+			Point:  geo.Point{Latitude: -26.2, Longitude: 28.04},
+			Radius: 500,
+		},
+		polygon.Criterion{
+			Field: "location",
+			Points: []geo.Point{
+				{Latitude: -26, Longitude: 28},
+				{Latitude: -26, Longitude: 29},
+				{Latitude: -27, Longitude: 28.5},
+			},
+		},
+		box.Criterion{
+			Field:     "location",
+			SouthWest: geo.Point{Latitude: -27, Longitude: 28},
+			NorthEast: geo.Point{Latitude: -26, Longitude: 29},
+		},
+	}
+
+	for _, criterion := range criteria {
+		assert.NoError(t, criterion.IsValid(), string(criterion.Type()))
+		assert.Equal(t, criterion, roundTrip(t, criterion))
+	}
+
+	// geo criteria may be nested in an or criterion
+	orCriterion := or2.Criterion{Criteria: criteria}
+	assert.Equal(t, orCriterion, roundTrip(t, orCriterion))
+}
+
+func TestGeoCriteriaFilters(t *testing.T) {
+	// polygons are closed
+	filter := polygon.Criterion{
+		Field: "location",
+		Points: []geo.Point{
+			{Latitude: -26, Longitude: 28},
+			{Latitude: -26, Longitude: 29},
+			{Latitude: -27, Longitude: 28.5},
+		},
+	}.ToFilter()
+	assert.Equal(t,
+		[][][]float64{{{28, -26}, {29, -26}, {28.5, -27}, {28, -26}}},
+		filter["location"].(bson.M)["$geoWithin"].(bson.M)["$geometry"].(map[string]interface{})["coordinates"],
+	)
+
+	// boxes of more than 180 degrees of longitude are invalid
+	assert.Error(t, box.Criterion{
+		Field:     "location",
+		SouthWest: geo.Point{Latitude: -10, Longitude: -100},
+		NorthEast: geo.Point{Latitude: 10, Longitude: 100},
+	}.IsValid())
+
+	// a radius must be given
+	assert.Error(t, near.Criterion{
+		Field: "location",
+		Point: geo.Point{Latitude: -26.2, Longitude: 28.04},
+	}.IsValid())
+}
+
+func TestValueCriteria(t *testing.T) {
+	criteria := []criterion2.Criterion{
+		number.Criterion{
+			Field: "timeStamp",
+			Start: number.RangeValue{Number: 1546300800, Inclusive: true},
+			End:   number.RangeValue{Ignore: true},
+		},
+		boolean.Criterion{
+			Field:   "registered",
+			Boolean: false,
+		},
+		exists.Criterion{
+			Field:  "assignedId.id",
+			Exists: false,
+		},
+		not.Criterion{
+			Criterion: text2.Criterion{
+				Field: "deviceId",
+				List:  []string{"1234", "5678"},
+			},
+		},
+	}
+
+	for _, criterion := range criteria {
+		assert.NoError(t, criterion.IsValid(), string(criterion.Type()))
+		assert.Equal(t, criterion, roundTrip(t, criterion))
+	}
+
+	// criteria may be nested in or and not criteria
+	nestedCriterion := not.Criterion{
+		Criterion: or2.Criterion{Criteria: criteria},
+	}
+	assert.NoError(t, nestedCriterion.IsValid())
+	assert.Equal(t, nestedCriterion, roundTrip(t, nestedCriterion))
+}
+
+func TestValueCriteriaFilters(t *testing.T) {
+	assert.Equal(t,
+		map[string]interface{}{"timeStamp": bson.M{"$gt": float64(10), "$lte": float64(20)}},
+		number.Criterion{
+			Field: "timeStamp",
+			Start: number.RangeValue{Number: 10},
+			End:   number.RangeValue{Number: 20, Inclusive: true},
+		}.ToFilter(),
+	)
+
+	assert.Equal(t,
+		map[string]interface{}{"$nor": []bson.M{{"deviceId": bson.M{"$in": []string{"1234"}}}}},
+		not.Criterion{
+			Criterion: text2.Criterion{
+				Field: "deviceId",
+				List:  []string{"1234"},
+			},
+		}.ToFilter(),
+	)
+
+	// ranges must be in order
+	assert.Error(t, number.Criterion{
+		Field: "timeStamp",
+		Start: number.RangeValue{Number: 20},
+		End:   number.RangeValue{Number: 10},
+	}.IsValid())
+
+	// identifiers which are not set are stored with a blank id
+	assert.Equal(t,
+		map[string]interface{}{"assignedId": bson.M{"$in": []interface{}{nil, "", bson.M{"id": ""}}}},
+		exists.Criterion{Field: "assignedId"}.ToFilter(),
+	)
+	assert.Equal(t,
+		map[string]interface{}{"assignedId.id": bson.M{"$nin": []interface{}{nil, "", bson.M{"id": ""}}}},
+		exists.Criterion{Field: "assignedId.id", Exists: true}.ToFilter(),
+	)
+
+	// not criteria must negate a valid criterion
+	assert.Error(t, not.Criterion{}.IsValid())
+	assert.Error(t, not.Criterion{Criterion: exists.Criterion{}}.IsValid())
+
+	// nil criteria cannot be wrapped
+	_, err := Wrap(not.Criterion{})
+	assert.Error(t, err)
+}
+
+func TestUnWrapInvalidCriteria(t *testing.T) {
+	invalidBox := box.Criterion{
+		Field:     "location",
+		SouthWest: geo.Point{Latitude: -26, Longitude: 29},
+		NorthEast: geo.Point{Latitude: -27, Longitude: 28},
+	}
+	criteria := []criterion2.Criterion{
+		invalidBox,
+		// invalid criteria nested in or and not criteria are found
+		or2.Criterion{Criteria: []criterion2.Criterion{
+			exists.Criterion{Field: "assignedId.id"},
+			invalidBox,
+		}},
+		not.Criterion{Criterion: or2.Criterion{Criteria: []criterion2.Criterion{invalidBox}}},
+		or2.Criterion{Criteria: []criterion2.Criterion{
+			boolean.Criterion{Field: "registered"},
+			number.Criterion{
+				Field: "timeStamp",
+				Start: number.RangeValue{Ignore: true},
+				End:   number.RangeValue{Ignore: true},
+			},
+		}},
+		or2.Criterion{Criteria: []criterion2.Criterion{
+			not.Criterion{Criterion: exists.Criterion{}},
+		}},
+	}
+
+	for _, criterion := range criteria {
+		wrapped, err := Wrap(criterion)
+		if !assert.NoError(t, err, string(criterion.Type())) {
+			continue
+		}
+		_, err = wrapped.UnWrap()
+		assert.Error(t, err, string(criterion.Type()))
+	}
+}
@@ -13,12 +13,13 @@ import (
 	nameIdentifier "github.com/iot-my-world/brain/pkg/search/identifier/name"
 	partyIdentifier "github.com/iot-my-world/brain/pkg/search/identifier/party"
 	usernameIdentifier "github.com/iot-my-world/brain/pkg/search/identifier/username"
+	sigfoxBackendDataCallbackMessage "github.com/iot-my-world/brain/pkg/sigfox/backend/callback/data/message"
 )
 
 type Wrapped struct {
-	Type       identifier.Type       `json:"type"`
-	Value      json.RawMessage       `json:"value"`
-	Identifier identifier.Identifier `json:"-"`
+	Type       identifier.Type       `json:"type" bson:"type"`
+	Value      json.RawMessage       `json:"value" bson:"value"`
+	Identifier identifier.Identifier `json:"-" bson:"-"`
 }
 
 func Wrap(id identifier.Identifier) (*Wrapped, error) {
@@ -95,6 +96,13 @@ func (i *Wrapped) UnmarshalJSON(data []byte) error {
 		}
 		i.Identifier = unmarshalledId
 
+	case sigfoxBackendDataCallbackMessage.SequenceIdentifier:
+		var unmarshalledId sigfoxBackendDataCallbackMessage.Identifier
+		if err := json.Unmarshal(i.Value, &unmarshalledId); err != nil {
+			return exception.Unwrapping{Reasons: []string{"unmarshalling", err.Error()}}
+		}
+		i.Identifier = unmarshalledId
+
 	default:
 		return exception.Invalid{Reasons: []string{"invalid type", string(i.Type)}}
 	}
@@ -15,6 +15,7 @@ const RegisterClientAdminUser Type = "RegisterClientAdminUser"
 const RegisterClientUser Type = "RegisterClientUser"
 const ResetPassword Type = "ResetPassword"
 const SigfoxBackend Type = "SigfoxBackend"
+const VerifyTOTP Type = "VerifyTOTP"
 
 type Claims interface {
 	Type() Type
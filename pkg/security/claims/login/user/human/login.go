@@ -1,9 +1,12 @@
 package human
 
 import (
+	jsonRpcServerAuthenticator "github.com/iot-my-world/brain/pkg/api/jsonRpc/server/authenticator"
 	"github.com/iot-my-world/brain/pkg/party"
 	"github.com/iot-my-world/brain/pkg/search/identifier/id"
 	claims "github.com/iot-my-world/brain/pkg/security/claims"
+	apiPermission "github.com/iot-my-world/brain/pkg/security/permission/api"
+	humanUserAdministrator "github.com/iot-my-world/brain/pkg/user/human/administrator"
 	"time"
 )
 
@@ -15,6 +18,15 @@ type Login struct {
 	ParentId        id.Identifier `json:"parentId"`
 	PartyType       party.Type    `json:"partyType"`
 	PartyId         id.Identifier `json:"partyId"`
+
+	// TOTPEnrolmentRequired is set when the party of the user requires
+	// two-factor authentication and the user has not yet enrolled.
+	// Only TOTPEnrolmentAPIPermissions are granted until they do.
+	TOTPEnrolmentRequired bool `json:"totpEnrolmentRequired,omitempty"`
+
+	// SessionId is the id of the refresh token session in which these
+	// claims were issued, blank for claims issued outside of a session
+	SessionId string `json:"sessionId,omitempty"`
 }
 
 func (l Login) Type() claims.Type {
@@ -41,3 +53,12 @@ func (l Login) PartyDetails() party.Details {
 func (l Login) TimeToExpiry() time.Duration {
 	return time.Unix(l.ExpirationTime, 0).UTC().Sub(time.Now().UTC())
 }
+
+// permissions granted by login claims of a user who must still
+// enrol in two-factor authentication
+var TOTPEnrolmentAPIPermissions = []apiPermission.Permission{
+	humanUserAdministrator.GetMyUserService,
+	humanUserAdministrator.EnrolTOTPService,
+	humanUserAdministrator.ConfirmTOTPEnrolmentService,
+	jsonRpcServerAuthenticator.LogoutService,
+}
@@ -44,4 +44,5 @@ func (r SigfoxBackend) PartyDetails() party.Details {
 // permissions granted by having a valid set of these claims
 var GrantedAPIPermissions = []apiPermission.Permission{
 	sigfoxBackendCallbackServer.HandleDataMessageService,
+	sigfoxBackendCallbackServer.HandleDownlinkAckService,
 }
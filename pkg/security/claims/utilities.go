@@ -2,6 +2,7 @@ package claims
 
 import (
 	"github.com/iot-my-world/brain/pkg/party"
+	"github.com/iot-my-world/brain/pkg/search/identifier/id"
 	"gopkg.in/mgo.v2/bson"
 )
 
@@ -21,3 +22,15 @@ func ContextualiseFilter(filter bson.M, claimsToAdd Claims) bson.M {
 		}}
 	}
 }
+
+// EntityInContext is the in memory equivalent of ContextualiseFilter.
+// It returns true if an entity with the given owner and assigned ids
+// would be visible to a party with the given claims.
+func EntityInContext(ownerId, assignedId id.Identifier, claimsToCheck Claims) bool {
+	if claimsToCheck.PartyDetails().PartyType == party.System {
+		// if the party is system, then all entities are visible
+		return true
+	}
+	partyId := claimsToCheck.PartyDetails().PartyId.Id
+	return ownerId.Id == partyId || assignedId.Id == partyId
+}
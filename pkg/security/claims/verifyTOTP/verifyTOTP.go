@@ -0,0 +1,53 @@
+package verifyTOTP
+
+import (
+	jsonRpcServerAuthenticator "github.com/iot-my-world/brain/pkg/api/jsonRpc/server/authenticator"
+	"github.com/iot-my-world/brain/pkg/party"
+	"github.com/iot-my-world/brain/pkg/search/identifier/id"
+	"github.com/iot-my-world/brain/pkg/security/claims"
+	apiPermission "github.com/iot-my-world/brain/pkg/security/permission/api"
+	"time"
+)
+
+// VerifyTOTP claims are issued once a user with two-factor authentication
+// enabled has given the correct password. They only allow the user to
+// exchange a code from their authenticator app for login claims.
+type VerifyTOTP struct {
+	UserId          id.Identifier `json:"userId"`
+	IssueTime       int64         `json:"issueTime"`
+	ExpirationTime  int64         `json:"expirationTime"`
+	ParentPartyType party.Type    `json:"parentPartyType"`
+	ParentId        id.Identifier `json:"parentId"`
+	PartyType       party.Type    `json:"partyType"`
+	PartyId         id.Identifier `json:"partyId"`
+}
+
+func (v VerifyTOTP) Type() claims.Type {
+	return claims.VerifyTOTP
+}
+
+func (v VerifyTOTP) Expired() bool {
+	return time.Now().UTC().After(time.Unix(v.ExpirationTime, 0).UTC())
+}
+
+func (v VerifyTOTP) TimeToExpiry() time.Duration {
+	return time.Unix(v.ExpirationTime, 0).UTC().Sub(time.Now().UTC())
+}
+
+func (v VerifyTOTP) PartyDetails() party.Details {
+	return party.Details{
+		Detail: party.Detail{
+			PartyType: v.PartyType,
+			PartyId:   v.PartyId,
+		},
+		ParentDetail: party.ParentDetail{
+			ParentPartyType: v.ParentPartyType,
+			ParentId:        v.ParentId,
+		},
+	}
+}
+
+// permissions granted by having a valid set of these claims
+var GrantedAPIPermissions = []apiPermission.Permission{
+	jsonRpcServerAuthenticator.VerifyTOTPService,
+}
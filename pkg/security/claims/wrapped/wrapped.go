@@ -12,6 +12,7 @@ import (
 	registerCompanyUserClaims "github.com/iot-my-world/brain/pkg/security/claims/registerCompanyUser"
 	resetPasswordClaims "github.com/iot-my-world/brain/pkg/security/claims/resetPassword"
 	sigfoxBackendClaims "github.com/iot-my-world/brain/pkg/security/claims/sigfoxBackend"
+	verifyTOTPClaims "github.com/iot-my-world/brain/pkg/security/claims/verifyTOTP"
 	"github.com/iot-my-world/brain/pkg/security/claims/wrapped/exception"
 	"net/http"
 )
@@ -19,6 +20,11 @@ import (
 type Wrapped struct {
 	Type  claims.Type     `json:"type"`
 	Value json.RawMessage `json:"value"`
+
+	// TokenId and IssueTime are set when the claims are
+	// wrapped to be signed into a token
+	TokenId   string `json:"jti,omitempty"`
+	IssueTime int64  `json:"iat,omitempty"`
 }
 
 func Wrap(claimsToWrap claims.Claims) (Wrapped, error) {
@@ -96,6 +102,13 @@ func (wc Wrapped) Unwrap() (claims.Claims, error) {
 		}
 		result = unmarshalledClaims
 
+	case claims.VerifyTOTP:
+		var unmarshalledClaims verifyTOTPClaims.VerifyTOTP
+		if err := json.Unmarshal(wc.Value, &unmarshalledClaims); err != nil {
+			return nil, exception.Unwrapping{Reasons: []string{"unmarshalling", err.Error()}}
+		}
+		result = unmarshalledClaims
+
 	default:
 		return nil, exception.Invalid{Reasons: []string{"invalid type"}}
 	}
@@ -113,9 +126,17 @@ func (wc Wrapped) Unwrap() (claims.Claims, error) {
 }
 
 func UnwrapClaimsFromContext(r *http.Request) (claims.Claims, error) {
+	wrapped, err := WrappedClaimsFromContext(r)
+	if err != nil {
+		return nil, err
+	}
+	return wrapped.Unwrap()
+}
+
+func WrappedClaimsFromContext(r *http.Request) (Wrapped, error) {
 	wrapped, ok := r.Context().Value("wrappedClaims").(Wrapped)
 	if !ok {
-		return nil, exception.CouldNotParseFromContext{}
+		return Wrapped{}, exception.CouldNotParseFromContext{}
 	}
-	return wrapped.Unwrap()
+	return wrapped, nil
 }
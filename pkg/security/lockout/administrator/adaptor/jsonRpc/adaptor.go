@@ -0,0 +1,59 @@
+package jsonRpc
+
+import (
+	"github.com/iot-my-world/brain/internal/log"
+	jsonRpcServiceProvider "github.com/iot-my-world/brain/pkg/api/jsonRpc/service/provider"
+	"github.com/iot-my-world/brain/pkg/search/identifier"
+	wrappedIdentifier "github.com/iot-my-world/brain/pkg/search/identifier/wrapped"
+	wrappedClaims "github.com/iot-my-world/brain/pkg/security/claims/wrapped"
+	lockoutAdministrator "github.com/iot-my-world/brain/pkg/security/lockout/administrator"
+	"net/http"
+)
+
+type adaptor struct {
+	administrator lockoutAdministrator.Administrator
+}
+
+func New(administrator lockoutAdministrator.Administrator) *adaptor {
+	return &adaptor{
+		administrator: administrator,
+	}
+}
+
+func (a *adaptor) Name() jsonRpcServiceProvider.Name {
+	return jsonRpcServiceProvider.Name(lockoutAdministrator.ServiceProvider)
+}
+
+func (a *adaptor) MethodRequiresAuthorization(string) bool {
+	return true
+}
+
+type UnlockRequest struct {
+	WrappedUserIdentifier *wrappedIdentifier.Wrapped `json:"userIdentifier,omitempty"`
+	IPAddress             string                     `json:"ipAddress"`
+}
+
+type UnlockResponse struct{}
+
+func (a *adaptor) Unlock(r *http.Request, request *UnlockRequest, response *UnlockResponse) error {
+	claims, err := wrappedClaims.UnwrapClaimsFromContext(r)
+	if err != nil {
+		log.Warn(err.Error())
+		return err
+	}
+
+	var userIdentifier identifier.Identifier
+	if request.WrappedUserIdentifier != nil {
+		userIdentifier = request.WrappedUserIdentifier.Identifier
+	}
+
+	if _, err := a.administrator.Unlock(&lockoutAdministrator.UnlockRequest{
+		Claims:         claims,
+		UserIdentifier: userIdentifier,
+		IPAddress:      request.IPAddress,
+	}); err != nil {
+		return err
+	}
+
+	return nil
+}
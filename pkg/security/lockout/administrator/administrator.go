@@ -0,0 +1,74 @@
+package administrator
+
+import (
+	"github.com/iot-my-world/brain/pkg/search/identifier"
+	"github.com/iot-my-world/brain/pkg/search/identifier/id"
+	"github.com/iot-my-world/brain/pkg/security/claims"
+	"github.com/iot-my-world/brain/pkg/security/permission/api"
+	"time"
+)
+
+// Administrator tracks failed log in attempts per user and per
+// ip address, delaying and then locking out further attempts
+type Administrator interface {
+	CheckAttempt(request *CheckAttemptRequest) (*CheckAttemptResponse, error)
+	RecordFailure(request *RecordFailureRequest) (*RecordFailureResponse, error)
+	RecordSuccess(request *RecordSuccessRequest) (*RecordSuccessResponse, error)
+	Unlock(request *UnlockRequest) (*UnlockResponse, error)
+}
+
+const ServiceProvider = "Lockout-Administrator"
+const UnlockService = ServiceProvider + ".Unlock"
+
+var SystemUserPermissions = []api.Permission{
+	UnlockService,
+}
+
+var CompanyAdminUserPermissions = make([]api.Permission, 0)
+
+var CompanyUserPermissions = make([]api.Permission, 0)
+
+var ClientAdminUserPermissions = make([]api.Permission, 0)
+
+var ClientUserPermissions = make([]api.Permission, 0)
+
+// CheckAttemptRequest checks if an attempt may be made for the
+// given user from the given ip address. Either may be blank.
+type CheckAttemptRequest struct {
+	UserId    id.Identifier
+	IPAddress string
+}
+
+type CheckAttemptResponse struct {
+	Allowed    bool
+	RetryAfter time.Duration
+}
+
+// RecordFailureRequest records a failed attempt for the given user
+// from the given ip address. Either may be blank.
+type RecordFailureRequest struct {
+	UserId    id.Identifier
+	IPAddress string
+}
+
+type RecordFailureResponse struct {
+	LockedOut bool
+}
+
+type RecordSuccessRequest struct {
+	UserId id.Identifier
+}
+
+type RecordSuccessResponse struct {
+}
+
+// UnlockRequest forgets the failed attempts of the given user and
+// from the given ip address. At least one of them must be given.
+type UnlockRequest struct {
+	Claims         claims.Claims
+	UserIdentifier identifier.Identifier
+	IPAddress      string
+}
+
+type UnlockResponse struct {
+}
@@ -0,0 +1,344 @@
+package basic
+
+import (
+	brainException "github.com/iot-my-world/brain/internal/exception"
+	"github.com/iot-my-world/brain/internal/log"
+	"github.com/iot-my-world/brain/pkg/clock"
+	emailGenerator "github.com/iot-my-world/brain/pkg/communication/email/generator"
+	lockoutEmailGenerator "github.com/iot-my-world/brain/pkg/communication/email/generator/lockout"
+	"github.com/iot-my-world/brain/pkg/communication/email/mailer"
+	brainRecordHandler "github.com/iot-my-world/brain/pkg/recordHandler"
+	"github.com/iot-my-world/brain/pkg/search/criterion"
+	exactTextCriterion "github.com/iot-my-world/brain/pkg/search/criterion/exact/text"
+	numberRangeCriterion "github.com/iot-my-world/brain/pkg/search/criterion/range/number"
+	"github.com/iot-my-world/brain/pkg/search/identifier/id"
+	"github.com/iot-my-world/brain/pkg/search/query"
+	humanUserLoginClaims "github.com/iot-my-world/brain/pkg/security/claims/login/user/human"
+	"github.com/iot-my-world/brain/pkg/security/lockout"
+	lockoutAdministrator "github.com/iot-my-world/brain/pkg/security/lockout/administrator"
+	lockoutAdministratorException "github.com/iot-my-world/brain/pkg/security/lockout/administrator/exception"
+	lockoutRecordHandler "github.com/iot-my-world/brain/pkg/security/lockout/recordHandler"
+	lockoutRecordHandlerException "github.com/iot-my-world/brain/pkg/security/lockout/recordHandler/exception"
+	humanUserRecordHandler "github.com/iot-my-world/brain/pkg/user/human/recordHandler"
+	"time"
+)
+
+type administrator struct {
+	lockoutRecordHandler   lockoutRecordHandler.RecordHandler
+	humanUserRecordHandler humanUserRecordHandler.RecordHandler
+	mailer                 mailer.Mailer
+	lockoutEmailGenerator  emailGenerator.Generator
+	clock                  clock.Clock
+	policy                 lockout.Policy
+	systemClaims           *humanUserLoginClaims.Login
+}
+
+func New(
+	lockoutRecordHandler lockoutRecordHandler.RecordHandler,
+	humanUserRecordHandler humanUserRecordHandler.RecordHandler,
+	mailer mailer.Mailer,
+	lockoutEmailGenerator emailGenerator.Generator,
+	clock clock.Clock,
+	policy lockout.Policy,
+	systemClaims *humanUserLoginClaims.Login,
+) lockoutAdministrator.Administrator {
+	return &administrator{
+		lockoutRecordHandler:   lockoutRecordHandler,
+		humanUserRecordHandler: humanUserRecordHandler,
+		mailer:                 mailer,
+		lockoutEmailGenerator:  lockoutEmailGenerator,
+		clock:                  clock,
+		policy:                 policy,
+		systemClaims:           systemClaims,
+	}
+}
+
+// subject is a subject of which failed attempts are tracked
+type subject struct {
+	subjectType lockout.SubjectType
+	subject     string
+}
+
+// subjects returns the non blank subjects of which
+// attempts are tracked for the given user and ip address
+func subjects(userId id.Identifier, ipAddress string) []subject {
+	result := make([]subject, 0)
+	if userId.Id != "" {
+		result = append(result, subject{subjectType: lockout.User, subject: userId.Id})
+	}
+	if ipAddress != "" {
+		result = append(result, subject{subjectType: lockout.IPAddress, subject: ipAddress})
+	}
+	return result
+}
+
+// retrieveLockout retrieves the lockout of the given subject.
+// If the subject has no lockout yet an empty one is returned.
+func (a *administrator) retrieveLockout(subjectToRetrieve subject) (*lockout.Lockout, error) {
+	collectResponse, err := a.lockoutRecordHandler.Collect(&lockoutRecordHandler.CollectRequest{
+		Claims:   a.systemClaims,
+		Criteria: subjectCriteria(subjectToRetrieve),
+		Query:    query.Query{Limit: 1},
+	})
+	if err != nil {
+		return nil, lockoutAdministratorException.LockoutRetrieval{Reasons: []string{err.Error()}}
+	}
+	if len(collectResponse.Records) > 0 {
+		return &collectResponse.Records[0], nil
+	}
+	return &lockout.Lockout{
+		SubjectType: subjectToRetrieve.subjectType,
+		Subject:     subjectToRetrieve.subject,
+	}, nil
+}
+
+// subjectCriteria returns the criteria identifying the lockout of the given subject
+func subjectCriteria(subjectToIdentify subject) []criterion.Criterion {
+	return []criterion.Criterion{
+		exactTextCriterion.Criterion{
+			Field: "subjectType",
+			Text:  string(subjectToIdentify.subjectType),
+		},
+		exactTextCriterion.Criterion{
+			Field: "subject",
+			Text:  subjectToIdentify.subject,
+		},
+	}
+}
+
+// fail atomically records a failed attempt at the given time on the lockout
+// of the given subject, creating it if there is none, and returns the lockout
+// as it is after the attempt
+func (a *administrator) fail(subjectToFail subject, now time.Time) (*lockout.Lockout, error) {
+	// forget the failed attempts of the subject if the last one was long enough ago
+	if _, err := a.lockoutRecordHandler.UpdateFields(&lockoutRecordHandler.UpdateFieldsRequest{
+		Claims: a.systemClaims,
+		Criteria: append(
+			subjectCriteria(subjectToFail),
+			numberRangeCriterion.Criterion{
+				Field: "lastFailedAttempt",
+				Start: numberRangeCriterion.RangeValue{Ignore: true},
+				End: numberRangeCriterion.RangeValue{
+					Number:    float64(now.Add(-a.policy.LockoutDuration).Unix()),
+					Inclusive: true,
+				},
+			},
+		),
+		Update: brainRecordHandler.FieldsUpdate{
+			Set: map[string]interface{}{"failedAttempts": 0},
+		},
+	}); err != nil {
+		switch err.(type) {
+		case lockoutRecordHandlerException.NotFound:
+		default:
+			return nil, lockoutAdministratorException.LockoutUpdate{Reasons: []string{err.Error()}}
+		}
+	}
+
+	failRequest := lockoutRecordHandler.UpdateFieldsRequest{
+		Claims:   a.systemClaims,
+		Criteria: subjectCriteria(subjectToFail),
+		Update: brainRecordHandler.FieldsUpdate{
+			Increment: map[string]interface{}{"failedAttempts": 1},
+			Set:       map[string]interface{}{"lastFailedAttempt": now.Unix()},
+		},
+		Upsert: true,
+	}
+	failResponse, err := a.lockoutRecordHandler.UpdateFields(&failRequest)
+	if err != nil {
+		switch err.(type) {
+		case lockoutRecordHandlerException.Duplicate:
+			// the lockout was created by a concurrent
+			// first failure, and so now it can be updated
+			failResponse, err = a.lockoutRecordHandler.UpdateFields(&failRequest)
+			if err != nil {
+				return nil, lockoutAdministratorException.LockoutUpdate{Reasons: []string{err.Error()}}
+			}
+		default:
+			return nil, lockoutAdministratorException.LockoutUpdate{Reasons: []string{err.Error()}}
+		}
+	}
+	failedLockout := failResponse.Lockout
+
+	// concurrent failures never shorten the time for which the subject is delayed
+	if lockedUntil, _ := a.policy.LockedUntil(failedLockout.FailedAttempts, now); !lockedUntil.IsZero() &&
+		lockedUntil.Unix() > failedLockout.LockedUntil {
+		lockedUntilResponse, err := a.lockoutRecordHandler.UpdateFields(&lockoutRecordHandler.UpdateFieldsRequest{
+			Claims: a.systemClaims,
+			Criteria: []criterion.Criterion{
+				exactTextCriterion.Criterion{
+					Field: "id",
+					Text:  failedLockout.Id,
+				},
+			},
+			Update: brainRecordHandler.FieldsUpdate{
+				Maximum: map[string]interface{}{"lockedUntil": lockedUntil.Unix()},
+			},
+		})
+		if err != nil {
+			return nil, lockoutAdministratorException.LockoutUpdate{Reasons: []string{err.Error()}}
+		}
+		failedLockout.LockedUntil = lockedUntilResponse.Lockout.LockedUntil
+	}
+
+	return &failedLockout, nil
+}
+
+func (a *administrator) CheckAttempt(request *lockoutAdministrator.CheckAttemptRequest) (*lockoutAdministrator.CheckAttemptResponse, error) {
+	now := a.clock.Now()
+
+	response := lockoutAdministrator.CheckAttemptResponse{
+		Allowed: true,
+	}
+	for _, subjectToCheck := range subjects(request.UserId, request.IPAddress) {
+		lockoutToCheck, err := a.retrieveLockout(subjectToCheck)
+		if err != nil {
+			log.Error(err.Error())
+			return nil, err
+		}
+		if allowed, retryAfter := a.policy.Allowed(*lockoutToCheck, now); !allowed {
+			response.Allowed = false
+			if retryAfter > response.RetryAfter {
+				response.RetryAfter = retryAfter
+			}
+		}
+	}
+
+	return &response, nil
+}
+
+func (a *administrator) RecordFailure(request *lockoutAdministrator.RecordFailureRequest) (*lockoutAdministrator.RecordFailureResponse, error) {
+	now := a.clock.Now()
+
+	response := lockoutAdministrator.RecordFailureResponse{}
+	for _, subjectToFail := range subjects(request.UserId, request.IPAddress) {
+		failedLockout, err := a.fail(subjectToFail, now)
+		if err != nil {
+			log.Error(err.Error())
+			return nil, err
+		}
+
+		if _, lockedOut := a.policy.LockedUntil(failedLockout.FailedAttempts, now); !lockedOut {
+			continue
+		}
+		response.LockedOut = true
+
+		// only the failure which reaches the threshold notifies the user,
+		// so that they are only notified once per lockout
+		if subjectToFail.subjectType == lockout.User && failedLockout.FailedAttempts == a.policy.LockoutThreshold {
+			// failure to notify the user does not fail the recording of the attempt
+			if err := a.notifyUser(request.UserId, time.Unix(failedLockout.LockedUntil, 0)); err != nil {
+				log.Error(err.Error())
+			}
+		}
+	}
+
+	return &response, nil
+}
+
+// notifyUser emails the user with the given id that
+// their account is locked until the given time
+func (a *administrator) notifyUser(userId id.Identifier, lockedUntil time.Time) error {
+	retrieveResponse, err := a.humanUserRecordHandler.Retrieve(&humanUserRecordHandler.RetrieveRequest{
+		Claims:     a.systemClaims,
+		Identifier: userId,
+	})
+	if err != nil {
+		return lockoutAdministratorException.UserRetrieval{Reasons: []string{err.Error()}}
+	}
+
+	generateResponse, err := a.lockoutEmailGenerator.Generate(&emailGenerator.GenerateRequest{
+		Data: lockoutEmailGenerator.Data{
+			User:        retrieveResponse.User,
+			LockedUntil: lockedUntil.UTC().Format(time.RFC1123),
+		},
+	})
+	if err != nil {
+		return lockoutAdministratorException.Notification{Reasons: []string{"generating email", err.Error()}}
+	}
+
+	if _, err := a.mailer.Send(&mailer.SendRequest{
+		Email: generateResponse.Email,
+	}); err != nil {
+		return lockoutAdministratorException.Notification{Reasons: []string{"sending email", err.Error()}}
+	}
+
+	return nil
+}
+
+func (a *administrator) RecordSuccess(request *lockoutAdministrator.RecordSuccessRequest) (*lockoutAdministrator.RecordSuccessResponse, error) {
+	if err := a.reset(subjects(request.UserId, "")); err != nil {
+		log.Error(err.Error())
+		return nil, err
+	}
+	return &lockoutAdministrator.RecordSuccessResponse{}, nil
+}
+
+func (a *administrator) ValidateUnlockRequest(request *lockoutAdministrator.UnlockRequest) error {
+	reasonsInvalid := make([]string, 0)
+
+	if request.Claims == nil {
+		reasonsInvalid = append(reasonsInvalid, "claims are nil")
+	}
+
+	if request.UserIdentifier == nil && request.IPAddress == "" {
+		reasonsInvalid = append(reasonsInvalid, "user identifier and ip address are both blank")
+	}
+
+	if len(reasonsInvalid) > 0 {
+		return brainException.RequestInvalid{Reasons: reasonsInvalid}
+	}
+	return nil
+}
+
+func (a *administrator) Unlock(request *lockoutAdministrator.UnlockRequest) (*lockoutAdministrator.UnlockResponse, error) {
+	if err := a.ValidateUnlockRequest(request); err != nil {
+		log.Error(err.Error())
+		return nil, err
+	}
+
+	var userId id.Identifier
+	if request.UserIdentifier != nil {
+		retrieveResponse, err := a.humanUserRecordHandler.Retrieve(&humanUserRecordHandler.RetrieveRequest{
+			Claims:     request.Claims,
+			Identifier: request.UserIdentifier,
+		})
+		if err != nil {
+			err = lockoutAdministratorException.UserRetrieval{Reasons: []string{err.Error()}}
+			log.Error(err.Error())
+			return nil, err
+		}
+		userId = id.Identifier{Id: retrieveResponse.User.Id}
+	}
+
+	if err := a.reset(subjects(userId, request.IPAddress)); err != nil {
+		log.Error(err.Error())
+		return nil, err
+	}
+
+	return &lockoutAdministrator.UnlockResponse{}, nil
+}
+
+// reset forgets the failed attempts of the given subjects
+func (a *administrator) reset(subjectsToReset []subject) error {
+	for _, subjectToReset := range subjectsToReset {
+		if _, err := a.lockoutRecordHandler.UpdateFields(&lockoutRecordHandler.UpdateFieldsRequest{
+			Claims:   a.systemClaims,
+			Criteria: subjectCriteria(subjectToReset),
+			Update: brainRecordHandler.FieldsUpdate{
+				Set: map[string]interface{}{
+					"failedAttempts":    0,
+					"lastFailedAttempt": 0,
+					"lockedUntil":       0,
+				},
+			},
+		}); err != nil {
+			switch err.(type) {
+			case lockoutRecordHandlerException.NotFound:
+			default:
+				return lockoutAdministratorException.LockoutUpdate{Reasons: []string{err.Error()}}
+			}
+		}
+	}
+	return nil
+}
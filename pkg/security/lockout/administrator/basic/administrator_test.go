@@ -0,0 +1,408 @@
+package basic
+
+import (
+	"errors"
+	"github.com/iot-my-world/brain/pkg/communication/email"
+	emailGenerator "github.com/iot-my-world/brain/pkg/communication/email/generator"
+	lockoutEmailGenerator "github.com/iot-my-world/brain/pkg/communication/email/generator/lockout"
+	"github.com/iot-my-world/brain/pkg/communication/email/mailer"
+	"github.com/iot-my-world/brain/pkg/party"
+	"github.com/iot-my-world/brain/pkg/search/criterion"
+	exactTextCriterion "github.com/iot-my-world/brain/pkg/search/criterion/exact/text"
+	numberRangeCriterion "github.com/iot-my-world/brain/pkg/search/criterion/range/number"
+	"github.com/iot-my-world/brain/pkg/search/identifier/id"
+	humanUserLoginClaims "github.com/iot-my-world/brain/pkg/security/claims/login/user/human"
+	"github.com/iot-my-world/brain/pkg/security/lockout"
+	lockoutAdministrator "github.com/iot-my-world/brain/pkg/security/lockout/administrator"
+	lockoutRecordHandler "github.com/iot-my-world/brain/pkg/security/lockout/recordHandler"
+	lockoutRecordHandlerException "github.com/iot-my-world/brain/pkg/security/lockout/recordHandler/exception"
+	humanUser "github.com/iot-my-world/brain/pkg/user/human"
+	humanUserRecordHandler "github.com/iot-my-world/brain/pkg/user/human/recordHandler"
+	"github.com/stretchr/testify/assert"
+	"strconv"
+	"testing"
+	"time"
+)
+
+// fakeClock is a clock which is only moved on by the test
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	return c.now
+}
+
+func (c *fakeClock) Advance(duration time.Duration) {
+	c.now = c.now.Add(duration)
+}
+
+// fakeLockoutRecordHandler stores lockouts in memory
+type fakeLockoutRecordHandler struct {
+	lockoutRecordHandler.RecordHandler
+	lockouts map[string]lockout.Lockout
+}
+
+func (r *fakeLockoutRecordHandler) matches(l lockout.Lockout, criteria []criterion.Criterion) bool {
+	for _, crit := range criteria {
+		switch typedCriterion := crit.(type) {
+		case exactTextCriterion.Criterion:
+			switch typedCriterion.Field {
+			case "id":
+				if l.Id != typedCriterion.Text {
+					return false
+				}
+			case "subjectType":
+				if string(l.SubjectType) != typedCriterion.Text {
+					return false
+				}
+			case "subject":
+				if l.Subject != typedCriterion.Text {
+					return false
+				}
+			}
+		case numberRangeCriterion.Criterion:
+			// only an inclusive end on the last failed attempt is used
+			if float64(l.LastFailedAttempt) > typedCriterion.End.Number {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func (r *fakeLockoutRecordHandler) Collect(request *lockoutRecordHandler.CollectRequest) (*lockoutRecordHandler.CollectResponse, error) {
+	records := make([]lockout.Lockout, 0)
+	for _, l := range r.lockouts {
+		if r.matches(l, request.Criteria) {
+			records = append(records, l)
+		}
+	}
+	return &lockoutRecordHandler.CollectResponse{Records: records, Total: len(records)}, nil
+}
+
+// field returns the lockout field with the given name
+func field(l *lockout.Lockout, name string) *int64 {
+	switch name {
+	case "lastFailedAttempt":
+		return &l.LastFailedAttempt
+	case "lockedUntil":
+		return &l.LockedUntil
+	default:
+		panic("unsupported field " + name)
+	}
+}
+
+func (r *fakeLockoutRecordHandler) UpdateFields(request *lockoutRecordHandler.UpdateFieldsRequest) (*lockoutRecordHandler.UpdateFieldsResponse, error) {
+	var l lockout.Lockout
+	found := false
+	for _, existing := range r.lockouts {
+		if r.matches(existing, request.Criteria) {
+			l, found = existing, true
+			break
+		}
+	}
+	if !found {
+		if !request.Upsert {
+			return nil, lockoutRecordHandlerException.NotFound{}
+		}
+		l.Id = strconv.Itoa(len(r.lockouts) + 1)
+		for _, crit := range request.Criteria {
+			textCriterion := crit.(exactTextCriterion.Criterion)
+			switch textCriterion.Field {
+			case "subjectType":
+				l.SubjectType = lockout.SubjectType(textCriterion.Text)
+			case "subject":
+				l.Subject = textCriterion.Text
+			}
+		}
+	}
+
+	for name, value := range request.Update.Set {
+		if name == "failedAttempts" {
+			l.FailedAttempts = value.(int)
+		} else {
+			*field(&l, name) = toInt64(value)
+		}
+	}
+	for name, value := range request.Update.Increment {
+		if name != "failedAttempts" {
+			panic("unsupported field " + name)
+		}
+		l.FailedAttempts += value.(int)
+	}
+	for name, value := range request.Update.Maximum {
+		if maximum := toInt64(value); maximum > *field(&l, name) {
+			*field(&l, name) = maximum
+		}
+	}
+
+	r.lockouts[l.Id] = l
+	return &lockoutRecordHandler.UpdateFieldsResponse{Lockout: l}, nil
+}
+
+func toInt64(value interface{}) int64 {
+	switch typedValue := value.(type) {
+	case int:
+		return int64(typedValue)
+	case int64:
+		return typedValue
+	default:
+		panic("unsupported value")
+	}
+}
+
+// fakeHumanUserRecordHandler retrieves users from memory by id
+type fakeHumanUserRecordHandler struct {
+	humanUserRecordHandler.RecordHandler
+	users map[string]humanUser.User
+}
+
+func (r *fakeHumanUserRecordHandler) Retrieve(request *humanUserRecordHandler.RetrieveRequest) (*humanUserRecordHandler.RetrieveResponse, error) {
+	user, found := r.users[request.Identifier.(id.Identifier).Id]
+	if !found {
+		return nil, errors.New("not found")
+	}
+	return &humanUserRecordHandler.RetrieveResponse{User: user}, nil
+}
+
+// fakeEmailGenerator generates emails without a template
+type fakeEmailGenerator struct {
+}
+
+func (g *fakeEmailGenerator) Generate(request *emailGenerator.GenerateRequest) (*emailGenerator.GenerateResponse, error) {
+	return &emailGenerator.GenerateResponse{
+		Email: email.Email{
+			Body:    request.Data.(lockoutEmailGenerator.Data).LockedUntil,
+			Details: request.Data.Details(),
+		},
+	}, nil
+}
+
+// fakeMailer keeps the emails sent
+type fakeMailer struct {
+	sent []email.Email
+}
+
+func (m *fakeMailer) Send(request *mailer.SendRequest) (*mailer.SendResponse, error) {
+	m.sent = append(m.sent, request.Email)
+	return &mailer.SendResponse{}, nil
+}
+
+var testPolicy = lockout.Policy{
+	DelayThreshold:   3,
+	BaseDelay:        time.Second,
+	LockoutThreshold: 5,
+	LockoutDuration:  15 * time.Minute,
+}
+
+var testUser = humanUser.User{
+	Id:           "user-1",
+	Name:         "Test",
+	EmailAddress: "test@example.com",
+}
+
+type testContext struct {
+	administrator lockoutAdministrator.Administrator
+	clock         *fakeClock
+	mailer        *fakeMailer
+}
+
+func newTestContext() testContext {
+	clock := &fakeClock{now: time.Date(2019, 6, 1, 12, 0, 0, 0, time.UTC)}
+	mailer := &fakeMailer{}
+	return testContext{
+		administrator: New(
+			&fakeLockoutRecordHandler{lockouts: make(map[string]lockout.Lockout)},
+			&fakeHumanUserRecordHandler{users: map[string]humanUser.User{testUser.Id: testUser}},
+			mailer,
+			&fakeEmailGenerator{},
+			clock,
+			testPolicy,
+			&humanUserLoginClaims.Login{PartyType: party.System},
+		),
+		clock:  clock,
+		mailer: mailer,
+	}
+}
+
+func (c testContext) fail(t *testing.T, userId id.Identifier, ipAddress string) bool {
+	response, err := c.administrator.RecordFailure(&lockoutAdministrator.RecordFailureRequest{
+		UserId:    userId,
+		IPAddress: ipAddress,
+	})
+	if err != nil {
+		t.Fatalf("error recording failure: %s", err.Error())
+	}
+	return response.LockedOut
+}
+
+func (c testContext) check(t *testing.T, userId id.Identifier, ipAddress string) (bool, time.Duration) {
+	response, err := c.administrator.CheckAttempt(&lockoutAdministrator.CheckAttemptRequest{
+		UserId:    userId,
+		IPAddress: ipAddress,
+	})
+	if err != nil {
+		t.Fatalf("error checking attempt: %s", err.Error())
+	}
+	return response.Allowed, response.RetryAfter
+}
+
+var userId = id.Identifier{Id: testUser.Id}
+
+func TestProgressiveDelay(t *testing.T) {
+	c := newTestContext()
+
+	c.fail(t, userId, "")
+	c.fail(t, userId, "")
+	allowed, _ := c.check(t, userId, "")
+	assert.True(t, allowed, "allowed before delay threshold")
+
+	c.fail(t, userId, "")
+	allowed, retryAfter := c.check(t, userId, "")
+	assert.False(t, allowed, "allowed at delay threshold")
+	assert.Equal(t, time.Second, retryAfter, "retry after")
+
+	c.clock.Advance(time.Second)
+	allowed, _ = c.check(t, userId, "")
+	assert.True(t, allowed, "allowed after delay")
+
+	c.fail(t, userId, "")
+	_, retryAfter = c.check(t, userId, "")
+	assert.Equal(t, 2*time.Second, retryAfter, "retry after doubled")
+}
+
+func TestLockoutNotifiesUserOnce(t *testing.T) {
+	c := newTestContext()
+
+	for attempt := 1; attempt < testPolicy.LockoutThreshold; attempt++ {
+		assert.False(t, c.fail(t, userId, ""), "locked out after attempt %d", attempt)
+	}
+	assert.True(t, c.fail(t, userId, ""), "locked out")
+
+	allowed, retryAfter := c.check(t, userId, "")
+	assert.False(t, allowed, "allowed during lockout")
+	assert.Equal(t, testPolicy.LockoutDuration, retryAfter, "retry after")
+
+	if assert.Len(t, c.mailer.sent, 1, "emails sent") {
+		assert.Equal(t, testUser.EmailAddress, c.mailer.sent[0].Details.To[0].Address, "email recipient")
+	}
+
+	// a further failure while locked out does not notify the user again
+	c.fail(t, userId, "")
+	assert.Len(t, c.mailer.sent, 1, "emails sent")
+
+	c.clock.Advance(testPolicy.LockoutDuration)
+	allowed, _ = c.check(t, userId, "")
+	assert.True(t, allowed, "allowed after lockout")
+}
+
+func TestIPAddressTrackedSeparately(t *testing.T) {
+	c := newTestContext()
+
+	// failures for unknown users are tracked against the ip address only
+	for attempt := 1; attempt <= testPolicy.LockoutThreshold; attempt++ {
+		c.fail(t, id.Identifier{}, "10.0.0.1")
+	}
+
+	allowed, _ := c.check(t, id.Identifier{}, "10.0.0.1")
+	assert.False(t, allowed, "allowed from locked out ip address")
+
+	allowed, _ = c.check(t, userId, "10.0.0.2")
+	assert.True(t, allowed, "allowed for user from other ip address")
+
+	allowed, _ = c.check(t, userId, "10.0.0.1")
+	assert.False(t, allowed, "allowed for user from locked out ip address")
+
+	assert.Len(t, c.mailer.sent, 0, "emails sent")
+}
+
+func TestSuccessResetsUser(t *testing.T) {
+	c := newTestContext()
+
+	for attempt := 1; attempt <= testPolicy.DelayThreshold; attempt++ {
+		c.fail(t, userId, "")
+	}
+	allowed, _ := c.check(t, userId, "")
+	assert.False(t, allowed, "allowed after delay threshold")
+
+	if _, err := c.administrator.RecordSuccess(&lockoutAdministrator.RecordSuccessRequest{
+		UserId: userId,
+	}); err != nil {
+		t.Fatalf("error recording success: %s", err.Error())
+	}
+
+	allowed, _ = c.check(t, userId, "")
+	assert.True(t, allowed, "allowed after success")
+}
+
+func TestUnlock(t *testing.T) {
+	c := newTestContext()
+
+	for attempt := 1; attempt <= testPolicy.LockoutThreshold; attempt++ {
+		c.fail(t, userId, "10.0.0.1")
+	}
+
+	if _, err := c.administrator.Unlock(&lockoutAdministrator.UnlockRequest{}); err == nil {
+		t.Errorf("expected error unlocking without user identifier or ip address")
+	}
+
+	if _, err := c.administrator.Unlock(&lockoutAdministrator.UnlockRequest{
+		Claims:         &humanUserLoginClaims.Login{PartyType: party.System},
+		UserIdentifier: userId,
+		IPAddress:      "10.0.0.1",
+	}); err != nil {
+		t.Fatalf("error unlocking: %s", err.Error())
+	}
+
+	allowed, _ := c.check(t, userId, "10.0.0.1")
+	assert.True(t, allowed, "allowed after unlock")
+}
+
+func TestFailedAttemptsForgotten(t *testing.T) {
+	c := newTestContext()
+
+	for attempt := 1; attempt < testPolicy.LockoutThreshold; attempt++ {
+		c.fail(t, userId, "")
+	}
+
+	c.clock.Advance(testPolicy.LockoutDuration)
+	assert.False(t, c.fail(t, userId, ""), "locked out after failures forgotten")
+}
+
+func TestFailedAttemptsRememberedWithinLockoutDuration(t *testing.T) {
+	c := newTestContext()
+
+	for attempt := 1; attempt < testPolicy.LockoutThreshold; attempt++ {
+		c.fail(t, userId, "")
+	}
+
+	c.clock.Advance(testPolicy.LockoutDuration - time.Second)
+	assert.True(t, c.fail(t, userId, ""), "locked out")
+}
+
+func TestDelayCappedAtLockoutDuration(t *testing.T) {
+	c := newTestContext()
+	c.administrator = New(
+		&fakeLockoutRecordHandler{lockouts: make(map[string]lockout.Lockout)},
+		&fakeHumanUserRecordHandler{},
+		c.mailer,
+		&fakeEmailGenerator{},
+		c.clock,
+		lockout.Policy{
+			DelayThreshold:   1,
+			BaseDelay:        time.Minute,
+			LockoutThreshold: 100,
+			LockoutDuration:  10 * time.Minute,
+		},
+		&humanUserLoginClaims.Login{PartyType: party.System},
+	)
+
+	for attempt := 1; attempt < 10; attempt++ {
+		c.fail(t, userId, "")
+		_, retryAfter := c.check(t, userId, "")
+		assert.True(t, retryAfter <= 10*time.Minute, "delay %s exceeds lockout duration", retryAfter)
+		// attempts are made as soon as they are allowed
+		c.clock.Advance(retryAfter)
+	}
+}
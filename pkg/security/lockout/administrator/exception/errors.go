@@ -0,0 +1,35 @@
+package exception
+
+import "strings"
+
+type LockoutRetrieval struct {
+	Reasons []string
+}
+
+func (e LockoutRetrieval) Error() string {
+	return "error retrieving lockout: " + strings.Join(e.Reasons, "; ")
+}
+
+type LockoutUpdate struct {
+	Reasons []string
+}
+
+func (e LockoutUpdate) Error() string {
+	return "error updating lockout: " + strings.Join(e.Reasons, "; ")
+}
+
+type UserRetrieval struct {
+	Reasons []string
+}
+
+func (e UserRetrieval) Error() string {
+	return "error retrieving user: " + strings.Join(e.Reasons, "; ")
+}
+
+type Notification struct {
+	Reasons []string
+}
+
+func (e Notification) Error() string {
+	return "error notifying user of lockout: " + strings.Join(e.Reasons, "; ")
+}
@@ -0,0 +1,78 @@
+package jsonRpc
+
+import (
+	brainException "github.com/iot-my-world/brain/internal/exception"
+	"github.com/iot-my-world/brain/internal/log"
+	jsonRpcClient "github.com/iot-my-world/brain/pkg/api/jsonRpc/client"
+	wrappedIdentifier "github.com/iot-my-world/brain/pkg/search/identifier/wrapped"
+	lockoutAdministrator "github.com/iot-my-world/brain/pkg/security/lockout/administrator"
+	lockoutAdministratorJsonRpcAdaptor "github.com/iot-my-world/brain/pkg/security/lockout/administrator/adaptor/jsonRpc"
+)
+
+type administrator struct {
+	jsonRpcClient jsonRpcClient.Client
+}
+
+func New(
+	jsonRpcClient jsonRpcClient.Client,
+) lockoutAdministrator.Administrator {
+	return &administrator{
+		jsonRpcClient: jsonRpcClient,
+	}
+}
+
+func (a *administrator) CheckAttempt(request *lockoutAdministrator.CheckAttemptRequest) (*lockoutAdministrator.CheckAttemptResponse, error) {
+	return nil, brainException.NotImplemented{}
+}
+
+func (a *administrator) RecordFailure(request *lockoutAdministrator.RecordFailureRequest) (*lockoutAdministrator.RecordFailureResponse, error) {
+	return nil, brainException.NotImplemented{}
+}
+
+func (a *administrator) RecordSuccess(request *lockoutAdministrator.RecordSuccessRequest) (*lockoutAdministrator.RecordSuccessResponse, error) {
+	return nil, brainException.NotImplemented{}
+}
+
+func (a *administrator) ValidateUnlockRequest(request *lockoutAdministrator.UnlockRequest) error {
+	reasonsInvalid := make([]string, 0)
+
+	if request.UserIdentifier == nil && request.IPAddress == "" {
+		reasonsInvalid = append(reasonsInvalid, "user identifier and ip address are both blank")
+	}
+
+	if len(reasonsInvalid) > 0 {
+		return brainException.RequestInvalid{Reasons: reasonsInvalid}
+	}
+	return nil
+}
+
+func (a *administrator) Unlock(request *lockoutAdministrator.UnlockRequest) (*lockoutAdministrator.UnlockResponse, error) {
+	if err := a.ValidateUnlockRequest(request); err != nil {
+		log.Error(err.Error())
+		return nil, err
+	}
+
+	unlockRequest := lockoutAdministratorJsonRpcAdaptor.UnlockRequest{
+		IPAddress: request.IPAddress,
+	}
+	if request.UserIdentifier != nil {
+		wrappedUserIdentifier, err := wrappedIdentifier.Wrap(request.UserIdentifier)
+		if err != nil {
+			log.Error(err.Error())
+			return nil, err
+		}
+		unlockRequest.WrappedUserIdentifier = wrappedUserIdentifier
+	}
+
+	unlockResponse := lockoutAdministratorJsonRpcAdaptor.UnlockResponse{}
+	if err := a.jsonRpcClient.JsonRpcRequest(
+		lockoutAdministrator.UnlockService,
+		unlockRequest,
+		&unlockResponse,
+	); err != nil {
+		log.Error(err.Error())
+		return nil, err
+	}
+
+	return &lockoutAdministrator.UnlockResponse{}, nil
+}
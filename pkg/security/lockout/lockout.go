@@ -0,0 +1,77 @@
+package lockout
+
+import "time"
+
+type SubjectType string
+
+const User SubjectType = "User"
+const IPAddress SubjectType = "IPAddress"
+
+// Lockout tracks the failed log in attempts
+// made for a user or from an ip address
+type Lockout struct {
+	Id string `json:"id" bson:"id"`
+
+	// Subject is the id of the user or the ip address
+	SubjectType SubjectType `json:"subjectType" bson:"subjectType"`
+	Subject     string      `json:"subject" bson:"subject"`
+
+	FailedAttempts    int   `json:"failedAttempts" bson:"failedAttempts"`
+	LastFailedAttempt int64 `json:"lastFailedAttempt" bson:"lastFailedAttempt"`
+
+	// LockedUntil is the time before which no further attempts are allowed
+	LockedUntil int64 `json:"lockedUntil" bson:"lockedUntil"`
+}
+
+func (l *Lockout) SetId(id string) {
+	l.Id = id
+}
+
+// Policy determines how failed attempts are delayed and locked out
+type Policy struct {
+	// DelayThreshold is the number of failed attempts after
+	// which each further attempt is delayed
+	DelayThreshold int
+
+	// BaseDelay is the delay after the DelayThreshold is reached,
+	// which doubles with every further failed attempt
+	BaseDelay time.Duration
+
+	// LockoutThreshold is the number of failed attempts
+	// after which the subject is locked out
+	LockoutThreshold int
+
+	// LockoutDuration is how long a subject is locked out for.
+	// Failed attempts are also forgotten this long after the last one.
+	LockoutDuration time.Duration
+}
+
+// Allowed returns true if an attempt may be made for the given lockout at
+// the given time, and otherwise the time to wait until one is allowed
+func (p Policy) Allowed(l Lockout, now time.Time) (bool, time.Duration) {
+	lockedUntil := time.Unix(l.LockedUntil, 0)
+	if now.Before(lockedUntil) {
+		return false, lockedUntil.Sub(now)
+	}
+	return true, 0
+}
+
+// LockedUntil returns the time before which no further attempts are
+// allowed after the given number of failed attempts, the last of which
+// was made at the given time, and true if the subject is locked out.
+// The zero time is returned if further attempts are not delayed.
+func (p Policy) LockedUntil(failedAttempts int, lastFailedAttempt time.Time) (time.Time, bool) {
+	if failedAttempts >= p.LockoutThreshold {
+		return lastFailedAttempt.Add(p.LockoutDuration), true
+	}
+
+	if failedAttempts >= p.DelayThreshold {
+		delay := p.BaseDelay << uint(failedAttempts-p.DelayThreshold)
+		if delay > p.LockoutDuration || delay <= 0 {
+			delay = p.LockoutDuration
+		}
+		return lastFailedAttempt.Add(delay), false
+	}
+
+	return time.Time{}, false
+}
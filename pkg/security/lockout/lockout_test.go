@@ -0,0 +1,65 @@
+package lockout
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+var testPolicy = Policy{
+	DelayThreshold:   3,
+	BaseDelay:        time.Second,
+	LockoutThreshold: 6,
+	LockoutDuration:  15 * time.Minute,
+}
+
+var start = time.Date(2019, 6, 1, 12, 0, 0, 0, time.UTC)
+
+func TestPolicyLockedUntil(t *testing.T) {
+	tests := []struct {
+		failedAttempts      int
+		expectedLockedUntil time.Time
+		expectedLockedOut   bool
+	}{
+		// attempts before the delay threshold are not delayed
+		{failedAttempts: 1},
+		{failedAttempts: 2},
+		// thereafter each attempt doubles the delay
+		{failedAttempts: 3, expectedLockedUntil: start.Add(time.Second)},
+		{failedAttempts: 4, expectedLockedUntil: start.Add(2 * time.Second)},
+		{failedAttempts: 5, expectedLockedUntil: start.Add(4 * time.Second)},
+		// until the subject is locked out
+		{failedAttempts: 6, expectedLockedUntil: start.Add(testPolicy.LockoutDuration), expectedLockedOut: true},
+		{failedAttempts: 7, expectedLockedUntil: start.Add(testPolicy.LockoutDuration), expectedLockedOut: true},
+	}
+
+	for _, test := range tests {
+		lockedUntil, lockedOut := testPolicy.LockedUntil(test.failedAttempts, start)
+		assert.Equal(t, test.expectedLockedUntil, lockedUntil, "locked until after %d attempts", test.failedAttempts)
+		assert.Equal(t, test.expectedLockedOut, lockedOut, "locked out after %d attempts", test.failedAttempts)
+	}
+}
+
+func TestPolicyDelayCappedAtLockoutDuration(t *testing.T) {
+	policy := Policy{
+		DelayThreshold:   1,
+		BaseDelay:        time.Minute,
+		LockoutThreshold: 100,
+		LockoutDuration:  10 * time.Minute,
+	}
+	for failedAttempts := 1; failedAttempts < policy.LockoutThreshold; failedAttempts++ {
+		lockedUntil, _ := policy.LockedUntil(failedAttempts, start)
+		assert.True(t, lockedUntil.Sub(start) <= policy.LockoutDuration, "delay %s exceeds lockout duration", lockedUntil.Sub(start))
+	}
+}
+
+func TestPolicyAllowed(t *testing.T) {
+	l := Lockout{LockedUntil: start.Add(time.Minute).Unix()}
+
+	allowed, retryAfter := testPolicy.Allowed(l, start)
+	assert.False(t, allowed, "allowed before locked until")
+	assert.Equal(t, time.Minute, retryAfter, "retry after")
+
+	allowed, _ = testPolicy.Allowed(l, start.Add(time.Minute))
+	assert.True(t, allowed, "allowed at locked until")
+}
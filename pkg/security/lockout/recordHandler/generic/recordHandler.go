@@ -0,0 +1,159 @@
+package lockoutRecordHandler
+
+import (
+	brainException "github.com/iot-my-world/brain/internal/exception"
+	brainRecordHandler "github.com/iot-my-world/brain/pkg/recordHandler"
+	brainRecordHandlerException "github.com/iot-my-world/brain/pkg/recordHandler/exception"
+	"github.com/iot-my-world/brain/pkg/security/lockout"
+	lockoutRecordHandler "github.com/iot-my-world/brain/pkg/security/lockout/recordHandler"
+	lockoutRecordHandlerException "github.com/iot-my-world/brain/pkg/security/lockout/recordHandler/exception"
+)
+
+type RecordHandler struct {
+	lockoutRecordHandler brainRecordHandler.RecordHandler
+}
+
+func New(
+	brainLockoutRecordHandler brainRecordHandler.RecordHandler,
+) lockoutRecordHandler.RecordHandler {
+
+	return &RecordHandler{
+		lockoutRecordHandler: brainLockoutRecordHandler,
+	}
+}
+
+type CreateRequest struct {
+	Lockout lockout.Lockout
+}
+
+type CreateResponse struct {
+	Lockout lockout.Lockout
+}
+
+func (r *RecordHandler) ValidateCreateRequest(request *lockoutRecordHandler.CreateRequest) error {
+	reasonsInvalid := make([]string, 0)
+
+	if len(reasonsInvalid) > 0 {
+		return brainException.RequestInvalid{Reasons: reasonsInvalid}
+	}
+
+	return nil
+}
+
+func (r *RecordHandler) Create(request *lockoutRecordHandler.CreateRequest) (*lockoutRecordHandler.CreateResponse, error) {
+	if err := r.ValidateCreateRequest(request); err != nil {
+		return nil, err
+	}
+
+	createResponse := brainRecordHandler.CreateResponse{}
+	if err := r.lockoutRecordHandler.Create(&brainRecordHandler.CreateRequest{
+		Entity: &request.Lockout,
+	}, &createResponse); err != nil {
+		return nil, lockoutRecordHandlerException.Create{Reasons: []string{err.Error()}}
+	}
+	createdLockout, ok := createResponse.Entity.(*lockout.Lockout)
+	if !ok {
+		return nil, lockoutRecordHandlerException.Create{Reasons: []string{"could not cast created entity to lockout"}}
+	}
+
+	return &lockoutRecordHandler.CreateResponse{
+		Lockout: *createdLockout,
+	}, nil
+}
+
+func (r *RecordHandler) Retrieve(request *lockoutRecordHandler.RetrieveRequest) (*lockoutRecordHandler.RetrieveResponse, error) {
+	retrievedLockout := lockout.Lockout{}
+	retrieveResponse := brainRecordHandler.RetrieveResponse{
+		Entity: &retrievedLockout,
+	}
+	if err := r.lockoutRecordHandler.Retrieve(&brainRecordHandler.RetrieveRequest{
+		Claims:     request.Claims,
+		Identifier: request.Identifier,
+	}, &retrieveResponse); err != nil {
+		switch err.(type) {
+		case brainRecordHandlerException.NotFound:
+			return nil, lockoutRecordHandlerException.NotFound{}
+		default:
+			return nil, err
+		}
+	}
+
+	return &lockoutRecordHandler.RetrieveResponse{
+		Lockout: retrievedLockout,
+	}, nil
+}
+
+func (r *RecordHandler) Update(request *lockoutRecordHandler.UpdateRequest) (*lockoutRecordHandler.UpdateResponse, error) {
+	updateResponse := brainRecordHandler.UpdateResponse{}
+	if err := r.lockoutRecordHandler.Update(&brainRecordHandler.UpdateRequest{
+		Claims:     request.Claims,
+		Identifier: request.Identifier,
+		Entity:     &request.Lockout,
+	}, &updateResponse); err != nil {
+		return nil, lockoutRecordHandlerException.Update{Reasons: []string{err.Error()}}
+	}
+
+	return &lockoutRecordHandler.UpdateResponse{}, nil
+}
+
+func (r *RecordHandler) Delete(request *lockoutRecordHandler.DeleteRequest) (*lockoutRecordHandler.DeleteResponse, error) {
+	deleteResponse := brainRecordHandler.DeleteResponse{}
+	if err := r.lockoutRecordHandler.Delete(&brainRecordHandler.DeleteRequest{
+		Claims:     request.Claims,
+		Identifier: request.Identifier,
+	}, &deleteResponse); err != nil {
+		return nil, lockoutRecordHandlerException.Delete{Reasons: []string{err.Error()}}
+	}
+
+	return &lockoutRecordHandler.DeleteResponse{}, nil
+}
+
+func (r *RecordHandler) Collect(request *lockoutRecordHandler.CollectRequest) (*lockoutRecordHandler.CollectResponse, error) {
+	var collectedLockout []lockout.Lockout
+	collectResponse := brainRecordHandler.CollectResponse{
+		Records: &collectedLockout,
+	}
+	err := r.lockoutRecordHandler.Collect(&brainRecordHandler.CollectRequest{
+		Claims:   request.Claims,
+		Criteria: request.Criteria,
+		Query:    request.Query,
+	}, &collectResponse)
+	if err != nil {
+		return nil, lockoutRecordHandlerException.Collect{Reasons: []string{err.Error()}}
+	}
+
+	if collectedLockout == nil {
+		collectedLockout = make([]lockout.Lockout, 0)
+	}
+
+	return &lockoutRecordHandler.CollectResponse{
+		Records: collectedLockout,
+		Total:   collectResponse.Total,
+	}, nil
+}
+
+func (r *RecordHandler) UpdateFields(request *lockoutRecordHandler.UpdateFieldsRequest) (*lockoutRecordHandler.UpdateFieldsResponse, error) {
+	updatedLockout := lockout.Lockout{}
+	updateFieldsResponse := brainRecordHandler.UpdateFieldsResponse{
+		Entity: &updatedLockout,
+	}
+	if err := r.lockoutRecordHandler.UpdateFields(&brainRecordHandler.UpdateFieldsRequest{
+		Claims:   request.Claims,
+		Criteria: request.Criteria,
+		Update:   request.Update,
+		Upsert:   request.Upsert,
+	}, &updateFieldsResponse); err != nil {
+		switch err.(type) {
+		case brainRecordHandlerException.NotFound:
+			return nil, lockoutRecordHandlerException.NotFound{}
+		case brainRecordHandlerException.Duplicate:
+			return nil, lockoutRecordHandlerException.Duplicate{Reasons: []string{err.Error()}}
+		default:
+			return nil, lockoutRecordHandlerException.Update{Reasons: []string{err.Error()}}
+		}
+	}
+
+	return &lockoutRecordHandler.UpdateFieldsResponse{
+		Lockout: updatedLockout,
+	}, nil
+}
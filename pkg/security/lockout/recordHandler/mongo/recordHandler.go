@@ -0,0 +1,38 @@
+package mongo
+
+import (
+	brainMongoRecordHandler "github.com/iot-my-world/brain/pkg/recordHandler/mongo"
+	"github.com/iot-my-world/brain/pkg/security/claims"
+	"github.com/iot-my-world/brain/pkg/security/lockout"
+	lockoutRecordHandler "github.com/iot-my-world/brain/pkg/security/lockout/recordHandler"
+	lockoutGenericRecordHandler "github.com/iot-my-world/brain/pkg/security/lockout/recordHandler/generic"
+	"gopkg.in/mgo.v2"
+)
+
+func New(
+	mongoSession *mgo.Session,
+	databaseName string,
+	collectionName string,
+) lockoutRecordHandler.RecordHandler {
+	mongoRecordHandler := brainMongoRecordHandler.New(
+		mongoSession,
+		databaseName,
+		collectionName,
+		[]mgo.Index{
+			{
+				Key:    []string{"id"},
+				Unique: true,
+			},
+			{
+				Key:    []string{"subjectType", "subject"},
+				Unique: true,
+			},
+		},
+		lockout.IsValidIdentifier,
+		claims.ContextualiseFilter,
+	)
+
+	return lockoutGenericRecordHandler.New(
+		mongoRecordHandler,
+	)
+}
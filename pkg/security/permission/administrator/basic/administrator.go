@@ -3,6 +3,8 @@ package basic
 import (
 	"fmt"
 	brainException "github.com/iot-my-world/brain/internal/exception"
+	"github.com/iot-my-world/brain/pkg/search/identifier"
+	"github.com/iot-my-world/brain/pkg/search/identifier/id"
 	"github.com/iot-my-world/brain/pkg/search/identifier/name"
 	"github.com/iot-my-world/brain/pkg/security/claims/login/user/api"
 	"github.com/iot-my-world/brain/pkg/security/claims/login/user/human"
@@ -10,6 +12,7 @@ import (
 	"github.com/iot-my-world/brain/pkg/security/permission/administrator/exception"
 	api2 "github.com/iot-my-world/brain/pkg/security/permission/api"
 	view2 "github.com/iot-my-world/brain/pkg/security/permission/view"
+	"github.com/iot-my-world/brain/pkg/security/role"
 	"github.com/iot-my-world/brain/pkg/security/role/recordHandler"
 	apiUserRecordHandler "github.com/iot-my-world/brain/pkg/user/api/recordHandler"
 	humanUser "github.com/iot-my-world/brain/pkg/user/human"
@@ -34,6 +37,43 @@ func New(
 	}
 }
 
+// roleGrantsPermissions returns true if the given role grants its
+// permissions to users of the party with the given id
+func roleGrantsPermissions(roleToCheck role.Role, partyId id.Identifier) bool {
+	return !roleToCheck.Custom() || roleToCheck.OwnerId.Id == partyId.Id
+}
+
+// usersRoles retrieves the system roles with the given names and the custom
+// roles with the given ids which grant their permissions to users of the
+// party with the given id
+func (a *administrator) usersRoles(roleNames []string, customRoleIds []id.Identifier, partyId id.Identifier) ([]role.Role, error) {
+	roleIdentifiers := make([]identifier.Identifier, 0)
+	for _, roleName := range roleNames {
+		roleIdentifiers = append(roleIdentifiers, name.Identifier{Name: roleName})
+	}
+	for _, customRoleId := range customRoleIds {
+		roleIdentifiers = append(roleIdentifiers, customRoleId)
+	}
+
+	roles := make([]role.Role, 0)
+	for _, roleIdentifier := range roleIdentifiers {
+		// retrieve the role
+		roleRetrieveResponse, err := a.roleRecordHandler.Retrieve(&recordHandler.RetrieveRequest{
+			Identifier: roleIdentifier,
+		})
+		if err != nil {
+			return nil, err
+		}
+		// custom roles only grant permissions to users of the owner party
+		if !roleGrantsPermissions(roleRetrieveResponse.Role, partyId) {
+			continue
+		}
+		roles = append(roles, roleRetrieveResponse.Role)
+	}
+
+	return roles, nil
+}
+
 func (a *administrator) ValidateUserHasPermissionRequest(request *administrator2.UserHasPermissionRequest) error {
 	reasonsInvalid := make([]string, 0)
 
@@ -109,8 +149,10 @@ func (a *administrator) GetAllUsersAPIPermissions(request *administrator2.GetAll
 		return nil, err
 	}
 
-	// get all of the roles assigned to this user
-	var roles []string
+	// get all of the roles assigned to this user and the user's party
+	var roleNames []string
+	var customRoleIds []id.Identifier
+	var partyId id.Identifier
 	switch request.Claims.(type) {
 	case human.Login:
 		// try and retrieve the human user
@@ -121,7 +163,9 @@ func (a *administrator) GetAllUsersAPIPermissions(request *administrator2.GetAll
 		if err != nil {
 			return nil, err
 		}
-		roles = userRetrieveResponse.User.Roles
+		roleNames = userRetrieveResponse.User.Roles
+		customRoleIds = userRetrieveResponse.User.CustomRoles
+		partyId = userRetrieveResponse.User.PartyId
 
 	case api.Login:
 		// try and retrieve the api user
@@ -132,25 +176,22 @@ func (a *administrator) GetAllUsersAPIPermissions(request *administrator2.GetAll
 		if err != nil {
 			return nil, err
 		}
-		roles = apiUserRetrieveResponse.User.Roles
+		roleNames = apiUserRetrieveResponse.User.Roles
+		partyId = apiUserRetrieveResponse.User.PartyId
 
 	default:
 		return nil, exception.GetAllPermissions{Reasons: []string{"invalid claims type", string(request.Claims.Type())}}
 	}
 
-	usersAPIPermissions := make([]api2.Permission, 0)
+	roles, err := a.usersRoles(roleNames, customRoleIds, partyId)
+	if err != nil {
+		return nil, err
+	}
 
-	// for every role that the user has been assigned
-	for _, roleName := range roles {
-		// retrieve the role
-		roleRetrieveResponse, err := a.roleRecordHandler.Retrieve(&recordHandler.RetrieveRequest{
-			Identifier: name.Identifier{Name: roleName},
-		})
-		if err != nil {
-			return nil, err
-		}
-		// add all of the permissions of the role
-		usersAPIPermissions = append(usersAPIPermissions, roleRetrieveResponse.Role.APIPermissions...)
+	// add all of the permissions of every role that the user has been assigned
+	usersAPIPermissions := make([]api2.Permission, 0)
+	for _, usersRole := range roles {
+		usersAPIPermissions = append(usersAPIPermissions, usersRole.APIPermissions...)
 	}
 
 	return &administrator2.GetAllUsersAPIPermissionsResponse{Permissions: usersAPIPermissions}, nil
@@ -188,19 +229,15 @@ func (a *administrator) GetAllUsersViewPermissions(request *administrator2.GetAl
 		return nil, err
 	}
 
-	usersViewPermissions := make([]view2.Permission, 0)
+	roles, err := a.usersRoles(userRetrieveResponse.User.Roles, userRetrieveResponse.User.CustomRoles, userRetrieveResponse.User.PartyId)
+	if err != nil {
+		return nil, err
+	}
 
-	// for every role that the user has been assigned
-	for _, roleName := range userRetrieveResponse.User.Roles {
-		// retrieve the role
-		roleRetrieveResponse, err := a.roleRecordHandler.Retrieve(&recordHandler.RetrieveRequest{
-			Identifier: name.Identifier{Name: roleName},
-		})
-		if err != nil {
-			return nil, err
-		}
-		// add all of the permissions of the role
-		usersViewPermissions = append(usersViewPermissions, roleRetrieveResponse.Role.ViewPermissions...)
+	// add all of the permissions of every role that the user has been assigned
+	usersViewPermissions := make([]view2.Permission, 0)
+	for _, usersRole := range roles {
+		usersViewPermissions = append(usersViewPermissions, usersRole.ViewPermissions...)
 	}
 
 	return &administrator2.GetAllUsersViewPermissionsResponse{Permissions: usersViewPermissions}, nil
@@ -0,0 +1,181 @@
+package jsonRpc
+
+import (
+	"github.com/iot-my-world/brain/internal/log"
+	jsonRpcServiceProvider "github.com/iot-my-world/brain/pkg/api/jsonRpc/service/provider"
+	"github.com/iot-my-world/brain/pkg/search/criterion"
+	wrappedCriterion "github.com/iot-my-world/brain/pkg/search/criterion/wrapped"
+	wrappedIdentifier "github.com/iot-my-world/brain/pkg/search/identifier/wrapped"
+	"github.com/iot-my-world/brain/pkg/search/query"
+	wrappedClaims "github.com/iot-my-world/brain/pkg/security/claims/wrapped"
+	"github.com/iot-my-world/brain/pkg/security/role"
+	"github.com/iot-my-world/brain/pkg/security/role/administrator"
+	"net/http"
+)
+
+type adaptor struct {
+	administrator administrator.Administrator
+}
+
+func New(administrator administrator.Administrator) *adaptor {
+	return &adaptor{
+		administrator: administrator,
+	}
+}
+
+func (a *adaptor) Name() jsonRpcServiceProvider.Name {
+	return jsonRpcServiceProvider.Name(administrator.ServiceProvider)
+}
+
+func (a *adaptor) MethodRequiresAuthorization(string) bool {
+	return true
+}
+
+type CreateRequest struct {
+	Role role.Role `json:"role"`
+}
+
+type CreateResponse struct {
+	Role role.Role `json:"role"`
+}
+
+func (a *adaptor) Create(r *http.Request, request *CreateRequest, response *CreateResponse) error {
+	claims, err := wrappedClaims.UnwrapClaimsFromContext(r)
+	if err != nil {
+		log.Warn(err.Error())
+		return err
+	}
+
+	createResponse, err := a.administrator.Create(&administrator.CreateRequest{
+		Claims: claims,
+		Role:   request.Role,
+	})
+	if err != nil {
+		return err
+	}
+
+	response.Role = createResponse.Role
+
+	return nil
+}
+
+type UpdateAllowedFieldsRequest struct {
+	Role role.Role `json:"role"`
+}
+
+type UpdateAllowedFieldsResponse struct {
+	Role role.Role `json:"role"`
+}
+
+func (a *adaptor) UpdateAllowedFields(r *http.Request, request *UpdateAllowedFieldsRequest, response *UpdateAllowedFieldsResponse) error {
+	claims, err := wrappedClaims.UnwrapClaimsFromContext(r)
+	if err != nil {
+		log.Warn(err.Error())
+		return err
+	}
+
+	updateAllowedFieldsResponse, err := a.administrator.UpdateAllowedFields(&administrator.UpdateAllowedFieldsRequest{
+		Claims: claims,
+		Role:   request.Role,
+	})
+	if err != nil {
+		return err
+	}
+
+	response.Role = updateAllowedFieldsResponse.Role
+
+	return nil
+}
+
+type CollectRequest struct {
+	Criteria []wrappedCriterion.Wrapped `json:"criteria"`
+	Query    query.Query                `json:"query"`
+}
+
+type CollectResponse struct {
+	Records []role.Role `json:"records"`
+	Total   int         `json:"total"`
+}
+
+func (a *adaptor) Collect(r *http.Request, request *CollectRequest, response *CollectResponse) error {
+	claims, err := wrappedClaims.UnwrapClaimsFromContext(r)
+	if err != nil {
+		log.Warn(err.Error())
+		return err
+	}
+
+	criteria := make([]criterion.Criterion, 0)
+	for criterionIdx := range request.Criteria {
+		if c, err := request.Criteria[criterionIdx].UnWrap(); err == nil {
+			criteria = append(criteria, c)
+		} else {
+			return err
+		}
+	}
+
+	collectResponse, err := a.administrator.Collect(&administrator.CollectRequest{
+		Claims:   claims,
+		Criteria: criteria,
+		Query:    request.Query,
+	})
+	if err != nil {
+		return err
+	}
+
+	response.Records = collectResponse.Records
+	response.Total = collectResponse.Total
+
+	return nil
+}
+
+type AssignRoleRequest struct {
+	UserIdentifier wrappedIdentifier.Wrapped `json:"userIdentifier"`
+	RoleIdentifier wrappedIdentifier.Wrapped `json:"roleIdentifier"`
+}
+
+type AssignRoleResponse struct {
+}
+
+func (a *adaptor) AssignRole(r *http.Request, request *AssignRoleRequest, response *AssignRoleResponse) error {
+	claims, err := wrappedClaims.UnwrapClaimsFromContext(r)
+	if err != nil {
+		log.Warn(err.Error())
+		return err
+	}
+
+	if _, err := a.administrator.AssignRole(&administrator.AssignRoleRequest{
+		Claims:         claims,
+		UserIdentifier: request.UserIdentifier.Identifier,
+		RoleIdentifier: request.RoleIdentifier.Identifier,
+	}); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+type UnassignRoleRequest struct {
+	UserIdentifier wrappedIdentifier.Wrapped `json:"userIdentifier"`
+	RoleIdentifier wrappedIdentifier.Wrapped `json:"roleIdentifier"`
+}
+
+type UnassignRoleResponse struct {
+}
+
+func (a *adaptor) UnassignRole(r *http.Request, request *UnassignRoleRequest, response *UnassignRoleResponse) error {
+	claims, err := wrappedClaims.UnwrapClaimsFromContext(r)
+	if err != nil {
+		log.Warn(err.Error())
+		return err
+	}
+
+	if _, err := a.administrator.UnassignRole(&administrator.UnassignRoleRequest{
+		Claims:         claims,
+		UserIdentifier: request.UserIdentifier.Identifier,
+		RoleIdentifier: request.RoleIdentifier.Identifier,
+	}); err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,94 @@
+package administrator
+
+import (
+	"github.com/iot-my-world/brain/pkg/search/criterion"
+	"github.com/iot-my-world/brain/pkg/search/identifier"
+	"github.com/iot-my-world/brain/pkg/search/query"
+	"github.com/iot-my-world/brain/pkg/security/claims"
+	"github.com/iot-my-world/brain/pkg/security/permission/api"
+	"github.com/iot-my-world/brain/pkg/security/role"
+)
+
+type Administrator interface {
+	Create(request *CreateRequest) (*CreateResponse, error)
+	UpdateAllowedFields(request *UpdateAllowedFieldsRequest) (*UpdateAllowedFieldsResponse, error)
+	Collect(request *CollectRequest) (*CollectResponse, error)
+	AssignRole(request *AssignRoleRequest) (*AssignRoleResponse, error)
+	UnassignRole(request *UnassignRoleRequest) (*UnassignRoleResponse, error)
+}
+
+const ServiceProvider = "Role-Administrator"
+const CreateService = ServiceProvider + ".Create"
+const UpdateAllowedFieldsService = ServiceProvider + ".UpdateAllowedFields"
+const CollectService = ServiceProvider + ".Collect"
+const AssignRoleService = ServiceProvider + ".AssignRole"
+const UnassignRoleService = ServiceProvider + ".UnassignRole"
+
+var SystemUserPermissions = []api.Permission{
+	CreateService,
+	UpdateAllowedFieldsService,
+	CollectService,
+	AssignRoleService,
+	UnassignRoleService,
+}
+
+var CompanyAdminUserPermissions = []api.Permission{
+	CreateService,
+	UpdateAllowedFieldsService,
+	CollectService,
+	AssignRoleService,
+	UnassignRoleService,
+}
+
+var CompanyUserPermissions = make([]api.Permission, 0)
+
+var ClientAdminUserPermissions = make([]api.Permission, 0)
+
+var ClientUserPermissions = make([]api.Permission, 0)
+
+type CreateRequest struct {
+	Claims claims.Claims
+	Role   role.Role
+}
+
+type CreateResponse struct {
+	Role role.Role
+}
+
+type UpdateAllowedFieldsRequest struct {
+	Claims claims.Claims
+	Role   role.Role
+}
+
+type UpdateAllowedFieldsResponse struct {
+	Role role.Role
+}
+
+type CollectRequest struct {
+	Claims   claims.Claims
+	Criteria []criterion.Criterion
+	Query    query.Query
+}
+
+type CollectResponse struct {
+	Records []role.Role
+	Total   int
+}
+
+type AssignRoleRequest struct {
+	Claims         claims.Claims
+	UserIdentifier identifier.Identifier
+	RoleIdentifier identifier.Identifier
+}
+
+type AssignRoleResponse struct {
+}
+
+type UnassignRoleRequest struct {
+	Claims         claims.Claims
+	UserIdentifier identifier.Identifier
+	RoleIdentifier identifier.Identifier
+}
+
+type UnassignRoleResponse struct {
+}
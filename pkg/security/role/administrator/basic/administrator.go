@@ -0,0 +1,356 @@
+package basic
+
+import (
+	"fmt"
+	brainException "github.com/iot-my-world/brain/internal/exception"
+	"github.com/iot-my-world/brain/internal/log"
+	"github.com/iot-my-world/brain/pkg/action"
+	"github.com/iot-my-world/brain/pkg/search/identifier"
+	"github.com/iot-my-world/brain/pkg/search/identifier/id"
+	"github.com/iot-my-world/brain/pkg/security/claims"
+	"github.com/iot-my-world/brain/pkg/security/role"
+	roleAction "github.com/iot-my-world/brain/pkg/security/role/action"
+	roleAdministrator "github.com/iot-my-world/brain/pkg/security/role/administrator"
+	roleAdministratorException "github.com/iot-my-world/brain/pkg/security/role/administrator/exception"
+	roleRecordHandler "github.com/iot-my-world/brain/pkg/security/role/recordHandler"
+	roleValidator "github.com/iot-my-world/brain/pkg/security/role/validator"
+	humanUser "github.com/iot-my-world/brain/pkg/user/human"
+	humanUserRecordHandler "github.com/iot-my-world/brain/pkg/user/human/recordHandler"
+)
+
+type administrator struct {
+	roleValidator          roleValidator.Validator
+	roleRecordHandler      roleRecordHandler.RecordHandler
+	humanUserRecordHandler humanUserRecordHandler.RecordHandler
+}
+
+func New(
+	roleValidator roleValidator.Validator,
+	roleRecordHandler roleRecordHandler.RecordHandler,
+	humanUserRecordHandler humanUserRecordHandler.RecordHandler,
+) roleAdministrator.Administrator {
+	return &administrator{
+		roleValidator:          roleValidator,
+		roleRecordHandler:      roleRecordHandler,
+		humanUserRecordHandler: humanUserRecordHandler,
+	}
+}
+
+// validateRole returns the reasons for which the given role
+// is invalid for the given action in the context of the given claims
+func (a *administrator) validateRole(claimsToValidateWith claims.Claims, roleToValidate role.Role, roleActionToValidate action.Action) []string {
+	reasonsInvalid := make([]string, 0)
+
+	roleValidateResponse, err := a.roleValidator.Validate(&roleValidator.ValidateRequest{
+		Claims: claimsToValidateWith,
+		Role:   roleToValidate,
+		Action: roleActionToValidate,
+	})
+	if err != nil {
+		reasonsInvalid = append(reasonsInvalid, "error validating role: "+err.Error())
+	} else {
+		for _, reason := range roleValidateResponse.ReasonsInvalid {
+			reasonsInvalid = append(reasonsInvalid, fmt.Sprintf("role invalid: %s - %s - %s", reason.Field, reason.Type, reason.Help))
+		}
+	}
+
+	return reasonsInvalid
+}
+
+func (a *administrator) ValidateCreateRequest(request *roleAdministrator.CreateRequest) error {
+	reasonsInvalid := make([]string, 0)
+
+	if request.Claims == nil {
+		reasonsInvalid = append(reasonsInvalid, "claims are nil")
+	} else {
+		reasonsInvalid = append(reasonsInvalid, a.validateRole(request.Claims, request.Role, roleAction.Create)...)
+	}
+
+	if len(reasonsInvalid) > 0 {
+		return brainException.RequestInvalid{Reasons: reasonsInvalid}
+	}
+	return nil
+}
+
+func (a *administrator) Create(request *roleAdministrator.CreateRequest) (*roleAdministrator.CreateResponse, error) {
+	if err := a.ValidateCreateRequest(request); err != nil {
+		return nil, err
+	}
+
+	createResponse, err := a.roleRecordHandler.Create(&roleRecordHandler.CreateRequest{
+		Role: request.Role,
+	})
+	if err != nil {
+		err = roleAdministratorException.RoleCreation{Reasons: []string{err.Error()}}
+		log.Error(err.Error())
+		return nil, err
+	}
+
+	return &roleAdministrator.CreateResponse{
+		Role: createResponse.Role,
+	}, nil
+}
+
+func (a *administrator) ValidateUpdateAllowedFieldsRequest(request *roleAdministrator.UpdateAllowedFieldsRequest) error {
+	reasonsInvalid := make([]string, 0)
+
+	if request.Claims == nil {
+		reasonsInvalid = append(reasonsInvalid, "claims are nil")
+	} else {
+		reasonsInvalid = append(reasonsInvalid, a.validateRole(request.Claims, request.Role, roleAction.UpdateAllowedFields)...)
+	}
+
+	if len(reasonsInvalid) > 0 {
+		return brainException.RequestInvalid{Reasons: reasonsInvalid}
+	}
+	return nil
+}
+
+func (a *administrator) UpdateAllowedFields(request *roleAdministrator.UpdateAllowedFieldsRequest) (*roleAdministrator.UpdateAllowedFieldsResponse, error) {
+	if err := a.ValidateUpdateAllowedFieldsRequest(request); err != nil {
+		return nil, err
+	}
+
+	// retrieve the role
+	roleToUpdate, err := a.retrieveCustomRole(request.Claims, id.Identifier{Id: request.Role.Id})
+	if err != nil {
+		log.Error(err.Error())
+		return nil, err
+	}
+
+	// the owner of a role cannot be changed
+	if roleToUpdate.OwnerPartyType != request.Role.OwnerPartyType || roleToUpdate.OwnerId.Id != request.Role.OwnerId.Id {
+		err = brainException.RequestInvalid{Reasons: []string{"role owner cannot be changed"}}
+		log.Error(err.Error())
+		return nil, err
+	}
+
+	// update the allowed fields on the role
+	roleToUpdate.APIPermissions = request.Role.APIPermissions
+	roleToUpdate.ViewPermissions = request.Role.ViewPermissions
+
+	// update the role
+	if _, err := a.roleRecordHandler.Update(&roleRecordHandler.UpdateRequest{
+		Identifier: id.Identifier{Id: roleToUpdate.Id},
+		Role:       *roleToUpdate,
+	}); err != nil {
+		err = roleAdministratorException.RoleUpdate{Reasons: []string{err.Error()}}
+		log.Error(err.Error())
+		return nil, err
+	}
+
+	return &roleAdministrator.UpdateAllowedFieldsResponse{
+		Role: *roleToUpdate,
+	}, nil
+}
+
+func (a *administrator) ValidateCollectRequest(request *roleAdministrator.CollectRequest) error {
+	reasonsInvalid := make([]string, 0)
+
+	if request.Claims == nil {
+		reasonsInvalid = append(reasonsInvalid, "claims are nil")
+	}
+
+	if len(reasonsInvalid) > 0 {
+		return brainException.RequestInvalid{Reasons: reasonsInvalid}
+	}
+	return nil
+}
+
+func (a *administrator) Collect(request *roleAdministrator.CollectRequest) (*roleAdministrator.CollectResponse, error) {
+	if err := a.ValidateCollectRequest(request); err != nil {
+		return nil, err
+	}
+
+	collectResponse, err := a.roleRecordHandler.Collect(&roleRecordHandler.CollectRequest{
+		Claims:   request.Claims,
+		Criteria: request.Criteria,
+		Query:    request.Query,
+	})
+	if err != nil {
+		err = roleAdministratorException.RoleCollection{Reasons: []string{err.Error()}}
+		log.Error(err.Error())
+		return nil, err
+	}
+
+	return &roleAdministrator.CollectResponse{
+		Records: collectResponse.Records,
+		Total:   collectResponse.Total,
+	}, nil
+}
+
+// retrieveCustomRole retrieves the role with the given identifier, which
+// must be a custom role owned by a party in the context of the given claims
+func (a *administrator) retrieveCustomRole(claimsToRetrieveWith claims.Claims, roleIdentifier identifier.Identifier) (*role.Role, error) {
+	retrieveResponse, err := a.roleRecordHandler.Retrieve(&roleRecordHandler.RetrieveRequest{
+		Identifier: roleIdentifier,
+	})
+	if err != nil {
+		return nil, roleAdministratorException.RoleRetrieval{Reasons: []string{err.Error()}}
+	}
+
+	if !retrieveResponse.Role.Custom() {
+		return nil, brainException.RequestInvalid{Reasons: []string{"system roles cannot be administered"}}
+	}
+	if !claims.EntityInContext(retrieveResponse.Role.OwnerId, id.Identifier{}, claimsToRetrieveWith) {
+		return nil, roleAdministratorException.RoleRetrieval{Reasons: []string{"role not found"}}
+	}
+
+	return &retrieveResponse.Role, nil
+}
+
+// retrieveRoleAndUser retrieves the custom role and the user
+// which is to be assigned to, or unassigned from, the role
+func (a *administrator) retrieveRoleAndUser(claimsToRetrieveWith claims.Claims, roleIdentifier, userIdentifier identifier.Identifier) (*role.Role, *humanUser.User, error) {
+	roleToAssign, err := a.retrieveCustomRole(claimsToRetrieveWith, roleIdentifier)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	userRetrieveResponse, err := a.humanUserRecordHandler.Retrieve(&humanUserRecordHandler.RetrieveRequest{
+		Claims:     claimsToRetrieveWith,
+		Identifier: userIdentifier,
+	})
+	if err != nil {
+		return nil, nil, roleAdministratorException.UserRetrieval{Reasons: []string{err.Error()}}
+	}
+
+	// custom roles can only be assigned to users of the owner party
+	if userRetrieveResponse.User.PartyType != roleToAssign.OwnerPartyType ||
+		userRetrieveResponse.User.PartyId.Id != roleToAssign.OwnerId.Id {
+		return nil, nil, brainException.RequestInvalid{Reasons: []string{"user is not of role owner party"}}
+	}
+
+	return roleToAssign, &userRetrieveResponse.User, nil
+}
+
+// updateUserCustomRoles updates the custom roles of the given user
+func (a *administrator) updateUserCustomRoles(claimsToUpdateWith claims.Claims, userToUpdate *humanUser.User, customRoles []id.Identifier) error {
+	userToUpdate.CustomRoles = customRoles
+	if _, err := a.humanUserRecordHandler.Update(&humanUserRecordHandler.UpdateRequest{
+		Claims:     claimsToUpdateWith,
+		Identifier: id.Identifier{Id: userToUpdate.Id},
+		User:       *userToUpdate,
+	}); err != nil {
+		return roleAdministratorException.UserUpdate{Reasons: []string{err.Error()}}
+	}
+	return nil
+}
+
+func (a *administrator) ValidateAssignRoleRequest(request *roleAdministrator.AssignRoleRequest) error {
+	reasonsInvalid := make([]string, 0)
+
+	if request.Claims == nil {
+		reasonsInvalid = append(reasonsInvalid, "claims are nil")
+	}
+
+	if request.UserIdentifier == nil {
+		reasonsInvalid = append(reasonsInvalid, "user identifier is nil")
+	} else if !humanUser.IsValidIdentifier(request.UserIdentifier) {
+		reasonsInvalid = append(reasonsInvalid, fmt.Sprintf("identifier of type %s not supported for user", request.UserIdentifier.Type()))
+	}
+
+	if request.RoleIdentifier == nil {
+		reasonsInvalid = append(reasonsInvalid, "role identifier is nil")
+	} else if request.RoleIdentifier.Type() != identifier.Id {
+		// role names are only unique to the party which owns
+		// a role, and so custom roles are identified by id
+		reasonsInvalid = append(reasonsInvalid, fmt.Sprintf("identifier of type %s not supported for custom role", request.RoleIdentifier.Type()))
+	}
+
+	if len(reasonsInvalid) > 0 {
+		return brainException.RequestInvalid{Reasons: reasonsInvalid}
+	}
+	return nil
+}
+
+func (a *administrator) AssignRole(request *roleAdministrator.AssignRoleRequest) (*roleAdministrator.AssignRoleResponse, error) {
+	if err := a.ValidateAssignRoleRequest(request); err != nil {
+		return nil, err
+	}
+
+	roleToAssign, userToAssignTo, err := a.retrieveRoleAndUser(request.Claims, request.RoleIdentifier, request.UserIdentifier)
+	if err != nil {
+		log.Error(err.Error())
+		return nil, err
+	}
+
+	// the role is validated again since the permissions
+	// of the assigner may have changed since it was defined
+	if reasonsInvalid := a.validateRole(request.Claims, *roleToAssign, roleAction.Assign); len(reasonsInvalid) > 0 {
+		err = brainException.RequestInvalid{Reasons: reasonsInvalid}
+		log.Error(err.Error())
+		return nil, err
+	}
+
+	for _, customRoleId := range userToAssignTo.CustomRoles {
+		if customRoleId.Id == roleToAssign.Id {
+			// user already has the role
+			return &roleAdministrator.AssignRoleResponse{}, nil
+		}
+	}
+
+	if err := a.updateUserCustomRoles(request.Claims, userToAssignTo, append(userToAssignTo.CustomRoles, id.Identifier{Id: roleToAssign.Id})); err != nil {
+		log.Error(err.Error())
+		return nil, err
+	}
+
+	return &roleAdministrator.AssignRoleResponse{}, nil
+}
+
+func (a *administrator) ValidateUnassignRoleRequest(request *roleAdministrator.UnassignRoleRequest) error {
+	reasonsInvalid := make([]string, 0)
+
+	if request.Claims == nil {
+		reasonsInvalid = append(reasonsInvalid, "claims are nil")
+	}
+
+	if request.UserIdentifier == nil {
+		reasonsInvalid = append(reasonsInvalid, "user identifier is nil")
+	} else if !humanUser.IsValidIdentifier(request.UserIdentifier) {
+		reasonsInvalid = append(reasonsInvalid, fmt.Sprintf("identifier of type %s not supported for user", request.UserIdentifier.Type()))
+	}
+
+	if request.RoleIdentifier == nil {
+		reasonsInvalid = append(reasonsInvalid, "role identifier is nil")
+	} else if request.RoleIdentifier.Type() != identifier.Id {
+		// role names are only unique to the party which owns
+		// a role, and so custom roles are identified by id
+		reasonsInvalid = append(reasonsInvalid, fmt.Sprintf("identifier of type %s not supported for custom role", request.RoleIdentifier.Type()))
+	}
+
+	if len(reasonsInvalid) > 0 {
+		return brainException.RequestInvalid{Reasons: reasonsInvalid}
+	}
+	return nil
+}
+
+func (a *administrator) UnassignRole(request *roleAdministrator.UnassignRoleRequest) (*roleAdministrator.UnassignRoleResponse, error) {
+	if err := a.ValidateUnassignRoleRequest(request); err != nil {
+		return nil, err
+	}
+
+	roleToUnassign, userToUnassignFrom, err := a.retrieveRoleAndUser(request.Claims, request.RoleIdentifier, request.UserIdentifier)
+	if err != nil {
+		log.Error(err.Error())
+		return nil, err
+	}
+
+	remainingCustomRoles := make([]id.Identifier, 0)
+	for _, customRoleId := range userToUnassignFrom.CustomRoles {
+		if customRoleId.Id != roleToUnassign.Id {
+			remainingCustomRoles = append(remainingCustomRoles, customRoleId)
+		}
+	}
+	if len(remainingCustomRoles) == len(userToUnassignFrom.CustomRoles) {
+		// user does not have the role
+		return &roleAdministrator.UnassignRoleResponse{}, nil
+	}
+
+	if err := a.updateUserCustomRoles(request.Claims, userToUnassignFrom, remainingCustomRoles); err != nil {
+		log.Error(err.Error())
+		return nil, err
+	}
+
+	return &roleAdministrator.UnassignRoleResponse{}, nil
+}
@@ -0,0 +1,53 @@
+package exception
+
+import (
+	"strings"
+)
+
+type RoleCreation struct {
+	Reasons []string
+}
+
+func (e RoleCreation) Error() string {
+	return "error creating role: " + strings.Join(e.Reasons, "; ")
+}
+
+type RoleRetrieval struct {
+	Reasons []string
+}
+
+func (e RoleRetrieval) Error() string {
+	return "error retrieving role: " + strings.Join(e.Reasons, "; ")
+}
+
+type RoleUpdate struct {
+	Reasons []string
+}
+
+func (e RoleUpdate) Error() string {
+	return "error updating role: " + strings.Join(e.Reasons, "; ")
+}
+
+type RoleCollection struct {
+	Reasons []string
+}
+
+func (e RoleCollection) Error() string {
+	return "error collecting roles: " + strings.Join(e.Reasons, "; ")
+}
+
+type UserRetrieval struct {
+	Reasons []string
+}
+
+func (e UserRetrieval) Error() string {
+	return "error retrieving user: " + strings.Join(e.Reasons, "; ")
+}
+
+type UserUpdate struct {
+	Reasons []string
+}
+
+func (e UserUpdate) Error() string {
+	return "error updating user: " + strings.Join(e.Reasons, "; ")
+}
@@ -0,0 +1,231 @@
+package jsonRpc
+
+import (
+	brainException "github.com/iot-my-world/brain/internal/exception"
+	"github.com/iot-my-world/brain/internal/log"
+	jsonRpcClient "github.com/iot-my-world/brain/pkg/api/jsonRpc/client"
+	wrappedCriterion "github.com/iot-my-world/brain/pkg/search/criterion/wrapped"
+	wrappedIdentifier "github.com/iot-my-world/brain/pkg/search/identifier/wrapped"
+	roleAdministrator "github.com/iot-my-world/brain/pkg/security/role/administrator"
+	roleAdministratorJsonRpcAdaptor "github.com/iot-my-world/brain/pkg/security/role/administrator/adaptor/jsonRpc"
+)
+
+type administrator struct {
+	jsonRpcClient jsonRpcClient.Client
+}
+
+func New(
+	jsonRpcClient jsonRpcClient.Client,
+) roleAdministrator.Administrator {
+	return &administrator{
+		jsonRpcClient: jsonRpcClient,
+	}
+}
+
+func (a *administrator) ValidateCreateRequest(request *roleAdministrator.CreateRequest) error {
+	reasonsInvalid := make([]string, 0)
+
+	if len(reasonsInvalid) > 0 {
+		return brainException.RequestInvalid{Reasons: reasonsInvalid}
+	}
+	return nil
+}
+
+func (a *administrator) Create(request *roleAdministrator.CreateRequest) (*roleAdministrator.CreateResponse, error) {
+	if err := a.ValidateCreateRequest(request); err != nil {
+		return nil, err
+	}
+
+	roleCreateResponse := roleAdministratorJsonRpcAdaptor.CreateResponse{}
+	if err := a.jsonRpcClient.JsonRpcRequest(
+		roleAdministrator.CreateService,
+		roleAdministratorJsonRpcAdaptor.CreateRequest{
+			Role: request.Role,
+		},
+		&roleCreateResponse,
+	); err != nil {
+		log.Error(err.Error())
+		return nil, err
+	}
+
+	return &roleAdministrator.CreateResponse{Role: roleCreateResponse.Role}, nil
+}
+
+func (a *administrator) ValidateUpdateAllowedFieldsRequest(request *roleAdministrator.UpdateAllowedFieldsRequest) error {
+	reasonsInvalid := make([]string, 0)
+
+	if len(reasonsInvalid) > 0 {
+		return brainException.RequestInvalid{Reasons: reasonsInvalid}
+	}
+	return nil
+}
+
+func (a *administrator) UpdateAllowedFields(request *roleAdministrator.UpdateAllowedFieldsRequest) (*roleAdministrator.UpdateAllowedFieldsResponse, error) {
+	if err := a.ValidateUpdateAllowedFieldsRequest(request); err != nil {
+		return nil, err
+	}
+
+	roleUpdateAllowedFieldsResponse := roleAdministratorJsonRpcAdaptor.UpdateAllowedFieldsResponse{}
+	if err := a.jsonRpcClient.JsonRpcRequest(
+		roleAdministrator.UpdateAllowedFieldsService,
+		roleAdministratorJsonRpcAdaptor.UpdateAllowedFieldsRequest{
+			Role: request.Role,
+		},
+		&roleUpdateAllowedFieldsResponse,
+	); err != nil {
+		log.Error(err.Error())
+		return nil, err
+	}
+
+	return &roleAdministrator.UpdateAllowedFieldsResponse{
+		Role: roleUpdateAllowedFieldsResponse.Role,
+	}, nil
+}
+
+func (a *administrator) ValidateCollectRequest(request *roleAdministrator.CollectRequest) error {
+	reasonsInvalid := make([]string, 0)
+
+	for _, crit := range request.Criteria {
+		if crit == nil {
+			reasonsInvalid = append(reasonsInvalid, "a criterion is nil")
+		}
+	}
+
+	if len(reasonsInvalid) > 0 {
+		return brainException.RequestInvalid{Reasons: reasonsInvalid}
+	}
+	return nil
+}
+
+func (a *administrator) Collect(request *roleAdministrator.CollectRequest) (*roleAdministrator.CollectResponse, error) {
+	if err := a.ValidateCollectRequest(request); err != nil {
+		return nil, err
+	}
+
+	// wrap criteria
+	criteria := make([]wrappedCriterion.Wrapped, 0)
+	for _, crit := range request.Criteria {
+		wrapped, err := wrappedCriterion.Wrap(crit)
+		if err != nil {
+			log.Error(err.Error())
+			return nil, err
+		}
+		criteria = append(criteria, *wrapped)
+	}
+
+	roleCollectResponse := roleAdministratorJsonRpcAdaptor.CollectResponse{}
+	if err := a.jsonRpcClient.JsonRpcRequest(
+		roleAdministrator.CollectService,
+		roleAdministratorJsonRpcAdaptor.CollectRequest{
+			Criteria: criteria,
+			Query:    request.Query,
+		},
+		&roleCollectResponse,
+	); err != nil {
+		log.Error(err.Error())
+		return nil, err
+	}
+
+	return &roleAdministrator.CollectResponse{
+		Records: roleCollectResponse.Records,
+		Total:   roleCollectResponse.Total,
+	}, nil
+}
+
+func (a *administrator) ValidateAssignRoleRequest(request *roleAdministrator.AssignRoleRequest) error {
+	reasonsInvalid := make([]string, 0)
+
+	if request.UserIdentifier == nil {
+		reasonsInvalid = append(reasonsInvalid, "user identifier is nil")
+	}
+
+	if request.RoleIdentifier == nil {
+		reasonsInvalid = append(reasonsInvalid, "role identifier is nil")
+	}
+
+	if len(reasonsInvalid) > 0 {
+		return brainException.RequestInvalid{Reasons: reasonsInvalid}
+	}
+	return nil
+}
+
+func (a *administrator) AssignRole(request *roleAdministrator.AssignRoleRequest) (*roleAdministrator.AssignRoleResponse, error) {
+	if err := a.ValidateAssignRoleRequest(request); err != nil {
+		return nil, err
+	}
+
+	userIdentifier, err := wrappedIdentifier.Wrap(request.UserIdentifier)
+	if err != nil {
+		log.Error(err.Error())
+		return nil, err
+	}
+	roleIdentifier, err := wrappedIdentifier.Wrap(request.RoleIdentifier)
+	if err != nil {
+		log.Error(err.Error())
+		return nil, err
+	}
+
+	roleAssignRoleResponse := roleAdministratorJsonRpcAdaptor.AssignRoleResponse{}
+	if err := a.jsonRpcClient.JsonRpcRequest(
+		roleAdministrator.AssignRoleService,
+		roleAdministratorJsonRpcAdaptor.AssignRoleRequest{
+			UserIdentifier: *userIdentifier,
+			RoleIdentifier: *roleIdentifier,
+		},
+		&roleAssignRoleResponse,
+	); err != nil {
+		log.Error(err.Error())
+		return nil, err
+	}
+
+	return &roleAdministrator.AssignRoleResponse{}, nil
+}
+
+func (a *administrator) ValidateUnassignRoleRequest(request *roleAdministrator.UnassignRoleRequest) error {
+	reasonsInvalid := make([]string, 0)
+
+	if request.UserIdentifier == nil {
+		reasonsInvalid = append(reasonsInvalid, "user identifier is nil")
+	}
+
+	if request.RoleIdentifier == nil {
+		reasonsInvalid = append(reasonsInvalid, "role identifier is nil")
+	}
+
+	if len(reasonsInvalid) > 0 {
+		return brainException.RequestInvalid{Reasons: reasonsInvalid}
+	}
+	return nil
+}
+
+func (a *administrator) UnassignRole(request *roleAdministrator.UnassignRoleRequest) (*roleAdministrator.UnassignRoleResponse, error) {
+	if err := a.ValidateUnassignRoleRequest(request); err != nil {
+		return nil, err
+	}
+
+	userIdentifier, err := wrappedIdentifier.Wrap(request.UserIdentifier)
+	if err != nil {
+		log.Error(err.Error())
+		return nil, err
+	}
+	roleIdentifier, err := wrappedIdentifier.Wrap(request.RoleIdentifier)
+	if err != nil {
+		log.Error(err.Error())
+		return nil, err
+	}
+
+	roleUnassignRoleResponse := roleAdministratorJsonRpcAdaptor.UnassignRoleResponse{}
+	if err := a.jsonRpcClient.JsonRpcRequest(
+		roleAdministrator.UnassignRoleService,
+		roleAdministratorJsonRpcAdaptor.UnassignRoleRequest{
+			UserIdentifier: *userIdentifier,
+			RoleIdentifier: *roleIdentifier,
+		},
+		&roleUnassignRoleResponse,
+	); err != nil {
+		log.Error(err.Error())
+		return nil, err
+	}
+
+	return &roleAdministrator.UnassignRoleResponse{}, nil
+}
@@ -23,3 +23,11 @@ type Update struct {
 func (e Update) Error() string {
 	return "role update error: " + strings.Join(e.Reasons, "; ")
 }
+
+type Collect struct {
+	Reasons []string
+}
+
+func (e Collect) Error() string {
+	return "role collection error: " + strings.Join(e.Reasons, "; ")
+}
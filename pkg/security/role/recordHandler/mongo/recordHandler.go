@@ -4,12 +4,17 @@ import (
 	"fmt"
 	brainException "github.com/iot-my-world/brain/internal/exception"
 	"github.com/iot-my-world/brain/internal/log"
+	"github.com/iot-my-world/brain/pkg/search/criterion"
+	"github.com/iot-my-world/brain/pkg/search/identifier"
+	"github.com/iot-my-world/brain/pkg/search/identifier/id"
+	"github.com/iot-my-world/brain/pkg/security/claims"
 	role2 "github.com/iot-my-world/brain/pkg/security/role"
 	recordHandler2 "github.com/iot-my-world/brain/pkg/security/role/recordHandler"
 	"github.com/iot-my-world/brain/pkg/security/role/recordHandler/exception"
 	"github.com/iot-my-world/brain/pkg/security/role/setup"
 	"github.com/satori/go.uuid"
 	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
 )
 
 type recordHandler struct {
@@ -40,14 +45,17 @@ func setupIndices(mongoSession *mgo.Session, database, collection string) {
 	defer mgoSesh.Close()
 	roleCollection := mgoSesh.DB(database).C(collection)
 
-	//Ensure index Uniqueness
+	// role names are unique to the party which owns them, with system
+	// roles having no owner. the index which made names unique across all
+	// parties is dropped, it does not exist on databases set up since.
+	_ = roleCollection.DropIndex("name")
 	uniqueIndex := mgo.Index{
-		Key:    []string{"name"},
+		Key:    []string{"ownerId.id", "name"},
 		Unique: true,
 	}
 
 	if err := roleCollection.EnsureIndex(uniqueIndex); err != nil {
-		log.Fatal("Could not ensure uniqueness on name index in role collection: ", err)
+		log.Fatal("Could not ensure uniqueness on owner and name index in role collection: ", err)
 	}
 
 	// Ensure id uniqueness
@@ -77,7 +85,7 @@ func (r *recordHandler) Create(request *recordHandler2.CreateRequest) (*recordHa
 		log.Error("Could not create Role! ", err)
 		return nil, err
 	}
-	return &recordHandler2.CreateResponse{}, nil
+	return &recordHandler2.CreateResponse{Role: request.Role}, nil
 }
 
 func (r *recordHandler) ValidateRetrieveRequest(request *recordHandler2.RetrieveRequest) error {
@@ -109,7 +117,14 @@ func (r *recordHandler) Retrieve(request *recordHandler2.RetrieveRequest) (*reco
 
 	var roleRecord role2.Role
 
-	if err := userCollection.Find(request.Identifier.ToFilter()).One(&roleRecord); err != nil {
+	// names are only unique to the owner of a role, and so only
+	// identify system roles. custom roles are identified by id.
+	filter := request.Identifier.ToFilter()
+	if request.Identifier.Type() == identifier.Name {
+		filter["ownerId.id"] = bson.M{"$in": []interface{}{"", nil}}
+	}
+
+	if err := userCollection.Find(filter).One(&roleRecord); err != nil {
 		if err == mgo.ErrNotFound {
 			return nil, exception.NotFound{}
 		} else {
@@ -141,9 +156,70 @@ func (r *recordHandler) Update(request *recordHandler2.UpdateRequest) (*recordHa
 	retrieveRoleResponse.Role.ViewPermissions = request.Role.ViewPermissions
 	retrieveRoleResponse.Role.APIPermissions = request.Role.APIPermissions
 
-	if err := roleCollection.Update(request.Identifier.ToFilter(), retrieveRoleResponse.Role); err != nil {
+	if err := roleCollection.Update(id.Identifier{Id: retrieveRoleResponse.Role.Id}.ToFilter(), retrieveRoleResponse.Role); err != nil {
 		return nil, exception.Update{Reasons: []string{"updating record", err.Error()}}
 	}
 
 	return &recordHandler2.UpdateResponse{}, nil
 }
+
+func (r *recordHandler) ValidateCollectRequest(request *recordHandler2.CollectRequest) error {
+	reasonsInvalid := make([]string, 0)
+
+	if request.Claims == nil {
+		reasonsInvalid = append(reasonsInvalid, "claims are nil")
+	}
+
+	for _, crit := range request.Criteria {
+		if crit == nil {
+			reasonsInvalid = append(reasonsInvalid, "a criterion is nil")
+		} else if err := crit.IsValid(); err != nil {
+			reasonsInvalid = append(reasonsInvalid, err.Error())
+		}
+	}
+
+	if len(reasonsInvalid) > 0 {
+		return brainException.RequestInvalid{Reasons: reasonsInvalid}
+	}
+	return nil
+}
+
+// Collect collects roles in the context of the given claims. Only system
+// users see system roles, other parties only see their custom roles.
+func (r *recordHandler) Collect(request *recordHandler2.CollectRequest) (*recordHandler2.CollectResponse, error) {
+	if err := r.ValidateCollectRequest(request); err != nil {
+		return nil, err
+	}
+
+	filter := criterion.CriteriaToFilter(request.Criteria)
+	filter = claims.ContextualiseFilter(filter, request.Claims)
+
+	mgoSession := r.mongoSession.Copy()
+	defer mgoSession.Close()
+
+	roleCollection := mgoSession.DB(r.database).C(r.collection)
+
+	query := roleCollection.Find(filter)
+
+	total, err := query.Count()
+	if err != nil {
+		return nil, exception.Collect{Reasons: []string{"counting records", err.Error()}}
+	}
+
+	if request.Query.Limit > 0 {
+		query.Limit(request.Query.Limit)
+	}
+
+	records := make([]role2.Role, 0)
+	if err := query.
+		Skip(request.Query.Offset).
+		Sort(request.Query.ToMongoSortFormat()...).
+		All(&records); err != nil {
+		return nil, exception.Collect{Reasons: []string{"finding records", err.Error()}}
+	}
+
+	return &recordHandler2.CollectResponse{
+		Records: records,
+		Total:   total,
+	}, nil
+}
@@ -1,7 +1,10 @@
 package recordHandler
 
 import (
+	"github.com/iot-my-world/brain/pkg/search/criterion"
 	"github.com/iot-my-world/brain/pkg/search/identifier"
+	"github.com/iot-my-world/brain/pkg/search/query"
+	"github.com/iot-my-world/brain/pkg/security/claims"
 	api2 "github.com/iot-my-world/brain/pkg/security/permission/api"
 	role2 "github.com/iot-my-world/brain/pkg/security/role"
 )
@@ -10,17 +13,20 @@ type RecordHandler interface {
 	Create(request *CreateRequest) (*CreateResponse, error)
 	Retrieve(request *RetrieveRequest) (*RetrieveResponse, error)
 	Update(request *UpdateRequest) (*UpdateResponse, error)
+	Collect(request *CollectRequest) (*CollectResponse, error)
 }
 
 const ServiceProvider = "Role-RecordHandler"
 const CreateService = ServiceProvider + ".Create"
 const RetrieveService = ServiceProvider + ".Retrieve"
 const UpdateService = ServiceProvider + ".Update"
+const CollectService = ServiceProvider + ".Collect"
 
 var SystemUserPermissions = []api2.Permission{
 	CreateService,
 	RetrieveService,
 	UpdateService,
+	CollectService,
 }
 
 var CompanyAdminUserPermissions = make([]api2.Permission, 0)
@@ -55,3 +61,14 @@ type UpdateRequest struct {
 type UpdateResponse struct {
 	Role role2.Role
 }
+
+type CollectRequest struct {
+	Claims   claims.Claims
+	Criteria []criterion.Criterion
+	Query    query.Query
+}
+
+type CollectResponse struct {
+	Records []role2.Role
+	Total   int
+}
@@ -1,6 +1,8 @@
 package role
 
 import (
+	"github.com/iot-my-world/brain/pkg/party"
+	"github.com/iot-my-world/brain/pkg/search/identifier/id"
 	api2 "github.com/iot-my-world/brain/pkg/security/permission/api"
 	view2 "github.com/iot-my-world/brain/pkg/security/permission/view"
 )
@@ -10,6 +12,16 @@ type Role struct {
 	Name            string             `json:"name" bson:"name"`
 	APIPermissions  []api2.Permission  `json:"apiPermissions" bson:"apiPermissions"`
 	ViewPermissions []view2.Permission `json:"viewPermissions" bson:"viewPermissions"`
+
+	// Owner Details, set only on custom roles defined by a party
+	// for its own users. System roles have no owner.
+	OwnerPartyType party.Type    `json:"ownerPartyType" bson:"ownerPartyType"`
+	OwnerId        id.Identifier `json:"ownerId" bson:"ownerId"`
+}
+
+// Custom returns true if the role was defined by a party for its users
+func (r *Role) Custom() bool {
+	return r.OwnerId.Id != ""
 }
 
 func (r *Role) CompareAPIPermissions(perms []api2.Permission) bool {
@@ -2,12 +2,38 @@ package setup
 
 import (
 	"github.com/iot-my-world/brain/internal/log"
+	alertDeliveryRecordHandler "github.com/iot-my-world/brain/pkg/alert/delivery/recordHandler"
+	alertRuleAdministrator "github.com/iot-my-world/brain/pkg/alert/rule/administrator"
+	alertRuleRecordHandler "github.com/iot-my-world/brain/pkg/alert/rule/recordHandler"
+	alertRuleValidator "github.com/iot-my-world/brain/pkg/alert/rule/validator"
+	jsonRpcServerAuthenticator "github.com/iot-my-world/brain/pkg/api/jsonRpc/server/authenticator"
+	auditLogger "github.com/iot-my-world/brain/pkg/audit/logger"
+	"github.com/iot-my-world/brain/pkg/communication/websocket"
 	sigbugAdministrator "github.com/iot-my-world/brain/pkg/device/sigbug/administrator"
+	sigbugDownlinkRecordHandler "github.com/iot-my-world/brain/pkg/device/sigbug/downlink/recordHandler"
+	sigbugEventRecordHandler "github.com/iot-my-world/brain/pkg/device/sigbug/event/recordHandler"
+	sigbugImporter "github.com/iot-my-world/brain/pkg/device/sigbug/importer"
+	sigbugMonitor "github.com/iot-my-world/brain/pkg/device/sigbug/monitor"
+	sigbugBatteryReadingAdministrator "github.com/iot-my-world/brain/pkg/device/sigbug/reading/battery/administrator"
+	sigbugBatteryReadingRecordHandler "github.com/iot-my-world/brain/pkg/device/sigbug/reading/battery/recordHandler"
+	sigbugBatteryReadingValidator "github.com/iot-my-world/brain/pkg/device/sigbug/reading/battery/validator"
 	sigbugGPSReadingAdministrator "github.com/iot-my-world/brain/pkg/device/sigbug/reading/gps/administrator"
+	sigbugGPSReadingExporter "github.com/iot-my-world/brain/pkg/device/sigbug/reading/gps/exporter"
 	sigbugGPSReadingRecordHandler "github.com/iot-my-world/brain/pkg/device/sigbug/reading/gps/recordHandler"
 	sigbugGPSReadingValidator "github.com/iot-my-world/brain/pkg/device/sigbug/reading/gps/validator"
+	sigbugHeartbeatReadingAdministrator "github.com/iot-my-world/brain/pkg/device/sigbug/reading/heartbeat/administrator"
+	sigbugHeartbeatReadingRecordHandler "github.com/iot-my-world/brain/pkg/device/sigbug/reading/heartbeat/recordHandler"
+	sigbugHeartbeatReadingValidator "github.com/iot-my-world/brain/pkg/device/sigbug/reading/heartbeat/validator"
+	sigbugTemperatureReadingAdministrator "github.com/iot-my-world/brain/pkg/device/sigbug/reading/temperature/administrator"
+	sigbugTemperatureReadingRecordHandler "github.com/iot-my-world/brain/pkg/device/sigbug/reading/temperature/recordHandler"
+	sigbugTemperatureReadingValidator "github.com/iot-my-world/brain/pkg/device/sigbug/reading/temperature/validator"
 	sigbugRecordHandler "github.com/iot-my-world/brain/pkg/device/sigbug/recordHandler"
+	sigbugTripRecordHandler "github.com/iot-my-world/brain/pkg/device/sigbug/trip/recordHandler"
 	sigbugValidator "github.com/iot-my-world/brain/pkg/device/sigbug/validator"
+	geofenceAdministrator "github.com/iot-my-world/brain/pkg/geofence/administrator"
+	geofenceEventRecordHandler "github.com/iot-my-world/brain/pkg/geofence/event/recordHandler"
+	geofenceRecordHandler "github.com/iot-my-world/brain/pkg/geofence/recordHandler"
+	geofenceValidator "github.com/iot-my-world/brain/pkg/geofence/validator"
 	partyAdministrator "github.com/iot-my-world/brain/pkg/party/administrator"
 	clientAdministrator "github.com/iot-my-world/brain/pkg/party/client/administrator"
 	clientRecordHandler "github.com/iot-my-world/brain/pkg/party/client/recordHandler"
@@ -17,21 +43,37 @@ import (
 	companyValidator "github.com/iot-my-world/brain/pkg/party/company/validator"
 	partyRegistrar "github.com/iot-my-world/brain/pkg/party/registrar"
 	systemRecordHandler "github.com/iot-my-world/brain/pkg/party/system/recordHandler"
+	trackingReport "github.com/iot-my-world/brain/pkg/report/tracking"
+	tripReport "github.com/iot-my-world/brain/pkg/report/trip"
+	retentionPolicyAdministrator "github.com/iot-my-world/brain/pkg/retention/policy/administrator"
+	retentionPolicyRecordHandler "github.com/iot-my-world/brain/pkg/retention/policy/recordHandler"
+	retentionPolicyValidator "github.com/iot-my-world/brain/pkg/retention/policy/validator"
 	"github.com/iot-my-world/brain/pkg/search/identifier/id"
 	"github.com/iot-my-world/brain/pkg/search/identifier/name"
+	lockoutAdministrator "github.com/iot-my-world/brain/pkg/security/lockout/administrator"
 	"github.com/iot-my-world/brain/pkg/security/permission/administrator"
 	apiPermission "github.com/iot-my-world/brain/pkg/security/permission/api"
 	viewPermission "github.com/iot-my-world/brain/pkg/security/permission/view"
 	"github.com/iot-my-world/brain/pkg/security/role"
+	roleAdministrator "github.com/iot-my-world/brain/pkg/security/role/administrator"
 	"github.com/iot-my-world/brain/pkg/security/role/recordHandler"
 	roleRecordHandlerException "github.com/iot-my-world/brain/pkg/security/role/recordHandler/exception"
 	"github.com/iot-my-world/brain/pkg/security/role/setup/exception"
+	roleValidator "github.com/iot-my-world/brain/pkg/security/role/validator"
+	tokenRevocationAdministrator "github.com/iot-my-world/brain/pkg/security/token/revocation/administrator"
 	sigfoxBackendAdministrator "github.com/iot-my-world/brain/pkg/sigfox/backend/administrator"
+	sigfoxBackendDataCallbackMessageDeadLetterAdministrator "github.com/iot-my-world/brain/pkg/sigfox/backend/callback/data/message/deadLetter/administrator"
+	sigfoxBackendDataCallbackMessageDeadLetterRecordHandler "github.com/iot-my-world/brain/pkg/sigfox/backend/callback/data/message/deadLetter/recordHandler"
 	sigfoxBackendRecordHandler "github.com/iot-my-world/brain/pkg/sigfox/backend/recordHandler"
 	sigfoxBackendValidator "github.com/iot-my-world/brain/pkg/sigfox/backend/validator"
+	apiUserAuthenticator "github.com/iot-my-world/brain/pkg/user/api/authenticator"
 	humanUserAdministrator "github.com/iot-my-world/brain/pkg/user/human/administrator"
 	humanUserRecordHandler "github.com/iot-my-world/brain/pkg/user/human/recordHandler"
 	humanUserValidator "github.com/iot-my-world/brain/pkg/user/human/validator"
+	webhookDeliveryRecordHandler "github.com/iot-my-world/brain/pkg/webhook/delivery/recordHandler"
+	webhookSubscriptionAdministrator "github.com/iot-my-world/brain/pkg/webhook/subscription/administrator"
+	webhookSubscriptionRecordHandler "github.com/iot-my-world/brain/pkg/webhook/subscription/recordHandler"
+	webhookSubscriptionValidator "github.com/iot-my-world/brain/pkg/webhook/subscription/validator"
 )
 
 var CompanyAdmin = role.Role{
@@ -112,6 +154,27 @@ var initialRoles = func() []role.Role {
 	ClientAdmin.APIPermissions = append(ClientAdmin.APIPermissions, administrator.ClientAdminUserPermissions...)
 	ClientUser.APIPermissions = append(ClientUser.APIPermissions, administrator.ClientUserPermissions...)
 
+	// Server Authenticator
+	rootAPIPermissions = append(rootAPIPermissions, jsonRpcServerAuthenticator.SystemUserPermissions...)
+	CompanyAdmin.APIPermissions = append(CompanyAdmin.APIPermissions, jsonRpcServerAuthenticator.CompanyAdminUserPermissions...)
+	CompanyUser.APIPermissions = append(CompanyUser.APIPermissions, jsonRpcServerAuthenticator.CompanyUserPermissions...)
+	ClientAdmin.APIPermissions = append(ClientAdmin.APIPermissions, jsonRpcServerAuthenticator.ClientAdminUserPermissions...)
+	ClientUser.APIPermissions = append(ClientUser.APIPermissions, jsonRpcServerAuthenticator.ClientUserPermissions...)
+
+	// API User Authenticator
+	rootAPIPermissions = append(rootAPIPermissions, apiUserAuthenticator.SystemUserPermissions...)
+	CompanyAdmin.APIPermissions = append(CompanyAdmin.APIPermissions, apiUserAuthenticator.CompanyAdminUserPermissions...)
+	CompanyUser.APIPermissions = append(CompanyUser.APIPermissions, apiUserAuthenticator.CompanyUserPermissions...)
+	ClientAdmin.APIPermissions = append(ClientAdmin.APIPermissions, apiUserAuthenticator.ClientAdminUserPermissions...)
+	ClientUser.APIPermissions = append(ClientUser.APIPermissions, apiUserAuthenticator.ClientUserPermissions...)
+
+	// Token Revocation Administrator
+	rootAPIPermissions = append(rootAPIPermissions, tokenRevocationAdministrator.SystemUserPermissions...)
+	CompanyAdmin.APIPermissions = append(CompanyAdmin.APIPermissions, tokenRevocationAdministrator.CompanyAdminUserPermissions...)
+	CompanyUser.APIPermissions = append(CompanyUser.APIPermissions, tokenRevocationAdministrator.CompanyUserPermissions...)
+	ClientAdmin.APIPermissions = append(ClientAdmin.APIPermissions, tokenRevocationAdministrator.ClientAdminUserPermissions...)
+	ClientUser.APIPermissions = append(ClientUser.APIPermissions, tokenRevocationAdministrator.ClientUserPermissions...)
+
 	// Human User RecordHandler
 	rootAPIPermissions = append(rootAPIPermissions, humanUserRecordHandler.SystemUserPermissions...)
 	CompanyAdmin.APIPermissions = append(CompanyAdmin.APIPermissions, humanUserRecordHandler.CompanyAdminUserPermissions...)
@@ -188,6 +251,12 @@ var initialRoles = func() []role.Role {
 	CompanyUser.APIPermissions = append(CompanyUser.APIPermissions, sigbugAdministrator.CompanyUserPermissions...)
 	ClientAdmin.APIPermissions = append(ClientAdmin.APIPermissions, sigbugAdministrator.ClientAdminUserPermissions...)
 	ClientUser.APIPermissions = append(ClientUser.APIPermissions, sigbugAdministrator.ClientUserPermissions...)
+	// Sigbug Importer
+	rootAPIPermissions = append(rootAPIPermissions, sigbugImporter.SystemUserPermissions...)
+	CompanyAdmin.APIPermissions = append(CompanyAdmin.APIPermissions, sigbugImporter.CompanyAdminUserPermissions...)
+	CompanyUser.APIPermissions = append(CompanyUser.APIPermissions, sigbugImporter.CompanyUserPermissions...)
+	ClientAdmin.APIPermissions = append(ClientAdmin.APIPermissions, sigbugImporter.ClientAdminUserPermissions...)
+	ClientUser.APIPermissions = append(ClientUser.APIPermissions, sigbugImporter.ClientUserPermissions...)
 	// Sigbug RecordHandler
 	rootAPIPermissions = append(rootAPIPermissions, sigbugRecordHandler.SystemUserPermissions...)
 	CompanyAdmin.APIPermissions = append(CompanyAdmin.APIPermissions, sigbugRecordHandler.CompanyAdminUserPermissions...)
@@ -200,6 +269,12 @@ var initialRoles = func() []role.Role {
 	CompanyUser.APIPermissions = append(CompanyUser.APIPermissions, sigbugValidator.CompanyUserPermissions...)
 	ClientAdmin.APIPermissions = append(ClientAdmin.APIPermissions, sigbugValidator.ClientAdminUserPermissions...)
 	ClientUser.APIPermissions = append(ClientUser.APIPermissions, sigbugValidator.ClientUserPermissions...)
+	// Sigbug Downlink RecordHandler
+	rootAPIPermissions = append(rootAPIPermissions, sigbugDownlinkRecordHandler.SystemUserPermissions...)
+	CompanyAdmin.APIPermissions = append(CompanyAdmin.APIPermissions, sigbugDownlinkRecordHandler.CompanyAdminUserPermissions...)
+	CompanyUser.APIPermissions = append(CompanyUser.APIPermissions, sigbugDownlinkRecordHandler.CompanyUserPermissions...)
+	ClientAdmin.APIPermissions = append(ClientAdmin.APIPermissions, sigbugDownlinkRecordHandler.ClientAdminUserPermissions...)
+	ClientUser.APIPermissions = append(ClientUser.APIPermissions, sigbugDownlinkRecordHandler.ClientUserPermissions...)
 
 	// Sigbug GPS Reading Administrator
 	rootAPIPermissions = append(rootAPIPermissions, sigbugGPSReadingAdministrator.SystemUserPermissions...)
@@ -207,6 +282,12 @@ var initialRoles = func() []role.Role {
 	CompanyUser.APIPermissions = append(CompanyUser.APIPermissions, sigbugGPSReadingAdministrator.CompanyUserPermissions...)
 	ClientAdmin.APIPermissions = append(ClientAdmin.APIPermissions, sigbugGPSReadingAdministrator.ClientAdminUserPermissions...)
 	ClientUser.APIPermissions = append(ClientUser.APIPermissions, sigbugGPSReadingAdministrator.ClientUserPermissions...)
+	// Sigbug GPS Reading Exporter
+	rootAPIPermissions = append(rootAPIPermissions, sigbugGPSReadingExporter.SystemUserPermissions...)
+	CompanyAdmin.APIPermissions = append(CompanyAdmin.APIPermissions, sigbugGPSReadingExporter.CompanyAdminUserPermissions...)
+	CompanyUser.APIPermissions = append(CompanyUser.APIPermissions, sigbugGPSReadingExporter.CompanyUserPermissions...)
+	ClientAdmin.APIPermissions = append(ClientAdmin.APIPermissions, sigbugGPSReadingExporter.ClientAdminUserPermissions...)
+	ClientUser.APIPermissions = append(ClientUser.APIPermissions, sigbugGPSReadingExporter.ClientUserPermissions...)
 	// Sigbug GPS Reading RecordHandler
 	rootAPIPermissions = append(rootAPIPermissions, sigbugGPSReadingRecordHandler.SystemUserPermissions...)
 	CompanyAdmin.APIPermissions = append(CompanyAdmin.APIPermissions, sigbugGPSReadingRecordHandler.CompanyAdminUserPermissions...)
@@ -220,6 +301,84 @@ var initialRoles = func() []role.Role {
 	ClientAdmin.APIPermissions = append(ClientAdmin.APIPermissions, sigbugGPSReadingValidator.ClientAdminUserPermissions...)
 	ClientUser.APIPermissions = append(ClientUser.APIPermissions, sigbugGPSReadingValidator.ClientUserPermissions...)
 
+	// Sigbug Battery Reading Administrator
+	rootAPIPermissions = append(rootAPIPermissions, sigbugBatteryReadingAdministrator.SystemUserPermissions...)
+	CompanyAdmin.APIPermissions = append(CompanyAdmin.APIPermissions, sigbugBatteryReadingAdministrator.CompanyAdminUserPermissions...)
+	CompanyUser.APIPermissions = append(CompanyUser.APIPermissions, sigbugBatteryReadingAdministrator.CompanyUserPermissions...)
+	ClientAdmin.APIPermissions = append(ClientAdmin.APIPermissions, sigbugBatteryReadingAdministrator.ClientAdminUserPermissions...)
+	ClientUser.APIPermissions = append(ClientUser.APIPermissions, sigbugBatteryReadingAdministrator.ClientUserPermissions...)
+	// Sigbug Battery Reading RecordHandler
+	rootAPIPermissions = append(rootAPIPermissions, sigbugBatteryReadingRecordHandler.SystemUserPermissions...)
+	CompanyAdmin.APIPermissions = append(CompanyAdmin.APIPermissions, sigbugBatteryReadingRecordHandler.CompanyAdminUserPermissions...)
+	CompanyUser.APIPermissions = append(CompanyUser.APIPermissions, sigbugBatteryReadingRecordHandler.CompanyUserPermissions...)
+	ClientAdmin.APIPermissions = append(ClientAdmin.APIPermissions, sigbugBatteryReadingRecordHandler.ClientAdminUserPermissions...)
+	ClientUser.APIPermissions = append(ClientUser.APIPermissions, sigbugBatteryReadingRecordHandler.ClientUserPermissions...)
+	// Sigbug Battery Reading Validator
+	rootAPIPermissions = append(rootAPIPermissions, sigbugBatteryReadingValidator.SystemUserPermissions...)
+	CompanyAdmin.APIPermissions = append(CompanyAdmin.APIPermissions, sigbugBatteryReadingValidator.CompanyAdminUserPermissions...)
+	CompanyUser.APIPermissions = append(CompanyUser.APIPermissions, sigbugBatteryReadingValidator.CompanyUserPermissions...)
+	ClientAdmin.APIPermissions = append(ClientAdmin.APIPermissions, sigbugBatteryReadingValidator.ClientAdminUserPermissions...)
+	ClientUser.APIPermissions = append(ClientUser.APIPermissions, sigbugBatteryReadingValidator.ClientUserPermissions...)
+
+	// Sigbug Temperature Reading Administrator
+	rootAPIPermissions = append(rootAPIPermissions, sigbugTemperatureReadingAdministrator.SystemUserPermissions...)
+	CompanyAdmin.APIPermissions = append(CompanyAdmin.APIPermissions, sigbugTemperatureReadingAdministrator.CompanyAdminUserPermissions...)
+	CompanyUser.APIPermissions = append(CompanyUser.APIPermissions, sigbugTemperatureReadingAdministrator.CompanyUserPermissions...)
+	ClientAdmin.APIPermissions = append(ClientAdmin.APIPermissions, sigbugTemperatureReadingAdministrator.ClientAdminUserPermissions...)
+	ClientUser.APIPermissions = append(ClientUser.APIPermissions, sigbugTemperatureReadingAdministrator.ClientUserPermissions...)
+	// Sigbug Temperature Reading RecordHandler
+	rootAPIPermissions = append(rootAPIPermissions, sigbugTemperatureReadingRecordHandler.SystemUserPermissions...)
+	CompanyAdmin.APIPermissions = append(CompanyAdmin.APIPermissions, sigbugTemperatureReadingRecordHandler.CompanyAdminUserPermissions...)
+	CompanyUser.APIPermissions = append(CompanyUser.APIPermissions, sigbugTemperatureReadingRecordHandler.CompanyUserPermissions...)
+	ClientAdmin.APIPermissions = append(ClientAdmin.APIPermissions, sigbugTemperatureReadingRecordHandler.ClientAdminUserPermissions...)
+	ClientUser.APIPermissions = append(ClientUser.APIPermissions, sigbugTemperatureReadingRecordHandler.ClientUserPermissions...)
+	// Sigbug Temperature Reading Validator
+	rootAPIPermissions = append(rootAPIPermissions, sigbugTemperatureReadingValidator.SystemUserPermissions...)
+	CompanyAdmin.APIPermissions = append(CompanyAdmin.APIPermissions, sigbugTemperatureReadingValidator.CompanyAdminUserPermissions...)
+	CompanyUser.APIPermissions = append(CompanyUser.APIPermissions, sigbugTemperatureReadingValidator.CompanyUserPermissions...)
+	ClientAdmin.APIPermissions = append(ClientAdmin.APIPermissions, sigbugTemperatureReadingValidator.ClientAdminUserPermissions...)
+	ClientUser.APIPermissions = append(ClientUser.APIPermissions, sigbugTemperatureReadingValidator.ClientUserPermissions...)
+
+	// Sigbug Heartbeat Reading Administrator
+	rootAPIPermissions = append(rootAPIPermissions, sigbugHeartbeatReadingAdministrator.SystemUserPermissions...)
+	CompanyAdmin.APIPermissions = append(CompanyAdmin.APIPermissions, sigbugHeartbeatReadingAdministrator.CompanyAdminUserPermissions...)
+	CompanyUser.APIPermissions = append(CompanyUser.APIPermissions, sigbugHeartbeatReadingAdministrator.CompanyUserPermissions...)
+	ClientAdmin.APIPermissions = append(ClientAdmin.APIPermissions, sigbugHeartbeatReadingAdministrator.ClientAdminUserPermissions...)
+	ClientUser.APIPermissions = append(ClientUser.APIPermissions, sigbugHeartbeatReadingAdministrator.ClientUserPermissions...)
+	// Sigbug Heartbeat Reading RecordHandler
+	rootAPIPermissions = append(rootAPIPermissions, sigbugHeartbeatReadingRecordHandler.SystemUserPermissions...)
+	CompanyAdmin.APIPermissions = append(CompanyAdmin.APIPermissions, sigbugHeartbeatReadingRecordHandler.CompanyAdminUserPermissions...)
+	CompanyUser.APIPermissions = append(CompanyUser.APIPermissions, sigbugHeartbeatReadingRecordHandler.CompanyUserPermissions...)
+	ClientAdmin.APIPermissions = append(ClientAdmin.APIPermissions, sigbugHeartbeatReadingRecordHandler.ClientAdminUserPermissions...)
+	ClientUser.APIPermissions = append(ClientUser.APIPermissions, sigbugHeartbeatReadingRecordHandler.ClientUserPermissions...)
+	// Sigbug Heartbeat Reading Validator
+	rootAPIPermissions = append(rootAPIPermissions, sigbugHeartbeatReadingValidator.SystemUserPermissions...)
+	CompanyAdmin.APIPermissions = append(CompanyAdmin.APIPermissions, sigbugHeartbeatReadingValidator.CompanyAdminUserPermissions...)
+	CompanyUser.APIPermissions = append(CompanyUser.APIPermissions, sigbugHeartbeatReadingValidator.CompanyUserPermissions...)
+	ClientAdmin.APIPermissions = append(ClientAdmin.APIPermissions, sigbugHeartbeatReadingValidator.ClientAdminUserPermissions...)
+	ClientUser.APIPermissions = append(ClientUser.APIPermissions, sigbugHeartbeatReadingValidator.ClientUserPermissions...)
+
+	// Sigbug Event RecordHandler
+	rootAPIPermissions = append(rootAPIPermissions, sigbugEventRecordHandler.SystemUserPermissions...)
+	CompanyAdmin.APIPermissions = append(CompanyAdmin.APIPermissions, sigbugEventRecordHandler.CompanyAdminUserPermissions...)
+	CompanyUser.APIPermissions = append(CompanyUser.APIPermissions, sigbugEventRecordHandler.CompanyUserPermissions...)
+	ClientAdmin.APIPermissions = append(ClientAdmin.APIPermissions, sigbugEventRecordHandler.ClientAdminUserPermissions...)
+	ClientUser.APIPermissions = append(ClientUser.APIPermissions, sigbugEventRecordHandler.ClientUserPermissions...)
+
+	// Sigbug Trip RecordHandler
+	rootAPIPermissions = append(rootAPIPermissions, sigbugTripRecordHandler.SystemUserPermissions...)
+	CompanyAdmin.APIPermissions = append(CompanyAdmin.APIPermissions, sigbugTripRecordHandler.CompanyAdminUserPermissions...)
+	CompanyUser.APIPermissions = append(CompanyUser.APIPermissions, sigbugTripRecordHandler.CompanyUserPermissions...)
+	ClientAdmin.APIPermissions = append(ClientAdmin.APIPermissions, sigbugTripRecordHandler.ClientAdminUserPermissions...)
+	ClientUser.APIPermissions = append(ClientUser.APIPermissions, sigbugTripRecordHandler.ClientUserPermissions...)
+
+	// Sigbug Monitor
+	rootAPIPermissions = append(rootAPIPermissions, sigbugMonitor.SystemUserPermissions...)
+	CompanyAdmin.APIPermissions = append(CompanyAdmin.APIPermissions, sigbugMonitor.CompanyAdminUserPermissions...)
+	CompanyUser.APIPermissions = append(CompanyUser.APIPermissions, sigbugMonitor.CompanyUserPermissions...)
+	ClientAdmin.APIPermissions = append(ClientAdmin.APIPermissions, sigbugMonitor.ClientAdminUserPermissions...)
+	ClientUser.APIPermissions = append(ClientUser.APIPermissions, sigbugMonitor.ClientUserPermissions...)
+
 	// Sigfox Backend Administrator
 	rootAPIPermissions = append(rootAPIPermissions, sigfoxBackendAdministrator.SystemUserPermissions...)
 	CompanyAdmin.APIPermissions = append(CompanyAdmin.APIPermissions, sigfoxBackendAdministrator.CompanyAdminUserPermissions...)
@@ -238,6 +397,166 @@ var initialRoles = func() []role.Role {
 	CompanyUser.APIPermissions = append(CompanyUser.APIPermissions, sigfoxBackendValidator.CompanyUserPermissions...)
 	ClientAdmin.APIPermissions = append(ClientAdmin.APIPermissions, sigfoxBackendValidator.ClientAdminUserPermissions...)
 	ClientUser.APIPermissions = append(ClientUser.APIPermissions, sigfoxBackendValidator.ClientUserPermissions...)
+	// Sigfox Backend Data Callback Message Dead Letter Administrator
+	rootAPIPermissions = append(rootAPIPermissions, sigfoxBackendDataCallbackMessageDeadLetterAdministrator.SystemUserPermissions...)
+	CompanyAdmin.APIPermissions = append(CompanyAdmin.APIPermissions, sigfoxBackendDataCallbackMessageDeadLetterAdministrator.CompanyAdminUserPermissions...)
+	CompanyUser.APIPermissions = append(CompanyUser.APIPermissions, sigfoxBackendDataCallbackMessageDeadLetterAdministrator.CompanyUserPermissions...)
+	ClientAdmin.APIPermissions = append(ClientAdmin.APIPermissions, sigfoxBackendDataCallbackMessageDeadLetterAdministrator.ClientAdminUserPermissions...)
+	ClientUser.APIPermissions = append(ClientUser.APIPermissions, sigfoxBackendDataCallbackMessageDeadLetterAdministrator.ClientUserPermissions...)
+	// Sigfox Backend Data Callback Message Dead Letter RecordHandler
+	rootAPIPermissions = append(rootAPIPermissions, sigfoxBackendDataCallbackMessageDeadLetterRecordHandler.SystemUserPermissions...)
+	CompanyAdmin.APIPermissions = append(CompanyAdmin.APIPermissions, sigfoxBackendDataCallbackMessageDeadLetterRecordHandler.CompanyAdminUserPermissions...)
+	CompanyUser.APIPermissions = append(CompanyUser.APIPermissions, sigfoxBackendDataCallbackMessageDeadLetterRecordHandler.CompanyUserPermissions...)
+	ClientAdmin.APIPermissions = append(ClientAdmin.APIPermissions, sigfoxBackendDataCallbackMessageDeadLetterRecordHandler.ClientAdminUserPermissions...)
+	ClientUser.APIPermissions = append(ClientUser.APIPermissions, sigfoxBackendDataCallbackMessageDeadLetterRecordHandler.ClientUserPermissions...)
+
+	// Geofence Administrator
+	rootAPIPermissions = append(rootAPIPermissions, geofenceAdministrator.SystemUserPermissions...)
+	CompanyAdmin.APIPermissions = append(CompanyAdmin.APIPermissions, geofenceAdministrator.CompanyAdminUserPermissions...)
+	CompanyUser.APIPermissions = append(CompanyUser.APIPermissions, geofenceAdministrator.CompanyUserPermissions...)
+	ClientAdmin.APIPermissions = append(ClientAdmin.APIPermissions, geofenceAdministrator.ClientAdminUserPermissions...)
+	ClientUser.APIPermissions = append(ClientUser.APIPermissions, geofenceAdministrator.ClientUserPermissions...)
+	// Geofence RecordHandler
+	rootAPIPermissions = append(rootAPIPermissions, geofenceRecordHandler.SystemUserPermissions...)
+	CompanyAdmin.APIPermissions = append(CompanyAdmin.APIPermissions, geofenceRecordHandler.CompanyAdminUserPermissions...)
+	CompanyUser.APIPermissions = append(CompanyUser.APIPermissions, geofenceRecordHandler.CompanyUserPermissions...)
+	ClientAdmin.APIPermissions = append(ClientAdmin.APIPermissions, geofenceRecordHandler.ClientAdminUserPermissions...)
+	ClientUser.APIPermissions = append(ClientUser.APIPermissions, geofenceRecordHandler.ClientUserPermissions...)
+	// Geofence Validator
+	rootAPIPermissions = append(rootAPIPermissions, geofenceValidator.SystemUserPermissions...)
+	CompanyAdmin.APIPermissions = append(CompanyAdmin.APIPermissions, geofenceValidator.CompanyAdminUserPermissions...)
+	CompanyUser.APIPermissions = append(CompanyUser.APIPermissions, geofenceValidator.CompanyUserPermissions...)
+	ClientAdmin.APIPermissions = append(ClientAdmin.APIPermissions, geofenceValidator.ClientAdminUserPermissions...)
+	ClientUser.APIPermissions = append(ClientUser.APIPermissions, geofenceValidator.ClientUserPermissions...)
+
+	// Geofence Event RecordHandler
+	rootAPIPermissions = append(rootAPIPermissions, geofenceEventRecordHandler.SystemUserPermissions...)
+	CompanyAdmin.APIPermissions = append(CompanyAdmin.APIPermissions, geofenceEventRecordHandler.CompanyAdminUserPermissions...)
+	CompanyUser.APIPermissions = append(CompanyUser.APIPermissions, geofenceEventRecordHandler.CompanyUserPermissions...)
+	ClientAdmin.APIPermissions = append(ClientAdmin.APIPermissions, geofenceEventRecordHandler.ClientAdminUserPermissions...)
+	ClientUser.APIPermissions = append(ClientUser.APIPermissions, geofenceEventRecordHandler.ClientUserPermissions...)
+
+	// Alert Rule Administrator
+	rootAPIPermissions = append(rootAPIPermissions, alertRuleAdministrator.SystemUserPermissions...)
+	CompanyAdmin.APIPermissions = append(CompanyAdmin.APIPermissions, alertRuleAdministrator.CompanyAdminUserPermissions...)
+	CompanyUser.APIPermissions = append(CompanyUser.APIPermissions, alertRuleAdministrator.CompanyUserPermissions...)
+	ClientAdmin.APIPermissions = append(ClientAdmin.APIPermissions, alertRuleAdministrator.ClientAdminUserPermissions...)
+	ClientUser.APIPermissions = append(ClientUser.APIPermissions, alertRuleAdministrator.ClientUserPermissions...)
+	// Alert Rule RecordHandler
+	rootAPIPermissions = append(rootAPIPermissions, alertRuleRecordHandler.SystemUserPermissions...)
+	CompanyAdmin.APIPermissions = append(CompanyAdmin.APIPermissions, alertRuleRecordHandler.CompanyAdminUserPermissions...)
+	CompanyUser.APIPermissions = append(CompanyUser.APIPermissions, alertRuleRecordHandler.CompanyUserPermissions...)
+	ClientAdmin.APIPermissions = append(ClientAdmin.APIPermissions, alertRuleRecordHandler.ClientAdminUserPermissions...)
+	ClientUser.APIPermissions = append(ClientUser.APIPermissions, alertRuleRecordHandler.ClientUserPermissions...)
+	// Alert Rule Validator
+	rootAPIPermissions = append(rootAPIPermissions, alertRuleValidator.SystemUserPermissions...)
+	CompanyAdmin.APIPermissions = append(CompanyAdmin.APIPermissions, alertRuleValidator.CompanyAdminUserPermissions...)
+	CompanyUser.APIPermissions = append(CompanyUser.APIPermissions, alertRuleValidator.CompanyUserPermissions...)
+	ClientAdmin.APIPermissions = append(ClientAdmin.APIPermissions, alertRuleValidator.ClientAdminUserPermissions...)
+	ClientUser.APIPermissions = append(ClientUser.APIPermissions, alertRuleValidator.ClientUserPermissions...)
+
+	// Alert Delivery RecordHandler
+	rootAPIPermissions = append(rootAPIPermissions, alertDeliveryRecordHandler.SystemUserPermissions...)
+	CompanyAdmin.APIPermissions = append(CompanyAdmin.APIPermissions, alertDeliveryRecordHandler.CompanyAdminUserPermissions...)
+	CompanyUser.APIPermissions = append(CompanyUser.APIPermissions, alertDeliveryRecordHandler.CompanyUserPermissions...)
+	ClientAdmin.APIPermissions = append(ClientAdmin.APIPermissions, alertDeliveryRecordHandler.ClientAdminUserPermissions...)
+	ClientUser.APIPermissions = append(ClientUser.APIPermissions, alertDeliveryRecordHandler.ClientUserPermissions...)
+
+	// Webhook Subscription Administrator
+	rootAPIPermissions = append(rootAPIPermissions, webhookSubscriptionAdministrator.SystemUserPermissions...)
+	CompanyAdmin.APIPermissions = append(CompanyAdmin.APIPermissions, webhookSubscriptionAdministrator.CompanyAdminUserPermissions...)
+	CompanyUser.APIPermissions = append(CompanyUser.APIPermissions, webhookSubscriptionAdministrator.CompanyUserPermissions...)
+	ClientAdmin.APIPermissions = append(ClientAdmin.APIPermissions, webhookSubscriptionAdministrator.ClientAdminUserPermissions...)
+	ClientUser.APIPermissions = append(ClientUser.APIPermissions, webhookSubscriptionAdministrator.ClientUserPermissions...)
+	// Webhook Subscription RecordHandler
+	rootAPIPermissions = append(rootAPIPermissions, webhookSubscriptionRecordHandler.SystemUserPermissions...)
+	CompanyAdmin.APIPermissions = append(CompanyAdmin.APIPermissions, webhookSubscriptionRecordHandler.CompanyAdminUserPermissions...)
+	CompanyUser.APIPermissions = append(CompanyUser.APIPermissions, webhookSubscriptionRecordHandler.CompanyUserPermissions...)
+	ClientAdmin.APIPermissions = append(ClientAdmin.APIPermissions, webhookSubscriptionRecordHandler.ClientAdminUserPermissions...)
+	ClientUser.APIPermissions = append(ClientUser.APIPermissions, webhookSubscriptionRecordHandler.ClientUserPermissions...)
+	// Webhook Subscription Validator
+	rootAPIPermissions = append(rootAPIPermissions, webhookSubscriptionValidator.SystemUserPermissions...)
+	CompanyAdmin.APIPermissions = append(CompanyAdmin.APIPermissions, webhookSubscriptionValidator.CompanyAdminUserPermissions...)
+	CompanyUser.APIPermissions = append(CompanyUser.APIPermissions, webhookSubscriptionValidator.CompanyUserPermissions...)
+	ClientAdmin.APIPermissions = append(ClientAdmin.APIPermissions, webhookSubscriptionValidator.ClientAdminUserPermissions...)
+	ClientUser.APIPermissions = append(ClientUser.APIPermissions, webhookSubscriptionValidator.ClientUserPermissions...)
+
+	// Webhook Delivery RecordHandler
+	rootAPIPermissions = append(rootAPIPermissions, webhookDeliveryRecordHandler.SystemUserPermissions...)
+	CompanyAdmin.APIPermissions = append(CompanyAdmin.APIPermissions, webhookDeliveryRecordHandler.CompanyAdminUserPermissions...)
+	CompanyUser.APIPermissions = append(CompanyUser.APIPermissions, webhookDeliveryRecordHandler.CompanyUserPermissions...)
+	ClientAdmin.APIPermissions = append(ClientAdmin.APIPermissions, webhookDeliveryRecordHandler.ClientAdminUserPermissions...)
+	ClientUser.APIPermissions = append(ClientUser.APIPermissions, webhookDeliveryRecordHandler.ClientUserPermissions...)
+
+	// Audit Log
+	rootAPIPermissions = append(rootAPIPermissions, auditLogger.SystemUserPermissions...)
+	CompanyAdmin.APIPermissions = append(CompanyAdmin.APIPermissions, auditLogger.CompanyAdminUserPermissions...)
+	CompanyUser.APIPermissions = append(CompanyUser.APIPermissions, auditLogger.CompanyUserPermissions...)
+	ClientAdmin.APIPermissions = append(ClientAdmin.APIPermissions, auditLogger.ClientAdminUserPermissions...)
+	ClientUser.APIPermissions = append(ClientUser.APIPermissions, auditLogger.ClientUserPermissions...)
+
+	// Lockout Administrator
+	rootAPIPermissions = append(rootAPIPermissions, lockoutAdministrator.SystemUserPermissions...)
+	CompanyAdmin.APIPermissions = append(CompanyAdmin.APIPermissions, lockoutAdministrator.CompanyAdminUserPermissions...)
+	CompanyUser.APIPermissions = append(CompanyUser.APIPermissions, lockoutAdministrator.CompanyUserPermissions...)
+	ClientAdmin.APIPermissions = append(ClientAdmin.APIPermissions, lockoutAdministrator.ClientAdminUserPermissions...)
+	ClientUser.APIPermissions = append(ClientUser.APIPermissions, lockoutAdministrator.ClientUserPermissions...)
+
+	// Role Administrator
+	rootAPIPermissions = append(rootAPIPermissions, roleAdministrator.SystemUserPermissions...)
+	CompanyAdmin.APIPermissions = append(CompanyAdmin.APIPermissions, roleAdministrator.CompanyAdminUserPermissions...)
+	CompanyUser.APIPermissions = append(CompanyUser.APIPermissions, roleAdministrator.CompanyUserPermissions...)
+	ClientAdmin.APIPermissions = append(ClientAdmin.APIPermissions, roleAdministrator.ClientAdminUserPermissions...)
+	ClientUser.APIPermissions = append(ClientUser.APIPermissions, roleAdministrator.ClientUserPermissions...)
+
+	// Role Validator
+	rootAPIPermissions = append(rootAPIPermissions, roleValidator.SystemUserPermissions...)
+	CompanyAdmin.APIPermissions = append(CompanyAdmin.APIPermissions, roleValidator.CompanyAdminUserPermissions...)
+	CompanyUser.APIPermissions = append(CompanyUser.APIPermissions, roleValidator.CompanyUserPermissions...)
+	ClientAdmin.APIPermissions = append(ClientAdmin.APIPermissions, roleValidator.ClientAdminUserPermissions...)
+	ClientUser.APIPermissions = append(ClientUser.APIPermissions, roleValidator.ClientUserPermissions...)
+
+	// Tracking Report
+	rootAPIPermissions = append(rootAPIPermissions, trackingReport.SystemUserPermissions...)
+	CompanyAdmin.APIPermissions = append(CompanyAdmin.APIPermissions, trackingReport.CompanyAdminUserPermissions...)
+	CompanyUser.APIPermissions = append(CompanyUser.APIPermissions, trackingReport.CompanyUserPermissions...)
+	ClientAdmin.APIPermissions = append(ClientAdmin.APIPermissions, trackingReport.ClientAdminUserPermissions...)
+	ClientUser.APIPermissions = append(ClientUser.APIPermissions, trackingReport.ClientUserPermissions...)
+
+	// Trip Report
+	rootAPIPermissions = append(rootAPIPermissions, tripReport.SystemUserPermissions...)
+	CompanyAdmin.APIPermissions = append(CompanyAdmin.APIPermissions, tripReport.CompanyAdminUserPermissions...)
+	CompanyUser.APIPermissions = append(CompanyUser.APIPermissions, tripReport.CompanyUserPermissions...)
+	ClientAdmin.APIPermissions = append(ClientAdmin.APIPermissions, tripReport.ClientAdminUserPermissions...)
+	ClientUser.APIPermissions = append(ClientUser.APIPermissions, tripReport.ClientUserPermissions...)
+
+	// Retention Policy RecordHandler
+	rootAPIPermissions = append(rootAPIPermissions, retentionPolicyRecordHandler.SystemUserPermissions...)
+	CompanyAdmin.APIPermissions = append(CompanyAdmin.APIPermissions, retentionPolicyRecordHandler.CompanyAdminUserPermissions...)
+	CompanyUser.APIPermissions = append(CompanyUser.APIPermissions, retentionPolicyRecordHandler.CompanyUserPermissions...)
+	ClientAdmin.APIPermissions = append(ClientAdmin.APIPermissions, retentionPolicyRecordHandler.ClientAdminUserPermissions...)
+	ClientUser.APIPermissions = append(ClientUser.APIPermissions, retentionPolicyRecordHandler.ClientUserPermissions...)
+
+	// Retention Policy Validator
+	rootAPIPermissions = append(rootAPIPermissions, retentionPolicyValidator.SystemUserPermissions...)
+	CompanyAdmin.APIPermissions = append(CompanyAdmin.APIPermissions, retentionPolicyValidator.CompanyAdminUserPermissions...)
+	CompanyUser.APIPermissions = append(CompanyUser.APIPermissions, retentionPolicyValidator.CompanyUserPermissions...)
+	ClientAdmin.APIPermissions = append(ClientAdmin.APIPermissions, retentionPolicyValidator.ClientAdminUserPermissions...)
+	ClientUser.APIPermissions = append(ClientUser.APIPermissions, retentionPolicyValidator.ClientUserPermissions...)
+
+	// Retention Policy Administrator
+	rootAPIPermissions = append(rootAPIPermissions, retentionPolicyAdministrator.SystemUserPermissions...)
+	CompanyAdmin.APIPermissions = append(CompanyAdmin.APIPermissions, retentionPolicyAdministrator.CompanyAdminUserPermissions...)
+	CompanyUser.APIPermissions = append(CompanyUser.APIPermissions, retentionPolicyAdministrator.CompanyUserPermissions...)
+	ClientAdmin.APIPermissions = append(ClientAdmin.APIPermissions, retentionPolicyAdministrator.ClientAdminUserPermissions...)
+	ClientUser.APIPermissions = append(ClientUser.APIPermissions, retentionPolicyAdministrator.ClientUserPermissions...)
+
+	// Websocket Hub
+	rootAPIPermissions = append(rootAPIPermissions, websocket.SystemUserPermissions...)
+	CompanyAdmin.APIPermissions = append(CompanyAdmin.APIPermissions, websocket.CompanyAdminUserPermissions...)
+	CompanyUser.APIPermissions = append(CompanyUser.APIPermissions, websocket.CompanyUserPermissions...)
+	ClientAdmin.APIPermissions = append(ClientAdmin.APIPermissions, websocket.ClientAdminUserPermissions...)
+	ClientUser.APIPermissions = append(ClientUser.APIPermissions, websocket.ClientUserPermissions...)
 
 	// Register roles here
 	allRoles := []role.Role{
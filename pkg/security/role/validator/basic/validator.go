@@ -0,0 +1,279 @@
+package basic
+
+import (
+	brainException "github.com/iot-my-world/brain/internal/exception"
+	"github.com/iot-my-world/brain/internal/log"
+	"github.com/iot-my-world/brain/pkg/action"
+	"github.com/iot-my-world/brain/pkg/party"
+	partyAdministrator "github.com/iot-my-world/brain/pkg/party/administrator"
+	partyAdministratorException "github.com/iot-my-world/brain/pkg/party/administrator/exception"
+	"github.com/iot-my-world/brain/pkg/search/criterion"
+	exactTextCriterion "github.com/iot-my-world/brain/pkg/search/criterion/exact/text"
+	humanUserLoginClaims "github.com/iot-my-world/brain/pkg/security/claims/login/user/human"
+	permissionAdministrator "github.com/iot-my-world/brain/pkg/security/permission/administrator"
+	"github.com/iot-my-world/brain/pkg/security/permission/api"
+	"github.com/iot-my-world/brain/pkg/security/permission/view"
+	roleAction "github.com/iot-my-world/brain/pkg/security/role/action"
+	roleRecordHandler "github.com/iot-my-world/brain/pkg/security/role/recordHandler"
+	roleValidator "github.com/iot-my-world/brain/pkg/security/role/validator"
+	roleValidatorException "github.com/iot-my-world/brain/pkg/security/role/validator/exception"
+	"github.com/iot-my-world/brain/pkg/validate/reasonInvalid"
+)
+
+type validator struct {
+	partyAdministrator      partyAdministrator.Administrator
+	permissionAdministrator permissionAdministrator.Administrator
+	roleRecordHandler       roleRecordHandler.RecordHandler
+	actionIgnoredReasons    map[action.Action]reasonInvalid.IgnoredReasonsInvalid
+}
+
+func New(
+	partyAdministrator partyAdministrator.Administrator,
+	permissionAdministrator permissionAdministrator.Administrator,
+	roleRecordHandler roleRecordHandler.RecordHandler,
+) roleValidator.Validator {
+
+	actionIgnoredReasons := map[action.Action]reasonInvalid.IgnoredReasonsInvalid{
+		roleAction.Create: {
+			ReasonsInvalid: map[string][]reasonInvalid.Type{
+				"id": {
+					reasonInvalid.Blank,
+				},
+			},
+		},
+		roleAction.UpdateAllowedFields: {
+			ReasonsInvalid: map[string][]reasonInvalid.Type{},
+		},
+		roleAction.Assign: {
+			ReasonsInvalid: map[string][]reasonInvalid.Type{},
+		},
+	}
+
+	return &validator{
+		partyAdministrator:      partyAdministrator,
+		permissionAdministrator: permissionAdministrator,
+		roleRecordHandler:       roleRecordHandler,
+		actionIgnoredReasons:    actionIgnoredReasons,
+	}
+}
+
+func (v *validator) ValidateValidateRequest(request *roleValidator.ValidateRequest) error {
+	reasonsInvalid := make([]string, 0)
+
+	if request.Claims == nil {
+		reasonsInvalid = append(reasonsInvalid, "claims are nil")
+	}
+
+	if len(reasonsInvalid) > 0 {
+		return brainException.RequestInvalid{Reasons: reasonsInvalid}
+	}
+	return nil
+}
+
+func (v *validator) Validate(request *roleValidator.ValidateRequest) (*roleValidator.ValidateResponse, error) {
+	if err := v.ValidateValidateRequest(request); err != nil {
+		return nil, err
+	}
+
+	allReasonsInvalid := make([]reasonInvalid.ReasonInvalid, 0)
+	roleToValidate := &request.Role
+
+	if (*roleToValidate).Id == "" {
+		allReasonsInvalid = append(allReasonsInvalid, reasonInvalid.ReasonInvalid{
+			Field: "id",
+			Type:  reasonInvalid.Blank,
+			Help:  "cannot be blank",
+			Data:  (*roleToValidate).Id,
+		})
+	}
+
+	if (*roleToValidate).Name == "" {
+		allReasonsInvalid = append(allReasonsInvalid, reasonInvalid.ReasonInvalid{
+			Field: "name",
+			Type:  reasonInvalid.Blank,
+			Help:  "cannot be blank",
+			Data:  (*roleToValidate).Name,
+		})
+	} else {
+		// role names are unique to the party which owns them. the roles are
+		// collected in the context of the claims, and so the roles of other
+		// parties are not seen, and so no names of theirs are revealed.
+		collectResponse, err := v.roleRecordHandler.Collect(&roleRecordHandler.CollectRequest{
+			Claims: request.Claims,
+			Criteria: []criterion.Criterion{
+				exactTextCriterion.Criterion{
+					Field: "name",
+					Text:  (*roleToValidate).Name,
+				},
+				exactTextCriterion.Criterion{
+					Field: "ownerId.id",
+					Text:  (*roleToValidate).OwnerId.Id,
+				},
+			},
+		})
+		if err != nil {
+			err = roleValidatorException.Validate{Reasons: []string{"collecting roles by name", err.Error()}}
+			log.Error(err.Error())
+			return nil, err
+		}
+		for _, roleWithName := range collectResponse.Records {
+			if roleWithName.Id != (*roleToValidate).Id {
+				allReasonsInvalid = append(allReasonsInvalid, reasonInvalid.ReasonInvalid{
+					Field: "name",
+					Type:  reasonInvalid.Duplicate,
+					Help:  "already exists",
+					Data:  (*roleToValidate).Name,
+				})
+				break
+			}
+		}
+	}
+
+	if (*roleToValidate).OwnerPartyType == "" {
+		allReasonsInvalid = append(allReasonsInvalid, reasonInvalid.ReasonInvalid{
+			Field: "ownerPartyType",
+			Type:  reasonInvalid.Blank,
+			Help:  "cannot be blank",
+			Data:  (*roleToValidate).OwnerPartyType,
+		})
+	}
+
+	if (*roleToValidate).OwnerId.Id == "" {
+		allReasonsInvalid = append(allReasonsInvalid, reasonInvalid.ReasonInvalid{
+			Field: "ownerId",
+			Type:  reasonInvalid.Blank,
+			Help:  "cannot be blank",
+			Data:  (*roleToValidate).OwnerId,
+		})
+	}
+
+	// if neither owner party type nor owner id are blank
+	if (*roleToValidate).OwnerPartyType != "" && (*roleToValidate).OwnerId.Id != "" {
+		switch (*roleToValidate).OwnerPartyType {
+		case party.Client, party.Company:
+			_, err := v.partyAdministrator.RetrieveParty(&partyAdministrator.RetrievePartyRequest{
+				Claims:     request.Claims,
+				PartyType:  (*roleToValidate).OwnerPartyType,
+				Identifier: (*roleToValidate).OwnerId,
+			})
+			if err != nil {
+				switch err.(type) {
+				case partyAdministratorException.NotFound:
+					allReasonsInvalid = append(allReasonsInvalid, reasonInvalid.ReasonInvalid{
+						Field: "ownerId",
+						Type:  reasonInvalid.MustExist,
+						Help:  "owner party must exist",
+						Data:  (*roleToValidate).OwnerId,
+					})
+				default:
+					err = roleValidatorException.Validate{Reasons: []string{"retrieving owner party", err.Error()}}
+					log.Error(err.Error())
+					return nil, err
+				}
+			}
+
+		default:
+			allReasonsInvalid = append(allReasonsInvalid, reasonInvalid.ReasonInvalid{
+				Field: "ownerPartyType",
+				Type:  reasonInvalid.Invalid,
+				Help:  "must be a company or client",
+				Data:  (*roleToValidate).OwnerPartyType,
+			})
+		}
+
+		// parties other than system may only define roles for themselves
+		if request.Claims.PartyDetails().PartyType != party.System &&
+			(request.Claims.PartyDetails().PartyType != (*roleToValidate).OwnerPartyType ||
+				request.Claims.PartyDetails().PartyId.Id != (*roleToValidate).OwnerId.Id) {
+			allReasonsInvalid = append(allReasonsInvalid, reasonInvalid.ReasonInvalid{
+				Field: "ownerId",
+				Type:  reasonInvalid.Invalid,
+				Help:  "must be your party",
+				Data:  (*roleToValidate).OwnerId,
+			})
+		}
+	}
+
+	// to prevent privilege escalation parties other than system
+	// may only grant permissions which the caller already holds
+	if request.Claims.PartyDetails().PartyType != party.System {
+		heldAPIPermissions, heldViewPermissions, err := v.callerPermissions(request)
+		if err != nil {
+			log.Error(err.Error())
+			return nil, err
+		}
+
+		for _, apiPermission := range (*roleToValidate).APIPermissions {
+			if _, held := heldAPIPermissions[apiPermission]; !held {
+				allReasonsInvalid = append(allReasonsInvalid, reasonInvalid.ReasonInvalid{
+					Field: "apiPermissions",
+					Type:  reasonInvalid.Invalid,
+					Help:  "must be held by you",
+					Data:  apiPermission,
+				})
+			}
+		}
+
+		for _, viewPermission := range (*roleToValidate).ViewPermissions {
+			if _, held := heldViewPermissions[viewPermission]; !held {
+				allReasonsInvalid = append(allReasonsInvalid, reasonInvalid.ReasonInvalid{
+					Field: "viewPermissions",
+					Type:  reasonInvalid.Invalid,
+					Help:  "must be held by you",
+					Data:  viewPermission,
+				})
+			}
+		}
+	}
+
+	// Make list of reasons invalid to return
+	returnedReasonsInvalid := make([]reasonInvalid.ReasonInvalid, 0)
+
+	// Add all reasons that cannot be ignored for the given action
+	if v.actionIgnoredReasons[request.Action].ReasonsInvalid != nil {
+		for _, reason := range allReasonsInvalid {
+			if !v.actionIgnoredReasons[request.Action].CanIgnore(reason) {
+				returnedReasonsInvalid = append(returnedReasonsInvalid, reason)
+			}
+		}
+	}
+
+	return &roleValidator.ValidateResponse{
+		ReasonsInvalid: returnedReasonsInvalid,
+	}, nil
+}
+
+// callerPermissions returns the api and view permissions
+// held by the human user making the given request
+func (v *validator) callerPermissions(request *roleValidator.ValidateRequest) (map[api.Permission]bool, map[view.Permission]bool, error) {
+	loginClaims, ok := request.Claims.(humanUserLoginClaims.Login)
+	if !ok {
+		return nil, nil, roleValidatorException.Validate{Reasons: []string{"invalid claims type", string(request.Claims.Type())}}
+	}
+
+	getAllUsersAPIPermissionsResponse, err := v.permissionAdministrator.GetAllUsersAPIPermissions(&permissionAdministrator.GetAllUsersAPIPermissionsRequest{
+		Claims:         request.Claims,
+		UserIdentifier: loginClaims.UserId,
+	})
+	if err != nil {
+		return nil, nil, roleValidatorException.Validate{Reasons: []string{"getting api permissions", err.Error()}}
+	}
+	heldAPIPermissions := make(map[api.Permission]bool)
+	for _, apiPermission := range getAllUsersAPIPermissionsResponse.Permissions {
+		heldAPIPermissions[apiPermission] = true
+	}
+
+	getAllUsersViewPermissionsResponse, err := v.permissionAdministrator.GetAllUsersViewPermissions(&permissionAdministrator.GetAllUsersViewPermissionsRequest{
+		Claims:         request.Claims,
+		UserIdentifier: loginClaims.UserId,
+	})
+	if err != nil {
+		return nil, nil, roleValidatorException.Validate{Reasons: []string{"getting view permissions", err.Error()}}
+	}
+	heldViewPermissions := make(map[view.Permission]bool)
+	for _, viewPermission := range getAllUsersViewPermissionsResponse.Permissions {
+		heldViewPermissions[viewPermission] = true
+	}
+
+	return heldAPIPermissions, heldViewPermissions, nil
+}
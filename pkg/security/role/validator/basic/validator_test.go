@@ -0,0 +1,230 @@
+package basic
+
+import (
+	"github.com/iot-my-world/brain/pkg/party"
+	partyAdministrator "github.com/iot-my-world/brain/pkg/party/administrator"
+	exactTextCriterion "github.com/iot-my-world/brain/pkg/search/criterion/exact/text"
+	"github.com/iot-my-world/brain/pkg/search/identifier/id"
+	"github.com/iot-my-world/brain/pkg/security/claims"
+	humanUserLoginClaims "github.com/iot-my-world/brain/pkg/security/claims/login/user/human"
+	permissionAdministrator "github.com/iot-my-world/brain/pkg/security/permission/administrator"
+	"github.com/iot-my-world/brain/pkg/security/permission/api"
+	"github.com/iot-my-world/brain/pkg/security/permission/view"
+	"github.com/iot-my-world/brain/pkg/security/role"
+	roleAction "github.com/iot-my-world/brain/pkg/security/role/action"
+	roleRecordHandler "github.com/iot-my-world/brain/pkg/security/role/recordHandler"
+	roleValidator "github.com/iot-my-world/brain/pkg/security/role/validator"
+	"github.com/iot-my-world/brain/pkg/validate/reasonInvalid"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+type fakePartyAdministrator struct {
+	partyAdministrator.Administrator
+}
+
+func (a *fakePartyAdministrator) RetrieveParty(request *partyAdministrator.RetrievePartyRequest) (*partyAdministrator.RetrievePartyResponse, error) {
+	return &partyAdministrator.RetrievePartyResponse{}, nil
+}
+
+// fakePermissionAdministrator gives every user the same permissions
+type fakePermissionAdministrator struct {
+	permissionAdministrator.Administrator
+	apiPermissions  []api.Permission
+	viewPermissions []view.Permission
+}
+
+func (a *fakePermissionAdministrator) GetAllUsersAPIPermissions(request *permissionAdministrator.GetAllUsersAPIPermissionsRequest) (*permissionAdministrator.GetAllUsersAPIPermissionsResponse, error) {
+	return &permissionAdministrator.GetAllUsersAPIPermissionsResponse{Permissions: a.apiPermissions}, nil
+}
+
+func (a *fakePermissionAdministrator) GetAllUsersViewPermissions(request *permissionAdministrator.GetAllUsersViewPermissionsRequest) (*permissionAdministrator.GetAllUsersViewPermissionsResponse, error) {
+	return &permissionAdministrator.GetAllUsersViewPermissionsResponse{Permissions: a.viewPermissions}, nil
+}
+
+// fakeRoleRecordHandler collects roles matching exact text
+// criteria on name and owner in the context of the claims
+type fakeRoleRecordHandler struct {
+	roleRecordHandler.RecordHandler
+	roles []role.Role
+}
+
+func (r *fakeRoleRecordHandler) Collect(request *roleRecordHandler.CollectRequest) (*roleRecordHandler.CollectResponse, error) {
+	records := make([]role.Role, 0)
+nextRole:
+	for _, existingRole := range r.roles {
+		if !claims.EntityInContext(existingRole.OwnerId, id.Identifier{}, request.Claims) {
+			continue
+		}
+		for _, c := range request.Criteria {
+			textCriterion := c.(exactTextCriterion.Criterion)
+			switch textCriterion.Field {
+			case "name":
+				if existingRole.Name != textCriterion.Text {
+					continue nextRole
+				}
+			case "ownerId.id":
+				if existingRole.OwnerId.Id != textCriterion.Text {
+					continue nextRole
+				}
+			default:
+				panic("unsupported field " + textCriterion.Field)
+			}
+		}
+		records = append(records, existingRole)
+	}
+	return &roleRecordHandler.CollectResponse{Records: records, Total: len(records)}, nil
+}
+
+func companyClaims(companyId string) humanUserLoginClaims.Login {
+	return humanUserLoginClaims.Login{
+		UserId:    id.Identifier{Id: "user"},
+		PartyType: party.Company,
+		PartyId:   id.Identifier{Id: companyId},
+	}
+}
+
+func companyRole(companyId, name string, apiPermissions []api.Permission, viewPermissions []view.Permission) role.Role {
+	return role.Role{
+		Name:            name,
+		APIPermissions:  apiPermissions,
+		ViewPermissions: viewPermissions,
+		OwnerPartyType:  party.Company,
+		OwnerId:         id.Identifier{Id: companyId},
+	}
+}
+
+func newTestValidator() roleValidator.Validator {
+	return New(
+		&fakePartyAdministrator{},
+		&fakePermissionAdministrator{
+			apiPermissions:  []api.Permission{"Held.Method"},
+			viewPermissions: []view.Permission{"HeldView"},
+		},
+		&fakeRoleRecordHandler{roles: []role.Role{
+			{Id: "1", Name: "Company Admin"},
+			{Id: "2", Name: "Operators", OwnerPartyType: party.Company, OwnerId: id.Identifier{Id: "company1"}},
+			{Id: "3", Name: "Drivers", OwnerPartyType: party.Company, OwnerId: id.Identifier{Id: "company2"}},
+		}},
+	)
+}
+
+func TestValidatePermissionsMustBeHeld(t *testing.T) {
+	validator := newTestValidator()
+
+	tests := []struct {
+		name     string
+		claims   claims.Claims
+		role     role.Role
+		expected []reasonInvalid.ReasonInvalid
+	}{
+		{
+			name:   "held permissions",
+			claims: companyClaims("company1"),
+			role:   companyRole("company1", "Dispatchers", []api.Permission{"Held.Method"}, []view.Permission{"HeldView"}),
+		},
+		{
+			name:   "api permission not held",
+			claims: companyClaims("company1"),
+			role:   companyRole("company1", "Dispatchers", []api.Permission{"Held.Method", "Other.Method"}, nil),
+			expected: []reasonInvalid.ReasonInvalid{{
+				Field: "apiPermissions",
+				Type:  reasonInvalid.Invalid,
+				Help:  "must be held by you",
+				Data:  api.Permission("Other.Method"),
+			}},
+		},
+		{
+			name:   "view permission not held",
+			claims: companyClaims("company1"),
+			role:   companyRole("company1", "Dispatchers", nil, []view.Permission{"OtherView"}),
+			expected: []reasonInvalid.ReasonInvalid{{
+				Field: "viewPermissions",
+				Type:  reasonInvalid.Invalid,
+				Help:  "must be held by you",
+				Data:  view.Permission("OtherView"),
+			}},
+		},
+		{
+			name: "system may grant any permission",
+			claims: humanUserLoginClaims.Login{
+				UserId:    id.Identifier{Id: "root"},
+				PartyType: party.System,
+				PartyId:   id.Identifier{Id: "system"},
+			},
+			role: companyRole("company1", "Dispatchers", []api.Permission{"Other.Method"}, []view.Permission{"OtherView"}),
+		},
+		{
+			name:   "role for another party",
+			claims: companyClaims("company1"),
+			role:   companyRole("company2", "Dispatchers", []api.Permission{"Held.Method"}, nil),
+			expected: []reasonInvalid.ReasonInvalid{{
+				Field: "ownerId",
+				Type:  reasonInvalid.Invalid,
+				Help:  "must be your party",
+				Data:  id.Identifier{Id: "company2"},
+			}},
+		},
+	}
+
+	for _, test := range tests {
+		response, err := validator.Validate(&roleValidator.ValidateRequest{
+			Claims: test.claims,
+			Role:   test.role,
+			Action: roleAction.Create,
+		})
+		if !assert.NoError(t, err, test.name) {
+			continue
+		}
+		if test.expected == nil {
+			assert.Empty(t, response.ReasonsInvalid, test.name)
+		} else {
+			assert.Equal(t, test.expected, response.ReasonsInvalid, test.name)
+		}
+	}
+}
+
+func TestValidateNameUniqueToOwner(t *testing.T) {
+	validator := newTestValidator()
+
+	tests := []struct {
+		name      string
+		role      role.Role
+		duplicate bool
+	}{
+		{
+			name:      "name of role of own party",
+			role:      companyRole("company1", "Operators", nil, nil),
+			duplicate: true,
+		},
+		{
+			name: "name of role of another party",
+			role: companyRole("company1", "Drivers", nil, nil),
+		},
+		{
+			name: "name of system role",
+			role: companyRole("company1", "Company Admin", nil, nil),
+		},
+	}
+
+	for _, test := range tests {
+		response, err := validator.Validate(&roleValidator.ValidateRequest{
+			Claims: companyClaims("company1"),
+			Role:   test.role,
+			Action: roleAction.Create,
+		})
+		if !assert.NoError(t, err, test.name) {
+			continue
+		}
+		if test.duplicate {
+			assert.Equal(t, []reasonInvalid.ReasonInvalid{{
+				Field: "name",
+				Type:  reasonInvalid.Duplicate,
+				Help:  "already exists",
+				Data:  test.role.Name,
+			}}, response.ReasonsInvalid, test.name)
+		} else {
+			assert.Empty(t, response.ReasonsInvalid, test.name)
+		}
+	}
+}
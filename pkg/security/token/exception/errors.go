@@ -28,3 +28,17 @@ type JWTUnmarshalling struct {
 func (e JWTUnmarshalling) Error() string {
 	return fmt.Sprintf("JWT unmarshalling error: %s", strings.Join(e.Reasons, "; "))
 }
+
+type RevokedJWT struct{}
+
+func (e RevokedJWT) Error() string {
+	return "JWT has been revoked"
+}
+
+type RevocationCheck struct {
+	Reasons []string
+}
+
+func (e RevocationCheck) Error() string {
+	return fmt.Sprintf("JWT revocation check error: %s", strings.Join(e.Reasons, "; "))
+}
@@ -6,7 +6,9 @@ import (
 	"github.com/iot-my-world/brain/internal/log"
 	claims2 "github.com/iot-my-world/brain/pkg/security/claims"
 	wrapped2 "github.com/iot-my-world/brain/pkg/security/claims/wrapped"
+	"github.com/satori/go.uuid"
 	"gopkg.in/square/go-jose.v2"
+	"time"
 )
 
 type JWTGenerator struct {
@@ -32,6 +34,15 @@ func (g JWTGenerator) GenerateToken(claimsToWrap claims2.Claims) (string, error)
 	if err != nil {
 		return "", err
 	}
+
+	// give the token an id so that it can be revoked
+	tokenId, err := uuid.NewV4()
+	if err != nil {
+		return "", err
+	}
+	wrapped.TokenId = tokenId.String()
+	wrapped.IssueTime = time.Now().UTC().Unix()
+
 	return getSignedJWT(wrapped, g.signer)
 }
 
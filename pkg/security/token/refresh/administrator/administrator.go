@@ -0,0 +1,45 @@
+package administrator
+
+import (
+	"github.com/iot-my-world/brain/pkg/search/identifier/id"
+	"github.com/iot-my-world/brain/pkg/security/token/refresh"
+)
+
+// Administrator issues and rotates the refresh tokens of log in sessions
+type Administrator interface {
+	Issue(request *IssueRequest) (*IssueResponse, error)
+	Exchange(request *ExchangeRequest) (*ExchangeResponse, error)
+	RevokeSession(request *RevokeSessionRequest) (*RevokeSessionResponse, error)
+}
+
+// IssueRequest issues a refresh token for the given user
+// which starts a new session
+type IssueRequest struct {
+	UserId id.Identifier
+}
+
+type IssueResponse struct {
+	Token        string
+	RefreshToken refresh.Token
+}
+
+// ExchangeRequest exchanges the given refresh token for a new one in
+// the same session. If the given token has already been exchanged
+// the whole session is revoked.
+type ExchangeRequest struct {
+	Token string
+}
+
+type ExchangeResponse struct {
+	// ExchangedRefreshToken is the refresh token which was exchanged
+	ExchangedRefreshToken refresh.Token
+	Token                 string
+	RefreshToken          refresh.Token
+}
+
+type RevokeSessionRequest struct {
+	SessionId string
+}
+
+type RevokeSessionResponse struct {
+}
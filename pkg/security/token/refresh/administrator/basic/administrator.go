@@ -0,0 +1,303 @@
+package basic
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	brainException "github.com/iot-my-world/brain/internal/exception"
+	"github.com/iot-my-world/brain/internal/log"
+	brainRecordHandler "github.com/iot-my-world/brain/pkg/recordHandler"
+	"github.com/iot-my-world/brain/pkg/search/criterion"
+	exactBooleanCriterion "github.com/iot-my-world/brain/pkg/search/criterion/exact/boolean"
+	exactTextCriterion "github.com/iot-my-world/brain/pkg/search/criterion/exact/text"
+	"github.com/iot-my-world/brain/pkg/search/identifier/id"
+	"github.com/iot-my-world/brain/pkg/search/query"
+	humanUserLoginClaims "github.com/iot-my-world/brain/pkg/security/claims/login/user/human"
+	"github.com/iot-my-world/brain/pkg/security/token/refresh"
+	refreshTokenAdministrator "github.com/iot-my-world/brain/pkg/security/token/refresh/administrator"
+	refreshTokenAdministratorException "github.com/iot-my-world/brain/pkg/security/token/refresh/administrator/exception"
+	refreshTokenRecordHandler "github.com/iot-my-world/brain/pkg/security/token/refresh/recordHandler"
+	refreshTokenRecordHandlerException "github.com/iot-my-world/brain/pkg/security/token/refresh/recordHandler/exception"
+	tokenRevocationAdministrator "github.com/iot-my-world/brain/pkg/security/token/revocation/administrator"
+	"github.com/satori/go.uuid"
+	"time"
+)
+
+// tokenBytes is the number of random bytes in a refresh token
+const tokenBytes = 32
+
+type administrator struct {
+	refreshTokenRecordHandler    refreshTokenRecordHandler.RecordHandler
+	tokenRevocationAdministrator tokenRevocationAdministrator.Administrator
+	lifetime                     time.Duration
+	sessionLifetime              time.Duration
+	systemClaims                 *humanUserLoginClaims.Login
+}
+
+func New(
+	refreshTokenRecordHandler refreshTokenRecordHandler.RecordHandler,
+	tokenRevocationAdministrator tokenRevocationAdministrator.Administrator,
+	lifetime time.Duration,
+	sessionLifetime time.Duration,
+	systemClaims *humanUserLoginClaims.Login,
+) refreshTokenAdministrator.Administrator {
+	return &administrator{
+		refreshTokenRecordHandler:    refreshTokenRecordHandler,
+		tokenRevocationAdministrator: tokenRevocationAdministrator,
+		lifetime:                     lifetime,
+		sessionLifetime:              sessionLifetime,
+		systemClaims:                 systemClaims,
+	}
+}
+
+// hashToken returns the hash of the given token under which it is stored
+func hashToken(token string) string {
+	hash := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(hash[:])
+}
+
+// issue creates a new refresh token for the given user in the given session.
+// The token expires after the token lifetime, or at the end of the session
+// if that is sooner.
+func (a *administrator) issue(userId id.Identifier, sessionId string, sessionStartTime time.Time) (string, *refresh.Token, error) {
+	randomBytes := make([]byte, tokenBytes)
+	if _, err := rand.Read(randomBytes); err != nil {
+		return "", nil, refreshTokenAdministratorException.TokenGeneration{Reasons: []string{err.Error()}}
+	}
+	token := base64.RawURLEncoding.EncodeToString(randomBytes)
+
+	now := time.Now().UTC()
+	expirationTime := now.Add(a.lifetime)
+	if sessionEndTime := sessionStartTime.Add(a.sessionLifetime); sessionEndTime.Before(expirationTime) {
+		expirationTime = sessionEndTime
+	}
+	createResponse, err := a.refreshTokenRecordHandler.Create(&refreshTokenRecordHandler.CreateRequest{
+		RefreshToken: refresh.Token{
+			TokenHash:        hashToken(token),
+			SessionId:        sessionId,
+			UserId:           userId,
+			SessionStartTime: sessionStartTime.Unix(),
+			IssueTime:        now.Unix(),
+			ExpirationTime:   expirationTime.Unix(),
+			ExpiresAt:        expirationTime,
+		},
+	})
+	if err != nil {
+		return "", nil, refreshTokenAdministratorException.TokenCreation{Reasons: []string{err.Error()}}
+	}
+
+	return token, &createResponse.RefreshToken, nil
+}
+
+func (a *administrator) ValidateIssueRequest(request *refreshTokenAdministrator.IssueRequest) error {
+	reasonsInvalid := make([]string, 0)
+
+	if request.UserId.Id == "" {
+		reasonsInvalid = append(reasonsInvalid, "user id is blank")
+	}
+
+	if len(reasonsInvalid) > 0 {
+		return brainException.RequestInvalid{Reasons: reasonsInvalid}
+	}
+	return nil
+}
+
+func (a *administrator) Issue(request *refreshTokenAdministrator.IssueRequest) (*refreshTokenAdministrator.IssueResponse, error) {
+	if err := a.ValidateIssueRequest(request); err != nil {
+		log.Error(err.Error())
+		return nil, err
+	}
+
+	sessionId, err := uuid.NewV4()
+	if err != nil {
+		err = brainException.UUIDGeneration{Reasons: []string{err.Error()}}
+		log.Error(err.Error())
+		return nil, err
+	}
+
+	token, refreshToken, err := a.issue(request.UserId, sessionId.String(), time.Now().UTC())
+	if err != nil {
+		log.Error(err.Error())
+		return nil, err
+	}
+
+	return &refreshTokenAdministrator.IssueResponse{
+		Token:        token,
+		RefreshToken: *refreshToken,
+	}, nil
+}
+
+func (a *administrator) ValidateExchangeRequest(request *refreshTokenAdministrator.ExchangeRequest) error {
+	reasonsInvalid := make([]string, 0)
+
+	if request.Token == "" {
+		reasonsInvalid = append(reasonsInvalid, "token is blank")
+	}
+
+	if len(reasonsInvalid) > 0 {
+		return brainException.RequestInvalid{Reasons: reasonsInvalid}
+	}
+	return nil
+}
+
+func (a *administrator) Exchange(request *refreshTokenAdministrator.ExchangeRequest) (*refreshTokenAdministrator.ExchangeResponse, error) {
+	if err := a.ValidateExchangeRequest(request); err != nil {
+		log.Error(err.Error())
+		return nil, err
+	}
+
+	collectResponse, err := a.refreshTokenRecordHandler.Collect(&refreshTokenRecordHandler.CollectRequest{
+		Claims: a.systemClaims,
+		Criteria: []criterion.Criterion{
+			exactTextCriterion.Criterion{
+				Field: "tokenHash",
+				Text:  hashToken(request.Token),
+			},
+		},
+		Query: query.Query{Limit: 1},
+	})
+	if err != nil {
+		err = refreshTokenAdministratorException.TokenRetrieval{Reasons: []string{err.Error()}}
+		log.Error(err.Error())
+		return nil, err
+	}
+	if len(collectResponse.Records) == 0 {
+		err = refreshTokenAdministratorException.InvalidToken{Reasons: []string{"not found"}}
+		log.Error(err.Error())
+		return nil, err
+	}
+	if collectResponse.Records[0].Revoked {
+		err = refreshTokenAdministratorException.InvalidToken{Reasons: []string{"revoked"}}
+		log.Error(err.Error())
+		return nil, err
+	}
+
+	// claim the token by marking it as used only if it has not been used
+	// yet, so that it can only be exchanged once however many times it is
+	// presented concurrently
+	claimResponse, err := a.refreshTokenRecordHandler.UpdateFields(&refreshTokenRecordHandler.UpdateFieldsRequest{
+		Claims: a.systemClaims,
+		Criteria: []criterion.Criterion{
+			exactTextCriterion.Criterion{
+				Field: "id",
+				Text:  collectResponse.Records[0].Id,
+			},
+			exactBooleanCriterion.Criterion{
+				Field:   "used",
+				Boolean: false,
+			},
+			exactBooleanCriterion.Criterion{
+				Field:   "revoked",
+				Boolean: false,
+			},
+		},
+		Update: brainRecordHandler.FieldsUpdate{
+			Set: map[string]interface{}{"used": true},
+		},
+	})
+	if err != nil {
+		switch err.(type) {
+		case refreshTokenRecordHandlerException.NotFound:
+			// a token which has already been exchanged has been leaked, so the
+			// whole session is revoked to lock out whoever else is using it
+			if err := a.revokeSession(collectResponse.Records[0].SessionId); err != nil {
+				log.Error(err.Error())
+				return nil, err
+			}
+			err = refreshTokenAdministratorException.TokenReuse{SessionId: collectResponse.Records[0].SessionId}
+			log.Error(err.Error())
+			return nil, err
+		default:
+			err = refreshTokenAdministratorException.TokenUpdate{Reasons: []string{err.Error()}}
+			log.Error(err.Error())
+			return nil, err
+		}
+	}
+	exchangedRefreshToken := claimResponse.RefreshToken
+
+	now := time.Now().UTC()
+	sessionStartTime := time.Unix(exchangedRefreshToken.SessionStartTime, 0)
+	if now.After(sessionStartTime.Add(a.sessionLifetime)) {
+		err = refreshTokenAdministratorException.InvalidToken{Reasons: []string{"session expired"}}
+		log.Error(err.Error())
+		return nil, err
+	}
+	if now.After(time.Unix(exchangedRefreshToken.ExpirationTime, 0)) {
+		err = refreshTokenAdministratorException.InvalidToken{Reasons: []string{"expired"}}
+		log.Error(err.Error())
+		return nil, err
+	}
+
+	token, refreshToken, err := a.issue(exchangedRefreshToken.UserId, exchangedRefreshToken.SessionId, sessionStartTime)
+	if err != nil {
+		log.Error(err.Error())
+		return nil, err
+	}
+
+	return &refreshTokenAdministrator.ExchangeResponse{
+		ExchangedRefreshToken: exchangedRefreshToken,
+		Token:                 token,
+		RefreshToken:          *refreshToken,
+	}, nil
+}
+
+func (a *administrator) ValidateRevokeSessionRequest(request *refreshTokenAdministrator.RevokeSessionRequest) error {
+	reasonsInvalid := make([]string, 0)
+
+	if request.SessionId == "" {
+		reasonsInvalid = append(reasonsInvalid, "session id is blank")
+	}
+
+	if len(reasonsInvalid) > 0 {
+		return brainException.RequestInvalid{Reasons: reasonsInvalid}
+	}
+	return nil
+}
+
+func (a *administrator) RevokeSession(request *refreshTokenAdministrator.RevokeSessionRequest) (*refreshTokenAdministrator.RevokeSessionResponse, error) {
+	if err := a.ValidateRevokeSessionRequest(request); err != nil {
+		log.Error(err.Error())
+		return nil, err
+	}
+
+	if err := a.revokeSession(request.SessionId); err != nil {
+		log.Error(err.Error())
+		return nil, err
+	}
+
+	return &refreshTokenAdministrator.RevokeSessionResponse{}, nil
+}
+
+// revokeSession revokes all of the refresh tokens in the given session
+// and records the revocation of the session so that the login tokens
+// issued in it are no longer accepted
+func (a *administrator) revokeSession(sessionId string) error {
+	if _, err := a.refreshTokenRecordHandler.UpdateAll(&refreshTokenRecordHandler.UpdateAllRequest{
+		Claims: a.systemClaims,
+		Criteria: []criterion.Criterion{
+			exactTextCriterion.Criterion{
+				Field: "sessionId",
+				Text:  sessionId,
+			},
+			exactBooleanCriterion.Criterion{
+				Field:   "revoked",
+				Boolean: false,
+			},
+		},
+		Update: brainRecordHandler.FieldsUpdate{
+			Set: map[string]interface{}{"revoked": true},
+		},
+	}); err != nil {
+		return refreshTokenAdministratorException.SessionRevocation{Reasons: []string{"updating tokens", err.Error()}}
+	}
+
+	if _, err := a.tokenRevocationAdministrator.RevokeSession(&tokenRevocationAdministrator.RevokeSessionRequest{
+		Claims:    a.systemClaims,
+		SessionId: sessionId,
+	}); err != nil {
+		return refreshTokenAdministratorException.SessionRevocation{Reasons: []string{"revoking login tokens", err.Error()}}
+	}
+
+	return nil
+}
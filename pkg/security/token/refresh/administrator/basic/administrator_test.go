@@ -0,0 +1,262 @@
+package basic
+
+import (
+	"github.com/iot-my-world/brain/pkg/search/criterion"
+	exactBooleanCriterion "github.com/iot-my-world/brain/pkg/search/criterion/exact/boolean"
+	exactTextCriterion "github.com/iot-my-world/brain/pkg/search/criterion/exact/text"
+	"github.com/iot-my-world/brain/pkg/search/identifier/id"
+	humanUserLoginClaims "github.com/iot-my-world/brain/pkg/security/claims/login/user/human"
+	"github.com/iot-my-world/brain/pkg/security/token/refresh"
+	refreshTokenAdministrator "github.com/iot-my-world/brain/pkg/security/token/refresh/administrator"
+	refreshTokenAdministratorException "github.com/iot-my-world/brain/pkg/security/token/refresh/administrator/exception"
+	refreshTokenRecordHandler "github.com/iot-my-world/brain/pkg/security/token/refresh/recordHandler"
+	refreshTokenRecordHandlerException "github.com/iot-my-world/brain/pkg/security/token/refresh/recordHandler/exception"
+	tokenRevocationAdministrator "github.com/iot-my-world/brain/pkg/security/token/revocation/administrator"
+	"github.com/stretchr/testify/assert"
+	"strconv"
+	"testing"
+	"time"
+)
+
+// fakeRecordHandler keeps refresh tokens in memory. It supports the
+// exact text and exact boolean criteria used by the administrator.
+type fakeRecordHandler struct {
+	refreshTokenRecordHandler.RecordHandler
+	tokens []refresh.Token
+}
+
+func (r *fakeRecordHandler) field(token refresh.Token, field string) interface{} {
+	switch field {
+	case "id":
+		return token.Id
+	case "tokenHash":
+		return token.TokenHash
+	case "sessionId":
+		return token.SessionId
+	case "used":
+		return token.Used
+	case "revoked":
+		return token.Revoked
+	default:
+		panic("unsupported field " + field)
+	}
+}
+
+func (r *fakeRecordHandler) matches(token refresh.Token, criteria []criterion.Criterion) bool {
+	for _, c := range criteria {
+		switch typedCriterion := c.(type) {
+		case exactTextCriterion.Criterion:
+			if r.field(token, typedCriterion.Field) != typedCriterion.Text {
+				return false
+			}
+		case exactBooleanCriterion.Criterion:
+			if r.field(token, typedCriterion.Field) != typedCriterion.Boolean {
+				return false
+			}
+		default:
+			panic("unsupported criterion")
+		}
+	}
+	return true
+}
+
+func (r *fakeRecordHandler) apply(token *refresh.Token, set map[string]interface{}) {
+	for field, value := range set {
+		switch field {
+		case "used":
+			token.Used = value.(bool)
+		case "revoked":
+			token.Revoked = value.(bool)
+		default:
+			panic("unsupported field " + field)
+		}
+	}
+}
+
+func (r *fakeRecordHandler) Create(request *refreshTokenRecordHandler.CreateRequest) (*refreshTokenRecordHandler.CreateResponse, error) {
+	request.RefreshToken.Id = strconv.Itoa(len(r.tokens))
+	r.tokens = append(r.tokens, request.RefreshToken)
+	return &refreshTokenRecordHandler.CreateResponse{RefreshToken: request.RefreshToken}, nil
+}
+
+func (r *fakeRecordHandler) Collect(request *refreshTokenRecordHandler.CollectRequest) (*refreshTokenRecordHandler.CollectResponse, error) {
+	records := make([]refresh.Token, 0)
+	for _, token := range r.tokens {
+		if r.matches(token, request.Criteria) {
+			records = append(records, token)
+		}
+	}
+	return &refreshTokenRecordHandler.CollectResponse{Records: records, Total: len(records)}, nil
+}
+
+func (r *fakeRecordHandler) UpdateFields(request *refreshTokenRecordHandler.UpdateFieldsRequest) (*refreshTokenRecordHandler.UpdateFieldsResponse, error) {
+	for i := range r.tokens {
+		if r.matches(r.tokens[i], request.Criteria) {
+			r.apply(&r.tokens[i], request.Update.Set)
+			return &refreshTokenRecordHandler.UpdateFieldsResponse{RefreshToken: r.tokens[i]}, nil
+		}
+	}
+	return nil, refreshTokenRecordHandlerException.NotFound{}
+}
+
+func (r *fakeRecordHandler) UpdateAll(request *refreshTokenRecordHandler.UpdateAllRequest) (*refreshTokenRecordHandler.UpdateAllResponse, error) {
+	noUpdated := 0
+	for i := range r.tokens {
+		if r.matches(r.tokens[i], request.Criteria) {
+			r.apply(&r.tokens[i], request.Update.Set)
+			noUpdated++
+		}
+	}
+	return &refreshTokenRecordHandler.UpdateAllResponse{NoUpdated: noUpdated}, nil
+}
+
+type fakeTokenRevocationAdministrator struct {
+	tokenRevocationAdministrator.Administrator
+	revokedSessions []string
+}
+
+func (a *fakeTokenRevocationAdministrator) RevokeSession(request *tokenRevocationAdministrator.RevokeSessionRequest) (*tokenRevocationAdministrator.RevokeSessionResponse, error) {
+	a.revokedSessions = append(a.revokedSessions, request.SessionId)
+	return &tokenRevocationAdministrator.RevokeSessionResponse{}, nil
+}
+
+func newTestAdministrator(lifetime, sessionLifetime time.Duration) (refreshTokenAdministrator.Administrator, *fakeRecordHandler, *fakeTokenRevocationAdministrator) {
+	recordHandler := &fakeRecordHandler{}
+	revocationAdministrator := &fakeTokenRevocationAdministrator{}
+	return New(recordHandler, revocationAdministrator, lifetime, sessionLifetime, &humanUserLoginClaims.Login{}),
+		recordHandler,
+		revocationAdministrator
+}
+
+func TestExchangeRotatesToken(t *testing.T) {
+	administrator, recordHandler, revocationAdministrator := newTestAdministrator(time.Hour, 24*time.Hour)
+
+	issueResponse, err := administrator.Issue(&refreshTokenAdministrator.IssueRequest{
+		UserId: id.Identifier{Id: "user"},
+	})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	exchangeResponse, err := administrator.Exchange(&refreshTokenAdministrator.ExchangeRequest{
+		Token: issueResponse.Token,
+	})
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.NotEqual(t, issueResponse.Token, exchangeResponse.Token)
+	assert.Equal(t, issueResponse.RefreshToken.Id, exchangeResponse.ExchangedRefreshToken.Id)
+	assert.Equal(t, issueResponse.RefreshToken.SessionId, exchangeResponse.RefreshToken.SessionId)
+	assert.Equal(t, "user", exchangeResponse.RefreshToken.UserId.Id)
+	assert.True(t, recordHandler.tokens[0].Used)
+	assert.False(t, recordHandler.tokens[1].Used)
+
+	// the new token can in turn be exchanged
+	_, err = administrator.Exchange(&refreshTokenAdministrator.ExchangeRequest{
+		Token: exchangeResponse.Token,
+	})
+	assert.NoError(t, err)
+	assert.Empty(t, revocationAdministrator.revokedSessions)
+}
+
+func TestExchangeReuseRevokesSession(t *testing.T) {
+	administrator, recordHandler, revocationAdministrator := newTestAdministrator(time.Hour, 24*time.Hour)
+
+	issueResponse, err := administrator.Issue(&refreshTokenAdministrator.IssueRequest{
+		UserId: id.Identifier{Id: "user"},
+	})
+	if !assert.NoError(t, err) {
+		return
+	}
+	exchangeResponse, err := administrator.Exchange(&refreshTokenAdministrator.ExchangeRequest{
+		Token: issueResponse.Token,
+	})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	// presenting the exchanged token again revokes the whole session
+	_, err = administrator.Exchange(&refreshTokenAdministrator.ExchangeRequest{
+		Token: issueResponse.Token,
+	})
+	assert.IsType(t, refreshTokenAdministratorException.TokenReuse{}, err)
+	for _, token := range recordHandler.tokens {
+		assert.True(t, token.Revoked)
+	}
+	assert.Equal(t, []string{issueResponse.RefreshToken.SessionId}, revocationAdministrator.revokedSessions)
+
+	// including the token issued in exchange
+	_, err = administrator.Exchange(&refreshTokenAdministrator.ExchangeRequest{
+		Token: exchangeResponse.Token,
+	})
+	assert.IsType(t, refreshTokenAdministratorException.InvalidToken{}, err)
+}
+
+func TestExchangeExpiredToken(t *testing.T) {
+	administrator, recordHandler, revocationAdministrator := newTestAdministrator(-time.Second, 24*time.Hour)
+
+	issueResponse, err := administrator.Issue(&refreshTokenAdministrator.IssueRequest{
+		UserId: id.Identifier{Id: "user"},
+	})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	_, err = administrator.Exchange(&refreshTokenAdministrator.ExchangeRequest{
+		Token: issueResponse.Token,
+	})
+	assert.IsType(t, refreshTokenAdministratorException.InvalidToken{}, err)
+	assert.Len(t, recordHandler.tokens, 1)
+	assert.Empty(t, revocationAdministrator.revokedSessions)
+}
+
+func TestExpirationLimitedBySession(t *testing.T) {
+	administrator, _, _ := newTestAdministrator(time.Hour, 30*time.Minute)
+
+	issueResponse, err := administrator.Issue(&refreshTokenAdministrator.IssueRequest{
+		UserId: id.Identifier{Id: "user"},
+	})
+	if !assert.NoError(t, err) {
+		return
+	}
+	issuedToken := issueResponse.RefreshToken
+	assert.Equal(t, issuedToken.SessionStartTime+1800, issuedToken.ExpirationTime)
+	assert.Equal(t, issuedToken.ExpirationTime, issuedToken.ExpiresAt.Unix())
+
+	// tokens issued in exchange do not outlive the session either
+	exchangeResponse, err := administrator.Exchange(&refreshTokenAdministrator.ExchangeRequest{
+		Token: issueResponse.Token,
+	})
+	if assert.NoError(t, err) {
+		assert.Equal(t, issuedToken.SessionStartTime, exchangeResponse.RefreshToken.SessionStartTime)
+		assert.Equal(t, issuedToken.ExpirationTime, exchangeResponse.RefreshToken.ExpirationTime)
+	}
+}
+
+func TestExchangeExpiredSession(t *testing.T) {
+	administrator, recordHandler, _ := newTestAdministrator(time.Hour, -time.Second)
+
+	issueResponse, err := administrator.Issue(&refreshTokenAdministrator.IssueRequest{
+		UserId: id.Identifier{Id: "user"},
+	})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	_, err = administrator.Exchange(&refreshTokenAdministrator.ExchangeRequest{
+		Token: issueResponse.Token,
+	})
+	if assert.IsType(t, refreshTokenAdministratorException.InvalidToken{}, err) {
+		assert.Equal(t, []string{"session expired"}, err.(refreshTokenAdministratorException.InvalidToken).Reasons)
+	}
+	assert.Len(t, recordHandler.tokens, 1)
+}
+
+func TestExchangeUnknownToken(t *testing.T) {
+	administrator, _, _ := newTestAdministrator(time.Hour, 24*time.Hour)
+
+	_, err := administrator.Exchange(&refreshTokenAdministrator.ExchangeRequest{
+		Token: "unknown",
+	})
+	assert.IsType(t, refreshTokenAdministratorException.InvalidToken{}, err)
+}
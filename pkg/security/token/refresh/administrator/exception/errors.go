@@ -0,0 +1,59 @@
+package exception
+
+import "strings"
+
+type TokenGeneration struct {
+	Reasons []string
+}
+
+func (e TokenGeneration) Error() string {
+	return "error generating refresh token: " + strings.Join(e.Reasons, "; ")
+}
+
+type TokenCreation struct {
+	Reasons []string
+}
+
+func (e TokenCreation) Error() string {
+	return "error creating refresh token: " + strings.Join(e.Reasons, "; ")
+}
+
+type TokenRetrieval struct {
+	Reasons []string
+}
+
+func (e TokenRetrieval) Error() string {
+	return "error retrieving refresh token: " + strings.Join(e.Reasons, "; ")
+}
+
+type TokenUpdate struct {
+	Reasons []string
+}
+
+func (e TokenUpdate) Error() string {
+	return "error updating refresh token: " + strings.Join(e.Reasons, "; ")
+}
+
+type InvalidToken struct {
+	Reasons []string
+}
+
+func (e InvalidToken) Error() string {
+	return "invalid refresh token: " + strings.Join(e.Reasons, "; ")
+}
+
+type TokenReuse struct {
+	SessionId string
+}
+
+func (e TokenReuse) Error() string {
+	return "refresh token reused, session " + e.SessionId + " revoked"
+}
+
+type SessionRevocation struct {
+	Reasons []string
+}
+
+func (e SessionRevocation) Error() string {
+	return "error revoking session: " + strings.Join(e.Reasons, "; ")
+}
@@ -0,0 +1,171 @@
+package refreshTokenRecordHandler
+
+import (
+	brainException "github.com/iot-my-world/brain/internal/exception"
+	brainRecordHandler "github.com/iot-my-world/brain/pkg/recordHandler"
+	brainRecordHandlerException "github.com/iot-my-world/brain/pkg/recordHandler/exception"
+	"github.com/iot-my-world/brain/pkg/security/token/refresh"
+	refreshTokenRecordHandler "github.com/iot-my-world/brain/pkg/security/token/refresh/recordHandler"
+	refreshTokenRecordHandlerException "github.com/iot-my-world/brain/pkg/security/token/refresh/recordHandler/exception"
+)
+
+type RecordHandler struct {
+	refreshTokenRecordHandler brainRecordHandler.RecordHandler
+}
+
+func New(
+	brainRefreshTokenRecordHandler brainRecordHandler.RecordHandler,
+) refreshTokenRecordHandler.RecordHandler {
+
+	return &RecordHandler{
+		refreshTokenRecordHandler: brainRefreshTokenRecordHandler,
+	}
+}
+
+type CreateRequest struct {
+	RefreshToken refresh.Token
+}
+
+type CreateResponse struct {
+	RefreshToken refresh.Token
+}
+
+func (r *RecordHandler) ValidateCreateRequest(request *refreshTokenRecordHandler.CreateRequest) error {
+	reasonsInvalid := make([]string, 0)
+
+	if len(reasonsInvalid) > 0 {
+		return brainException.RequestInvalid{Reasons: reasonsInvalid}
+	}
+
+	return nil
+}
+
+func (r *RecordHandler) Create(request *refreshTokenRecordHandler.CreateRequest) (*refreshTokenRecordHandler.CreateResponse, error) {
+	if err := r.ValidateCreateRequest(request); err != nil {
+		return nil, err
+	}
+
+	createResponse := brainRecordHandler.CreateResponse{}
+	if err := r.refreshTokenRecordHandler.Create(&brainRecordHandler.CreateRequest{
+		Entity: &request.RefreshToken,
+	}, &createResponse); err != nil {
+		return nil, refreshTokenRecordHandlerException.Create{Reasons: []string{err.Error()}}
+	}
+	createdRefreshToken, ok := createResponse.Entity.(*refresh.Token)
+	if !ok {
+		return nil, refreshTokenRecordHandlerException.Create{Reasons: []string{"could not cast created entity to refresh token"}}
+	}
+
+	return &refreshTokenRecordHandler.CreateResponse{
+		RefreshToken: *createdRefreshToken,
+	}, nil
+}
+
+func (r *RecordHandler) Retrieve(request *refreshTokenRecordHandler.RetrieveRequest) (*refreshTokenRecordHandler.RetrieveResponse, error) {
+	retrievedRefreshToken := refresh.Token{}
+	retrieveResponse := brainRecordHandler.RetrieveResponse{
+		Entity: &retrievedRefreshToken,
+	}
+	if err := r.refreshTokenRecordHandler.Retrieve(&brainRecordHandler.RetrieveRequest{
+		Claims:     request.Claims,
+		Identifier: request.Identifier,
+	}, &retrieveResponse); err != nil {
+		switch err.(type) {
+		case brainRecordHandlerException.NotFound:
+			return nil, refreshTokenRecordHandlerException.NotFound{}
+		default:
+			return nil, err
+		}
+	}
+
+	return &refreshTokenRecordHandler.RetrieveResponse{
+		RefreshToken: retrievedRefreshToken,
+	}, nil
+}
+
+func (r *RecordHandler) Update(request *refreshTokenRecordHandler.UpdateRequest) (*refreshTokenRecordHandler.UpdateResponse, error) {
+	updateResponse := brainRecordHandler.UpdateResponse{}
+	if err := r.refreshTokenRecordHandler.Update(&brainRecordHandler.UpdateRequest{
+		Claims:     request.Claims,
+		Identifier: request.Identifier,
+		Entity:     &request.RefreshToken,
+	}, &updateResponse); err != nil {
+		return nil, refreshTokenRecordHandlerException.Update{Reasons: []string{err.Error()}}
+	}
+
+	return &refreshTokenRecordHandler.UpdateResponse{}, nil
+}
+
+func (r *RecordHandler) Delete(request *refreshTokenRecordHandler.DeleteRequest) (*refreshTokenRecordHandler.DeleteResponse, error) {
+	deleteResponse := brainRecordHandler.DeleteResponse{}
+	if err := r.refreshTokenRecordHandler.Delete(&brainRecordHandler.DeleteRequest{
+		Claims:     request.Claims,
+		Identifier: request.Identifier,
+	}, &deleteResponse); err != nil {
+		return nil, refreshTokenRecordHandlerException.Delete{Reasons: []string{err.Error()}}
+	}
+
+	return &refreshTokenRecordHandler.DeleteResponse{}, nil
+}
+
+func (r *RecordHandler) Collect(request *refreshTokenRecordHandler.CollectRequest) (*refreshTokenRecordHandler.CollectResponse, error) {
+	var collectedRefreshTokens []refresh.Token
+	collectResponse := brainRecordHandler.CollectResponse{
+		Records: &collectedRefreshTokens,
+	}
+	err := r.refreshTokenRecordHandler.Collect(&brainRecordHandler.CollectRequest{
+		Claims:   request.Claims,
+		Criteria: request.Criteria,
+		Query:    request.Query,
+	}, &collectResponse)
+	if err != nil {
+		return nil, refreshTokenRecordHandlerException.Collect{Reasons: []string{err.Error()}}
+	}
+
+	if collectedRefreshTokens == nil {
+		collectedRefreshTokens = make([]refresh.Token, 0)
+	}
+
+	return &refreshTokenRecordHandler.CollectResponse{
+		Records: collectedRefreshTokens,
+		Total:   collectResponse.Total,
+	}, nil
+}
+
+func (r *RecordHandler) UpdateFields(request *refreshTokenRecordHandler.UpdateFieldsRequest) (*refreshTokenRecordHandler.UpdateFieldsResponse, error) {
+	updatedRefreshToken := refresh.Token{}
+	updateFieldsResponse := brainRecordHandler.UpdateFieldsResponse{
+		Entity: &updatedRefreshToken,
+	}
+	if err := r.refreshTokenRecordHandler.UpdateFields(&brainRecordHandler.UpdateFieldsRequest{
+		Claims:   request.Claims,
+		Criteria: request.Criteria,
+		Update:   request.Update,
+	}, &updateFieldsResponse); err != nil {
+		switch err.(type) {
+		case brainRecordHandlerException.NotFound:
+			return nil, refreshTokenRecordHandlerException.NotFound{}
+		default:
+			return nil, refreshTokenRecordHandlerException.Update{Reasons: []string{err.Error()}}
+		}
+	}
+
+	return &refreshTokenRecordHandler.UpdateFieldsResponse{
+		RefreshToken: updatedRefreshToken,
+	}, nil
+}
+
+func (r *RecordHandler) UpdateAll(request *refreshTokenRecordHandler.UpdateAllRequest) (*refreshTokenRecordHandler.UpdateAllResponse, error) {
+	updateAllResponse := brainRecordHandler.UpdateAllResponse{}
+	if err := r.refreshTokenRecordHandler.UpdateAll(&brainRecordHandler.UpdateAllRequest{
+		Claims:   request.Claims,
+		Criteria: request.Criteria,
+		Update:   request.Update,
+	}, &updateAllResponse); err != nil {
+		return nil, refreshTokenRecordHandlerException.Update{Reasons: []string{err.Error()}}
+	}
+
+	return &refreshTokenRecordHandler.UpdateAllResponse{
+		NoUpdated: updateAllResponse.NoUpdated,
+	}, nil
+}
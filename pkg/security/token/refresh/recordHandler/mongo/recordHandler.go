@@ -0,0 +1,46 @@
+package mongo
+
+import (
+	brainMongoRecordHandler "github.com/iot-my-world/brain/pkg/recordHandler/mongo"
+	"github.com/iot-my-world/brain/pkg/security/claims"
+	"github.com/iot-my-world/brain/pkg/security/token/refresh"
+	refreshTokenRecordHandler "github.com/iot-my-world/brain/pkg/security/token/refresh/recordHandler"
+	refreshTokenGenericRecordHandler "github.com/iot-my-world/brain/pkg/security/token/refresh/recordHandler/generic"
+	"gopkg.in/mgo.v2"
+	"time"
+)
+
+func New(
+	mongoSession *mgo.Session,
+	databaseName string,
+	collectionName string,
+) refreshTokenRecordHandler.RecordHandler {
+	mongoRecordHandler := brainMongoRecordHandler.New(
+		mongoSession,
+		databaseName,
+		collectionName,
+		[]mgo.Index{
+			{
+				Key:    []string{"id"},
+				Unique: true,
+			},
+			{
+				Key:    []string{"tokenHash"},
+				Unique: true,
+			},
+			{
+				Key: []string{"sessionId"},
+			},
+			{
+				Key:         []string{"expiresAt"},
+				ExpireAfter: time.Second,
+			},
+		},
+		refresh.IsValidIdentifier,
+		claims.ContextualiseFilter,
+	)
+
+	return refreshTokenGenericRecordHandler.New(
+		mongoRecordHandler,
+	)
+}
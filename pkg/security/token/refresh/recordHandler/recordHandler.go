@@ -0,0 +1,106 @@
+package recordHandler
+
+import (
+	brainRecordHandler "github.com/iot-my-world/brain/pkg/recordHandler"
+	"github.com/iot-my-world/brain/pkg/search/criterion"
+	"github.com/iot-my-world/brain/pkg/search/identifier"
+	"github.com/iot-my-world/brain/pkg/search/query"
+	"github.com/iot-my-world/brain/pkg/security/claims"
+	"github.com/iot-my-world/brain/pkg/security/permission/api"
+	"github.com/iot-my-world/brain/pkg/security/token/refresh"
+)
+
+type RecordHandler interface {
+	Create(*CreateRequest) (*CreateResponse, error)
+	Retrieve(*RetrieveRequest) (*RetrieveResponse, error)
+	Update(*UpdateRequest) (*UpdateResponse, error)
+	Delete(*DeleteRequest) (*DeleteResponse, error)
+	Collect(*CollectRequest) (*CollectResponse, error)
+	UpdateFields(*UpdateFieldsRequest) (*UpdateFieldsResponse, error)
+	UpdateAll(*UpdateAllRequest) (*UpdateAllResponse, error)
+}
+
+const ServiceProvider = "RefreshToken-RecordHandler"
+const CreateService = ServiceProvider + ".Create"
+const RetrieveService = ServiceProvider + ".Retrieve"
+const UpdateService = ServiceProvider + ".Update"
+const DeleteService = ServiceProvider + ".Delete"
+const CollectService = ServiceProvider + ".Collect"
+
+var SystemUserPermissions = make([]api.Permission, 0)
+
+var CompanyAdminUserPermissions = make([]api.Permission, 0)
+
+var CompanyUserPermissions = make([]api.Permission, 0)
+
+var ClientAdminUserPermissions = make([]api.Permission, 0)
+
+var ClientUserPermissions = make([]api.Permission, 0)
+
+type CreateRequest struct {
+	RefreshToken refresh.Token
+}
+
+type CreateResponse struct {
+	RefreshToken refresh.Token
+}
+
+type RetrieveRequest struct {
+	Claims     claims.Claims
+	Identifier identifier.Identifier
+}
+
+type RetrieveResponse struct {
+	RefreshToken refresh.Token
+}
+
+type UpdateRequest struct {
+	Claims       claims.Claims
+	Identifier   identifier.Identifier
+	RefreshToken refresh.Token
+}
+
+type UpdateResponse struct{}
+
+type DeleteRequest struct {
+	Claims     claims.Claims
+	Identifier identifier.Identifier
+}
+
+type DeleteResponse struct {
+}
+
+type CollectRequest struct {
+	Claims   claims.Claims
+	Criteria []criterion.Criterion
+	Query    query.Query
+}
+
+type CollectResponse struct {
+	Records []refresh.Token
+	Total   int
+}
+
+// UpdateFieldsRequest atomically updates the fields of the first
+// refresh token matching the criteria
+type UpdateFieldsRequest struct {
+	Claims   claims.Claims
+	Criteria []criterion.Criterion
+	Update   brainRecordHandler.FieldsUpdate
+}
+
+type UpdateFieldsResponse struct {
+	RefreshToken refresh.Token
+}
+
+// UpdateAllRequest updates the fields of all refresh tokens
+// matching the criteria
+type UpdateAllRequest struct {
+	Claims   claims.Claims
+	Criteria []criterion.Criterion
+	Update   brainRecordHandler.FieldsUpdate
+}
+
+type UpdateAllResponse struct {
+	NoUpdated int
+}
@@ -0,0 +1,49 @@
+package refresh
+
+import (
+	"github.com/iot-my-world/brain/pkg/search/identifier"
+	"github.com/iot-my-world/brain/pkg/search/identifier/id"
+	"time"
+)
+
+// Token is a refresh token which can be exchanged once for a new access
+// token and refresh token. Only a hash of the token itself is kept.
+// The tokens issued from a single log in make up a session, all of
+// which are revoked if a token in it is used more than once. No token
+// in a session expires later than the session lifetime after log in.
+type Token struct {
+	Id string `json:"id" bson:"id"`
+
+	TokenHash string        `json:"tokenHash" bson:"tokenHash"`
+	SessionId string        `json:"sessionId" bson:"sessionId"`
+	UserId    id.Identifier `json:"userId" bson:"userId"`
+
+	SessionStartTime int64 `json:"sessionStartTime" bson:"sessionStartTime"`
+	IssueTime        int64 `json:"issueTime" bson:"issueTime"`
+	ExpirationTime   int64 `json:"expirationTime" bson:"expirationTime"`
+
+	// ExpiresAt is the expiration time as a date, after which the
+	// token is removed from the store
+	ExpiresAt time.Time `json:"-" bson:"expiresAt"`
+
+	// Used is set once the token has been exchanged
+	Used    bool `json:"used" bson:"used"`
+	Revoked bool `json:"revoked" bson:"revoked"`
+}
+
+func (t *Token) SetId(id string) {
+	t.Id = id
+}
+
+func IsValidIdentifier(id identifier.Identifier) bool {
+	if id == nil {
+		return false
+	}
+
+	switch id.Type() {
+	case identifier.Id:
+		return true
+	default:
+		return false
+	}
+}
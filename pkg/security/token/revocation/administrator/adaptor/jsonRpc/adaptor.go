@@ -0,0 +1,55 @@
+package jsonRpc
+
+import (
+	"github.com/iot-my-world/brain/internal/log"
+	jsonRpcServiceProvider "github.com/iot-my-world/brain/pkg/api/jsonRpc/service/provider"
+	wrappedIdentifier "github.com/iot-my-world/brain/pkg/search/identifier/wrapped"
+	wrappedClaims "github.com/iot-my-world/brain/pkg/security/claims/wrapped"
+	"github.com/iot-my-world/brain/pkg/security/token/revocation"
+	"github.com/iot-my-world/brain/pkg/security/token/revocation/administrator"
+	"net/http"
+)
+
+type adaptor struct {
+	administrator administrator.Administrator
+}
+
+func New(administrator administrator.Administrator) *adaptor {
+	return &adaptor{
+		administrator: administrator,
+	}
+}
+
+func (a *adaptor) Name() jsonRpcServiceProvider.Name {
+	return jsonRpcServiceProvider.Name(administrator.ServiceProvider)
+}
+
+func (a *adaptor) MethodRequiresAuthorization(string) bool {
+	return true
+}
+
+type RevokeAllTokensRequest struct {
+	SubjectType              revocation.SubjectType    `json:"subjectType"`
+	WrappedSubjectIdentifier wrappedIdentifier.Wrapped `json:"subjectIdentifier"`
+}
+
+type RevokeAllTokensResponse struct {
+}
+
+func (a *adaptor) RevokeAllTokens(r *http.Request, request *RevokeAllTokensRequest, response *RevokeAllTokensResponse) error {
+	claims, err := wrappedClaims.UnwrapClaimsFromContext(r)
+	if err != nil {
+		log.Warn(err.Error())
+		return err
+	}
+
+	if _, err := a.administrator.RevokeAllTokens(&administrator.RevokeAllTokensRequest{
+		Claims:            claims,
+		SubjectType:       request.SubjectType,
+		SubjectIdentifier: request.WrappedSubjectIdentifier.Identifier,
+	}); err != nil {
+		return err
+	}
+
+	return nil
+}
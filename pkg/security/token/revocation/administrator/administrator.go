@@ -0,0 +1,76 @@
+package administrator
+
+import (
+	"github.com/iot-my-world/brain/pkg/search/identifier"
+	"github.com/iot-my-world/brain/pkg/search/identifier/id"
+	"github.com/iot-my-world/brain/pkg/security/claims"
+	"github.com/iot-my-world/brain/pkg/security/permission/api"
+	"github.com/iot-my-world/brain/pkg/security/token/revocation"
+)
+
+type Administrator interface {
+	RevokeToken(request *RevokeTokenRequest) (*RevokeTokenResponse, error)
+	RevokeAllTokens(request *RevokeAllTokensRequest) (*RevokeAllTokensResponse, error)
+	RevokeSession(request *RevokeSessionRequest) (*RevokeSessionResponse, error)
+	TokenRevoked(request *TokenRevokedRequest) (*TokenRevokedResponse, error)
+}
+
+const ServiceProvider = "TokenRevocation-Administrator"
+const RevokeAllTokensService = ServiceProvider + ".RevokeAllTokens"
+
+var SystemUserPermissions = []api.Permission{
+	RevokeAllTokensService,
+}
+
+var CompanyAdminUserPermissions = []api.Permission{
+	RevokeAllTokensService,
+}
+
+var CompanyUserPermissions = make([]api.Permission, 0)
+
+var ClientAdminUserPermissions = []api.Permission{
+	RevokeAllTokensService,
+}
+
+var ClientUserPermissions = make([]api.Permission, 0)
+
+type RevokeTokenRequest struct {
+	Claims         claims.Claims
+	TokenId        string
+	ExpirationTime int64
+}
+
+type RevokeTokenResponse struct {
+}
+
+type RevokeAllTokensRequest struct {
+	Claims            claims.Claims
+	SubjectType       revocation.SubjectType
+	SubjectIdentifier identifier.Identifier
+}
+
+type RevokeAllTokensResponse struct {
+}
+
+// RevokeSessionRequest revokes all of the tokens issued in the log
+// in session with the given id. ExpirationTime is the time after which
+// no token in the session can be valid, 0 if unknown.
+type RevokeSessionRequest struct {
+	Claims         claims.Claims
+	SessionId      string
+	ExpirationTime int64
+}
+
+type RevokeSessionResponse struct {
+}
+
+type TokenRevokedRequest struct {
+	TokenId   string
+	SessionId string
+	SubjectId id.Identifier
+	IssueTime int64
+}
+
+type TokenRevokedResponse struct {
+	Result bool
+}
@@ -0,0 +1,290 @@
+package basic
+
+import (
+	brainException "github.com/iot-my-world/brain/internal/exception"
+	"github.com/iot-my-world/brain/internal/log"
+	brainRecordHandler "github.com/iot-my-world/brain/pkg/recordHandler"
+	"github.com/iot-my-world/brain/pkg/search/criterion"
+	exactTextCriterion "github.com/iot-my-world/brain/pkg/search/criterion/exact/text"
+	orCriterion "github.com/iot-my-world/brain/pkg/search/criterion/or"
+	"github.com/iot-my-world/brain/pkg/search/identifier/id"
+	humanUserLoginClaims "github.com/iot-my-world/brain/pkg/security/claims/login/user/human"
+	"github.com/iot-my-world/brain/pkg/security/token/revocation"
+	tokenRevocationAdministrator "github.com/iot-my-world/brain/pkg/security/token/revocation/administrator"
+	tokenRevocationAdministratorException "github.com/iot-my-world/brain/pkg/security/token/revocation/administrator/exception"
+	tokenRevocationRecordHandler "github.com/iot-my-world/brain/pkg/security/token/revocation/recordHandler"
+	sigfoxBackendRecordHandler "github.com/iot-my-world/brain/pkg/sigfox/backend/recordHandler"
+	apiUserRecordHandler "github.com/iot-my-world/brain/pkg/user/api/recordHandler"
+	humanUserRecordHandler "github.com/iot-my-world/brain/pkg/user/human/recordHandler"
+	"time"
+)
+
+type administrator struct {
+	tokenRevocationRecordHandler tokenRevocationRecordHandler.RecordHandler
+	humanUserRecordHandler       humanUserRecordHandler.RecordHandler
+	apiUserRecordHandler         apiUserRecordHandler.RecordHandler
+	sigfoxBackendRecordHandler   sigfoxBackendRecordHandler.RecordHandler
+	systemClaims                 *humanUserLoginClaims.Login
+}
+
+func New(
+	tokenRevocationRecordHandler tokenRevocationRecordHandler.RecordHandler,
+	humanUserRecordHandler humanUserRecordHandler.RecordHandler,
+	apiUserRecordHandler apiUserRecordHandler.RecordHandler,
+	sigfoxBackendRecordHandler sigfoxBackendRecordHandler.RecordHandler,
+	systemClaims *humanUserLoginClaims.Login,
+) tokenRevocationAdministrator.Administrator {
+	return &administrator{
+		tokenRevocationRecordHandler: tokenRevocationRecordHandler,
+		humanUserRecordHandler:       humanUserRecordHandler,
+		apiUserRecordHandler:         apiUserRecordHandler,
+		sigfoxBackendRecordHandler:   sigfoxBackendRecordHandler,
+		systemClaims:                 systemClaims,
+	}
+}
+
+func (a *administrator) ValidateRevokeTokenRequest(request *tokenRevocationAdministrator.RevokeTokenRequest) error {
+	reasonsInvalid := make([]string, 0)
+
+	if request.Claims == nil {
+		reasonsInvalid = append(reasonsInvalid, "claims are nil")
+	}
+
+	if request.TokenId == "" {
+		reasonsInvalid = append(reasonsInvalid, "token id is blank")
+	}
+
+	if len(reasonsInvalid) > 0 {
+		return brainException.RequestInvalid{Reasons: reasonsInvalid}
+	}
+	return nil
+}
+
+func (a *administrator) RevokeToken(request *tokenRevocationAdministrator.RevokeTokenRequest) (*tokenRevocationAdministrator.RevokeTokenResponse, error) {
+	if err := a.ValidateRevokeTokenRequest(request); err != nil {
+		return nil, err
+	}
+
+	if _, err := a.tokenRevocationRecordHandler.Create(&tokenRevocationRecordHandler.CreateRequest{
+		Revocation: revocation.Revocation{
+			TokenId:        request.TokenId,
+			RevocationTime: time.Now().UTC().Unix(),
+			ExpirationTime: request.ExpirationTime,
+			ExpiresAt:      expiresAt(request.ExpirationTime),
+		},
+	}); err != nil {
+		err = tokenRevocationAdministratorException.RevocationCreation{Reasons: []string{err.Error()}}
+		log.Error(err.Error())
+		return nil, err
+	}
+
+	return &tokenRevocationAdministrator.RevokeTokenResponse{}, nil
+}
+
+func (a *administrator) ValidateRevokeAllTokensRequest(request *tokenRevocationAdministrator.RevokeAllTokensRequest) error {
+	reasonsInvalid := make([]string, 0)
+
+	if request.Claims == nil {
+		reasonsInvalid = append(reasonsInvalid, "claims are nil")
+	}
+
+	switch request.SubjectType {
+	case revocation.HumanUser, revocation.APIUser, revocation.SigfoxBackend:
+	default:
+		reasonsInvalid = append(reasonsInvalid, "invalid subject type: "+string(request.SubjectType))
+	}
+
+	if request.SubjectIdentifier == nil {
+		reasonsInvalid = append(reasonsInvalid, "subject identifier is nil")
+	} else if err := request.SubjectIdentifier.IsValid(); err != nil {
+		reasonsInvalid = append(reasonsInvalid, "invalid subject identifier: "+err.Error())
+	}
+
+	if len(reasonsInvalid) > 0 {
+		return brainException.RequestInvalid{Reasons: reasonsInvalid}
+	}
+	return nil
+}
+
+func (a *administrator) RevokeAllTokens(request *tokenRevocationAdministrator.RevokeAllTokensRequest) (*tokenRevocationAdministrator.RevokeAllTokensResponse, error) {
+	if err := a.ValidateRevokeAllTokensRequest(request); err != nil {
+		return nil, err
+	}
+
+	// retrieve the subject with the claims of the request to confirm that
+	// it exists and that it is in the context of the party making the request
+	var subjectId id.Identifier
+	switch request.SubjectType {
+	case revocation.HumanUser:
+		retrieveResponse, err := a.humanUserRecordHandler.Retrieve(&humanUserRecordHandler.RetrieveRequest{
+			Claims:     request.Claims,
+			Identifier: request.SubjectIdentifier,
+		})
+		if err != nil {
+			err = tokenRevocationAdministratorException.SubjectRetrieval{Reasons: []string{"human user", err.Error()}}
+			log.Error(err.Error())
+			return nil, err
+		}
+		subjectId = id.Identifier{Id: retrieveResponse.User.Id}
+
+	case revocation.APIUser:
+		retrieveResponse, err := a.apiUserRecordHandler.Retrieve(&apiUserRecordHandler.RetrieveRequest{
+			Claims:     request.Claims,
+			Identifier: request.SubjectIdentifier,
+		})
+		if err != nil {
+			err = tokenRevocationAdministratorException.SubjectRetrieval{Reasons: []string{"api user", err.Error()}}
+			log.Error(err.Error())
+			return nil, err
+		}
+		subjectId = id.Identifier{Id: retrieveResponse.User.Id}
+
+	case revocation.SigfoxBackend:
+		retrieveResponse, err := a.sigfoxBackendRecordHandler.Retrieve(&sigfoxBackendRecordHandler.RetrieveRequest{
+			Claims:     request.Claims,
+			Identifier: request.SubjectIdentifier,
+		})
+		if err != nil {
+			err = tokenRevocationAdministratorException.SubjectRetrieval{Reasons: []string{"sigfox backend", err.Error()}}
+			log.Error(err.Error())
+			return nil, err
+		}
+		subjectId = id.Identifier{Id: retrieveResponse.Backend.Id}
+	}
+
+	// the revocation of the subject is created the first time that all of
+	// its tokens are revoked, and its revocation time moved on thereafter
+	if _, err := a.tokenRevocationRecordHandler.UpdateFields(&tokenRevocationRecordHandler.UpdateFieldsRequest{
+		Claims: a.systemClaims,
+		Criteria: []criterion.Criterion{
+			exactTextCriterion.Criterion{
+				Field: "subjectType",
+				Text:  string(request.SubjectType),
+			},
+			exactTextCriterion.Criterion{
+				Field: "subjectId.id",
+				Text:  subjectId.Id,
+			},
+		},
+		Update: brainRecordHandler.FieldsUpdate{
+			Maximum: map[string]interface{}{"revocationTime": time.Now().UTC().Unix()},
+		},
+		Upsert: true,
+	}); err != nil {
+		err = tokenRevocationAdministratorException.RevocationCreation{Reasons: []string{err.Error()}}
+		log.Error(err.Error())
+		return nil, err
+	}
+
+	return &tokenRevocationAdministrator.RevokeAllTokensResponse{}, nil
+}
+
+func (a *administrator) ValidateRevokeSessionRequest(request *tokenRevocationAdministrator.RevokeSessionRequest) error {
+	reasonsInvalid := make([]string, 0)
+
+	if request.Claims == nil {
+		reasonsInvalid = append(reasonsInvalid, "claims are nil")
+	}
+
+	if request.SessionId == "" {
+		reasonsInvalid = append(reasonsInvalid, "session id is blank")
+	}
+
+	if len(reasonsInvalid) > 0 {
+		return brainException.RequestInvalid{Reasons: reasonsInvalid}
+	}
+	return nil
+}
+
+func (a *administrator) RevokeSession(request *tokenRevocationAdministrator.RevokeSessionRequest) (*tokenRevocationAdministrator.RevokeSessionResponse, error) {
+	if err := a.ValidateRevokeSessionRequest(request); err != nil {
+		return nil, err
+	}
+
+	if _, err := a.tokenRevocationRecordHandler.Create(&tokenRevocationRecordHandler.CreateRequest{
+		Revocation: revocation.Revocation{
+			SessionId:      request.SessionId,
+			RevocationTime: time.Now().UTC().Unix(),
+			ExpirationTime: request.ExpirationTime,
+			ExpiresAt:      expiresAt(request.ExpirationTime),
+		},
+	}); err != nil {
+		err = tokenRevocationAdministratorException.RevocationCreation{Reasons: []string{err.Error()}}
+		log.Error(err.Error())
+		return nil, err
+	}
+
+	return &tokenRevocationAdministrator.RevokeSessionResponse{}, nil
+}
+
+// TokenRevoked checks the revocations of the token, its session and its
+// subject in a single query. A token is only revoked by the revocation of
+// its subject if it was issued in an earlier second than the revocation,
+// so that tokens issued immediately after, e.g. on logging in again after
+// changing a password, are not revoked along with the earlier ones.
+func (a *administrator) TokenRevoked(request *tokenRevocationAdministrator.TokenRevokedRequest) (*tokenRevocationAdministrator.TokenRevokedResponse, error) {
+	revocationCriteria := make([]criterion.Criterion, 0)
+	if request.TokenId != "" {
+		revocationCriteria = append(revocationCriteria, exactTextCriterion.Criterion{
+			Field: "tokenId",
+			Text:  request.TokenId,
+		})
+	}
+	if request.SessionId != "" {
+		revocationCriteria = append(revocationCriteria, exactTextCriterion.Criterion{
+			Field: "sessionId",
+			Text:  request.SessionId,
+		})
+	}
+	if request.SubjectId.Id != "" {
+		revocationCriteria = append(revocationCriteria, exactTextCriterion.Criterion{
+			Field: "subjectId.id",
+			Text:  request.SubjectId.Id,
+		})
+	}
+	if len(revocationCriteria) == 0 {
+		return &tokenRevocationAdministrator.TokenRevokedResponse{Result: false}, nil
+	}
+
+	collectResponse, err := a.tokenRevocationRecordHandler.Collect(&tokenRevocationRecordHandler.CollectRequest{
+		Claims: a.systemClaims,
+		Criteria: []criterion.Criterion{
+			orCriterion.Criterion{Criteria: revocationCriteria},
+		},
+		SkipCount: true,
+	})
+	if err != nil {
+		err = tokenRevocationAdministratorException.RevocationCollection{Reasons: []string{err.Error()}}
+		log.Error(err.Error())
+		return nil, err
+	}
+
+	for _, revocationToCheck := range collectResponse.Records {
+		switch {
+		case revocationToCheck.TokenId != "":
+			if revocationToCheck.TokenId == request.TokenId {
+				return &tokenRevocationAdministrator.TokenRevokedResponse{Result: true}, nil
+			}
+		case revocationToCheck.SessionId != "":
+			if revocationToCheck.SessionId == request.SessionId {
+				return &tokenRevocationAdministrator.TokenRevokedResponse{Result: true}, nil
+			}
+		default:
+			if revocationToCheck.SubjectId.Id == request.SubjectId.Id &&
+				revocationToCheck.RevocationTime > request.IssueTime {
+				return &tokenRevocationAdministrator.TokenRevokedResponse{Result: true}, nil
+			}
+		}
+	}
+
+	return &tokenRevocationAdministrator.TokenRevokedResponse{Result: false}, nil
+}
+
+// expiresAt returns the given expiration time as a date,
+// or the zero time if the revoked token never expires
+func expiresAt(expirationTime int64) time.Time {
+	if expirationTime == 0 {
+		return time.Time{}
+	}
+	return time.Unix(expirationTime, 0).UTC()
+}
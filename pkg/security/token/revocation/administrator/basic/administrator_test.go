@@ -0,0 +1,114 @@
+package basic
+
+import (
+	"github.com/iot-my-world/brain/pkg/party"
+	exactTextCriterion "github.com/iot-my-world/brain/pkg/search/criterion/exact/text"
+	orCriterion "github.com/iot-my-world/brain/pkg/search/criterion/or"
+	"github.com/iot-my-world/brain/pkg/search/identifier/id"
+	humanUserLoginClaims "github.com/iot-my-world/brain/pkg/security/claims/login/user/human"
+	"github.com/iot-my-world/brain/pkg/security/token/revocation"
+	tokenRevocationAdministrator "github.com/iot-my-world/brain/pkg/security/token/revocation/administrator"
+	tokenRevocationRecordHandler "github.com/iot-my-world/brain/pkg/security/token/revocation/recordHandler"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+// fakeRecordHandler collects the given revocations matching any
+// of the exact text criteria in the or criterion of a request,
+// recording the number of collect requests made
+type fakeRecordHandler struct {
+	tokenRevocationRecordHandler.RecordHandler
+	revocations   []revocation.Revocation
+	noCollections int
+}
+
+func field(r revocation.Revocation, name string) string {
+	switch name {
+	case "tokenId":
+		return r.TokenId
+	case "sessionId":
+		return r.SessionId
+	case "subjectId.id":
+		return r.SubjectId.Id
+	default:
+		panic("unsupported field " + name)
+	}
+}
+
+func (r *fakeRecordHandler) Collect(request *tokenRevocationRecordHandler.CollectRequest) (*tokenRevocationRecordHandler.CollectResponse, error) {
+	r.noCollections++
+	records := make([]revocation.Revocation, 0)
+	for _, revocationToMatch := range r.revocations {
+		for _, crit := range request.Criteria[0].(orCriterion.Criterion).Criteria {
+			textCriterion := crit.(exactTextCriterion.Criterion)
+			if field(revocationToMatch, textCriterion.Field) == textCriterion.Text {
+				records = append(records, revocationToMatch)
+				break
+			}
+		}
+	}
+	return &tokenRevocationRecordHandler.CollectResponse{Records: records}, nil
+}
+
+func TestTokenRevoked(t *testing.T) {
+	recordHandler := &fakeRecordHandler{
+		revocations: []revocation.Revocation{
+			{TokenId: "revoked-token"},
+			{SessionId: "revoked-session"},
+			{SubjectType: revocation.HumanUser, SubjectId: id.Identifier{Id: "user"}, RevocationTime: 1000},
+		},
+	}
+	a := New(recordHandler, nil, nil, nil, &humanUserLoginClaims.Login{PartyType: party.System})
+
+	tests := []struct {
+		name     string
+		request  tokenRevocationAdministrator.TokenRevokedRequest
+		expected bool
+	}{
+		{
+			name:     "revoked token",
+			request:  tokenRevocationAdministrator.TokenRevokedRequest{TokenId: "revoked-token", SubjectId: id.Identifier{Id: "other"}},
+			expected: true,
+		},
+		{
+			name:     "token of revoked session",
+			request:  tokenRevocationAdministrator.TokenRevokedRequest{TokenId: "token", SessionId: "revoked-session"},
+			expected: true,
+		},
+		{
+			name:     "token issued before revocation of subject",
+			request:  tokenRevocationAdministrator.TokenRevokedRequest{TokenId: "token", SubjectId: id.Identifier{Id: "user"}, IssueTime: 999},
+			expected: true,
+		},
+		{
+			name:     "token issued in the second of revocation of subject",
+			request:  tokenRevocationAdministrator.TokenRevokedRequest{TokenId: "token", SubjectId: id.Identifier{Id: "user"}, IssueTime: 1000},
+			expected: false,
+		},
+		{
+			name:     "token of other subject",
+			request:  tokenRevocationAdministrator.TokenRevokedRequest{TokenId: "token", SessionId: "session", SubjectId: id.Identifier{Id: "other"}},
+			expected: false,
+		},
+		{
+			name:     "token without id",
+			request:  tokenRevocationAdministrator.TokenRevokedRequest{},
+			expected: false,
+		},
+	}
+
+	for _, test := range tests {
+		recordHandler.noCollections = 0
+		response, err := a.TokenRevoked(&test.request)
+		if !assert.NoError(t, err, test.name) {
+			continue
+		}
+		assert.Equal(t, test.expected, response.Result, test.name)
+		assert.True(t, recordHandler.noCollections <= 1, "%s: %d collections", test.name, recordHandler.noCollections)
+	}
+}
+
+func TestExpiresAt(t *testing.T) {
+	assert.True(t, expiresAt(0).IsZero())
+	assert.Equal(t, int64(1000), expiresAt(1000).Unix())
+}
@@ -0,0 +1,29 @@
+package exception
+
+import (
+	"strings"
+)
+
+type SubjectRetrieval struct {
+	Reasons []string
+}
+
+func (e SubjectRetrieval) Error() string {
+	return "error retrieving token subject: " + strings.Join(e.Reasons, "; ")
+}
+
+type RevocationCreation struct {
+	Reasons []string
+}
+
+func (e RevocationCreation) Error() string {
+	return "error creating token revocation: " + strings.Join(e.Reasons, "; ")
+}
+
+type RevocationCollection struct {
+	Reasons []string
+}
+
+func (e RevocationCollection) Error() string {
+	return "error collecting token revocations: " + strings.Join(e.Reasons, "; ")
+}
@@ -0,0 +1,75 @@
+package jsonRpc
+
+import (
+	brainException "github.com/iot-my-world/brain/internal/exception"
+	"github.com/iot-my-world/brain/internal/log"
+	jsonRpcClient "github.com/iot-my-world/brain/pkg/api/jsonRpc/client"
+	wrappedIdentifier "github.com/iot-my-world/brain/pkg/search/identifier/wrapped"
+	tokenRevocationAdministrator "github.com/iot-my-world/brain/pkg/security/token/revocation/administrator"
+	tokenRevocationAdministratorJsonRpcAdaptor "github.com/iot-my-world/brain/pkg/security/token/revocation/administrator/adaptor/jsonRpc"
+)
+
+type administrator struct {
+	jsonRpcClient jsonRpcClient.Client
+}
+
+func New(
+	jsonRpcClient jsonRpcClient.Client,
+) tokenRevocationAdministrator.Administrator {
+	return &administrator{
+		jsonRpcClient: jsonRpcClient,
+	}
+}
+
+func (a *administrator) RevokeToken(request *tokenRevocationAdministrator.RevokeTokenRequest) (*tokenRevocationAdministrator.RevokeTokenResponse, error) {
+	return nil, brainException.NotImplemented{}
+}
+
+func (a *administrator) ValidateRevokeAllTokensRequest(request *tokenRevocationAdministrator.RevokeAllTokensRequest) error {
+	reasonsInvalid := make([]string, 0)
+
+	if request.SubjectIdentifier == nil {
+		reasonsInvalid = append(reasonsInvalid, "subject identifier is nil")
+	}
+
+	if len(reasonsInvalid) > 0 {
+		return brainException.RequestInvalid{Reasons: reasonsInvalid}
+	}
+	return nil
+}
+
+func (a *administrator) RevokeAllTokens(request *tokenRevocationAdministrator.RevokeAllTokensRequest) (*tokenRevocationAdministrator.RevokeAllTokensResponse, error) {
+	if err := a.ValidateRevokeAllTokensRequest(request); err != nil {
+		return nil, err
+	}
+
+	// wrap identifier
+	subjectIdentifier, err := wrappedIdentifier.Wrap(request.SubjectIdentifier)
+	if err != nil {
+		log.Error(err.Error())
+		return nil, err
+	}
+
+	revokeAllTokensResponse := tokenRevocationAdministratorJsonRpcAdaptor.RevokeAllTokensResponse{}
+	if err := a.jsonRpcClient.JsonRpcRequest(
+		tokenRevocationAdministrator.RevokeAllTokensService,
+		tokenRevocationAdministratorJsonRpcAdaptor.RevokeAllTokensRequest{
+			SubjectType:              request.SubjectType,
+			WrappedSubjectIdentifier: *subjectIdentifier,
+		},
+		&revokeAllTokensResponse,
+	); err != nil {
+		log.Error(err.Error())
+		return nil, err
+	}
+
+	return &tokenRevocationAdministrator.RevokeAllTokensResponse{}, nil
+}
+
+func (a *administrator) RevokeSession(request *tokenRevocationAdministrator.RevokeSessionRequest) (*tokenRevocationAdministrator.RevokeSessionResponse, error) {
+	return nil, brainException.NotImplemented{}
+}
+
+func (a *administrator) TokenRevoked(request *tokenRevocationAdministrator.TokenRevokedRequest) (*tokenRevocationAdministrator.TokenRevokedResponse, error) {
+	return nil, brainException.NotImplemented{}
+}
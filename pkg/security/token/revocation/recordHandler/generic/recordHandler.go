@@ -0,0 +1,158 @@
+package tokenRevocationRecordHandler
+
+import (
+	brainException "github.com/iot-my-world/brain/internal/exception"
+	brainRecordHandler "github.com/iot-my-world/brain/pkg/recordHandler"
+	brainRecordHandlerException "github.com/iot-my-world/brain/pkg/recordHandler/exception"
+	"github.com/iot-my-world/brain/pkg/security/token/revocation"
+	tokenRevocationRecordHandler "github.com/iot-my-world/brain/pkg/security/token/revocation/recordHandler"
+	tokenRevocationRecordHandlerException "github.com/iot-my-world/brain/pkg/security/token/revocation/recordHandler/exception"
+)
+
+type RecordHandler struct {
+	tokenRevocationRecordHandler brainRecordHandler.RecordHandler
+}
+
+func New(
+	brainRevocationRecordHandler brainRecordHandler.RecordHandler,
+) tokenRevocationRecordHandler.RecordHandler {
+
+	return &RecordHandler{
+		tokenRevocationRecordHandler: brainRevocationRecordHandler,
+	}
+}
+
+type CreateRequest struct {
+	Revocation revocation.Revocation
+}
+
+type CreateResponse struct {
+	Revocation revocation.Revocation
+}
+
+func (r *RecordHandler) ValidateCreateRequest(request *tokenRevocationRecordHandler.CreateRequest) error {
+	reasonsInvalid := make([]string, 0)
+
+	if len(reasonsInvalid) > 0 {
+		return brainException.RequestInvalid{Reasons: reasonsInvalid}
+	}
+
+	return nil
+}
+
+func (r *RecordHandler) Create(request *tokenRevocationRecordHandler.CreateRequest) (*tokenRevocationRecordHandler.CreateResponse, error) {
+	if err := r.ValidateCreateRequest(request); err != nil {
+		return nil, err
+	}
+
+	createResponse := brainRecordHandler.CreateResponse{}
+	if err := r.tokenRevocationRecordHandler.Create(&brainRecordHandler.CreateRequest{
+		Entity: &request.Revocation,
+	}, &createResponse); err != nil {
+		return nil, tokenRevocationRecordHandlerException.Create{Reasons: []string{err.Error()}}
+	}
+	createdRevocation, ok := createResponse.Entity.(*revocation.Revocation)
+	if !ok {
+		return nil, tokenRevocationRecordHandlerException.Create{Reasons: []string{"could not cast created entity to token revocation"}}
+	}
+
+	return &tokenRevocationRecordHandler.CreateResponse{
+		Revocation: *createdRevocation,
+	}, nil
+}
+
+func (r *RecordHandler) Retrieve(request *tokenRevocationRecordHandler.RetrieveRequest) (*tokenRevocationRecordHandler.RetrieveResponse, error) {
+	retrievedRevocation := revocation.Revocation{}
+	retrieveResponse := brainRecordHandler.RetrieveResponse{
+		Entity: &retrievedRevocation,
+	}
+	if err := r.tokenRevocationRecordHandler.Retrieve(&brainRecordHandler.RetrieveRequest{
+		Claims:     request.Claims,
+		Identifier: request.Identifier,
+	}, &retrieveResponse); err != nil {
+		switch err.(type) {
+		case brainRecordHandlerException.NotFound:
+			return nil, tokenRevocationRecordHandlerException.NotFound{}
+		default:
+			return nil, err
+		}
+	}
+
+	return &tokenRevocationRecordHandler.RetrieveResponse{
+		Revocation: retrievedRevocation,
+	}, nil
+}
+
+func (r *RecordHandler) Update(request *tokenRevocationRecordHandler.UpdateRequest) (*tokenRevocationRecordHandler.UpdateResponse, error) {
+	updateResponse := brainRecordHandler.UpdateResponse{}
+	if err := r.tokenRevocationRecordHandler.Update(&brainRecordHandler.UpdateRequest{
+		Claims:     request.Claims,
+		Identifier: request.Identifier,
+		Entity:     &request.Revocation,
+	}, &updateResponse); err != nil {
+		return nil, tokenRevocationRecordHandlerException.Update{Reasons: []string{err.Error()}}
+	}
+
+	return &tokenRevocationRecordHandler.UpdateResponse{}, nil
+}
+
+func (r *RecordHandler) Delete(request *tokenRevocationRecordHandler.DeleteRequest) (*tokenRevocationRecordHandler.DeleteResponse, error) {
+	deleteResponse := brainRecordHandler.DeleteResponse{}
+	if err := r.tokenRevocationRecordHandler.Delete(&brainRecordHandler.DeleteRequest{
+		Claims:     request.Claims,
+		Identifier: request.Identifier,
+	}, &deleteResponse); err != nil {
+		return nil, tokenRevocationRecordHandlerException.Delete{Reasons: []string{err.Error()}}
+	}
+
+	return &tokenRevocationRecordHandler.DeleteResponse{}, nil
+}
+
+func (r *RecordHandler) Collect(request *tokenRevocationRecordHandler.CollectRequest) (*tokenRevocationRecordHandler.CollectResponse, error) {
+	var collectedRevocation []revocation.Revocation
+	collectResponse := brainRecordHandler.CollectResponse{
+		Records: &collectedRevocation,
+	}
+	err := r.tokenRevocationRecordHandler.Collect(&brainRecordHandler.CollectRequest{
+		Claims:    request.Claims,
+		Criteria:  request.Criteria,
+		Query:     request.Query,
+		SkipCount: request.SkipCount,
+	}, &collectResponse)
+	if err != nil {
+		return nil, tokenRevocationRecordHandlerException.Collect{Reasons: []string{err.Error()}}
+	}
+
+	if collectedRevocation == nil {
+		collectedRevocation = make([]revocation.Revocation, 0)
+	}
+
+	return &tokenRevocationRecordHandler.CollectResponse{
+		Records: collectedRevocation,
+		Total:   collectResponse.Total,
+	}, nil
+}
+
+func (r *RecordHandler) UpdateFields(request *tokenRevocationRecordHandler.UpdateFieldsRequest) (*tokenRevocationRecordHandler.UpdateFieldsResponse, error) {
+	updatedRevocation := revocation.Revocation{}
+	updateFieldsResponse := brainRecordHandler.UpdateFieldsResponse{
+		Entity: &updatedRevocation,
+	}
+	if err := r.tokenRevocationRecordHandler.UpdateFields(&brainRecordHandler.UpdateFieldsRequest{
+		Claims:   request.Claims,
+		Criteria: request.Criteria,
+		Update:   request.Update,
+		Upsert:   request.Upsert,
+	}, &updateFieldsResponse); err != nil {
+		switch err.(type) {
+		case brainRecordHandlerException.NotFound:
+			return nil, tokenRevocationRecordHandlerException.NotFound{}
+		default:
+			return nil, tokenRevocationRecordHandlerException.Update{Reasons: []string{err.Error()}}
+		}
+	}
+
+	return &tokenRevocationRecordHandler.UpdateFieldsResponse{
+		Revocation: updatedRevocation,
+	}, nil
+}
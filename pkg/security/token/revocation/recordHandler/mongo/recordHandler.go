@@ -0,0 +1,49 @@
+package mongo
+
+import (
+	brainMongoRecordHandler "github.com/iot-my-world/brain/pkg/recordHandler/mongo"
+	"github.com/iot-my-world/brain/pkg/security/claims"
+	"github.com/iot-my-world/brain/pkg/security/token/revocation"
+	tokenRevocationRecordHandler "github.com/iot-my-world/brain/pkg/security/token/revocation/recordHandler"
+	tokenRevocationGenericRecordHandler "github.com/iot-my-world/brain/pkg/security/token/revocation/recordHandler/generic"
+	"gopkg.in/mgo.v2"
+	"time"
+)
+
+func New(
+	mongoSession *mgo.Session,
+	databaseName string,
+	collectionName string,
+) tokenRevocationRecordHandler.RecordHandler {
+	mongoRecordHandler := brainMongoRecordHandler.New(
+		mongoSession,
+		databaseName,
+		collectionName,
+		[]mgo.Index{
+			{
+				Key:    []string{"id"},
+				Unique: true,
+			},
+			{
+				Key: []string{"tokenId"},
+			},
+			{
+				Key: []string{"sessionId"},
+			},
+			{
+				Key: []string{"subjectId.id"},
+			},
+			{
+				// remove revocations once the revoked token has expired
+				Key:         []string{"expiresAt"},
+				ExpireAfter: time.Second,
+			},
+		},
+		revocation.IsValidIdentifier,
+		claims.ContextualiseFilter,
+	)
+
+	return tokenRevocationGenericRecordHandler.New(
+		mongoRecordHandler,
+	)
+}
@@ -0,0 +1,53 @@
+package revocation
+
+import (
+	"github.com/iot-my-world/brain/pkg/search/identifier"
+	"github.com/iot-my-world/brain/pkg/search/identifier/id"
+	"time"
+)
+
+type SubjectType string
+
+const HumanUser SubjectType = "HumanUser"
+const APIUser SubjectType = "APIUser"
+const SigfoxBackend SubjectType = "SigfoxBackend"
+
+// Revocation is either the revocation of a single token, in which
+// case TokenId is set, the revocation of all tokens issued in a log
+// in session, in which case SessionId is set, or the revocation of all
+// tokens issued for a subject before the RevocationTime, in which case
+// TokenId and SessionId are blank and the SubjectType and SubjectId are
+// set. There is only one revocation for each subject, of which the
+// RevocationTime is moved on each time that all of its tokens are revoked.
+type Revocation struct {
+	Id string `json:"id" bson:"id"`
+
+	TokenId     string        `json:"tokenId" bson:"tokenId"`
+	SessionId   string        `json:"sessionId" bson:"sessionId"`
+	SubjectType SubjectType   `json:"subjectType" bson:"subjectType"`
+	SubjectId   id.Identifier `json:"subjectId" bson:"subjectId"`
+
+	RevocationTime int64 `json:"revocationTime" bson:"revocationTime"`
+	// ExpirationTime of the revoked token, 0 if it never expires
+	ExpirationTime int64 `json:"expirationTime" bson:"expirationTime"`
+	// ExpiresAt is the ExpirationTime as a date, by which revocations
+	// are removed once the revoked token can no longer be used anyway
+	ExpiresAt time.Time `json:"-" bson:"expiresAt,omitempty"`
+}
+
+func (r *Revocation) SetId(id string) {
+	r.Id = id
+}
+
+func IsValidIdentifier(id identifier.Identifier) bool {
+	if id == nil {
+		return false
+	}
+
+	switch id.Type() {
+	case identifier.Id:
+		return true
+	default:
+		return false
+	}
+}
@@ -3,17 +3,36 @@ package token
 import (
 	"crypto/rsa"
 	"encoding/json"
+	"github.com/iot-my-world/brain/pkg/search/identifier/id"
+	"github.com/iot-my-world/brain/pkg/security/claims"
+	apiUserLoginClaims "github.com/iot-my-world/brain/pkg/security/claims/login/user/api"
+	humanUserLoginClaims "github.com/iot-my-world/brain/pkg/security/claims/login/user/human"
+	registerClientAdminUserClaims "github.com/iot-my-world/brain/pkg/security/claims/registerClientAdminUser"
+	registerClientUserClaims "github.com/iot-my-world/brain/pkg/security/claims/registerClientUser"
+	registerCompanyAdminUserClaims "github.com/iot-my-world/brain/pkg/security/claims/registerCompanyAdminUser"
+	registerCompanyUserClaims "github.com/iot-my-world/brain/pkg/security/claims/registerCompanyUser"
+	resetPasswordClaims "github.com/iot-my-world/brain/pkg/security/claims/resetPassword"
+	sigfoxBackendClaims "github.com/iot-my-world/brain/pkg/security/claims/sigfoxBackend"
+	verifyTOTPClaims "github.com/iot-my-world/brain/pkg/security/claims/verifyTOTP"
 	wrappedClaims "github.com/iot-my-world/brain/pkg/security/claims/wrapped"
 	"github.com/iot-my-world/brain/pkg/security/token/exception"
+	tokenRevocationAdministrator "github.com/iot-my-world/brain/pkg/security/token/revocation/administrator"
 	"gopkg.in/square/go-jose.v2"
 )
 
 type JWTValidator struct {
-	rsaPublicKey *rsa.PublicKey
+	rsaPublicKey                 *rsa.PublicKey
+	tokenRevocationAdministrator tokenRevocationAdministrator.Administrator
 }
 
-func NewJWTValidator(rsaPublicKey *rsa.PublicKey) JWTValidator {
-	return JWTValidator{rsaPublicKey: rsaPublicKey}
+func NewJWTValidator(
+	rsaPublicKey *rsa.PublicKey,
+	tokenRevocationAdministrator tokenRevocationAdministrator.Administrator,
+) JWTValidator {
+	return JWTValidator{
+		rsaPublicKey:                 rsaPublicKey,
+		tokenRevocationAdministrator: tokenRevocationAdministrator,
+	}
 }
 
 func (jwtv *JWTValidator) ValidateJWT(jwt string) (wrappedClaims.Wrapped, error) {
@@ -38,6 +57,60 @@ func (jwtv *JWTValidator) ValidateJWT(jwt string) (wrappedClaims.Wrapped, error)
 		return wrappedClaims.Wrapped{}, exception.JWTUnmarshalling{Reasons: []string{err.Error()}}
 	}
 
+	// Confirm that the token has not been revoked
+	unwrapped, err := wrapped.Unwrap()
+	if err != nil {
+		return wrappedClaims.Wrapped{}, exception.InvalidJWT{Reasons: []string{err.Error()}}
+	}
+	tokenRevokedResponse, err := jwtv.tokenRevocationAdministrator.TokenRevoked(&tokenRevocationAdministrator.TokenRevokedRequest{
+		TokenId:   wrapped.TokenId,
+		SessionId: sessionId(unwrapped),
+		SubjectId: subjectId(unwrapped),
+		IssueTime: wrapped.IssueTime,
+	})
+	if err != nil {
+		return wrappedClaims.Wrapped{}, exception.RevocationCheck{Reasons: []string{err.Error()}}
+	}
+	if tokenRevokedResponse.Result {
+		return wrappedClaims.Wrapped{}, exception.RevokedJWT{}
+	}
+
 	// Unwrap the claims and return the result
 	return wrapped, nil
 }
+
+// subjectId returns the id of the entity for which a token
+// with the given claims was issued
+func subjectId(claimsToCheck claims.Claims) id.Identifier {
+	switch typedClaims := claimsToCheck.(type) {
+	case humanUserLoginClaims.Login:
+		return typedClaims.UserId
+	case apiUserLoginClaims.Login:
+		return typedClaims.UserId
+	case resetPasswordClaims.ResetPassword:
+		return typedClaims.UserId
+	case registerCompanyAdminUserClaims.RegisterCompanyAdminUser:
+		return id.Identifier{Id: typedClaims.User.Id}
+	case registerCompanyUserClaims.RegisterCompanyUser:
+		return id.Identifier{Id: typedClaims.User.Id}
+	case registerClientAdminUserClaims.RegisterClientAdminUser:
+		return id.Identifier{Id: typedClaims.User.Id}
+	case registerClientUserClaims.RegisterClientUser:
+		return id.Identifier{Id: typedClaims.User.Id}
+	case sigfoxBackendClaims.SigfoxBackend:
+		return typedClaims.BackendId
+	case verifyTOTPClaims.VerifyTOTP:
+		return typedClaims.UserId
+	default:
+		return id.Identifier{}
+	}
+}
+
+// sessionId returns the id of the log in session in which a
+// token with the given claims was issued, blank if there is none
+func sessionId(claimsToCheck claims.Claims) string {
+	if loginClaims, ok := claimsToCheck.(humanUserLoginClaims.Login); ok {
+		return loginClaims.SessionId
+	}
+	return ""
+}
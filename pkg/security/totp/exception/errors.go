@@ -0,0 +1,35 @@
+package exception
+
+import "strings"
+
+type SecretGeneration struct {
+	Reasons []string
+}
+
+func (e SecretGeneration) Error() string {
+	return "error generating totp secret: " + strings.Join(e.Reasons, "; ")
+}
+
+type InvalidSecret struct {
+	Reasons []string
+}
+
+func (e InvalidSecret) Error() string {
+	return "invalid totp secret: " + strings.Join(e.Reasons, "; ")
+}
+
+type SecretEncryption struct {
+	Reasons []string
+}
+
+func (e SecretEncryption) Error() string {
+	return "error encrypting totp secret: " + strings.Join(e.Reasons, "; ")
+}
+
+type SecretDecryption struct {
+	Reasons []string
+}
+
+func (e SecretDecryption) Error() string {
+	return "error decrypting totp secret: " + strings.Join(e.Reasons, "; ")
+}
@@ -0,0 +1,177 @@
+package totp
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"encoding/base32"
+	"encoding/base64"
+	"encoding/binary"
+	"fmt"
+	totpException "github.com/iot-my-world/brain/pkg/security/totp/exception"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Issuer is the name shown for accounts in authenticator apps
+const Issuer = "IOT My World"
+
+// Period is the number of seconds for which a code is valid
+const Period = 30
+
+// Digits is the number of digits in a code
+const Digits = 6
+
+// Skew is the number of periods before and after the current
+// one in which a code is still accepted, allowing for clock drift
+const Skew = 1
+
+const secretLength = 20
+
+var encoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// GenerateSecret returns a new random base32 encoded shared secret
+func GenerateSecret() (string, error) {
+	secret := make([]byte, secretLength)
+	if _, err := rand.Read(secret); err != nil {
+		return "", totpException.SecretGeneration{Reasons: []string{err.Error()}}
+	}
+	return encoding.EncodeToString(secret), nil
+}
+
+// EncryptSecret encrypts the given secret with the given AES key so
+// that it can be stored, since it is needed in plain text to check codes
+func EncryptSecret(key []byte, secret string) (string, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return "", totpException.SecretEncryption{Reasons: []string{err.Error()}}
+	}
+
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", totpException.SecretEncryption{Reasons: []string{"generating nonce", err.Error()}}
+	}
+
+	return base64.StdEncoding.EncodeToString(gcm.Seal(nonce, nonce, []byte(secret), nil)), nil
+}
+
+// DecryptSecret decrypts the given secret encrypted with EncryptSecret
+func DecryptSecret(key []byte, encryptedSecret string) (string, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return "", totpException.SecretDecryption{Reasons: []string{err.Error()}}
+	}
+
+	sealedSecret, err := base64.StdEncoding.DecodeString(encryptedSecret)
+	if err != nil {
+		return "", totpException.SecretDecryption{Reasons: []string{err.Error()}}
+	}
+	if len(sealedSecret) < gcm.NonceSize() {
+		return "", totpException.SecretDecryption{Reasons: []string{"encrypted secret too short"}}
+	}
+
+	secret, err := gcm.Open(nil, sealedSecret[:gcm.NonceSize()], sealedSecret[gcm.NonceSize():], nil)
+	if err != nil {
+		return "", totpException.SecretDecryption{Reasons: []string{err.Error()}}
+	}
+	return string(secret), nil
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// ProvisioningURI returns the otpauth uri with which an
+// authenticator app can be enrolled, usually shown as a qr code
+func ProvisioningURI(accountName, secret string) string {
+	query := url.Values{}
+	query.Set("secret", secret)
+	query.Set("issuer", Issuer)
+	query.Set("algorithm", "SHA1")
+	query.Set("digits", fmt.Sprintf("%d", Digits))
+	query.Set("period", fmt.Sprintf("%d", Period))
+
+	return (&url.URL{
+		Scheme:   "otpauth",
+		Host:     "totp",
+		Path:     "/" + Issuer + ":" + accountName,
+		RawQuery: query.Encode(),
+	}).String()
+}
+
+// Step returns the time step in which the given time falls
+func Step(at time.Time) int64 {
+	return at.Unix() / Period
+}
+
+// Code returns the code for the given secret in the given time step
+func Code(secret string, step int64) (string, error) {
+	key, err := encoding.DecodeString(strings.ToUpper(secret))
+	if err != nil {
+		return "", totpException.InvalidSecret{Reasons: []string{err.Error()}}
+	}
+
+	counter := make([]byte, 8)
+	binary.BigEndian.PutUint64(counter, uint64(step))
+	mac := hmac.New(sha1.New, key)
+	mac.Write(counter)
+	sum := mac.Sum(nil)
+
+	// dynamic truncation as described in RFC 4226
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+	modulus := uint32(1)
+	for i := 0; i < Digits; i++ {
+		modulus *= 10
+	}
+
+	return fmt.Sprintf("%0*d", Digits, value%modulus), nil
+}
+
+// Validate checks the given code against the given secret at the given time.
+// If the code is valid the time step for which it was generated is returned
+// so that the caller can reject the code should it be used again.
+func Validate(secret, code string, at time.Time) (bool, int64, error) {
+	code = strings.TrimSpace(code)
+	if len(code) != Digits {
+		return false, 0, nil
+	}
+
+	currentStep := Step(at)
+	for step := currentStep - Skew; step <= currentStep+Skew; step++ {
+		expectedCode, err := Code(secret, step)
+		if err != nil {
+			return false, 0, err
+		}
+		if hmac.Equal([]byte(expectedCode), []byte(code)) {
+			return true, step, nil
+		}
+	}
+
+	return false, 0, nil
+}
+
+// RecoveryCodeCount is the number of recovery codes issued on enrolment
+const RecoveryCodeCount = 10
+
+// GenerateRecoveryCodes returns a new set of random single use
+// codes with which a user can log in without their authenticator
+func GenerateRecoveryCodes() ([]string, error) {
+	codes := make([]string, 0)
+	for i := 0; i < RecoveryCodeCount; i++ {
+		codeBytes := make([]byte, 5)
+		if _, err := rand.Read(codeBytes); err != nil {
+			return nil, totpException.SecretGeneration{Reasons: []string{"recovery code", err.Error()}}
+		}
+		code := strings.ToLower(encoding.EncodeToString(codeBytes))
+		codes = append(codes, code[:4]+"-"+code[4:])
+	}
+	return codes, nil
+}
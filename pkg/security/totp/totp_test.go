@@ -0,0 +1,30 @@
+package totp
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+var testKey = []byte("0123456789abcdef0123456789abcdef")
+
+func TestSecretEncryption(t *testing.T) {
+	secret, err := GenerateSecret()
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	encryptedSecret, err := EncryptSecret(testKey, secret)
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.NotContains(t, encryptedSecret, secret)
+
+	decryptedSecret, err := DecryptSecret(testKey, encryptedSecret)
+	if assert.NoError(t, err) {
+		assert.Equal(t, secret, decryptedSecret)
+	}
+
+	// the secret cannot be decrypted with another key
+	_, err = DecryptSecret([]byte("fedcba9876543210fedcba9876543210"), encryptedSecret)
+	assert.Error(t, err)
+}
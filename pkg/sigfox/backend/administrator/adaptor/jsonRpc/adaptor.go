@@ -3,6 +3,7 @@ package jsonRpc
 import (
 	"github.com/iot-my-world/brain/internal/log"
 	jsonRpcServiceProvider "github.com/iot-my-world/brain/pkg/api/jsonRpc/service/provider"
+	wrappedIdentifier "github.com/iot-my-world/brain/pkg/search/identifier/wrapped"
 	wrappedClaims "github.com/iot-my-world/brain/pkg/security/claims/wrapped"
 	"github.com/iot-my-world/brain/pkg/sigfox/backend"
 	"github.com/iot-my-world/brain/pkg/sigfox/backend/administrator"
@@ -82,3 +83,31 @@ func (a *adaptor) UpdateAllowedFields(r *http.Request, request *UpdateAllowedFie
 
 	return nil
 }
+
+type RegenerateTokenRequest struct {
+	WrappedIdentifier wrappedIdentifier.Wrapped `json:"identifier"`
+}
+
+type RegenerateTokenResponse struct {
+	Backend backend.Backend `json:"backend"`
+}
+
+func (a *adaptor) RegenerateToken(r *http.Request, request *RegenerateTokenRequest, response *RegenerateTokenResponse) error {
+	claims, err := wrappedClaims.UnwrapClaimsFromContext(r)
+	if err != nil {
+		log.Warn(err.Error())
+		return err
+	}
+
+	regenerateTokenResponse, err := a.administrator.RegenerateToken(&administrator.RegenerateTokenRequest{
+		Claims:     claims,
+		Identifier: request.WrappedIdentifier.Identifier,
+	})
+	if err != nil {
+		return err
+	}
+
+	response.Backend = regenerateTokenResponse.Backend
+
+	return nil
+}
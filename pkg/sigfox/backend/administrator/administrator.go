@@ -10,20 +10,24 @@ import (
 type Administrator interface {
 	Create(request *CreateRequest) (*CreateResponse, error)
 	UpdateAllowedFields(request *UpdateAllowedFieldsRequest) (*UpdateAllowedFieldsResponse, error)
+	RegenerateToken(request *RegenerateTokenRequest) (*RegenerateTokenResponse, error)
 }
 
 const ServiceProvider = "SigfoxBackend-Administrator"
 const UpdateAllowedFieldsService = ServiceProvider + ".UpdateAllowedFields"
 const CreateService = ServiceProvider + ".Create"
+const RegenerateTokenService = ServiceProvider + ".RegenerateToken"
 
 var SystemUserPermissions = []api.Permission{
 	CreateService,
 	UpdateAllowedFieldsService,
+	RegenerateTokenService,
 }
 
 var CompanyAdminUserPermissions = []api.Permission{
 	CreateService,
 	UpdateAllowedFieldsService,
+	RegenerateTokenService,
 }
 
 var CompanyUserPermissions = make([]api.Permission, 0)
@@ -31,6 +35,7 @@ var CompanyUserPermissions = make([]api.Permission, 0)
 var ClientAdminUserPermissions = []api.Permission{
 	CreateService,
 	UpdateAllowedFieldsService,
+	RegenerateTokenService,
 }
 
 var ClientUserPermissions = make([]api.Permission, 0)
@@ -53,6 +58,18 @@ type UpdateAllowedFieldsResponse struct {
 	Backend backend.Backend
 }
 
+// RegenerateTokenRequest revokes all of the tokens issued for the backend
+// with the given identifier and issues it a new one, e.g. when its token
+// has been leaked
+type RegenerateTokenRequest struct {
+	Claims     claims.Claims
+	Identifier identifier.Identifier
+}
+
+type RegenerateTokenResponse struct {
+	Backend backend.Backend
+}
+
 type HeartbeatRequest struct {
 	Claims            claims.Claims
 	BackendIdentifier identifier.Identifier
@@ -8,6 +8,9 @@ import (
 	"github.com/iot-my-world/brain/pkg/search/identifier/id"
 	sigfoxBackendClaims "github.com/iot-my-world/brain/pkg/security/claims/sigfoxBackend"
 	"github.com/iot-my-world/brain/pkg/security/token"
+	"github.com/iot-my-world/brain/pkg/security/token/revocation"
+	tokenRevocationAdministrator "github.com/iot-my-world/brain/pkg/security/token/revocation/administrator"
+	"github.com/iot-my-world/brain/pkg/sigfox/backend"
 	"github.com/iot-my-world/brain/pkg/sigfox/backend/action"
 	backendAdministrator "github.com/iot-my-world/brain/pkg/sigfox/backend/administrator"
 	"github.com/iot-my-world/brain/pkg/sigfox/backend/administrator/exception"
@@ -16,20 +19,23 @@ import (
 )
 
 type administrator struct {
-	backendDeviceValidator validator.Validator
-	backendRecordHandler   recordHandler.RecordHandler
-	jwtGenerator           token.JWTGenerator
+	backendDeviceValidator       validator.Validator
+	backendRecordHandler         recordHandler.RecordHandler
+	jwtGenerator                 token.JWTGenerator
+	tokenRevocationAdministrator tokenRevocationAdministrator.Administrator
 }
 
 func New(
 	backendDeviceValidator validator.Validator,
 	backendRecordHandler recordHandler.RecordHandler,
 	rsaPrivateKey *rsa.PrivateKey,
+	tokenRevocationAdministrator tokenRevocationAdministrator.Administrator,
 ) backendAdministrator.Administrator {
 	return &administrator{
-		backendDeviceValidator: backendDeviceValidator,
-		backendRecordHandler:   backendRecordHandler,
-		jwtGenerator:           token.NewJWTGenerator(rsaPrivateKey),
+		backendDeviceValidator:       backendDeviceValidator,
+		backendRecordHandler:         backendRecordHandler,
+		jwtGenerator:                 token.NewJWTGenerator(rsaPrivateKey),
+		tokenRevocationAdministrator: tokenRevocationAdministrator,
 	}
 }
 
@@ -76,12 +82,7 @@ func (a *administrator) Create(request *backendAdministrator.CreateRequest) (*ba
 		return nil, err
 	}
 
-	claimsForToken := sigfoxBackendClaims.SigfoxBackend{
-		BackendId:      id.Identifier{Id: createResponse.Backend.Id},
-		OwnerPartyType: createResponse.Backend.OwnerPartyType,
-		OwnerId:        createResponse.Backend.OwnerId,
-	}
-	backendToken, err := a.jwtGenerator.GenerateToken(claimsForToken)
+	backendToken, err := a.generateToken(createResponse.Backend)
 	if err != nil {
 		err = exception.DeviceCreation{Reasons: []string{"token generation", err.Error()}}
 		log.Error(err.Error())
@@ -162,3 +163,80 @@ func (a *administrator) UpdateAllowedFields(request *backendAdministrator.Update
 		Backend: backendRetrieveResponse.Backend,
 	}, nil
 }
+
+// generateToken generates a token for the given backend
+// with which it authenticates its callbacks
+func (a *administrator) generateToken(backendToIssue backend.Backend) (string, error) {
+	return a.jwtGenerator.GenerateToken(sigfoxBackendClaims.SigfoxBackend{
+		BackendId:      id.Identifier{Id: backendToIssue.Id},
+		OwnerPartyType: backendToIssue.OwnerPartyType,
+		OwnerId:        backendToIssue.OwnerId,
+	})
+}
+
+func (a *administrator) ValidateRegenerateTokenRequest(request *backendAdministrator.RegenerateTokenRequest) error {
+	reasonsInvalid := make([]string, 0)
+
+	if request.Claims == nil {
+		reasonsInvalid = append(reasonsInvalid, "claims are nil")
+	}
+
+	if request.Identifier == nil {
+		reasonsInvalid = append(reasonsInvalid, "identifier is nil")
+	}
+
+	if len(reasonsInvalid) > 0 {
+		return brainException.RequestInvalid{Reasons: reasonsInvalid}
+	}
+	return nil
+}
+
+func (a *administrator) RegenerateToken(request *backendAdministrator.RegenerateTokenRequest) (*backendAdministrator.RegenerateTokenResponse, error) {
+	if err := a.ValidateRegenerateTokenRequest(request); err != nil {
+		return nil, err
+	}
+
+	// retrieve the backend
+	backendRetrieveResponse, err := a.backendRecordHandler.Retrieve(&recordHandler.RetrieveRequest{
+		Claims:     request.Claims,
+		Identifier: request.Identifier,
+	})
+	if err != nil {
+		err = exception.DeviceRetrieval{Reasons: []string{err.Error()}}
+		log.Error(err.Error())
+		return nil, err
+	}
+
+	// revoke the tokens issued to the backend so far
+	if _, err := a.tokenRevocationAdministrator.RevokeAllTokens(&tokenRevocationAdministrator.RevokeAllTokensRequest{
+		Claims:            request.Claims,
+		SubjectType:       revocation.SigfoxBackend,
+		SubjectIdentifier: id.Identifier{Id: backendRetrieveResponse.Backend.Id},
+	}); err != nil {
+		err = exception.TokenRegeneration{Reasons: []string{"revoking tokens", err.Error()}}
+		log.Error(err.Error())
+		return nil, err
+	}
+
+	backendToken, err := a.generateToken(backendRetrieveResponse.Backend)
+	if err != nil {
+		err = exception.TokenRegeneration{Reasons: []string{"token generation", err.Error()}}
+		log.Error(err.Error())
+		return nil, err
+	}
+
+	backendRetrieveResponse.Backend.Token = backendToken
+	if _, err := a.backendRecordHandler.Update(&recordHandler.UpdateRequest{
+		Claims:     request.Claims,
+		Identifier: id.Identifier{Id: backendRetrieveResponse.Backend.Id},
+		Backend:    backendRetrieveResponse.Backend,
+	}); err != nil {
+		err = exception.TokenRegeneration{Reasons: []string{"update sigfox backend with token", err.Error()}}
+		log.Error(err.Error())
+		return nil, err
+	}
+
+	return &backendAdministrator.RegenerateTokenResponse{
+		Backend: backendRetrieveResponse.Backend,
+	}, nil
+}
@@ -51,3 +51,11 @@ type Heartbeat struct {
 func (e Heartbeat) Error() string {
 	return "heartbeat error: " + strings.Join(e.Reasons, "; ")
 }
+
+type TokenRegeneration struct {
+	Reasons []string
+}
+
+func (e TokenRegeneration) Error() string {
+	return "error regenerating backend token: " + strings.Join(e.Reasons, "; ")
+}
@@ -4,6 +4,7 @@ import (
 	brainException "github.com/iot-my-world/brain/internal/exception"
 	"github.com/iot-my-world/brain/internal/log"
 	jsonRpcClient "github.com/iot-my-world/brain/pkg/api/jsonRpc/client"
+	wrappedIdentifier "github.com/iot-my-world/brain/pkg/search/identifier/wrapped"
 	backendAdministrator "github.com/iot-my-world/brain/pkg/sigfox/backend/administrator"
 	backendAdministratorJsonRpcAdaptor "github.com/iot-my-world/brain/pkg/sigfox/backend/administrator/adaptor/jsonRpc"
 )
@@ -79,3 +80,45 @@ func (a *administrator) UpdateAllowedFields(request *backendAdministrator.Update
 		Backend: backendUpdateAllowedFieldsResponse.Backend,
 	}, nil
 }
+
+func (a *administrator) ValidateRegenerateTokenRequest(request *backendAdministrator.RegenerateTokenRequest) error {
+	reasonsInvalid := make([]string, 0)
+
+	if request.Identifier == nil {
+		reasonsInvalid = append(reasonsInvalid, "identifier is nil")
+	}
+
+	if len(reasonsInvalid) > 0 {
+		return brainException.RequestInvalid{Reasons: reasonsInvalid}
+	}
+	return nil
+}
+
+func (a *administrator) RegenerateToken(request *backendAdministrator.RegenerateTokenRequest) (*backendAdministrator.RegenerateTokenResponse, error) {
+	if err := a.ValidateRegenerateTokenRequest(request); err != nil {
+		return nil, err
+	}
+
+	// wrap identifier
+	id, err := wrappedIdentifier.Wrap(request.Identifier)
+	if err != nil {
+		log.Error(err.Error())
+		return nil, err
+	}
+
+	regenerateTokenResponse := backendAdministratorJsonRpcAdaptor.RegenerateTokenResponse{}
+	if err := a.jsonRpcClient.JsonRpcRequest(
+		backendAdministrator.RegenerateTokenService,
+		backendAdministratorJsonRpcAdaptor.RegenerateTokenRequest{
+			WrappedIdentifier: *id,
+		},
+		&regenerateTokenResponse,
+	); err != nil {
+		log.Error(err.Error())
+		return nil, err
+	}
+
+	return &backendAdministrator.RegenerateTokenResponse{
+		Backend: regenerateTokenResponse.Backend,
+	}, nil
+}
@@ -7,6 +7,7 @@ import (
 	messageAdministrator "github.com/iot-my-world/brain/pkg/sigfox/backend/callback/data/message/administrator"
 	"github.com/iot-my-world/brain/pkg/sigfox/backend/callback/data/message/administrator/exception"
 	"github.com/iot-my-world/brain/pkg/sigfox/backend/callback/data/message/recordHandler"
+	recordHandlerException "github.com/iot-my-world/brain/pkg/sigfox/backend/callback/data/message/recordHandler/exception"
 	"github.com/iot-my-world/brain/pkg/sigfox/backend/callback/data/message/validator"
 )
 
@@ -63,7 +64,13 @@ func (a *administrator) Create(request *messageAdministrator.CreateRequest) (*me
 		Message: request.Message,
 	})
 	if err != nil {
-		return nil, exception.DeviceCreation{Reasons: []string{err.Error()}}
+		switch err.(type) {
+		case recordHandlerException.Duplicate:
+			// repeated messages are reported as such so that they can be ignored
+			return nil, err
+		default:
+			return nil, exception.DeviceCreation{Reasons: []string{err.Error()}}
+		}
 	}
 
 	return &messageAdministrator.CreateResponse{
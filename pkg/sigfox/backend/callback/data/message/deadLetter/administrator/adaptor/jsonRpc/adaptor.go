@@ -0,0 +1,57 @@
+package jsonRpc
+
+import (
+	"github.com/iot-my-world/brain/internal/log"
+	jsonRpcServiceProvider "github.com/iot-my-world/brain/pkg/api/jsonRpc/service/provider"
+	wrappedIdentifier "github.com/iot-my-world/brain/pkg/search/identifier/wrapped"
+	wrappedClaims "github.com/iot-my-world/brain/pkg/security/claims/wrapped"
+	"github.com/iot-my-world/brain/pkg/sigfox/backend/callback/data/message/deadLetter"
+	"github.com/iot-my-world/brain/pkg/sigfox/backend/callback/data/message/deadLetter/administrator"
+	"net/http"
+)
+
+type adaptor struct {
+	administrator administrator.Administrator
+}
+
+func New(administrator administrator.Administrator) *adaptor {
+	return &adaptor{
+		administrator: administrator,
+	}
+}
+
+func (a *adaptor) Name() jsonRpcServiceProvider.Name {
+	return jsonRpcServiceProvider.Name(administrator.ServiceProvider)
+}
+
+func (a *adaptor) MethodRequiresAuthorization(string) bool {
+	return true
+}
+
+type ReplayRequest struct {
+	WrappedIdentifier wrappedIdentifier.Wrapped `json:"identifier"`
+}
+
+type ReplayResponse struct {
+	DeadLetter deadLetter.DeadLetter `json:"deadLetter"`
+}
+
+func (a *adaptor) Replay(r *http.Request, request *ReplayRequest, response *ReplayResponse) error {
+	claims, err := wrappedClaims.UnwrapClaimsFromContext(r)
+	if err != nil {
+		log.Warn(err.Error())
+		return err
+	}
+
+	replayResponse, err := a.administrator.Replay(&administrator.ReplayRequest{
+		Claims:     claims,
+		Identifier: request.WrappedIdentifier.Identifier,
+	})
+	if err != nil {
+		return err
+	}
+
+	response.DeadLetter = replayResponse.DeadLetter
+
+	return nil
+}
@@ -0,0 +1,36 @@
+package administrator
+
+import (
+	"github.com/iot-my-world/brain/pkg/search/identifier"
+	"github.com/iot-my-world/brain/pkg/security/claims"
+	"github.com/iot-my-world/brain/pkg/security/permission/api"
+	"github.com/iot-my-world/brain/pkg/sigfox/backend/callback/data/message/deadLetter"
+)
+
+type Administrator interface {
+	Replay(request *ReplayRequest) (*ReplayResponse, error)
+}
+
+const ServiceProvider = "SigfoxBackendDataCallbackMessageDeadLetter-Administrator"
+const ReplayService = ServiceProvider + ".Replay"
+
+var SystemUserPermissions = []api.Permission{
+	ReplayService,
+}
+
+var CompanyAdminUserPermissions = make([]api.Permission, 0)
+
+var CompanyUserPermissions = make([]api.Permission, 0)
+
+var ClientAdminUserPermissions = make([]api.Permission, 0)
+
+var ClientUserPermissions = make([]api.Permission, 0)
+
+type ReplayRequest struct {
+	Claims     claims.Claims
+	Identifier identifier.Identifier
+}
+
+type ReplayResponse struct {
+	DeadLetter deadLetter.DeadLetter
+}
@@ -0,0 +1,141 @@
+package basic
+
+import (
+	brainException "github.com/iot-my-world/brain/internal/exception"
+	"github.com/iot-my-world/brain/internal/log"
+	brainRecordHandler "github.com/iot-my-world/brain/pkg/recordHandler"
+	"github.com/iot-my-world/brain/pkg/search/criterion"
+	exactBooleanCriterion "github.com/iot-my-world/brain/pkg/search/criterion/exact/boolean"
+	exactTextCriterion "github.com/iot-my-world/brain/pkg/search/criterion/exact/text"
+	sigfoxBackendDataCallbackMessageDeadLetterAdministrator "github.com/iot-my-world/brain/pkg/sigfox/backend/callback/data/message/deadLetter/administrator"
+	sigfoxBackendDataCallbackMessageDeadLetterAdministratorException "github.com/iot-my-world/brain/pkg/sigfox/backend/callback/data/message/deadLetter/administrator/exception"
+	sigfoxBackendDataCallbackMessageDeadLetterRecordHandler "github.com/iot-my-world/brain/pkg/sigfox/backend/callback/data/message/deadLetter/recordHandler"
+	sigfoxBackendDataCallbackMessageDeadLetterRecordHandlerException "github.com/iot-my-world/brain/pkg/sigfox/backend/callback/data/message/deadLetter/recordHandler/exception"
+	sigfoxBackendDataCallbackMessageDispatcher "github.com/iot-my-world/brain/pkg/sigfox/backend/callback/data/message/dispatcher"
+	"time"
+)
+
+type administrator struct {
+	sigfoxBackendDataCallbackMessageDeadLetterRecordHandler sigfoxBackendDataCallbackMessageDeadLetterRecordHandler.RecordHandler
+	sigfoxBackendDataCallbackMessageDispatcher              sigfoxBackendDataCallbackMessageDispatcher.Dispatcher
+}
+
+func New(
+	sigfoxBackendDataCallbackMessageDeadLetterRecordHandler sigfoxBackendDataCallbackMessageDeadLetterRecordHandler.RecordHandler,
+	sigfoxBackendDataCallbackMessageDispatcher sigfoxBackendDataCallbackMessageDispatcher.Dispatcher,
+) sigfoxBackendDataCallbackMessageDeadLetterAdministrator.Administrator {
+	return &administrator{
+		sigfoxBackendDataCallbackMessageDeadLetterRecordHandler: sigfoxBackendDataCallbackMessageDeadLetterRecordHandler,
+		sigfoxBackendDataCallbackMessageDispatcher:              sigfoxBackendDataCallbackMessageDispatcher,
+	}
+}
+
+func (a *administrator) ValidateReplayRequest(request *sigfoxBackendDataCallbackMessageDeadLetterAdministrator.ReplayRequest) error {
+	reasonsInvalid := make([]string, 0)
+
+	if request.Claims == nil {
+		reasonsInvalid = append(reasonsInvalid, "claims are nil")
+	}
+
+	if request.Identifier == nil {
+		reasonsInvalid = append(reasonsInvalid, "identifier is nil")
+	}
+
+	if len(reasonsInvalid) > 0 {
+		return brainException.RequestInvalid{Reasons: reasonsInvalid}
+	}
+	return nil
+}
+
+func (a *administrator) Replay(request *sigfoxBackendDataCallbackMessageDeadLetterAdministrator.ReplayRequest) (*sigfoxBackendDataCallbackMessageDeadLetterAdministrator.ReplayResponse, error) {
+	if err := a.ValidateReplayRequest(request); err != nil {
+		log.Error(err.Error())
+		return nil, err
+	}
+
+	// retrieve the dead letter
+	retrieveResponse, err := a.sigfoxBackendDataCallbackMessageDeadLetterRecordHandler.Retrieve(&sigfoxBackendDataCallbackMessageDeadLetterRecordHandler.RetrieveRequest{
+		Claims:     request.Claims,
+		Identifier: request.Identifier,
+	})
+	if err != nil {
+		err = sigfoxBackendDataCallbackMessageDeadLetterAdministratorException.Replay{Reasons: []string{"retrieving dead letter", err.Error()}}
+		log.Error(err.Error())
+		return nil, err
+	}
+	if retrieveResponse.DeadLetter.Replayed {
+		err = sigfoxBackendDataCallbackMessageDeadLetterAdministratorException.AlreadyReplayed{Reasons: []string{retrieveResponse.DeadLetter.Id}}
+		log.Error(err.Error())
+		return nil, err
+	}
+
+	// claim the dead letter by marking it as replayed only if it has not been
+	// replayed yet, so that it is only replayed once however many times it is
+	// replayed concurrently. should the replay fail a new dead letter is recorded.
+	claimResponse, err := a.sigfoxBackendDataCallbackMessageDeadLetterRecordHandler.UpdateFields(&sigfoxBackendDataCallbackMessageDeadLetterRecordHandler.UpdateFieldsRequest{
+		Claims: request.Claims,
+		Criteria: []criterion.Criterion{
+			exactTextCriterion.Criterion{
+				Field: "id",
+				Text:  retrieveResponse.DeadLetter.Id,
+			},
+			exactBooleanCriterion.Criterion{
+				Field:   "replayed",
+				Boolean: false,
+			},
+		},
+		Update: brainRecordHandler.FieldsUpdate{
+			Set: map[string]interface{}{
+				"replayed":     true,
+				"replayedTime": time.Now().UTC().Unix(),
+			},
+		},
+	})
+	if err != nil {
+		switch err.(type) {
+		case sigfoxBackendDataCallbackMessageDeadLetterRecordHandlerException.NotFound:
+			err = sigfoxBackendDataCallbackMessageDeadLetterAdministratorException.AlreadyReplayed{Reasons: []string{retrieveResponse.DeadLetter.Id}}
+		default:
+			err = sigfoxBackendDataCallbackMessageDeadLetterAdministratorException.Replay{Reasons: []string{"claiming dead letter", err.Error()}}
+		}
+		log.Error(err.Error())
+		return nil, err
+	}
+
+	// give the message back to the handler which failed to handle it.
+	// the callback has long since been responded to and so no downlink can be sent.
+	replayMessage := claimResponse.DeadLetter.Message
+	replayMessage.Ack = false
+	if _, err := a.sigfoxBackendDataCallbackMessageDispatcher.Dispatch(&sigfoxBackendDataCallbackMessageDispatcher.DispatchRequest{
+		Claims:  request.Claims,
+		Message: replayMessage,
+		Handler: claimResponse.DeadLetter.Handler,
+	}); err != nil {
+		err = sigfoxBackendDataCallbackMessageDeadLetterAdministratorException.Replay{Reasons: []string{"dispatching message", err.Error()}}
+		log.Error(err.Error())
+
+		// release the claim so that the dead letter can be replayed again
+		if _, releaseErr := a.sigfoxBackendDataCallbackMessageDeadLetterRecordHandler.UpdateFields(&sigfoxBackendDataCallbackMessageDeadLetterRecordHandler.UpdateFieldsRequest{
+			Claims: request.Claims,
+			Criteria: []criterion.Criterion{
+				exactTextCriterion.Criterion{
+					Field: "id",
+					Text:  claimResponse.DeadLetter.Id,
+				},
+			},
+			Update: brainRecordHandler.FieldsUpdate{
+				Set: map[string]interface{}{
+					"replayed":     false,
+					"replayedTime": int64(0),
+				},
+			},
+		}); releaseErr != nil {
+			log.Error("error releasing claim on dead letter: " + releaseErr.Error())
+		}
+		return nil, err
+	}
+
+	return &sigfoxBackendDataCallbackMessageDeadLetterAdministrator.ReplayResponse{
+		DeadLetter: claimResponse.DeadLetter,
+	}, nil
+}
@@ -0,0 +1,19 @@
+package exception
+
+import "strings"
+
+type AlreadyReplayed struct {
+	Reasons []string
+}
+
+func (e AlreadyReplayed) Error() string {
+	return "dead letter already replayed: " + strings.Join(e.Reasons, "; ")
+}
+
+type Replay struct {
+	Reasons []string
+}
+
+func (e Replay) Error() string {
+	return "error replaying dead letter: " + strings.Join(e.Reasons, "; ")
+}
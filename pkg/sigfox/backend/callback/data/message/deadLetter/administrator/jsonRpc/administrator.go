@@ -0,0 +1,64 @@
+package jsonRpc
+
+import (
+	brainException "github.com/iot-my-world/brain/internal/exception"
+	"github.com/iot-my-world/brain/internal/log"
+	jsonRpcClient "github.com/iot-my-world/brain/pkg/api/jsonRpc/client"
+	wrappedIdentifier "github.com/iot-my-world/brain/pkg/search/identifier/wrapped"
+	sigfoxBackendDataCallbackMessageDeadLetterAdministrator "github.com/iot-my-world/brain/pkg/sigfox/backend/callback/data/message/deadLetter/administrator"
+	sigfoxBackendDataCallbackMessageDeadLetterAdministratorJsonRpcAdaptor "github.com/iot-my-world/brain/pkg/sigfox/backend/callback/data/message/deadLetter/administrator/adaptor/jsonRpc"
+)
+
+type administrator struct {
+	jsonRpcClient jsonRpcClient.Client
+}
+
+func New(
+	jsonRpcClient jsonRpcClient.Client,
+) sigfoxBackendDataCallbackMessageDeadLetterAdministrator.Administrator {
+	return &administrator{
+		jsonRpcClient: jsonRpcClient,
+	}
+}
+
+func (a *administrator) ValidateReplayRequest(request *sigfoxBackendDataCallbackMessageDeadLetterAdministrator.ReplayRequest) error {
+	reasonsInvalid := make([]string, 0)
+
+	if request.Identifier == nil {
+		reasonsInvalid = append(reasonsInvalid, "identifier is nil")
+	}
+
+	if len(reasonsInvalid) > 0 {
+		return brainException.RequestInvalid{Reasons: reasonsInvalid}
+	}
+	return nil
+}
+
+func (a *administrator) Replay(request *sigfoxBackendDataCallbackMessageDeadLetterAdministrator.ReplayRequest) (*sigfoxBackendDataCallbackMessageDeadLetterAdministrator.ReplayResponse, error) {
+	if err := a.ValidateReplayRequest(request); err != nil {
+		return nil, err
+	}
+
+	// wrap identifier
+	id, err := wrappedIdentifier.Wrap(request.Identifier)
+	if err != nil {
+		log.Error(err.Error())
+		return nil, err
+	}
+
+	replayResponse := sigfoxBackendDataCallbackMessageDeadLetterAdministratorJsonRpcAdaptor.ReplayResponse{}
+	if err := a.jsonRpcClient.JsonRpcRequest(
+		sigfoxBackendDataCallbackMessageDeadLetterAdministrator.ReplayService,
+		sigfoxBackendDataCallbackMessageDeadLetterAdministratorJsonRpcAdaptor.ReplayRequest{
+			WrappedIdentifier: *id,
+		},
+		&replayResponse,
+	); err != nil {
+		log.Error(err.Error())
+		return nil, err
+	}
+
+	return &sigfoxBackendDataCallbackMessageDeadLetterAdministrator.ReplayResponse{
+		DeadLetter: replayResponse.DeadLetter,
+	}, nil
+}
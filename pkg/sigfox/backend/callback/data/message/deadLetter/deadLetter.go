@@ -0,0 +1,29 @@
+package deadLetter
+
+import (
+	sigfoxBackendDataCallbackMessage "github.com/iot-my-world/brain/pkg/sigfox/backend/callback/data/message"
+	sigfoxBackendDataCallbackMessageHandler "github.com/iot-my-world/brain/pkg/sigfox/backend/callback/data/message/handler"
+)
+
+// DeadLetter records a sigfox data message which a handler
+// failed to handle after all of its attempts
+type DeadLetter struct {
+	Id string `json:"id" bson:"id"`
+
+	// Message which could not be handled
+	Message sigfoxBackendDataCallbackMessage.Message `json:"message" bson:"message"`
+
+	// Handling Details
+	Handler    sigfoxBackendDataCallbackMessageHandler.Name `json:"handler" bson:"handler"`
+	Attempts   int                                          `json:"attempts" bson:"attempts"`
+	Errors     []string                                     `json:"errors" bson:"errors"`
+	FailedTime int64                                        `json:"failedTime" bson:"failedTime"`
+
+	// Replay Details
+	Replayed     bool  `json:"replayed" bson:"replayed"`
+	ReplayedTime int64 `json:"replayedTime" bson:"replayedTime"`
+}
+
+func (d *DeadLetter) SetId(id string) {
+	d.Id = id
+}
@@ -0,0 +1,157 @@
+package sigfoxBackendDataCallbackMessageDeadLetterRecordHandler
+
+import (
+	brainException "github.com/iot-my-world/brain/internal/exception"
+	brainRecordHandler "github.com/iot-my-world/brain/pkg/recordHandler"
+	brainRecordHandlerException "github.com/iot-my-world/brain/pkg/recordHandler/exception"
+	"github.com/iot-my-world/brain/pkg/sigfox/backend/callback/data/message/deadLetter"
+	sigfoxBackendDataCallbackMessageDeadLetterRecordHandler "github.com/iot-my-world/brain/pkg/sigfox/backend/callback/data/message/deadLetter/recordHandler"
+	sigfoxBackendDataCallbackMessageDeadLetterRecordHandlerException "github.com/iot-my-world/brain/pkg/sigfox/backend/callback/data/message/deadLetter/recordHandler/exception"
+)
+
+type RecordHandler struct {
+	sigfoxBackendDataCallbackMessageDeadLetterRecordHandler brainRecordHandler.RecordHandler
+}
+
+func New(
+	brainDeadLetterRecordHandler brainRecordHandler.RecordHandler,
+) sigfoxBackendDataCallbackMessageDeadLetterRecordHandler.RecordHandler {
+
+	return &RecordHandler{
+		sigfoxBackendDataCallbackMessageDeadLetterRecordHandler: brainDeadLetterRecordHandler,
+	}
+}
+
+type CreateRequest struct {
+	DeadLetter deadLetter.DeadLetter
+}
+
+type CreateResponse struct {
+	DeadLetter deadLetter.DeadLetter
+}
+
+func (r *RecordHandler) ValidateCreateRequest(request *sigfoxBackendDataCallbackMessageDeadLetterRecordHandler.CreateRequest) error {
+	reasonsInvalid := make([]string, 0)
+
+	if len(reasonsInvalid) > 0 {
+		return brainException.RequestInvalid{Reasons: reasonsInvalid}
+	}
+
+	return nil
+}
+
+func (r *RecordHandler) Create(request *sigfoxBackendDataCallbackMessageDeadLetterRecordHandler.CreateRequest) (*sigfoxBackendDataCallbackMessageDeadLetterRecordHandler.CreateResponse, error) {
+	if err := r.ValidateCreateRequest(request); err != nil {
+		return nil, err
+	}
+
+	createResponse := brainRecordHandler.CreateResponse{}
+	if err := r.sigfoxBackendDataCallbackMessageDeadLetterRecordHandler.Create(&brainRecordHandler.CreateRequest{
+		Entity: &request.DeadLetter,
+	}, &createResponse); err != nil {
+		return nil, sigfoxBackendDataCallbackMessageDeadLetterRecordHandlerException.Create{Reasons: []string{err.Error()}}
+	}
+	createdDeadLetter, ok := createResponse.Entity.(*deadLetter.DeadLetter)
+	if !ok {
+		return nil, sigfoxBackendDataCallbackMessageDeadLetterRecordHandlerException.Create{Reasons: []string{"could not cast created entity to dead letter"}}
+	}
+
+	return &sigfoxBackendDataCallbackMessageDeadLetterRecordHandler.CreateResponse{
+		DeadLetter: *createdDeadLetter,
+	}, nil
+}
+
+func (r *RecordHandler) Retrieve(request *sigfoxBackendDataCallbackMessageDeadLetterRecordHandler.RetrieveRequest) (*sigfoxBackendDataCallbackMessageDeadLetterRecordHandler.RetrieveResponse, error) {
+	retrievedDeadLetter := deadLetter.DeadLetter{}
+	retrieveResponse := brainRecordHandler.RetrieveResponse{
+		Entity: &retrievedDeadLetter,
+	}
+	if err := r.sigfoxBackendDataCallbackMessageDeadLetterRecordHandler.Retrieve(&brainRecordHandler.RetrieveRequest{
+		Claims:     request.Claims,
+		Identifier: request.Identifier,
+	}, &retrieveResponse); err != nil {
+		switch err.(type) {
+		case brainRecordHandlerException.NotFound:
+			return nil, sigfoxBackendDataCallbackMessageDeadLetterRecordHandlerException.NotFound{}
+		default:
+			return nil, err
+		}
+	}
+
+	return &sigfoxBackendDataCallbackMessageDeadLetterRecordHandler.RetrieveResponse{
+		DeadLetter: retrievedDeadLetter,
+	}, nil
+}
+
+func (r *RecordHandler) Update(request *sigfoxBackendDataCallbackMessageDeadLetterRecordHandler.UpdateRequest) (*sigfoxBackendDataCallbackMessageDeadLetterRecordHandler.UpdateResponse, error) {
+	updateResponse := brainRecordHandler.UpdateResponse{}
+	if err := r.sigfoxBackendDataCallbackMessageDeadLetterRecordHandler.Update(&brainRecordHandler.UpdateRequest{
+		Claims:     request.Claims,
+		Identifier: request.Identifier,
+		Entity:     &request.DeadLetter,
+	}, &updateResponse); err != nil {
+		return nil, sigfoxBackendDataCallbackMessageDeadLetterRecordHandlerException.Update{Reasons: []string{err.Error()}}
+	}
+
+	return &sigfoxBackendDataCallbackMessageDeadLetterRecordHandler.UpdateResponse{}, nil
+}
+
+func (r *RecordHandler) Delete(request *sigfoxBackendDataCallbackMessageDeadLetterRecordHandler.DeleteRequest) (*sigfoxBackendDataCallbackMessageDeadLetterRecordHandler.DeleteResponse, error) {
+	deleteResponse := brainRecordHandler.DeleteResponse{}
+	if err := r.sigfoxBackendDataCallbackMessageDeadLetterRecordHandler.Delete(&brainRecordHandler.DeleteRequest{
+		Claims:     request.Claims,
+		Identifier: request.Identifier,
+	}, &deleteResponse); err != nil {
+		return nil, sigfoxBackendDataCallbackMessageDeadLetterRecordHandlerException.Delete{Reasons: []string{err.Error()}}
+	}
+
+	return &sigfoxBackendDataCallbackMessageDeadLetterRecordHandler.DeleteResponse{}, nil
+}
+
+func (r *RecordHandler) Collect(request *sigfoxBackendDataCallbackMessageDeadLetterRecordHandler.CollectRequest) (*sigfoxBackendDataCallbackMessageDeadLetterRecordHandler.CollectResponse, error) {
+	var collectedDeadLetter []deadLetter.DeadLetter
+	collectResponse := brainRecordHandler.CollectResponse{
+		Records: &collectedDeadLetter,
+	}
+	err := r.sigfoxBackendDataCallbackMessageDeadLetterRecordHandler.Collect(&brainRecordHandler.CollectRequest{
+		Claims:   request.Claims,
+		Criteria: request.Criteria,
+		Query:    request.Query,
+	}, &collectResponse)
+	if err != nil {
+		return nil, sigfoxBackendDataCallbackMessageDeadLetterRecordHandlerException.Collect{Reasons: []string{err.Error()}}
+	}
+
+	if collectedDeadLetter == nil {
+		collectedDeadLetter = make([]deadLetter.DeadLetter, 0)
+	}
+
+	return &sigfoxBackendDataCallbackMessageDeadLetterRecordHandler.CollectResponse{
+		Records: collectedDeadLetter,
+		Total:   collectResponse.Total,
+	}, nil
+}
+
+func (r *RecordHandler) UpdateFields(request *sigfoxBackendDataCallbackMessageDeadLetterRecordHandler.UpdateFieldsRequest) (*sigfoxBackendDataCallbackMessageDeadLetterRecordHandler.UpdateFieldsResponse, error) {
+	updatedDeadLetter := deadLetter.DeadLetter{}
+	updateFieldsResponse := brainRecordHandler.UpdateFieldsResponse{
+		Entity: &updatedDeadLetter,
+	}
+	if err := r.sigfoxBackendDataCallbackMessageDeadLetterRecordHandler.UpdateFields(&brainRecordHandler.UpdateFieldsRequest{
+		Claims:   request.Claims,
+		Criteria: request.Criteria,
+		Query:    request.Query,
+		Update:   request.Update,
+	}, &updateFieldsResponse); err != nil {
+		switch err.(type) {
+		case brainRecordHandlerException.NotFound:
+			return nil, sigfoxBackendDataCallbackMessageDeadLetterRecordHandlerException.NotFound{}
+		default:
+			return nil, sigfoxBackendDataCallbackMessageDeadLetterRecordHandlerException.Update{Reasons: []string{err.Error()}}
+		}
+	}
+
+	return &sigfoxBackendDataCallbackMessageDeadLetterRecordHandler.UpdateFieldsResponse{
+		DeadLetter: updatedDeadLetter,
+	}, nil
+}
@@ -0,0 +1,131 @@
+package jsonRpc
+
+import (
+	brainException "github.com/iot-my-world/brain/internal/exception"
+	"github.com/iot-my-world/brain/internal/log"
+	jsonRpcClient "github.com/iot-my-world/brain/pkg/api/jsonRpc/client"
+	wrappedCriterion "github.com/iot-my-world/brain/pkg/search/criterion/wrapped"
+	wrappedIdentifier "github.com/iot-my-world/brain/pkg/search/identifier/wrapped"
+	sigfoxBackendDataCallbackMessageDeadLetterRecordHandler "github.com/iot-my-world/brain/pkg/sigfox/backend/callback/data/message/deadLetter/recordHandler"
+	sigfoxBackendDataCallbackMessageDeadLetterRecordHandlerJsonRpcAdaptor "github.com/iot-my-world/brain/pkg/sigfox/backend/callback/data/message/deadLetter/recordHandler/adaptor/jsonRpc"
+)
+
+type recordHandler struct {
+	jsonRpcClient jsonRpcClient.Client
+}
+
+func New(
+	jsonRpcClient jsonRpcClient.Client,
+) sigfoxBackendDataCallbackMessageDeadLetterRecordHandler.RecordHandler {
+	return &recordHandler{
+		jsonRpcClient: jsonRpcClient,
+	}
+}
+
+func (r *recordHandler) Create(request *sigfoxBackendDataCallbackMessageDeadLetterRecordHandler.CreateRequest) (*sigfoxBackendDataCallbackMessageDeadLetterRecordHandler.CreateResponse, error) {
+	return nil, brainException.NotImplemented{}
+}
+
+func (r *recordHandler) ValidateRetrieveRequest(request *sigfoxBackendDataCallbackMessageDeadLetterRecordHandler.RetrieveRequest) error {
+	reasonsInvalid := make([]string, 0)
+	if request.Identifier == nil {
+		reasonsInvalid = append(reasonsInvalid, "identifier is nil")
+	}
+
+	if len(reasonsInvalid) > 0 {
+		return brainException.RequestInvalid{Reasons: reasonsInvalid}
+	}
+	return nil
+}
+
+func (r *recordHandler) Retrieve(request *sigfoxBackendDataCallbackMessageDeadLetterRecordHandler.RetrieveRequest) (*sigfoxBackendDataCallbackMessageDeadLetterRecordHandler.RetrieveResponse, error) {
+	if err := r.ValidateRetrieveRequest(request); err != nil {
+		log.Error(err.Error())
+		return nil, err
+	}
+
+	// wrap identifier
+	id, err := wrappedIdentifier.Wrap(request.Identifier)
+	if err != nil {
+		log.Error(err.Error())
+		return nil, err
+	}
+
+	sigfoxBackendDataCallbackMessageDeadLetterRetrieveResponse := sigfoxBackendDataCallbackMessageDeadLetterRecordHandlerJsonRpcAdaptor.RetrieveResponse{}
+	if err := r.jsonRpcClient.JsonRpcRequest(
+		sigfoxBackendDataCallbackMessageDeadLetterRecordHandler.RetrieveService,
+		sigfoxBackendDataCallbackMessageDeadLetterRecordHandlerJsonRpcAdaptor.RetrieveRequest{
+			WrappedIdentifier: *id,
+		},
+		&sigfoxBackendDataCallbackMessageDeadLetterRetrieveResponse,
+	); err != nil {
+		log.Error(err.Error())
+		return nil, err
+	}
+
+	return &sigfoxBackendDataCallbackMessageDeadLetterRecordHandler.RetrieveResponse{
+		DeadLetter: sigfoxBackendDataCallbackMessageDeadLetterRetrieveResponse.DeadLetter,
+	}, nil
+}
+func (r *recordHandler) Update(request *sigfoxBackendDataCallbackMessageDeadLetterRecordHandler.UpdateRequest) (*sigfoxBackendDataCallbackMessageDeadLetterRecordHandler.UpdateResponse, error) {
+	return nil, brainException.NotImplemented{}
+}
+func (r *recordHandler) Delete(request *sigfoxBackendDataCallbackMessageDeadLetterRecordHandler.DeleteRequest) (*sigfoxBackendDataCallbackMessageDeadLetterRecordHandler.DeleteResponse, error) {
+	return nil, brainException.NotImplemented{}
+}
+
+func (r *recordHandler) ValidateCollectRequest(request *sigfoxBackendDataCallbackMessageDeadLetterRecordHandler.CollectRequest) error {
+	reasonsInvalid := make([]string, 0)
+
+	if request.Criteria == nil {
+		reasonsInvalid = append(reasonsInvalid, "criteria is nil")
+	} else {
+		for _, crit := range request.Criteria {
+			if crit == nil {
+				reasonsInvalid = append(reasonsInvalid, "a criterion is nil")
+			}
+		}
+	}
+
+	if len(reasonsInvalid) > 0 {
+		return brainException.RequestInvalid{Reasons: reasonsInvalid}
+	}
+	return nil
+}
+
+func (r *recordHandler) Collect(request *sigfoxBackendDataCallbackMessageDeadLetterRecordHandler.CollectRequest) (*sigfoxBackendDataCallbackMessageDeadLetterRecordHandler.CollectResponse, error) {
+	if err := r.ValidateCollectRequest(request); err != nil {
+		return nil, err
+	}
+
+	// wrap criteria
+	criteria := make([]wrappedCriterion.Wrapped, 0)
+	for _, crit := range request.Criteria {
+		wrapped, err := wrappedCriterion.Wrap(crit)
+		if err != nil {
+			log.Error(err.Error())
+			return nil, err
+		}
+		criteria = append(criteria, *wrapped)
+	}
+
+	collectResponse := sigfoxBackendDataCallbackMessageDeadLetterRecordHandlerJsonRpcAdaptor.CollectResponse{}
+	if err := r.jsonRpcClient.JsonRpcRequest(
+		sigfoxBackendDataCallbackMessageDeadLetterRecordHandler.CollectService,
+		sigfoxBackendDataCallbackMessageDeadLetterRecordHandlerJsonRpcAdaptor.CollectRequest{
+			Criteria: criteria,
+			Query:    request.Query,
+		},
+		&collectResponse); err != nil {
+		return nil, err
+	}
+
+	return &sigfoxBackendDataCallbackMessageDeadLetterRecordHandler.CollectResponse{
+		Records: collectResponse.Records,
+		Total:   collectResponse.Total,
+	}, nil
+}
+
+func (r *recordHandler) UpdateFields(request *sigfoxBackendDataCallbackMessageDeadLetterRecordHandler.UpdateFieldsRequest) (*sigfoxBackendDataCallbackMessageDeadLetterRecordHandler.UpdateFieldsResponse, error) {
+	return nil, brainException.NotImplemented{}
+}
@@ -0,0 +1,37 @@
+package mongo
+
+import (
+	brainMongoRecordHandler "github.com/iot-my-world/brain/pkg/recordHandler/mongo"
+	"github.com/iot-my-world/brain/pkg/security/claims"
+	"github.com/iot-my-world/brain/pkg/sigfox/backend/callback/data/message/deadLetter"
+	sigfoxBackendDataCallbackMessageDeadLetterRecordHandler "github.com/iot-my-world/brain/pkg/sigfox/backend/callback/data/message/deadLetter/recordHandler"
+	sigfoxBackendDataCallbackMessageDeadLetterGenericRecordHandler "github.com/iot-my-world/brain/pkg/sigfox/backend/callback/data/message/deadLetter/recordHandler/generic"
+	"gopkg.in/mgo.v2"
+)
+
+func New(
+	mongoSession *mgo.Session,
+	databaseName string,
+	collectionName string,
+) sigfoxBackendDataCallbackMessageDeadLetterRecordHandler.RecordHandler {
+	mongoRecordHandler := brainMongoRecordHandler.New(
+		mongoSession,
+		databaseName,
+		collectionName,
+		[]mgo.Index{
+			{
+				Key:    []string{"id"},
+				Unique: true,
+			},
+			{
+				Key: []string{"message.id"},
+			},
+		},
+		deadLetter.IsValidIdentifier,
+		claims.ContextualiseFilter,
+	)
+
+	return sigfoxBackendDataCallbackMessageDeadLetterGenericRecordHandler.New(
+		mongoRecordHandler,
+	)
+}
@@ -0,0 +1,33 @@
+package decoder
+
+import (
+	"github.com/iot-my-world/brain/pkg/security/claims"
+	sigfoxBackendDataCallbackMessage "github.com/iot-my-world/brain/pkg/sigfox/backend/callback/data/message"
+)
+
+// DeviceType identifies a family of devices which send sigfox data
+// messages, the first byte of which gives the type of the message
+type DeviceType string
+
+// Decoder turns the data of a sigfox data message into a typed reading
+type Decoder interface {
+	Decode(data []byte) (interface{}, error)
+}
+
+// Sink does something with readings decoded from sigfox data
+// messages, e.g. persist them
+type Sink interface {
+	Sink(request *SinkRequest) (*SinkResponse, error)
+}
+
+type SinkRequest struct {
+	Claims      claims.Claims
+	DataMessage sigfoxBackendDataCallbackMessage.Message
+	Reading     interface{}
+}
+
+type SinkResponse struct {
+	// DownlinkData to be sent to the device if the message requested
+	// an acknowledgement, nil if there is nothing to send
+	DownlinkData []byte
+}
@@ -0,0 +1,32 @@
+package exception
+
+import (
+	"fmt"
+	"strings"
+)
+
+type InvalidDataLength struct {
+	Expected int
+	Actual   int
+}
+
+func (e InvalidDataLength) Error() string {
+	return fmt.Sprintf("invalid data length: expected %d bytes, got %d", e.Expected, e.Actual)
+}
+
+type InvalidMessageType struct {
+	Expected byte
+	Actual   byte
+}
+
+func (e InvalidMessageType) Error() string {
+	return fmt.Sprintf("invalid message type: expected %d, got %d", e.Expected, e.Actual)
+}
+
+type Decode struct {
+	Reasons []string
+}
+
+func (e Decode) Error() string {
+	return "error decoding message: " + strings.Join(e.Reasons, "; ")
+}
@@ -0,0 +1,90 @@
+package basic
+
+import (
+	brainException "github.com/iot-my-world/brain/internal/exception"
+	sigfoxBackendDataCallbackMessageDecoder "github.com/iot-my-world/brain/pkg/sigfox/backend/callback/data/message/decoder"
+	sigfoxBackendDataCallbackMessageDecoderRegistry "github.com/iot-my-world/brain/pkg/sigfox/backend/callback/data/message/decoder/registry"
+	sigfoxBackendDataCallbackMessageDecoderRegistryException "github.com/iot-my-world/brain/pkg/sigfox/backend/callback/data/message/decoder/registry/exception"
+	"sync"
+)
+
+type registration struct {
+	decoder sigfoxBackendDataCallbackMessageDecoder.Decoder
+	sink    sigfoxBackendDataCallbackMessageDecoder.Sink
+}
+
+type registry struct {
+	mutex         sync.RWMutex
+	registrations map[sigfoxBackendDataCallbackMessageDecoder.DeviceType]map[byte]registration
+}
+
+func New() sigfoxBackendDataCallbackMessageDecoderRegistry.Registry {
+	return &registry{
+		registrations: make(map[sigfoxBackendDataCallbackMessageDecoder.DeviceType]map[byte]registration),
+	}
+}
+
+func (r *registry) ValidateRegisterRequest(request *sigfoxBackendDataCallbackMessageDecoderRegistry.RegisterRequest) error {
+	reasonsInvalid := make([]string, 0)
+
+	if request.DeviceType == "" {
+		reasonsInvalid = append(reasonsInvalid, "device type is blank")
+	}
+
+	if request.Decoder == nil {
+		reasonsInvalid = append(reasonsInvalid, "decoder is nil")
+	}
+
+	if request.Sink == nil {
+		reasonsInvalid = append(reasonsInvalid, "sink is nil")
+	}
+
+	if len(reasonsInvalid) > 0 {
+		return brainException.RequestInvalid{Reasons: reasonsInvalid}
+	}
+	return nil
+}
+
+func (r *registry) Register(request *sigfoxBackendDataCallbackMessageDecoderRegistry.RegisterRequest) error {
+	if err := r.ValidateRegisterRequest(request); err != nil {
+		return err
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if _, found := r.registrations[request.DeviceType]; !found {
+		r.registrations[request.DeviceType] = make(map[byte]registration)
+	}
+	if _, found := r.registrations[request.DeviceType][request.MessageType]; found {
+		return sigfoxBackendDataCallbackMessageDecoderRegistryException.AlreadyRegistered{
+			DeviceType:  request.DeviceType,
+			MessageType: request.MessageType,
+		}
+	}
+
+	r.registrations[request.DeviceType][request.MessageType] = registration{
+		decoder: request.Decoder,
+		sink:    request.Sink,
+	}
+
+	return nil
+}
+
+func (r *registry) Retrieve(request *sigfoxBackendDataCallbackMessageDecoderRegistry.RetrieveRequest) (*sigfoxBackendDataCallbackMessageDecoderRegistry.RetrieveResponse, error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	messageTypeRegistration, found := r.registrations[request.DeviceType][request.MessageType]
+	if !found {
+		return nil, sigfoxBackendDataCallbackMessageDecoderRegistryException.NotRegistered{
+			DeviceType:  request.DeviceType,
+			MessageType: request.MessageType,
+		}
+	}
+
+	return &sigfoxBackendDataCallbackMessageDecoderRegistry.RetrieveResponse{
+		Decoder: messageTypeRegistration.decoder,
+		Sink:    messageTypeRegistration.sink,
+	}, nil
+}
@@ -0,0 +1,24 @@
+package exception
+
+import (
+	"fmt"
+	sigfoxBackendDataCallbackMessageDecoder "github.com/iot-my-world/brain/pkg/sigfox/backend/callback/data/message/decoder"
+)
+
+type AlreadyRegistered struct {
+	DeviceType  sigfoxBackendDataCallbackMessageDecoder.DeviceType
+	MessageType byte
+}
+
+func (e AlreadyRegistered) Error() string {
+	return fmt.Sprintf("decoder already registered for message type %d of device type %s", e.MessageType, e.DeviceType)
+}
+
+type NotRegistered struct {
+	DeviceType  sigfoxBackendDataCallbackMessageDecoder.DeviceType
+	MessageType byte
+}
+
+func (e NotRegistered) Error() string {
+	return fmt.Sprintf("no decoder registered for message type %d of device type %s", e.MessageType, e.DeviceType)
+}
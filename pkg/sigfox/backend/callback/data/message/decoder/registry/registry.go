@@ -0,0 +1,29 @@
+package registry
+
+import (
+	sigfoxBackendDataCallbackMessageDecoder "github.com/iot-my-world/brain/pkg/sigfox/backend/callback/data/message/decoder"
+)
+
+// Registry holds the decoder and sink for each type
+// of message sent by each type of device
+type Registry interface {
+	Register(request *RegisterRequest) error
+	Retrieve(request *RetrieveRequest) (*RetrieveResponse, error)
+}
+
+type RegisterRequest struct {
+	DeviceType  sigfoxBackendDataCallbackMessageDecoder.DeviceType
+	MessageType byte
+	Decoder     sigfoxBackendDataCallbackMessageDecoder.Decoder
+	Sink        sigfoxBackendDataCallbackMessageDecoder.Sink
+}
+
+type RetrieveRequest struct {
+	DeviceType  sigfoxBackendDataCallbackMessageDecoder.DeviceType
+	MessageType byte
+}
+
+type RetrieveResponse struct {
+	Decoder sigfoxBackendDataCallbackMessageDecoder.Decoder
+	Sink    sigfoxBackendDataCallbackMessageDecoder.Sink
+}
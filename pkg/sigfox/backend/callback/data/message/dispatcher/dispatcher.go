@@ -0,0 +1,28 @@
+package dispatcher
+
+import (
+	"github.com/iot-my-world/brain/pkg/security/claims"
+	sigfoxBackendDataCallbackMessage "github.com/iot-my-world/brain/pkg/sigfox/backend/callback/data/message"
+	sigfoxBackendDataCallbackMessageHandler "github.com/iot-my-world/brain/pkg/sigfox/backend/callback/data/message/handler"
+)
+
+// Dispatcher gives sigfox data messages to the handlers which
+// want them to be handled outside of the callback request
+type Dispatcher interface {
+	Dispatch(request *DispatchRequest) (*DispatchResponse, error)
+}
+
+type DispatchRequest struct {
+	Claims  claims.Claims
+	Message sigfoxBackendDataCallbackMessage.Message
+
+	// Handler, if given, is the only handler to which the message
+	// is dispatched. This is used to replay failed messages.
+	Handler sigfoxBackendDataCallbackMessageHandler.Name
+}
+
+type DispatchResponse struct {
+	// DownlinkData to be sent to the device if the message requested
+	// an acknowledgement, nil if there is nothing to send
+	DownlinkData []byte
+}
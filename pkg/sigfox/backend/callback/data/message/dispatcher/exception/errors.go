@@ -0,0 +1,11 @@
+package exception
+
+import "strings"
+
+type HandlerNotFound struct {
+	Reasons []string
+}
+
+func (e HandlerNotFound) Error() string {
+	return "handler not found: " + strings.Join(e.Reasons, "; ")
+}
@@ -0,0 +1,281 @@
+package pool
+
+import (
+	"fmt"
+	brainException "github.com/iot-my-world/brain/internal/exception"
+	"github.com/iot-my-world/brain/internal/log"
+	"github.com/iot-my-world/brain/pkg/security/claims"
+	sigfoxBackendDataCallbackMessage "github.com/iot-my-world/brain/pkg/sigfox/backend/callback/data/message"
+	"github.com/iot-my-world/brain/pkg/sigfox/backend/callback/data/message/deadLetter"
+	sigfoxBackendDataCallbackMessageDeadLetterRecordHandler "github.com/iot-my-world/brain/pkg/sigfox/backend/callback/data/message/deadLetter/recordHandler"
+	sigfoxBackendDataCallbackMessageDispatcher "github.com/iot-my-world/brain/pkg/sigfox/backend/callback/data/message/dispatcher"
+	sigfoxBackendDataCallbackMessageDispatcherException "github.com/iot-my-world/brain/pkg/sigfox/backend/callback/data/message/dispatcher/exception"
+	sigfoxBackendDataCallbackMessageHandler "github.com/iot-my-world/brain/pkg/sigfox/backend/callback/data/message/handler"
+	"hash/fnv"
+	"time"
+)
+
+// jobQueueSize is the number of jobs which can be waiting for
+// each worker before dispatching to that worker blocks
+const jobQueueSize = 1000
+
+// job is the handling of a message by a single handler
+type job struct {
+	claims   claims.Claims
+	message  sigfoxBackendDataCallbackMessage.Message
+	handler  sigfoxBackendDataCallbackMessageHandler.Handler
+	attempts int
+	errors   []string
+
+	// firstAttempt receives the response of the first attempt to handle the
+	// message, or nil if it failed. It is only set if someone is waiting.
+	firstAttempt chan *sigfoxBackendDataCallbackMessageHandler.HandleResponse
+}
+
+// dispatcher gives messages to a pool of workers which handle them.
+// The messages of a device are always given to the same worker so that
+// they are handled in the order in which they were received. Failed
+// attempts are retried with exponential backoff until the maximum number
+// of attempts is reached, after which a dead letter is recorded. While
+// a job waits to be retried the later jobs of its device wait with it,
+// and the worker handles the jobs of other devices in the meantime.
+type dispatcher struct {
+	handlers                                                []sigfoxBackendDataCallbackMessageHandler.Handler
+	sigfoxBackendDataCallbackMessageDeadLetterRecordHandler sigfoxBackendDataCallbackMessageDeadLetterRecordHandler.RecordHandler
+	jobs                                                    []chan *job
+	maxAttempts                                             int
+	retryBackoff                                            time.Duration
+	downlinkTimeout                                         time.Duration
+}
+
+func New(
+	handlers []sigfoxBackendDataCallbackMessageHandler.Handler,
+	sigfoxBackendDataCallbackMessageDeadLetterRecordHandler sigfoxBackendDataCallbackMessageDeadLetterRecordHandler.RecordHandler,
+	workers int,
+	maxAttempts int,
+	retryBackoff time.Duration,
+	downlinkTimeout time.Duration,
+) sigfoxBackendDataCallbackMessageDispatcher.Dispatcher {
+	if workers < 1 {
+		workers = 1
+	}
+	d := &dispatcher{
+		handlers: handlers,
+		sigfoxBackendDataCallbackMessageDeadLetterRecordHandler: sigfoxBackendDataCallbackMessageDeadLetterRecordHandler,
+		jobs:            make([]chan *job, workers),
+		maxAttempts:     maxAttempts,
+		retryBackoff:    retryBackoff,
+		downlinkTimeout: downlinkTimeout,
+	}
+
+	// start workers, each with its own queue
+	for i := range d.jobs {
+		d.jobs[i] = make(chan *job, jobQueueSize)
+		go d.work(d.jobs[i])
+	}
+
+	return d
+}
+
+func (d *dispatcher) ValidateDispatchRequest(request *sigfoxBackendDataCallbackMessageDispatcher.DispatchRequest) error {
+	reasonsInvalid := make([]string, 0)
+
+	if request.Claims == nil {
+		reasonsInvalid = append(reasonsInvalid, "claims are nil")
+	}
+
+	if request.Message.Id == "" {
+		reasonsInvalid = append(reasonsInvalid, "message id is blank")
+	}
+
+	if len(reasonsInvalid) > 0 {
+		return brainException.RequestInvalid{Reasons: reasonsInvalid}
+	}
+	return nil
+}
+
+func (d *dispatcher) Dispatch(request *sigfoxBackendDataCallbackMessageDispatcher.DispatchRequest) (*sigfoxBackendDataCallbackMessageDispatcher.DispatchResponse, error) {
+	if err := d.ValidateDispatchRequest(request); err != nil {
+		log.Error(err.Error())
+		return nil, err
+	}
+
+	// create a job for every handler which is to handle the message.
+	// the channels on which the first attempts are received are kept
+	// since the workers clear them from the jobs once they are used.
+	jobs := make([]*job, 0)
+	firstAttempts := make([]chan *sigfoxBackendDataCallbackMessageHandler.HandleResponse, 0)
+	for handlerIdx := range d.handlers {
+		if request.Handler != "" {
+			if d.handlers[handlerIdx].Name() != request.Handler {
+				continue
+			}
+		} else if !d.handlers[handlerIdx].WantMessage(request.Message) {
+			continue
+		}
+
+		newJob := &job{
+			claims:  request.Claims,
+			message: request.Message,
+			handler: d.handlers[handlerIdx],
+			errors:  make([]string, 0),
+		}
+		if request.Message.Ack {
+			newJob.firstAttempt = make(chan *sigfoxBackendDataCallbackMessageHandler.HandleResponse, 1)
+			firstAttempts = append(firstAttempts, newJob.firstAttempt)
+		}
+		jobs = append(jobs, newJob)
+	}
+	if request.Handler != "" && len(jobs) == 0 {
+		err := sigfoxBackendDataCallbackMessageDispatcherException.HandlerNotFound{Reasons: []string{string(request.Handler)}}
+		log.Error(err.Error())
+		return nil, err
+	}
+
+	// give jobs to the worker of the device
+	deviceJobs := d.deviceJobs(request.Message.DeviceId)
+	for jobIdx := range jobs {
+		deviceJobs <- jobs[jobIdx]
+	}
+
+	response := sigfoxBackendDataCallbackMessageDispatcher.DispatchResponse{}
+	if !request.Message.Ack {
+		return &response, nil
+	}
+
+	// the device is waiting for a downlink, which can only be sent in reply to the
+	// callback, so wait for the first attempts to handle the message to complete.
+	// only one downlink can be sent in reply to a message, the first given is used.
+	timeout := time.After(d.downlinkTimeout)
+	for _, firstAttempt := range firstAttempts {
+		select {
+		case handleResponse := <-firstAttempt:
+			if handleResponse != nil && response.DownlinkData == nil {
+				response.DownlinkData = handleResponse.DownlinkData
+			}
+		case <-timeout:
+			log.Warn(fmt.Sprintf("timed out waiting for downlink data for message %s", request.Message.Id))
+			return &response, nil
+		}
+	}
+
+	return &response, nil
+}
+
+// deviceJobs returns the job queue of the worker
+// which handles the messages of the given device
+func (d *dispatcher) deviceJobs(deviceId string) chan *job {
+	hash := fnv.New32a()
+	_, _ = hash.Write([]byte(deviceId))
+	return d.jobs[hash.Sum32()%uint32(len(d.jobs))]
+}
+
+// work handles the jobs on the given queue until it is closed
+func (d *dispatcher) work(jobs chan *job) {
+	// retries receives jobs once they have waited to be retried
+	retries := make(chan *job)
+	// waiting holds the jobs of each device with a job waiting to be retried
+	waiting := make(map[string][]*job)
+
+	for {
+		select {
+		case j, ok := <-jobs:
+			if !ok {
+				return
+			}
+			if waitingJobs, deviceWaiting := waiting[j.message.DeviceId]; deviceWaiting {
+				waiting[j.message.DeviceId] = append(waitingJobs, j)
+				continue
+			}
+			d.handle([]*job{j}, waiting, retries)
+
+		case j := <-retries:
+			waitingJobs := waiting[j.message.DeviceId]
+			delete(waiting, j.message.DeviceId)
+			d.handle(append([]*job{j}, waitingJobs...), waiting, retries)
+		}
+	}
+}
+
+// handle attempts to handle the given jobs of a device in order. If an attempt
+// fails and is to be retried the job is given back on retries once it has
+// waited, with the jobs after it waiting until it has been retried so that
+// the next message of the device is not handled first.
+func (d *dispatcher) handle(deviceJobs []*job, waiting map[string][]*job, retries chan<- *job) {
+	for jobIdx, j := range deviceJobs {
+		backoff, retry := d.attempt(j)
+		if !retry {
+			continue
+		}
+		waiting[j.message.DeviceId] = append(make([]*job, 0), deviceJobs[jobIdx+1:]...)
+		retryJob := j
+		time.AfterFunc(backoff, func() {
+			retries <- retryJob
+		})
+		return
+	}
+}
+
+// backoff returns the time to wait before retrying
+// a job which has made the given number of attempts
+func (d *dispatcher) backoff(attempts int) time.Duration {
+	return d.retryBackoff * time.Duration(1<<uint(attempts-1))
+}
+
+// attempt makes an attempt to handle the message of the given job,
+// returning the time to wait before retrying if the attempt failed
+// and is to be retried
+func (d *dispatcher) attempt(j *job) (time.Duration, bool) {
+	j.attempts++
+
+	// a downlink can only be sent in reply to the callback, which will have
+	// been responded to by the time that an attempt is retried
+	dataMessage := j.message
+	if j.attempts > 1 {
+		dataMessage.Ack = false
+	}
+
+	handleResponse, err := j.handler.Handle(&sigfoxBackendDataCallbackMessageHandler.HandleRequest{
+		Claims:      j.claims,
+		DataMessage: dataMessage,
+	})
+	if j.firstAttempt != nil {
+		if err != nil {
+			j.firstAttempt <- nil
+		} else {
+			j.firstAttempt <- handleResponse
+		}
+		j.firstAttempt = nil
+	}
+	if err == nil {
+		return 0, false
+	}
+	j.errors = append(j.errors, err.Error())
+
+	if j.attempts < d.maxAttempts {
+		backoff := d.backoff(j.attempts)
+		log.Warn(fmt.Sprintf(
+			"attempt %d of %d by %s to handle message %s failed, retrying in %s: %s",
+			j.attempts, d.maxAttempts, j.handler.Name(), j.message.Id, backoff, err.Error(),
+		))
+		return backoff, true
+	}
+
+	// all attempts have failed, record dead letter
+	log.Error(fmt.Sprintf(
+		"all %d attempts by %s to handle message %s failed: %s",
+		j.attempts, j.handler.Name(), j.message.Id, err.Error(),
+	))
+	if _, err := d.sigfoxBackendDataCallbackMessageDeadLetterRecordHandler.Create(&sigfoxBackendDataCallbackMessageDeadLetterRecordHandler.CreateRequest{
+		DeadLetter: deadLetter.DeadLetter{
+			Message:    j.message,
+			Handler:    j.handler.Name(),
+			Attempts:   j.attempts,
+			Errors:     j.errors,
+			FailedTime: time.Now().UTC().Unix(),
+		},
+	}); err != nil {
+		log.Error("error creating dead letter: " + err.Error())
+	}
+	return 0, false
+}
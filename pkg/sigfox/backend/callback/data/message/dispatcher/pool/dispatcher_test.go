@@ -0,0 +1,244 @@
+package pool
+
+import (
+	"errors"
+	"github.com/iot-my-world/brain/pkg/party"
+	"github.com/iot-my-world/brain/pkg/search/identifier/id"
+	humanUserLoginClaims "github.com/iot-my-world/brain/pkg/security/claims/login/user/human"
+	sigfoxBackendDataCallbackMessage "github.com/iot-my-world/brain/pkg/sigfox/backend/callback/data/message"
+	sigfoxBackendDataCallbackMessageDeadLetterRecordHandler "github.com/iot-my-world/brain/pkg/sigfox/backend/callback/data/message/deadLetter/recordHandler"
+	sigfoxBackendDataCallbackMessageDispatcher "github.com/iot-my-world/brain/pkg/sigfox/backend/callback/data/message/dispatcher"
+	sigfoxBackendDataCallbackMessageHandler "github.com/iot-my-world/brain/pkg/sigfox/backend/callback/data/message/handler"
+	"github.com/stretchr/testify/assert"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+)
+
+var testClaims = humanUserLoginClaims.Login{
+	UserId:    id.Identifier{Id: "system"},
+	PartyType: party.System,
+	PartyId:   id.Identifier{Id: "system"},
+}
+
+// fakeHandler fails the given number of attempts to handle each
+// message, recording every attempt and the messages handled
+type fakeHandler struct {
+	mutex    sync.Mutex
+	failures map[string]int
+	attempts []sigfoxBackendDataCallbackMessage.Message
+	handled  []string
+	done     chan bool
+}
+
+func newFakeHandler(failures map[string]int) *fakeHandler {
+	return &fakeHandler{
+		failures: failures,
+		done:     make(chan bool, 100),
+	}
+}
+
+func (h *fakeHandler) Name() sigfoxBackendDataCallbackMessageHandler.Name {
+	return "fake"
+}
+
+func (h *fakeHandler) WantMessage(sigfoxBackendDataCallbackMessage.Message) bool {
+	return true
+}
+
+func (h *fakeHandler) Handle(request *sigfoxBackendDataCallbackMessageHandler.HandleRequest) (*sigfoxBackendDataCallbackMessageHandler.HandleResponse, error) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	h.attempts = append(h.attempts, request.DataMessage)
+	if h.failures[request.DataMessage.Id] > 0 {
+		h.failures[request.DataMessage.Id]--
+		return nil, errors.New("handling failed")
+	}
+	h.handled = append(h.handled, request.DataMessage.Id)
+	h.done <- true
+	return &sigfoxBackendDataCallbackMessageHandler.HandleResponse{
+		DownlinkData: []byte(request.DataMessage.Id),
+	}, nil
+}
+
+// fakeDeadLetterRecordHandler records the dead letters created
+type fakeDeadLetterRecordHandler struct {
+	sigfoxBackendDataCallbackMessageDeadLetterRecordHandler.RecordHandler
+	mutex   sync.Mutex
+	created []sigfoxBackendDataCallbackMessageDeadLetterRecordHandler.CreateRequest
+	done    chan bool
+}
+
+func newFakeDeadLetterRecordHandler() *fakeDeadLetterRecordHandler {
+	return &fakeDeadLetterRecordHandler{
+		done: make(chan bool, 100),
+	}
+}
+
+func (r *fakeDeadLetterRecordHandler) Create(request *sigfoxBackendDataCallbackMessageDeadLetterRecordHandler.CreateRequest) (*sigfoxBackendDataCallbackMessageDeadLetterRecordHandler.CreateResponse, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.created = append(r.created, *request)
+	r.done <- true
+	return &sigfoxBackendDataCallbackMessageDeadLetterRecordHandler.CreateResponse{DeadLetter: request.DeadLetter}, nil
+}
+
+// newTestDispatcher returns a dispatcher with a single worker
+// which retries failed attempts after the given backoff
+func newTestDispatcher(handler *fakeHandler, deadLetterRecordHandler *fakeDeadLetterRecordHandler, maxAttempts int, retryBackoff time.Duration) sigfoxBackendDataCallbackMessageDispatcher.Dispatcher {
+	return New(
+		[]sigfoxBackendDataCallbackMessageHandler.Handler{handler},
+		deadLetterRecordHandler,
+		1,
+		maxAttempts,
+		retryBackoff,
+		time.Second,
+	)
+}
+
+// wait waits for the given number of signals on done
+func wait(t *testing.T, done chan bool, n int) {
+	for i := 0; i < n; i++ {
+		select {
+		case <-done:
+		case <-time.After(time.Second):
+			t.Fatal("timed out waiting for messages to be handled")
+		}
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	d := &dispatcher{retryBackoff: time.Second}
+	assert.Equal(t, time.Second, d.backoff(1))
+	assert.Equal(t, 2*time.Second, d.backoff(2))
+	assert.Equal(t, 8*time.Second, d.backoff(4))
+}
+
+func TestDispatchRetries(t *testing.T) {
+	deadLetterRecordHandler := newFakeDeadLetterRecordHandler()
+	handler := newFakeHandler(map[string]int{"1": 2})
+	d := newTestDispatcher(handler, deadLetterRecordHandler, 5, time.Millisecond)
+
+	// the first attempt fails, and so no downlink is given
+	dispatchResponse, err := d.Dispatch(&sigfoxBackendDataCallbackMessageDispatcher.DispatchRequest{
+		Claims:  testClaims,
+		Message: sigfoxBackendDataCallbackMessage.Message{Id: "1", DeviceId: "device", Ack: true},
+	})
+	if assert.NoError(t, err) {
+		assert.Nil(t, dispatchResponse.DownlinkData)
+	}
+	wait(t, handler.done, 1)
+
+	handler.mutex.Lock()
+	defer handler.mutex.Unlock()
+	assert.Equal(t, []string{"1"}, handler.handled)
+	assert.Empty(t, deadLetterRecordHandler.created)
+
+	// only the first attempt was acknowledged
+	if assert.Len(t, handler.attempts, 3) {
+		assert.True(t, handler.attempts[0].Ack)
+		assert.False(t, handler.attempts[1].Ack)
+		assert.False(t, handler.attempts[2].Ack)
+	}
+}
+
+func TestDispatchDeadLetters(t *testing.T) {
+	deadLetterRecordHandler := newFakeDeadLetterRecordHandler()
+	handler := newFakeHandler(map[string]int{"1": 5})
+	d := newTestDispatcher(handler, deadLetterRecordHandler, 3, time.Millisecond)
+
+	message := sigfoxBackendDataCallbackMessage.Message{Id: "1", DeviceId: "device"}
+	_, err := d.Dispatch(&sigfoxBackendDataCallbackMessageDispatcher.DispatchRequest{
+		Claims:  testClaims,
+		Message: message,
+	})
+	if !assert.NoError(t, err) {
+		return
+	}
+	wait(t, deadLetterRecordHandler.done, 1)
+
+	handler.mutex.Lock()
+	defer handler.mutex.Unlock()
+	assert.Len(t, handler.attempts, 3)
+	assert.Empty(t, handler.handled)
+	if assert.Len(t, deadLetterRecordHandler.created, 1) {
+		deadLetter := deadLetterRecordHandler.created[0].DeadLetter
+		assert.Equal(t, message, deadLetter.Message)
+		assert.Equal(t, sigfoxBackendDataCallbackMessageHandler.Name("fake"), deadLetter.Handler)
+		assert.Equal(t, 3, deadLetter.Attempts)
+		assert.Equal(t, []string{"handling failed", "handling failed", "handling failed"}, deadLetter.Errors)
+	}
+}
+
+func TestDispatchRetriesWithoutHoldingUpOtherDevices(t *testing.T) {
+	// the first message of the device waits long to be retried
+	handler := newFakeHandler(map[string]int{"1": 1})
+	d := newTestDispatcher(handler, newFakeDeadLetterRecordHandler(), 5, time.Hour)
+
+	for _, message := range []sigfoxBackendDataCallbackMessage.Message{
+		{Id: "1", DeviceId: "device"},
+		{Id: "2", DeviceId: "device"},
+		{Id: "3", DeviceId: "other device"},
+	} {
+		_, err := d.Dispatch(&sigfoxBackendDataCallbackMessageDispatcher.DispatchRequest{
+			Claims:  testClaims,
+			Message: message,
+		})
+		if !assert.NoError(t, err) {
+			return
+		}
+	}
+	wait(t, handler.done, 1)
+
+	// the message of the other device is handled by the same worker in the
+	// meantime, while the next message of the device waits for the retry
+	handler.mutex.Lock()
+	defer handler.mutex.Unlock()
+	assert.Equal(t, []string{"3"}, handler.handled)
+	assert.Len(t, handler.attempts, 2)
+}
+
+func TestDispatchOrdersDeviceMessages(t *testing.T) {
+	// the first message is retried before the others are handled
+	handler := newFakeHandler(map[string]int{"0": 2})
+	d := New(
+		[]sigfoxBackendDataCallbackMessageHandler.Handler{handler},
+		newFakeDeadLetterRecordHandler(),
+		4,
+		5,
+		time.Millisecond,
+		time.Second,
+	)
+
+	expectedOrder := make([]string, 0)
+	for messageIdx := 0; messageIdx < 10; messageIdx++ {
+		messageId := strconv.Itoa(messageIdx)
+		expectedOrder = append(expectedOrder, messageId)
+		_, err := d.Dispatch(&sigfoxBackendDataCallbackMessageDispatcher.DispatchRequest{
+			Claims:  testClaims,
+			Message: sigfoxBackendDataCallbackMessage.Message{Id: messageId, DeviceId: "device"},
+		})
+		if !assert.NoError(t, err) {
+			return
+		}
+	}
+
+	wait(t, handler.done, len(expectedOrder))
+	handler.mutex.Lock()
+	defer handler.mutex.Unlock()
+	assert.Equal(t, expectedOrder, handler.handled)
+}
+
+func TestDispatchAck(t *testing.T) {
+	handler := newFakeHandler(map[string]int{})
+	d := newTestDispatcher(handler, newFakeDeadLetterRecordHandler(), 5, time.Millisecond)
+
+	dispatchResponse, err := d.Dispatch(&sigfoxBackendDataCallbackMessageDispatcher.DispatchRequest{
+		Claims:  testClaims,
+		Message: sigfoxBackendDataCallbackMessage.Message{Id: "1", DeviceId: "device", Ack: true},
+	})
+	if assert.NoError(t, err) {
+		assert.Equal(t, []byte("1"), dispatchResponse.DownlinkData)
+	}
+}
@@ -0,0 +1,11 @@
+package exception
+
+import "strings"
+
+type Handle struct {
+	Reasons []string
+}
+
+func (e Handle) Error() string {
+	return "error handling data message: " + strings.Join(e.Reasons, "; ")
+}
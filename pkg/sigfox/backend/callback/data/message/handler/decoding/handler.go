@@ -0,0 +1,115 @@
+package decoding
+
+import (
+	brainException "github.com/iot-my-world/brain/internal/exception"
+	"github.com/iot-my-world/brain/internal/log"
+	sigfoxBackendDataCallbackMessage "github.com/iot-my-world/brain/pkg/sigfox/backend/callback/data/message"
+	sigfoxBackendDataCallbackMessageDecoder "github.com/iot-my-world/brain/pkg/sigfox/backend/callback/data/message/decoder"
+	sigfoxBackendDataCallbackMessageDecoderRegistry "github.com/iot-my-world/brain/pkg/sigfox/backend/callback/data/message/decoder/registry"
+	sigfoxBackendDataCallbackMessageHandler "github.com/iot-my-world/brain/pkg/sigfox/backend/callback/data/message/handler"
+	decodingHandlerException "github.com/iot-my-world/brain/pkg/sigfox/backend/callback/data/message/handler/decoding/exception"
+)
+
+// handler handles the data messages of a type of device by decoding them
+// with the decoder registered for the type of the message and giving the
+// resulting reading to the sink registered with the decoder
+type handler struct {
+	deviceType      sigfoxBackendDataCallbackMessageDecoder.DeviceType
+	decoderRegistry sigfoxBackendDataCallbackMessageDecoderRegistry.Registry
+}
+
+func New(
+	deviceType sigfoxBackendDataCallbackMessageDecoder.DeviceType,
+	decoderRegistry sigfoxBackendDataCallbackMessageDecoderRegistry.Registry,
+) sigfoxBackendDataCallbackMessageHandler.Handler {
+	return &handler{
+		deviceType:      deviceType,
+		decoderRegistry: decoderRegistry,
+	}
+}
+
+// Name returns the name of the handler, which is given by its device type
+func (h *handler) Name() sigfoxBackendDataCallbackMessageHandler.Name {
+	return sigfoxBackendDataCallbackMessageHandler.Name(h.deviceType + "-DecodingHandler")
+}
+
+// WantMessage returns true if the message can be decoded by a decoder
+// registered for the device type of the handler
+func (h *handler) WantMessage(dataMessage sigfoxBackendDataCallbackMessage.Message) bool {
+	if len(dataMessage.Data) == 0 {
+		return false
+	}
+
+	retrieveResponse, err := h.decoderRegistry.Retrieve(&sigfoxBackendDataCallbackMessageDecoderRegistry.RetrieveRequest{
+		DeviceType:  h.deviceType,
+		MessageType: dataMessage.Data[0],
+	})
+	if err != nil {
+		return false
+	}
+
+	if _, err := retrieveResponse.Decoder.Decode(dataMessage.Data); err != nil {
+		return false
+	}
+
+	return true
+}
+
+func (h *handler) ValidateHandleRequest(request *sigfoxBackendDataCallbackMessageHandler.HandleRequest) error {
+	reasonsInvalid := make([]string, 0)
+
+	if request.Claims == nil {
+		reasonsInvalid = append(reasonsInvalid, "claims are nil")
+	}
+
+	if len(request.DataMessage.Data) == 0 {
+		reasonsInvalid = append(reasonsInvalid, "message data is empty")
+	}
+
+	if len(reasonsInvalid) > 0 {
+		return brainException.RequestInvalid{Reasons: reasonsInvalid}
+	}
+	return nil
+}
+
+func (h *handler) Handle(request *sigfoxBackendDataCallbackMessageHandler.HandleRequest) (*sigfoxBackendDataCallbackMessageHandler.HandleResponse, error) {
+	if err := h.ValidateHandleRequest(request); err != nil {
+		log.Error(err.Error())
+		return nil, err
+	}
+
+	// retrieve the decoder and sink for this type of message
+	retrieveResponse, err := h.decoderRegistry.Retrieve(&sigfoxBackendDataCallbackMessageDecoderRegistry.RetrieveRequest{
+		DeviceType:  h.deviceType,
+		MessageType: request.DataMessage.Data[0],
+	})
+	if err != nil {
+		err = decodingHandlerException.Handle{Reasons: []string{"retrieving decoder", err.Error()}}
+		log.Error(err.Error())
+		return nil, err
+	}
+
+	// decode the message
+	reading, err := retrieveResponse.Decoder.Decode(request.DataMessage.Data)
+	if err != nil {
+		err = decodingHandlerException.Handle{Reasons: []string{"decoding", err.Error()}}
+		log.Error(err.Error())
+		return nil, err
+	}
+
+	// and give the decoded reading to the sink
+	sinkResponse, err := retrieveResponse.Sink.Sink(&sigfoxBackendDataCallbackMessageDecoder.SinkRequest{
+		Claims:      request.Claims,
+		DataMessage: request.DataMessage,
+		Reading:     reading,
+	})
+	if err != nil {
+		err = decodingHandlerException.Handle{Reasons: []string{"sinking reading", err.Error()}}
+		log.Error(err.Error())
+		return nil, err
+	}
+
+	return &sigfoxBackendDataCallbackMessageHandler.HandleResponse{
+		DownlinkData: sinkResponse.DownlinkData,
+	}, nil
+}
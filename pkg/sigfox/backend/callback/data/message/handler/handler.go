@@ -5,8 +5,13 @@ import (
 	sigfoxBackendDataDataCallbackMessage "github.com/iot-my-world/brain/pkg/sigfox/backend/callback/data/message"
 )
 
+// Name uniquely identifies a handler so that failed messages
+// can be given back to the handler which failed to handle them
+type Name string
+
 type Handler interface {
-	Handle(*HandleRequest) error
+	Name() Name
+	Handle(*HandleRequest) (*HandleResponse, error)
 	WantMessage(sigfoxBackendDataDataCallbackMessage.Message) bool
 }
 
@@ -14,3 +19,9 @@ type HandleRequest struct {
 	Claims      claims.Claims
 	DataMessage sigfoxBackendDataDataCallbackMessage.Message
 }
+
+type HandleResponse struct {
+	// DownlinkData to be sent to the device if the message requested
+	// an acknowledgement, nil if there is nothing to send
+	DownlinkData []byte
+}
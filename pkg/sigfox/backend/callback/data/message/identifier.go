@@ -0,0 +1,37 @@
+package message
+
+import (
+	"errors"
+	"github.com/iot-my-world/brain/pkg/search/identifier"
+	"gopkg.in/mgo.v2/bson"
+)
+
+const SequenceIdentifier identifier.Type = "SigfoxDataCallbackMessageSequenceIdentifier"
+
+// Identifier identifies a message by the device which sent it and its
+// sequence number. Sequence numbers wrap and so the device time at which
+// the message was sent is required to make the identifier unique.
+type Identifier struct {
+	DeviceId  string `json:"deviceId"`
+	SeqNumber int    `json:"seqNumber"`
+	Time      int64  `json:"time"`
+}
+
+func (i Identifier) IsValid() error {
+	if i.DeviceId == "" {
+		return errors.New("device id cannot be blank")
+	}
+	return nil
+}
+
+func (i Identifier) Type() identifier.Type {
+	return SequenceIdentifier
+}
+
+func (i Identifier) ToFilter() bson.M {
+	return bson.M{
+		"deviceId":  i.DeviceId,
+		"seqNumber": i.SeqNumber,
+		"time":      i.Time,
+	}
+}
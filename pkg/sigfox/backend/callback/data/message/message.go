@@ -1,12 +1,70 @@
 package message
 
+import (
+	"fmt"
+)
+
 type Message struct {
-	Id        string `json:"id" bson:"id"`
+	Id string `json:"id" bson:"id"`
+	// Timestamp is the time at which the message was received
 	Timestamp int64  `json:"timeStamp" bson:"timeStamp"`
 	DeviceId  string `json:"deviceId" bson:"deviceId"`
 	Data      []byte `json:"data" bson:"data"`
+	// Ack is set if the device expects a downlink in reply to the message
+	Ack bool `json:"ack" bson:"ack"`
+
+	// Sigfox Callback Metadata
+	SeqNumber int `json:"seqNumber" bson:"seqNumber"`
+	// Time is the time at which the message was sent by the device
+	Time             int64             `json:"time" bson:"time"`
+	Station          string            `json:"station" bson:"station"`
+	RSSI             float64           `json:"rssi" bson:"rssi"`
+	SNR              float64           `json:"snr" bson:"snr"`
+	Duplicate        bool              `json:"duplicate" bson:"duplicate"`
+	ComputedLocation *ComputedLocation `json:"computedLocation" bson:"computedLocation"`
+
+	// DeduplicationKey identifies repeats of the message. It is blank if the
+	// message does not carry the metadata with which repeats can be recognised.
+	DeduplicationKey string `json:"deduplicationKey" bson:"deduplicationKey,omitempty"`
+
+	// Dispatched is set once the message has been given to the dispatcher
+	// to be handled. Repeats of a message which was recorded but never
+	// dispatched are dispatched in its place.
+	Dispatched bool `json:"dispatched" bson:"dispatched"`
+}
+
+// ComputedLocation is the location of a device as
+// computed by the sigfox network, e.g. by atlas
+type ComputedLocation struct {
+	Latitude  float64 `json:"latitude" bson:"latitude"`
+	Longitude float64 `json:"longitude" bson:"longitude"`
+	// Radius of accuracy in metres
+	Radius int `json:"radius" bson:"radius"`
+	Source int `json:"source" bson:"source"`
+	Status int `json:"status" bson:"status"`
 }
 
 func (m *Message) SetId(id string) {
 	m.Id = id
 }
+
+// SetDeduplicationKey sets the key which identifies repeats of the message
+// from the device which sent it, its sequence number and the time at which
+// it was sent. Messages without a device time cannot be told apart from
+// their repeats and so are given no key.
+func (m *Message) SetDeduplicationKey() {
+	if m.Time == 0 {
+		m.DeduplicationKey = ""
+		return
+	}
+	m.DeduplicationKey = fmt.Sprintf("%s:%d:%d", m.DeviceId, m.SeqNumber, m.SentTime())
+}
+
+// SentTime returns the time at which the message was sent by the device,
+// or the time at which it was received if the device time is not known
+func (m Message) SentTime() int64 {
+	if m.Time != 0 {
+		return m.Time
+	}
+	return m.Timestamp
+}
@@ -22,6 +22,15 @@ func (e Create) Error() string {
 	return "message creation error: " + strings.Join(e.Reasons, "; ")
 }
 
+// Duplicate is returned when a message has already been recorded
+type Duplicate struct {
+	Reasons []string
+}
+
+func (e Duplicate) Error() string {
+	return "message already recorded: " + strings.Join(e.Reasons, "; ")
+}
+
 type Retrieve struct {
 	Reasons []string
 }
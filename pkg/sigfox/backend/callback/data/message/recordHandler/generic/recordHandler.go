@@ -49,7 +49,12 @@ func (r *RecordHandler) Create(request *sigfoxBackendDataCallbackMessageRecordHa
 	if err := r.sigfoxBackendDataCallbackMessageRecordHandler.Create(&brainRecordHandler.CreateRequest{
 		Entity: &request.Message,
 	}, &createResponse); err != nil {
-		return nil, sigfoxBackendDataCallbackMessageRecordHandlerException.Create{Reasons: []string{err.Error()}}
+		switch err.(type) {
+		case brainRecordHandlerException.Duplicate:
+			return nil, sigfoxBackendDataCallbackMessageRecordHandlerException.Duplicate{Reasons: []string{err.Error()}}
+		default:
+			return nil, sigfoxBackendDataCallbackMessageRecordHandlerException.Create{Reasons: []string{err.Error()}}
+		}
 	}
 	createdMessage, ok := createResponse.Entity.(*sigfoxBackendDataCallbackMessage.Message)
 	if !ok {
@@ -131,3 +136,17 @@ func (r *RecordHandler) Collect(request *sigfoxBackendDataCallbackMessageRecordH
 		Total:   collectResponse.Total,
 	}, nil
 }
+
+func (r *RecordHandler) DeleteAll(request *sigfoxBackendDataCallbackMessageRecordHandler.DeleteAllRequest) (*sigfoxBackendDataCallbackMessageRecordHandler.DeleteAllResponse, error) {
+	deleteAllResponse := brainRecordHandler.DeleteAllResponse{}
+	if err := r.sigfoxBackendDataCallbackMessageRecordHandler.DeleteAll(&brainRecordHandler.DeleteAllRequest{
+		Claims:   request.Claims,
+		Criteria: request.Criteria,
+	}, &deleteAllResponse); err != nil {
+		return nil, sigfoxBackendDataCallbackMessageRecordHandlerException.Delete{Reasons: []string{err.Error()}}
+	}
+
+	return &sigfoxBackendDataCallbackMessageRecordHandler.DeleteAllResponse{
+		NoDeleted: deleteAllResponse.NoDeleted,
+	}, nil
+}
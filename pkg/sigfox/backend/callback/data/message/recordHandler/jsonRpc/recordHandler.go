@@ -86,3 +86,7 @@ func (r *recordHandler) Collect(request *sigfoxBackendDataCallbackMessageRecordH
 		Total:   collectResponse.Total,
 	}, nil
 }
+
+func (r *recordHandler) DeleteAll(request *sigfoxBackendDataCallbackMessageRecordHandler.DeleteAllRequest) (*sigfoxBackendDataCallbackMessageRecordHandler.DeleteAllResponse, error) {
+	return nil, brainException.NotImplemented{}
+}
@@ -23,6 +23,13 @@ func New(
 				Key:    []string{"id"},
 				Unique: true,
 			},
+			{
+				// guard against repeated callbacks for the same message.
+				// messages without a key cannot be recognised as repeats.
+				Key:    []string{"deduplicationKey"},
+				Unique: true,
+				Sparse: true,
+			},
 		},
 		message.IsValidIdentifier,
 		claims.ContextualiseFilter,
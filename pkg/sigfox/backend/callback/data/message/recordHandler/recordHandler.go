@@ -15,6 +15,7 @@ type RecordHandler interface {
 	Update(*UpdateRequest) (*UpdateResponse, error)
 	Delete(*DeleteRequest) (*DeleteResponse, error)
 	Collect(*CollectRequest) (*CollectResponse, error)
+	DeleteAll(*DeleteAllRequest) (*DeleteAllResponse, error)
 }
 
 const ServiceProvider = "Message-RecordHandler"
@@ -89,3 +90,13 @@ type CollectResponse struct {
 	Records []sigfoxBackendDataCallbackMessage.Message
 	Total   int
 }
+
+// DeleteAllRequest deletes all messages matching the criteria
+type DeleteAllRequest struct {
+	Claims   claims.Claims
+	Criteria []criterion.Criterion
+}
+
+type DeleteAllResponse struct {
+	NoDeleted int
+}
@@ -9,7 +9,7 @@ func IsValidIdentifier(id identifier.Identifier) bool {
 		return false
 	}
 	switch id.Type() {
-	case identifier.Id:
+	case identifier.Id, SequenceIdentifier:
 		return true
 	default:
 		return false
@@ -0,0 +1,143 @@
+package http
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	brainException "github.com/iot-my-world/brain/internal/exception"
+	"github.com/iot-my-world/brain/internal/log"
+	jsonRpcServerAuthoriser "github.com/iot-my-world/brain/pkg/api/jsonRpc/server/authoriser"
+	"github.com/iot-my-world/brain/pkg/security/claims"
+	sigfoxBackendCallbackServer "github.com/iot-my-world/brain/pkg/sigfox/backend/callback/server"
+	sigfoxBackendCallbackServerJsonRpcAdaptor "github.com/iot-my-world/brain/pkg/sigfox/backend/callback/server/adaptor/jsonRpc"
+	netHttp "net/http"
+)
+
+// DataPath is the path to which the sigfox backend
+// sends data callbacks, including bidirectional ones
+const DataPath = "/sigfox/callback/data"
+
+// DownlinkAckPath is the path to which the sigfox
+// backend sends downlink acknowledgement callbacks
+const DownlinkAckPath = "/sigfox/callback/downlinkAck"
+
+// DataMessageResponse is in the shape expected by the sigfox backend
+// for bidirectional callbacks, i.e. {"<deviceId>": {"downlinkData": "<hex>"}}
+type DataMessageResponse map[string]Downlink
+
+type Downlink struct {
+	DownlinkData string `json:"downlinkData"`
+}
+
+// NewAuthorisedDataHandler returns a handler which authorises sigfox backend
+// data callbacks before handling them. The callback body is in the shape of the
+// json rpc request and the response is in the raw shape expected by the sigfox
+// backend, which is why bidirectional callbacks are not served over json rpc.
+// No content is returned if there is no downlink to send to the device.
+func NewAuthorisedDataHandler(server sigfoxBackendCallbackServer.Server, authoriser jsonRpcServerAuthoriser.Authoriser) netHttp.Handler {
+	return netHttp.HandlerFunc(func(w netHttp.ResponseWriter, r *netHttp.Request) {
+		claims, ok := authorise(w, r, authoriser, sigfoxBackendCallbackServer.HandleDataMessageService)
+		if !ok {
+			return
+		}
+
+		var request sigfoxBackendCallbackServerJsonRpcAdaptor.HandleDataMessageRequest
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			netHttp.Error(w, err.Error(), netHttp.StatusBadRequest)
+			return
+		}
+		message, err := request.Message()
+		if err != nil {
+			netHttp.Error(w, err.Error(), netHttp.StatusBadRequest)
+			return
+		}
+
+		handleDataMessageResponse, err := server.HandleDataMessage(&sigfoxBackendCallbackServer.HandleDataMessageRequest{
+			Claims:  claims,
+			Message: message,
+		})
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+
+		if handleDataMessageResponse.DownlinkData == nil {
+			w.WriteHeader(netHttp.StatusNoContent)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(DataMessageResponse{
+			request.DeviceId: Downlink{
+				DownlinkData: hex.EncodeToString(handleDataMessageResponse.DownlinkData),
+			},
+		}); err != nil {
+			log.Error("error writing downlink response: " + err.Error())
+		}
+	})
+}
+
+// NewAuthorisedDownlinkAckHandler returns a handler which authorises sigfox
+// backend downlink acknowledgement callbacks before handling them
+func NewAuthorisedDownlinkAckHandler(server sigfoxBackendCallbackServer.Server, authoriser jsonRpcServerAuthoriser.Authoriser) netHttp.Handler {
+	return netHttp.HandlerFunc(func(w netHttp.ResponseWriter, r *netHttp.Request) {
+		claims, ok := authorise(w, r, authoriser, sigfoxBackendCallbackServer.HandleDownlinkAckService)
+		if !ok {
+			return
+		}
+
+		var request sigfoxBackendCallbackServerJsonRpcAdaptor.HandleDownlinkAckRequest
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			netHttp.Error(w, err.Error(), netHttp.StatusBadRequest)
+			return
+		}
+
+		if _, err := server.HandleDownlinkAck(&sigfoxBackendCallbackServer.HandleDownlinkAckRequest{
+			Claims:      claims,
+			DeviceId:    request.DeviceId,
+			DownlinkAck: request.DownlinkAck,
+		}); err != nil {
+			writeError(w, err)
+			return
+		}
+
+		w.WriteHeader(netHttp.StatusNoContent)
+	})
+}
+
+// authorise checks that the callback request is a post authorised to
+// access the given service, writing an error response if it is not
+func authorise(w netHttp.ResponseWriter, r *netHttp.Request, authoriser jsonRpcServerAuthoriser.Authoriser, service string) (claims.Claims, bool) {
+	if r.Method != netHttp.MethodPost {
+		netHttp.Error(w, "Method Not Allowed", netHttp.StatusMethodNotAllowed)
+		return nil, false
+	}
+
+	if r.Header["Authorization"] == nil {
+		log.Info("Unauthorised Sigfox Backend Callback! - No Authorisation header!")
+		netHttp.Error(w, "Unauthorised", netHttp.StatusForbidden)
+		return nil, false
+	}
+
+	wrappedClaims, err := authoriser.AuthoriseServiceMethod(r.Header["Authorization"][0], service)
+	if err != nil {
+		log.Warn("Unauthorised Sigfox Backend Callback Attempt", err.Error())
+		netHttp.Error(w, "Unauthorised", netHttp.StatusForbidden)
+		return nil, false
+	}
+	claims, err := wrappedClaims.Unwrap()
+	if err != nil {
+		log.Warn("Unauthorised Sigfox Backend Callback Attempt", err.Error())
+		netHttp.Error(w, "Unauthorised", netHttp.StatusForbidden)
+		return nil, false
+	}
+
+	return claims, true
+}
+
+func writeError(w netHttp.ResponseWriter, err error) {
+	switch err.(type) {
+	case brainException.RequestInvalid:
+		netHttp.Error(w, err.Error(), netHttp.StatusBadRequest)
+	default:
+		netHttp.Error(w, err.Error(), netHttp.StatusInternalServerError)
+	}
+}
@@ -30,12 +30,67 @@ func (a *adaptor) MethodRequiresAuthorization(string) bool {
 	return true
 }
 
+// HandleDataMessageRequest is populated from the variables
+// of the sigfox backend data callback of the same names
 type HandleDataMessageRequest struct {
-	DeviceId string `json:"deviceId"`
-	Data     string `json:"data"`
+	DeviceId         string            `json:"deviceId"`
+	Data             string            `json:"data"`
+	Ack              bool              `json:"ack"`
+	SeqNumber        int               `json:"seqNumber"`
+	Time             int64             `json:"time"`
+	Station          string            `json:"station"`
+	RSSI             float64           `json:"rssi"`
+	SNR              float64           `json:"snr"`
+	Duplicate        bool              `json:"duplicate"`
+	ComputedLocation *ComputedLocation `json:"computedLocation"`
 }
 
+// ComputedLocation is in the shape given by the sigfox backend
+type ComputedLocation struct {
+	Lat    float64 `json:"lat"`
+	Lng    float64 `json:"lng"`
+	Radius int     `json:"radius"`
+	Source int     `json:"source"`
+	Status int     `json:"status"`
+}
+
+// HandleDataMessageResponse gives the downlink to be sent to the device, if any.
+// This is not the shape expected by the sigfox backend for bidirectional
+// callbacks, which are to be configured to use the http callback adaptor.
 type HandleDataMessageResponse struct {
+	DownlinkData string `json:"downlinkData"`
+}
+
+// Message returns the sigfox data message given by the request
+func (r HandleDataMessageRequest) Message() (sigfoxBackendCallbackDataMessage.Message, error) {
+	messageData, err := hex.DecodeString(r.Data)
+	if err != nil {
+		return sigfoxBackendCallbackDataMessage.Message{}, err
+	}
+
+	var computedLocation *sigfoxBackendCallbackDataMessage.ComputedLocation
+	if r.ComputedLocation != nil {
+		computedLocation = &sigfoxBackendCallbackDataMessage.ComputedLocation{
+			Latitude:  r.ComputedLocation.Lat,
+			Longitude: r.ComputedLocation.Lng,
+			Radius:    r.ComputedLocation.Radius,
+			Source:    r.ComputedLocation.Source,
+			Status:    r.ComputedLocation.Status,
+		}
+	}
+
+	return sigfoxBackendCallbackDataMessage.Message{
+		DeviceId:         r.DeviceId,
+		Data:             messageData,
+		Ack:              r.Ack,
+		SeqNumber:        r.SeqNumber,
+		Time:             r.Time,
+		Station:          r.Station,
+		RSSI:             r.RSSI,
+		SNR:              r.SNR,
+		Duplicate:        r.Duplicate,
+		ComputedLocation: computedLocation,
+	}, nil
 }
 
 func (a *adaptor) HandleDataMessage(r *http.Request, request *HandleDataMessageRequest, response *HandleDataMessageResponse) error {
@@ -45,18 +100,45 @@ func (a *adaptor) HandleDataMessage(r *http.Request, request *HandleDataMessageR
 		return err
 	}
 
-	messageData, err := hex.DecodeString(request.Data)
+	message, err := request.Message()
 	if err != nil {
 		log.Error(err.Error())
 		return err
 	}
 
-	if _, err := a.Server.HandleDataMessage(&sigfoxBackendCallbackServer.HandleDataMessageRequest{
-		Claims: claims,
-		Message: sigfoxBackendCallbackDataMessage.Message{
-			DeviceId: request.DeviceId,
-			Data:     messageData,
-		},
+	handleDataMessageResponse, err := a.Server.HandleDataMessage(&sigfoxBackendCallbackServer.HandleDataMessageRequest{
+		Claims:  claims,
+		Message: message,
+	})
+	if err != nil {
+		return err
+	}
+
+	response.DownlinkData = hex.EncodeToString(handleDataMessageResponse.DownlinkData)
+
+	return nil
+}
+
+// HandleDownlinkAckRequest is populated from the variables of
+// the sigfox backend downlink acknowledgement callback
+type HandleDownlinkAckRequest struct {
+	DeviceId    string `json:"deviceId"`
+	DownlinkAck bool   `json:"downlinkAck"`
+}
+
+type HandleDownlinkAckResponse struct{}
+
+func (a *adaptor) HandleDownlinkAck(r *http.Request, request *HandleDownlinkAckRequest, response *HandleDownlinkAckResponse) error {
+	claims, err := wrappedClaims.UnwrapClaimsFromContext(r)
+	if err != nil {
+		log.Warn(err.Error())
+		return err
+	}
+
+	if _, err := a.Server.HandleDownlinkAck(&sigfoxBackendCallbackServer.HandleDownlinkAckRequest{
+		Claims:      claims,
+		DeviceId:    request.DeviceId,
+		DownlinkAck: request.DownlinkAck,
 	}); err != nil {
 		return err
 	}
@@ -1,27 +1,44 @@
 package basic
 
 import (
+	"fmt"
 	brainException "github.com/iot-my-world/brain/internal/exception"
 	"github.com/iot-my-world/brain/internal/log"
+	"github.com/iot-my-world/brain/pkg/device/sigbug"
+	sigbugDownlinkAdministrator "github.com/iot-my-world/brain/pkg/device/sigbug/downlink/administrator"
+	"github.com/iot-my-world/brain/pkg/search/identifier/id"
+	humanUserLoginClaims "github.com/iot-my-world/brain/pkg/security/claims/login/user/human"
+	sigfoxBackendDataCallbackMessage "github.com/iot-my-world/brain/pkg/sigfox/backend/callback/data/message"
 	sigfoxBackendDataCallbackMessageAdministrator "github.com/iot-my-world/brain/pkg/sigfox/backend/callback/data/message/administrator"
-	sigfoxBackendDataMessageHandler "github.com/iot-my-world/brain/pkg/sigfox/backend/callback/data/message/handler"
+	sigfoxBackendDataCallbackMessageDispatcher "github.com/iot-my-world/brain/pkg/sigfox/backend/callback/data/message/dispatcher"
+	sigfoxBackendDataCallbackMessageRecordHandler "github.com/iot-my-world/brain/pkg/sigfox/backend/callback/data/message/recordHandler"
+	sigfoxBackendDataCallbackMessageRecordHandlerException "github.com/iot-my-world/brain/pkg/sigfox/backend/callback/data/message/recordHandler/exception"
 	sigfoxBackendCallbackServer "github.com/iot-my-world/brain/pkg/sigfox/backend/callback/server"
 	sigfoxBackendCallbackServerException "github.com/iot-my-world/brain/pkg/sigfox/backend/callback/server/exception"
 	"time"
 )
 
 type server struct {
-	handlers                                      []sigfoxBackendDataMessageHandler.Handler
 	sigfoxBackendDataCallbackMessageAdministrator sigfoxBackendDataCallbackMessageAdministrator.Administrator
+	sigfoxBackendDataCallbackMessageRecordHandler sigfoxBackendDataCallbackMessageRecordHandler.RecordHandler
+	sigfoxBackendDataCallbackMessageDispatcher    sigfoxBackendDataCallbackMessageDispatcher.Dispatcher
+	sigbugDownlinkAdministrator                   sigbugDownlinkAdministrator.Administrator
+	systemClaims                                  *humanUserLoginClaims.Login
 }
 
 func New(
 	sigfoxBackendDataCallbackMessageAdministrator sigfoxBackendDataCallbackMessageAdministrator.Administrator,
-	handlers []sigfoxBackendDataMessageHandler.Handler,
+	sigfoxBackendDataCallbackMessageRecordHandler sigfoxBackendDataCallbackMessageRecordHandler.RecordHandler,
+	sigfoxBackendDataCallbackMessageDispatcher sigfoxBackendDataCallbackMessageDispatcher.Dispatcher,
+	sigbugDownlinkAdministrator sigbugDownlinkAdministrator.Administrator,
+	systemClaims *humanUserLoginClaims.Login,
 ) sigfoxBackendCallbackServer.Server {
 	return &server{
-		handlers: handlers,
 		sigfoxBackendDataCallbackMessageAdministrator: sigfoxBackendDataCallbackMessageAdministrator,
+		sigfoxBackendDataCallbackMessageRecordHandler: sigfoxBackendDataCallbackMessageRecordHandler,
+		sigfoxBackendDataCallbackMessageDispatcher:    sigfoxBackendDataCallbackMessageDispatcher,
+		sigbugDownlinkAdministrator:                   sigbugDownlinkAdministrator,
+		systemClaims:                                  systemClaims,
 	}
 }
 
@@ -44,33 +61,129 @@ func (s *server) HandleDataMessage(request *sigfoxBackendCallbackServer.HandleDa
 		return nil, err
 	}
 
-	// set timestamp on data callback message
+	// set received timestamp on data callback message
 	request.Message.Timestamp = time.Now().UTC().Unix()
 
-	// record data callback message
+	// record data callback message. sigfox retries callbacks which fail
+	// and can send repeats of a message, possibly concurrently, which are
+	// recognised by the unique index on the deduplication key.
+	request.Message.SetDeduplicationKey()
+	var messageToDispatch sigfoxBackendDataCallbackMessage.Message
 	createMessageResponse, err := s.sigfoxBackendDataCallbackMessageAdministrator.Create(
 		&sigfoxBackendDataCallbackMessageAdministrator.CreateRequest{
 			Claims:  request.Claims,
 			Message: request.Message,
 		},
 	)
+	switch err.(type) {
+	case nil:
+		messageToDispatch = createMessageResponse.Message
+
+	case sigfoxBackendDataCallbackMessageRecordHandlerException.Duplicate:
+		// a repeat of a message which has already been dispatched is ignored.
+		// the message repeated was not dispatched if handling the callback
+		// which recorded it failed, and so it is dispatched now.
+		retrieveMessageResponse, err := s.sigfoxBackendDataCallbackMessageRecordHandler.Retrieve(
+			&sigfoxBackendDataCallbackMessageRecordHandler.RetrieveRequest{
+				Claims: s.systemClaims,
+				Identifier: sigfoxBackendDataCallbackMessage.Identifier{
+					DeviceId:  request.Message.DeviceId,
+					SeqNumber: request.Message.SeqNumber,
+					Time:      request.Message.Time,
+				},
+			},
+		)
+		if err != nil {
+			err = sigfoxBackendCallbackServerException.HandleDataMessage{Reasons: []string{"retrieving repeated message", err.Error()}}
+			log.Error(err.Error())
+			return nil, err
+		}
+		if retrieveMessageResponse.Message.Dispatched {
+			log.Info(fmt.Sprintf(
+				"ignoring repeated message %d from device %s",
+				request.Message.SeqNumber, request.Message.DeviceId,
+			))
+			return &sigfoxBackendCallbackServer.HandleDataMessageResponse{}, nil
+		}
+		messageToDispatch = retrieveMessageResponse.Message
+
+	default:
+		log.Error(err.Error())
+		return nil, err
+	}
+
+	// dispatch message to be handled by the handlers that want it.
+	// handling failures are retried and recorded by the dispatcher, the
+	// message has been recorded and so they do not fail the callback.
+	dispatchResponse, err := s.sigfoxBackendDataCallbackMessageDispatcher.Dispatch(&sigfoxBackendDataCallbackMessageDispatcher.DispatchRequest{
+		Claims:  request.Claims,
+		Message: messageToDispatch,
+	})
 	if err != nil {
+		err = sigfoxBackendCallbackServerException.HandleDataMessage{Reasons: []string{"dispatching message", err.Error()}}
 		log.Error(err.Error())
 		return nil, err
 	}
 
-	// give message to handlers that want it
-	for handlerIdx := range s.handlers {
-		if s.handlers[handlerIdx].WantMessage(request.Message) {
-			if err := s.handlers[handlerIdx].Handle(&sigfoxBackendDataMessageHandler.HandleRequest{
-				Claims:      request.Claims,
-				DataMessage: createMessageResponse.Message,
-			}); err != nil {
-				err = sigfoxBackendCallbackServerException.HandleDataMessage{Reasons: []string{"handling message", err.Error()}}
-				log.Error(err.Error())
-				return nil, err
-			}
-		}
+	// record that the message has been dispatched so that its repeats are
+	// ignored. failure to do so is logged but does not fail the callback,
+	// since the message has been handled and at worst a repeat of it is
+	// dispatched again.
+	messageToDispatch.Dispatched = true
+	if _, err := s.sigfoxBackendDataCallbackMessageRecordHandler.Update(&sigfoxBackendDataCallbackMessageRecordHandler.UpdateRequest{
+		Claims:     s.systemClaims,
+		Identifier: id.Identifier{Id: messageToDispatch.Id},
+		Message:    messageToDispatch,
+	}); err != nil {
+		log.Error("error recording that message was dispatched: " + err.Error())
+	}
+
+	return &sigfoxBackendCallbackServer.HandleDataMessageResponse{
+		DownlinkData: dispatchResponse.DownlinkData,
+	}, nil
+}
+
+func (s *server) ValidateHandleDownlinkAckRequest(request *sigfoxBackendCallbackServer.HandleDownlinkAckRequest) error {
+	reasonsInvalid := make([]string, 0)
+
+	if request.Claims == nil {
+		reasonsInvalid = append(reasonsInvalid, "claims are nil")
+	}
+
+	if request.DeviceId == "" {
+		reasonsInvalid = append(reasonsInvalid, "device id is blank")
 	}
-	return nil, nil
+
+	if len(reasonsInvalid) > 0 {
+		return brainException.RequestInvalid{Reasons: reasonsInvalid}
+	}
+	return nil
+}
+
+// HandleDownlinkAck marks the downlink sent to the device as delivered,
+// or returns it to the queue of the device if it could not be delivered.
+// Downlinks are only sent to sigbug devices.
+func (s *server) HandleDownlinkAck(request *sigfoxBackendCallbackServer.HandleDownlinkAckRequest) (*sigfoxBackendCallbackServer.HandleDownlinkAckResponse, error) {
+	if err := s.ValidateHandleDownlinkAckRequest(request); err != nil {
+		log.Error(err.Error())
+		return nil, err
+	}
+
+	acknowledgeResponse, err := s.sigbugDownlinkAdministrator.Acknowledge(&sigbugDownlinkAdministrator.AcknowledgeRequest{
+		Claims: request.Claims,
+		Identifier: sigbug.Identifier{
+			DeviceId: request.DeviceId,
+		},
+		Delivered: request.DownlinkAck,
+	})
+	if err != nil {
+		err = sigfoxBackendCallbackServerException.HandleDownlinkAck{Reasons: []string{err.Error()}}
+		log.Error(err.Error())
+		return nil, err
+	}
+	if !acknowledgeResponse.Acknowledged {
+		log.Warn(fmt.Sprintf("no downlink sent to device %s to acknowledge", request.DeviceId))
+	}
+
+	return &sigfoxBackendCallbackServer.HandleDownlinkAckResponse{}, nil
 }
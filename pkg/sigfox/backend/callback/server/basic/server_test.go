@@ -0,0 +1,141 @@
+package basic
+
+import (
+	"errors"
+	"github.com/iot-my-world/brain/pkg/search/identifier/id"
+	humanUserLoginClaims "github.com/iot-my-world/brain/pkg/security/claims/login/user/human"
+	sigfoxBackendDataCallbackMessage "github.com/iot-my-world/brain/pkg/sigfox/backend/callback/data/message"
+	sigfoxBackendDataCallbackMessageAdministrator "github.com/iot-my-world/brain/pkg/sigfox/backend/callback/data/message/administrator"
+	sigfoxBackendDataCallbackMessageDispatcher "github.com/iot-my-world/brain/pkg/sigfox/backend/callback/data/message/dispatcher"
+	sigfoxBackendDataCallbackMessageRecordHandler "github.com/iot-my-world/brain/pkg/sigfox/backend/callback/data/message/recordHandler"
+	sigfoxBackendDataCallbackMessageRecordHandlerException "github.com/iot-my-world/brain/pkg/sigfox/backend/callback/data/message/recordHandler/exception"
+	sigfoxBackendCallbackServer "github.com/iot-my-world/brain/pkg/sigfox/backend/callback/server"
+	"github.com/stretchr/testify/assert"
+	"strconv"
+	"testing"
+)
+
+// fakeRecordHandler records messages in memory
+type fakeRecordHandler struct {
+	sigfoxBackendDataCallbackMessageRecordHandler.RecordHandler
+	messages []sigfoxBackendDataCallbackMessage.Message
+}
+
+// fakeAdministrator creates messages with the given record handler,
+// enforcing the uniqueness of deduplication keys as the mongo index does
+type fakeAdministrator struct {
+	*fakeRecordHandler
+}
+
+func (a fakeAdministrator) Create(request *sigfoxBackendDataCallbackMessageAdministrator.CreateRequest) (*sigfoxBackendDataCallbackMessageAdministrator.CreateResponse, error) {
+	for _, message := range a.messages {
+		if request.Message.DeduplicationKey != "" && message.DeduplicationKey == request.Message.DeduplicationKey {
+			return nil, sigfoxBackendDataCallbackMessageRecordHandlerException.Duplicate{}
+		}
+	}
+	request.Message.Id = strconv.Itoa(len(a.messages))
+	a.messages = append(a.messages, request.Message)
+	return &sigfoxBackendDataCallbackMessageAdministrator.CreateResponse{Message: request.Message}, nil
+}
+
+func (r *fakeRecordHandler) Retrieve(request *sigfoxBackendDataCallbackMessageRecordHandler.RetrieveRequest) (*sigfoxBackendDataCallbackMessageRecordHandler.RetrieveResponse, error) {
+	for _, message := range r.messages {
+		if request.Identifier.(sigfoxBackendDataCallbackMessage.Identifier) == (sigfoxBackendDataCallbackMessage.Identifier{
+			DeviceId:  message.DeviceId,
+			SeqNumber: message.SeqNumber,
+			Time:      message.Time,
+		}) {
+			return &sigfoxBackendDataCallbackMessageRecordHandler.RetrieveResponse{Message: message}, nil
+		}
+	}
+	return nil, sigfoxBackendDataCallbackMessageRecordHandlerException.NotFound{}
+}
+
+func (r *fakeRecordHandler) Update(request *sigfoxBackendDataCallbackMessageRecordHandler.UpdateRequest) (*sigfoxBackendDataCallbackMessageRecordHandler.UpdateResponse, error) {
+	for messageIdx := range r.messages {
+		if r.messages[messageIdx].Id == request.Identifier.(id.Identifier).Id {
+			r.messages[messageIdx] = request.Message
+		}
+	}
+	return &sigfoxBackendDataCallbackMessageRecordHandler.UpdateResponse{}, nil
+}
+
+// fakeDispatcher fails the given number of dispatches
+type fakeDispatcher struct {
+	failures   int
+	dispatched []string
+}
+
+func (d *fakeDispatcher) Dispatch(request *sigfoxBackendDataCallbackMessageDispatcher.DispatchRequest) (*sigfoxBackendDataCallbackMessageDispatcher.DispatchResponse, error) {
+	if d.failures > 0 {
+		d.failures--
+		return nil, errors.New("dispatching failed")
+	}
+	d.dispatched = append(d.dispatched, request.Message.Id)
+	return &sigfoxBackendDataCallbackMessageDispatcher.DispatchResponse{}, nil
+}
+
+func TestHandleDataMessageRepeats(t *testing.T) {
+	recordHandler := &fakeRecordHandler{}
+	dispatcher := &fakeDispatcher{}
+	s := New(fakeAdministrator{recordHandler}, recordHandler, dispatcher, nil, &humanUserLoginClaims.Login{})
+
+	messages := []sigfoxBackendDataCallbackMessage.Message{
+		// messages without sigfox metadata are never taken to be repeats
+		{DeviceId: "1234"},
+		{DeviceId: "1234"},
+		// messages with metadata are
+		{DeviceId: "1234", SeqNumber: 1, Time: 1546300800},
+		{DeviceId: "1234", SeqNumber: 1, Time: 1546300800},
+		// sequence numbers wrap and so are distinguished by time
+		{DeviceId: "1234", SeqNumber: 1, Time: 1546300900},
+		{DeviceId: "5678", SeqNumber: 1, Time: 1546300800},
+	}
+	for _, message := range messages {
+		_, err := s.HandleDataMessage(&sigfoxBackendCallbackServer.HandleDataMessageRequest{
+			Claims:  &humanUserLoginClaims.Login{},
+			Message: message,
+		})
+		assert.NoError(t, err)
+	}
+
+	assert.Len(t, recordHandler.messages, 5)
+	assert.Equal(t, []string{"0", "1", "2", "3", "4"}, dispatcher.dispatched)
+	assert.Equal(t, "", recordHandler.messages[0].DeduplicationKey)
+	assert.Equal(t, "1234:1:1546300800", recordHandler.messages[2].DeduplicationKey)
+	for _, message := range recordHandler.messages {
+		assert.True(t, message.Dispatched)
+	}
+}
+
+func TestHandleDataMessageRepeatOfUndispatchedMessage(t *testing.T) {
+	recordHandler := &fakeRecordHandler{}
+	dispatcher := &fakeDispatcher{failures: 1}
+	s := New(fakeAdministrator{recordHandler}, recordHandler, dispatcher, nil, &humanUserLoginClaims.Login{})
+
+	message := sigfoxBackendDataCallbackMessage.Message{DeviceId: "1234", SeqNumber: 1, Time: 1546300800}
+	handle := func() error {
+		_, err := s.HandleDataMessage(&sigfoxBackendCallbackServer.HandleDataMessageRequest{
+			Claims:  &humanUserLoginClaims.Login{},
+			Message: message,
+		})
+		return err
+	}
+
+	// the message is recorded but dispatching it fails
+	assert.Error(t, handle())
+	if assert.Len(t, recordHandler.messages, 1) {
+		assert.False(t, recordHandler.messages[0].Dispatched)
+	}
+
+	// the repeat sent by sigfox is dispatched in its place
+	assert.NoError(t, handle())
+	assert.Equal(t, []string{"0"}, dispatcher.dispatched)
+	if assert.Len(t, recordHandler.messages, 1) {
+		assert.True(t, recordHandler.messages[0].Dispatched)
+	}
+
+	// further repeats are ignored
+	assert.NoError(t, handle())
+	assert.Equal(t, []string{"0"}, dispatcher.dispatched)
+}
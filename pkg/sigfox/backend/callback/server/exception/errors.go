@@ -9,3 +9,11 @@ type HandleDataMessage struct {
 func (e HandleDataMessage) Error() string {
 	return "error handling data message: " + strings.Join(e.Reasons, "; ")
 }
+
+type HandleDownlinkAck struct {
+	Reasons []string
+}
+
+func (e HandleDownlinkAck) Error() string {
+	return "error handling downlink acknowledgement: " + strings.Join(e.Reasons, "; ")
+}
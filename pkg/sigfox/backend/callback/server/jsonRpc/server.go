@@ -36,16 +36,78 @@ func (s *server) HandleDataMessage(request *sigfoxBackendCallbackServer.HandleDa
 		return nil, err
 	}
 
+	var computedLocation *sigfoxBackendCallbackServerJsonRpcAdaptor.ComputedLocation
+	if request.Message.ComputedLocation != nil {
+		computedLocation = &sigfoxBackendCallbackServerJsonRpcAdaptor.ComputedLocation{
+			Lat:    request.Message.ComputedLocation.Latitude,
+			Lng:    request.Message.ComputedLocation.Longitude,
+			Radius: request.Message.ComputedLocation.Radius,
+			Source: request.Message.ComputedLocation.Source,
+			Status: request.Message.ComputedLocation.Status,
+		}
+	}
+
 	handleDataMessageResponse := sigfoxBackendCallbackServerJsonRpcAdaptor.HandleDataMessageResponse{}
 	if err := s.jsonRpcClient.JsonRpcRequest(
 		sigfoxBackendCallbackServer.HandleDataMessageService,
 		sigfoxBackendCallbackServerJsonRpcAdaptor.HandleDataMessageRequest{
-			DeviceId: request.Message.DeviceId,
-			Data:     hex.EncodeToString(request.Message.Data),
+			DeviceId:         request.Message.DeviceId,
+			Data:             hex.EncodeToString(request.Message.Data),
+			Ack:              request.Message.Ack,
+			SeqNumber:        request.Message.SeqNumber,
+			Time:             request.Message.Time,
+			Station:          request.Message.Station,
+			RSSI:             request.Message.RSSI,
+			SNR:              request.Message.SNR,
+			Duplicate:        request.Message.Duplicate,
+			ComputedLocation: computedLocation,
 		},
 		&handleDataMessageResponse); err != nil {
 		return nil, err
 	}
 
-	return &sigfoxBackendCallbackServer.HandleDataMessageResponse{}, nil
+	response := sigfoxBackendCallbackServer.HandleDataMessageResponse{}
+	if handleDataMessageResponse.DownlinkData != "" {
+		downlinkData, err := hex.DecodeString(handleDataMessageResponse.DownlinkData)
+		if err != nil {
+			log.Error(err.Error())
+			return nil, err
+		}
+		response.DownlinkData = downlinkData
+	}
+
+	return &response, nil
+}
+
+func (s *server) ValidateHandleDownlinkAckRequest(request *sigfoxBackendCallbackServer.HandleDownlinkAckRequest) error {
+	reasonsInvalid := make([]string, 0)
+
+	if request.DeviceId == "" {
+		reasonsInvalid = append(reasonsInvalid, "device id is blank")
+	}
+
+	if len(reasonsInvalid) > 0 {
+		return brainException.RequestInvalid{Reasons: reasonsInvalid}
+	}
+	return nil
+}
+
+func (s *server) HandleDownlinkAck(request *sigfoxBackendCallbackServer.HandleDownlinkAckRequest) (*sigfoxBackendCallbackServer.HandleDownlinkAckResponse, error) {
+	if err := s.ValidateHandleDownlinkAckRequest(request); err != nil {
+		log.Error(err.Error())
+		return nil, err
+	}
+
+	handleDownlinkAckResponse := sigfoxBackendCallbackServerJsonRpcAdaptor.HandleDownlinkAckResponse{}
+	if err := s.jsonRpcClient.JsonRpcRequest(
+		sigfoxBackendCallbackServer.HandleDownlinkAckService,
+		sigfoxBackendCallbackServerJsonRpcAdaptor.HandleDownlinkAckRequest{
+			DeviceId:    request.DeviceId,
+			DownlinkAck: request.DownlinkAck,
+		},
+		&handleDownlinkAckResponse); err != nil {
+		return nil, err
+	}
+
+	return &sigfoxBackendCallbackServer.HandleDownlinkAckResponse{}, nil
 }
@@ -7,11 +7,13 @@ import (
 
 type Server interface {
 	HandleDataMessage(*HandleDataMessageRequest) (*HandleDataMessageResponse, error)
+	HandleDownlinkAck(*HandleDownlinkAckRequest) (*HandleDownlinkAckResponse, error)
 }
 
 const ServiceProvider = "SigfoxBackendCallbackServer"
 
 const HandleDataMessageService = ServiceProvider + ".HandleDataMessage"
+const HandleDownlinkAckService = ServiceProvider + ".HandleDownlinkAck"
 
 type HandleDataMessageRequest struct {
 	Claims  claims.Claims
@@ -19,4 +21,18 @@ type HandleDataMessageRequest struct {
 }
 
 type HandleDataMessageResponse struct {
+	// DownlinkData to be sent to the device if the message
+	// requested an acknowledgement, nil if there is nothing to send
+	DownlinkData []byte
 }
+
+// HandleDownlinkAckRequest is populated from the sigfox backend
+// downlink acknowledgement callback, sent once the downlink sent
+// in reply to a data message has been delivered or has failed
+type HandleDownlinkAckRequest struct {
+	Claims      claims.Claims
+	DeviceId    string
+	DownlinkAck bool
+}
+
+type HandleDownlinkAckResponse struct{}
@@ -0,0 +1,82 @@
+package jsonRpc
+
+import (
+	"github.com/iot-my-world/brain/internal/log"
+	jsonRpcServiceProvider "github.com/iot-my-world/brain/pkg/api/jsonRpc/service/provider"
+	wrappedClaims "github.com/iot-my-world/brain/pkg/security/claims/wrapped"
+	apiUserAuthenticator "github.com/iot-my-world/brain/pkg/user/api/authenticator"
+	"net/http"
+)
+
+type adaptor struct {
+	apiUserAuthenticator apiUserAuthenticator.Authenticator
+}
+
+func New(apiUserAuthenticator apiUserAuthenticator.Authenticator) *adaptor {
+	return &adaptor{
+		apiUserAuthenticator: apiUserAuthenticator,
+	}
+}
+
+func (a *adaptor) Name() jsonRpcServiceProvider.Name {
+	return jsonRpcServiceProvider.Name(apiUserAuthenticator.ServiceProvider)
+}
+
+func (a *adaptor) MethodRequiresAuthorization(method string) bool {
+	switch method {
+	case apiUserAuthenticator.LoginService:
+		return false
+	}
+	return true
+}
+
+type LoginRequest struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+}
+
+type LoginResponse struct {
+	Jwt string `json:"jwt"`
+}
+
+func (a *adaptor) Login(r *http.Request, request *LoginRequest, response *LoginResponse) error {
+	loginResponse, err := a.apiUserAuthenticator.Login(&apiUserAuthenticator.LoginRequest{
+		Username: request.Username,
+		Password: request.Password,
+	})
+	if err != nil {
+		return err
+	}
+
+	response.Jwt = loginResponse.Jwt
+
+	return nil
+}
+
+type LogoutRequest struct {
+}
+
+type LogoutResponse struct {
+}
+
+func (a *adaptor) Logout(r *http.Request, request *LogoutRequest, response *LogoutResponse) error {
+	wrapped, err := wrappedClaims.WrappedClaimsFromContext(r)
+	if err != nil {
+		log.Warn(err.Error())
+		return err
+	}
+	claims, err := wrapped.Unwrap()
+	if err != nil {
+		log.Warn(err.Error())
+		return err
+	}
+
+	if _, err := a.apiUserAuthenticator.Logout(&apiUserAuthenticator.LogoutRequest{
+		Claims:  claims,
+		TokenId: wrapped.TokenId,
+	}); err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,52 @@
+package authenticator
+
+import (
+	"github.com/iot-my-world/brain/pkg/security/claims"
+	"github.com/iot-my-world/brain/pkg/security/permission/api"
+)
+
+type Authenticator interface {
+	Login(request *LoginRequest) (*LoginResponse, error)
+	Logout(request *LogoutRequest) (*LogoutResponse, error)
+}
+
+const ServiceProvider = "APIUser-Authenticator"
+const LoginService = ServiceProvider + ".Login"
+const LogoutService = ServiceProvider + ".Logout"
+
+var SystemUserPermissions = []api.Permission{
+	LogoutService,
+}
+
+var CompanyAdminUserPermissions = []api.Permission{
+	LogoutService,
+}
+
+var CompanyUserPermissions = []api.Permission{
+	LogoutService,
+}
+
+var ClientAdminUserPermissions = []api.Permission{
+	LogoutService,
+}
+
+var ClientUserPermissions = []api.Permission{
+	LogoutService,
+}
+
+type LoginRequest struct {
+	Username string
+	Password string
+}
+
+type LoginResponse struct {
+	Jwt string
+}
+
+type LogoutRequest struct {
+	Claims  claims.Claims
+	TokenId string
+}
+
+type LogoutResponse struct {
+}
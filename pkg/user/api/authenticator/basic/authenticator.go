@@ -0,0 +1,150 @@
+package basic
+
+import (
+	"crypto/rsa"
+	"errors"
+	brainException "github.com/iot-my-world/brain/internal/exception"
+	"github.com/iot-my-world/brain/internal/log"
+	partyAdministrator "github.com/iot-my-world/brain/pkg/party/administrator"
+	"github.com/iot-my-world/brain/pkg/search/identifier/id"
+	"github.com/iot-my-world/brain/pkg/search/identifier/username"
+	apiUserLoginClaims "github.com/iot-my-world/brain/pkg/security/claims/login/user/api"
+	humanUserLoginClaims "github.com/iot-my-world/brain/pkg/security/claims/login/user/human"
+	"github.com/iot-my-world/brain/pkg/security/token"
+	tokenRevocationAdministrator "github.com/iot-my-world/brain/pkg/security/token/revocation/administrator"
+	apiUserAuthenticator "github.com/iot-my-world/brain/pkg/user/api/authenticator"
+	apiUserRecordHandler "github.com/iot-my-world/brain/pkg/user/api/recordHandler"
+	"golang.org/x/crypto/bcrypt"
+	"time"
+)
+
+// time for which api user login tokens are valid
+const loginTokenLifetime = 15 * time.Minute
+
+type authenticator struct {
+	partyAdministrator           partyAdministrator.Administrator
+	apiUserRecordHandler         apiUserRecordHandler.RecordHandler
+	jwtGenerator                 token.JWTGenerator
+	systemClaims                 *humanUserLoginClaims.Login
+	tokenRevocationAdministrator tokenRevocationAdministrator.Administrator
+}
+
+func New(
+	partyAdministrator partyAdministrator.Administrator,
+	apiUserRecordHandler apiUserRecordHandler.RecordHandler,
+	rsaPrivateKey *rsa.PrivateKey,
+	systemClaims *humanUserLoginClaims.Login,
+	tokenRevocationAdministrator tokenRevocationAdministrator.Administrator,
+) apiUserAuthenticator.Authenticator {
+	return &authenticator{
+		partyAdministrator:           partyAdministrator,
+		apiUserRecordHandler:         apiUserRecordHandler,
+		jwtGenerator:                 token.NewJWTGenerator(rsaPrivateKey),
+		systemClaims:                 systemClaims,
+		tokenRevocationAdministrator: tokenRevocationAdministrator,
+	}
+}
+
+func (a *authenticator) ValidateLoginRequest(request *apiUserAuthenticator.LoginRequest) error {
+	reasonsInvalid := make([]string, 0)
+
+	if request.Username == "" {
+		reasonsInvalid = append(reasonsInvalid, "username is blank")
+	}
+
+	if request.Password == "" {
+		reasonsInvalid = append(reasonsInvalid, "password is blank")
+	}
+
+	if len(reasonsInvalid) > 0 {
+		return brainException.RequestInvalid{Reasons: reasonsInvalid}
+	}
+	return nil
+}
+
+func (a *authenticator) Login(request *apiUserAuthenticator.LoginRequest) (*apiUserAuthenticator.LoginResponse, error) {
+	if err := a.ValidateLoginRequest(request); err != nil {
+		return nil, err
+	}
+
+	// try and retrieve api user record with username
+	retrieveUserResponse, err := a.apiUserRecordHandler.Retrieve(&apiUserRecordHandler.RetrieveRequest{
+		Claims:     *a.systemClaims,
+		Identifier: username.Identifier{Username: request.Username},
+	})
+	if err != nil {
+		return nil, errors.New("log in failed")
+	}
+
+	// api user record retrieved successfully, check password
+	if err := bcrypt.CompareHashAndPassword(retrieveUserResponse.User.Password, []byte(request.Password)); err != nil {
+		return nil, errors.New("log in failed")
+	}
+
+	// password is correct. retrieve the party of the api user for its parent details
+	retrievePartyResponse, err := a.partyAdministrator.RetrieveParty(&partyAdministrator.RetrievePartyRequest{
+		Claims:     *a.systemClaims,
+		PartyType:  retrieveUserResponse.User.PartyType,
+		Identifier: retrieveUserResponse.User.PartyId,
+	})
+	if err != nil {
+		log.Error(err.Error())
+		return nil, errors.New("log in failed")
+	}
+
+	// try and generate login token
+	loginToken, err := a.jwtGenerator.GenerateToken(apiUserLoginClaims.Login{
+		UserId:          id.Identifier{Id: retrieveUserResponse.User.Id},
+		IssueTime:       time.Now().UTC().Unix(),
+		ExpirationTime:  time.Now().Add(loginTokenLifetime).UTC().Unix(),
+		ParentPartyType: retrievePartyResponse.Party.Details().ParentPartyType,
+		ParentId:        retrievePartyResponse.Party.Details().ParentId,
+		PartyType:       retrieveUserResponse.User.PartyType,
+		PartyId:         retrieveUserResponse.User.PartyId,
+	})
+	if err != nil {
+		log.Error(err.Error())
+		return nil, errors.New("log in failed")
+	}
+
+	return &apiUserAuthenticator.LoginResponse{Jwt: loginToken}, nil
+}
+
+func (a *authenticator) ValidateLogoutRequest(request *apiUserAuthenticator.LogoutRequest) error {
+	reasonsInvalid := make([]string, 0)
+
+	if request.Claims == nil {
+		reasonsInvalid = append(reasonsInvalid, "claims are nil")
+	}
+
+	if request.TokenId == "" {
+		reasonsInvalid = append(reasonsInvalid, "token id is blank")
+	}
+
+	if len(reasonsInvalid) > 0 {
+		return brainException.RequestInvalid{Reasons: reasonsInvalid}
+	}
+	return nil
+}
+
+func (a *authenticator) Logout(request *apiUserAuthenticator.LogoutRequest) (*apiUserAuthenticator.LogoutResponse, error) {
+	if err := a.ValidateLogoutRequest(request); err != nil {
+		return nil, err
+	}
+
+	// revoke the token with which the api user is logged in until it would have expired
+	expirationTime := int64(0)
+	if timeToExpiry := request.Claims.TimeToExpiry(); timeToExpiry >= 0 {
+		expirationTime = time.Now().Add(timeToExpiry).UTC().Unix()
+	}
+	if _, err := a.tokenRevocationAdministrator.RevokeToken(&tokenRevocationAdministrator.RevokeTokenRequest{
+		Claims:         request.Claims,
+		TokenId:        request.TokenId,
+		ExpirationTime: expirationTime,
+	}); err != nil {
+		log.Error(err.Error())
+		return nil, errors.New("log out failed")
+	}
+
+	return &apiUserAuthenticator.LogoutResponse{}, nil
+}
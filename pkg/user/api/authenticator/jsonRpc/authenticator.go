@@ -0,0 +1,51 @@
+package jsonRpc
+
+import (
+	"github.com/iot-my-world/brain/internal/log"
+	jsonRpcClient "github.com/iot-my-world/brain/pkg/api/jsonRpc/client"
+	apiUserAuthenticator "github.com/iot-my-world/brain/pkg/user/api/authenticator"
+	apiUserAuthenticatorJsonRpcAdaptor "github.com/iot-my-world/brain/pkg/user/api/authenticator/adaptor/jsonRpc"
+)
+
+type authenticator struct {
+	jsonRpcClient jsonRpcClient.Client
+}
+
+func New(
+	jsonRpcClient jsonRpcClient.Client,
+) apiUserAuthenticator.Authenticator {
+	return &authenticator{
+		jsonRpcClient: jsonRpcClient,
+	}
+}
+
+func (a *authenticator) Login(request *apiUserAuthenticator.LoginRequest) (*apiUserAuthenticator.LoginResponse, error) {
+	loginResponse := apiUserAuthenticatorJsonRpcAdaptor.LoginResponse{}
+	if err := a.jsonRpcClient.JsonRpcRequest(
+		apiUserAuthenticator.LoginService,
+		apiUserAuthenticatorJsonRpcAdaptor.LoginRequest{
+			Username: request.Username,
+			Password: request.Password,
+		},
+		&loginResponse,
+	); err != nil {
+		log.Error(err.Error())
+		return nil, err
+	}
+
+	return &apiUserAuthenticator.LoginResponse{Jwt: loginResponse.Jwt}, nil
+}
+
+func (a *authenticator) Logout(request *apiUserAuthenticator.LogoutRequest) (*apiUserAuthenticator.LogoutResponse, error) {
+	logoutResponse := apiUserAuthenticatorJsonRpcAdaptor.LogoutResponse{}
+	if err := a.jsonRpcClient.JsonRpcRequest(
+		apiUserAuthenticator.LogoutService,
+		apiUserAuthenticatorJsonRpcAdaptor.LogoutRequest{},
+		&logoutResponse,
+	); err != nil {
+		log.Error(err.Error())
+		return nil, err
+	}
+
+	return &apiUserAuthenticator.LogoutResponse{}, nil
+}
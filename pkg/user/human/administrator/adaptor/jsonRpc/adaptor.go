@@ -218,3 +218,83 @@ func (a *adaptor) SetPassword(r *http.Request, request *SetPasswordRequest, resp
 
 	return nil
 }
+
+type EnrolTOTPRequest struct {
+}
+
+type EnrolTOTPResponse struct {
+	Secret          string `json:"secret"`
+	ProvisioningURI string `json:"provisioningURI"`
+}
+
+func (a *adaptor) EnrolTOTP(r *http.Request, request *EnrolTOTPRequest, response *EnrolTOTPResponse) error {
+	claims, err := wrappedClaims.UnwrapClaimsFromContext(r)
+	if err != nil {
+		log.Warn(err.Error())
+		return err
+	}
+
+	enrolTOTPResponse, err := a.humanUserAdministrator.EnrolTOTP(&administrator.EnrolTOTPRequest{
+		Claims: claims,
+	})
+	if err != nil {
+		return err
+	}
+
+	response.Secret = enrolTOTPResponse.Secret
+	response.ProvisioningURI = enrolTOTPResponse.ProvisioningURI
+
+	return nil
+}
+
+type ConfirmTOTPEnrolmentRequest struct {
+	Code string `json:"code"`
+}
+
+type ConfirmTOTPEnrolmentResponse struct {
+	RecoveryCodes []string `json:"recoveryCodes"`
+}
+
+func (a *adaptor) ConfirmTOTPEnrolment(r *http.Request, request *ConfirmTOTPEnrolmentRequest, response *ConfirmTOTPEnrolmentResponse) error {
+	claims, err := wrappedClaims.UnwrapClaimsFromContext(r)
+	if err != nil {
+		log.Warn(err.Error())
+		return err
+	}
+
+	confirmTOTPEnrolmentResponse, err := a.humanUserAdministrator.ConfirmTOTPEnrolment(&administrator.ConfirmTOTPEnrolmentRequest{
+		Claims: claims,
+		Code:   request.Code,
+	})
+	if err != nil {
+		return err
+	}
+
+	response.RecoveryCodes = confirmTOTPEnrolmentResponse.RecoveryCodes
+
+	return nil
+}
+
+type DisableTOTPRequest struct {
+	Code string `json:"code"`
+}
+
+type DisableTOTPResponse struct {
+}
+
+func (a *adaptor) DisableTOTP(r *http.Request, request *DisableTOTPRequest, response *DisableTOTPResponse) error {
+	claims, err := wrappedClaims.UnwrapClaimsFromContext(r)
+	if err != nil {
+		log.Warn(err.Error())
+		return err
+	}
+
+	if _, err := a.humanUserAdministrator.DisableTOTP(&administrator.DisableTOTPRequest{
+		Claims: claims,
+		Code:   request.Code,
+	}); err != nil {
+		return err
+	}
+
+	return nil
+}
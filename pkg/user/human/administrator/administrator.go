@@ -15,6 +15,9 @@ type Administrator interface {
 	CheckPassword(request *CheckPasswordRequest) (*CheckPasswordResponse, error)
 	UpdatePassword(request *UpdatePasswordRequest) (*UpdatePasswordResponse, error)
 	ForgotPassword(request *ForgotPasswordRequest) (*ForgotPasswordResponse, error)
+	EnrolTOTP(request *EnrolTOTPRequest) (*EnrolTOTPResponse, error)
+	ConfirmTOTPEnrolment(request *ConfirmTOTPEnrolmentRequest) (*ConfirmTOTPEnrolmentResponse, error)
+	DisableTOTP(request *DisableTOTPRequest) (*DisableTOTPResponse, error)
 }
 
 const ServiceProvider = "HumanUser-Administrator"
@@ -25,6 +28,9 @@ const SetPasswordService = ServiceProvider + ".SetPassword"
 const CheckPasswordService = ServiceProvider + ".CheckPassword"
 const UpdatePasswordService = ServiceProvider + ".UpdatePassword"
 const ForgotPasswordService = ServiceProvider + ".ForgotPassword"
+const EnrolTOTPService = ServiceProvider + ".EnrolTOTP"
+const ConfirmTOTPEnrolmentService = ServiceProvider + ".ConfirmTOTPEnrolment"
+const DisableTOTPService = ServiceProvider + ".DisableTOTP"
 
 var SystemUserPermissions = make([]api.Permission, 0)
 
@@ -34,6 +40,9 @@ var CompanyAdminUserPermissions = []api.Permission{
 	GetMyUserService,
 	UpdatePasswordService,
 	CheckPasswordService,
+	EnrolTOTPService,
+	ConfirmTOTPEnrolmentService,
+	DisableTOTPService,
 }
 
 var CompanyUserPermissions = []api.Permission{
@@ -42,6 +51,9 @@ var CompanyUserPermissions = []api.Permission{
 	GetMyUserService,
 	UpdatePasswordService,
 	CheckPasswordService,
+	EnrolTOTPService,
+	ConfirmTOTPEnrolmentService,
+	DisableTOTPService,
 }
 
 var ClientAdminUserPermissions = []api.Permission{
@@ -50,6 +62,9 @@ var ClientAdminUserPermissions = []api.Permission{
 	GetMyUserService,
 	UpdatePasswordService,
 	CheckPasswordService,
+	EnrolTOTPService,
+	ConfirmTOTPEnrolmentService,
+	DisableTOTPService,
 }
 
 var ClientUserPermissions = []api.Permission{
@@ -58,6 +73,9 @@ var ClientUserPermissions = []api.Permission{
 	GetMyUserService,
 	UpdatePasswordService,
 	CheckPasswordService,
+	EnrolTOTPService,
+	ConfirmTOTPEnrolmentService,
+	DisableTOTPService,
 }
 
 type UpdateAllowedFieldsRequest struct {
@@ -121,3 +139,31 @@ type ForgotPasswordRequest struct {
 type ForgotPasswordResponse struct {
 	URLToken string
 }
+
+type EnrolTOTPRequest struct {
+	Claims claims.Claims
+}
+
+type EnrolTOTPResponse struct {
+	Secret          string
+	ProvisioningURI string
+}
+
+type ConfirmTOTPEnrolmentRequest struct {
+	Claims claims.Claims
+	Code   string
+}
+
+type ConfirmTOTPEnrolmentResponse struct {
+	RecoveryCodes []string
+}
+
+type DisableTOTPRequest struct {
+	Claims claims.Claims
+
+	// Code from the authenticator app of the user, or a recovery code
+	Code string
+}
+
+type DisableTOTPResponse struct {
+}
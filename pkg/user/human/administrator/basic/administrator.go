@@ -2,6 +2,7 @@ package basic
 
 import (
 	"crypto/rsa"
+	"errors"
 	"fmt"
 	"github.com/iot-my-world/brain/internal/environment"
 	brainException "github.com/iot-my-world/brain/internal/exception"
@@ -10,13 +11,19 @@ import (
 	setPasswordEmail "github.com/iot-my-world/brain/pkg/communication/email/generator/set/password"
 	"github.com/iot-my-world/brain/pkg/communication/email/mailer"
 	"github.com/iot-my-world/brain/pkg/party"
+	brainRecordHandler "github.com/iot-my-world/brain/pkg/recordHandler"
+	"github.com/iot-my-world/brain/pkg/search/criterion"
+	exactBooleanCriterion "github.com/iot-my-world/brain/pkg/search/criterion/exact/boolean"
+	exactTextCriterion "github.com/iot-my-world/brain/pkg/search/criterion/exact/text"
 	"github.com/iot-my-world/brain/pkg/search/identifier/emailAddress"
 	"github.com/iot-my-world/brain/pkg/search/identifier/id"
 	"github.com/iot-my-world/brain/pkg/search/identifier/username"
 	"github.com/iot-my-world/brain/pkg/security/claims"
 	humanUserLoginClaims "github.com/iot-my-world/brain/pkg/security/claims/login/user/human"
 	forgotPasswordClaims "github.com/iot-my-world/brain/pkg/security/claims/resetPassword"
+	lockoutAdministrator "github.com/iot-my-world/brain/pkg/security/lockout/administrator"
 	"github.com/iot-my-world/brain/pkg/security/token"
+	"github.com/iot-my-world/brain/pkg/security/totp"
 	"github.com/iot-my-world/brain/pkg/user/human"
 	"github.com/iot-my-world/brain/pkg/user/human/action"
 	humanUserAdministrator "github.com/iot-my-world/brain/pkg/user/human/administrator"
@@ -37,6 +44,8 @@ type administrator struct {
 	systemClaims              *humanUserLoginClaims.Login
 	setPasswordEmailGenerator emailGenerator.Generator
 	environmentType           environment.Type
+	lockoutAdministrator      lockoutAdministrator.Administrator
+	totpSecretKey             []byte
 }
 
 func New(
@@ -48,6 +57,8 @@ func New(
 	systemClaims *humanUserLoginClaims.Login,
 	setPasswordEmailGenerator emailGenerator.Generator,
 	environmentType environment.Type,
+	lockoutAdministrator lockoutAdministrator.Administrator,
+	totpSecretKey []byte,
 ) humanUserAdministrator.Administrator {
 	return &administrator{
 		humanUserRecordHandler:    humanUserRecordHandler,
@@ -58,6 +69,8 @@ func New(
 		systemClaims:              systemClaims,
 		setPasswordEmailGenerator: setPasswordEmailGenerator,
 		environmentType:           environmentType,
+		lockoutAdministrator:      lockoutAdministrator,
+		totpSecretKey:             totpSecretKey,
 	}
 }
 
@@ -367,13 +380,22 @@ func (a *administrator) UpdatePassword(request *humanUserAdministrator.UpdatePas
 		return nil, err
 	}
 
+	// check that password attempts for this user are not being delayed or locked out
+	if err := a.checkAttempt(loginClaims.UserId); err != nil {
+		err = humanUserAdministratorException.UpdatePassword{Reasons: []string{err.Error()}}
+		log.Error(err.Error())
+		return nil, err
+	}
+
 	//User record retrieved successfully, check given old password
 	if err := bcrypt.CompareHashAndPassword(retrieveUserResponse.User.Password, []byte(request.ExistingPassword)); err != nil {
 		//Password Incorrect
+		a.recordAttempt(loginClaims.UserId, false)
 		err = humanUserAdministratorException.UpdatePassword{Reasons: []string{"given existing password incorrect"}}
 		log.Error(err.Error())
 		return nil, err
 	}
+	a.recordAttempt(loginClaims.UserId, true)
 
 	// Hash the new Password
 	pwdHash, err := bcrypt.GenerateFromPassword([]byte(request.NewPassword), bcrypt.DefaultCost)
@@ -449,12 +471,20 @@ func (a *administrator) CheckPassword(request *humanUserAdministrator.CheckPassw
 		return nil, err
 	}
 
+	// check that password attempts for this user are not being delayed or locked out
+	if err := a.checkAttempt(loginClaims.UserId); err != nil {
+		err = humanUserAdministratorException.CheckPassword{Reasons: []string{err.Error()}}
+		log.Error(err.Error())
+		return nil, err
+	}
+
 	result := true
 	//User record retrieved successfully, check given old password
 	if err := bcrypt.CompareHashAndPassword(retrieveUserResponse.User.Password, []byte(request.Password)); err != nil {
 		//Password Incorrect
 		result = false
 	}
+	a.recordAttempt(loginClaims.UserId, result)
 
 	return &humanUserAdministrator.CheckPasswordResponse{
 		Result: result,
@@ -556,3 +586,388 @@ func (a *administrator) ForgotPassword(request *humanUserAdministrator.ForgotPas
 
 	return &humanUserAdministrator.ForgotPasswordResponse{}, nil
 }
+
+func (a *administrator) ValidateEnrolTOTPRequest(request *humanUserAdministrator.EnrolTOTPRequest) error {
+	reasonsInvalid := make([]string, 0)
+
+	if request.Claims == nil {
+		reasonsInvalid = append(reasonsInvalid, "claims are nil")
+	} else {
+		// claims must be login claims since you can only enrol yourself
+		if request.Claims.Type() != claims.HumanUserLogin {
+			reasonsInvalid = append(reasonsInvalid, "claims must be of type login")
+		}
+	}
+
+	if len(reasonsInvalid) > 0 {
+		return brainException.RequestInvalid{Reasons: reasonsInvalid}
+	}
+	return nil
+}
+
+// EnrolTOTP generates a new two-factor authentication secret for the user
+// of the given claims. Two-factor authentication is only enabled for the
+// user once the enrolment is confirmed with a code generated from the secret.
+func (a *administrator) EnrolTOTP(request *humanUserAdministrator.EnrolTOTPRequest) (*humanUserAdministrator.EnrolTOTPResponse, error) {
+	if err := a.ValidateEnrolTOTPRequest(request); err != nil {
+		log.Error(err.Error())
+		return nil, err
+	}
+
+	loginClaims, ok := request.Claims.(humanUserLoginClaims.Login)
+	if !ok {
+		err := brainException.Unexpected{Reasons: []string{"inferring claims to type login claims"}}
+		log.Error(err.Error())
+		return nil, err
+	}
+
+	// Retrieve User
+	retrieveUserResponse, err := a.humanUserRecordHandler.Retrieve(&recordHandler.RetrieveRequest{
+		Claims:     request.Claims,
+		Identifier: loginClaims.UserId,
+	})
+	if err != nil {
+		err = humanUserAdministratorException.EnrolTOTP{Reasons: []string{"retrieving user record", err.Error()}}
+		log.Error(err.Error())
+		return nil, err
+	}
+	if retrieveUserResponse.User.TOTP.Enabled {
+		err = humanUserAdministratorException.EnrolTOTP{Reasons: []string{"two-factor authentication already enabled"}}
+		log.Error(err.Error())
+		return nil, err
+	}
+
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		err = humanUserAdministratorException.EnrolTOTP{Reasons: []string{err.Error()}}
+		log.Error(err.Error())
+		return nil, err
+	}
+
+	// only the encrypted secret is stored
+	encryptedSecret, err := totp.EncryptSecret(a.totpSecretKey, secret)
+	if err != nil {
+		err = humanUserAdministratorException.EnrolTOTP{Reasons: []string{err.Error()}}
+		log.Error(err.Error())
+		return nil, err
+	}
+
+	// update user
+	retrieveUserResponse.User.TOTP = human.TOTP{
+		Secret: encryptedSecret,
+	}
+	if _, err := a.humanUserRecordHandler.Update(&recordHandler.UpdateRequest{
+		Claims:     request.Claims,
+		Identifier: loginClaims.UserId,
+		User:       retrieveUserResponse.User,
+	}); err != nil {
+		err = humanUserAdministratorException.EnrolTOTP{Reasons: []string{"update user", err.Error()}}
+		log.Error(err.Error())
+		return nil, err
+	}
+
+	accountName := retrieveUserResponse.User.EmailAddress
+	if retrieveUserResponse.User.Username != "" {
+		accountName = retrieveUserResponse.User.Username
+	}
+
+	return &humanUserAdministrator.EnrolTOTPResponse{
+		Secret:          secret,
+		ProvisioningURI: totp.ProvisioningURI(accountName, secret),
+	}, nil
+}
+
+func (a *administrator) ValidateConfirmTOTPEnrolmentRequest(request *humanUserAdministrator.ConfirmTOTPEnrolmentRequest) error {
+	reasonsInvalid := make([]string, 0)
+
+	if request.Claims == nil {
+		reasonsInvalid = append(reasonsInvalid, "claims are nil")
+	} else {
+		// claims must be login claims since you can only enrol yourself
+		if request.Claims.Type() != claims.HumanUserLogin {
+			reasonsInvalid = append(reasonsInvalid, "claims must be of type login")
+		}
+	}
+
+	if request.Code == "" {
+		reasonsInvalid = append(reasonsInvalid, "code blank")
+	}
+
+	if len(reasonsInvalid) > 0 {
+		return brainException.RequestInvalid{Reasons: reasonsInvalid}
+	}
+	return nil
+}
+
+// ConfirmTOTPEnrolment enables two-factor authentication for the user of the
+// given claims if the given code was generated from the secret issued on
+// enrolment. The recovery codes returned are not stored and cannot be shown again.
+func (a *administrator) ConfirmTOTPEnrolment(request *humanUserAdministrator.ConfirmTOTPEnrolmentRequest) (*humanUserAdministrator.ConfirmTOTPEnrolmentResponse, error) {
+	if err := a.ValidateConfirmTOTPEnrolmentRequest(request); err != nil {
+		log.Error(err.Error())
+		return nil, err
+	}
+
+	loginClaims, ok := request.Claims.(humanUserLoginClaims.Login)
+	if !ok {
+		err := brainException.Unexpected{Reasons: []string{"inferring claims to type login claims"}}
+		log.Error(err.Error())
+		return nil, err
+	}
+
+	// Retrieve User
+	retrieveUserResponse, err := a.humanUserRecordHandler.Retrieve(&recordHandler.RetrieveRequest{
+		Claims:     request.Claims,
+		Identifier: loginClaims.UserId,
+	})
+	if err != nil {
+		err = humanUserAdministratorException.ConfirmTOTPEnrolment{Reasons: []string{"retrieving user record", err.Error()}}
+		log.Error(err.Error())
+		return nil, err
+	}
+	if retrieveUserResponse.User.TOTP.Enabled {
+		err = humanUserAdministratorException.ConfirmTOTPEnrolment{Reasons: []string{"two-factor authentication already enabled"}}
+		log.Error(err.Error())
+		return nil, err
+	}
+	if retrieveUserResponse.User.TOTP.Secret == "" {
+		err = humanUserAdministratorException.ConfirmTOTPEnrolment{Reasons: []string{"user has not enrolled"}}
+		log.Error(err.Error())
+		return nil, err
+	}
+
+	// check that code attempts for this user are not being delayed or locked out
+	if err := a.checkAttempt(loginClaims.UserId); err != nil {
+		err = humanUserAdministratorException.ConfirmTOTPEnrolment{Reasons: []string{err.Error()}}
+		log.Error(err.Error())
+		return nil, err
+	}
+
+	// check the given code
+	code, codeValid, err := retrieveUserResponse.User.TOTP.VerifyCode(a.totpSecretKey, request.Code, time.Now())
+	if err != nil {
+		err = humanUserAdministratorException.ConfirmTOTPEnrolment{Reasons: []string{"verifying code", err.Error()}}
+		log.Error(err.Error())
+		return nil, err
+	}
+	if !codeValid {
+		a.recordAttempt(loginClaims.UserId, false)
+		err = humanUserAdministratorException.ConfirmTOTPEnrolment{Reasons: []string{"code incorrect"}}
+		log.Error(err.Error())
+		return nil, err
+	}
+	if err := a.useTOTPCode(request.Claims, retrieveUserResponse.User.Id, code); err != nil {
+		err = humanUserAdministratorException.ConfirmTOTPEnrolment{Reasons: []string{err.Error()}}
+		log.Error(err.Error())
+		return nil, err
+	}
+	a.recordAttempt(loginClaims.UserId, true)
+
+	// generate recovery codes, of which only the hashes are kept
+	recoveryCodes, err := totp.GenerateRecoveryCodes()
+	if err != nil {
+		err = humanUserAdministratorException.ConfirmTOTPEnrolment{Reasons: []string{err.Error()}}
+		log.Error(err.Error())
+		return nil, err
+	}
+	recoveryCodeHashes := make([]string, 0)
+	for _, recoveryCode := range recoveryCodes {
+		recoveryCodeHash, err := bcrypt.GenerateFromPassword([]byte(recoveryCode), bcrypt.DefaultCost)
+		if err != nil {
+			err = humanUserAdministratorException.ConfirmTOTPEnrolment{Reasons: []string{"hashing recovery code", err.Error()}}
+			log.Error(err.Error())
+			return nil, err
+		}
+		recoveryCodeHashes = append(recoveryCodeHashes, string(recoveryCodeHash))
+	}
+
+	// enable two-factor authentication, unless it was enabled concurrently
+	if _, err := a.humanUserRecordHandler.UpdateFields(&recordHandler.UpdateFieldsRequest{
+		Claims: request.Claims,
+		Criteria: []criterion.Criterion{
+			exactTextCriterion.Criterion{
+				Field: "id",
+				Text:  retrieveUserResponse.User.Id,
+			},
+			exactBooleanCriterion.Criterion{
+				Field:   "totp.enabled",
+				Boolean: false,
+			},
+		},
+		Update: brainRecordHandler.FieldsUpdate{
+			Set: map[string]interface{}{
+				"totp.enabled":       true,
+				"totp.recoveryCodes": recoveryCodeHashes,
+			},
+		},
+	}); err != nil {
+		switch err.(type) {
+		case exception.NotFound:
+			err = humanUserAdministratorException.ConfirmTOTPEnrolment{Reasons: []string{"two-factor authentication already enabled"}}
+		default:
+			err = humanUserAdministratorException.ConfirmTOTPEnrolment{Reasons: []string{"update user", err.Error()}}
+		}
+		log.Error(err.Error())
+		return nil, err
+	}
+
+	return &humanUserAdministrator.ConfirmTOTPEnrolmentResponse{
+		RecoveryCodes: recoveryCodes,
+	}, nil
+}
+
+func (a *administrator) ValidateDisableTOTPRequest(request *humanUserAdministrator.DisableTOTPRequest) error {
+	reasonsInvalid := make([]string, 0)
+
+	if request.Claims == nil {
+		reasonsInvalid = append(reasonsInvalid, "claims are nil")
+	} else {
+		// claims must be login claims since you can only disable for yourself
+		if request.Claims.Type() != claims.HumanUserLogin {
+			reasonsInvalid = append(reasonsInvalid, "claims must be of type login")
+		}
+	}
+
+	if request.Code == "" {
+		reasonsInvalid = append(reasonsInvalid, "code blank")
+	}
+
+	if len(reasonsInvalid) > 0 {
+		return brainException.RequestInvalid{Reasons: reasonsInvalid}
+	}
+	return nil
+}
+
+// DisableTOTP disables two-factor authentication for the user of the given
+// claims. If the party of the user requires two-factor authentication the
+// user will have to enrol again the next time that they log in.
+func (a *administrator) DisableTOTP(request *humanUserAdministrator.DisableTOTPRequest) (*humanUserAdministrator.DisableTOTPResponse, error) {
+	if err := a.ValidateDisableTOTPRequest(request); err != nil {
+		log.Error(err.Error())
+		return nil, err
+	}
+
+	loginClaims, ok := request.Claims.(humanUserLoginClaims.Login)
+	if !ok {
+		err := brainException.Unexpected{Reasons: []string{"inferring claims to type login claims"}}
+		log.Error(err.Error())
+		return nil, err
+	}
+
+	// Retrieve User
+	retrieveUserResponse, err := a.humanUserRecordHandler.Retrieve(&recordHandler.RetrieveRequest{
+		Claims:     request.Claims,
+		Identifier: loginClaims.UserId,
+	})
+	if err != nil {
+		err = humanUserAdministratorException.DisableTOTP{Reasons: []string{"retrieving user record", err.Error()}}
+		log.Error(err.Error())
+		return nil, err
+	}
+	if !retrieveUserResponse.User.TOTP.Enabled {
+		err = humanUserAdministratorException.DisableTOTP{Reasons: []string{"two-factor authentication not enabled"}}
+		log.Error(err.Error())
+		return nil, err
+	}
+
+	// check that code attempts for this user are not being delayed or locked out
+	if err := a.checkAttempt(loginClaims.UserId); err != nil {
+		err = humanUserAdministratorException.DisableTOTP{Reasons: []string{err.Error()}}
+		log.Error(err.Error())
+		return nil, err
+	}
+
+	// check the given code
+	code, codeValid, err := retrieveUserResponse.User.TOTP.VerifyCode(a.totpSecretKey, request.Code, time.Now())
+	if err != nil {
+		err = humanUserAdministratorException.DisableTOTP{Reasons: []string{"verifying code", err.Error()}}
+		log.Error(err.Error())
+		return nil, err
+	}
+	if !codeValid {
+		a.recordAttempt(loginClaims.UserId, false)
+		err = humanUserAdministratorException.DisableTOTP{Reasons: []string{"code incorrect"}}
+		log.Error(err.Error())
+		return nil, err
+	}
+	if err := a.useTOTPCode(request.Claims, retrieveUserResponse.User.Id, code); err != nil {
+		err = humanUserAdministratorException.DisableTOTP{Reasons: []string{err.Error()}}
+		log.Error(err.Error())
+		return nil, err
+	}
+	a.recordAttempt(loginClaims.UserId, true)
+
+	// update user
+	if _, err := a.humanUserRecordHandler.UpdateFields(&recordHandler.UpdateFieldsRequest{
+		Claims: request.Claims,
+		Criteria: []criterion.Criterion{
+			exactTextCriterion.Criterion{
+				Field: "id",
+				Text:  retrieveUserResponse.User.Id,
+			},
+		},
+		Update: brainRecordHandler.FieldsUpdate{
+			Set: map[string]interface{}{"totp": human.TOTP{}},
+		},
+	}); err != nil {
+		err = humanUserAdministratorException.DisableTOTP{Reasons: []string{"update user", err.Error()}}
+		log.Error(err.Error())
+		return nil, err
+	}
+
+	return &humanUserAdministrator.DisableTOTPResponse{}, nil
+}
+
+// useTOTPCode uses up the given code accepted for the user with the given
+// id so that it cannot be used again, failing if it was used concurrently
+func (a *administrator) useTOTPCode(useClaims claims.Claims, userId string, code human.TOTPCode) error {
+	if _, err := a.humanUserRecordHandler.UpdateFields(&recordHandler.UpdateFieldsRequest{
+		Claims:   useClaims,
+		Criteria: code.UseCriteria(userId),
+		Update:   code.UseUpdate(),
+	}); err != nil {
+		switch err.(type) {
+		case exception.NotFound:
+			return errors.New("code already used")
+		default:
+			return err
+		}
+	}
+	return nil
+}
+
+// checkAttempt returns an error if password or two-factor code attempts
+// for the user with the given id are being delayed or locked out
+func (a *administrator) checkAttempt(userId id.Identifier) error {
+	checkAttemptResponse, err := a.lockoutAdministrator.CheckAttempt(&lockoutAdministrator.CheckAttemptRequest{
+		UserId: userId,
+	})
+	if err != nil {
+		return err
+	}
+	if !checkAttemptResponse.Allowed {
+		return fmt.Errorf(
+			"too many failed attempts, try again in %s",
+			(checkAttemptResponse.RetryAfter + time.Second - 1).Truncate(time.Second),
+		)
+	}
+	return nil
+}
+
+// recordAttempt records the outcome of a password or two-factor code attempt
+// for the user with the given id. Failure to record it is logged and does not change the outcome.
+func (a *administrator) recordAttempt(userId id.Identifier, successful bool) {
+	var err error
+	if successful {
+		_, err = a.lockoutAdministrator.RecordSuccess(&lockoutAdministrator.RecordSuccessRequest{
+			UserId: userId,
+		})
+	} else {
+		_, err = a.lockoutAdministrator.RecordFailure(&lockoutAdministrator.RecordFailureRequest{
+			UserId: userId,
+		})
+	}
+	if err != nil {
+		log.Error(err.Error())
+	}
+}
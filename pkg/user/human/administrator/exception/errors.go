@@ -105,3 +105,27 @@ type ForgotPassword struct {
 func (e ForgotPassword) Error() string {
 	return "error processing forgotten password: " + strings.Join(e.Reasons, "; ")
 }
+
+type EnrolTOTP struct {
+	Reasons []string
+}
+
+func (e EnrolTOTP) Error() string {
+	return "error enrolling in two-factor authentication: " + strings.Join(e.Reasons, "; ")
+}
+
+type ConfirmTOTPEnrolment struct {
+	Reasons []string
+}
+
+func (e ConfirmTOTPEnrolment) Error() string {
+	return "error confirming two-factor authentication enrolment: " + strings.Join(e.Reasons, "; ")
+}
+
+type DisableTOTP struct {
+	Reasons []string
+}
+
+func (e DisableTOTP) Error() string {
+	return "error disabling two-factor authentication: " + strings.Join(e.Reasons, "; ")
+}
@@ -247,3 +247,54 @@ func (a *administrator) ForgotPassword(request *administrator2.ForgotPasswordReq
 
 	return &administrator2.ForgotPasswordResponse{URLToken: forgotPasswordResponse.URLToken}, nil
 }
+
+func (a *administrator) EnrolTOTP(request *administrator2.EnrolTOTPRequest) (*administrator2.EnrolTOTPResponse, error) {
+	enrolTOTPResponse := jsonRpc.EnrolTOTPResponse{}
+	if err := a.jsonRpcClient.JsonRpcRequest(
+		administrator2.EnrolTOTPService,
+		jsonRpc.EnrolTOTPRequest{},
+		&enrolTOTPResponse,
+	); err != nil {
+		log.Error(err.Error())
+		return nil, err
+	}
+
+	return &administrator2.EnrolTOTPResponse{
+		Secret:          enrolTOTPResponse.Secret,
+		ProvisioningURI: enrolTOTPResponse.ProvisioningURI,
+	}, nil
+}
+
+func (a *administrator) ConfirmTOTPEnrolment(request *administrator2.ConfirmTOTPEnrolmentRequest) (*administrator2.ConfirmTOTPEnrolmentResponse, error) {
+	confirmTOTPEnrolmentResponse := jsonRpc.ConfirmTOTPEnrolmentResponse{}
+	if err := a.jsonRpcClient.JsonRpcRequest(
+		administrator2.ConfirmTOTPEnrolmentService,
+		jsonRpc.ConfirmTOTPEnrolmentRequest{
+			Code: request.Code,
+		},
+		&confirmTOTPEnrolmentResponse,
+	); err != nil {
+		log.Error(err.Error())
+		return nil, err
+	}
+
+	return &administrator2.ConfirmTOTPEnrolmentResponse{
+		RecoveryCodes: confirmTOTPEnrolmentResponse.RecoveryCodes,
+	}, nil
+}
+
+func (a *administrator) DisableTOTP(request *administrator2.DisableTOTPRequest) (*administrator2.DisableTOTPResponse, error) {
+	disableTOTPResponse := jsonRpc.DisableTOTPResponse{}
+	if err := a.jsonRpcClient.JsonRpcRequest(
+		administrator2.DisableTOTPService,
+		jsonRpc.DisableTOTPRequest{
+			Code: request.Code,
+		},
+		&disableTOTPResponse,
+	); err != nil {
+		log.Error(err.Error())
+		return nil, err
+	}
+
+	return &administrator2.DisableTOTPResponse{}, nil
+}
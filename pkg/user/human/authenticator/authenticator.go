@@ -3,37 +3,113 @@ package jsonRpcServerAuthenticator
 import (
 	"crypto/rsa"
 	"errors"
+	"fmt"
+	brainException "github.com/iot-my-world/brain/internal/exception"
+	"github.com/iot-my-world/brain/internal/log"
 	jsonRpcServerAuthenticator "github.com/iot-my-world/brain/pkg/api/jsonRpc/server/authenticator"
+	partyAdministrator "github.com/iot-my-world/brain/pkg/party/administrator"
+	"github.com/iot-my-world/brain/pkg/party/client"
+	"github.com/iot-my-world/brain/pkg/party/company"
 	"github.com/iot-my-world/brain/pkg/search/identifier/emailAddress"
 	"github.com/iot-my-world/brain/pkg/search/identifier/id"
 	"github.com/iot-my-world/brain/pkg/search/identifier/username"
+	"github.com/iot-my-world/brain/pkg/security/claims"
 	"github.com/iot-my-world/brain/pkg/security/claims/login/user/human"
+	verifyTOTPClaims "github.com/iot-my-world/brain/pkg/security/claims/verifyTOTP"
+	lockoutAdministrator "github.com/iot-my-world/brain/pkg/security/lockout/administrator"
 	securityToken "github.com/iot-my-world/brain/pkg/security/token"
+	refreshTokenAdministrator "github.com/iot-my-world/brain/pkg/security/token/refresh/administrator"
+	tokenRevocationAdministrator "github.com/iot-my-world/brain/pkg/security/token/revocation/administrator"
+	humanUser "github.com/iot-my-world/brain/pkg/user/human"
 	userRecordHandler "github.com/iot-my-world/brain/pkg/user/human/recordHandler"
 	userRecordHandlerException "github.com/iot-my-world/brain/pkg/user/human/recordHandler/exception"
 	"golang.org/x/crypto/bcrypt"
 	"time"
 )
 
+// loginTokenLifetime is kept short since
+// sessions are extended with refresh tokens
+const loginTokenLifetime = 15 * time.Minute
+
 type authenticator struct {
-	userRecordHandler userRecordHandler.RecordHandler
-	jwtGenerator      securityToken.JWTGenerator
-	systemClaims      *human.Login
+	userRecordHandler            userRecordHandler.RecordHandler
+	jwtGenerator                 securityToken.JWTGenerator
+	systemClaims                 *human.Login
+	tokenRevocationAdministrator tokenRevocationAdministrator.Administrator
+	partyAdministrator           partyAdministrator.Administrator
+	lockoutAdministrator         lockoutAdministrator.Administrator
+	refreshTokenAdministrator    refreshTokenAdministrator.Administrator
+	totpSecretKey                []byte
 }
 
 func New(
 	userRecordHandler userRecordHandler.RecordHandler,
 	rsaPrivateKey *rsa.PrivateKey,
 	systemClaims *human.Login,
+	tokenRevocationAdministrator tokenRevocationAdministrator.Administrator,
+	partyAdministrator partyAdministrator.Administrator,
+	lockoutAdministrator lockoutAdministrator.Administrator,
+	refreshTokenAdministrator refreshTokenAdministrator.Administrator,
+	totpSecretKey []byte,
 ) jsonRpcServerAuthenticator.Authenticator {
 	return &authenticator{
-		userRecordHandler: userRecordHandler,
-		jwtGenerator:      securityToken.NewJWTGenerator(rsaPrivateKey),
-		systemClaims:      systemClaims,
+		userRecordHandler:            userRecordHandler,
+		jwtGenerator:                 securityToken.NewJWTGenerator(rsaPrivateKey),
+		systemClaims:                 systemClaims,
+		tokenRevocationAdministrator: tokenRevocationAdministrator,
+		partyAdministrator:           partyAdministrator,
+		lockoutAdministrator:         lockoutAdministrator,
+		refreshTokenAdministrator:    refreshTokenAdministrator,
+		totpSecretKey:                totpSecretKey,
+	}
+}
+
+func (a *authenticator) ValidateLogoutRequest(request *jsonRpcServerAuthenticator.LogoutRequest) error {
+	reasonsInvalid := make([]string, 0)
+
+	if request.Claims == nil {
+		reasonsInvalid = append(reasonsInvalid, "claims are nil")
+	}
+
+	if request.TokenId == "" {
+		reasonsInvalid = append(reasonsInvalid, "token id is blank")
+	}
+
+	if len(reasonsInvalid) > 0 {
+		return brainException.RequestInvalid{Reasons: reasonsInvalid}
 	}
+	return nil
 }
 
 func (a *authenticator) Logout(request *jsonRpcServerAuthenticator.LogoutRequest) (*jsonRpcServerAuthenticator.LogoutResponse, error) {
+	if err := a.ValidateLogoutRequest(request); err != nil {
+		return nil, err
+	}
+
+	// revoke the token with which the user is logged in until it would have expired
+	expirationTime := int64(0)
+	if timeToExpiry := request.Claims.TimeToExpiry(); timeToExpiry >= 0 {
+		expirationTime = time.Now().Add(timeToExpiry).UTC().Unix()
+	}
+	if _, err := a.tokenRevocationAdministrator.RevokeToken(&tokenRevocationAdministrator.RevokeTokenRequest{
+		Claims:         request.Claims,
+		TokenId:        request.TokenId,
+		ExpirationTime: expirationTime,
+	}); err != nil {
+		log.Error(err.Error())
+		return nil, errors.New("log out failed")
+	}
+
+	// and revoke the refresh tokens of the session in which it was issued
+	if loginClaims, ok := request.Claims.(human.Login); ok && loginClaims.SessionId != "" {
+		if _, err := a.refreshTokenAdministrator.RevokeSession(&refreshTokenAdministrator.RevokeSessionRequest{
+			SessionId: loginClaims.SessionId,
+		}); err != nil {
+			log.Error(err.Error())
+			return nil, errors.New("log out failed")
+		}
+	}
+
 	return &jsonRpcServerAuthenticator.LogoutResponse{}, nil
 }
 
@@ -41,6 +117,11 @@ func (a *authenticator) Login(request *jsonRpcServerAuthenticator.LoginRequest)
 	var retrieveUserResponse *userRecordHandler.RetrieveResponse
 	var err error
 
+	// check that attempts from this ip address are not being delayed or locked out
+	if err := a.checkAttempt(id.Identifier{}, request.IPAddress, "log in failed"); err != nil {
+		return nil, err
+	}
+
 	//try and retrieve User record with username
 	retrieveUserResponse, err = a.userRecordHandler.Retrieve(&userRecordHandler.RetrieveRequest{
 		Claims:     *a.systemClaims,
@@ -55,6 +136,7 @@ func (a *authenticator) Login(request *jsonRpcServerAuthenticator.LoginRequest)
 				Identifier: emailAddress.Identifier{EmailAddress: request.UsernameOrEmailAddress},
 			})
 			if err != nil {
+				a.recordFailure(id.Identifier{}, request.IPAddress)
 				return nil, errors.New("log in failed")
 			}
 		default:
@@ -62,27 +144,340 @@ func (a *authenticator) Login(request *jsonRpcServerAuthenticator.LoginRequest)
 		}
 	}
 
+	// check that attempts for this user are not being delayed or locked out
+	userId := id.Identifier{Id: retrieveUserResponse.User.Id}
+	if err := a.checkAttempt(userId, "", "log In failed"); err != nil {
+		return nil, err
+	}
+
 	//User record retrieved successfully, check password
 	if err := bcrypt.CompareHashAndPassword(retrieveUserResponse.User.Password, []byte(request.Password)); err != nil {
 		//Password Incorrect
+		a.recordFailure(userId, request.IPAddress)
 		return nil, errors.New("log In failed")
 	}
 
-	// Password is correct. Try and generate loginToken
-	loginToken, err := a.jwtGenerator.GenerateToken(human.Login{
-		UserId:          id.Identifier{Id: retrieveUserResponse.User.Id},
-		IssueTime:       time.Now().UTC().Unix(),
-		ExpirationTime:  time.Now().Add(90 * time.Minute).UTC().Unix(),
-		ParentPartyType: retrieveUserResponse.User.ParentPartyType,
-		ParentId:        retrieveUserResponse.User.ParentId,
-		PartyType:       retrieveUserResponse.User.PartyType,
-		PartyId:         retrieveUserResponse.User.PartyId,
-	})
+	// Password is correct. If the user has two-factor authentication enabled
+	// then only a token with which the code can be verified is issued.
+	if retrieveUserResponse.User.TOTP.Enabled {
+		verifyTOTPToken, err := a.jwtGenerator.GenerateToken(verifyTOTPClaims.VerifyTOTP{
+			UserId:          userId,
+			IssueTime:       time.Now().UTC().Unix(),
+			ExpirationTime:  time.Now().Add(5 * time.Minute).UTC().Unix(),
+			ParentPartyType: retrieveUserResponse.User.ParentPartyType,
+			ParentId:        retrieveUserResponse.User.ParentId,
+			PartyType:       retrieveUserResponse.User.PartyType,
+			PartyId:         retrieveUserResponse.User.PartyId,
+		})
+		if err != nil {
+			//Unexpected Error!
+			return nil, errors.New("log In failed")
+		}
+		return &jsonRpcServerAuthenticator.LoginResponse{
+			Jwt:          verifyTOTPToken,
+			TOTPRequired: true,
+		}, nil
+	}
+
+	// otherwise check if the party of the user requires two-factor authentication
+	totpEnrolmentRequired, err := a.partyRequiresTOTP(retrieveUserResponse.User)
+	if err != nil {
+		log.Error(err.Error())
+		return nil, errors.New("log In failed")
+	}
+
+	// Try and generate loginToken in a new session
+	loginToken, refreshToken, err := a.startSession(retrieveUserResponse.User, totpEnrolmentRequired)
 	if err != nil {
 		//Unexpected Error!
+		log.Error(err.Error())
 		return nil, errors.New("log In failed")
 	}
 
+	a.recordSuccess(userId)
+
 	//Login Successful, return Token
-	return &jsonRpcServerAuthenticator.LoginResponse{Jwt: loginToken}, nil
+	return &jsonRpcServerAuthenticator.LoginResponse{
+		Jwt:          loginToken,
+		RefreshToken: refreshToken,
+	}, nil
+}
+
+func (a *authenticator) ValidateVerifyTOTPRequest(request *jsonRpcServerAuthenticator.VerifyTOTPRequest) error {
+	reasonsInvalid := make([]string, 0)
+
+	if request.Claims == nil {
+		reasonsInvalid = append(reasonsInvalid, "claims are nil")
+	} else if request.Claims.Type() != claims.VerifyTOTP {
+		reasonsInvalid = append(reasonsInvalid, "claims must be of type verify totp")
+	}
+
+	if request.TokenId == "" {
+		reasonsInvalid = append(reasonsInvalid, "token id is blank")
+	}
+
+	if request.Code == "" {
+		reasonsInvalid = append(reasonsInvalid, "code is blank")
+	}
+
+	if len(reasonsInvalid) > 0 {
+		return brainException.RequestInvalid{Reasons: reasonsInvalid}
+	}
+	return nil
+}
+
+// VerifyTOTP completes the log in of a user with two-factor authentication
+// enabled by exchanging a code from their authenticator app, or one of
+// their recovery codes, for login claims
+func (a *authenticator) VerifyTOTP(request *jsonRpcServerAuthenticator.VerifyTOTPRequest) (*jsonRpcServerAuthenticator.VerifyTOTPResponse, error) {
+	if err := a.ValidateVerifyTOTPRequest(request); err != nil {
+		return nil, err
+	}
+
+	typedClaims, ok := request.Claims.(verifyTOTPClaims.VerifyTOTP)
+	if !ok {
+		return nil, brainException.Unexpected{Reasons: []string{"inferring claims to type verify totp claims"}}
+	}
+
+	retrieveUserResponse, err := a.userRecordHandler.Retrieve(&userRecordHandler.RetrieveRequest{
+		Claims:     *a.systemClaims,
+		Identifier: typedClaims.UserId,
+	})
+	if err != nil {
+		log.Error(err.Error())
+		return nil, errors.New("verification failed")
+	}
+	if !retrieveUserResponse.User.TOTP.Enabled {
+		return nil, errors.New("verification failed")
+	}
+
+	// check that attempts for this user are not being delayed or locked out
+	if err := a.checkAttempt(typedClaims.UserId, request.IPAddress, "verification failed"); err != nil {
+		return nil, err
+	}
+
+	code, codeValid, err := retrieveUserResponse.User.TOTP.VerifyCode(a.totpSecretKey, request.Code, time.Now())
+	if err != nil {
+		log.Error(err.Error())
+		return nil, errors.New("verification failed")
+	}
+	if !codeValid {
+		a.recordFailure(typedClaims.UserId, request.IPAddress)
+		return nil, errors.New("verification failed")
+	}
+
+	// use up the code so that it cannot be used again
+	if _, err := a.userRecordHandler.UpdateFields(&userRecordHandler.UpdateFieldsRequest{
+		Claims:   *a.systemClaims,
+		Criteria: code.UseCriteria(retrieveUserResponse.User.Id),
+		Update:   code.UseUpdate(),
+	}); err != nil {
+		switch err.(type) {
+		case userRecordHandlerException.NotFound:
+			// the code was used concurrently
+			a.recordFailure(typedClaims.UserId, request.IPAddress)
+		default:
+			log.Error(err.Error())
+		}
+		return nil, errors.New("verification failed")
+	}
+
+	// revoke the verification token so that it cannot be used again
+	if _, err := a.tokenRevocationAdministrator.RevokeToken(&tokenRevocationAdministrator.RevokeTokenRequest{
+		Claims:         request.Claims,
+		TokenId:        request.TokenId,
+		ExpirationTime: typedClaims.ExpirationTime,
+	}); err != nil {
+		log.Error(err.Error())
+		return nil, errors.New("verification failed")
+	}
+
+	loginToken, refreshToken, err := a.startSession(retrieveUserResponse.User, false)
+	if err != nil {
+		log.Error(err.Error())
+		return nil, errors.New("verification failed")
+	}
+
+	a.recordSuccess(typedClaims.UserId)
+
+	return &jsonRpcServerAuthenticator.VerifyTOTPResponse{
+		Jwt:          loginToken,
+		RefreshToken: refreshToken,
+	}, nil
+}
+
+func (a *authenticator) ValidateRefreshRequest(request *jsonRpcServerAuthenticator.RefreshRequest) error {
+	reasonsInvalid := make([]string, 0)
+
+	if request.RefreshToken == "" {
+		reasonsInvalid = append(reasonsInvalid, "refresh token is blank")
+	}
+
+	if len(reasonsInvalid) > 0 {
+		return brainException.RequestInvalid{Reasons: reasonsInvalid}
+	}
+	return nil
+}
+
+// Refresh exchanges a refresh token for a new login token and refresh
+// token in the same session, extending the session
+func (a *authenticator) Refresh(request *jsonRpcServerAuthenticator.RefreshRequest) (*jsonRpcServerAuthenticator.RefreshResponse, error) {
+	if err := a.ValidateRefreshRequest(request); err != nil {
+		return nil, err
+	}
+
+	exchangeResponse, err := a.refreshTokenAdministrator.Exchange(&refreshTokenAdministrator.ExchangeRequest{
+		Token: request.RefreshToken,
+	})
+	if err != nil {
+		log.Error(err.Error())
+		return nil, errors.New("refresh failed")
+	}
+
+	// the session ends if all of the tokens of the user have since been revoked
+	tokenRevokedResponse, err := a.tokenRevocationAdministrator.TokenRevoked(&tokenRevocationAdministrator.TokenRevokedRequest{
+		SubjectId: exchangeResponse.ExchangedRefreshToken.UserId,
+		IssueTime: exchangeResponse.ExchangedRefreshToken.IssueTime,
+	})
+	if err != nil {
+		log.Error(err.Error())
+		return nil, errors.New("refresh failed")
+	}
+	if tokenRevokedResponse.Result {
+		if _, err := a.refreshTokenAdministrator.RevokeSession(&refreshTokenAdministrator.RevokeSessionRequest{
+			SessionId: exchangeResponse.RefreshToken.SessionId,
+		}); err != nil {
+			log.Error(err.Error())
+		}
+		return nil, errors.New("refresh failed")
+	}
+
+	retrieveUserResponse, err := a.userRecordHandler.Retrieve(&userRecordHandler.RetrieveRequest{
+		Claims:     *a.systemClaims,
+		Identifier: exchangeResponse.RefreshToken.UserId,
+	})
+	if err != nil {
+		log.Error(err.Error())
+		return nil, errors.New("refresh failed")
+	}
+
+	// two-factor authentication may have been made mandatory during the session
+	totpEnrolmentRequired := false
+	if !retrieveUserResponse.User.TOTP.Enabled {
+		totpEnrolmentRequired, err = a.partyRequiresTOTP(retrieveUserResponse.User)
+		if err != nil {
+			log.Error(err.Error())
+			return nil, errors.New("refresh failed")
+		}
+	}
+
+	loginToken, err := a.generateLoginToken(
+		retrieveUserResponse.User,
+		totpEnrolmentRequired,
+		exchangeResponse.RefreshToken.SessionId,
+	)
+	if err != nil {
+		log.Error(err.Error())
+		return nil, errors.New("refresh failed")
+	}
+
+	return &jsonRpcServerAuthenticator.RefreshResponse{
+		Jwt:          loginToken,
+		RefreshToken: exchangeResponse.Token,
+	}, nil
+}
+
+// startSession issues a refresh token for the given user in a new session
+// and returns it together with a login token issued in that session
+func (a *authenticator) startSession(user humanUser.User, totpEnrolmentRequired bool) (string, string, error) {
+	issueResponse, err := a.refreshTokenAdministrator.Issue(&refreshTokenAdministrator.IssueRequest{
+		UserId: id.Identifier{Id: user.Id},
+	})
+	if err != nil {
+		return "", "", err
+	}
+
+	loginToken, err := a.generateLoginToken(user, totpEnrolmentRequired, issueResponse.RefreshToken.SessionId)
+	if err != nil {
+		return "", "", err
+	}
+
+	return loginToken, issueResponse.Token, nil
+}
+
+// checkAttempt returns an error if attempts for the given user or from the
+// given ip address are being delayed or locked out. If this cannot be
+// determined an error with the given failure message is returned.
+func (a *authenticator) checkAttempt(userId id.Identifier, ipAddress, failureMessage string) error {
+	checkAttemptResponse, err := a.lockoutAdministrator.CheckAttempt(&lockoutAdministrator.CheckAttemptRequest{
+		UserId:    userId,
+		IPAddress: ipAddress,
+	})
+	if err != nil {
+		log.Error(err.Error())
+		return errors.New(failureMessage)
+	}
+	if !checkAttemptResponse.Allowed {
+		return fmt.Errorf(
+			"too many failed attempts, try again in %s",
+			(checkAttemptResponse.RetryAfter + time.Second - 1).Truncate(time.Second),
+		)
+	}
+	return nil
+}
+
+// recordFailure records a failed attempt for the given user from the given
+// ip address. Failure to record it is logged and does not change the outcome.
+func (a *authenticator) recordFailure(userId id.Identifier, ipAddress string) {
+	if _, err := a.lockoutAdministrator.RecordFailure(&lockoutAdministrator.RecordFailureRequest{
+		UserId:    userId,
+		IPAddress: ipAddress,
+	}); err != nil {
+		log.Error(err.Error())
+	}
+}
+
+// recordSuccess forgets the failed attempts for the given user
+func (a *authenticator) recordSuccess(userId id.Identifier) {
+	if _, err := a.lockoutAdministrator.RecordSuccess(&lockoutAdministrator.RecordSuccessRequest{
+		UserId: userId,
+	}); err != nil {
+		log.Error(err.Error())
+	}
+}
+
+func (a *authenticator) generateLoginToken(user humanUser.User, totpEnrolmentRequired bool, sessionId string) (string, error) {
+	return a.jwtGenerator.GenerateToken(human.Login{
+		UserId:                id.Identifier{Id: user.Id},
+		IssueTime:             time.Now().UTC().Unix(),
+		ExpirationTime:        time.Now().Add(loginTokenLifetime).UTC().Unix(),
+		ParentPartyType:       user.ParentPartyType,
+		ParentId:              user.ParentId,
+		PartyType:             user.PartyType,
+		PartyId:               user.PartyId,
+		TOTPEnrolmentRequired: totpEnrolmentRequired,
+		SessionId:             sessionId,
+	})
+}
+
+// partyRequiresTOTP returns true if the party of the given user
+// has made two-factor authentication mandatory for its users
+func (a *authenticator) partyRequiresTOTP(user humanUser.User) (bool, error) {
+	retrieveResponse, err := a.partyAdministrator.RetrieveParty(&partyAdministrator.RetrievePartyRequest{
+		Claims:     *a.systemClaims,
+		PartyType:  user.PartyType,
+		Identifier: user.PartyId,
+	})
+	if err != nil {
+		return false, err
+	}
+
+	switch typedParty := retrieveResponse.Party.(type) {
+	case company.Company:
+		return typedParty.TwoFactorRequired, nil
+	case client.Client:
+		return typedParty.TwoFactorRequired, nil
+	default:
+		return false, nil
+	}
 }
@@ -5,12 +5,14 @@ import (
 	"github.com/iot-my-world/brain/internal/log"
 	jsonRpcServerAuthoriser "github.com/iot-my-world/brain/pkg/api/jsonRpc/server/authoriser"
 	authoriserException "github.com/iot-my-world/brain/pkg/api/jsonRpc/server/authoriser/exception"
+	apiUserLoginClaims "github.com/iot-my-world/brain/pkg/security/claims/login/user/api"
 	humanUserLoginClaims "github.com/iot-my-world/brain/pkg/security/claims/login/user/human"
 	registerClientAdminUserClaims "github.com/iot-my-world/brain/pkg/security/claims/registerClientAdminUser"
 	registerClientUserClaims "github.com/iot-my-world/brain/pkg/security/claims/registerClientUser"
 	registerCompanyAdminUserClaims "github.com/iot-my-world/brain/pkg/security/claims/registerCompanyAdminUser"
 	registerCompanyUserClaims "github.com/iot-my-world/brain/pkg/security/claims/registerCompanyUser"
 	resetPasswordClaims "github.com/iot-my-world/brain/pkg/security/claims/resetPassword"
+	verifyTOTPClaims "github.com/iot-my-world/brain/pkg/security/claims/verifyTOTP"
 	wrappedClaims "github.com/iot-my-world/brain/pkg/security/claims/wrapped"
 	permissionAdministrator "github.com/iot-my-world/brain/pkg/security/permission/administrator"
 	apiPermissions "github.com/iot-my-world/brain/pkg/security/permission/api"
@@ -48,6 +50,17 @@ func (a *authoriser) AuthoriseServiceMethod(jwt string, jsonRpcMethod string) (w
 
 	switch typedClaims := unwrappedJWTClaims.(type) {
 	case humanUserLoginClaims.Login:
+		// if the user must still enrol in two-factor authentication
+		// then only the permissions required to do so are granted
+		if typedClaims.TOTPEnrolmentRequired {
+			for _, allowedPermission := range humanUserLoginClaims.TOTPEnrolmentAPIPermissions {
+				if allowedPermission == apiPermissions.Permission(jsonRpcMethod) {
+					return wrappedJWTClaims, nil
+				}
+			}
+			return wrappedClaims.Wrapped{}, authoriserException.NotAuthorised{Permission: apiPermissions.Permission(jsonRpcMethod)}
+		}
+
 		// if these are login claims we check in the normal way if the user has the
 		// required permission to check access the api
 		userHasPermissionResponse, err := a.permissionAdministrator.UserHasPermission(&permissionAdministrator.UserHasPermissionRequest{
@@ -64,6 +77,23 @@ func (a *authoriser) AuthoriseServiceMethod(jwt string, jsonRpcMethod string) (w
 		// user was authorised
 		return wrappedJWTClaims, nil
 
+	case apiUserLoginClaims.Login:
+		// api users are authorised in the same way as human users, by checking
+		// if the roles of the api user have the required permission
+		userHasPermissionResponse, err := a.permissionAdministrator.UserHasPermission(&permissionAdministrator.UserHasPermissionRequest{
+			Claims:         typedClaims,
+			UserIdentifier: typedClaims.UserId,
+			Permission:     apiPermissions.Permission(jsonRpcMethod),
+		})
+		if err != nil {
+			return wrappedClaims.Wrapped{}, brainException.Unexpected{Reasons: []string{"determining if api user has permission", err.Error()}}
+		}
+		if !userHasPermissionResponse.Result {
+			return wrappedClaims.Wrapped{}, authoriserException.NotAuthorised{Permission: apiPermissions.Permission(jsonRpcMethod)}
+		}
+		// api user was authorised
+		return wrappedJWTClaims, nil
+
 	case registerCompanyAdminUserClaims.RegisterCompanyAdminUser:
 		permissionForMethod := apiPermissions.Permission(jsonRpcMethod)
 		// check the permissions granted by the RegisterCompanyAdminUser claims to see if this
@@ -129,6 +159,19 @@ func (a *authoriser) AuthoriseServiceMethod(jwt string, jsonRpcMethod string) (w
 			}
 		}
 
+	case verifyTOTPClaims.VerifyTOTP:
+		permissionForMethod := apiPermissions.Permission(jsonRpcMethod)
+		// check the permissions granted by the VerifyTOTP claims to see if this
+		// method is allowed
+		for allowedPermIdx := range verifyTOTPClaims.GrantedAPIPermissions {
+			if verifyTOTPClaims.GrantedAPIPermissions[allowedPermIdx] == permissionForMethod {
+				return wrappedJWTClaims, nil
+			}
+			if allowedPermIdx == len(verifyTOTPClaims.GrantedAPIPermissions)-1 {
+				return wrappedClaims.Wrapped{}, authoriserException.NotAuthorised{Permission: apiPermissions.Permission(jsonRpcMethod)}
+			}
+		}
+
 	default:
 		return wrappedClaims.Wrapped{}, authoriserException.NotAuthorised{Permission: apiPermissions.Permission(jsonRpcMethod)}
 	}
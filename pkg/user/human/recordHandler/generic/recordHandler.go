@@ -112,3 +112,26 @@ func (r *RecordHandler) Collect(request *recordHandler.CollectRequest) (*recordH
 		Total:   collectResponse.Total,
 	}, nil
 }
+
+func (r *RecordHandler) UpdateFields(request *recordHandler.UpdateFieldsRequest) (*recordHandler.UpdateFieldsResponse, error) {
+	updatedUser := human.User{}
+	updateFieldsResponse := brainRecordHandler.UpdateFieldsResponse{
+		Entity: &updatedUser,
+	}
+	if err := r.recordHandler.UpdateFields(&brainRecordHandler.UpdateFieldsRequest{
+		Claims:   request.Claims,
+		Criteria: request.Criteria,
+		Update:   request.Update,
+	}, &updateFieldsResponse); err != nil {
+		switch err.(type) {
+		case brainRecordHandlerException.NotFound:
+			return nil, exception.NotFound{}
+		default:
+			return nil, exception.Update{Reasons: []string{err.Error()}}
+		}
+	}
+
+	return &recordHandler.UpdateFieldsResponse{
+		User: updatedUser,
+	}, nil
+}
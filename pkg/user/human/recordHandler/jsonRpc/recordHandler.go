@@ -125,3 +125,7 @@ func (r *recordHandler) Update(request *recordHandler2.UpdateRequest) (*recordHa
 func (r *recordHandler) Delete(request *recordHandler2.DeleteRequest) (*recordHandler2.DeleteResponse, error) {
 	return nil, brainException.NotImplemented{}
 }
+
+func (r *recordHandler) UpdateFields(request *recordHandler2.UpdateFieldsRequest) (*recordHandler2.UpdateFieldsResponse, error) {
+	return nil, brainException.NotImplemented{}
+}
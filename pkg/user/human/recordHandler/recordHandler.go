@@ -1,6 +1,7 @@
 package recordHandler
 
 import (
+	brainRecordHandler "github.com/iot-my-world/brain/pkg/recordHandler"
 	"github.com/iot-my-world/brain/pkg/search/criterion"
 	"github.com/iot-my-world/brain/pkg/search/identifier"
 	"github.com/iot-my-world/brain/pkg/search/query"
@@ -15,6 +16,7 @@ type RecordHandler interface {
 	Update(request *UpdateRequest) (*UpdateResponse, error)
 	Delete(request *DeleteRequest) (*DeleteResponse, error)
 	Collect(request *CollectRequest) (*CollectResponse, error)
+	UpdateFields(request *UpdateFieldsRequest) (*UpdateFieldsResponse, error)
 }
 
 const ServiceProvider = "HumanUser-RecordHandler"
@@ -86,3 +88,15 @@ type CollectResponse struct {
 	Records []human.User
 	Total   int
 }
+
+// UpdateFieldsRequest atomically updates the fields of the
+// first user matching the criteria
+type UpdateFieldsRequest struct {
+	Claims   claims.Claims
+	Criteria []criterion.Criterion
+	Update   brainRecordHandler.FieldsUpdate
+}
+
+type UpdateFieldsResponse struct {
+	User human.User
+}
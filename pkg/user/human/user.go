@@ -2,7 +2,15 @@ package human
 
 import (
 	"github.com/iot-my-world/brain/pkg/party"
+	brainRecordHandler "github.com/iot-my-world/brain/pkg/recordHandler"
+	"github.com/iot-my-world/brain/pkg/search/criterion"
+	exactTextCriterion "github.com/iot-my-world/brain/pkg/search/criterion/exact/text"
+	numberRangeCriterion "github.com/iot-my-world/brain/pkg/search/criterion/range/number"
 	"github.com/iot-my-world/brain/pkg/search/identifier/id"
+	"github.com/iot-my-world/brain/pkg/security/totp"
+	"golang.org/x/crypto/bcrypt"
+	"strings"
+	"time"
 )
 
 // Defines the User record for the database
@@ -19,6 +27,11 @@ type User struct {
 	Password     []byte   `json:"password" bson:"password"`
 	Roles        []string `json:"roles" bson:"roles"`
 
+	// CustomRoles are the ids of the roles defined by the party of
+	// the user which have been assigned to the user. Roles gives the
+	// names of the system roles of the user.
+	CustomRoles []id.Identifier `json:"customRoles" bson:"customRoles"`
+
 	// Party Details
 	ParentPartyType party.Type    `json:"parentPartyType" bson:"parentPartyType"`
 	ParentId        id.Identifier `json:"parentId" bson:"parentId"`
@@ -26,8 +39,105 @@ type User struct {
 	PartyId         id.Identifier `json:"partyId" bson:"partyId"`
 
 	Registered bool `json:"registered" bson:"registered"`
+
+	// Two-Factor Authentication Details
+	TOTP TOTP `json:"totp" bson:"totp"`
+}
+
+// TOTP holds the time-based one-time password details of a user.
+// The secret and recovery codes are never marshalled to json.
+type TOTP struct {
+	Enabled bool `json:"enabled" bson:"enabled"`
+
+	// Secret shared with the authenticator app of the user, encrypted
+	// with the totp secret key. It is set on enrolment and the user
+	// is only enabled once it is confirmed.
+	Secret string `json:"-" bson:"secret"`
+
+	// RecoveryCodes are bcrypt hashes of the unused recovery codes
+	RecoveryCodes []string `json:"-" bson:"recoveryCodes"`
+
+	// LastUsedStep is the time step of the last code
+	// accepted, so that a code cannot be used twice
+	LastUsedStep int64 `json:"-" bson:"lastUsedStep"`
 }
 
 func (u *User) SetId(id string) {
 	u.Id = id
 }
+
+// TOTPCode is a code accepted by VerifyCode
+type TOTPCode struct {
+	// Step is the time step of a code from the authenticator app
+	Step int64
+
+	// RecoveryCode is the hash of a recovery code
+	RecoveryCode string
+}
+
+// VerifyCode checks the given code from the authenticator app of the user,
+// or one of their recovery codes, at the given time. The secret is decrypted
+// with the given key. A code which is accepted must be used up with
+// UseCriteria and UseUpdate before it is acted on.
+func (t TOTP) VerifyCode(secretKey []byte, code string, at time.Time) (TOTPCode, bool, error) {
+	secret, err := totp.DecryptSecret(secretKey, t.Secret)
+	if err != nil {
+		return TOTPCode{}, false, err
+	}
+
+	valid, step, err := totp.Validate(secret, code, at)
+	if err != nil {
+		return TOTPCode{}, false, err
+	}
+	if valid {
+		if step <= t.LastUsedStep {
+			// code has already been used
+			return TOTPCode{}, false, nil
+		}
+		return TOTPCode{Step: step}, true, nil
+	}
+
+	recoveryCode := strings.ToLower(strings.TrimSpace(code))
+	for _, recoveryCodeHash := range t.RecoveryCodes {
+		if err := bcrypt.CompareHashAndPassword([]byte(recoveryCodeHash), []byte(recoveryCode)); err == nil {
+			return TOTPCode{RecoveryCode: recoveryCodeHash}, true, nil
+		}
+	}
+
+	return TOTPCode{}, false, nil
+}
+
+// UseCriteria returns the criteria matching the user with the given id
+// only while the code is unused, so that a code used concurrently is
+// only accepted once
+func (c TOTPCode) UseCriteria(userId string) []criterion.Criterion {
+	useCriteria := []criterion.Criterion{
+		exactTextCriterion.Criterion{
+			Field: "id",
+			Text:  userId,
+		},
+	}
+	if c.RecoveryCode != "" {
+		return append(useCriteria, exactTextCriterion.Criterion{
+			Field: "totp.recoveryCodes",
+			Text:  c.RecoveryCode,
+		})
+	}
+	return append(useCriteria, numberRangeCriterion.Criterion{
+		Field: "totp.lastUsedStep",
+		Start: numberRangeCriterion.RangeValue{Ignore: true},
+		End:   numberRangeCriterion.RangeValue{Number: float64(c.Step)},
+	})
+}
+
+// UseUpdate returns the update with which the code is used up
+func (c TOTPCode) UseUpdate() brainRecordHandler.FieldsUpdate {
+	if c.RecoveryCode != "" {
+		return brainRecordHandler.FieldsUpdate{
+			Pull: map[string]interface{}{"totp.recoveryCodes": c.RecoveryCode},
+		}
+	}
+	return brainRecordHandler.FieldsUpdate{
+		Set: map[string]interface{}{"totp.lastUsedStep": c.Step},
+	}
+}
@@ -0,0 +1,49 @@
+package delivery
+
+import (
+	"github.com/iot-my-world/brain/pkg/party"
+	"github.com/iot-my-world/brain/pkg/search/identifier/id"
+	webhookEvent "github.com/iot-my-world/brain/pkg/webhook/event"
+)
+
+type Status string
+
+const Pending Status = "Pending"
+const Delivered Status = "Delivered"
+const Failed Status = "Failed"
+
+// Delivery is the history of the attempts made to post an
+// event to the url of a webhook subscription
+type Delivery struct {
+	Id string `json:"id" bson:"id"`
+
+	// Owner Details, taken from the subscription
+	OwnerPartyType party.Type    `json:"ownerPartyType" bson:"ownerPartyType"`
+	OwnerId        id.Identifier `json:"ownerId" bson:"ownerId"`
+
+	// Delivery Details
+	SubscriptionId id.Identifier     `json:"subscriptionId" bson:"subscriptionId"`
+	EventId        string            `json:"eventId" bson:"eventId"`
+	EventType      webhookEvent.Type `json:"eventType" bson:"eventType"`
+	DeviceId       id.Identifier     `json:"deviceId" bson:"deviceId"`
+	URL            string            `json:"url" bson:"url"`
+	Payload        string            `json:"payload" bson:"payload"`
+	CreatedTime    int64             `json:"createdTime" bson:"createdTime"`
+
+	// Outcome of the attempts
+	Status          Status   `json:"status" bson:"status"`
+	Attempts        int      `json:"attempts" bson:"attempts"`
+	Errors          []string `json:"errors" bson:"errors"`
+	ResponseStatus  int      `json:"responseStatus" bson:"responseStatus"`
+	LastAttemptTime int64    `json:"lastAttemptTime" bson:"lastAttemptTime"`
+	DeliveredTime   int64    `json:"deliveredTime" bson:"deliveredTime"`
+
+	// NextAttemptTime is when a pending delivery is next due to be
+	// attempted. It is pushed forward while the delivery is claimed
+	// by a publisher so that it is not attempted twice at once.
+	NextAttemptTime int64 `json:"nextAttemptTime" bson:"nextAttemptTime"`
+}
+
+func (d *Delivery) SetId(id string) {
+	d.Id = id
+}
@@ -0,0 +1,157 @@
+package webhookDeliveryRecordHandler
+
+import (
+	brainException "github.com/iot-my-world/brain/internal/exception"
+	brainRecordHandler "github.com/iot-my-world/brain/pkg/recordHandler"
+	brainRecordHandlerException "github.com/iot-my-world/brain/pkg/recordHandler/exception"
+	"github.com/iot-my-world/brain/pkg/webhook/delivery"
+	webhookDeliveryRecordHandler "github.com/iot-my-world/brain/pkg/webhook/delivery/recordHandler"
+	webhookDeliveryRecordHandlerException "github.com/iot-my-world/brain/pkg/webhook/delivery/recordHandler/exception"
+)
+
+type RecordHandler struct {
+	webhookDeliveryRecordHandler brainRecordHandler.RecordHandler
+}
+
+func New(
+	brainDeliveryRecordHandler brainRecordHandler.RecordHandler,
+) webhookDeliveryRecordHandler.RecordHandler {
+
+	return &RecordHandler{
+		webhookDeliveryRecordHandler: brainDeliveryRecordHandler,
+	}
+}
+
+type CreateRequest struct {
+	Delivery delivery.Delivery
+}
+
+type CreateResponse struct {
+	Delivery delivery.Delivery
+}
+
+func (r *RecordHandler) ValidateCreateRequest(request *webhookDeliveryRecordHandler.CreateRequest) error {
+	reasonsInvalid := make([]string, 0)
+
+	if len(reasonsInvalid) > 0 {
+		return brainException.RequestInvalid{Reasons: reasonsInvalid}
+	}
+
+	return nil
+}
+
+func (r *RecordHandler) Create(request *webhookDeliveryRecordHandler.CreateRequest) (*webhookDeliveryRecordHandler.CreateResponse, error) {
+	if err := r.ValidateCreateRequest(request); err != nil {
+		return nil, err
+	}
+
+	createResponse := brainRecordHandler.CreateResponse{}
+	if err := r.webhookDeliveryRecordHandler.Create(&brainRecordHandler.CreateRequest{
+		Entity: &request.Delivery,
+	}, &createResponse); err != nil {
+		return nil, webhookDeliveryRecordHandlerException.Create{Reasons: []string{err.Error()}}
+	}
+	createdDelivery, ok := createResponse.Entity.(*delivery.Delivery)
+	if !ok {
+		return nil, webhookDeliveryRecordHandlerException.Create{Reasons: []string{"could not cast created entity to webhook delivery"}}
+	}
+
+	return &webhookDeliveryRecordHandler.CreateResponse{
+		Delivery: *createdDelivery,
+	}, nil
+}
+
+func (r *RecordHandler) Retrieve(request *webhookDeliveryRecordHandler.RetrieveRequest) (*webhookDeliveryRecordHandler.RetrieveResponse, error) {
+	retrievedDelivery := delivery.Delivery{}
+	retrieveResponse := brainRecordHandler.RetrieveResponse{
+		Entity: &retrievedDelivery,
+	}
+	if err := r.webhookDeliveryRecordHandler.Retrieve(&brainRecordHandler.RetrieveRequest{
+		Claims:     request.Claims,
+		Identifier: request.Identifier,
+	}, &retrieveResponse); err != nil {
+		switch err.(type) {
+		case brainRecordHandlerException.NotFound:
+			return nil, webhookDeliveryRecordHandlerException.NotFound{}
+		default:
+			return nil, err
+		}
+	}
+
+	return &webhookDeliveryRecordHandler.RetrieveResponse{
+		Delivery: retrievedDelivery,
+	}, nil
+}
+
+func (r *RecordHandler) Update(request *webhookDeliveryRecordHandler.UpdateRequest) (*webhookDeliveryRecordHandler.UpdateResponse, error) {
+	updateResponse := brainRecordHandler.UpdateResponse{}
+	if err := r.webhookDeliveryRecordHandler.Update(&brainRecordHandler.UpdateRequest{
+		Claims:     request.Claims,
+		Identifier: request.Identifier,
+		Entity:     &request.Delivery,
+	}, &updateResponse); err != nil {
+		return nil, webhookDeliveryRecordHandlerException.Update{Reasons: []string{err.Error()}}
+	}
+
+	return &webhookDeliveryRecordHandler.UpdateResponse{}, nil
+}
+
+func (r *RecordHandler) Delete(request *webhookDeliveryRecordHandler.DeleteRequest) (*webhookDeliveryRecordHandler.DeleteResponse, error) {
+	deleteResponse := brainRecordHandler.DeleteResponse{}
+	if err := r.webhookDeliveryRecordHandler.Delete(&brainRecordHandler.DeleteRequest{
+		Claims:     request.Claims,
+		Identifier: request.Identifier,
+	}, &deleteResponse); err != nil {
+		return nil, webhookDeliveryRecordHandlerException.Delete{Reasons: []string{err.Error()}}
+	}
+
+	return &webhookDeliveryRecordHandler.DeleteResponse{}, nil
+}
+
+func (r *RecordHandler) Collect(request *webhookDeliveryRecordHandler.CollectRequest) (*webhookDeliveryRecordHandler.CollectResponse, error) {
+	var collectedDelivery []delivery.Delivery
+	collectResponse := brainRecordHandler.CollectResponse{
+		Records: &collectedDelivery,
+	}
+	err := r.webhookDeliveryRecordHandler.Collect(&brainRecordHandler.CollectRequest{
+		Claims:   request.Claims,
+		Criteria: request.Criteria,
+		Query:    request.Query,
+	}, &collectResponse)
+	if err != nil {
+		return nil, webhookDeliveryRecordHandlerException.Collect{Reasons: []string{err.Error()}}
+	}
+
+	if collectedDelivery == nil {
+		collectedDelivery = make([]delivery.Delivery, 0)
+	}
+
+	return &webhookDeliveryRecordHandler.CollectResponse{
+		Records: collectedDelivery,
+		Total:   collectResponse.Total,
+	}, nil
+}
+
+func (r *RecordHandler) UpdateFields(request *webhookDeliveryRecordHandler.UpdateFieldsRequest) (*webhookDeliveryRecordHandler.UpdateFieldsResponse, error) {
+	updatedDelivery := delivery.Delivery{}
+	updateFieldsResponse := brainRecordHandler.UpdateFieldsResponse{
+		Entity: &updatedDelivery,
+	}
+	if err := r.webhookDeliveryRecordHandler.UpdateFields(&brainRecordHandler.UpdateFieldsRequest{
+		Claims:   request.Claims,
+		Criteria: request.Criteria,
+		Query:    request.Query,
+		Update:   request.Update,
+	}, &updateFieldsResponse); err != nil {
+		switch err.(type) {
+		case brainRecordHandlerException.NotFound:
+			return nil, webhookDeliveryRecordHandlerException.NotFound{}
+		default:
+			return nil, webhookDeliveryRecordHandlerException.Update{Reasons: []string{err.Error()}}
+		}
+	}
+
+	return &webhookDeliveryRecordHandler.UpdateFieldsResponse{
+		Delivery: updatedDelivery,
+	}, nil
+}
@@ -0,0 +1,40 @@
+package mongo
+
+import (
+	brainMongoRecordHandler "github.com/iot-my-world/brain/pkg/recordHandler/mongo"
+	"github.com/iot-my-world/brain/pkg/security/claims"
+	"github.com/iot-my-world/brain/pkg/webhook/delivery"
+	webhookDeliveryRecordHandler "github.com/iot-my-world/brain/pkg/webhook/delivery/recordHandler"
+	webhookDeliveryGenericRecordHandler "github.com/iot-my-world/brain/pkg/webhook/delivery/recordHandler/generic"
+	"gopkg.in/mgo.v2"
+)
+
+func New(
+	mongoSession *mgo.Session,
+	databaseName string,
+	collectionName string,
+) webhookDeliveryRecordHandler.RecordHandler {
+	mongoRecordHandler := brainMongoRecordHandler.New(
+		mongoSession,
+		databaseName,
+		collectionName,
+		[]mgo.Index{
+			{
+				Key:    []string{"id"},
+				Unique: true,
+			},
+			{
+				Key: []string{"subscriptionId.id", "createdTime"},
+			},
+			{
+				Key: []string{"status", "nextAttemptTime"},
+			},
+		},
+		delivery.IsValidIdentifier,
+		claims.ContextualiseFilter,
+	)
+
+	return webhookDeliveryGenericRecordHandler.New(
+		mongoRecordHandler,
+	)
+}
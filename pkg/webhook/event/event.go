@@ -0,0 +1,39 @@
+package event
+
+import (
+	"encoding/json"
+	"github.com/iot-my-world/brain/pkg/search/identifier/id"
+)
+
+type Type string
+
+// NewReading is published when a reading from a device is stored
+const NewReading Type = "NewReading"
+
+// DeviceAssigned is published when a device is assigned to a party
+const DeviceAssigned Type = "DeviceAssigned"
+
+// DeviceOffline is published when a device has been silent for longer than its threshold
+const DeviceOffline Type = "DeviceOffline"
+
+// Event is the body of the request made to the url of a webhook subscription
+type Event struct {
+	Id        string          `json:"id"`
+	Type      Type            `json:"type"`
+	TimeStamp int64           `json:"timeStamp"`
+	DeviceId  id.Identifier   `json:"deviceId"`
+	Data      json.RawMessage `json:"data"`
+}
+
+type ReadingType string
+
+const GPSReading ReadingType = "GPS"
+const BatteryReading ReadingType = "Battery"
+const TemperatureReading ReadingType = "Temperature"
+const HeartbeatReading ReadingType = "Heartbeat"
+
+// ReadingData is the data of a NewReading event
+type ReadingData struct {
+	ReadingType ReadingType `json:"readingType"`
+	Reading     interface{} `json:"reading"`
+}
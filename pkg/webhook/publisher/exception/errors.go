@@ -0,0 +1,27 @@
+package exception
+
+import "strings"
+
+type DataMarshalling struct {
+	Reasons []string
+}
+
+func (e DataMarshalling) Error() string {
+	return "error marshalling webhook event data: " + strings.Join(e.Reasons, "; ")
+}
+
+type SubscriptionCollection struct {
+	Reasons []string
+}
+
+func (e SubscriptionCollection) Error() string {
+	return "error collecting webhook subscriptions: " + strings.Join(e.Reasons, "; ")
+}
+
+type DeliveryCreation struct {
+	Reasons []string
+}
+
+func (e DeliveryCreation) Error() string {
+	return "error creating webhook delivery: " + strings.Join(e.Reasons, "; ")
+}
@@ -0,0 +1,436 @@
+package pool
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	brainException "github.com/iot-my-world/brain/internal/exception"
+	"github.com/iot-my-world/brain/internal/log"
+	"github.com/iot-my-world/brain/internal/publicAddress"
+	brainRecordHandler "github.com/iot-my-world/brain/pkg/recordHandler"
+	"github.com/iot-my-world/brain/pkg/search/criterion"
+	exactTextCriterion "github.com/iot-my-world/brain/pkg/search/criterion/exact/text"
+	numberRangeCriterion "github.com/iot-my-world/brain/pkg/search/criterion/range/number"
+	"github.com/iot-my-world/brain/pkg/search/identifier/id"
+	"github.com/iot-my-world/brain/pkg/search/query"
+	humanUserLoginClaims "github.com/iot-my-world/brain/pkg/security/claims/login/user/human"
+	"github.com/iot-my-world/brain/pkg/webhook/delivery"
+	webhookDeliveryRecordHandler "github.com/iot-my-world/brain/pkg/webhook/delivery/recordHandler"
+	webhookDeliveryRecordHandlerException "github.com/iot-my-world/brain/pkg/webhook/delivery/recordHandler/exception"
+	webhookEvent "github.com/iot-my-world/brain/pkg/webhook/event"
+	webhookPublisher "github.com/iot-my-world/brain/pkg/webhook/publisher"
+	webhookPublisherException "github.com/iot-my-world/brain/pkg/webhook/publisher/exception"
+	webhookSubscriptionRecordHandler "github.com/iot-my-world/brain/pkg/webhook/subscription/recordHandler"
+	webhookSubscriptionRecordHandlerException "github.com/iot-my-world/brain/pkg/webhook/subscription/recordHandler/exception"
+	"github.com/satori/go.uuid"
+	"net/http"
+	"time"
+)
+
+// jobQueueSize is the number of jobs which can be waiting for a worker.
+// Deliveries for which there is no room are left pending in the store
+// to be resumed once there is.
+const jobQueueSize = 1000
+
+// resumePeriod is how often pending deliveries which are due
+// are taken from the store and given to the workers
+const resumePeriod = 10 * time.Second
+
+// claimLease is how long a pending delivery taken from the store is
+// claimed by the publisher. If the delivery is not attempted in this
+// time, e.g. because the process stopped, it is taken again.
+const claimLease = 5 * time.Minute
+
+// job is the delivery of an event to a single subscription
+type job struct {
+	delivery delivery.Delivery
+	secret   string
+}
+
+// publisher gives deliveries to a pool of workers which post them.
+// Failed attempts are retried with exponential backoff until the maximum
+// number of attempts is reached, after which the delivery is marked failed.
+// Retries are scheduled in the store and so survive a restart.
+type publisher struct {
+	webhookSubscriptionRecordHandler webhookSubscriptionRecordHandler.RecordHandler
+	webhookDeliveryRecordHandler     webhookDeliveryRecordHandler.RecordHandler
+	httpClient                       *http.Client
+	jobs                             chan *job
+	maxAttempts                      int
+	retryBackoff                     time.Duration
+	systemClaims                     *humanUserLoginClaims.Login
+}
+
+func New(
+	webhookSubscriptionRecordHandler webhookSubscriptionRecordHandler.RecordHandler,
+	webhookDeliveryRecordHandler webhookDeliveryRecordHandler.RecordHandler,
+	workers int,
+	maxAttempts int,
+	retryBackoff time.Duration,
+	requestTimeout time.Duration,
+	systemClaims *humanUserLoginClaims.Login,
+) webhookPublisher.Publisher {
+	p := &publisher{
+		webhookSubscriptionRecordHandler: webhookSubscriptionRecordHandler,
+		webhookDeliveryRecordHandler:     webhookDeliveryRecordHandler,
+		httpClient:                       publicAddress.NewHTTPClient(requestTimeout),
+		jobs:                             make(chan *job, jobQueueSize),
+		maxAttempts:                      maxAttempts,
+		retryBackoff:                     retryBackoff,
+		systemClaims:                     systemClaims,
+	}
+
+	// start workers
+	for i := 0; i < workers; i++ {
+		go p.work()
+	}
+
+	// resume the deliveries left pending, including on startup
+	go p.resume()
+
+	return p
+}
+
+func (p *publisher) ValidatePublishRequest(request *webhookPublisher.PublishRequest) error {
+	reasonsInvalid := make([]string, 0)
+
+	if request.Claims == nil {
+		reasonsInvalid = append(reasonsInvalid, "claims are nil")
+	}
+
+	if request.EventType == "" {
+		reasonsInvalid = append(reasonsInvalid, "event type is blank")
+	}
+
+	if request.Device.Id == "" {
+		reasonsInvalid = append(reasonsInvalid, "device id is blank")
+	}
+
+	if len(reasonsInvalid) > 0 {
+		return brainException.RequestInvalid{Reasons: reasonsInvalid}
+	}
+	return nil
+}
+
+func (p *publisher) Publish(request *webhookPublisher.PublishRequest) (*webhookPublisher.PublishResponse, error) {
+	if err := p.ValidatePublishRequest(request); err != nil {
+		log.Error(err.Error())
+		return nil, err
+	}
+
+	// build the event which is posted to every subscription
+	data, err := json.Marshal(request.Data)
+	if err != nil {
+		err = webhookPublisherException.DataMarshalling{Reasons: []string{err.Error()}}
+		log.Error(err.Error())
+		return nil, err
+	}
+	eventId, err := uuid.NewV4()
+	if err != nil {
+		err = brainException.UUIDGeneration{Reasons: []string{"event id", err.Error()}}
+		log.Error(err.Error())
+		return nil, err
+	}
+	payload, err := json.Marshal(webhookEvent.Event{
+		Id:        eventId.String(),
+		Type:      request.EventType,
+		TimeStamp: request.TimeStamp,
+		DeviceId:  id.Identifier{Id: request.Device.Id},
+		Data:      data,
+	})
+	if err != nil {
+		err = webhookPublisherException.DataMarshalling{Reasons: []string{err.Error()}}
+		log.Error(err.Error())
+		return nil, err
+	}
+
+	// events are only published to the parties which own the device or to which it is assigned
+	partyIds := make([]id.Identifier, 0)
+	if request.Device.OwnerId.Id != "" {
+		partyIds = append(partyIds, request.Device.OwnerId)
+	}
+	if request.Device.AssignedId.Id != "" && request.Device.AssignedId.Id != request.Device.OwnerId.Id {
+		partyIds = append(partyIds, request.Device.AssignedId)
+	}
+
+	deliveries := make([]delivery.Delivery, 0)
+	for _, partyId := range partyIds {
+		subscriptionCollectResponse, err := p.webhookSubscriptionRecordHandler.Collect(&webhookSubscriptionRecordHandler.CollectRequest{
+			Claims: p.systemClaims,
+			Criteria: []criterion.Criterion{
+				exactTextCriterion.Criterion{
+					Field: "ownerId.id",
+					Text:  partyId.Id,
+				},
+				exactTextCriterion.Criterion{
+					Field: "eventTypes",
+					Text:  string(request.EventType),
+				},
+			},
+		})
+		if err != nil {
+			err = webhookPublisherException.SubscriptionCollection{Reasons: []string{err.Error()}}
+			log.Error(err.Error())
+			return nil, err
+		}
+
+		for _, subscriptionToPublishTo := range subscriptionCollectResponse.Records {
+			if !subscriptionToPublishTo.Enabled {
+				continue
+			}
+
+			createResponse, err := p.webhookDeliveryRecordHandler.Create(&webhookDeliveryRecordHandler.CreateRequest{
+				Delivery: delivery.Delivery{
+					OwnerPartyType: subscriptionToPublishTo.OwnerPartyType,
+					OwnerId:        subscriptionToPublishTo.OwnerId,
+					SubscriptionId: id.Identifier{Id: subscriptionToPublishTo.Id},
+					EventId:        eventId.String(),
+					EventType:      request.EventType,
+					DeviceId:       id.Identifier{Id: request.Device.Id},
+					URL:            subscriptionToPublishTo.URL,
+					Payload:        string(payload),
+					CreatedTime:    time.Now().UTC().Unix(),
+					Status:         delivery.Pending,
+					Errors:         make([]string, 0),
+					// claimed so that it is not resumed while queued
+					NextAttemptTime: time.Now().UTC().Add(claimLease).Unix(),
+				},
+			})
+			if err != nil {
+				err = webhookPublisherException.DeliveryCreation{Reasons: []string{err.Error()}}
+				log.Error(err.Error())
+				return nil, err
+			}
+
+			p.enqueue(&job{
+				delivery: createResponse.Delivery,
+				secret:   subscriptionToPublishTo.Secret,
+			})
+			deliveries = append(deliveries, createResponse.Delivery)
+		}
+	}
+
+	return &webhookPublisher.PublishResponse{
+		Deliveries: deliveries,
+	}, nil
+}
+
+// enqueue gives the job to the workers without blocking. If the queue is
+// full the claim on the delivery is released so that it is resumed from the
+// store once there is room. Returns true if the job was queued.
+func (p *publisher) enqueue(j *job) bool {
+	select {
+	case p.jobs <- j:
+		return true
+	default:
+	}
+
+	log.Warn(fmt.Sprintf("webhook job queue full, delivery %s left pending", j.delivery.Id))
+	if _, err := p.webhookDeliveryRecordHandler.UpdateFields(&webhookDeliveryRecordHandler.UpdateFieldsRequest{
+		Claims: p.systemClaims,
+		Criteria: []criterion.Criterion{
+			exactTextCriterion.Criterion{
+				Field: "id",
+				Text:  j.delivery.Id,
+			},
+		},
+		Update: brainRecordHandler.FieldsUpdate{
+			Set: map[string]interface{}{
+				"nextAttemptTime": time.Now().UTC().Unix(),
+			},
+		},
+	}); err != nil {
+		log.Error("error releasing webhook delivery: " + err.Error())
+	}
+	return false
+}
+
+// resume gives pending deliveries which are due to the workers, once
+// on startup and then periodically
+func (p *publisher) resume() {
+	ticker := time.NewTicker(resumePeriod)
+	defer ticker.Stop()
+	for {
+		p.resumeDue()
+		<-ticker.C
+	}
+}
+
+// resumeDue claims pending deliveries which are due, oldest first,
+// and queues them while there is room in the queue
+func (p *publisher) resumeDue() {
+	for len(p.jobs) < cap(p.jobs) {
+		now := time.Now().UTC()
+		updateFieldsResponse, err := p.webhookDeliveryRecordHandler.UpdateFields(&webhookDeliveryRecordHandler.UpdateFieldsRequest{
+			Claims: p.systemClaims,
+			Criteria: []criterion.Criterion{
+				exactTextCriterion.Criterion{
+					Field: "status",
+					Text:  string(delivery.Pending),
+				},
+				numberRangeCriterion.Criterion{
+					Field: "nextAttemptTime",
+					Start: numberRangeCriterion.RangeValue{Ignore: true},
+					End: numberRangeCriterion.RangeValue{
+						Number:    float64(now.Unix()),
+						Inclusive: true,
+					},
+				},
+			},
+			Query: query.Query{
+				Order:  []query.SortOrder{query.SortOrderAscending, query.SortOrderAscending},
+				SortBy: []string{"nextAttemptTime", "id"},
+			},
+			Update: brainRecordHandler.FieldsUpdate{
+				Set: map[string]interface{}{
+					"nextAttemptTime": now.Add(claimLease).Unix(),
+				},
+			},
+		})
+		if err != nil {
+			switch err.(type) {
+			case webhookDeliveryRecordHandlerException.NotFound:
+			default:
+				log.Error("error claiming pending webhook delivery: " + err.Error())
+			}
+			return
+		}
+
+		j, err := p.resumedJob(updateFieldsResponse.Delivery)
+		if err != nil {
+			log.Error(err.Error())
+			continue
+		}
+		if j == nil {
+			continue
+		}
+		if !p.enqueue(j) {
+			return
+		}
+	}
+}
+
+// resumedJob returns the job for a delivery taken from the store. The
+// secret is taken from the subscription, and if the subscription no longer
+// exists or has been disabled the delivery is failed and no job is returned.
+func (p *publisher) resumedJob(resumedDelivery delivery.Delivery) (*job, error) {
+	subscriptionRetrieveResponse, err := p.webhookSubscriptionRecordHandler.Retrieve(&webhookSubscriptionRecordHandler.RetrieveRequest{
+		Claims:     p.systemClaims,
+		Identifier: resumedDelivery.SubscriptionId,
+	})
+	if err != nil {
+		switch err.(type) {
+		case webhookSubscriptionRecordHandlerException.NotFound:
+			p.fail(resumedDelivery, "subscription no longer exists")
+			return nil, nil
+		default:
+			return nil, err
+		}
+	}
+	if !subscriptionRetrieveResponse.Subscription.Enabled {
+		p.fail(resumedDelivery, "subscription disabled")
+		return nil, nil
+	}
+
+	return &job{
+		delivery: resumedDelivery,
+		secret:   subscriptionRetrieveResponse.Subscription.Secret,
+	}, nil
+}
+
+// fail marks the given delivery as failed without attempting it
+func (p *publisher) fail(failedDelivery delivery.Delivery, reason string) {
+	failedDelivery.Status = delivery.Failed
+	failedDelivery.Errors = append(failedDelivery.Errors, reason)
+	if _, err := p.webhookDeliveryRecordHandler.Update(&webhookDeliveryRecordHandler.UpdateRequest{
+		Claims:     p.systemClaims,
+		Identifier: id.Identifier{Id: failedDelivery.Id},
+		Delivery:   failedDelivery,
+	}); err != nil {
+		log.Error("error updating webhook delivery: " + err.Error())
+	}
+}
+
+// work posts deliveries until the job channel is closed
+func (p *publisher) work() {
+	for j := range p.jobs {
+		p.attempt(j)
+	}
+}
+
+// attempt makes an attempt to post the delivery of the given job
+// and records the outcome on the delivery
+func (p *publisher) attempt(j *job) {
+	j.delivery.Attempts++
+	j.delivery.LastAttemptTime = time.Now().UTC().Unix()
+
+	responseStatus, err := p.post(j)
+	j.delivery.ResponseStatus = responseStatus
+	if err == nil {
+		j.delivery.Status = delivery.Delivered
+		j.delivery.DeliveredTime = j.delivery.LastAttemptTime
+	} else {
+		j.delivery.Errors = append(j.delivery.Errors, err.Error())
+		if j.delivery.Attempts >= p.maxAttempts {
+			j.delivery.Status = delivery.Failed
+		} else {
+			// the retry is resumed from the store once it is due
+			j.delivery.NextAttemptTime = time.Now().UTC().Add(p.backoff(j.delivery.Attempts)).Unix()
+		}
+	}
+
+	if _, updateErr := p.webhookDeliveryRecordHandler.Update(&webhookDeliveryRecordHandler.UpdateRequest{
+		Claims:     p.systemClaims,
+		Identifier: id.Identifier{Id: j.delivery.Id},
+		Delivery:   j.delivery,
+	}); updateErr != nil {
+		log.Error("error updating webhook delivery: " + updateErr.Error())
+	}
+
+	if err == nil {
+		return
+	}
+
+	if j.delivery.Attempts < p.maxAttempts {
+		log.Warn(fmt.Sprintf(
+			"attempt %d of %d to post webhook delivery %s failed, retrying in %s: %s",
+			j.delivery.Attempts, p.maxAttempts, j.delivery.Id, p.backoff(j.delivery.Attempts), err.Error(),
+		))
+		return
+	}
+
+	log.Error(fmt.Sprintf(
+		"all %d attempts to post webhook delivery %s failed: %s",
+		j.delivery.Attempts, j.delivery.Id, err.Error(),
+	))
+}
+
+// backoff returns how long to wait before retrying a delivery
+// after the given number of attempts, doubling with each attempt
+func (p *publisher) backoff(attempts int) time.Duration {
+	return p.retryBackoff * time.Duration(1<<uint(attempts-1))
+}
+
+// post makes the request for the delivery of the given job, returning
+// the status of the response if one was received
+func (p *publisher) post(j *job) (int, error) {
+	httpRequest, err := http.NewRequest(http.MethodPost, j.delivery.URL, bytes.NewReader([]byte(j.delivery.Payload)))
+	if err != nil {
+		return 0, err
+	}
+	httpRequest.Header.Set("Content-Type", "application/json")
+	httpRequest.Header.Set(webhookPublisher.EventHeader, string(j.delivery.EventType))
+	httpRequest.Header.Set(webhookPublisher.DeliveryHeader, j.delivery.Id)
+	httpRequest.Header.Set(webhookPublisher.SignatureHeader, webhookPublisher.Sign(j.secret, []byte(j.delivery.Payload)))
+
+	httpResponse, err := p.httpClient.Do(httpRequest)
+	if err != nil {
+		return 0, err
+	}
+	defer httpResponse.Body.Close()
+
+	if httpResponse.StatusCode < 200 || httpResponse.StatusCode > 299 {
+		return httpResponse.StatusCode, fmt.Errorf("unsuccessful response status %d", httpResponse.StatusCode)
+	}
+	return httpResponse.StatusCode, nil
+}
@@ -0,0 +1,321 @@
+package pool
+
+import (
+	"encoding/json"
+	"github.com/iot-my-world/brain/pkg/device/sigbug"
+	"github.com/iot-my-world/brain/pkg/party"
+	"github.com/iot-my-world/brain/pkg/search/criterion"
+	exactTextCriterion "github.com/iot-my-world/brain/pkg/search/criterion/exact/text"
+	numberRangeCriterion "github.com/iot-my-world/brain/pkg/search/criterion/range/number"
+	"github.com/iot-my-world/brain/pkg/search/identifier/id"
+	humanUserLoginClaims "github.com/iot-my-world/brain/pkg/security/claims/login/user/human"
+	"github.com/iot-my-world/brain/pkg/webhook/delivery"
+	webhookDeliveryRecordHandler "github.com/iot-my-world/brain/pkg/webhook/delivery/recordHandler"
+	webhookDeliveryRecordHandlerException "github.com/iot-my-world/brain/pkg/webhook/delivery/recordHandler/exception"
+	webhookEvent "github.com/iot-my-world/brain/pkg/webhook/event"
+	webhookPublisher "github.com/iot-my-world/brain/pkg/webhook/publisher"
+	"github.com/iot-my-world/brain/pkg/webhook/subscription"
+	webhookSubscriptionRecordHandler "github.com/iot-my-world/brain/pkg/webhook/subscription/recordHandler"
+	webhookSubscriptionRecordHandlerException "github.com/iot-my-world/brain/pkg/webhook/subscription/recordHandler/exception"
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+)
+
+type fakeSubscriptionRecordHandler struct {
+	webhookSubscriptionRecordHandler.RecordHandler
+	subscriptions []subscription.Subscription
+}
+
+func (r *fakeSubscriptionRecordHandler) Collect(request *webhookSubscriptionRecordHandler.CollectRequest) (*webhookSubscriptionRecordHandler.CollectResponse, error) {
+	return &webhookSubscriptionRecordHandler.CollectResponse{
+		Records: r.subscriptions,
+		Total:   len(r.subscriptions),
+	}, nil
+}
+
+func (r *fakeSubscriptionRecordHandler) Retrieve(request *webhookSubscriptionRecordHandler.RetrieveRequest) (*webhookSubscriptionRecordHandler.RetrieveResponse, error) {
+	for _, s := range r.subscriptions {
+		if s.Id == request.Identifier.(id.Identifier).Id {
+			return &webhookSubscriptionRecordHandler.RetrieveResponse{Subscription: s}, nil
+		}
+	}
+	return nil, webhookSubscriptionRecordHandlerException.NotFound{}
+}
+
+// fakeDeliveryRecordHandler stores deliveries in memory and supports
+// the criteria with which the publisher updates them
+type fakeDeliveryRecordHandler struct {
+	webhookDeliveryRecordHandler.RecordHandler
+	mutex      sync.Mutex
+	deliveries []delivery.Delivery
+}
+
+func (r *fakeDeliveryRecordHandler) Create(request *webhookDeliveryRecordHandler.CreateRequest) (*webhookDeliveryRecordHandler.CreateResponse, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	request.Delivery.Id = strconv.Itoa(len(r.deliveries) + 1)
+	r.deliveries = append(r.deliveries, request.Delivery)
+	return &webhookDeliveryRecordHandler.CreateResponse{Delivery: request.Delivery}, nil
+}
+
+func (r *fakeDeliveryRecordHandler) Update(request *webhookDeliveryRecordHandler.UpdateRequest) (*webhookDeliveryRecordHandler.UpdateResponse, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	for i := range r.deliveries {
+		if r.deliveries[i].Id == request.Identifier.(id.Identifier).Id {
+			r.deliveries[i] = request.Delivery
+			return &webhookDeliveryRecordHandler.UpdateResponse{}, nil
+		}
+	}
+	return nil, webhookDeliveryRecordHandlerException.NotFound{}
+}
+
+// UpdateFields updates the matching delivery with the
+// earliest next attempt time
+func (r *fakeDeliveryRecordHandler) UpdateFields(request *webhookDeliveryRecordHandler.UpdateFieldsRequest) (*webhookDeliveryRecordHandler.UpdateFieldsResponse, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	match := -1
+	for i := range r.deliveries {
+		if matches(r.deliveries[i], request.Criteria) &&
+			(match == -1 || r.deliveries[i].NextAttemptTime < r.deliveries[match].NextAttemptTime) {
+			match = i
+		}
+	}
+	if match == -1 {
+		return nil, webhookDeliveryRecordHandlerException.NotFound{}
+	}
+	for field, value := range request.Update.Set {
+		switch field {
+		case "nextAttemptTime":
+			r.deliveries[match].NextAttemptTime = value.(int64)
+		default:
+			panic("unsupported field " + field)
+		}
+	}
+	return &webhookDeliveryRecordHandler.UpdateFieldsResponse{Delivery: r.deliveries[match]}, nil
+}
+
+func (r *fakeDeliveryRecordHandler) retrieve(deliveryId string) delivery.Delivery {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	for _, d := range r.deliveries {
+		if d.Id == deliveryId {
+			return d
+		}
+	}
+	return delivery.Delivery{}
+}
+
+func matches(d delivery.Delivery, criteria []criterion.Criterion) bool {
+	for _, c := range criteria {
+		switch typedCriterion := c.(type) {
+		case exactTextCriterion.Criterion:
+			switch typedCriterion.Field {
+			case "id":
+				if d.Id != typedCriterion.Text {
+					return false
+				}
+			case "status":
+				if string(d.Status) != typedCriterion.Text {
+					return false
+				}
+			default:
+				panic("unsupported field " + typedCriterion.Field)
+			}
+		case numberRangeCriterion.Criterion:
+			if typedCriterion.Field != "nextAttemptTime" || !typedCriterion.Start.Ignore {
+				panic("unsupported range on " + typedCriterion.Field)
+			}
+			if float64(d.NextAttemptTime) > typedCriterion.End.Number {
+				return false
+			}
+		default:
+			panic("unsupported criterion")
+		}
+	}
+	return true
+}
+
+var testClaims = &humanUserLoginClaims.Login{
+	UserId:    id.Identifier{Id: "root"},
+	PartyType: party.System,
+	PartyId:   id.Identifier{Id: "system"},
+}
+
+func newTestPublisher(subscriptions []subscription.Subscription, deliveries []delivery.Delivery, queueSize int) (*publisher, *fakeDeliveryRecordHandler) {
+	deliveryRecordHandler := &fakeDeliveryRecordHandler{deliveries: deliveries}
+	return &publisher{
+		webhookSubscriptionRecordHandler: &fakeSubscriptionRecordHandler{subscriptions: subscriptions},
+		webhookDeliveryRecordHandler:     deliveryRecordHandler,
+		// the test servers listen on loopback, which the publisher's own client refuses
+		httpClient:   &http.Client{Timeout: time.Second},
+		jobs:         make(chan *job, queueSize),
+		maxAttempts:  3,
+		retryBackoff: time.Minute,
+		systemClaims: testClaims,
+	}, deliveryRecordHandler
+}
+
+func TestPostSignsPayload(t *testing.T) {
+	var receivedHeader http.Header
+	var receivedBody string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		receivedHeader = r.Header
+		body, _ := ioutil.ReadAll(r.Body)
+		receivedBody = string(body)
+	}))
+	defer server.Close()
+
+	p, _ := newTestPublisher(nil, nil, 1)
+	status, err := p.post(&job{
+		delivery: delivery.Delivery{
+			Id:        "1",
+			EventType: webhookEvent.NewReading,
+			URL:       server.URL,
+			Payload:   `{"type":"NewReading"}`,
+		},
+		secret: "secret",
+	})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, `{"type":"NewReading"}`, receivedBody)
+	assert.Equal(t, webhookPublisher.Sign("secret", []byte(receivedBody)), receivedHeader.Get(webhookPublisher.SignatureHeader))
+	assert.Equal(t, string(webhookEvent.NewReading), receivedHeader.Get(webhookPublisher.EventHeader))
+	assert.Equal(t, "1", receivedHeader.Get(webhookPublisher.DeliveryHeader))
+}
+
+func TestBackoff(t *testing.T) {
+	p, _ := newTestPublisher(nil, nil, 1)
+	assert.Equal(t, time.Minute, p.backoff(1))
+	assert.Equal(t, 2*time.Minute, p.backoff(2))
+	assert.Equal(t, 4*time.Minute, p.backoff(3))
+}
+
+func TestAttemptRetries(t *testing.T) {
+	tests := []struct {
+		name           string
+		responseStatus []int
+		expectedStatus []delivery.Status
+	}{
+		{
+			name:           "delivered after retries",
+			responseStatus: []int{http.StatusInternalServerError, http.StatusBadGateway, http.StatusOK},
+			expectedStatus: []delivery.Status{delivery.Pending, delivery.Pending, delivery.Delivered},
+		},
+		{
+			name:           "failed after max attempts",
+			responseStatus: []int{http.StatusInternalServerError, http.StatusInternalServerError, http.StatusInternalServerError},
+			expectedStatus: []delivery.Status{delivery.Pending, delivery.Pending, delivery.Failed},
+		},
+	}
+
+	for _, test := range tests {
+		requests := 0
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(test.responseStatus[requests])
+			requests++
+		}))
+
+		p, deliveryRecordHandler := newTestPublisher(nil, []delivery.Delivery{
+			{Id: "1", URL: server.URL, Status: delivery.Pending, Errors: make([]string, 0)},
+		}, 1)
+
+		for i, expectedStatus := range test.expectedStatus {
+			p.attempt(&job{delivery: deliveryRecordHandler.retrieve("1")})
+			attemptedDelivery := deliveryRecordHandler.retrieve("1")
+
+			assert.Equal(t, i+1, attemptedDelivery.Attempts, test.name)
+			assert.Equal(t, expectedStatus, attemptedDelivery.Status, test.name)
+			assert.Equal(t, test.responseStatus[i], attemptedDelivery.ResponseStatus, test.name)
+			if expectedStatus == delivery.Pending {
+				// retried with exponential backoff
+				assert.InDelta(t,
+					time.Now().UTC().Add(p.backoff(i+1)).Unix(),
+					attemptedDelivery.NextAttemptTime,
+					1, test.name,
+				)
+			}
+		}
+
+		// nothing is retried in memory
+		assert.Len(t, p.jobs, 0, test.name)
+		server.Close()
+	}
+}
+
+func TestPublishDoesNotBlockWhenQueueFull(t *testing.T) {
+	p, deliveryRecordHandler := newTestPublisher([]subscription.Subscription{
+		{Id: "s1", Enabled: true, URL: "https://203.0.113.1/hook"},
+		{Id: "s2", Enabled: true, URL: "https://203.0.113.2/hook"},
+	}, nil, 1)
+
+	publishResponse, err := p.Publish(&webhookPublisher.PublishRequest{
+		Claims:    testClaims,
+		EventType: webhookEvent.NewReading,
+		Device: sigbug.Sigbug{
+			Id:      "device",
+			OwnerId: id.Identifier{Id: "company"},
+		},
+		Data: json.RawMessage(`{}`),
+	})
+	if !assert.NoError(t, err) {
+		return
+	}
+	if !assert.Len(t, publishResponse.Deliveries, 2) {
+		return
+	}
+
+	// the first delivery is queued and stays claimed
+	assert.Len(t, p.jobs, 1)
+	assert.True(t, deliveryRecordHandler.retrieve("1").NextAttemptTime > time.Now().UTC().Unix())
+
+	// the second is left pending and due, to be resumed
+	assert.True(t, deliveryRecordHandler.retrieve("2").NextAttemptTime <= time.Now().UTC().Unix())
+}
+
+func TestResumeDue(t *testing.T) {
+	now := time.Now().UTC().Unix()
+	p, deliveryRecordHandler := newTestPublisher(
+		[]subscription.Subscription{
+			{Id: "enabled", Enabled: true, Secret: "secret"},
+			{Id: "disabled", Enabled: false},
+		},
+		[]delivery.Delivery{
+			{Id: "due", SubscriptionId: id.Identifier{Id: "enabled"}, Status: delivery.Pending, NextAttemptTime: now - 60},
+			{Id: "notDue", SubscriptionId: id.Identifier{Id: "enabled"}, Status: delivery.Pending, NextAttemptTime: now + 60},
+			{Id: "delivered", SubscriptionId: id.Identifier{Id: "enabled"}, Status: delivery.Delivered, NextAttemptTime: now - 60},
+			{Id: "disabled", SubscriptionId: id.Identifier{Id: "disabled"}, Status: delivery.Pending, NextAttemptTime: now - 60},
+			{Id: "deleted", SubscriptionId: id.Identifier{Id: "deleted"}, Status: delivery.Pending, NextAttemptTime: now - 60},
+		},
+		10,
+	)
+
+	p.resumeDue()
+
+	if !assert.Len(t, p.jobs, 1) {
+		return
+	}
+	resumedJob := <-p.jobs
+	assert.Equal(t, "due", resumedJob.delivery.Id)
+	assert.Equal(t, "secret", resumedJob.secret)
+
+	// the resumed delivery is claimed so that it is not resumed again
+	assert.True(t, deliveryRecordHandler.retrieve("due").NextAttemptTime > now)
+	p.resumeDue()
+	assert.Len(t, p.jobs, 0)
+
+	// deliveries to subscriptions which are gone or disabled are failed
+	assert.Equal(t, delivery.Failed, deliveryRecordHandler.retrieve("disabled").Status)
+	assert.Equal(t, delivery.Failed, deliveryRecordHandler.retrieve("deleted").Status)
+	assert.Equal(t, delivery.Pending, deliveryRecordHandler.retrieve("notDue").Status)
+}
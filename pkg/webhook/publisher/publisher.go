@@ -0,0 +1,49 @@
+package publisher
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"github.com/iot-my-world/brain/pkg/device/sigbug"
+	"github.com/iot-my-world/brain/pkg/security/claims"
+	"github.com/iot-my-world/brain/pkg/webhook/delivery"
+	webhookEvent "github.com/iot-my-world/brain/pkg/webhook/event"
+)
+
+// SignatureHeader is the header of each webhook request which carries the
+// hex encoded HMAC-SHA256 of the request body, keyed with the subscription
+// secret, in the form "sha256=<signature>"
+const SignatureHeader = "X-Brain-Signature"
+
+// Sign returns the value of the SignatureHeader of a request
+// with the given body made with the given secret
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// EventHeader is the header of each webhook request which carries the event type
+const EventHeader = "X-Brain-Event"
+
+// DeliveryHeader is the header of each webhook request which carries the delivery id
+const DeliveryHeader = "X-Brain-Delivery"
+
+// Publisher posts events for a device to the webhook subscriptions of
+// the parties which own the device or to which it is assigned
+type Publisher interface {
+	Publish(request *PublishRequest) (*PublishResponse, error)
+}
+
+type PublishRequest struct {
+	Claims    claims.Claims
+	EventType webhookEvent.Type
+	Device    sigbug.Sigbug
+	TimeStamp int64
+	Data      interface{}
+}
+
+type PublishResponse struct {
+	// Deliveries which were queued, one for each subscription
+	Deliveries []delivery.Delivery
+}
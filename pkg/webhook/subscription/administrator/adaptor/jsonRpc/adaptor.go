@@ -0,0 +1,84 @@
+package jsonRpc
+
+import (
+	"github.com/iot-my-world/brain/internal/log"
+	jsonRpcServiceProvider "github.com/iot-my-world/brain/pkg/api/jsonRpc/service/provider"
+	wrappedClaims "github.com/iot-my-world/brain/pkg/security/claims/wrapped"
+	"github.com/iot-my-world/brain/pkg/webhook/subscription"
+	"github.com/iot-my-world/brain/pkg/webhook/subscription/administrator"
+	"net/http"
+)
+
+type adaptor struct {
+	administrator administrator.Administrator
+}
+
+func New(administrator administrator.Administrator) *adaptor {
+	return &adaptor{
+		administrator: administrator,
+	}
+}
+
+func (a *adaptor) Name() jsonRpcServiceProvider.Name {
+	return jsonRpcServiceProvider.Name(administrator.ServiceProvider)
+}
+
+func (a *adaptor) MethodRequiresAuthorization(string) bool {
+	return true
+}
+
+type CreateRequest struct {
+	Subscription subscription.Subscription `json:"subscription"`
+}
+
+type CreateResponse struct {
+	Subscription subscription.Subscription `json:"subscription"`
+}
+
+func (a *adaptor) Create(r *http.Request, request *CreateRequest, response *CreateResponse) error {
+	claims, err := wrappedClaims.UnwrapClaimsFromContext(r)
+	if err != nil {
+		log.Warn(err.Error())
+		return err
+	}
+
+	createResponse, err := a.administrator.Create(&administrator.CreateRequest{
+		Claims:       claims,
+		Subscription: request.Subscription,
+	})
+	if err != nil {
+		return err
+	}
+
+	response.Subscription = createResponse.Subscription
+
+	return nil
+}
+
+type UpdateAllowedFieldsRequest struct {
+	Subscription subscription.Subscription `json:"subscription"`
+}
+
+type UpdateAllowedFieldsResponse struct {
+	Subscription subscription.Subscription `json:"subscription"`
+}
+
+func (a *adaptor) UpdateAllowedFields(r *http.Request, request *UpdateAllowedFieldsRequest, response *UpdateAllowedFieldsResponse) error {
+	claims, err := wrappedClaims.UnwrapClaimsFromContext(r)
+	if err != nil {
+		log.Warn(err.Error())
+		return err
+	}
+
+	updateAllowedFieldsResponse, err := a.administrator.UpdateAllowedFields(&administrator.UpdateAllowedFieldsRequest{
+		Claims:       claims,
+		Subscription: request.Subscription,
+	})
+	if err != nil {
+		return err
+	}
+
+	response.Subscription = updateAllowedFieldsResponse.Subscription
+
+	return nil
+}
@@ -0,0 +1,160 @@
+package basic
+
+import (
+	"fmt"
+	brainException "github.com/iot-my-world/brain/internal/exception"
+	"github.com/iot-my-world/brain/internal/log"
+	"github.com/iot-my-world/brain/pkg/search/identifier/id"
+	apiUserPasswordGenerator "github.com/iot-my-world/brain/pkg/user/api/password/generator"
+	"github.com/iot-my-world/brain/pkg/webhook/subscription/action"
+	webhookSubscriptionAdministrator "github.com/iot-my-world/brain/pkg/webhook/subscription/administrator"
+	"github.com/iot-my-world/brain/pkg/webhook/subscription/administrator/exception"
+	"github.com/iot-my-world/brain/pkg/webhook/subscription/recordHandler"
+	"github.com/iot-my-world/brain/pkg/webhook/subscription/validator"
+)
+
+type administrator struct {
+	webhookSubscriptionValidator     validator.Validator
+	webhookSubscriptionRecordHandler recordHandler.RecordHandler
+	secretGenerator                  apiUserPasswordGenerator.Generator
+}
+
+func New(
+	webhookSubscriptionValidator validator.Validator,
+	webhookSubscriptionRecordHandler recordHandler.RecordHandler,
+	secretGenerator apiUserPasswordGenerator.Generator,
+) webhookSubscriptionAdministrator.Administrator {
+	return &administrator{
+		webhookSubscriptionValidator:     webhookSubscriptionValidator,
+		webhookSubscriptionRecordHandler: webhookSubscriptionRecordHandler,
+		secretGenerator:                  secretGenerator,
+	}
+}
+
+func (a *administrator) ValidateCreateRequest(request *webhookSubscriptionAdministrator.CreateRequest) error {
+	reasonsInvalid := make([]string, 0)
+
+	if request.Claims == nil {
+		reasonsInvalid = append(reasonsInvalid, "claims are nil")
+	} else {
+		webhookSubscriptionValidateResponse, err := a.webhookSubscriptionValidator.Validate(&validator.ValidateRequest{
+			Claims:       request.Claims,
+			Subscription: request.Subscription,
+			Action:       action.Create,
+		})
+		if err != nil {
+			reasonsInvalid = append(reasonsInvalid, "error validating webhook subscription: "+err.Error())
+		} else {
+			if len(webhookSubscriptionValidateResponse.ReasonsInvalid) > 0 {
+				for _, reason := range webhookSubscriptionValidateResponse.ReasonsInvalid {
+					reasonsInvalid = append(reasonsInvalid, fmt.Sprintf("webhook subscription invalid: %s - %s - %s", reason.Field, reason.Type, reason.Help))
+				}
+			}
+		}
+	}
+
+	if len(reasonsInvalid) > 0 {
+		return brainException.RequestInvalid{Reasons: reasonsInvalid}
+	}
+
+	return nil
+}
+
+func (a *administrator) Create(request *webhookSubscriptionAdministrator.CreateRequest) (*webhookSubscriptionAdministrator.CreateResponse, error) {
+	if err := a.ValidateCreateRequest(request); err != nil {
+		return nil, err
+	}
+
+	// generate a secret if one was not given
+	if request.Subscription.Secret == "" {
+		secretGenerateResponse, err := a.secretGenerator.Generate(&apiUserPasswordGenerator.GenerateRequest{
+			CryptoBytesLength: 32,
+		})
+		if err != nil {
+			err = exception.SecretGeneration{Reasons: []string{err.Error()}}
+			log.Error(err.Error())
+			return nil, err
+		}
+		request.Subscription.Secret = secretGenerateResponse.Password
+	}
+
+	createResponse, err := a.webhookSubscriptionRecordHandler.Create(&recordHandler.CreateRequest{
+		Subscription: request.Subscription,
+	})
+	if err != nil {
+		err = exception.SubscriptionCreation{Reasons: []string{err.Error()}}
+		log.Error(err.Error())
+		return nil, err
+	}
+
+	return &webhookSubscriptionAdministrator.CreateResponse{
+		Subscription: createResponse.Subscription,
+	}, nil
+}
+
+func (a *administrator) ValidateUpdateAllowedFieldsRequest(request *webhookSubscriptionAdministrator.UpdateAllowedFieldsRequest) error {
+	reasonsInvalid := make([]string, 0)
+
+	if request.Claims == nil {
+		reasonsInvalid = append(reasonsInvalid, "claims are nil")
+	} else {
+		// webhook subscription must be valid
+		validationResponse, err := a.webhookSubscriptionValidator.Validate(&validator.ValidateRequest{
+			Claims:       request.Claims,
+			Subscription: request.Subscription,
+			Action:       action.UpdateAllowedFields,
+		})
+		if err != nil {
+			reasonsInvalid = append(reasonsInvalid, "error validating webhook subscription: "+err.Error())
+		} else {
+			if len(validationResponse.ReasonsInvalid) > 0 {
+				for _, reason := range validationResponse.ReasonsInvalid {
+					reasonsInvalid = append(reasonsInvalid, fmt.Sprintf("webhook subscription invalid: %s - %s - %s", reason.Field, reason.Type, reason.Help))
+				}
+			}
+		}
+	}
+
+	if len(reasonsInvalid) > 0 {
+		return brainException.RequestInvalid{Reasons: reasonsInvalid}
+	}
+	return nil
+}
+
+func (a *administrator) UpdateAllowedFields(request *webhookSubscriptionAdministrator.UpdateAllowedFieldsRequest) (*webhookSubscriptionAdministrator.UpdateAllowedFieldsResponse, error) {
+	if err := a.ValidateUpdateAllowedFieldsRequest(request); err != nil {
+		return nil, err
+	}
+
+	// retrieve the webhook subscription
+	webhookSubscriptionRetrieveResponse, err := a.webhookSubscriptionRecordHandler.Retrieve(&recordHandler.RetrieveRequest{
+		Claims:     request.Claims,
+		Identifier: id.Identifier{Id: request.Subscription.Id},
+	})
+	if err != nil {
+		return nil, exception.SubscriptionRetrieval{Reasons: []string{err.Error()}}
+	}
+
+	// update the allowed fields on the webhook subscription
+	webhookSubscriptionRetrieveResponse.Subscription.Name = request.Subscription.Name
+	webhookSubscriptionRetrieveResponse.Subscription.Enabled = request.Subscription.Enabled
+	webhookSubscriptionRetrieveResponse.Subscription.URL = request.Subscription.URL
+	webhookSubscriptionRetrieveResponse.Subscription.EventTypes = request.Subscription.EventTypes
+	if request.Subscription.Secret != "" {
+		webhookSubscriptionRetrieveResponse.Subscription.Secret = request.Subscription.Secret
+	}
+
+	// update the webhook subscription
+	_, err = a.webhookSubscriptionRecordHandler.Update(&recordHandler.UpdateRequest{
+		Claims:       request.Claims,
+		Identifier:   id.Identifier{Id: request.Subscription.Id},
+		Subscription: webhookSubscriptionRetrieveResponse.Subscription,
+	})
+	if err != nil {
+		return nil, exception.SubscriptionUpdate{Reasons: []string{err.Error()}}
+	}
+
+	return &webhookSubscriptionAdministrator.UpdateAllowedFieldsResponse{
+		Subscription: webhookSubscriptionRetrieveResponse.Subscription,
+	}, nil
+}
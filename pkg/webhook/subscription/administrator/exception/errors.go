@@ -0,0 +1,37 @@
+package exception
+
+import (
+	"strings"
+)
+
+type SubscriptionCreation struct {
+	Reasons []string
+}
+
+func (e SubscriptionCreation) Error() string {
+	return "error creating webhook subscription: " + strings.Join(e.Reasons, "; ")
+}
+
+type SubscriptionRetrieval struct {
+	Reasons []string
+}
+
+func (e SubscriptionRetrieval) Error() string {
+	return "error retrieving webhook subscription: " + strings.Join(e.Reasons, "; ")
+}
+
+type SubscriptionUpdate struct {
+	Reasons []string
+}
+
+func (e SubscriptionUpdate) Error() string {
+	return "error updating webhook subscription: " + strings.Join(e.Reasons, "; ")
+}
+
+type SecretGeneration struct {
+	Reasons []string
+}
+
+func (e SecretGeneration) Error() string {
+	return "error generating webhook subscription secret: " + strings.Join(e.Reasons, "; ")
+}
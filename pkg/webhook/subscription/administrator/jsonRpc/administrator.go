@@ -0,0 +1,81 @@
+package jsonRpc
+
+import (
+	brainException "github.com/iot-my-world/brain/internal/exception"
+	"github.com/iot-my-world/brain/internal/log"
+	jsonRpcClient "github.com/iot-my-world/brain/pkg/api/jsonRpc/client"
+	webhookSubscriptionAdministrator "github.com/iot-my-world/brain/pkg/webhook/subscription/administrator"
+	webhookSubscriptionAdministratorJsonRpcAdaptor "github.com/iot-my-world/brain/pkg/webhook/subscription/administrator/adaptor/jsonRpc"
+)
+
+type administrator struct {
+	jsonRpcClient jsonRpcClient.Client
+}
+
+func New(
+	jsonRpcClient jsonRpcClient.Client,
+) webhookSubscriptionAdministrator.Administrator {
+	return &administrator{
+		jsonRpcClient: jsonRpcClient,
+	}
+}
+
+func (a *administrator) ValidateCreateRequest(request *webhookSubscriptionAdministrator.CreateRequest) error {
+	reasonsInvalid := make([]string, 0)
+
+	if len(reasonsInvalid) > 0 {
+		return brainException.RequestInvalid{Reasons: reasonsInvalid}
+	}
+	return nil
+}
+
+func (a *administrator) Create(request *webhookSubscriptionAdministrator.CreateRequest) (*webhookSubscriptionAdministrator.CreateResponse, error) {
+	if err := a.ValidateCreateRequest(request); err != nil {
+		return nil, err
+	}
+
+	webhookSubscriptionCreateResponse := webhookSubscriptionAdministratorJsonRpcAdaptor.CreateResponse{}
+	if err := a.jsonRpcClient.JsonRpcRequest(
+		webhookSubscriptionAdministrator.CreateService,
+		webhookSubscriptionAdministratorJsonRpcAdaptor.CreateRequest{
+			Subscription: request.Subscription,
+		},
+		&webhookSubscriptionCreateResponse,
+	); err != nil {
+		log.Error(err.Error())
+		return nil, err
+	}
+
+	return &webhookSubscriptionAdministrator.CreateResponse{Subscription: webhookSubscriptionCreateResponse.Subscription}, nil
+}
+
+func (a *administrator) ValidateUpdateAllowedFieldsRequest(request *webhookSubscriptionAdministrator.UpdateAllowedFieldsRequest) error {
+	reasonsInvalid := make([]string, 0)
+
+	if len(reasonsInvalid) > 0 {
+		return brainException.RequestInvalid{Reasons: reasonsInvalid}
+	}
+	return nil
+}
+
+func (a *administrator) UpdateAllowedFields(request *webhookSubscriptionAdministrator.UpdateAllowedFieldsRequest) (*webhookSubscriptionAdministrator.UpdateAllowedFieldsResponse, error) {
+	if err := a.ValidateUpdateAllowedFieldsRequest(request); err != nil {
+		return nil, err
+	}
+
+	webhookSubscriptionUpdateAllowedFieldsResponse := webhookSubscriptionAdministratorJsonRpcAdaptor.UpdateAllowedFieldsResponse{}
+	if err := a.jsonRpcClient.JsonRpcRequest(
+		webhookSubscriptionAdministrator.UpdateAllowedFieldsService,
+		webhookSubscriptionAdministratorJsonRpcAdaptor.UpdateAllowedFieldsRequest{
+			Subscription: request.Subscription,
+		},
+		&webhookSubscriptionUpdateAllowedFieldsResponse,
+	); err != nil {
+		log.Error(err.Error())
+		return nil, err
+	}
+
+	return &webhookSubscriptionAdministrator.UpdateAllowedFieldsResponse{
+		Subscription: webhookSubscriptionUpdateAllowedFieldsResponse.Subscription,
+	}, nil
+}
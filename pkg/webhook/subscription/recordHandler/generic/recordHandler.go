@@ -0,0 +1,133 @@
+package webhookSubscriptionRecordHandler
+
+import (
+	brainException "github.com/iot-my-world/brain/internal/exception"
+	brainRecordHandler "github.com/iot-my-world/brain/pkg/recordHandler"
+	brainRecordHandlerException "github.com/iot-my-world/brain/pkg/recordHandler/exception"
+	"github.com/iot-my-world/brain/pkg/webhook/subscription"
+	webhookSubscriptionRecordHandler "github.com/iot-my-world/brain/pkg/webhook/subscription/recordHandler"
+	webhookSubscriptionRecordHandlerException "github.com/iot-my-world/brain/pkg/webhook/subscription/recordHandler/exception"
+)
+
+type RecordHandler struct {
+	webhookSubscriptionRecordHandler brainRecordHandler.RecordHandler
+}
+
+func New(
+	brainSubscriptionRecordHandler brainRecordHandler.RecordHandler,
+) webhookSubscriptionRecordHandler.RecordHandler {
+
+	return &RecordHandler{
+		webhookSubscriptionRecordHandler: brainSubscriptionRecordHandler,
+	}
+}
+
+type CreateRequest struct {
+	Subscription subscription.Subscription
+}
+
+type CreateResponse struct {
+	Subscription subscription.Subscription
+}
+
+func (r *RecordHandler) ValidateCreateRequest(request *webhookSubscriptionRecordHandler.CreateRequest) error {
+	reasonsInvalid := make([]string, 0)
+
+	if len(reasonsInvalid) > 0 {
+		return brainException.RequestInvalid{Reasons: reasonsInvalid}
+	}
+
+	return nil
+}
+
+func (r *RecordHandler) Create(request *webhookSubscriptionRecordHandler.CreateRequest) (*webhookSubscriptionRecordHandler.CreateResponse, error) {
+	if err := r.ValidateCreateRequest(request); err != nil {
+		return nil, err
+	}
+
+	createResponse := brainRecordHandler.CreateResponse{}
+	if err := r.webhookSubscriptionRecordHandler.Create(&brainRecordHandler.CreateRequest{
+		Entity: &request.Subscription,
+	}, &createResponse); err != nil {
+		return nil, webhookSubscriptionRecordHandlerException.Create{Reasons: []string{err.Error()}}
+	}
+	createdSubscription, ok := createResponse.Entity.(*subscription.Subscription)
+	if !ok {
+		return nil, webhookSubscriptionRecordHandlerException.Create{Reasons: []string{"could not cast created entity to webhook subscription"}}
+	}
+
+	return &webhookSubscriptionRecordHandler.CreateResponse{
+		Subscription: *createdSubscription,
+	}, nil
+}
+
+func (r *RecordHandler) Retrieve(request *webhookSubscriptionRecordHandler.RetrieveRequest) (*webhookSubscriptionRecordHandler.RetrieveResponse, error) {
+	retrievedSubscription := subscription.Subscription{}
+	retrieveResponse := brainRecordHandler.RetrieveResponse{
+		Entity: &retrievedSubscription,
+	}
+	if err := r.webhookSubscriptionRecordHandler.Retrieve(&brainRecordHandler.RetrieveRequest{
+		Claims:     request.Claims,
+		Identifier: request.Identifier,
+	}, &retrieveResponse); err != nil {
+		switch err.(type) {
+		case brainRecordHandlerException.NotFound:
+			return nil, webhookSubscriptionRecordHandlerException.NotFound{}
+		default:
+			return nil, err
+		}
+	}
+
+	return &webhookSubscriptionRecordHandler.RetrieveResponse{
+		Subscription: retrievedSubscription,
+	}, nil
+}
+
+func (r *RecordHandler) Update(request *webhookSubscriptionRecordHandler.UpdateRequest) (*webhookSubscriptionRecordHandler.UpdateResponse, error) {
+	updateResponse := brainRecordHandler.UpdateResponse{}
+	if err := r.webhookSubscriptionRecordHandler.Update(&brainRecordHandler.UpdateRequest{
+		Claims:     request.Claims,
+		Identifier: request.Identifier,
+		Entity:     &request.Subscription,
+	}, &updateResponse); err != nil {
+		return nil, webhookSubscriptionRecordHandlerException.Update{Reasons: []string{err.Error()}}
+	}
+
+	return &webhookSubscriptionRecordHandler.UpdateResponse{}, nil
+}
+
+func (r *RecordHandler) Delete(request *webhookSubscriptionRecordHandler.DeleteRequest) (*webhookSubscriptionRecordHandler.DeleteResponse, error) {
+	deleteResponse := brainRecordHandler.DeleteResponse{}
+	if err := r.webhookSubscriptionRecordHandler.Delete(&brainRecordHandler.DeleteRequest{
+		Claims:     request.Claims,
+		Identifier: request.Identifier,
+	}, &deleteResponse); err != nil {
+		return nil, webhookSubscriptionRecordHandlerException.Delete{Reasons: []string{err.Error()}}
+	}
+
+	return &webhookSubscriptionRecordHandler.DeleteResponse{}, nil
+}
+
+func (r *RecordHandler) Collect(request *webhookSubscriptionRecordHandler.CollectRequest) (*webhookSubscriptionRecordHandler.CollectResponse, error) {
+	var collectedSubscription []subscription.Subscription
+	collectResponse := brainRecordHandler.CollectResponse{
+		Records: &collectedSubscription,
+	}
+	err := r.webhookSubscriptionRecordHandler.Collect(&brainRecordHandler.CollectRequest{
+		Claims:   request.Claims,
+		Criteria: request.Criteria,
+		Query:    request.Query,
+	}, &collectResponse)
+	if err != nil {
+		return nil, webhookSubscriptionRecordHandlerException.Collect{Reasons: []string{err.Error()}}
+	}
+
+	if collectedSubscription == nil {
+		collectedSubscription = make([]subscription.Subscription, 0)
+	}
+
+	return &webhookSubscriptionRecordHandler.CollectResponse{
+		Records: collectedSubscription,
+		Total:   collectResponse.Total,
+	}, nil
+}
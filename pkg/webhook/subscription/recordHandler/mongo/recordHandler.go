@@ -0,0 +1,37 @@
+package mongo
+
+import (
+	brainMongoRecordHandler "github.com/iot-my-world/brain/pkg/recordHandler/mongo"
+	"github.com/iot-my-world/brain/pkg/security/claims"
+	"github.com/iot-my-world/brain/pkg/webhook/subscription"
+	webhookSubscriptionRecordHandler "github.com/iot-my-world/brain/pkg/webhook/subscription/recordHandler"
+	webhookSubscriptionGenericRecordHandler "github.com/iot-my-world/brain/pkg/webhook/subscription/recordHandler/generic"
+	"gopkg.in/mgo.v2"
+)
+
+func New(
+	mongoSession *mgo.Session,
+	databaseName string,
+	collectionName string,
+) webhookSubscriptionRecordHandler.RecordHandler {
+	mongoRecordHandler := brainMongoRecordHandler.New(
+		mongoSession,
+		databaseName,
+		collectionName,
+		[]mgo.Index{
+			{
+				Key:    []string{"id"},
+				Unique: true,
+			},
+			{
+				Key: []string{"ownerId.id", "eventTypes"},
+			},
+		},
+		subscription.IsValidIdentifier,
+		claims.ContextualiseFilter,
+	)
+
+	return webhookSubscriptionGenericRecordHandler.New(
+		mongoRecordHandler,
+	)
+}
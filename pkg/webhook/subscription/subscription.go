@@ -0,0 +1,29 @@
+package subscription
+
+import (
+	"github.com/iot-my-world/brain/pkg/party"
+	"github.com/iot-my-world/brain/pkg/search/identifier/id"
+	webhookEvent "github.com/iot-my-world/brain/pkg/webhook/event"
+)
+
+// Subscription is the registration of a url to which the events of
+// the given types for the devices of the owner party are posted
+type Subscription struct {
+	Id string `json:"id" bson:"id"`
+
+	OwnerPartyType party.Type    `json:"ownerPartyType" bson:"ownerPartyType"`
+	OwnerId        id.Identifier `json:"ownerId" bson:"ownerId"`
+	Name           string        `json:"name" bson:"name"`
+	Enabled        bool          `json:"enabled" bson:"enabled"`
+
+	URL        string              `json:"url" bson:"url"`
+	EventTypes []webhookEvent.Type `json:"eventTypes" bson:"eventTypes"`
+
+	// Secret is the key used to sign the body of each request made
+	// to the url. It is generated on creation if not given.
+	Secret string `json:"secret" bson:"secret"`
+}
+
+func (s *Subscription) SetId(id string) {
+	s.Id = id
+}
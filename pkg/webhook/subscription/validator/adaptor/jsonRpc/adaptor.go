@@ -0,0 +1,60 @@
+package jsonRpc
+
+import (
+	"github.com/iot-my-world/brain/internal/log"
+	"github.com/iot-my-world/brain/pkg/action"
+	jsonRpcServiceProvider "github.com/iot-my-world/brain/pkg/api/jsonRpc/service/provider"
+	wrappedClaims "github.com/iot-my-world/brain/pkg/security/claims/wrapped"
+	"github.com/iot-my-world/brain/pkg/validate/reasonInvalid"
+	"github.com/iot-my-world/brain/pkg/webhook/subscription"
+	"github.com/iot-my-world/brain/pkg/webhook/subscription/validator"
+	"net/http"
+)
+
+type adaptor struct {
+	webhookSubscriptionValidator validator.Validator
+}
+
+func New(webhookSubscriptionValidator validator.Validator) *adaptor {
+	return &adaptor{
+		webhookSubscriptionValidator: webhookSubscriptionValidator,
+	}
+}
+
+func (a *adaptor) Name() jsonRpcServiceProvider.Name {
+	return jsonRpcServiceProvider.Name(validator.ServiceProvider)
+}
+
+func (a *adaptor) MethodRequiresAuthorization(string) bool {
+	return true
+}
+
+type ValidateRequest struct {
+	Subscription subscription.Subscription `json:"subscription"`
+	Action       action.Action             `json:"action"`
+}
+
+type ValidateResponse struct {
+	ReasonsInvalid []reasonInvalid.ReasonInvalid `json:"reasonsInvalid"`
+}
+
+func (a *adaptor) Validate(r *http.Request, request *ValidateRequest, response *ValidateResponse) error {
+	claims, err := wrappedClaims.UnwrapClaimsFromContext(r)
+	if err != nil {
+		log.Warn(err.Error())
+		return err
+	}
+
+	validateSubscriptionResponse, err := a.webhookSubscriptionValidator.Validate(&validator.ValidateRequest{
+		Claims:       claims,
+		Subscription: request.Subscription,
+		Action:       request.Action,
+	})
+	if err != nil {
+		return err
+	}
+
+	response.ReasonsInvalid = validateSubscriptionResponse.ReasonsInvalid
+
+	return nil
+}
@@ -0,0 +1,212 @@
+package validator
+
+import (
+	brainException "github.com/iot-my-world/brain/internal/exception"
+	"github.com/iot-my-world/brain/internal/log"
+	"github.com/iot-my-world/brain/internal/publicAddress"
+	"github.com/iot-my-world/brain/pkg/action"
+	"github.com/iot-my-world/brain/pkg/party"
+	partyAdministrator "github.com/iot-my-world/brain/pkg/party/administrator"
+	partyAdministratorException "github.com/iot-my-world/brain/pkg/party/administrator/exception"
+	"github.com/iot-my-world/brain/pkg/validate/reasonInvalid"
+	webhookEvent "github.com/iot-my-world/brain/pkg/webhook/event"
+	webhookSubscriptionAction "github.com/iot-my-world/brain/pkg/webhook/subscription/action"
+	webhookSubscriptionValidator "github.com/iot-my-world/brain/pkg/webhook/subscription/validator"
+	webhookSubscriptionValidatorException "github.com/iot-my-world/brain/pkg/webhook/subscription/validator/exception"
+	"net/url"
+)
+
+type validator struct {
+	partyAdministrator   partyAdministrator.Administrator
+	actionIgnoredReasons map[action.Action]reasonInvalid.IgnoredReasonsInvalid
+}
+
+func New(
+	partyAdministrator partyAdministrator.Administrator,
+) webhookSubscriptionValidator.Validator {
+
+	actionIgnoredReasons := map[action.Action]reasonInvalid.IgnoredReasonsInvalid{
+		webhookSubscriptionAction.Create: {
+			ReasonsInvalid: map[string][]reasonInvalid.Type{
+				"id": {
+					reasonInvalid.Blank,
+				},
+				// a secret is generated if one is not given
+				"secret": {
+					reasonInvalid.Blank,
+				},
+			},
+		},
+		webhookSubscriptionAction.UpdateAllowedFields: {
+			ReasonsInvalid: map[string][]reasonInvalid.Type{
+				// the secret is only changed if a new one is given
+				"secret": {
+					reasonInvalid.Blank,
+				},
+			},
+		},
+	}
+
+	return &validator{
+		partyAdministrator:   partyAdministrator,
+		actionIgnoredReasons: actionIgnoredReasons,
+	}
+}
+
+func (v *validator) ValidateValidateRequest(request *webhookSubscriptionValidator.ValidateRequest) error {
+	reasonsInvalid := make([]string, 0)
+
+	if request.Claims == nil {
+		reasonsInvalid = append(reasonsInvalid, "claims are nil")
+	}
+
+	if len(reasonsInvalid) > 0 {
+		return brainException.RequestInvalid{Reasons: reasonsInvalid}
+	}
+	return nil
+}
+
+func (v *validator) Validate(request *webhookSubscriptionValidator.ValidateRequest) (*webhookSubscriptionValidator.ValidateResponse, error) {
+	if err := v.ValidateValidateRequest(request); err != nil {
+		return nil, err
+	}
+
+	allReasonsInvalid := make([]reasonInvalid.ReasonInvalid, 0)
+	webhookSubscriptionToValidate := &request.Subscription
+
+	if (*webhookSubscriptionToValidate).Id == "" {
+		allReasonsInvalid = append(allReasonsInvalid, reasonInvalid.ReasonInvalid{
+			Field: "id",
+			Type:  reasonInvalid.Blank,
+			Help:  "cannot be blank",
+			Data:  (*webhookSubscriptionToValidate).Id,
+		})
+	}
+
+	if (*webhookSubscriptionToValidate).OwnerPartyType == "" {
+		allReasonsInvalid = append(allReasonsInvalid, reasonInvalid.ReasonInvalid{
+			Field: "ownerPartyType",
+			Type:  reasonInvalid.Blank,
+			Help:  "cannot be blank",
+			Data:  (*webhookSubscriptionToValidate).OwnerPartyType,
+		})
+	}
+
+	if (*webhookSubscriptionToValidate).OwnerId.Id == "" {
+		allReasonsInvalid = append(allReasonsInvalid, reasonInvalid.ReasonInvalid{
+			Field: "ownerId",
+			Type:  reasonInvalid.Blank,
+			Help:  "cannot be blank",
+			Data:  (*webhookSubscriptionToValidate).OwnerId,
+		})
+	}
+
+	// if neither owner party type nor owner id are blank
+	if (*webhookSubscriptionToValidate).OwnerPartyType != "" && (*webhookSubscriptionToValidate).OwnerId.Id != "" {
+		// owner party type must be valid. i.e. must be of a valid type and the party must exist
+		switch (*webhookSubscriptionToValidate).OwnerPartyType {
+		case party.Client, party.Company:
+			_, err := v.partyAdministrator.RetrieveParty(&partyAdministrator.RetrievePartyRequest{
+				Claims:     request.Claims,
+				PartyType:  (*webhookSubscriptionToValidate).OwnerPartyType,
+				Identifier: (*webhookSubscriptionToValidate).OwnerId,
+			})
+			if err != nil {
+				switch err.(type) {
+				case partyAdministratorException.NotFound:
+					allReasonsInvalid = append(allReasonsInvalid, reasonInvalid.ReasonInvalid{
+						Field: "ownerId",
+						Type:  reasonInvalid.MustExist,
+						Help:  "owner party must exist",
+						Data:  (*webhookSubscriptionToValidate).OwnerId,
+					})
+				default:
+					err = webhookSubscriptionValidatorException.Validate{Reasons: []string{"retrieving owner party", err.Error()}}
+					log.Error(err.Error())
+					return nil, err
+				}
+			}
+
+		default:
+			allReasonsInvalid = append(allReasonsInvalid, reasonInvalid.ReasonInvalid{
+				Field: "ownerPartyType",
+				Type:  reasonInvalid.Invalid,
+				Help:  "must be a company or client",
+				Data:  (*webhookSubscriptionToValidate).OwnerPartyType,
+			})
+		}
+	}
+
+	if (*webhookSubscriptionToValidate).Name == "" {
+		allReasonsInvalid = append(allReasonsInvalid, reasonInvalid.ReasonInvalid{
+			Field: "name",
+			Type:  reasonInvalid.Blank,
+			Help:  "cannot be blank",
+			Data:  (*webhookSubscriptionToValidate).Name,
+		})
+	}
+
+	if subscriptionURL, err := url.ParseRequestURI((*webhookSubscriptionToValidate).URL); err != nil ||
+		(subscriptionURL.Scheme != "http" && subscriptionURL.Scheme != "https") {
+		allReasonsInvalid = append(allReasonsInvalid, reasonInvalid.ReasonInvalid{
+			Field: "url",
+			Type:  reasonInvalid.Invalid,
+			Help:  "must be a valid http or https url",
+			Data:  (*webhookSubscriptionToValidate).URL,
+		})
+	} else if err := publicAddress.ValidateURL((*webhookSubscriptionToValidate).URL); err != nil {
+		// urls may not lead to the services of the platform itself
+		allReasonsInvalid = append(allReasonsInvalid, reasonInvalid.ReasonInvalid{
+			Field: "url",
+			Type:  reasonInvalid.Invalid,
+			Help:  "must resolve to a public address",
+			Data:  (*webhookSubscriptionToValidate).URL,
+		})
+	}
+
+	if len((*webhookSubscriptionToValidate).EventTypes) == 0 {
+		allReasonsInvalid = append(allReasonsInvalid, reasonInvalid.ReasonInvalid{
+			Field: "eventTypes",
+			Type:  reasonInvalid.Blank,
+			Help:  "cannot be empty",
+			Data:  (*webhookSubscriptionToValidate).EventTypes,
+		})
+	}
+	for _, eventType := range (*webhookSubscriptionToValidate).EventTypes {
+		switch eventType {
+		case webhookEvent.NewReading, webhookEvent.DeviceAssigned, webhookEvent.DeviceOffline:
+		default:
+			allReasonsInvalid = append(allReasonsInvalid, reasonInvalid.ReasonInvalid{
+				Field: "eventTypes",
+				Type:  reasonInvalid.Invalid,
+				Help:  "must all be valid types",
+				Data:  eventType,
+			})
+		}
+	}
+
+	if (*webhookSubscriptionToValidate).Secret == "" {
+		allReasonsInvalid = append(allReasonsInvalid, reasonInvalid.ReasonInvalid{
+			Field: "secret",
+			Type:  reasonInvalid.Blank,
+			Help:  "cannot be blank",
+			Data:  (*webhookSubscriptionToValidate).Secret,
+		})
+	}
+
+	// Make list of reasons invalid to return
+	returnedReasonsInvalid := make([]reasonInvalid.ReasonInvalid, 0)
+
+	// Add all reasons that cannot be ignored for the given action
+	if v.actionIgnoredReasons[request.Action].ReasonsInvalid != nil {
+		for _, reason := range allReasonsInvalid {
+			if !v.actionIgnoredReasons[request.Action].CanIgnore(reason) {
+				returnedReasonsInvalid = append(returnedReasonsInvalid, reason)
+			}
+		}
+	}
+
+	return &webhookSubscriptionValidator.ValidateResponse{
+		ReasonsInvalid: returnedReasonsInvalid,
+	}, nil
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"github.com/360EntSecGroup-Skylar/excelize"
 	"github.com/iot-my-world/brain/pkg/workbook/exception"
+	"io"
 )
 
 type Workbook struct {
@@ -16,16 +17,39 @@ func New(
 	pathToWorkBook string,
 	sheetHeaderRowMap map[string]int,
 ) (*Workbook, error) {
-	if sheetHeaderRowMap == nil {
-		sheetHeaderRowMap = make(map[string]int)
+	// open the workbook
+	file, err := excelize.OpenFile(pathToWorkBook)
+	if err != nil {
+		return nil, exception.OpeningFile{Reasons: []string{err.Error()}}
 	}
 
+	return newFromFile(file, sheetHeaderRowMap)
+}
+
+// NewFromReader opens a workbook from the given reader, e.g. for an uploaded file
+func NewFromReader(
+	reader io.Reader,
+	sheetHeaderRowMap map[string]int,
+) (*Workbook, error) {
 	// open the workbook
-	file, err := excelize.OpenFile(pathToWorkBook)
+	file, err := excelize.OpenReader(reader)
 	if err != nil {
 		return nil, exception.OpeningFile{Reasons: []string{err.Error()}}
 	}
 
+	return newFromFile(file, sheetHeaderRowMap)
+}
+
+func newFromFile(
+	file *excelize.File,
+	sheetHeaderRowMap map[string]int,
+) (*Workbook, error) {
+	if sheetHeaderRowMap == nil {
+		sheetHeaderRowMap = make(map[string]int)
+	}
+
+	var err error
+
 	// build header map for each sheet
 	sheetHeaderMaps := make(map[string]map[string]string)
 	for _, sheetName := range file.GetSheetMap() {
@@ -42,8 +66,38 @@ func New(
 	}, nil
 }
 
-func (w *Workbook) DataRows(sheetName string) {
+// DataRows returns the rows of the given sheet below its header row.
+// Trailing empty cells are not included in a row.
+func (w *Workbook) DataRows(sheetName string) ([][]string, error) {
+	if _, found := w.SheetHeaderMaps[sheetName]; !found {
+		return nil, exception.SheetDoesNotExist{SheetName: sheetName}
+	}
+
+	rowsWithHeader, err := w.File.GetRows(sheetName)
+	if err != nil {
+		return nil, err
+	}
+
+	sheetHeaderRowIdx := w.SheetHeaderRowMap[sheetName]
+	if len(rowsWithHeader) <= sheetHeaderRowIdx+1 {
+		return make([][]string, 0), nil
+	}
+
+	return rowsWithHeader[sheetHeaderRowIdx+1:], nil
+}
+
+// FirstSheetName returns the name of the first sheet in the workbook
+func (w *Workbook) FirstSheetName() string {
+	firstSheetIdx := -1
+	firstSheetName := ""
+	for sheetIdx, sheetName := range w.File.GetSheetMap() {
+		if firstSheetIdx == -1 || sheetIdx < firstSheetIdx {
+			firstSheetIdx = sheetIdx
+			firstSheetName = sheetName
+		}
+	}
 
+	return firstSheetName
 }
 
 func (w *Workbook) SheetAsSliceMap(sheetName string) ([]map[string]string, error) {
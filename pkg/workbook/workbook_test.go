@@ -0,0 +1,83 @@
+package workbook
+
+import (
+	"github.com/360EntSecGroup-Skylar/excelize"
+	"github.com/iot-my-world/brain/pkg/workbook/exception"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+// newTestWorkbook returns a workbook of a single sheet with the given cells
+func newTestWorkbook(t *testing.T, cells map[string]string, sheetHeaderRowMap map[string]int) *Workbook {
+	file := excelize.NewFile()
+	for cell, value := range cells {
+		file.SetCellValue("Sheet1", cell, value)
+	}
+	buffer, err := file.WriteToBuffer()
+	if err != nil {
+		t.Fatal(err)
+	}
+	testWorkbook, err := NewFromReader(buffer, sheetHeaderRowMap)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return testWorkbook
+}
+
+func TestDataRows(t *testing.T) {
+	tests := []struct {
+		name              string
+		cells             map[string]string
+		sheetHeaderRowMap map[string]int
+		expectedHeaders   map[string]string
+		expectedRows      [][]string
+	}{
+		{
+			name: "header on first row",
+			cells: map[string]string{
+				"A1": "deviceId", "B1": "ownerId",
+				"A2": "1", "B2": "company",
+				"A3": "2", "B3": "client",
+			},
+			expectedHeaders: map[string]string{"deviceId": "A", "ownerId": "B"},
+			expectedRows:    [][]string{{"1", "company"}, {"2", "client"}},
+		},
+		{
+			name: "header below title",
+			cells: map[string]string{
+				"A1": "Devices",
+				"A2": "deviceId", "B2": "ownerId",
+				"A3": "1", "B3": "company",
+			},
+			sheetHeaderRowMap: map[string]int{"Sheet1": 1},
+			expectedHeaders:   map[string]string{"deviceId": "A", "ownerId": "B"},
+			expectedRows:      [][]string{{"1", "company"}},
+		},
+		{
+			name: "header only",
+			cells: map[string]string{
+				"A1": "deviceId",
+			},
+			expectedHeaders: map[string]string{"deviceId": "A"},
+			expectedRows:    [][]string{},
+		},
+	}
+
+	for _, test := range tests {
+		testWorkbook := newTestWorkbook(t, test.cells, test.sheetHeaderRowMap)
+		assert.Equal(t, "Sheet1", testWorkbook.FirstSheetName(), test.name)
+		assert.Equal(t, test.expectedHeaders, testWorkbook.SheetHeaderMaps["Sheet1"], test.name)
+
+		dataRows, err := testWorkbook.DataRows("Sheet1")
+		if assert.NoError(t, err, test.name) {
+			assert.Equal(t, test.expectedRows, dataRows, test.name)
+		}
+	}
+}
+
+func TestDataRowsSheetDoesNotExist(t *testing.T) {
+	testWorkbook := newTestWorkbook(t, map[string]string{"A1": "deviceId"}, nil)
+
+	_, err := testWorkbook.DataRows("Sheet2")
+	assert.Equal(t, exception.SheetDoesNotExist{SheetName: "Sheet2"}, err)
+}